@@ -741,7 +741,9 @@ func NewServiceInstanceFromProto(
 		SetServiceID(sid).
 		SetInstanceID(instance.Id).
 		SetEndpoint(instance.Endpoint).
-		SetShards(shards), nil
+		SetShards(shards).
+		SetIsolationGroup(instance.IsolationGroup).
+		SetZone(instance.Zone), nil
 }
 
 // NewServiceInstanceFromPlacementInstance creates a new service instance from placement instance.
@@ -753,14 +755,18 @@ func NewServiceInstanceFromPlacementInstance(
 		SetServiceID(sid).
 		SetInstanceID(instance.ID()).
 		SetEndpoint(instance.Endpoint()).
-		SetShards(instance.Shards())
+		SetShards(instance.Shards()).
+		SetIsolationGroup(instance.IsolationGroup()).
+		SetZone(instance.Zone())
 }
 
 type serviceInstance struct {
-	service  ServiceID
-	id       string
-	endpoint string
-	shards   shard.Shards
+	service        ServiceID
+	id             string
+	endpoint       string
+	shards         shard.Shards
+	isolationGroup string
+	zone           string
 }
 
 func (i *serviceInstance) InstanceID() string                       { return i.id }
@@ -770,6 +776,14 @@ func (i *serviceInstance) ServiceID() ServiceID                     { return i.s
 func (i *serviceInstance) SetInstanceID(id string) ServiceInstance  { i.id = id; return i }
 func (i *serviceInstance) SetEndpoint(e string) ServiceInstance     { i.endpoint = e; return i }
 func (i *serviceInstance) SetShards(s shard.Shards) ServiceInstance { i.shards = s; return i }
+func (i *serviceInstance) IsolationGroup() string                   { return i.isolationGroup }
+func (i *serviceInstance) Zone() string                             { return i.zone }
+func (i *serviceInstance) SetZone(zone string) ServiceInstance      { i.zone = zone; return i }
+
+func (i *serviceInstance) SetIsolationGroup(group string) ServiceInstance {
+	i.isolationGroup = group
+	return i
+}
 
 func (i *serviceInstance) SetServiceID(service ServiceID) ServiceInstance {
 	i.service = service
@@ -226,6 +226,18 @@ type ServiceInstance interface {
 
 	// SetShards sets the shards of the instance.
 	SetShards(s shard.Shards) ServiceInstance
+
+	// IsolationGroup returns the isolation group of the instance.
+	IsolationGroup() string
+
+	// SetIsolationGroup sets the isolation group of the instance.
+	SetIsolationGroup(group string) ServiceInstance
+
+	// Zone returns the zone of the instance.
+	Zone() string
+
+	// SetZone sets the zone of the instance.
+	SetZone(zone string) ServiceInstance
 }
 
 // Advertisement advertises the availability of a given instance of a service.
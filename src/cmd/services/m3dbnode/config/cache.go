@@ -20,7 +20,10 @@
 
 package config
 
-import "github.com/m3db/m3/src/dbnode/storage/series"
+import (
+	"github.com/m3db/m3/src/dbnode/storage/block"
+	"github.com/m3db/m3/src/dbnode/storage/series"
+)
 
 // CacheConfigurations is the cache configurations.
 type CacheConfigurations struct {
@@ -49,4 +52,34 @@ type SeriesCacheConfiguration struct {
 type LRUSeriesCachePolicyConfiguration struct {
 	MaxBlocks         uint `yaml:"maxBlocks" validate:"nonzero"`
 	EventsChannelSize uint `yaml:"eventsChannelSize" validate:"nonzero"`
+
+	// MaxBytes is the max bytes of block data retrieved from disk to keep
+	// cached, zero means no byte budget is enforced.
+	MaxBytes uint64 `yaml:"maxBytes"`
+
+	// Admission is the policy used to decide whether blocks retrieved from
+	// disk are admitted into the cache once it is full.
+	Admission block.WiredListAdmissionPolicy `yaml:"admission"`
+
+	// Namespaces are byte budgets for namespaces that should have a cache
+	// of their own rather than share the database cache.
+	Namespaces []LRUNamespaceCacheConfiguration `yaml:"namespaces"`
+}
+
+// NamespaceMaxBytes returns the max bytes to keep cached by namespace.
+func (c LRUSeriesCachePolicyConfiguration) NamespaceMaxBytes() map[string]int64 {
+	if len(c.Namespaces) == 0 {
+		return nil
+	}
+	result := make(map[string]int64, len(c.Namespaces))
+	for _, ns := range c.Namespaces {
+		result[ns.Namespace] = int64(ns.MaxBytes)
+	}
+	return result
+}
+
+// LRUNamespaceCacheConfiguration is the LRU cache byte budget of a namespace.
+type LRUNamespaceCacheConfiguration struct {
+	Namespace string `yaml:"namespace" validate:"nonzero"`
+	MaxBytes  uint64 `yaml:"maxBytes" validate:"nonzero"`
 }
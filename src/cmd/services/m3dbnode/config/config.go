@@ -32,6 +32,7 @@ import (
 	coordinatorcfg "github.com/m3db/m3/src/cmd/services/m3query/config"
 	"github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/dbnode/environment"
+	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
 	"github.com/m3db/m3x/config/hostid"
 	"github.com/m3db/m3x/instrument"
 	xlog "github.com/m3db/m3x/log"
@@ -72,6 +73,10 @@ type DBConfiguration struct {
 	// The host and port on which to listen for the node service.
 	ListenAddress string `yaml:"listenAddress" validate:"nonzero"`
 
+	// GRPCEnabled enables serving the node service over gRPC alongside
+	// TChannel on the same listen address.
+	GRPCEnabled bool `yaml:"grpcEnabled"`
+
 	// The host and port on which to listen for the cluster service.
 	ClusterListenAddress string `yaml:"clusterListenAddress" validate:"nonzero"`
 
@@ -140,6 +145,15 @@ type IndexConfiguration struct {
 	// important to prevent index queries from overloading the database entirely
 	// as they are very CPU-intensive (regex and FST matching.)
 	MaxQueryIDsConcurrency int `yaml:"maxQueryIDsConcurrency" validate:"min=0"`
+
+	// CompactionInterval is the interval at which the open index blocks
+	// compact their mutable segments into FST segments in the background,
+	// background compaction is disabled if unset or zero.
+	CompactionInterval *time.Duration `yaml:"compactionInterval"`
+
+	// MaxCompactionConcurrency controls the maximum number of background
+	// index compactions that can run concurrently across all index blocks.
+	MaxCompactionConcurrency int `yaml:"maxCompactionConcurrency" validate:"min=0"`
 }
 
 // TickConfiguration is the tick configuration for background processing of
@@ -195,6 +209,15 @@ type CommitLogPolicy struct {
 
 	// The commit log block size.
 	BlockSize time.Duration `yaml:"blockSize" validate:"nonzero"`
+
+	// The compression applied to each chunk of new commit logs, one of
+	// none or snappy.
+	Compression commitlog.CompressionType `yaml:"compression"`
+
+	// The path to a file containing a hex encoded AES key, if set new commit
+	// logs are encrypted with AES-GCM and the key is used to read encrypted
+	// commit logs.
+	EncryptionKeyFile string `yaml:"encryptionKeyFile"`
 }
 
 // CalculationType is a type of configuration parameter.
@@ -310,6 +310,8 @@ db:
 	expected := `db:
   index:
     maxQueryIDsConcurrency: 0
+    compactionInterval: null
+    maxCompactionConcurrency: 0
   logging:
     file: /var/log/m3dbnode.log
     level: info
@@ -328,6 +330,7 @@ db:
     extended: 3
     sanitization: 2
   listenAddress: 0.0.0.0:9000
+  grpcEnabled: false
   clusterListenAddress: 0.0.0.0:9001
   httpNodeListenAddress: 0.0.0.0:9002
   httpClusterListenAddress: 0.0.0.0:9003
@@ -362,8 +365,15 @@ db:
       maxRetries: 3
       forever: null
       jitter: true
+    hedgedReads: null
+    readIsolationGroup: null
+    readYourWrites: null
+    writeSpool: null
+    rejectUnboundedTagQueries: null
+    hostCircuitBreaker: null
     backgroundHealthCheckFailLimit: 4
     backgroundHealthCheckFailThrottleFactor: 0.5
+    transport: 0
     hashing:
       seed: 42
   gcPercentage: 100
@@ -395,6 +405,8 @@ db:
     mmap: null
     force_index_summaries_mmap_memory: true
     force_bloom_filter_mmap_memory: true
+    objectStore: null
+    seekCheckpointInterval: 0s
   commitlog:
     flushMaxBytes: 524288
     flushEvery: 1s
@@ -403,6 +415,8 @@ db:
       size: 2097152
     queueChannel: null
     blockSize: 10m0s
+    compression: none
+    encryptionKeyFile: ""
   repair:
     enabled: false
     interval: 2h0m0s
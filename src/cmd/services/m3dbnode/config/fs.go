@@ -23,6 +23,10 @@ package config
 import (
 	"fmt"
 	"os"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/persist/fs/objectstore"
 )
 
 const (
@@ -83,6 +87,15 @@ type FilesystemConfiguration struct {
 	// ForceBloomFilterMmapMemory forces the mmap that stores the index lookup bytes
 	// to be an anonymous region in memory as opposed to a file-based mmap.
 	ForceBloomFilterMmapMemory bool `yaml:"force_bloom_filter_mmap_memory"`
+
+	// ObjectStore is the object store that filesets of namespaces with cold
+	// storage enabled are offloaded to.
+	ObjectStore *ObjectStoreConfiguration `yaml:"objectStore"`
+
+	// SeekCheckpointInterval is the interval at which decoder checkpoints are
+	// written alongside flushed series so range reads can skip decoding the
+	// start of a block, zero disables checkpoints.
+	SeekCheckpointInterval time.Duration `yaml:"seekCheckpointInterval"`
 }
 
 // MmapConfiguration is the mmap configuration.
@@ -101,6 +114,43 @@ type MmapHugeTLBConfiguration struct {
 	Threshold int64 `yaml:"threshold"`
 }
 
+// ObjectStoreConfiguration is the object store configuration.
+type ObjectStoreConfiguration struct {
+	// LocalDirectory is the directory of an object store that is accessible
+	// as a local filesystem.
+	LocalDirectory string `yaml:"localDirectory" validate:"nonzero"`
+
+	// CachePathPrefix is the path prefix that offloaded filesets are fetched
+	// into when read, defaults to a directory within the file path prefix.
+	CachePathPrefix string `yaml:"cachePathPrefix"`
+
+	// CacheMaxBytes is the maximum size of the offloaded filesets fetched into
+	// the cache, the least recently read filesets are evicted beyond it.
+	CacheMaxBytes *int64 `yaml:"cacheMaxBytes"`
+
+	// FetchTimeout is how long a read waits for an offloaded fileset to be
+	// fetched into the cache, the fetch continues in the background after.
+	FetchTimeout *time.Duration `yaml:"fetchTimeout"`
+}
+
+// NewObjectStore returns the object store specified by the configuration.
+func (c ObjectStoreConfiguration) NewObjectStore() objectstore.Store {
+	return objectstore.NewLocalStore(c.LocalDirectory)
+}
+
+// NewObjectStoreCache returns the object store cache specified by the configuration.
+func (c ObjectStoreConfiguration) NewObjectStoreCache() fs.ObjectStoreCache {
+	maxBytes := int64(fs.DefaultObjectStoreCacheMaxBytes)
+	if c.CacheMaxBytes != nil {
+		maxBytes = *c.CacheMaxBytes
+	}
+	fetchTimeout := fs.DefaultObjectStoreFetchTimeout
+	if c.FetchTimeout != nil {
+		fetchTimeout = *c.FetchTimeout
+	}
+	return fs.NewObjectStoreCache(maxBytes, fetchTimeout)
+}
+
 // ParseNewFileMode parses the specified new file mode.
 func (p FilesystemConfiguration) ParseNewFileMode() (os.FileMode, error) {
 	if p.NewFileMode == nil {
@@ -96,6 +96,20 @@ type Configuration struct {
 
 	// Limits specifies limits on per-query resource usage.
 	Limits LimitsConfiguration `yaml:"limits"`
+
+	// ExactIDFetch enables fetching series directly by ID, bypassing the
+	// index, for queries whose equality matchers fully specify a series.
+	// It only applies to reads made with the M3-Exact-Tags header, which
+	// guarantees the matchers are the complete set of tags of the series.
+	// Queries fallback to the index when no such series is found.
+	ExactIDFetch bool `yaml:"exactIDFetch"`
+
+	// FetchTaggedPageSize enables fetching the series of queries from each
+	// host a page of this many series at a time, bounding the memory used by
+	// the hosts and the size of each response for queries matching many
+	// series. The coordinator still holds every page of a query in memory
+	// until the query completes. Zero disables paginated fetches.
+	FetchTaggedPageSize int `yaml:"fetchTaggedPageSize"`
 }
 
 // Filter is a query filter type.
@@ -122,6 +136,11 @@ type FilterConfiguration struct {
 // LimitsConfiguration represents limitations on per-query resource usage. Zero or negative values imply no limit.
 type LimitsConfiguration struct {
 	MaxComputedDatapoints int64 `yaml:"maxComputedDatapoints"`
+
+	// MaxQueryPostings refuses queries whose postings, estimated by asking
+	// each dbnode for the postings list sizes of the terms of the query,
+	// exceed this many. Zero disables estimating the cost of queries.
+	MaxQueryPostings int64 `yaml:"maxQueryPostings"`
 }
 
 // IngestConfiguration is the configuration for ingestion server.
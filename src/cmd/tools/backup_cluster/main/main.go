@@ -0,0 +1,101 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"flag"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	nchannel "github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/node/channel"
+	xlog "github.com/m3db/m3x/log"
+
+	tchannel "github.com/uber/tchannel-go"
+	"github.com/uber/tchannel-go/thrift"
+)
+
+func main() {
+	var (
+		hostsArg    = flag.String("hosts", "127.0.0.1:9003", "Node TChannel server addresses to backup, comma separated")
+		destPathArg = flag.String("dest-path", "/var/lib/m3db-backup", "Destination path on each node, each node writes to a sub-directory named after its address")
+		timeoutArg  = flag.Duration("timeout", time.Hour, "Timeout for the backup of a single node")
+	)
+	flag.Parse()
+
+	if *hostsArg == "" ||
+		*destPathArg == "" ||
+		*timeoutArg <= 0 {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	log := xlog.NewLogger(os.Stderr)
+
+	channel, err := tchannel.NewChannel("Client", nil)
+	if err != nil {
+		log.Fatalf("could not create new tchannel channel: %v", err)
+	}
+
+	var (
+		wg      sync.WaitGroup
+		errLock sync.Mutex
+		failed  []string
+	)
+	// Backup all the nodes concurrently so that the snapshots taken by each
+	// node are as close as possible to the same point in time.
+	for _, host := range strings.Split(*hostsArg, ",") {
+		host := strings.TrimSpace(host)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			endpoint := &thrift.ClientOptions{HostPort: host}
+			thriftClient := thrift.NewClient(channel, nchannel.ChannelName, endpoint)
+			client := rpc.NewTChanNodeClient(thriftClient)
+
+			tctx, _ := thrift.NewContext(*timeoutArg)
+			req := rpc.NewBackupRequest()
+			req.DestinationPath = path.Join(*destPathArg, strings.Replace(host, ":", "_", -1))
+
+			log.Infof("backing up node %s to %s", host, req.DestinationPath)
+			result, err := client.Backup(tctx, req)
+			if err != nil {
+				log.Errorf("could not backup node %s: %v", host, err)
+				errLock.Lock()
+				failed = append(failed, host)
+				errLock.Unlock()
+				return
+			}
+			log.Infof("backed up node %s: snapshot index %d, snapshot uuid %s, %d files",
+				host, result.SnapshotIndex, result.SnapshotUUID, result.NumFiles)
+		}()
+	}
+	wg.Wait()
+
+	if len(failed) > 0 {
+		log.Fatalf("could not backup nodes: %v", failed)
+	}
+	log.Infof("successfully backed up all nodes")
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"flag"
+	"os"
+	"strings"
+
+	"github.com/m3db/m3/src/cluster/placement"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/persist/fs/reshard"
+	"github.com/m3db/m3/src/query/generated/proto/admin"
+	"github.com/m3db/m3x/ident"
+	xlog "github.com/m3db/m3x/log"
+
+	"github.com/gogo/protobuf/jsonpb"
+)
+
+var (
+	optNamespace        = flag.String("namespace", "metrics", "Namespace to reshard")
+	optSrcPathPrefixes  = flag.String("src-path-prefixes", "/var/lib/m3db", "Source path prefixes, comma separated, each shard is read from the first path prefix containing it")
+	optDestPathPrefix   = flag.String("dest-path-prefix", "/tmp/m3db-reshard", "Destination path prefix, the filesets of each instance are written to a sub-directory named after the instance ID")
+	optNumShards        = flag.Int("num-shards", 0, "New number of shards")
+	optPlacementFile    = flag.String("placement-file", "", "Current placement, as returned by the placement get API")
+	optNewPlacementFile = flag.String("new-placement-file", "", "File to write the new placement to")
+	optWriterBufferSize = flag.Int("writer-buffer-size", fs.NewOptions().WriterBufferSize(), "Buffer size of each fileset writer, a writer is open for every shard of every instance while resharding a block")
+	optReaderBufferSize = flag.Int("reader-buffer-size", fs.NewOptions().DataReaderBufferSize(), "Buffer size of each fileset reader")
+)
+
+func main() {
+	flag.Parse()
+	if *optNamespace == "" ||
+		*optSrcPathPrefixes == "" ||
+		*optDestPathPrefix == "" ||
+		*optNumShards <= 0 ||
+		*optPlacementFile == "" ||
+		*optNewPlacementFile == "" {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	log := xlog.NewLogger(os.Stderr)
+
+	f, err := os.Open(*optPlacementFile)
+	if err != nil {
+		log.Fatalf("unable to open placement file: %v", err)
+	}
+	var resp admin.PlacementGetResponse
+	err = jsonpb.Unmarshal(f, &resp)
+	f.Close()
+	if err != nil {
+		log.Fatalf("unable to read placement: %v", err)
+	}
+	current, err := placement.NewPlacementFromProto(resp.Placement)
+	if err != nil {
+		log.Fatalf("unable to read placement: %v", err)
+	}
+
+	newPlacement, err := reshard.NewPlacement(current, *optNumShards)
+	if err != nil {
+		log.Fatalf("unable to create placement: %v", err)
+	}
+
+	fsOpts := fs.NewOptions().
+		SetWriterBufferSize(*optWriterBufferSize).
+		SetDataReaderBufferSize(*optReaderBufferSize).
+		SetInfoReaderBufferSize(*optReaderBufferSize)
+	opts := reshard.NewOptions().SetFilesystemOptions(fsOpts)
+	args := reshard.Args{
+		Namespace:             ident.StringID(*optNamespace),
+		SourcePathPrefixes:    strings.Split(*optSrcPathPrefixes, ","),
+		DestinationPathPrefix: *optDestPathPrefix,
+		Placement:             newPlacement,
+	}
+
+	log.Infof("resharding namespace %s from %d shards to %d shards",
+		*optNamespace, current.NumShards(), newPlacement.NumShards())
+	result, err := reshard.NewResharder(opts).Reshard(args)
+	if err != nil {
+		log.Fatalf("unable to reshard: %v", err)
+	}
+	log.Infof("resharded %d series into %d data filesets and %d index filesets",
+		result.NumSeries, result.NumDataFileSets, result.NumIndexFileSets)
+
+	placementProto, err := newPlacement.Proto()
+	if err != nil {
+		log.Fatalf("unable to encode placement: %v", err)
+	}
+	out, err := os.Create(*optNewPlacementFile)
+	if err != nil {
+		log.Fatalf("unable to create new placement file: %v", err)
+	}
+	marshaler := jsonpb.Marshaler{EmitDefaults: true, Indent: "  "}
+	if err := marshaler.Marshal(out, placementProto); err != nil {
+		log.Fatalf("unable to write new placement: %v", err)
+	}
+	if err := out.Close(); err != nil {
+		log.Fatalf("unable to write new placement: %v", err)
+	}
+
+	log.Infof("successfully resharded data, new placement written to %s", *optNewPlacementFile)
+}
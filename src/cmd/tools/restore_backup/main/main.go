@@ -0,0 +1,85 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/persist/fs/backup"
+	"github.com/m3db/m3x/ident"
+	xlog "github.com/m3db/m3x/log"
+)
+
+func main() {
+	var (
+		sourcePathsArg = flag.String("source-paths", "", "Backup paths to restore from, comma separated, shards are restored from the first backup containing them")
+		pathPrefixArg  = flag.String("path-prefix", "/var/lib/m3db", "Path prefix of the node to restore to")
+		namespacesArg  = flag.String("namespaces", "", "Namespaces to restore, comma separated, all namespaces are restored if empty")
+		shardsArg      = flag.String("shards", "", "Shards to restore, comma separated, all shards are restored if empty")
+	)
+	flag.Parse()
+
+	if *sourcePathsArg == "" ||
+		*pathPrefixArg == "" {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	args := backup.RestoreArgs{
+		SourcePaths: strings.Split(*sourcePathsArg, ","),
+	}
+	if *namespacesArg != "" {
+		for _, str := range strings.Split(*namespacesArg, ",") {
+			args.Namespaces = append(args.Namespaces, ident.StringID(str))
+		}
+	}
+	if *shardsArg != "" {
+		for _, str := range strings.Split(*shardsArg, ",") {
+			value, err := strconv.Atoi(str)
+			if err != nil {
+				log.Fatalf("could not parse shard '%s': %v", str, err)
+			}
+			if value < 0 {
+				log.Fatalf("could not parse shard '%s': not uint", str)
+			}
+			args.Shards = append(args.Shards, uint32(value))
+		}
+	}
+
+	log := xlog.NewLogger(os.Stderr)
+
+	opts := backup.NewOptions().
+		SetFilesystemOptions(fs.NewOptions().SetFilePathPrefix(*pathPrefixArg))
+	result, err := backup.NewRestorer(opts).Restore(args)
+	if err != nil {
+		log.Fatalf("unable to restore: %v", err)
+	}
+
+	for ns, shards := range result.Shards {
+		log.Infof("restored namespace %s shards %v", ns, shards)
+	}
+	log.Infof("successfully restored %d files", result.NumFiles)
+}
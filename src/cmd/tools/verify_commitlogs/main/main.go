@@ -69,6 +69,7 @@ var (
 	readConcurrency        = flagParser.Int("read-concurrency", 4, "Commitlog read concurrency")
 	encodingConcurrency    = flagParser.Int("encoding-concurrency", 4, "Encoding concurrency")
 	mergeShardsConcurrency = flagParser.Int("merge-shards-concurrency", 4, "Merge shards concurrency")
+	encryptionKeyFileArg   = flagParser.String("encryption-key-file", "", "Encryption key file - required to read encrypted commitlogs, must contain a hex encoded AES key")
 )
 
 func main() {
@@ -227,6 +228,13 @@ func main() {
 		SetBlockSize(blockSize).
 		SetReadConcurrency(*readConcurrency).
 		SetBytesPool(bytesPool)
+	if *encryptionKeyFileArg != "" {
+		key, err := commitlog.ReadEncryptionKeyFile(*encryptionKeyFileArg)
+		if err != nil {
+			log.Fatalf("could not read encryption key file: %v", err)
+		}
+		commitLogOpts = commitLogOpts.SetEncryptionKey(key)
+	}
 
 	opts := commitlogsrc.NewOptions().
 		SetResultOptions(resultOpts).
@@ -0,0 +1,93 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"sync"
+
+	"github.com/m3db/m3x/ident"
+
+	"github.com/uber-go/tally"
+)
+
+// asyncWriteOverheadBytes accounts for the fixed size fields of a write, such
+// as the timestamp and value, in the bytes in flight of an async write.
+const asyncWriteOverheadBytes = 32
+
+// asyncWrites limits the bytes of async writes in flight across the host
+// queues, blocking new async writes until enough in flight writes complete.
+type asyncWrites struct {
+	sync.Mutex
+	cond sync.Cond
+
+	maxInFlightBytes int64
+	inFlightBytes    int64
+
+	inFlightBytesGauge tally.Gauge
+	blocked            tally.Counter
+}
+
+func newAsyncWrites(maxInFlightBytes int64, scope tally.Scope) *asyncWrites {
+	w := &asyncWrites{
+		maxInFlightBytes:   maxInFlightBytes,
+		inFlightBytesGauge: scope.Gauge("in-flight-bytes"),
+		blocked:            scope.Counter("blocked"),
+	}
+	w.cond.L = w
+	return w
+}
+
+// acquire blocks until the bytes can be put in flight, a single write larger
+// than the limit is let through once nothing else is in flight.
+func (w *asyncWrites) acquire(bytes int64) {
+	w.Lock()
+	if w.inFlightBytes > 0 && w.inFlightBytes+bytes > w.maxInFlightBytes {
+		w.blocked.Inc(1)
+		for w.inFlightBytes > 0 && w.inFlightBytes+bytes > w.maxInFlightBytes {
+			w.cond.Wait()
+		}
+	}
+	w.inFlightBytes += bytes
+	w.inFlightBytesGauge.Update(float64(w.inFlightBytes))
+	w.Unlock()
+}
+
+func (w *asyncWrites) release(bytes int64) {
+	w.Lock()
+	w.inFlightBytes -= bytes
+	w.inFlightBytesGauge.Update(float64(w.inFlightBytes))
+	w.Unlock()
+	w.cond.Broadcast()
+}
+
+// asyncWriteBytes returns the bytes an async write accounts for while in flight.
+func asyncWriteBytes(id ident.ID, tags ident.TagIterator, annotation []byte) int64 {
+	bytes := asyncWriteOverheadBytes + len(id.Bytes()) + len(annotation)
+	if tags != nil {
+		dup := tags.Duplicate()
+		for dup.Next() {
+			tag := dup.Current()
+			bytes += len(tag.Name.Bytes()) + len(tag.Value.Bytes())
+		}
+		dup.Close()
+	}
+	return int64(bytes)
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3x/ident"
+
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+)
+
+func TestAsyncWritesAcquireBlocksUntilReleased(t *testing.T) {
+	w := newAsyncWrites(100, tally.NoopScope)
+	w.acquire(60)
+
+	var (
+		wg       sync.WaitGroup
+		acquired = make(chan struct{})
+	)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		w.acquire(60)
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+		require.FailNow(t, "acquired bytes over the limit")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	w.release(60)
+	wg.Wait()
+	require.Equal(t, int64(60), w.inFlightBytes)
+}
+
+func TestAsyncWritesAcquireLargerThanLimit(t *testing.T) {
+	w := newAsyncWrites(100, tally.NoopScope)
+
+	// A single write larger than the limit doesn't block forever.
+	w.acquire(200)
+	require.Equal(t, int64(200), w.inFlightBytes)
+	w.release(200)
+	require.Equal(t, int64(0), w.inFlightBytes)
+}
+
+func TestAsyncWriteBytes(t *testing.T) {
+	tags := ident.NewTagsIterator(ident.NewTags(ident.StringTag("foo", "bar")))
+	bytes := asyncWriteBytes(ident.StringID("id"), tags, []byte("a"))
+	require.Equal(t, int64(asyncWriteOverheadBytes+len("id")+len("foobar")+1), bytes)
+
+	// The tags are not consumed.
+	require.True(t, tags.Next())
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"sort"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+)
+
+type cardinalityOp struct {
+	request      rpc.CardinalityRequest
+	completionFn completionFn
+}
+
+func (c *cardinalityOp) Size() int {
+	// Cardinality is always a single op
+	return 1
+}
+
+func (c *cardinalityOp) CompletionFn() completionFn {
+	return c.completionFn
+}
+
+type cardinalityBlockAccumulator struct {
+	blockStart  time.Time
+	numSeries   int64
+	metricNames map[string]int64
+	tagNames    map[string]int64
+	tagValues   map[string]int64
+	exhaustive  bool
+}
+
+// cardinalityAccumulator merges the cardinality results returned by each host.
+// Every series is held by each of its replicas so series counts are summed and
+// then divided by the replication factor, distinct tag value counts cannot be
+// summed since hosts share values so the max across hosts is used instead.
+type cardinalityAccumulator struct {
+	limit    int
+	replicas int
+	blocks   map[time.Time]*cardinalityBlockAccumulator
+}
+
+func newCardinalityAccumulator(limit, replicas int) *cardinalityAccumulator {
+	if replicas < 1 {
+		replicas = 1
+	}
+	return &cardinalityAccumulator{
+		limit:    limit,
+		replicas: replicas,
+		blocks:   make(map[time.Time]*cardinalityBlockAccumulator),
+	}
+}
+
+func (a *cardinalityAccumulator) add(results []index.CardinalityResult) {
+	for _, r := range results {
+		key := r.BlockStart.UTC()
+		b, ok := a.blocks[key]
+		if !ok {
+			b = &cardinalityBlockAccumulator{
+				blockStart:  r.BlockStart,
+				metricNames: make(map[string]int64),
+				tagNames:    make(map[string]int64),
+				tagValues:   make(map[string]int64),
+				exhaustive:  true,
+			}
+			a.blocks[key] = b
+		}
+
+		b.numSeries += r.NumSeries
+		b.exhaustive = b.exhaustive && r.Exhaustive
+		for _, e := range r.MetricNames {
+			b.metricNames[string(e.Name)] += e.Count
+		}
+		for _, e := range r.TagNames {
+			b.tagNames[string(e.Name)] += e.Count
+		}
+		for _, e := range r.TagValues {
+			if e.Count > b.tagValues[string(e.Name)] {
+				b.tagValues[string(e.Name)] = e.Count
+			}
+		}
+	}
+}
+
+func (a *cardinalityAccumulator) results() []index.CardinalityResult {
+	results := make([]index.CardinalityResult, 0, len(a.blocks))
+	for _, b := range a.blocks {
+		results = append(results, index.CardinalityResult{
+			BlockStart:  b.blockStart,
+			NumSeries:   b.numSeries / int64(a.replicas),
+			MetricNames: a.top(b.metricNames, true),
+			TagNames:    a.top(b.tagNames, true),
+			TagValues:   a.top(b.tagValues, false),
+			Exhaustive:  b.exhaustive,
+		})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].BlockStart.After(results[j].BlockStart)
+	})
+	return results
+}
+
+func (a *cardinalityAccumulator) top(counts map[string]int64, perReplica bool) []index.CardinalityEntry {
+	if perReplica {
+		for name, count := range counts {
+			counts[name] = count / int64(a.replicas)
+		}
+	}
+	return index.TopCardinalityEntries(counts, a.limit)
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/storage/index"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCardinalityAccumulatorMergesHosts(t *testing.T) {
+	var (
+		t0 = time.Unix(0, 0).Add(2 * time.Hour)
+		t1 = t0.Add(time.Hour)
+	)
+
+	acc := newCardinalityAccumulator(2, 2)
+	acc.add([]index.CardinalityResult{
+		{
+			BlockStart: t0,
+			NumSeries:  10,
+			MetricNames: []index.CardinalityEntry{
+				{Name: []byte("cpu"), Count: 6},
+				{Name: []byte("mem"), Count: 4},
+			},
+			TagNames: []index.CardinalityEntry{
+				{Name: []byte("host"), Count: 10},
+			},
+			TagValues: []index.CardinalityEntry{
+				{Name: []byte("host"), Count: 5},
+			},
+			Exhaustive: true,
+		},
+	})
+	acc.add([]index.CardinalityResult{
+		{
+			BlockStart: t0,
+			NumSeries:  10,
+			MetricNames: []index.CardinalityEntry{
+				{Name: []byte("cpu"), Count: 6},
+				{Name: []byte("disk"), Count: 4},
+			},
+			TagNames: []index.CardinalityEntry{
+				{Name: []byte("host"), Count: 10},
+			},
+			TagValues: []index.CardinalityEntry{
+				{Name: []byte("host"), Count: 7},
+			},
+			Exhaustive: false,
+		},
+		{
+			BlockStart: t1,
+			NumSeries:  4,
+			Exhaustive: true,
+		},
+	})
+
+	results := acc.results()
+	require.Equal(t, 2, len(results))
+
+	require.Equal(t, t1, results[0].BlockStart)
+	require.Equal(t, int64(2), results[0].NumSeries)
+	require.True(t, results[0].Exhaustive)
+
+	require.Equal(t, t0, results[1].BlockStart)
+	require.Equal(t, int64(10), results[1].NumSeries)
+	require.False(t, results[1].Exhaustive)
+	require.Equal(t, []index.CardinalityEntry{
+		{Name: []byte("cpu"), Count: 6},
+		{Name: []byte("disk"), Count: 2},
+	}, results[1].MetricNames)
+	require.Equal(t, []index.CardinalityEntry{
+		{Name: []byte("host"), Count: 10},
+	}, results[1].TagNames)
+	require.Equal(t, []index.CardinalityEntry{
+		{Name: []byte("host"), Count: 7},
+	}, results[1].TagValues)
+}
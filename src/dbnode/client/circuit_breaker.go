@@ -0,0 +1,192 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+
+	"github.com/uber-go/tally"
+)
+
+type circuitBreakerState int
+
+const (
+	circuitBreakerClosed circuitBreakerState = iota
+	circuitBreakerOpen
+	circuitBreakerHalfOpen
+)
+
+type hostCircuitBreakerMetrics struct {
+	state    tally.Gauge
+	opened   tally.Counter
+	closed   tally.Counter
+	rejected tally.Counter
+	probes   tally.Counter
+}
+
+func newHostCircuitBreakerMetrics(scope tally.Scope) hostCircuitBreakerMetrics {
+	return hostCircuitBreakerMetrics{
+		state:    scope.Gauge("state"),
+		opened:   scope.Counter("opened"),
+		closed:   scope.Counter("closed"),
+		rejected: scope.Counter("rejected"),
+		probes:   scope.Counter("probes"),
+	}
+}
+
+// hostCircuitBreaker fast-fails requests to a host after consecutive requests
+// to it fail or are slower than the slow request threshold, so that the
+// consistency logic can complete with the other replicas rather than waiting
+// for requests to an unhealthy host to time out. Once open for the open
+// duration the breaker half-opens and lets a single probe request through,
+// closing again if it succeeds and reopening if it fails.
+type hostCircuitBreaker struct {
+	sync.Mutex
+
+	enabled              bool
+	failureThreshold     int
+	slowRequestThreshold time.Duration
+	openDuration         time.Duration
+	nowFn                clock.NowFn
+	metrics              hostCircuitBreakerMetrics
+
+	state               circuitBreakerState
+	consecutiveFailures int
+	openedAt            time.Time
+	probeInFlight       bool
+}
+
+func newHostCircuitBreaker(opts Options, scope tally.Scope) *hostCircuitBreaker {
+	b := &hostCircuitBreaker{
+		enabled:              opts.HostCircuitBreakerEnabled(),
+		failureThreshold:     opts.HostCircuitBreakerFailureThreshold(),
+		slowRequestThreshold: opts.HostCircuitBreakerSlowRequestThreshold(),
+		openDuration:         opts.HostCircuitBreakerOpenDuration(),
+		nowFn:                opts.ClockOptions().NowFn(),
+		metrics:              newHostCircuitBreakerMetrics(scope),
+	}
+	b.metrics.state.Update(float64(circuitBreakerClosed))
+	return b
+}
+
+// allow returns whether a request may be sent to the host, every allowed
+// request must have its outcome recorded with record or be released with
+// release.
+func (b *hostCircuitBreaker) allow() bool {
+	if !b.enabled {
+		return true
+	}
+
+	b.Lock()
+	defer b.Unlock()
+
+	switch b.state {
+	case circuitBreakerOpen:
+		if b.nowFn().Sub(b.openedAt) < b.openDuration {
+			b.metrics.rejected.Inc(1)
+			return false
+		}
+		b.setStateWithLock(circuitBreakerHalfOpen)
+		fallthrough
+	case circuitBreakerHalfOpen:
+		if b.probeInFlight {
+			b.metrics.rejected.Inc(1)
+			return false
+		}
+		b.probeInFlight = true
+		b.metrics.probes.Inc(1)
+	}
+	return true
+}
+
+// record records the outcome of a request allowed at start, bad request,
+// resource exhausted and per element batch errors are not a sign of an
+// unhealthy host and count as successes.
+func (b *hostCircuitBreaker) record(start time.Time, err error) {
+	if !b.enabled {
+		return
+	}
+
+	failed := err != nil && !IsBadRequestError(err) && !IsResourceExhaustedError(err)
+	if _, ok := err.(*rpc.WriteBatchRawErrors); ok {
+		failed = false
+	}
+	if b.slowRequestThreshold > 0 && b.nowFn().Sub(start) >= b.slowRequestThreshold {
+		failed = true
+	}
+
+	b.Lock()
+	defer b.Unlock()
+
+	switch b.state {
+	case circuitBreakerClosed:
+		if !failed {
+			b.consecutiveFailures = 0
+			return
+		}
+		b.consecutiveFailures++
+		if b.consecutiveFailures >= b.failureThreshold {
+			b.openWithLock()
+		}
+	case circuitBreakerHalfOpen:
+		b.probeInFlight = false
+		if failed {
+			b.openWithLock()
+			return
+		}
+		b.consecutiveFailures = 0
+		b.setStateWithLock(circuitBreakerClosed)
+		b.metrics.closed.Inc(1)
+	}
+	// NB: outcomes of requests allowed before the breaker opened are ignored
+	// while it is open.
+}
+
+// release releases a request allowed at start without recording its outcome,
+// e.g. as it was cancelled, so that the half-open breaker lets another probe
+// through if it was the probe.
+func (b *hostCircuitBreaker) release() {
+	if !b.enabled {
+		return
+	}
+
+	b.Lock()
+	defer b.Unlock()
+
+	if b.state == circuitBreakerHalfOpen {
+		b.probeInFlight = false
+	}
+}
+
+func (b *hostCircuitBreaker) openWithLock() {
+	b.openedAt = b.nowFn()
+	b.setStateWithLock(circuitBreakerOpen)
+	b.metrics.opened.Inc(1)
+}
+
+func (b *hostCircuitBreaker) setStateWithLock(state circuitBreakerState) {
+	b.state = state
+	b.metrics.state.Update(float64(state))
+}
@@ -0,0 +1,263 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	tterrors "github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/errors"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+	xnetcontext "golang.org/x/net/context"
+)
+
+func newTestHostCircuitBreaker(now *time.Time) *hostCircuitBreaker {
+	opts := newHostQueueTestOptions().
+		SetHostCircuitBreakerEnabled(true).
+		SetHostCircuitBreakerFailureThreshold(3).
+		SetHostCircuitBreakerSlowRequestThreshold(time.Second).
+		SetHostCircuitBreakerOpenDuration(10 * time.Second)
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time {
+		return *now
+	}))
+	return newHostCircuitBreaker(opts, tally.NoopScope)
+}
+
+func TestHostCircuitBreakerOpensOnConsecutiveFailures(t *testing.T) {
+	now := time.Now()
+	b := newTestHostCircuitBreaker(&now)
+	hostErr := errors.New("host error")
+
+	// Bad request, resource exhausted and batch element errors do not count
+	// as failures.
+	for i := 0; i < 3; i++ {
+		require.True(t, b.allow())
+		b.record(now, tterrors.NewBadRequestError(errors.New("bad request")))
+		require.True(t, b.allow())
+		b.record(now, tterrors.NewResourceExhaustedError(errors.New("limit exceeded")))
+		require.True(t, b.allow())
+		b.record(now, &rpc.WriteBatchRawErrors{})
+	}
+
+	// A success resets the consecutive failures.
+	for i := 0; i < 2; i++ {
+		require.True(t, b.allow())
+		b.record(now, hostErr)
+	}
+	require.True(t, b.allow())
+	b.record(now, nil)
+	require.Equal(t, circuitBreakerClosed, b.state)
+
+	for i := 0; i < 3; i++ {
+		require.True(t, b.allow())
+		b.record(now, hostErr)
+	}
+	require.Equal(t, circuitBreakerOpen, b.state)
+	require.False(t, b.allow())
+
+	// Half-opens with a single probe after the open duration.
+	now = now.Add(10 * time.Second)
+	require.True(t, b.allow())
+	require.Equal(t, circuitBreakerHalfOpen, b.state)
+	require.False(t, b.allow())
+
+	// A failed probe reopens.
+	b.record(now, hostErr)
+	require.Equal(t, circuitBreakerOpen, b.state)
+	require.False(t, b.allow())
+
+	// A successful probe closes.
+	now = now.Add(10 * time.Second)
+	require.True(t, b.allow())
+	b.record(now, nil)
+	require.Equal(t, circuitBreakerClosed, b.state)
+	require.True(t, b.allow())
+}
+
+func TestHostCircuitBreakerReleasedProbe(t *testing.T) {
+	now := time.Now()
+	b := newTestHostCircuitBreaker(&now)
+
+	for i := 0; i < 3; i++ {
+		require.True(t, b.allow())
+		b.record(now, errors.New("host error"))
+	}
+	require.Equal(t, circuitBreakerOpen, b.state)
+
+	// A released probe lets another probe through.
+	now = now.Add(10 * time.Second)
+	require.True(t, b.allow())
+	require.False(t, b.allow())
+	b.release()
+	require.Equal(t, circuitBreakerHalfOpen, b.state)
+	require.True(t, b.allow())
+	b.record(now, nil)
+	require.Equal(t, circuitBreakerClosed, b.state)
+}
+
+func TestHostCircuitBreakerOpensOnSlowRequests(t *testing.T) {
+	now := time.Now()
+	b := newTestHostCircuitBreaker(&now)
+
+	for i := 0; i < 3; i++ {
+		require.True(t, b.allow())
+		start := now
+		now = now.Add(time.Second)
+		b.record(start, nil)
+	}
+	require.Equal(t, circuitBreakerOpen, b.state)
+	require.False(t, b.allow())
+}
+
+func TestHostCircuitBreakerDisabled(t *testing.T) {
+	b := newHostCircuitBreaker(newHostQueueTestOptions(), tally.NoopScope)
+	for i := 0; i < 2*defaultHostCircuitBreakerFailureThreshold; i++ {
+		require.True(t, b.allow())
+		b.record(time.Time{}, errors.New("host error"))
+	}
+	require.Equal(t, circuitBreakerClosed, b.state)
+}
+
+func TestHostQueueFetchTaggedCircuitBreakerOpen(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockConnPool := NewMockconnectionPool(ctrl)
+
+	opts := newHostQueueTestOptions().
+		SetHostQueueOpsFlushSize(1).
+		SetHostCircuitBreakerEnabled(true).
+		SetHostCircuitBreakerFailureThreshold(1)
+	queue := newTestHostQueue(opts)
+	queue.connPool = mockConnPool
+
+	mockConnPool.EXPECT().Open()
+	queue.Open()
+
+	var (
+		results []hostQueueResult
+		wg      sync.WaitGroup
+	)
+	callback := func(r interface{}, err error) {
+		results = append(results, hostQueueResult{r, err})
+		wg.Done()
+	}
+
+	// The first fetch fails and opens the breaker, the second is fast-failed
+	// without a request sent to the host.
+	mockClient := rpc.NewMockTChanNode(ctrl)
+	mockClient.EXPECT().FetchTagged(gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("host error"))
+	mockConnPool.EXPECT().NextClient().Return(mockClient, nil).Times(2)
+	mockConnPool.EXPECT().Close().AnyTimes()
+
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		require.NoError(t, queue.Enqueue(testFetchTaggedOp("testNs", callback)))
+		wg.Wait()
+	}
+	queue.Close()
+
+	require.Equal(t, 2, len(results))
+	assert.EqualError(t, results[0].err, "host error")
+	assert.EqualError(t, results[1].err, errQueueCircuitBreakerOpen(h.ID()).Error())
+}
+
+func TestHostQueueFetchTaggedCancelledProbe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockConnPool := NewMockconnectionPool(ctrl)
+
+	now := time.Now()
+	opts := newHostQueueTestOptions().
+		SetHostQueueOpsFlushSize(1).
+		SetHostCircuitBreakerEnabled(true).
+		SetHostCircuitBreakerFailureThreshold(1).
+		SetHostCircuitBreakerOpenDuration(10 * time.Second)
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time {
+		return now
+	}))
+	queue := newTestHostQueue(opts)
+	queue.connPool = mockConnPool
+
+	mockConnPool.EXPECT().Open()
+	queue.Open()
+
+	var (
+		results []hostQueueResult
+		wg      sync.WaitGroup
+	)
+	callback := func(r interface{}, err error) {
+		results = append(results, hostQueueResult{r, err})
+		wg.Done()
+	}
+
+	// The first fetch fails and opens the breaker.
+	mockClient := rpc.NewMockTChanNode(ctrl)
+	mockClient.EXPECT().FetchTagged(gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("host error"))
+	mockConnPool.EXPECT().NextClient().Return(mockClient, nil).Times(3)
+	mockConnPool.EXPECT().Close().AnyTimes()
+
+	wg.Add(1)
+	require.NoError(t, queue.Enqueue(testFetchTaggedOp("testNs", callback)))
+	wg.Wait()
+	require.Equal(t, circuitBreakerOpen, queue.breaker.state)
+
+	// The probe once half-open is cancelled as the fetch completes while it
+	// is in flight.
+	now = now.Add(10 * time.Second)
+	probe := testFetchTaggedOp("testNs", callback)
+	probe.ctx, probe.cancel = xnetcontext.WithCancel(xnetcontext.Background())
+	mockClient.EXPECT().FetchTagged(gomock.Any(), gomock.Any()).
+		Do(func(ctx interface{}, req interface{}) {
+			probe.cancelRequests()
+		}).
+		Return(nil, errors.New("context canceled"))
+
+	wg.Add(1)
+	require.NoError(t, queue.Enqueue(probe))
+	wg.Wait()
+
+	// The breaker lets the next request through as a probe.
+	result := &rpc.FetchTaggedResult_{Exhaustive: true}
+	mockClient.EXPECT().FetchTagged(gomock.Any(), gomock.Any()).
+		Return(result, nil)
+
+	wg.Add(1)
+	require.NoError(t, queue.Enqueue(testFetchTaggedOp("testNs", callback)))
+	wg.Wait()
+	queue.Close()
+
+	require.Equal(t, 3, len(results))
+	assert.EqualError(t, results[0].err, "host error")
+	assert.Equal(t, errFetchTaggedOpCancelled, results[1].err)
+	assert.NoError(t, results[2].err)
+	require.Equal(t, circuitBreakerClosed, queue.breaker.state)
+}
@@ -69,6 +69,30 @@ type Configuration struct {
 	// FetchRetry is the fetch retry config.
 	FetchRetry retry.Configuration `yaml:"fetchRetry"`
 
+	// HedgedReads is the hedged reads config, hedged reads are disabled if
+	// not set.
+	HedgedReads *HedgedReadsConfiguration `yaml:"hedgedReads"`
+
+	// ReadIsolationGroup is the read isolation group config, replicas are
+	// read from regardless of isolation group if not set.
+	ReadIsolationGroup *ReadIsolationGroupConfiguration `yaml:"readIsolationGroup"`
+
+	// ReadYourWrites is the read your writes config, reads are not routed
+	// based on the session's recent writes if not set.
+	ReadYourWrites *ReadYourWritesConfiguration `yaml:"readYourWrites"`
+
+	// WriteSpool is the write spool config, failed writes are not spooled to
+	// disk if not set.
+	WriteSpool *WriteSpoolConfiguration `yaml:"writeSpool"`
+
+	// RejectUnboundedTagQueries rejects tagged fetches with a query that has
+	// no positive term rather than only logging them, defaults to false.
+	RejectUnboundedTagQueries *bool `yaml:"rejectUnboundedTagQueries"`
+
+	// HostCircuitBreaker is the host circuit breaker config, host circuit
+	// breakers are disabled if not set.
+	HostCircuitBreaker *HostCircuitBreakerConfiguration `yaml:"hostCircuitBreaker"`
+
 	// BackgroundHealthCheckFailLimit is the amount of times a background check
 	// must fail before a connection is taken out of consideration.
 	BackgroundHealthCheckFailLimit int `yaml:"backgroundHealthCheckFailLimit" validate:"min=1,max=10"`
@@ -77,10 +101,87 @@ type Configuration struct {
 	// time to use when sleeping between a failed health check and the next check.
 	BackgroundHealthCheckFailThrottleFactor float64 `yaml:"backgroundHealthCheckFailThrottleFactor" validate:"min=0,max=10"`
 
+	// Transport is the transport used to send requests to hosts, either
+	// "tchannel" or "grpc", defaults to "tchannel".
+	Transport Transport `yaml:"transport"`
+
 	// HashingConfiguration is the configuration for hashing of IDs to shards.
 	HashingConfiguration HashingConfiguration `yaml:"hashing"`
 }
 
+// HedgedReadsConfiguration is the configuration for hedged reads.
+type HedgedReadsConfiguration struct {
+	// Enabled enables hedged reads.
+	Enabled bool `yaml:"enabled"`
+
+	// DelayPercentile is the percentile of recent host response latencies
+	// to wait for before sending hedged requests.
+	DelayPercentile *float64 `yaml:"delayPercentile"`
+
+	// MinDelay is the minimum delay before sending hedged requests.
+	MinDelay *time.Duration `yaml:"minDelay"`
+}
+
+// ReadIsolationGroupConfiguration is the configuration for preferring
+// replicas in an isolation group for reads.
+type ReadIsolationGroupConfiguration struct {
+	// IsolationGroup is the isolation group of replicas to read from first.
+	IsolationGroup string `yaml:"isolationGroup"`
+
+	// FallbackTimeout is how long to wait for replicas in the isolation group
+	// before also reading from other replicas, if hedged reads are disabled.
+	FallbackTimeout *time.Duration `yaml:"fallbackTimeout"`
+}
+
+// ReadYourWritesConfiguration is the configuration for routing reads to the
+// replicas that acknowledged the session's recent writes.
+type ReadYourWritesConfiguration struct {
+	// Enabled enables read your writes.
+	Enabled bool `yaml:"enabled"`
+
+	// Window is how long the replicas that acknowledged writes are tracked.
+	Window *time.Duration `yaml:"window"`
+}
+
+// WriteSpoolConfiguration is the configuration for spooling writes to disk
+// while too few hosts can be written to.
+type WriteSpoolConfiguration struct {
+	// Path is the directory writes are spooled to, only synchronous writes
+	// are spooled.
+	Path string `yaml:"path" validate:"nonzero"`
+
+	// MaxBytes is the maximum bytes of writes spooled to disk.
+	MaxBytes *int64 `yaml:"maxBytes"`
+
+	// ReplayInterval is the interval spooled writes are replayed and synced
+	// to disk at.
+	ReplayInterval *time.Duration `yaml:"replayInterval"`
+
+	// BufferPast is the buffer past of the namespaces written to, spooled
+	// points older than this when replayed are dropped. It must be no greater
+	// than the smallest buffer past of any namespace written to.
+	BufferPast *time.Duration `yaml:"bufferPast"`
+}
+
+// HostCircuitBreakerConfiguration is the configuration for host circuit
+// breakers.
+type HostCircuitBreakerConfiguration struct {
+	// Enabled enables host circuit breakers.
+	Enabled bool `yaml:"enabled"`
+
+	// FailureThreshold is the number of consecutive failed or slow requests
+	// to a host that open its circuit breaker.
+	FailureThreshold *int `yaml:"failureThreshold"`
+
+	// SlowRequestThreshold is the latency at which a request to a host counts
+	// as failed.
+	SlowRequestThreshold *time.Duration `yaml:"slowRequestThreshold"`
+
+	// OpenDuration is how long a host circuit breaker stays open before
+	// letting a probe request through.
+	OpenDuration *time.Duration `yaml:"openDuration"`
+}
+
 // HashingConfiguration is the configuration for hashing
 type HashingConfiguration struct {
 	// Murmur32 seed value
@@ -188,8 +289,63 @@ func (c Configuration) NewAdminClient(
 		SetWriteRetrier(c.WriteRetry.NewRetrier(writeRequestScope)).
 		SetFetchRetrier(c.FetchRetry.NewRetrier(fetchRequestScope)).
 		SetChannelOptions(xtchannel.NewDefaultChannelOptions()).
+		SetTransport(c.Transport).
 		SetInstrumentOptions(iopts)
 
+	if hedgedReads := c.HedgedReads; hedgedReads != nil {
+		v = v.SetHedgedReadsEnabled(hedgedReads.Enabled)
+		if hedgedReads.DelayPercentile != nil {
+			v = v.SetHedgedReadsDelayPercentile(*hedgedReads.DelayPercentile)
+		}
+		if hedgedReads.MinDelay != nil {
+			v = v.SetHedgedReadsMinDelay(*hedgedReads.MinDelay)
+		}
+	}
+
+	if readIsolationGroup := c.ReadIsolationGroup; readIsolationGroup != nil {
+		v = v.SetReadIsolationGroup(readIsolationGroup.IsolationGroup)
+		if readIsolationGroup.FallbackTimeout != nil {
+			v = v.SetReadIsolationGroupFallbackTimeout(*readIsolationGroup.FallbackTimeout)
+		}
+	}
+
+	if readYourWrites := c.ReadYourWrites; readYourWrites != nil {
+		v = v.SetReadYourWritesEnabled(readYourWrites.Enabled)
+		if readYourWrites.Window != nil {
+			v = v.SetReadYourWritesWindow(*readYourWrites.Window)
+		}
+	}
+
+	if spool := c.WriteSpool; spool != nil {
+		v = v.SetWriteSpoolPath(spool.Path)
+		if spool.MaxBytes != nil {
+			v = v.SetWriteSpoolMaxBytes(*spool.MaxBytes)
+		}
+		if spool.ReplayInterval != nil {
+			v = v.SetWriteSpoolReplayInterval(*spool.ReplayInterval)
+		}
+		if spool.BufferPast != nil {
+			v = v.SetWriteSpoolBufferPast(*spool.BufferPast)
+		}
+	}
+
+	if c.RejectUnboundedTagQueries != nil {
+		v = v.SetRejectUnboundedTagQueries(*c.RejectUnboundedTagQueries)
+	}
+
+	if breaker := c.HostCircuitBreaker; breaker != nil {
+		v = v.SetHostCircuitBreakerEnabled(breaker.Enabled)
+		if breaker.FailureThreshold != nil {
+			v = v.SetHostCircuitBreakerFailureThreshold(*breaker.FailureThreshold)
+		}
+		if breaker.SlowRequestThreshold != nil {
+			v = v.SetHostCircuitBreakerSlowRequestThreshold(*breaker.SlowRequestThreshold)
+		}
+		if breaker.OpenDuration != nil {
+			v = v.SetHostCircuitBreakerOpenDuration(*breaker.OpenDuration)
+		}
+	}
+
 	encodingOpts := params.EncodingOptions
 	if encodingOpts == nil {
 		encodingOpts = encoding.NewOptions()
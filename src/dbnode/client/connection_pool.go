@@ -296,6 +296,9 @@ func (p *connPool) healthCheckEvery(interval time.Duration, stutter time.Duratio
 }
 
 func newConn(channelName string, address string, opts Options) (xclose.SimpleCloser, rpc.TChanNode, error) {
+	if opts.Transport() == TransportGRPC {
+		return newGRPCConn(address, opts)
+	}
 	channel, err := tchannel.NewChannel(channelName, opts.ChannelOptions())
 	if err != nil {
 		return nil, nil, err
@@ -53,6 +53,15 @@ type fetchState struct {
 	err                  error
 	done                 bool
 
+	// hedge is set when the request is first sent to a subset of the hosts
+	// and hedged to the hedgeQueues after a delay.
+	hedge            *hedgedReads
+	hedgeQueues      []hostQueue
+	hedgeTimer       *time.Timer
+	hedged           bool
+	hedgeShardCounts []int
+	primaryQueues    []hostQueue
+
 	pool fetchStatePool
 }
 
@@ -78,6 +87,17 @@ func (f *fetchState) close() {
 	f.err = nil
 	f.done = false
 	f.tagResultAccumulator.Clear()
+	f.hedge = nil
+	for i := range f.hedgeQueues {
+		f.hedgeQueues[i] = nil
+	}
+	f.hedgeQueues = f.hedgeQueues[:0]
+	for i := range f.primaryQueues {
+		f.primaryQueues[i] = nil
+	}
+	f.primaryQueues = f.primaryQueues[:0]
+	f.hedgeTimer = nil
+	f.hedged = false
 
 	if f.pool == nil {
 		return
@@ -97,23 +117,113 @@ func (f *fetchState) Reset(
 	f.tagResultAccumulator.Reset(startTime, endTime, topoMap, majority, consistencyLevel)
 }
 
+// splitQueuesForHedgingWithLock returns the queues to send the request to
+// first and retains the rest to hedge the request to after a delay.
+func (f *fetchState) splitQueuesForHedgingWithLock(
+	hedge *hedgedReads,
+	queues []hostQueue,
+	topoMap topology.Map,
+	majority int,
+	consistencyLevel topology.ReadConsistencyLevel,
+) []hostQueue {
+	f.hedge = hedge
+	f.hedgeShardCounts, f.primaryQueues, f.hedgeQueues = hedge.splitQueues(queues,
+		topoMap, consistencyLevel, majority, f.hedgeShardCounts,
+		f.primaryQueues[:0], f.hedgeQueues[:0])
+	return f.primaryQueues
+}
+
+// scheduleHedgeWithLock schedules hedging the request to the remaining
+// queues after the hedge delay.
+func (f *fetchState) scheduleHedgeWithLock() {
+	if f.hedge == nil || len(f.hedgeQueues) == 0 {
+		return
+	}
+	f.incRef() // released by the timer or when the timer is stopped
+	f.hedgeTimer = time.AfterFunc(f.hedge.delay(), f.hedgeTimerFn)
+}
+
+func (f *fetchState) hedgeTimerFn() {
+	f.Lock()
+	f.hedgeWithLock()
+	f.Unlock()
+	f.decRef() // release ref held onto by the timer
+}
+
+// stopHedgeTimerWithLock stops the hedge timer and returns whether the ref
+// held onto by the timer needs to be released by the caller.
+func (f *fetchState) stopHedgeTimerWithLock() bool {
+	if f.hedgeTimer == nil {
+		return false
+	}
+	stopped := f.hedgeTimer.Stop()
+	f.hedgeTimer = nil
+	return stopped
+}
+
+func (f *fetchState) hedgeWithLock() {
+	if f.done || f.hedged {
+		return
+	}
+	f.hedged = true
+	for _, hq := range f.hedgeQueues {
+		// inc to indicate the hostQueue has a reference to `op` which has a ref to the fetchState
+		f.incRef()
+		if err := hq.Enqueue(f.op); err != nil {
+			// NB: the caller holds a ref so this never releases the fetchState.
+			f.decRef()
+			// Count the host as having failed so the request can terminate.
+			done, accumErr := f.tagResultAccumulator.Add(
+				fetchTaggedResultAccumulatorOpts{host: hq.Host()}, err)
+			if done {
+				f.markDoneWithLock(accumErr)
+				return
+			}
+			continue
+		}
+		f.hedge.metrics.sent.Inc(1)
+	}
+}
+
+func (f *fetchState) isHedgeHostWithLock(host topology.Host) bool {
+	if !f.hedged {
+		return false
+	}
+	for _, hq := range f.hedgeQueues {
+		if hq.Host().ID() == host.ID() {
+			return true
+		}
+	}
+	return false
+}
+
 func (f *fetchState) completionFn(
 	result interface{},
 	resultErr error,
 ) {
+	var releaseTimerRef bool
 	f.Lock()
 	defer func() {
 		f.Unlock()
 		f.decRef() // release ref held onto by the hostQueue (via op.completionFn)
+		if releaseTimerRef {
+			f.decRef() // release ref held onto by the stopped hedge timer
+		}
 	}()
 
+	opts, ok := result.(fetchTaggedResultAccumulatorOpts)
+	if ok {
+		// NB: responses received once done are still sampled so that the
+		// hedge delay is not biased towards the fastest hosts.
+		f.recordLatencyWithLock(opts, resultErr)
+	}
+
 	if f.done {
 		// i.e. we've already failed, no need to continue processing any additional
 		// responses we receive
 		return
 	}
 
-	opts, ok := result.(fetchTaggedResultAccumulatorOpts)
 	if !ok {
 		// should never happen
 		f.markDoneWithLock(fmt.Errorf(
@@ -123,13 +233,47 @@ func (f *fetchState) completionFn(
 
 	done, err := f.tagResultAccumulator.Add(opts, resultErr)
 	if done {
+		if f.hedge != nil {
+			releaseTimerRef = f.stopHedgeTimerWithLock()
+			if err == nil && resultErr == nil && f.isHedgeHostWithLock(opts.host) {
+				f.hedge.metrics.won.Inc(1)
+			}
+		}
 		f.markDoneWithLock(err)
+		return
+	}
+
+	if f.hedge != nil && resultErr != nil {
+		// Don't wait for the hedge delay once a host has failed.
+		releaseTimerRef = f.stopHedgeTimerWithLock()
+		f.hedgeWithLock()
+	}
+}
+
+// recordLatencyWithLock records the latency of a host from when the request
+// was sent to it, requests cancelled once the fetch completed are recorded
+// with the time until they were cancelled which is a lower bound of the
+// latency of the host.
+func (f *fetchState) recordLatencyWithLock(
+	opts fetchTaggedResultAccumulatorOpts,
+	resultErr error,
+) {
+	if f.hedge == nil || opts.sentAt.IsZero() {
+		return
+	}
+	if resultErr != nil && resultErr != errFetchTaggedOpCancelled {
+		return
 	}
+	f.hedge.recordLatency(f.hedge.nowFn().Sub(opts.sentAt))
 }
 
 func (f *fetchState) markDoneWithLock(err error) {
 	f.done = true
 	f.err = err
+	if f.op != nil {
+		// The results of the hosts yet to respond are no longer needed.
+		f.op.cancelRequests()
+	}
 	f.Signal()
 }
 
@@ -165,6 +309,21 @@ func (f *fetchState) asEncodingSeriesIterators(pools fetchTaggedPools) (encoding
 	return f.tagResultAccumulator.AsEncodingSeriesIterators(limit, pools)
 }
 
+func (f *fetchState) asEncodingSeriesIteratorsPage(pools fetchTaggedPools) (encoding.SeriesIterators, []byte, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	if !f.done {
+		return nil, nil, errFetchStateStillProcessing
+	}
+
+	if err := f.err; err != nil {
+		return nil, nil, err
+	}
+
+	return f.tagResultAccumulator.AsEncodingSeriesIteratorsPage(pools)
+}
+
 // NB(prateek): this is backed by the sessionPools struct, but we're restricting it to a narrow
 // interface to force the fetchTagged code-paths to be explicit about the pools they need access
 // to. The alternative is to either expose the sessionPools struct (which is a worse abstraction),
@@ -173,6 +332,7 @@ func (f *fetchState) asEncodingSeriesIterators(pools fetchTaggedPools) (encoding
 type fetchTaggedPools interface {
 	MultiReaderIteratorArray() encoding.MultiReaderIteratorArrayPool
 	MultiReaderIterator() encoding.MultiReaderIteratorPool
+	NamespaceMultiReaderIterator(namespace []byte) encoding.MultiReaderIteratorPool
 	MutableSeriesIterators() encoding.MutableSeriesIteratorsPool
 	SeriesIterator() encoding.SeriesIteratorPool
 	CheckedBytesWrapper() xpool.CheckedBytesWrapperPool
@@ -0,0 +1,86 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3x/ident"
+)
+
+type seriesIteratorsCursor struct {
+	session   *session
+	ns        ident.ID
+	query     index.Query
+	opts      index.QueryOptions
+	pageSize  int64
+	pageToken []byte
+	done      bool
+	current   encoding.SeriesIterators
+	err       error
+}
+
+func newSeriesIteratorsCursor(
+	s *session,
+	ns ident.ID,
+	q index.Query,
+	opts index.QueryOptions,
+	pageSize int64,
+) SeriesIteratorsCursor {
+	return &seriesIteratorsCursor{
+		session:  s,
+		ns:       ns,
+		query:    q,
+		opts:     opts,
+		pageSize: pageSize,
+	}
+}
+
+func (c *seriesIteratorsCursor) Next() bool {
+	c.current = nil
+	for !c.done && c.err == nil {
+		iters, nextPageToken, err := c.session.fetchTaggedPage(c.ns, c.query,
+			c.opts, c.pageSize, c.pageToken)
+		if err != nil {
+			c.err = err
+			return false
+		}
+
+		c.pageToken = nextPageToken
+		c.done = nextPageToken == nil
+		if iters.Len() == 0 {
+			iters.Close()
+			continue
+		}
+
+		c.current = iters
+		return true
+	}
+	return false
+}
+
+func (c *seriesIteratorsCursor) Current() encoding.SeriesIterators {
+	return c.current
+}
+
+func (c *seriesIteratorsCursor) Err() error {
+	return c.err
+}
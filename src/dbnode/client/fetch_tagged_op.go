@@ -21,12 +21,20 @@
 package client
 
 import (
+	"errors"
+	"time"
+
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
 	"github.com/m3db/m3x/pool"
+
+	"github.com/uber/tchannel-go/thrift"
+	xnetcontext "golang.org/x/net/context"
 )
 
 var (
 	fetchTaggedOpRequestZeroed = rpc.FetchTaggedRequest{}
+
+	errFetchTaggedOpCancelled = errors.New("fetch tagged op cancelled as the fetch has completed")
 )
 
 type fetchTaggedOp struct {
@@ -34,6 +42,16 @@ type fetchTaggedOp struct {
 	request      rpc.FetchTaggedRequest
 	completionFn completionFn
 
+	// pageSize is set when fetching a single page of the results starting
+	// after pageToken, if set.
+	pageSize  int64
+	pageToken []byte
+
+	// ctx is cancelled once the fetch has completed so that the hosts which
+	// have yet to respond are not waited on.
+	ctx    xnetcontext.Context
+	cancel xnetcontext.CancelFunc
+
 	pool fetchTaggedOpPool
 }
 
@@ -43,6 +61,42 @@ func (f *fetchTaggedOp) CompletionFn() completionFn { return f.completionFn }
 func (f *fetchTaggedOp) update(req rpc.FetchTaggedRequest, fn completionFn) {
 	f.request = req
 	f.completionFn = fn
+	f.ctx, f.cancel = xnetcontext.WithCancel(xnetcontext.Background())
+}
+
+// newContext returns a context for a request to a host that is cancelled
+// when the op is cancelled.
+func (f *fetchTaggedOp) newContext(timeout time.Duration) (thrift.Context, xnetcontext.CancelFunc) {
+	parent := f.ctx
+	if parent == nil {
+		parent = xnetcontext.Background()
+	}
+	ctx, cancel := xnetcontext.WithTimeout(parent, timeout)
+	return thrift.WithHeaders(ctx, nil), cancel
+}
+
+// cancelRequests cancels the requests to hosts that are yet to respond.
+func (f *fetchTaggedOp) cancelRequests() {
+	if f.cancel != nil {
+		f.cancel()
+	}
+}
+
+func (f *fetchTaggedOp) isCancelled() bool {
+	return f.ctx != nil && f.ctx.Err() != nil
+}
+
+func (f *fetchTaggedOp) updatePage(pageSize int64, pageToken []byte) {
+	f.pageSize = pageSize
+	f.pageToken = pageToken
+}
+
+func (f *fetchTaggedOp) pageRequest() *rpc.FetchTaggedPageRequest {
+	return &rpc.FetchTaggedPageRequest{
+		FetchTagged: &f.request,
+		PageSize:    f.pageSize,
+		PageToken:   f.pageToken,
+	}
 }
 
 func (f *fetchTaggedOp) requestLimit(defaultValue int) int {
@@ -55,6 +109,11 @@ func (f *fetchTaggedOp) requestLimit(defaultValue int) int {
 func (f *fetchTaggedOp) close() {
 	f.completionFn = nil
 	f.request = fetchTaggedOpRequestZeroed
+	f.pageSize = 0
+	f.pageToken = nil
+	f.cancelRequests()
+	f.ctx = nil
+	f.cancel = nil
 	// return to pool
 	if f.pool == nil {
 		return
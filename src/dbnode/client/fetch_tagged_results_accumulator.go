@@ -29,13 +29,24 @@ import (
 	"github.com/m3db/m3/src/cluster/shard"
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/convert"
+	"github.com/m3db/m3/src/dbnode/sharding"
 	"github.com/m3db/m3/src/dbnode/topology"
 	xerrors "github.com/m3db/m3x/errors"
+	"github.com/m3db/m3x/ident"
 )
 
 type fetchTaggedResultAccumulatorOpts struct {
 	host     topology.Host
 	response *rpc.FetchTaggedResult_
+
+	// sentAt is set to when the request was sent to the host, if it was.
+	sentAt time.Time
+
+	// page is set for responses to a fetch tagged page request, with
+	// nextPageToken set if the host has more results after the page.
+	page          bool
+	nextPageToken []byte
 }
 
 func newFetchTaggedResultAccumulator() fetchTaggedResultAccumulator {
@@ -56,6 +67,12 @@ type fetchTaggedResultAccumulator struct {
 	responses  fetchTaggedIDResults
 	exhaustive bool
 
+	// pageFrontier is the smallest ID of the last series across hosts that
+	// returned a page with more results after it, every host has returned all
+	// of its results up to and including this ID.
+	pageFrontier    []byte
+	pageFrontierSet bool
+
 	startTime        time.Time
 	endTime          time.Time
 	majority         int
@@ -104,9 +121,28 @@ func (accum *fetchTaggedResultAccumulator) Add(
 			fmt.Errorf("error fetching tagged from host %s: %v", host.ID(), resultErr)))
 	} else {
 		accum.exhaustive = accum.exhaustive && response.Exhaustive
+		hostShards := hostShardSet.ShardSet()
+		allAvailable := shardsAllAvailable(hostShards)
 		for _, elem := range response.Elements {
+			if !allAvailable && !accum.shardAvailable(hostShards, elem.ID) {
+				// Only accept series from the host's available shards as
+				// only those count towards the consistency of the shards
+				// below, initializing shards can be missing data.
+				continue
+			}
 			accum.responses = append(accum.responses, elem)
 		}
+		if opts.page && opts.nextPageToken != nil {
+			last, err := convert.FromRPCFetchTaggedPageToken(opts.nextPageToken)
+			if err != nil {
+				doneAccumulating := true
+				return doneAccumulating, xerrors.NewNonRetryableError(err)
+			}
+			if !accum.pageFrontierSet || bytes.Compare(last, accum.pageFrontier) < 0 {
+				accum.pageFrontier = last
+				accum.pageFrontierSet = true
+			}
+		}
 	}
 
 	// FOLLOWUP(prateek): once we transmit the shards successfully satisfied by a response, the
@@ -166,6 +202,26 @@ func (accum *fetchTaggedResultAccumulator) Add(
 	return doneAccumulating, nil
 }
 
+// shardAvailable returns whether the host's replica of the shard a series
+// belongs to is available.
+func (accum *fetchTaggedResultAccumulator) shardAvailable(
+	hostShards sharding.ShardSet,
+	id []byte,
+) bool {
+	shardID := accum.topoMap.ShardSet().Lookup(ident.BytesID(id))
+	state, err := hostShards.LookupStateByID(shardID)
+	return err == nil && state == shard.Available
+}
+
+func shardsAllAvailable(shards sharding.ShardSet) bool {
+	for _, s := range shards.All() {
+		if s.State() != shard.Available {
+			return false
+		}
+	}
+	return true
+}
+
 func (accum *fetchTaggedResultAccumulator) Clear() {
 	for i := range accum.responses {
 		accum.responses[i] = nil
@@ -181,6 +237,8 @@ func (accum *fetchTaggedResultAccumulator) Clear() {
 	accum.startTime, accum.endTime = time.Time{}, time.Time{}
 	accum.topoMap = nil
 	accum.exhaustive = true
+	accum.pageFrontier = nil
+	accum.pageFrontierSet = false
 }
 
 func (accum *fetchTaggedResultAccumulator) Reset(
@@ -221,7 +279,7 @@ func (accum *fetchTaggedResultAccumulator) sliceResponsesAsSeriesIter(
 	for idx, elem := range elems {
 		slicesIter := pools.ReaderSliceOfSlicesIterator().Get()
 		slicesIter.Reset(elem.Segments)
-		multiIter := pools.MultiReaderIterator().Get()
+		multiIter := pools.NamespaceMultiReaderIterator(elem.NameSpace).Get()
 		multiIter.ResetSliceOfSlices(slicesIter)
 		iters[idx] = multiIter
 	}
@@ -279,6 +337,38 @@ func (accum *fetchTaggedResultAccumulator) AsEncodingSeriesIterators(
 	return result, exhaustive, nil
 }
 
+// AsEncodingSeriesIteratorsPage returns the series of a fetch tagged page that
+// every host has returned all results up to, along with the page token to
+// fetch the next page with, nil once all hosts have returned all results.
+func (accum *fetchTaggedResultAccumulator) AsEncodingSeriesIteratorsPage(
+	pools fetchTaggedPools,
+) (encoding.SeriesIterators, []byte, error) {
+	var pageToken []byte
+	if accum.pageFrontierSet {
+		// Drop the series after the frontier, some hosts are yet to return
+		// results for them and they will be included in the next page.
+		n := 0
+		for _, elem := range accum.responses {
+			if bytes.Compare(elem.ID, accum.pageFrontier) > 0 {
+				continue
+			}
+			accum.responses[n] = elem
+			n++
+		}
+		for i := n; i < len(accum.responses); i++ {
+			accum.responses[i] = nil
+		}
+		accum.responses = accum.responses[:n]
+		pageToken = convert.ToRPCFetchTaggedPageToken(accum.pageFrontier)
+	}
+
+	iters, _, err := accum.AsEncodingSeriesIterators(maxInt, pools)
+	if err != nil {
+		return nil, nil, err
+	}
+	return iters, pageToken, nil
+}
+
 func (accum *fetchTaggedResultAccumulator) AsTaggedIDsIterator(
 	limit int,
 	pools fetchTaggedPools,
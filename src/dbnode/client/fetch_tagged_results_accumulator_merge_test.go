@@ -22,6 +22,7 @@ package client
 
 import (
 	"fmt"
+	"sort"
 	"testing"
 	"time"
 
@@ -139,6 +140,62 @@ func TestFetchTaggedResultsAccumulatorIdsMergeUnstrictMajority(t *testing.T) {
 	require.True(t, matcher.Matches(resultsIter))
 }
 
+func TestFetchTaggedResultsAccumulatorIdsMergeSkipsInitializingShards(t *testing.T) {
+	// rf=3, the last host is initializing half of its shards
+	topoMap := testutil.MustNewTopologyMap(3, map[string][]shard.Shard{
+		"testhost0": testutil.ShardsRange(0, 29, shard.Available),
+		"testhost1": testutil.ShardsRange(0, 29, shard.Available),
+		"testhost2": append(testutil.ShardsRange(0, 14, shard.Available),
+			testutil.ShardsRange(15, 29, shard.Initializing)...),
+	})
+
+	// Find series only the last host returns in one of its available shards
+	// and in one of its initializing shards.
+	var available, initializing *testSeries
+	for i := 100; available == nil || initializing == nil; i++ {
+		s := newTestSeries(i)
+		if topoMap.ShardSet().Lookup(s.id) < 15 {
+			available = &s
+		} else {
+			initializing = &s
+		}
+	}
+
+	th := newTestFetchTaggedHelper(t)
+	serieses := newTestSerieses(1, 5)
+	workflow := testFetchTaggedWorkflow{
+		t:         t,
+		topoMap:   topoMap,
+		level:     topology.ReadConsistencyLevelUnstrictMajority,
+		startTime: testStartTime,
+		endTime:   testEndTime,
+		steps: []testFetchTaggedWorklowStep{
+			testFetchTaggedWorklowStep{
+				hostname: "testhost2",
+				response: append(serieses, *available, *initializing).
+					toRPCResult(th, testStartTime, true),
+			},
+			testFetchTaggedWorklowStep{
+				hostname: "testhost0",
+				response: serieses.toRPCResult(th, testStartTime, true),
+			},
+			testFetchTaggedWorklowStep{
+				hostname:     "testhost1",
+				response:     serieses.toRPCResult(th, testStartTime, true),
+				expectedDone: true,
+			},
+		},
+	}
+	accum := workflow.run()
+
+	// The series from the initializing shard is not returned.
+	resultsIter, resultsExhaustive, err := accum.AsTaggedIDsIterator(10, th.pools)
+	require.NoError(t, err)
+	require.True(t, resultsExhaustive)
+	matcher := append(serieses, *available).indexMatcher()
+	require.True(t, matcher.Matches(resultsIter))
+}
+
 func TestFetchTaggedResultsAccumulatorIdsMergeReportsExhaustiveCorrectly(t *testing.T) {
 	// rf=3, 3 identical hosts, with same shards
 	topoMap := testutil.MustNewTopologyMap(3, map[string][]shard.Shard{
@@ -290,6 +347,56 @@ func TestFetchTaggedResultsAccumulatorSeriesItersDatapointsNSplit(t *testing.T)
 	sg0.assertMatchesEncodingIters(t, iters)
 }
 
+func TestFetchTaggedResultsAccumulatorSeriesItersPage(t *testing.T) {
+	// rf=3, 3 identical hosts, with same shards
+	topoMap := testutil.MustNewTopologyMap(3, map[string][]shard.Shard{
+		"testhost0": testutil.ShardsRange(0, 29, shard.Available),
+		"testhost1": testutil.ShardsRange(0, 29, shard.Available),
+		"testhost2": testutil.ShardsRange(0, 29, shard.Available),
+	})
+
+	// order the series the way hosts return pages of them
+	sg0 := newTestSerieses(1, 10)
+	sort.Slice(sg0, func(i, j int) bool {
+		return sg0[i].id.String() < sg0[j].id.String()
+	})
+
+	th := newTestFetchTaggedHelper(t)
+	accum := newFetchTaggedResultAccumulator()
+	accum.Clear()
+	accum.Reset(testStartTime, testEndTime, topoMap,
+		topoMap.MajorityReplicas(), topology.ReadConsistencyLevelAll)
+
+	// testhost0 and testhost1 have more results after their pages, and
+	// testhost2 has returned all of its results
+	steps := []struct {
+		hostname      string
+		response      testSerieses
+		nextPageToken []byte
+	}{
+		{"testhost0", sg0[:6], convert.ToRPCFetchTaggedPageToken(sg0[5].id.Bytes())},
+		{"testhost1", sg0[:4], convert.ToRPCFetchTaggedPageToken(sg0[3].id.Bytes())},
+		{"testhost2", sg0, nil},
+	}
+	for i, s := range steps {
+		done, err := accum.Add(fetchTaggedResultAccumulatorOpts{
+			host:          host(t, topoMap, s.hostname),
+			response:      s.response.toRPCResult(th, testStartTime, true),
+			page:          true,
+			nextPageToken: s.nextPageToken,
+		}, nil)
+		require.NoError(t, err)
+		require.Equal(t, i == len(steps)-1, done)
+	}
+
+	iters, pageToken, err := accum.AsEncodingSeriesIteratorsPage(th.pools)
+	require.NoError(t, err)
+	require.Equal(t, convert.ToRPCFetchTaggedPageToken(sg0[3].id.Bytes()), pageToken)
+
+	// series after the smallest last series across hosts are dropped
+	sg0[:4].assertMatchesEncodingIters(t, iters)
+}
+
 type testFetchTaggedWorkflow struct {
 	t         *testing.T
 	topoMap   topology.Map
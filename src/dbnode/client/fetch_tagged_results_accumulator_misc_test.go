@@ -318,6 +318,10 @@ func (p testFetchTaggedPools) MultiReaderIterator() encoding.MultiReaderIterator
 	return p.multiReader
 }
 
+func (p testFetchTaggedPools) NamespaceMultiReaderIterator(namespace []byte) encoding.MultiReaderIteratorPool {
+	return p.multiReader
+}
+
 func (p testFetchTaggedPools) SeriesIterator() encoding.SeriesIteratorPool {
 	return p.seriesIter
 }
@@ -0,0 +1,299 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/shard"
+	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/topology"
+
+	"github.com/uber-go/tally"
+)
+
+const (
+	// hedgedReadsLatencySamples is the number of recent host response
+	// latencies the hedge delay is computed from.
+	hedgedReadsLatencySamples = 1024
+	// hedgedReadsMinLatencySamples is the number of latencies that need to be
+	// observed before the hedge delay is computed from them.
+	hedgedReadsMinLatencySamples = 64
+	// hedgedReadsRecomputeEvery is the number of latencies observed between
+	// recomputing the hedge delay.
+	hedgedReadsRecomputeEvery = 64
+)
+
+type hedgedReadsMetrics struct {
+	sent tally.Counter
+	won  tally.Counter
+}
+
+func newHedgedReadsMetrics(scope tally.Scope) hedgedReadsMetrics {
+	return hedgedReadsMetrics{
+		sent: scope.Counter("sent"),
+		won:  scope.Counter("won"),
+	}
+}
+
+// hedgedReads decides which hosts fetch tagged requests are sent to first
+// and how long to wait before hedging the request to the remaining hosts.
+// With hedged reads enabled the delay is based on a percentile of recently
+// observed host response latencies, otherwise when only a read isolation
+// group is set the delay is the isolation group fallback timeout.
+type hedgedReads struct {
+	sync.Mutex
+
+	hedgingEnabled  bool
+	percentile      float64
+	minDelay        time.Duration
+	isolationGroup  string
+	fallbackTimeout time.Duration
+	nowFn           clock.NowFn
+	metrics         hedgedReadsMetrics
+
+	latencies        []time.Duration
+	sorted           []time.Duration
+	next             int
+	sinceRecompute   int
+	delayNanos       int64
+	queuesRoundRobin uint32
+}
+
+func newHedgedReads(opts Options, scope tally.Scope) *hedgedReads {
+	delay := opts.HedgedReadsMinDelay()
+	if !opts.HedgedReadsEnabled() {
+		delay = opts.ReadIsolationGroupFallbackTimeout()
+	}
+	return &hedgedReads{
+		hedgingEnabled:  opts.HedgedReadsEnabled(),
+		percentile:      opts.HedgedReadsDelayPercentile(),
+		minDelay:        opts.HedgedReadsMinDelay(),
+		isolationGroup:  opts.ReadIsolationGroup(),
+		fallbackTimeout: opts.ReadIsolationGroupFallbackTimeout(),
+		nowFn:           opts.ClockOptions().NowFn(),
+		metrics:         newHedgedReadsMetrics(scope),
+		latencies:       make([]time.Duration, 0, hedgedReadsLatencySamples),
+		delayNanos:      int64(delay),
+	}
+}
+
+// recordLatency records the latency of a successful host response.
+func (h *hedgedReads) recordLatency(latency time.Duration) {
+	if !h.hedgingEnabled {
+		// The delay is the fixed isolation group fallback timeout.
+		return
+	}
+	h.Lock()
+	if len(h.latencies) < hedgedReadsLatencySamples {
+		h.latencies = append(h.latencies, latency)
+	} else {
+		h.latencies[h.next] = latency
+		h.next = (h.next + 1) % hedgedReadsLatencySamples
+	}
+	h.sinceRecompute++
+	if len(h.latencies) >= hedgedReadsMinLatencySamples &&
+		h.sinceRecompute >= hedgedReadsRecomputeEvery {
+		h.sinceRecompute = 0
+		h.recomputeDelayWithLock()
+	}
+	h.Unlock()
+}
+
+func (h *hedgedReads) recomputeDelayWithLock() {
+	h.sorted = append(h.sorted[:0], h.latencies...)
+	sort.Slice(h.sorted, func(i, j int) bool {
+		return h.sorted[i] < h.sorted[j]
+	})
+	idx := int(math.Ceil(h.percentile*float64(len(h.sorted)))) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	delay := h.sorted[idx]
+	if delay < h.minDelay {
+		delay = h.minDelay
+	}
+	atomic.StoreInt64(&h.delayNanos, int64(delay))
+}
+
+// delay returns how long to wait before sending hedged requests.
+func (h *hedgedReads) delay() time.Duration {
+	return time.Duration(atomic.LoadInt64(&h.delayNanos))
+}
+
+// preferLocal returns whether reads at the consistency level are sent to
+// replicas in the read isolation group first, which is only the case when the
+// consistency level can be met by a subset of the replicas.
+func (h *hedgedReads) preferLocal(level topology.ReadConsistencyLevel) bool {
+	if h.isolationGroup == "" {
+		return false
+	}
+	switch level {
+	case topology.ReadConsistencyLevelNone,
+		topology.ReadConsistencyLevelOne,
+		topology.ReadConsistencyLevelUnstrictMajority:
+		return true
+	}
+	return false
+}
+
+// splitQueues appends to primary the queues to send a fetch tagged request to
+// first, which if all successful meet the read consistency level for every
+// shard, and appends the rest of the queues to hedges. The hosts picked first
+// rotate between requests to spread load evenly. When a read isolation group
+// is set and the consistency level can be met by a subset of replicas, hosts
+// in the local isolation group are picked first. Without hedging or a local
+// preference applying all queues are primary.
+func (h *hedgedReads) splitQueues(
+	queues []hostQueue,
+	topoMap topology.Map,
+	level topology.ReadConsistencyLevel,
+	majority int,
+	shardCounts []int,
+	primary []hostQueue,
+	hedges []hostQueue,
+) ([]int, []hostQueue, []hostQueue) {
+	required := readConsistencyRequired(level, majority, topoMap.Replicas())
+
+	numShards := 1 + int(topoMap.ShardSet().Max())
+	shardCounts = shardCounts[:0]
+	for i := 0; i < numShards; i++ {
+		shardCounts = append(shardCounts, 0)
+	}
+
+	preferLocal := h.preferLocal(level)
+
+	if len(queues) == 0 {
+		return shardCounts, primary, hedges
+	}
+	if !h.hedgingEnabled && !preferLocal {
+		return shardCounts, append(primary, queues...), hedges
+	}
+
+	start := int(atomic.AddUint32(&h.queuesRoundRobin, 1) % uint32(len(queues)))
+	split := func(local bool) {
+		for i := range queues {
+			hq := queues[(start+i)%len(queues)]
+			if preferLocal && (hq.Host().IsolationGroup() == h.isolationGroup) != local {
+				continue
+			}
+
+			hostShardSet, ok := topoMap.LookupHostShardSet(hq.Host().ID())
+			if !ok {
+				hedges = append(hedges, hq)
+				continue
+			}
+
+			needed := false
+			for _, s := range hostShardSet.ShardSet().All() {
+				if s.State() == shard.Available && shardCounts[s.ID()] < required {
+					needed = true
+					break
+				}
+			}
+			if !needed {
+				hedges = append(hedges, hq)
+				continue
+			}
+
+			primary = append(primary, hq)
+			for _, s := range hostShardSet.ShardSet().All() {
+				if s.State() == shard.Available {
+					shardCounts[s.ID()]++
+				}
+			}
+		}
+	}
+	split(true)
+	if preferLocal {
+		// Fill in the remaining replicas needed from remote isolation groups.
+		split(false)
+	}
+	return shardCounts, primary, hedges
+}
+
+// readConsistencyRequired returns the number of replicas that need to be read
+// from successfully to meet the read consistency level.
+func readConsistencyRequired(
+	level topology.ReadConsistencyLevel,
+	majority, replicas int,
+) int {
+	switch level {
+	case topology.ReadConsistencyLevelMajority,
+		topology.ReadConsistencyLevelUnstrictMajority:
+		return majority
+	case topology.ReadConsistencyLevelAll:
+		return replicas
+	}
+	return 1
+}
+
+// fetchIDRoute is a replica a fetch of an ID is routed to.
+type fetchIDRoute struct {
+	hostIdx int
+	host    topology.Host
+	queue   hostQueue
+}
+
+// orderFetchIDRoutes moves the routes to replicas in the read isolation group
+// to the front of the routes, keeping the order of the routes otherwise.
+func (h *hedgedReads) orderFetchIDRoutes(routes, ordered []fetchIDRoute) []fetchIDRoute {
+	ordered = ordered[:0]
+	for _, local := range []bool{true, false} {
+		for _, r := range routes {
+			if (r.queue.Host().IsolationGroup() == h.isolationGroup) == local {
+				ordered = append(ordered, r)
+			}
+		}
+	}
+	return ordered
+}
+
+// fetchIDFallback holds the replicas outside the read isolation group that a
+// fetch of an ID falls back to when a replica it was first sent to fails or
+// none respond within the isolation group fallback timeout.
+type fetchIDFallback struct {
+	state        int32
+	queues       []hostQueue
+	namespace    []byte
+	id           []byte
+	completionFn completionFn
+	// releaseFn releases the references held for the fallback replicas when
+	// the fetch completes without falling back.
+	releaseFn func()
+}
+
+// take returns whether the caller gets to either send or release the
+// fallback, which only happens once.
+func (f *fetchIDFallback) take() bool {
+	return atomic.CompareAndSwapInt32(&f.state, 0, 1)
+}
+
+// release releases the fallback unless it was already sent.
+func (f *fetchIDFallback) release() {
+	if f.take() {
+		f.releaseFn()
+	}
+}
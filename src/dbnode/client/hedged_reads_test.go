@@ -0,0 +1,348 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/topology"
+	"github.com/m3db/m3x/ident"
+	"github.com/m3db/m3x/pool"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+)
+
+func newHedgedReadsTestQueues(
+	t *testing.T,
+	ctrl *gomock.Controller,
+	opts Options,
+) (topology.Map, []hostQueue) {
+	topoWatch, err := opts.TopologyInitializer().Init()
+	require.NoError(t, err)
+	topoMap := topoWatch.Get()
+
+	var queues []hostQueue
+	for _, host := range topoMap.Hosts() {
+		hq := NewMockhostQueue(ctrl)
+		hq.EXPECT().Host().Return(host).AnyTimes()
+		queues = append(queues, hq)
+	}
+	return topoMap, queues
+}
+
+func TestHedgedReadsSplitQueues(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSessionTestOptions()
+	topoMap, queues := newHedgedReadsTestQueues(t, ctrl, opts)
+	hedge := newHedgedReads(opts, tally.NoopScope)
+
+	tests := []struct {
+		level   topology.ReadConsistencyLevel
+		primary int
+	}{
+		{level: topology.ReadConsistencyLevelNone, primary: 1},
+		{level: topology.ReadConsistencyLevelOne, primary: 1},
+		{level: topology.ReadConsistencyLevelUnstrictMajority, primary: 2},
+		{level: topology.ReadConsistencyLevelMajority, primary: 2},
+		{level: topology.ReadConsistencyLevelAll, primary: 3},
+	}
+	for _, test := range tests {
+		_, primary, hedges := hedge.splitQueues(queues, topoMap, test.level,
+			2, nil, nil, nil)
+		require.Equal(t, test.primary, len(primary), test.level.String())
+		require.Equal(t, len(queues)-test.primary, len(hedges), test.level.String())
+	}
+
+	// Hosts picked first rotate between requests.
+	_, first, _ := hedge.splitQueues(queues, topoMap,
+		topology.ReadConsistencyLevelOne, 2, nil, nil, nil)
+	_, second, _ := hedge.splitQueues(queues, topoMap,
+		topology.ReadConsistencyLevelOne, 2, nil, nil, nil)
+	require.NotEqual(t, first[0].Host().ID(), second[0].Host().ID())
+}
+
+func TestHedgedReadsSplitQueuesPrefersIsolationGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSessionTestOptions().
+		SetReadIsolationGroup("local").
+		SetReadIsolationGroupFallbackTimeout(time.Second)
+	topoWatch, err := opts.TopologyInitializer().Init()
+	require.NoError(t, err)
+	topoMap := topoWatch.Get()
+
+	var queues []hostQueue
+	for i, host := range topoMap.Hosts() {
+		group := "remote"
+		if i == len(topoMap.Hosts())-1 {
+			group = "local"
+		}
+		hq := NewMockhostQueue(ctrl)
+		hq.EXPECT().Host().Return(topology.NewHostWithIsolationGroup(
+			host.ID(), host.Address(), group)).AnyTimes()
+		queues = append(queues, hq)
+	}
+
+	hedge := newHedgedReads(opts, tally.NoopScope)
+	require.Equal(t, time.Second, hedge.delay())
+
+	for i := 0; i < len(queues); i++ {
+		_, primary, hedges := hedge.splitQueues(queues, topoMap,
+			topology.ReadConsistencyLevelOne, 2, nil, nil, nil)
+		require.Equal(t, 1, len(primary))
+		require.Equal(t, "local", primary[0].Host().IsolationGroup())
+		require.Equal(t, len(queues)-1, len(hedges))
+
+		_, primary, hedges = hedge.splitQueues(queues, topoMap,
+			topology.ReadConsistencyLevelUnstrictMajority, 2, nil, nil, nil)
+		require.Equal(t, 2, len(primary))
+		require.Equal(t, "local", primary[0].Host().IsolationGroup())
+		require.Equal(t, "remote", primary[1].Host().IsolationGroup())
+		require.Equal(t, len(queues)-2, len(hedges))
+	}
+
+	// Without hedged reads levels that need a majority read from all hosts.
+	_, primary, hedges := hedge.splitQueues(queues, topoMap,
+		topology.ReadConsistencyLevelMajority, 2, nil, nil, nil)
+	require.Equal(t, len(queues), len(primary))
+	require.Equal(t, 0, len(hedges))
+}
+
+func TestHedgedReadsDelay(t *testing.T) {
+	opts := newSessionTestOptions().
+		SetHedgedReadsDelayPercentile(0.9).
+		SetHedgedReadsMinDelay(time.Millisecond)
+	hedge := newHedgedReads(opts, tally.NoopScope)
+	require.Equal(t, time.Millisecond, hedge.delay())
+
+	for i := 1; i <= 2*hedgedReadsRecomputeEvery; i++ {
+		hedge.recordLatency(time.Duration(i) * time.Millisecond)
+	}
+	require.Equal(t, 116*time.Millisecond, hedge.delay())
+}
+
+func TestFetchStateHedgesOnHostError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSessionTestOptions().SetHedgedReadsMinDelay(time.Hour)
+	topoMap, queues := newHedgedReadsTestQueues(t, ctrl, opts)
+	scope := tally.NewTestScope("", nil)
+	hedge := newHedgedReads(opts, scope)
+
+	p := newFetchStatePool(pool.NewObjectPoolOptions().SetSize(1))
+	p.Init()
+	f := p.Get()
+	f.incRef()
+	f.Reset(time.Time{}, time.Time{}, newFetchTaggedOp(nil), topoMap, 2,
+		topology.ReadConsistencyLevelOne)
+
+	f.Lock()
+	primary := f.splitQueuesForHedgingWithLock(hedge, queues, topoMap, 2,
+		topology.ReadConsistencyLevelOne)
+	require.Equal(t, 1, len(primary))
+	hedges := append([]hostQueue(nil), f.hedgeQueues...)
+	require.Equal(t, 2, len(hedges))
+	f.scheduleHedgeWithLock()
+	f.Unlock()
+
+	for _, hq := range hedges {
+		hq.(*MockhostQueue).EXPECT().Enqueue(gomock.Any()).Return(nil)
+	}
+
+	// The primary host failing sends the hedged requests without waiting for
+	// the hedge delay.
+	f.incRef()
+	f.completionFn(fetchTaggedResultAccumulatorOpts{
+		host: primary[0].Host(),
+	}, errors.New("timed out"))
+
+	f.Lock()
+	require.False(t, f.done)
+	f.Unlock()
+
+	for _, hq := range hedges {
+		f.completionFn(fetchTaggedResultAccumulatorOpts{
+			host:     hq.Host(),
+			response: &rpc.FetchTaggedResult_{Exhaustive: true},
+		}, nil)
+	}
+
+	f.Lock()
+	require.True(t, f.done)
+	require.NoError(t, f.err)
+	f.Unlock()
+
+	counters := scope.Snapshot().Counters()
+	require.Equal(t, int64(2), counters["sent+"].Value())
+	require.Equal(t, int64(1), counters["won+"].Value())
+
+	f.decRef()
+}
+
+func TestFetchStateCancelsRequestsOnceDone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSessionTestOptions().
+		SetHedgedReadsEnabled(true).
+		SetHedgedReadsMinDelay(time.Hour)
+	topoMap, queues := newHedgedReadsTestQueues(t, ctrl, opts)
+	hedge := newHedgedReads(opts, tally.NoopScope)
+	now := time.Now()
+	hedge.nowFn = func() time.Time { return now }
+
+	op := newFetchTaggedOp(nil)
+	op.update(rpc.FetchTaggedRequest{}, nil)
+
+	p := newFetchStatePool(pool.NewObjectPoolOptions().SetSize(1))
+	p.Init()
+	f := p.Get()
+	f.incRef()
+	f.Reset(time.Time{}, time.Time{}, op, topoMap, 2,
+		topology.ReadConsistencyLevelOne)
+
+	f.Lock()
+	f.splitQueuesForHedgingWithLock(hedge, queues, topoMap, 2,
+		topology.ReadConsistencyLevelOne)
+	f.Unlock()
+
+	// Every host responds, the latency of each is measured from when the
+	// request was sent to it including those that respond once done.
+	var expected []time.Duration
+	for i, hq := range queues {
+		latency := time.Duration(i+1) * time.Second
+		expected = append(expected, latency)
+		f.incRef()
+		f.completionFn(fetchTaggedResultAccumulatorOpts{
+			host:     hq.Host(),
+			sentAt:   now.Add(-latency),
+			response: &rpc.FetchTaggedResult_{Exhaustive: true},
+		}, nil)
+	}
+
+	f.Lock()
+	require.True(t, f.done)
+	require.NoError(t, f.err)
+	f.Unlock()
+
+	// The requests to hosts yet to respond are cancelled once done.
+	require.True(t, op.isCancelled())
+
+	hedge.Lock()
+	require.Equal(t, expected, hedge.latencies)
+	hedge.Unlock()
+
+	f.decRef()
+}
+
+func TestSessionFetchIDsPrefersIsolationGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSessionTestOptions().
+		SetReadConsistencyLevel(topology.ReadConsistencyLevelOne).
+		SetReadIsolationGroup("local").
+		SetReadIsolationGroupFallbackTimeout(time.Hour)
+	s, err := newSession(opts)
+	require.NoError(t, err)
+	session := s.(*session)
+
+	type enqueued struct {
+		host topology.Host
+		op   *fetchBatchOp
+	}
+	enqueuedCh := make(chan enqueued, sessionTestReplicas)
+	session.newHostQueueFn = func(
+		host topology.Host,
+		opts hostQueueOpts,
+	) (hostQueue, error) {
+		group := "remote"
+		if host.ID() == testHostName(sessionTestReplicas-1) {
+			group = "local"
+		}
+		host = topology.NewHostWithIsolationGroup(host.ID(), host.Address(), group)
+		hq := NewMockhostQueue(ctrl)
+		hq.EXPECT().Open()
+		hq.EXPECT().Host().Return(host).AnyTimes()
+		hq.EXPECT().ConnectionCount().Return(opts.opts.MinConnectionCount()).AnyTimes()
+		hq.EXPECT().Enqueue(gomock.Any()).Do(func(op op) error {
+			enqueuedCh <- enqueued{host: host, op: op.(*fetchBatchOp)}
+			return nil
+		}).Return(nil).AnyTimes()
+		hq.EXPECT().Close()
+		return hq, nil
+	}
+	require.NoError(t, session.Open())
+
+	start := time.Now().Truncate(time.Hour)
+	end := start.Add(2 * time.Hour)
+	fetches := testFetches([]testFetch{
+		{"foo", []testValue{
+			{1.0, start.Add(1 * time.Second), xtime.Second, nil},
+		}},
+	})
+
+	var (
+		results encoding.SeriesIterators
+		wg      sync.WaitGroup
+	)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		results, err = session.FetchIDs(ident.StringID(testNamespaceName),
+			fetches.IDsIter(), start, end)
+	}()
+
+	// The fetch is only sent to the replica in the local isolation group.
+	local := <-enqueuedCh
+	require.Equal(t, "local", local.host.IsolationGroup())
+	select {
+	case e := <-enqueuedCh:
+		require.FailNow(t, "unexpected fetch sent", e.host.ID())
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Once it fails the fetch falls back to the remote replicas.
+	local.op.completeAll(nil, errors.New("an error"))
+	for i := 0; i < sessionTestReplicas-1; i++ {
+		remote := <-enqueuedCh
+		require.Equal(t, "remote", remote.host.IsolationGroup())
+		fulfillTszFetchBatchOps(t, fetches, []*fetchBatchOp{remote.op}, 0)
+	}
+
+	wg.Wait()
+	require.NoError(t, err)
+	assertFetchResults(t, start, end, fetches, results)
+
+	require.NoError(t, session.Close())
+}
@@ -57,6 +57,7 @@ type queue struct {
 	opsLastRotatedAt                           time.Time
 	opsArrayPool                               *opArrayPool
 	drainIn                                    chan []op
+	breaker                                    *hostCircuitBreaker
 	status                                     status
 }
 
@@ -116,6 +117,7 @@ func newHostQueue(
 		ops:          opArrayPool.Get(),
 		opsArrayPool: opArrayPool,
 		drainIn:      make(chan []op, opsArraysLen),
+		breaker:      newHostCircuitBreaker(opts, scope.SubScope("circuit-breaker")),
 	}, nil
 }
 
@@ -259,6 +261,10 @@ func (q *queue) drain() {
 				q.asyncFetchTagged(v)
 			case *truncateOp:
 				q.asyncTruncate(v)
+			case *cardinalityOp:
+				q.asyncCardinality(v)
+			case *queryCostOp:
+				q.asyncQueryCost(v)
 			default:
 				completionFn := ops[i].CompletionFn()
 				completionFn(nil, errQueueUnknownOperation(q.host.ID()))
@@ -330,8 +336,16 @@ func (q *queue) asyncTaggedWrite(
 			return
 		}
 
+		if !q.breaker.allow() {
+			callAllCompletionFns(ops, q.host, errQueueCircuitBreakerOpen(q.host.ID()))
+			cleanup()
+			return
+		}
+
+		start := q.nowFn()
 		ctx, _ := thrift.NewContext(q.opts.WriteRequestTimeout())
 		err = client.WriteTaggedBatchRaw(ctx, req)
+		q.breaker.record(start, err)
 		if err == nil {
 			// All succeeded
 			callAllCompletionFns(ops, q.host, nil)
@@ -394,8 +408,16 @@ func (q *queue) asyncWrite(
 			return
 		}
 
+		if !q.breaker.allow() {
+			callAllCompletionFns(ops, q.host, errQueueCircuitBreakerOpen(q.host.ID()))
+			cleanup()
+			return
+		}
+
+		start := q.nowFn()
 		ctx, _ := thrift.NewContext(q.opts.WriteRequestTimeout())
 		err = client.WriteBatchRaw(ctx, req)
+		q.breaker.record(start, err)
 		if err == nil {
 			// All succeeded
 			callAllCompletionFns(ops, q.host, nil)
@@ -446,8 +468,16 @@ func (q *queue) asyncFetch(op *fetchBatchOp) {
 			return
 		}
 
+		if !q.breaker.allow() {
+			op.completeAll(nil, errQueueCircuitBreakerOpen(q.host.ID()))
+			cleanup()
+			return
+		}
+
+		start := q.nowFn()
 		ctx, _ := thrift.NewContext(q.opts.FetchRequestTimeout())
 		result, err := client.FetchBatchRaw(ctx, &op.request)
+		q.breaker.record(start, err)
 		if err != nil {
 			op.completeAll(nil, err)
 			cleanup()
@@ -489,22 +519,92 @@ func (q *queue) asyncFetchTagged(op *fetchTaggedOp) {
 			return
 		}
 
-		ctx, _ := thrift.NewContext(q.opts.FetchRequestTimeout())
+		if op.isCancelled() {
+			// The fetch completed before the request was sent to this host.
+			op.CompletionFn()(fetchTaggedResultAccumulatorOpts{host: q.host},
+				errFetchTaggedOpCancelled)
+			cleanup()
+			return
+		}
+
+		if !q.breaker.allow() {
+			op.CompletionFn()(fetchTaggedResultAccumulatorOpts{host: q.host},
+				errQueueCircuitBreakerOpen(q.host.ID()))
+			cleanup()
+			return
+		}
+
+		if op.pageSize > 0 {
+			q.fetchTaggedPage(client, op)
+			cleanup()
+			return
+		}
+
+		start := q.nowFn()
+		ctx, cancel := op.newContext(q.opts.FetchRequestTimeout())
 		result, err := client.FetchTagged(ctx, &op.request)
+		cancel()
+		err = q.recordFetchTagged(op, start, err)
 		if err != nil {
-			op.CompletionFn()(fetchTaggedResultAccumulatorOpts{host: q.host}, err)
+			op.CompletionFn()(fetchTaggedResultAccumulatorOpts{
+				host:   q.host,
+				sentAt: start,
+			}, err)
 			cleanup()
 			return
 		}
 
 		op.CompletionFn()(fetchTaggedResultAccumulatorOpts{
 			host:     q.host,
+			sentAt:   start,
 			response: result,
 		}, err)
 		cleanup()
 	})
 }
 
+// recordFetchTagged records the result of a fetch tagged request with the
+// circuit breaker and returns the error to complete the op with, requests
+// cancelled because the fetch completed are not failures of the host so
+// are released from the circuit breaker without recording them.
+func (q *queue) recordFetchTagged(op *fetchTaggedOp, start time.Time, err error) error {
+	if err != nil && op.isCancelled() {
+		q.breaker.release()
+		return errFetchTaggedOpCancelled
+	}
+	q.breaker.record(start, err)
+	return err
+}
+
+func (q *queue) fetchTaggedPage(client rpc.TChanNode, op *fetchTaggedOp) {
+	start := q.nowFn()
+	ctx, cancel := op.newContext(q.opts.FetchRequestTimeout())
+	result, err := client.FetchTaggedPage(ctx, op.pageRequest())
+	cancel()
+	err = q.recordFetchTagged(op, start, err)
+	if err != nil {
+		op.CompletionFn()(fetchTaggedResultAccumulatorOpts{
+			host:   q.host,
+			sentAt: start,
+			page:   true,
+		}, err)
+		return
+	}
+
+	// Each host returns its own page, the accumulator only keeps the series
+	// every host has returned all results up to.
+	op.CompletionFn()(fetchTaggedResultAccumulatorOpts{
+		host:   q.host,
+		sentAt: start,
+		response: &rpc.FetchTaggedResult_{
+			Elements:   result.Elements,
+			Exhaustive: true,
+		},
+		page:          true,
+		nextPageToken: result.NextPageToken,
+	}, nil)
+}
+
 func (q *queue) asyncTruncate(op *truncateOp) {
 	q.Add(1)
 
@@ -530,6 +630,56 @@ func (q *queue) asyncTruncate(op *truncateOp) {
 	})
 }
 
+func (q *queue) asyncCardinality(op *cardinalityOp) {
+	q.Add(1)
+
+	q.workerPool.Go(func() {
+		cleanup := q.Done
+
+		client, err := q.connPool.NextClient()
+		if err != nil {
+			// No client available
+			op.completionFn(nil, err)
+			cleanup()
+			return
+		}
+
+		ctx, _ := thrift.NewContext(q.opts.FetchRequestTimeout())
+		if res, err := client.Cardinality(ctx, &op.request); err != nil {
+			op.completionFn(nil, err)
+		} else {
+			op.completionFn(res, nil)
+		}
+
+		cleanup()
+	})
+}
+
+func (q *queue) asyncQueryCost(op *queryCostOp) {
+	q.Add(1)
+
+	q.workerPool.Go(func() {
+		cleanup := q.Done
+
+		client, err := q.connPool.NextClient()
+		if err != nil {
+			// No client available
+			op.completionFn(nil, err)
+			cleanup()
+			return
+		}
+
+		ctx, _ := thrift.NewContext(q.opts.FetchRequestTimeout())
+		if res, err := client.QueryCost(ctx, &op.request); err != nil {
+			op.completionFn(nil, err)
+		} else {
+			op.completionFn(res, nil)
+		}
+
+		cleanup()
+	})
+}
+
 func (q *queue) Len() int {
 	q.RLock()
 	v := q.opsSumSize
@@ -631,6 +781,10 @@ func errQueueUnknownOperation(hostID string) error {
 	return fmt.Errorf("host operation queue received unknown operation for host: %s", hostID)
 }
 
+func errQueueCircuitBreakerOpen(hostID string) error {
+	return fmt.Errorf("host operation queue circuit breaker open for host: %s", hostID)
+}
+
 func errQueueFetchNoResponse(hostID string) error {
 	return fmt.Errorf("host operation queue did not receive response for given fetch for host: %s", hostID)
 }
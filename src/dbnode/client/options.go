@@ -30,6 +30,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/clock"
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/encoding/proto"
 	m3dbruntime "github.com/m3db/m3/src/dbnode/runtime"
 	"github.com/m3db/m3/src/dbnode/topology"
 	"github.com/m3db/m3/src/x/serialize"
@@ -79,6 +80,49 @@ const (
 	// defaultTruncateRequestTimeout is the default truncate request timeout
 	defaultTruncateRequestTimeout = 60 * time.Second
 
+	// defaultHedgedReadsDelayPercentile is the default percentile of host
+	// response latencies to wait for before sending hedged reads
+	defaultHedgedReadsDelayPercentile = 0.95
+
+	// defaultHedgedReadsMinDelay is the default minimum delay before sending
+	// hedged reads
+	defaultHedgedReadsMinDelay = 10 * time.Millisecond
+
+	// defaultReadIsolationGroupFallbackTimeout is the default time to wait for
+	// replicas in the read isolation group before reading from other replicas
+	defaultReadIsolationGroupFallbackTimeout = 100 * time.Millisecond
+
+	// defaultReadYourWritesWindow is the default time writes are tracked for
+	// in read your writes sessions
+	defaultReadYourWritesWindow = 30 * time.Second
+
+	// defaultWriteSpoolMaxBytes is the default maximum bytes of writes spooled
+	// to disk
+	defaultWriteSpoolMaxBytes = 256 * 1024 * 1024
+
+	// defaultWriteSpoolReplayInterval is the default interval spooled writes
+	// are replayed at
+	defaultWriteSpoolReplayInterval = 10 * time.Second
+
+	// defaultWriteSpoolBufferPast is the default buffer past spooled writes
+	// are dropped after, matching the default namespace buffer past
+	defaultWriteSpoolBufferPast = 10 * time.Minute
+
+	// defaultHostCircuitBreakerFailureThreshold is the default number of
+	// consecutive failed requests to a host that open its circuit breaker
+	defaultHostCircuitBreakerFailureThreshold = 5
+
+	// defaultTransport is the default transport used to send requests to hosts
+	defaultTransport = TransportTChannel
+
+	// defaultHostCircuitBreakerOpenDuration is the default time a host circuit
+	// breaker stays open before letting a probe request through
+	defaultHostCircuitBreakerOpenDuration = 5 * time.Second
+
+	// defaultAsyncWriteMaxInFlightBytes is the default maximum bytes of async
+	// writes in flight
+	defaultAsyncWriteMaxInFlightBytes = 64 * 1024 * 1024
+
 	// defaultIdentifierPoolSize is the default identifier pool size
 	defaultIdentifierPoolSize = 8192
 
@@ -180,6 +224,17 @@ var (
 
 	errNoTopologyInitializerSet    = errors.New("no topology initializer set")
 	errNoReaderIteratorAllocateSet = errors.New("no reader iterator allocator set, encoding not set")
+	errHedgedReadsDelayPercentile  = errors.New("hedged reads delay percentile must be greater than 0 and at most 1")
+	errHedgedReadsMinDelayNegative = errors.New("hedged reads min delay must not be negative")
+	errAsyncWriteMaxInFlightBytes  = errors.New("async write max in flight bytes must be positive")
+	errReadIsolationGroupFallback  = errors.New("read isolation group fallback timeout must be positive")
+	errReadYourWritesWindow        = errors.New("read your writes window must be positive")
+	errWriteSpoolMaxBytes          = errors.New("write spool max bytes must be positive")
+	errWriteSpoolReplayInterval    = errors.New("write spool replay interval must be positive")
+	errWriteSpoolBufferPast        = errors.New("write spool buffer past must be positive")
+	errCircuitBreakerFailures      = errors.New("host circuit breaker failure threshold must be positive")
+	errCircuitBreakerSlowRequest   = errors.New("host circuit breaker slow request threshold must not be negative")
+	errCircuitBreakerOpenDuration  = errors.New("host circuit breaker open duration must be positive")
 )
 
 type options struct {
@@ -191,6 +246,7 @@ type options struct {
 	writeConsistencyLevel                   topology.ConsistencyLevel
 	bootstrapConsistencyLevel               topology.ReadConsistencyLevel
 	channelOptions                          *tchannel.ChannelOptions
+	transport                               Transport
 	maxConnectionCount                      int
 	minConnectionCount                      int
 	hostConnectTimeout                      time.Duration
@@ -211,8 +267,26 @@ type options struct {
 	tagDecoderPoolSize                      int
 	writeRetrier                            xretry.Retrier
 	fetchRetrier                            xretry.Retrier
+	hedgedReadsEnabled                      bool
+	hedgedReadsDelayPercentile              float64
+	hedgedReadsMinDelay                     time.Duration
+	readIsolationGroup                      string
+	readIsolationGroupFallbackTimeout       time.Duration
+	readYourWritesEnabled                   bool
+	readYourWritesWindow                    time.Duration
+	writeSpoolPath                          string
+	writeSpoolMaxBytes                      int64
+	writeSpoolReplayInterval                time.Duration
+	writeSpoolBufferPast                    time.Duration
+	rejectUnboundedTagQueries               bool
+	hostCircuitBreakerEnabled               bool
+	hostCircuitBreakerFailureThreshold      int
+	hostCircuitBreakerSlowRequestThreshold  time.Duration
+	hostCircuitBreakerOpenDuration          time.Duration
+	asyncWriteMaxInFlightBytes              int64
 	streamBlocksRetrier                     xretry.Retrier
 	readerIteratorAllocate                  encoding.ReaderIteratorAllocate
+	namespaceReaderIteratorAllocates        map[string]encoding.ReaderIteratorAllocate
 	writeOperationPoolSize                  int
 	writeTaggedOperationPoolSize            int
 	fetchBatchOpPoolSize                    int
@@ -287,6 +361,17 @@ func newOptions() *options {
 		backgroundHealthCheckFailThrottleFactor: defaultBackgroundHealthCheckFailThrottleFactor,
 		writeRetrier:                            defaultWriteRetrier,
 		fetchRetrier:                            defaultFetchRetrier,
+		hedgedReadsDelayPercentile:              defaultHedgedReadsDelayPercentile,
+		hedgedReadsMinDelay:                     defaultHedgedReadsMinDelay,
+		readIsolationGroupFallbackTimeout:       defaultReadIsolationGroupFallbackTimeout,
+		readYourWritesWindow:                    defaultReadYourWritesWindow,
+		writeSpoolMaxBytes:                      defaultWriteSpoolMaxBytes,
+		writeSpoolReplayInterval:                defaultWriteSpoolReplayInterval,
+		writeSpoolBufferPast:                    defaultWriteSpoolBufferPast,
+		hostCircuitBreakerFailureThreshold:      defaultHostCircuitBreakerFailureThreshold,
+		hostCircuitBreakerOpenDuration:          defaultHostCircuitBreakerOpenDuration,
+		transport:                               defaultTransport,
+		asyncWriteMaxInFlightBytes:              defaultAsyncWriteMaxInFlightBytes,
 		tagEncoderPoolSize:                      defaultTagEncoderPoolSize,
 		tagEncoderOpts:                          serialize.NewTagEncoderOptions(),
 		tagDecoderPoolSize:                      defaultTagDecoderPoolSize,
@@ -336,6 +421,39 @@ func (o *options) Validate() error {
 	); err != nil {
 		return err
 	}
+	if o.hedgedReadsDelayPercentile <= 0 || o.hedgedReadsDelayPercentile > 1 {
+		return errHedgedReadsDelayPercentile
+	}
+	if o.hedgedReadsMinDelay < 0 {
+		return errHedgedReadsMinDelayNegative
+	}
+	if o.readIsolationGroupFallbackTimeout <= 0 {
+		return errReadIsolationGroupFallback
+	}
+	if o.readYourWritesWindow <= 0 {
+		return errReadYourWritesWindow
+	}
+	if o.writeSpoolMaxBytes <= 0 {
+		return errWriteSpoolMaxBytes
+	}
+	if o.writeSpoolReplayInterval <= 0 {
+		return errWriteSpoolReplayInterval
+	}
+	if o.writeSpoolBufferPast <= 0 {
+		return errWriteSpoolBufferPast
+	}
+	if o.hostCircuitBreakerFailureThreshold <= 0 {
+		return errCircuitBreakerFailures
+	}
+	if o.hostCircuitBreakerSlowRequestThreshold < 0 {
+		return errCircuitBreakerSlowRequest
+	}
+	if o.hostCircuitBreakerOpenDuration <= 0 {
+		return errCircuitBreakerOpenDuration
+	}
+	if o.asyncWriteMaxInFlightBytes <= 0 {
+		return errAsyncWriteMaxInFlightBytes
+	}
 	return topology.ValidateConnectConsistencyLevel(
 		o.clusterConnectConsistencyLevel,
 	)
@@ -349,6 +467,24 @@ func (o *options) SetEncodingM3TSZ() Options {
 	return &opts
 }
 
+func (o *options) SetEncodingProto(namespace ident.ID, schema *proto.Schema) Options {
+	opts := *o
+	allocates := make(map[string]encoding.ReaderIteratorAllocate,
+		len(o.namespaceReaderIteratorAllocates)+1)
+	for ns, allocate := range o.namespaceReaderIteratorAllocates {
+		allocates[ns] = allocate
+	}
+	allocates[namespace.String()] = func(r io.Reader) encoding.ReaderIterator {
+		return proto.NewReaderIterator(r, schema, encoding.NewOptions())
+	}
+	opts.namespaceReaderIteratorAllocates = allocates
+	return &opts
+}
+
+func (o *options) NamespaceReaderIteratorAllocates() map[string]encoding.ReaderIteratorAllocate {
+	return o.namespaceReaderIteratorAllocates
+}
+
 func (o *options) SetRuntimeOptionsManager(value m3dbruntime.OptionsManager) Options {
 	opts := *o
 	opts.runtimeOptsMgr = value
@@ -429,6 +565,16 @@ func (o *options) ChannelOptions() *tchannel.ChannelOptions {
 	return o.channelOptions
 }
 
+func (o *options) SetTransport(value Transport) Options {
+	opts := *o
+	opts.transport = value
+	return &opts
+}
+
+func (o *options) Transport() Transport {
+	return o.transport
+}
+
 func (o *options) SetMaxConnectionCount(value int) Options {
 	opts := *o
 	opts.maxConnectionCount = value
@@ -589,6 +735,176 @@ func (o *options) FetchRetrier() xretry.Retrier {
 	return o.fetchRetrier
 }
 
+func (o *options) SetHedgedReadsEnabled(value bool) Options {
+	opts := *o
+	opts.hedgedReadsEnabled = value
+	return &opts
+}
+
+func (o *options) HedgedReadsEnabled() bool {
+	return o.hedgedReadsEnabled
+}
+
+func (o *options) SetHedgedReadsDelayPercentile(value float64) Options {
+	opts := *o
+	opts.hedgedReadsDelayPercentile = value
+	return &opts
+}
+
+func (o *options) HedgedReadsDelayPercentile() float64 {
+	return o.hedgedReadsDelayPercentile
+}
+
+func (o *options) SetHedgedReadsMinDelay(value time.Duration) Options {
+	opts := *o
+	opts.hedgedReadsMinDelay = value
+	return &opts
+}
+
+func (o *options) HedgedReadsMinDelay() time.Duration {
+	return o.hedgedReadsMinDelay
+}
+
+func (o *options) SetReadIsolationGroup(value string) Options {
+	opts := *o
+	opts.readIsolationGroup = value
+	return &opts
+}
+
+func (o *options) ReadIsolationGroup() string {
+	return o.readIsolationGroup
+}
+
+func (o *options) SetReadIsolationGroupFallbackTimeout(value time.Duration) Options {
+	opts := *o
+	opts.readIsolationGroupFallbackTimeout = value
+	return &opts
+}
+
+func (o *options) ReadIsolationGroupFallbackTimeout() time.Duration {
+	return o.readIsolationGroupFallbackTimeout
+}
+
+func (o *options) SetReadYourWritesEnabled(value bool) Options {
+	opts := *o
+	opts.readYourWritesEnabled = value
+	return &opts
+}
+
+func (o *options) ReadYourWritesEnabled() bool {
+	return o.readYourWritesEnabled
+}
+
+func (o *options) SetReadYourWritesWindow(value time.Duration) Options {
+	opts := *o
+	opts.readYourWritesWindow = value
+	return &opts
+}
+
+func (o *options) ReadYourWritesWindow() time.Duration {
+	return o.readYourWritesWindow
+}
+
+func (o *options) SetWriteSpoolPath(value string) Options {
+	opts := *o
+	opts.writeSpoolPath = value
+	return &opts
+}
+
+func (o *options) WriteSpoolPath() string {
+	return o.writeSpoolPath
+}
+
+func (o *options) SetWriteSpoolMaxBytes(value int64) Options {
+	opts := *o
+	opts.writeSpoolMaxBytes = value
+	return &opts
+}
+
+func (o *options) WriteSpoolMaxBytes() int64 {
+	return o.writeSpoolMaxBytes
+}
+
+func (o *options) SetWriteSpoolReplayInterval(value time.Duration) Options {
+	opts := *o
+	opts.writeSpoolReplayInterval = value
+	return &opts
+}
+
+func (o *options) WriteSpoolReplayInterval() time.Duration {
+	return o.writeSpoolReplayInterval
+}
+
+func (o *options) SetWriteSpoolBufferPast(value time.Duration) Options {
+	opts := *o
+	opts.writeSpoolBufferPast = value
+	return &opts
+}
+
+func (o *options) WriteSpoolBufferPast() time.Duration {
+	return o.writeSpoolBufferPast
+}
+
+func (o *options) SetRejectUnboundedTagQueries(value bool) Options {
+	opts := *o
+	opts.rejectUnboundedTagQueries = value
+	return &opts
+}
+
+func (o *options) RejectUnboundedTagQueries() bool {
+	return o.rejectUnboundedTagQueries
+}
+
+func (o *options) SetHostCircuitBreakerEnabled(value bool) Options {
+	opts := *o
+	opts.hostCircuitBreakerEnabled = value
+	return &opts
+}
+
+func (o *options) HostCircuitBreakerEnabled() bool {
+	return o.hostCircuitBreakerEnabled
+}
+
+func (o *options) SetHostCircuitBreakerFailureThreshold(value int) Options {
+	opts := *o
+	opts.hostCircuitBreakerFailureThreshold = value
+	return &opts
+}
+
+func (o *options) HostCircuitBreakerFailureThreshold() int {
+	return o.hostCircuitBreakerFailureThreshold
+}
+
+func (o *options) SetHostCircuitBreakerSlowRequestThreshold(value time.Duration) Options {
+	opts := *o
+	opts.hostCircuitBreakerSlowRequestThreshold = value
+	return &opts
+}
+
+func (o *options) HostCircuitBreakerSlowRequestThreshold() time.Duration {
+	return o.hostCircuitBreakerSlowRequestThreshold
+}
+
+func (o *options) SetHostCircuitBreakerOpenDuration(value time.Duration) Options {
+	opts := *o
+	opts.hostCircuitBreakerOpenDuration = value
+	return &opts
+}
+
+func (o *options) HostCircuitBreakerOpenDuration() time.Duration {
+	return o.hostCircuitBreakerOpenDuration
+}
+
+func (o *options) SetAsyncWriteMaxInFlightBytes(value int64) Options {
+	opts := *o
+	opts.asyncWriteMaxInFlightBytes = value
+	return &opts
+}
+
+func (o *options) AsyncWriteMaxInFlightBytes() int64 {
+	return o.asyncWriteMaxInFlightBytes
+}
+
 func (o *options) SetTagEncoderOptions(value serialize.TagEncoderOptions) Options {
 	opts := *o
 	opts.tagEncoderOpts = value
@@ -0,0 +1,124 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"sort"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+)
+
+type queryCostOp struct {
+	request      rpc.QueryCostRequest
+	completionFn completionFn
+}
+
+func (c *queryCostOp) Size() int {
+	// Query cost is always a single op
+	return 1
+}
+
+func (c *queryCostOp) CompletionFn() completionFn {
+	return c.completionFn
+}
+
+type queryCostBlockAccumulator struct {
+	blockStart time.Time
+	postings   []int64
+}
+
+// queryCostAccumulator merges the query cost estimates returned by each host.
+// Every series is held by each of its replicas so the postings of each term
+// are summed and then divided by the replication factor. When only some of
+// the hosts respond the postings are scaled up by the hosts that did not
+// respond as each host holds a similar share of the series.
+type queryCostAccumulator struct {
+	terms     []index.QueryTerm
+	replicas  int
+	hosts     int
+	responded int
+	blocks    map[time.Time]*queryCostBlockAccumulator
+}
+
+func newQueryCostAccumulator(
+	terms []index.QueryTerm,
+	replicas int,
+	hosts int,
+) *queryCostAccumulator {
+	if replicas < 1 {
+		replicas = 1
+	}
+	return &queryCostAccumulator{
+		terms:    terms,
+		replicas: replicas,
+		hosts:    hosts,
+		blocks:   make(map[time.Time]*queryCostBlockAccumulator),
+	}
+}
+
+func (a *queryCostAccumulator) add(results []index.QueryCostResult) {
+	a.responded++
+	for _, r := range results {
+		key := r.BlockStart.UTC()
+		b, ok := a.blocks[key]
+		if !ok {
+			b = &queryCostBlockAccumulator{
+				blockStart: r.BlockStart,
+				postings:   make([]int64, len(a.terms)),
+			}
+			a.blocks[key] = b
+		}
+
+		for i, t := range r.Terms {
+			if i < len(b.postings) {
+				b.postings[i] += t.Postings
+			}
+		}
+	}
+}
+
+func (a *queryCostAccumulator) results() []index.QueryCostResult {
+	hosts, responded := int64(a.hosts), int64(a.responded)
+	if responded < 1 || hosts < responded {
+		hosts, responded = 1, 1
+	}
+
+	results := make([]index.QueryCostResult, 0, len(a.blocks))
+	for _, b := range a.blocks {
+		terms := make([]index.QueryTermCost, 0, len(a.terms))
+		for i, t := range a.terms {
+			terms = append(terms, index.QueryTermCost{
+				Term:     t,
+				Postings: b.postings[i] * hosts / (responded * int64(a.replicas)),
+			})
+		}
+		results = append(results, index.QueryCostResult{
+			BlockStart: b.blockStart,
+			Terms:      terms,
+		})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].BlockStart.After(results[j].BlockStart)
+	})
+	return results
+}
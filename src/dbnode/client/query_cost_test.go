@@ -0,0 +1,203 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/m3ninx/idx"
+	"github.com/m3db/m3x/ident"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryCostAccumulatorMergesHosts(t *testing.T) {
+	var (
+		t0    = time.Unix(0, 0).Add(2 * time.Hour)
+		t1    = t0.Add(time.Hour)
+		terms = []index.QueryTerm{
+			{Type: index.QueryTermTypeTerm, Field: []byte("host"), Pattern: []byte("a")},
+			{Type: index.QueryTermTypeRegexp, Field: []byte("dc"), Pattern: []byte(".*"), Unanchored: true},
+		}
+	)
+
+	acc := newQueryCostAccumulator(terms, 2, 2)
+	acc.add([]index.QueryCostResult{
+		{
+			BlockStart: t0,
+			Terms: []index.QueryTermCost{
+				{Term: terms[0], Postings: 3},
+				{Term: terms[1], Postings: 10},
+			},
+		},
+	})
+	acc.add([]index.QueryCostResult{
+		{
+			BlockStart: t0,
+			Terms: []index.QueryTermCost{
+				{Term: terms[0], Postings: 5},
+				{Term: terms[1], Postings: 10},
+			},
+		},
+		{
+			BlockStart: t1,
+			Terms: []index.QueryTermCost{
+				{Term: terms[0], Postings: 4},
+				{Term: terms[1], Postings: 2},
+			},
+		},
+	})
+
+	results := acc.results()
+	require.Equal(t, 2, len(results))
+
+	require.Equal(t, t1, results[0].BlockStart)
+	require.Equal(t, []index.QueryTermCost{
+		{Term: terms[0], Postings: 2},
+		{Term: terms[1], Postings: 1},
+	}, results[0].Terms)
+
+	require.Equal(t, t0, results[1].BlockStart)
+	require.Equal(t, []index.QueryTermCost{
+		{Term: terms[0], Postings: 4},
+		{Term: terms[1], Postings: 10},
+	}, results[1].Terms)
+	require.Equal(t, int64(14), results[1].Postings())
+}
+
+func TestQueryCostAccumulatorScalesPartialResults(t *testing.T) {
+	var (
+		t0    = time.Unix(0, 0).Add(2 * time.Hour)
+		terms = []index.QueryTerm{
+			{Type: index.QueryTermTypeTerm, Field: []byte("host"), Pattern: []byte("a")},
+		}
+	)
+
+	// Only one of the six hosts responded.
+	acc := newQueryCostAccumulator(terms, 3, 6)
+	acc.add([]index.QueryCostResult{
+		{
+			BlockStart: t0,
+			Terms: []index.QueryTermCost{
+				{Term: terms[0], Postings: 5},
+			},
+		},
+	})
+
+	results := acc.results()
+	require.Equal(t, 1, len(results))
+	require.Equal(t, []index.QueryTermCost{
+		{Term: terms[0], Postings: 10},
+	}, results[0].Terms)
+}
+
+func TestSessionQueryCostPartialResults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSessionTestOptions()
+	s, err := newSession(opts)
+	assert.NoError(t, err)
+	session := s.(*session)
+
+	blockStart := time.Unix(0, 0).Add(2 * time.Hour)
+	mockHostQueues(ctrl, session, sessionTestReplicas, []testEnqueueFn{
+		func(idx int, op op) {
+			queryCost, ok := op.(*queryCostOp)
+			assert.True(t, ok)
+			if idx == 0 {
+				queryCost.completionFn(nil, errors.New("host unavailable"))
+				return
+			}
+			queryCost.completionFn(&rpc.QueryCostResult_{
+				Blocks: []*rpc.QueryCostBlock{
+					{BlockStart: blockStart.UnixNano(), TermPostings: []int64{6}},
+				},
+			}, nil)
+		},
+	})
+
+	assert.NoError(t, session.Open())
+
+	q := index.Query{idx.NewTermQuery([]byte("a"), []byte("b"))}
+	results, err := s.QueryCost(ident.StringID("metrics"), q, index.QueryCostOptions{
+		StartInclusive: blockStart,
+		EndExclusive:   blockStart.Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(results))
+	require.True(t, blockStart.Equal(results[0].BlockStart))
+	// Two of three hosts responded with 6 postings each at a replication
+	// factor of three.
+	require.Equal(t, int64(6), results[0].Postings())
+
+	assert.NoError(t, session.Close())
+}
+
+func TestSessionQueryCostAllHostsFail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSessionTestOptions()
+	s, err := newSession(opts)
+	assert.NoError(t, err)
+	session := s.(*session)
+
+	mockHostQueues(ctrl, session, sessionTestReplicas, []testEnqueueFn{
+		func(idx int, op op) {
+			queryCost, ok := op.(*queryCostOp)
+			assert.True(t, ok)
+			queryCost.completionFn(nil, errors.New("host unavailable"))
+		},
+	})
+
+	assert.NoError(t, session.Open())
+
+	now := time.Now()
+	q := index.Query{idx.NewTermQuery([]byte("a"), []byte("b"))}
+	_, err = s.QueryCost(ident.StringID("metrics"), q, index.QueryCostOptions{
+		StartInclusive: now.Add(-time.Hour),
+		EndExclusive:   now,
+	})
+	require.Error(t, err)
+
+	assert.NoError(t, session.Close())
+}
+
+func TestSessionQueryCostNotOpenError(t *testing.T) {
+	opts := newSessionTestOptions()
+	s, err := newSession(opts)
+	assert.NoError(t, err)
+
+	now := time.Now()
+	q := index.Query{idx.NewTermQuery([]byte("a"), []byte("b"))}
+	_, err = s.QueryCost(ident.StringID("metrics"), q, index.QueryCostOptions{
+		StartInclusive: now.Add(-time.Hour),
+		EndExclusive:   now,
+	})
+	assert.Equal(t, errSessionStatusNotOpen, err)
+}
@@ -0,0 +1,241 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/topology"
+	"github.com/m3db/m3x/ident"
+
+	"github.com/uber-go/tally"
+)
+
+type readYourWritesMetrics struct {
+	routed tally.Counter
+	bumped tally.Counter
+}
+
+func newReadYourWritesMetrics(scope tally.Scope) readYourWritesMetrics {
+	return readYourWritesMetrics{
+		routed: scope.Counter("routed"),
+		bumped: scope.Counter("bumped"),
+	}
+}
+
+// readYourWrites tracks, per namespace and shard, which replicas acknowledged
+// every write the session made within the window so that reads with a
+// consistency level weaker than majority can be routed to those replicas, or
+// bumped to a majority read when no single replica acknowledged every write.
+type readYourWrites struct {
+	sync.RWMutex
+
+	window  time.Duration
+	nowFn   clock.NowFn
+	metrics readYourWritesMetrics
+
+	namespaces map[string]*readYourWritesNamespace
+}
+
+// readYourWritesNamespace is locked separately from the other namespaces and
+// each of its shards separately from the other shards so that concurrent
+// writes only contend when they write to the same shard.
+type readYourWritesNamespace struct {
+	sync.RWMutex
+
+	// lastWrite is the time of the last write in unix nanoseconds, accessed
+	// atomically.
+	lastWrite int64
+	shards    map[uint32]*readYourWritesShard
+}
+
+type readYourWritesShard struct {
+	sync.Mutex
+
+	lastWrite time.Time
+	// acked is the set of hosts that acknowledged every write within the
+	// window, empty if no single host did.
+	acked map[string]struct{}
+}
+
+func newReadYourWrites(opts Options, scope tally.Scope) *readYourWrites {
+	return &readYourWrites{
+		window:     opts.ReadYourWritesWindow(),
+		nowFn:      opts.ClockOptions().NowFn(),
+		metrics:    newReadYourWritesMetrics(scope),
+		namespaces: make(map[string]*readYourWritesNamespace),
+	}
+}
+
+// recordWrite records the hosts that acknowledged a write to a shard by the
+// time the write completed, hosts that acknowledge it later are not counted.
+func (r *readYourWrites) recordWrite(
+	namespace ident.ID,
+	shard uint32,
+	acked []string,
+) {
+	if len(acked) == 0 {
+		// The write failed so there's nothing to read back.
+		return
+	}
+
+	now := r.nowFn()
+	ns := r.namespaceOrCreate(namespace)
+	atomic.StoreInt64(&ns.lastWrite, now.UnixNano())
+
+	s := ns.shardOrCreate(shard)
+	s.Lock()
+	if now.Sub(s.lastWrite) >= r.window {
+		// All previous writes are outside the window, start afresh.
+		for hostID := range s.acked {
+			delete(s.acked, hostID)
+		}
+		for _, hostID := range acked {
+			s.acked[hostID] = struct{}{}
+		}
+	} else {
+		for hostID := range s.acked {
+			if !containsHostID(acked, hostID) {
+				delete(s.acked, hostID)
+			}
+		}
+	}
+	s.lastWrite = now
+	s.Unlock()
+}
+
+// readHosts appends to hosts the hosts that acknowledged every write to the
+// shard within the window and returns whether there were any such writes, if
+// there were but no single host acknowledged all of them no hosts are
+// appended and reads need to be bumped to majority.
+func (r *readYourWrites) readHosts(
+	namespace ident.ID,
+	shard uint32,
+	hosts []string,
+) ([]string, bool) {
+	now := r.nowFn()
+	ns, ok := r.namespace(namespace)
+	if !ok {
+		return hosts, false
+	}
+	ns.RLock()
+	s, ok := ns.shards[shard]
+	ns.RUnlock()
+	if !ok {
+		return hosts, false
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	if now.Sub(s.lastWrite) >= r.window {
+		return hosts, false
+	}
+	for hostID := range s.acked {
+		hosts = append(hosts, hostID)
+	}
+	return hosts, true
+}
+
+// recentlyWritten returns whether the session wrote to the namespace within
+// the window.
+func (r *readYourWrites) recentlyWritten(namespace ident.ID) bool {
+	now := r.nowFn()
+	ns, ok := r.namespace(namespace)
+	if !ok {
+		return false
+	}
+	lastWrite := time.Unix(0, atomic.LoadInt64(&ns.lastWrite))
+	return now.Sub(lastWrite) < r.window
+}
+
+func (r *readYourWrites) namespace(
+	namespace ident.ID,
+) (*readYourWritesNamespace, bool) {
+	// NB: Indexing with the string conversion of the bytes directly does not
+	// allocate.
+	r.RLock()
+	ns, ok := r.namespaces[string(namespace.Bytes())]
+	r.RUnlock()
+	return ns, ok
+}
+
+func (r *readYourWrites) namespaceOrCreate(
+	namespace ident.ID,
+) *readYourWritesNamespace {
+	if ns, ok := r.namespace(namespace); ok {
+		return ns
+	}
+
+	r.Lock()
+	defer r.Unlock()
+	ns, ok := r.namespaces[string(namespace.Bytes())]
+	if !ok {
+		ns = &readYourWritesNamespace{
+			shards: make(map[uint32]*readYourWritesShard),
+		}
+		r.namespaces[namespace.String()] = ns
+	}
+	return ns
+}
+
+func (ns *readYourWritesNamespace) shardOrCreate(
+	shard uint32,
+) *readYourWritesShard {
+	ns.RLock()
+	s, ok := ns.shards[shard]
+	ns.RUnlock()
+	if ok {
+		return s
+	}
+
+	ns.Lock()
+	defer ns.Unlock()
+	s, ok = ns.shards[shard]
+	if !ok {
+		s = &readYourWritesShard{acked: make(map[string]struct{})}
+		ns.shards[shard] = s
+	}
+	return s
+}
+
+// readYourWritesWeakLevel returns whether a read at the consistency level
+// might not read from a replica that acknowledged a write.
+func readYourWritesWeakLevel(level topology.ReadConsistencyLevel) bool {
+	switch level {
+	case topology.ReadConsistencyLevelNone,
+		topology.ReadConsistencyLevelOne,
+		topology.ReadConsistencyLevelUnstrictMajority:
+		return true
+	}
+	return false
+}
+
+func containsHostID(hostIDs []string, hostID string) bool {
+	for _, id := range hostIDs {
+		if id == hostID {
+			return true
+		}
+	}
+	return false
+}
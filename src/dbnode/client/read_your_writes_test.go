@@ -0,0 +1,141 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/topology"
+	"github.com/m3db/m3x/ident"
+
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+)
+
+func newTestReadYourWrites(now *time.Time) *readYourWrites {
+	opts := newSessionTestOptions().
+		SetReadYourWritesEnabled(true).
+		SetReadYourWritesWindow(time.Minute)
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time {
+		return *now
+	}))
+	return newReadYourWrites(opts, tally.NoopScope)
+}
+
+func readYourWritesTestHosts(r *readYourWrites, ns ident.ID, shard uint32) ([]string, bool) {
+	hosts, recent := r.readHosts(ns, shard, nil)
+	sort.Strings(hosts)
+	return hosts, recent
+}
+
+func TestReadYourWritesTracksHostsThatAckedEveryWrite(t *testing.T) {
+	now := time.Now()
+	r := newTestReadYourWrites(&now)
+	ns := ident.StringID("testNs")
+
+	hosts, recent := readYourWritesTestHosts(r, ns, 0)
+	require.False(t, recent)
+	require.Equal(t, 0, len(hosts))
+	require.False(t, r.recentlyWritten(ns))
+
+	// Failed writes are not tracked.
+	r.recordWrite(ns, 0, nil)
+	require.False(t, r.recentlyWritten(ns))
+
+	r.recordWrite(ns, 0, []string{"a", "b"})
+	hosts, recent = readYourWritesTestHosts(r, ns, 0)
+	require.True(t, recent)
+	require.Equal(t, []string{"a", "b"}, hosts)
+	require.True(t, r.recentlyWritten(ns))
+
+	// Other shards are not affected.
+	_, recent = readYourWritesTestHosts(r, ns, 1)
+	require.False(t, recent)
+
+	// Only hosts that acked every write within the window are read from.
+	now = now.Add(time.Second)
+	r.recordWrite(ns, 0, []string{"b", "c"})
+	hosts, recent = readYourWritesTestHosts(r, ns, 0)
+	require.True(t, recent)
+	require.Equal(t, []string{"b"}, hosts)
+
+	// No single host acked every write so reads need a majority.
+	r.recordWrite(ns, 0, []string{"a", "c"})
+	hosts, recent = readYourWritesTestHosts(r, ns, 0)
+	require.True(t, recent)
+	require.Equal(t, 0, len(hosts))
+
+	// Once the writes are outside the window tracking starts afresh.
+	now = now.Add(time.Minute)
+	_, recent = readYourWritesTestHosts(r, ns, 0)
+	require.False(t, recent)
+	require.False(t, r.recentlyWritten(ns))
+
+	r.recordWrite(ns, 0, []string{"c"})
+	hosts, recent = readYourWritesTestHosts(r, ns, 0)
+	require.True(t, recent)
+	require.Equal(t, []string{"c"}, hosts)
+}
+
+func TestReadYourWritesConcurrentWrites(t *testing.T) {
+	now := time.Now()
+	r := newTestReadYourWrites(&now)
+
+	var (
+		namespaces = []ident.ID{ident.StringID("a"), ident.StringID("b")}
+		numShards  = 8
+		wg         sync.WaitGroup
+	)
+	for _, ns := range namespaces {
+		for shard := 0; shard < numShards; shard++ {
+			wg.Add(1)
+			go func(ns ident.ID, shard uint32) {
+				defer wg.Done()
+				for i := 0; i < 100; i++ {
+					r.recordWrite(ns, shard, []string{"a", "b"})
+					r.readHosts(ns, shard, nil)
+					r.recentlyWritten(ns)
+				}
+			}(ns, uint32(shard))
+		}
+	}
+	wg.Wait()
+
+	for _, ns := range namespaces {
+		require.True(t, r.recentlyWritten(ns))
+		for shard := 0; shard < numShards; shard++ {
+			hosts, recent := readYourWritesTestHosts(r, ns, uint32(shard))
+			require.True(t, recent)
+			require.Equal(t, []string{"a", "b"}, hosts)
+		}
+	}
+}
+
+func TestReadYourWritesWeakLevel(t *testing.T) {
+	require.True(t, readYourWritesWeakLevel(topology.ReadConsistencyLevelNone))
+	require.True(t, readYourWritesWeakLevel(topology.ReadConsistencyLevelOne))
+	require.True(t, readYourWritesWeakLevel(topology.ReadConsistencyLevelUnstrictMajority))
+	require.False(t, readYourWritesWeakLevel(topology.ReadConsistencyLevelMajority))
+	require.False(t, readYourWritesWeakLevel(topology.ReadConsistencyLevelAll))
+}
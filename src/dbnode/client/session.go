@@ -69,6 +69,10 @@ const (
 	blocksMetadataChannelInitialCapacity = 4096
 	gaugeReportInterval                  = 500 * time.Millisecond
 	blockMetadataChBufSize               = 4096
+
+	// unboundedTagQueryLogInterval is the minimum interval unbounded tag
+	// queries are logged at, the unbounded query counter counts every one
+	unboundedTagQueryLogInterval = time.Minute
 )
 
 type resultTypeEnum string
@@ -105,6 +109,15 @@ var (
 	// errUnableToEncodeTags is raised when the server is unable to encode provided tags
 	// to be sent over the wire.
 	errUnableToEncodeTags = errors.New("unable to include tags")
+	// errFetchNoAvailableReplicas is raised when every replica of a shard is
+	// initializing so there's no replica to read from.
+	errFetchNoAvailableReplicas = errors.New("fetch has no available replicas to read from")
+	// errFetchTaggedPageSize is raised when fetching tagged pages with a page size
+	// that is not positive
+	errFetchTaggedPageSize = errors.New("fetch tagged page size must be positive")
+	// errFetchTaggedUnboundedQuery is raised when fetching tagged with a query
+	// that has no positive term while unbounded tag queries are rejected
+	errFetchTaggedUnboundedQuery = errors.New("fetch tagged query has no positive term and would scan every series")
 )
 
 // sessionState is volatile state that is protected by a
@@ -150,6 +163,12 @@ type session struct {
 	streamBlocksMetadataBatchTimeout time.Duration
 	streamBlocksBatchTimeout         time.Duration
 	metrics                          sessionMetrics
+	hedgedReads                      *hedgedReads
+	readYourWrites                   *readYourWrites
+	writeSpool                       *writeSpool
+	asyncWrites                      *asyncWrites
+	asyncWriteResultFn               writeStateResultFn
+	unboundedTagQueryLoggedNanos     int64
 }
 
 type shardMetricsKey struct {
@@ -169,18 +188,22 @@ type sessionMetrics struct {
 	fetchNodesRespondingBadRequestErrors []tally.Counter
 	topologyUpdatedSuccess               tally.Counter
 	topologyUpdatedError                 tally.Counter
+	fetchTaggedUnboundedQuery            tally.Counter
+	queryCostPartialResults              tally.Counter
 	streamFromPeersMetrics               map[shardMetricsKey]streamFromPeersMetrics
 }
 
 func newSessionMetrics(scope tally.Scope) sessionMetrics {
 	return sessionMetrics{
-		writeSuccess:           scope.Counter("write.success"),
-		writeErrors:            scope.Counter("write.errors"),
-		fetchSuccess:           scope.Counter("fetch.success"),
-		fetchErrors:            scope.Counter("fetch.errors"),
-		topologyUpdatedSuccess: scope.Counter("topology.updated-success"),
-		topologyUpdatedError:   scope.Counter("topology.updated-error"),
-		streamFromPeersMetrics: make(map[shardMetricsKey]streamFromPeersMetrics),
+		writeSuccess:              scope.Counter("write.success"),
+		writeErrors:               scope.Counter("write.errors"),
+		fetchSuccess:              scope.Counter("fetch.success"),
+		fetchErrors:               scope.Counter("fetch.errors"),
+		topologyUpdatedSuccess:    scope.Counter("topology.updated-success"),
+		topologyUpdatedError:      scope.Counter("topology.updated-error"),
+		fetchTaggedUnboundedQuery: scope.Counter("fetch-tagged.unbounded-query"),
+		queryCostPartialResults:   scope.Counter("query-cost.partial-results"),
+		streamFromPeersMetrics:    make(map[shardMetricsKey]streamFromPeersMetrics),
 	}
 }
 
@@ -246,6 +269,15 @@ func newSession(opts Options) (clientSession, error) {
 		},
 		metrics: newSessionMetrics(scope),
 	}
+	s.asyncWrites = newAsyncWrites(opts.AsyncWriteMaxInFlightBytes(),
+		scope.SubScope("async-write"))
+	s.asyncWriteResultFn = s.asyncWriteResult
+	if opts.HedgedReadsEnabled() || opts.ReadIsolationGroup() != "" {
+		s.hedgedReads = newHedgedReads(opts, scope.SubScope("fetch-tagged-hedged-reads"))
+	}
+	if opts.ReadYourWritesEnabled() {
+		s.readYourWrites = newReadYourWrites(opts, scope.SubScope("read-your-writes"))
+	}
 	s.reattemptStreamBlocksFromPeersFn = s.streamBlocksReattemptFromPeers
 	s.pickBestPeerFn = s.streamBlocksPickBestPeer
 	writeAttemptPoolOpts := pool.NewObjectPoolOptions().
@@ -295,6 +327,14 @@ func newSession(opts Options) (clientSession, error) {
 	s.pools.checkedBytesWrapper = xpool.NewCheckedBytesWrapperPool(wrapperPoolOpts)
 	s.pools.checkedBytesWrapper.Init()
 
+	if opts.WriteSpoolPath() != "" {
+		s.writeSpool, err = newWriteSpool(opts, s.writeAttempt, s.pools.tagEncoder,
+			s.pools.tagDecoder, scope.SubScope("write-spool"))
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if opts, ok := opts.(AdminOptions); ok {
 		s.state.bootstrapLevel = opts.BootstrapConsistencyLevel()
 		s.origin = opts.Origin()
@@ -524,6 +564,10 @@ func (s *session) Open() error {
 	s.state.status = statusOpen
 	s.state.Unlock()
 
+	if s.writeSpool != nil {
+		s.writeSpool.start()
+	}
+
 	go func() {
 		for range watch.C() {
 			s.log.Info("received update for topology")
@@ -750,6 +794,23 @@ func (s *session) setTopologyWithLock(topoMap topology.Map, queues []hostQueue,
 		s.pools.multiReaderIterator = encoding.NewMultiReaderIteratorPool(poolOpts)
 		s.pools.multiReaderIterator.Init(s.opts.ReaderIteratorAllocate())
 	}
+	if s.pools.namespaceMultiReaderIterators == nil {
+		allocates := s.opts.NamespaceReaderIteratorAllocates()
+		s.pools.namespaceMultiReaderIterators = make(
+			map[string]encoding.MultiReaderIteratorPool, len(allocates))
+		for namespace, allocate := range allocates {
+			size := replicas * s.opts.SeriesIteratorPoolSize()
+			poolOpts := pool.NewObjectPoolOptions().
+				SetSize(size).
+				SetInstrumentOptions(s.opts.InstrumentOptions().SetMetricsScope(
+					s.scope.SubScope("multi-reader-iterator-pool").
+						Tagged(map[string]string{"namespace": namespace}),
+				))
+			p := encoding.NewMultiReaderIteratorPool(poolOpts)
+			p.Init(allocate)
+			s.pools.namespaceMultiReaderIterators[namespace] = p
+		}
+	}
 	if replicas > len(s.metrics.writeNodesRespondingErrors) {
 		curr := len(s.metrics.writeNodesRespondingErrors)
 		for i := curr; i < replicas; i++ {
@@ -879,6 +940,10 @@ func (s *session) Write(
 		t, value, unit, annotation
 	err := s.writeRetrier.Attempt(w.attemptFn)
 	s.pools.writeAttempt.Put(w)
+	if err != nil {
+		err = s.spoolWrite(untaggedWriteAttemptType, namespace, id,
+			ident.EmptyTagIterator, t, value, unit, annotation, err)
+	}
 	return err
 }
 
@@ -897,6 +962,110 @@ func (s *session) WriteTagged(
 		t, value, unit, annotation
 	err := s.writeRetrier.Attempt(w.attemptFn)
 	s.pools.writeAttempt.Put(w)
+	if err != nil {
+		err = s.spoolWrite(taggedWriteAttemptType, namespace, id, tags,
+			t, value, unit, annotation, err)
+	}
+	return err
+}
+
+// spoolWrite spools a write that failed because too few hosts could be
+// written to when the write spool is enabled, returning the write error if
+// the write is not spooled.
+func (s *session) spoolWrite(
+	wType writeAttemptType,
+	namespace, id ident.ID,
+	tags ident.TagIterator,
+	t time.Time,
+	value float64,
+	unit xtime.Unit,
+	annotation []byte,
+	writeErr error,
+) error {
+	if s.writeSpool == nil || !s.writeSpool.shouldSpool(writeErr) {
+		return writeErr
+	}
+	if err := s.writeSpool.spool(wType, namespace, id, tags, t, value,
+		unit, annotation); err != nil {
+		s.log.Errorf("failed to spool write: %v", err)
+		return writeErr
+	}
+	return nil
+}
+
+func (s *session) WriteTaggedAsync(
+	namespace, id ident.ID,
+	tags ident.TagIterator,
+	t time.Time,
+	value float64,
+	unit xtime.Unit,
+	annotation []byte,
+	onComplete WriteCompletionFn,
+) error {
+	timeType, timeTypeErr := convert.ToTimeType(unit)
+	if timeTypeErr != nil {
+		return timeTypeErr
+	}
+
+	timestamp, timestampErr := convert.ToValue(t, timeType)
+	if timestampErr != nil {
+		return timestampErr
+	}
+
+	// NB: acquire the bytes before taking the session lock so that blocking
+	// on in flight writes doesn't hold up topology changes or closing.
+	bytes := asyncWriteBytes(id, tags, annotation)
+	s.asyncWrites.acquire(bytes)
+
+	s.state.RLock()
+	if s.state.status != statusOpen {
+		s.state.RUnlock()
+		s.asyncWrites.release(bytes)
+		return errSessionStatusNotOpen
+	}
+
+	state, _, enqueued, err := s.writeAttemptWithRLock(taggedWriteAttemptType,
+		namespace, id, tags, timestamp, value, timeType, annotation)
+	s.state.RUnlock()
+
+	if err != nil {
+		s.asyncWrites.release(bytes)
+		return err
+	}
+
+	// NB: the state is still locked so no host queue can complete the write
+	// before it's marked as async.
+	state.async, state.asyncBytes = s.asyncWrites, bytes
+	state.enqueued = enqueued
+	state.resultFn, state.onComplete = s.asyncWriteResultFn, onComplete
+
+	var (
+		completeFn WriteCompletionFn
+		result     error
+	)
+	if state.pending == 0 {
+		completeFn, result = state.completeAsyncWithLock()
+	}
+
+	// must Unlock before decRef'ing, as the latter releases the writeState back into a
+	// pool if ref count == 0.
+	state.Unlock()
+	if completeFn != nil {
+		completeFn(result)
+	}
+	state.decRef()
+
+	return nil
+}
+
+// asyncWriteResult returns the result of an async write, it's called with
+// the write state locked.
+func (s *session) asyncWriteResult(state *writeState) error {
+	respErrs := int32(len(state.errors))
+	err := s.writeConsistencyResult(state.consistencyLevel, state.majority,
+		state.replicas, state.success, state.enqueued, state.enqueued-state.pending,
+		state.errors)
+	s.incWriteMetrics(err, respErrs)
 	return err
 }
 
@@ -937,8 +1106,8 @@ func (s *session) writeAttempt(
 	// returned from writeAttemptWithRLock.
 	state.Wait()
 
-	err = s.writeConsistencyResult(state.consistencyLevel, majority, enqueued,
-		enqueued-state.pending, int32(len(state.errors)), state.errors)
+	err = s.writeConsistencyResult(state.consistencyLevel, majority,
+		state.replicas, state.success, enqueued, enqueued-state.pending, state.errors)
 
 	s.incWriteMetrics(err, int32(len(state.errors)))
 
@@ -1018,10 +1187,11 @@ func (s *session) writeAttemptWithRLock(
 	state := s.pools.writeState.Get()
 	state.consistencyLevel = s.state.writeLevel
 	state.topoMap = s.state.topoMap
+	state.readYourWrites = s.readYourWrites
 	state.incRef()
 
 	// todo@bl: Can we combine the writeOpPool and the writeStatePool?
-	state.op, state.majority = op, majority
+	state.op, state.replicas, state.majority = op, int32(s.state.replicas), majority
 	state.nsID, state.tsID, state.tagEncoder = nsID, tsID, tagEncoder
 	op.SetCompletionFn(state.completionFn)
 
@@ -1091,6 +1261,9 @@ func (s *session) FetchIDs(
 func (s *session) FetchTagged(
 	ns ident.ID, q index.Query, opts index.QueryOptions,
 ) (encoding.SeriesIterators, bool, error) {
+	if err := s.validateTagQuery(q); err != nil {
+		return nil, false, err
+	}
 	f := s.pools.fetchTaggedAttempt.Get()
 	f.args.ns = ns
 	f.args.query = q
@@ -1111,7 +1284,7 @@ func (s *session) fetchTaggedAttempt(
 	}
 
 	const fetchData = true
-	fetchState, err := s.fetchTaggedAttemptWithRLock(ns, q, opts, fetchData)
+	fetchState, err := s.fetchTaggedAttemptWithRLock(ns, q, opts, fetchData, 0, nil)
 	s.state.RUnlock()
 
 	if err != nil {
@@ -1137,6 +1310,9 @@ func (s *session) fetchTaggedAttempt(
 func (s *session) FetchTaggedIDs(
 	ns ident.ID, q index.Query, opts index.QueryOptions,
 ) (TaggedIDsIterator, bool, error) {
+	if err := s.validateTagQuery(q); err != nil {
+		return nil, false, err
+	}
 	f := s.pools.fetchTaggedAttempt.Get()
 	f.args.ns = ns
 	f.args.query = q
@@ -1157,7 +1333,7 @@ func (s *session) fetchTaggedIDsAttempt(
 	}
 
 	const fetchData = false
-	fetchState, err := s.fetchTaggedAttemptWithRLock(ns, q, opts, fetchData)
+	fetchState, err := s.fetchTaggedAttemptWithRLock(ns, q, opts, fetchData, 0, nil)
 	s.state.RUnlock()
 
 	if err != nil {
@@ -1183,11 +1359,117 @@ func (s *session) fetchTaggedIDsAttempt(
 // NB(prateek): the returned fetchState, if valid, still holds the lock. Its ownership
 // is transferred to the calling function, and is expected to manage the lifecycle of
 // of the object (including releasing the lock/decRef'ing it).
+func (s *session) FetchTaggedPages(
+	ns ident.ID, q index.Query, opts index.QueryOptions, pageSize int,
+) (SeriesIteratorsCursor, error) {
+	if pageSize <= 0 {
+		return nil, errFetchTaggedPageSize
+	}
+	if err := s.validateTagQuery(q); err != nil {
+		return nil, err
+	}
+	return newSeriesIteratorsCursor(s, ns, q, opts, int64(pageSize)), nil
+}
+
+// validateTagQuery checks the query has a positive term, queries without one
+// have to scan the postings of every series in the index on every host so are
+// either rejected or logged depending on the session options.
+func (s *session) validateTagQuery(q index.Query) error {
+	if q.SearchQuery() == nil {
+		// Let the fetch itself fail with the invalid query
+		return nil
+	}
+	if index.AnalyzeQuery(q).HasPositiveTerm {
+		return nil
+	}
+
+	s.metrics.fetchTaggedUnboundedQuery.Inc(1)
+	if s.opts.RejectUnboundedTagQueries() {
+		return xerrors.NewInvalidParamsError(errFetchTaggedUnboundedQuery)
+	}
+	s.logUnboundedTagQuery(q)
+	return nil
+}
+
+// logUnboundedTagQuery logs an unbounded tag query at most once every
+// unboundedTagQueryLogInterval so that clients issuing them frequently do not
+// flood the logs.
+func (s *session) logUnboundedTagQuery(q index.Query) {
+	var (
+		now    = s.nowFn().UnixNano()
+		logged = atomic.LoadInt64(&s.unboundedTagQueryLoggedNanos)
+	)
+	if logged != 0 && now-logged < int64(unboundedTagQueryLogInterval) {
+		return
+	}
+	if !atomic.CompareAndSwapInt64(&s.unboundedTagQueryLoggedNanos, logged, now) {
+		// Another fetch is logging its query.
+		return
+	}
+	s.log.Warnf("fetch tagged query has no positive term and will scan every series: %s", q.String())
+}
+
+// fetchTaggedPage fetches the page of series after the page token, returning
+// the token of the next page or nil if it is the last page.
+func (s *session) fetchTaggedPage(
+	ns ident.ID, q index.Query, opts index.QueryOptions,
+	pageSize int64, pageToken []byte,
+) (encoding.SeriesIterators, []byte, error) {
+	var (
+		iters         encoding.SeriesIterators
+		nextPageToken []byte
+	)
+	err := s.fetchRetrier.Attempt(func() error {
+		var err error
+		iters, nextPageToken, err = s.fetchTaggedPageAttempt(ns, q, opts,
+			pageSize, pageToken)
+		return err
+	})
+	return iters, nextPageToken, err
+}
+
+func (s *session) fetchTaggedPageAttempt(
+	ns ident.ID, q index.Query, opts index.QueryOptions,
+	pageSize int64, pageToken []byte,
+) (encoding.SeriesIterators, []byte, error) {
+	s.state.RLock()
+	if s.state.status != statusOpen {
+		s.state.RUnlock()
+		return nil, nil, errSessionStatusNotOpen
+	}
+
+	const fetchData = true
+	fetchState, err := s.fetchTaggedAttemptWithRLock(ns, q, opts, fetchData,
+		pageSize, pageToken)
+	s.state.RUnlock()
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// it's safe to Wait() here, as we still hold the lock on fetchState, after it's
+	// returned from fetchTaggedAttemptWithRLock.
+	fetchState.Wait()
+
+	// must Unlock before calling `asEncodingSeriesIteratorsPage` as the latter
+	// needs to acquire the fetchState Lock
+	fetchState.Unlock()
+	iters, nextPageToken, err := fetchState.asEncodingSeriesIteratorsPage(s.pools)
+
+	// must Unlock() before decRef'ing, as the latter releases the fetchState back into a
+	// pool if ref count == 0.
+	fetchState.decRef()
+
+	return iters, nextPageToken, err
+}
+
 func (s *session) fetchTaggedAttemptWithRLock(
 	ns ident.ID,
 	q index.Query,
 	opts index.QueryOptions,
 	fetchData bool,
+	pageSize int64,
+	pageToken []byte,
 ) (*fetchState, error) {
 	// NB(prateek): we have to clone the namespace, as we cannot guarantee the lifecycle
 	// of the hostQueues responding is less than the lifecycle of the current method.
@@ -1213,10 +1495,26 @@ func (s *session) fetchTaggedAttemptWithRLock(
 	fetchState.incRef()       // indicate current go-routine has a reference to the fetchState
 	op.incRef()               // indicate current go-routine has a reference to the op
 	op.update(req, fetchState.completionFn)
+	op.updatePage(pageSize, pageToken)
+
+	readLevel := s.state.readLevel
+	if s.readYourWrites != nil && readYourWritesWeakLevel(readLevel) &&
+		s.readYourWrites.recentlyWritten(ns) {
+		// NB: the request spans every shard so rather than routing to the
+		// replicas that acknowledged writes per shard read from a majority,
+		// which overlaps the replicas that acknowledged majority writes.
+		readLevel = topology.ReadConsistencyLevelMajority
+		s.readYourWrites.metrics.bumped.Inc(1)
+	}
 
-	fetchState.Reset(opts.StartInclusive, opts.EndExclusive, op, topoMap, s.state.majority, s.state.readLevel)
+	fetchState.Reset(opts.StartInclusive, opts.EndExclusive, op, topoMap, s.state.majority, readLevel)
 	fetchState.Lock()
-	for _, hq := range s.state.queues {
+	queues := s.state.queues
+	if s.hedgedReads != nil {
+		queues = fetchState.splitQueuesForHedgingWithLock(s.hedgedReads, queues,
+			topoMap, s.state.majority, readLevel)
+	}
+	for _, hq := range queues {
 		// inc to indicate the hostQueue has a reference to `op` which has a ref to the fetchState
 		fetchState.incRef()
 		if err := hq.Enqueue(op); err != nil {
@@ -1235,6 +1533,8 @@ func (s *session) fetchTaggedAttemptWithRLock(
 
 	op.decRef() // release the ref for the current go-routine
 
+	fetchState.scheduleHedgeWithLock()
+
 	// NB(prateek): the calling go-routine still holds the lock and a ref
 	// on the returned fetchState object.
 	return fetchState, nil
@@ -1256,6 +1556,9 @@ func (s *session) fetchIDsAttempt(
 		majority               int32
 		consistencyLevel       topology.ReadConsistencyLevel
 		fetchBatchOpsByHostIdx [][]*fetchBatchOp
+		readYourWritesHosts    []string
+		routes, orderedRoutes  []fetchIDRoute
+		fallbacks              []*fetchIDFallback
 		success                = false
 	)
 
@@ -1316,6 +1619,8 @@ func (s *session) fetchIDsAttempt(
 		var (
 			idx  = idx // capture loop variable
 			tsID = s.pools.id.Clone(ids.Current())
+			// readLevel is bumped for read your writes sessions.
+			readLevel = consistencyLevel
 
 			wgIsDone int32
 			// NB(xichen): resultsAccessors and idAccessors get initialized to number of replicas + 1
@@ -1332,6 +1637,7 @@ func (s *session) fetchIDsAttempt(
 			success          int32
 			errors           []error
 			errs             int32
+			fallback         *fetchIDFallback
 		)
 
 		// increment namespaceAccesors by 1 to indicate it still needs to be handled by the
@@ -1348,7 +1654,7 @@ func (s *session) fetchIDsAttempt(
 				resultErrLock.RUnlock()
 			}
 			responded := enqueued - atomic.LoadInt32(&pending)
-			err := s.readConsistencyResult(consistencyLevel, majority, enqueued,
+			err := s.readConsistencyResult(readLevel, majority, enqueued,
 				responded, errsLen, reportErrors)
 			s.incFetchMetrics(err, errsLen)
 			if err != nil {
@@ -1404,7 +1710,7 @@ func (s *session) fetchIDsAttempt(
 			} else {
 				slicesIter := s.pools.readerSliceOfSlicesIterator.Get()
 				slicesIter.Reset(result.([]*rpc.Segments))
-				multiIter := s.pools.multiReaderIterator.Get()
+				multiIter := s.pools.NamespaceMultiReaderIterator(namespace.Bytes()).Get()
 				multiIter.ResetSliceOfSlices(slicesIter)
 				// Results is pre-allocated after creating fetch ops for this ID below
 				resultsLock.Lock()
@@ -1418,9 +1724,15 @@ func (s *session) fetchIDsAttempt(
 			// to iter.Reset down below before setting the iterator in the results array,
 			// which would cause a nil pointer exception.
 			remaining := atomic.AddInt32(&pending, -1)
-			shouldTerminate := topology.ReadConsistencyTermination(s.state.readLevel, majority, remaining, snapshotSuccess)
+			shouldTerminate := topology.ReadConsistencyTermination(readLevel, majority, remaining, snapshotSuccess)
 			if shouldTerminate && atomic.CompareAndSwapInt32(&wgIsDone, 0, 1) {
 				allCompletionFn()
+				if fallback != nil {
+					fallback.release()
+				}
+			} else if !shouldTerminate && err != nil && fallback != nil {
+				// Don't wait for the fallback timeout once a replica has failed.
+				s.enqueueFetchIDFallbacks([]*fetchIDFallback{fallback}, rangeStart, rangeEnd)
 			}
 
 			if atomic.AddInt32(&resultsAccessors, -1) == 0 {
@@ -1434,7 +1746,13 @@ func (s *session) fetchIDsAttempt(
 			}
 		}
 
-		if err := s.state.topoMap.RouteForEach(tsID, func(hostIdx int, host topology.Host) {
+		shardID := s.state.topoMap.ShardSet().Lookup(tsID)
+		routeFn := func(hostIdx int, host topology.Host) {
+			if s.shardInitializingWithRLock(host, shardID) {
+				// Never read from a shard that's still streaming data.
+				return
+			}
+
 			// Inc safely as this for each is sequential
 			enqueued++
 			pending++
@@ -1465,7 +1783,96 @@ func (s *session) fetchIDsAttempt(
 
 			// Append IDWithNamespace to this request
 			f.append(namespace.Bytes(), tsID.Bytes(), completionFn)
-		}); err != nil {
+		}
+
+		var (
+			readYourWritesRecent bool
+			err                  error
+		)
+		if s.readYourWrites != nil && readYourWritesWeakLevel(readLevel) {
+			readYourWritesHosts, readYourWritesRecent = s.readYourWrites.readHosts(
+				namespace, shardID, readYourWritesHosts[:0])
+		}
+		if readYourWritesRecent && len(readYourWritesHosts) > 0 {
+			// Only read from the replicas that acknowledged the session's
+			// recent writes to the shard.
+			err = s.state.topoMap.RouteForEach(tsID, func(hostIdx int, host topology.Host) {
+				if containsHostID(readYourWritesHosts, host.ID()) {
+					routeFn(hostIdx, host)
+				}
+			})
+			if err == nil && enqueued > 0 {
+				s.readYourWrites.metrics.routed.Inc(1)
+			}
+		}
+		if readYourWritesRecent && err == nil && enqueued == 0 {
+			// No replica that's still routed to acknowledged every recent
+			// write, read from a majority of the replicas instead.
+			readLevel = topology.ReadConsistencyLevelMajority
+			s.readYourWrites.metrics.bumped.Inc(1)
+		}
+		if err == nil && enqueued == 0 && s.hedgedReads != nil &&
+			s.hedgedReads.preferLocal(readLevel) {
+			// Read from replicas in the read isolation group first and only
+			// fall back to the other replicas on errors or timeouts.
+			routes = routes[:0]
+			err = s.state.topoMap.RouteForEach(tsID, func(hostIdx int, host topology.Host) {
+				routes = append(routes, fetchIDRoute{
+					hostIdx: hostIdx,
+					host:    host,
+					queue:   s.state.queues[hostIdx],
+				})
+			})
+			orderedRoutes = s.hedgedReads.orderFetchIDRoutes(routes, orderedRoutes)
+			required := readConsistencyRequired(readLevel, int(majority), len(orderedRoutes))
+			for _, r := range orderedRoutes {
+				if enqueued < int32(required) {
+					routeFn(r.hostIdx, r.host)
+					continue
+				}
+				if s.shardInitializingWithRLock(r.host, shardID) {
+					continue
+				}
+
+				// Inc safely as this for each is sequential
+				enqueued++
+				pending++
+				allPending++
+				resultsAccessors++
+				namespaceAccessors++
+				idAccessors++
+
+				if fallback == nil {
+					fallback = &fetchIDFallback{
+						namespace:    namespace.Bytes(),
+						id:           tsID.Bytes(),
+						completionFn: completionFn,
+					}
+					fallback.releaseFn = func() {
+						for range fallback.queues {
+							if atomic.AddInt32(&resultsAccessors, -1) == 0 {
+								s.pools.multiReaderIteratorArray.Put(results)
+							}
+							if atomic.AddInt32(&idAccessors, -1) == 0 {
+								tsID.Finalize()
+							}
+							if atomic.AddInt32(&namespaceAccessors, -1) == 0 {
+								namespace.Finalize()
+							}
+						}
+					}
+					fallbacks = append(fallbacks, fallback)
+				}
+				fallback.queues = append(fallback.queues, r.queue)
+			}
+		}
+		if err == nil && enqueued == 0 {
+			err = s.state.topoMap.RouteForEach(tsID, routeFn)
+		}
+		if err == nil && enqueued == 0 {
+			err = errFetchNoAvailableReplicas
+		}
+		if err != nil {
 			routeErr = err
 			break
 		}
@@ -1502,6 +1909,13 @@ func (s *session) fetchIDsAttempt(
 		return nil, enqueueErr
 	}
 
+	if len(fallbacks) > 0 {
+		fallbackTimer := time.AfterFunc(s.hedgedReads.fallbackTimeout, func() {
+			s.enqueueFetchIDFallbacks(fallbacks, rangeStart, rangeEnd)
+		})
+		defer fallbackTimer.Stop()
+	}
+
 	wg.Wait()
 
 	resultErrLock.RLock()
@@ -1514,14 +1928,84 @@ func (s *session) fetchIDsAttempt(
 	return iters, nil
 }
 
+// enqueueFetchIDFallbacks fetches the IDs from the replicas they fall back to
+// that haven't already been sent or released, batching the IDs per replica.
+func (s *session) enqueueFetchIDFallbacks(
+	fallbacks []*fetchIDFallback,
+	rangeStart, rangeEnd int64,
+) {
+	var (
+		queues []hostQueue
+		ops    []*fetchBatchOp
+	)
+	enqueue := func(hq hostQueue, f *fetchBatchOp) {
+		// Passing ownership of the op itself to the host queue
+		f.DecRef()
+		if err := hq.Enqueue(f); err != nil {
+			// The queue was closed by a topology change, count the replica
+			// as having failed so the fetch can complete.
+			f.completeAll(nil, err)
+		}
+	}
+	for _, fallback := range fallbacks {
+		if !fallback.take() {
+			continue
+		}
+		for _, hq := range fallback.queues {
+			idx := -1
+			for i := range queues {
+				if queues[i] == hq {
+					idx = i
+					break
+				}
+			}
+			if idx >= 0 && ops[idx].Size() >= s.fetchBatchSize {
+				enqueue(hq, ops[idx])
+				ops[idx] = nil
+			}
+			if idx < 0 {
+				idx = len(queues)
+				queues = append(queues, hq)
+				ops = append(ops, nil)
+			}
+			if ops[idx] == nil {
+				f := s.pools.fetchBatchOp.Get()
+				f.IncRef()
+				f.request.RangeStart = rangeStart
+				f.request.RangeEnd = rangeEnd
+				f.request.RangeTimeType = rpc.TimeType_UNIX_NANOSECONDS
+				ops[idx] = f
+			}
+			ops[idx].append(fallback.namespace, fallback.id, fallback.completionFn)
+		}
+	}
+	for i, f := range ops {
+		if f != nil {
+			enqueue(queues[i], f)
+		}
+	}
+}
+
+// shardInitializingWithRLock returns whether the host's replica of the shard
+// is initializing.
+func (s *session) shardInitializingWithRLock(host topology.Host, shardID uint32) bool {
+	hostShardSet, ok := s.state.topoMap.LookupHostShardSet(host.ID())
+	if !ok {
+		return false
+	}
+	state, err := hostShardSet.ShardSet().LookupStateByID(shardID)
+	return err == nil && state == shard.Initializing
+}
+
 func (s *session) writeConsistencyResult(
 	level topology.ConsistencyLevel,
-	majority, enqueued, responded, resultErrs int32,
+	majority, replicas, success, enqueued, responded int32,
 	errs []error,
 ) error {
-	// Check consistency level satisfied
-	success := enqueued - resultErrs
-	if !topology.WriteConsistencyAchieved(level, int(majority), int(enqueued), int(success)) {
+	// Check consistency level satisfied, success counts logical replicas so
+	// the leaving and initializing owners of a shard being moved between
+	// hosts count once and all requires every replica of the shard to ack.
+	if !topology.WriteConsistencyAchieved(level, int(majority), int(replicas), int(success)) {
 		return newConsistencyResultError(level, int(enqueued), int(responded), errs)
 	}
 	return nil
@@ -1561,6 +2045,10 @@ func (s *session) Close() error {
 	topo := s.state.topo
 	s.state.Unlock()
 
+	if s.writeSpool != nil {
+		s.writeSpool.close()
+	}
+
 	for _, q := range queues {
 		q.Close()
 	}
@@ -1648,6 +2136,140 @@ func (s *session) Truncate(namespace ident.ID) (int64, error) {
 	return truncated, resultErr.FinalError()
 }
 
+func (s *session) Cardinality(
+	namespace ident.ID,
+	opts index.CardinalityOptions,
+) ([]index.CardinalityResult, error) {
+	request, err := convert.ToRPCCardinalityRequest(namespace, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		wg            sync.WaitGroup
+		enqueueErr    xerrors.MultiError
+		resultErrLock sync.Mutex
+		resultErr     xerrors.MultiError
+		accumulator   = newCardinalityAccumulator(opts.Limit, s.Replicas())
+	)
+
+	c := &cardinalityOp{request: request}
+	c.completionFn = func(result interface{}, err error) {
+		if err == nil {
+			var results []index.CardinalityResult
+			results, err = convert.FromRPCCardinalityResult(result.(*rpc.CardinalityResult_))
+			if err == nil {
+				resultErrLock.Lock()
+				accumulator.add(results)
+				resultErrLock.Unlock()
+			}
+		}
+		if err != nil {
+			resultErrLock.Lock()
+			resultErr = resultErr.Add(err)
+			resultErrLock.Unlock()
+		}
+		wg.Done()
+	}
+
+	s.state.RLock()
+	for idx := range s.state.queues {
+		wg.Add(1)
+		if err := s.state.queues[idx].Enqueue(c); err != nil {
+			wg.Done()
+			enqueueErr = enqueueErr.Add(err)
+		}
+	}
+	s.state.RUnlock()
+
+	if err := enqueueErr.FinalError(); err != nil {
+		s.log.Errorf("failed to enqueue request: %v", err)
+		return nil, err
+	}
+
+	// Wait for all hosts to respond, each only holds a subset of the series
+	wg.Wait()
+
+	if err := resultErr.FinalError(); err != nil {
+		return nil, err
+	}
+	return accumulator.results(), nil
+}
+
+func (s *session) QueryCost(
+	namespace ident.ID,
+	q index.Query,
+	opts index.QueryCostOptions,
+) ([]index.QueryCostResult, error) {
+	request, err := convert.ToRPCQueryCostRequest(namespace, q, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	s.state.RLock()
+	if s.state.status != statusOpen {
+		s.state.RUnlock()
+		return nil, errSessionStatusNotOpen
+	}
+	queues := s.state.queues
+	replicas := s.state.replicas
+	s.state.RUnlock()
+
+	var (
+		wg            sync.WaitGroup
+		resultErrLock sync.Mutex
+		resultErr     xerrors.MultiError
+		// Hosts analyse the query the same way so the postings they return
+		// are in the order of the terms of the query analysed here
+		terms       = index.AnalyzeQuery(q).Terms
+		accumulator = newQueryCostAccumulator(terms, replicas, len(queues))
+	)
+
+	c := &queryCostOp{request: request}
+	c.completionFn = func(result interface{}, err error) {
+		if err == nil {
+			var results []index.QueryCostResult
+			results, err = convert.FromRPCQueryCostResult(result.(*rpc.QueryCostResult_), terms)
+			if err == nil {
+				resultErrLock.Lock()
+				accumulator.add(results)
+				resultErrLock.Unlock()
+			}
+		}
+		if err != nil {
+			resultErrLock.Lock()
+			resultErr = resultErr.Add(err)
+			resultErrLock.Unlock()
+		}
+		wg.Done()
+	}
+
+	for _, queue := range queues {
+		wg.Add(1)
+		if err := queue.Enqueue(c); err != nil {
+			// The op is never completed by a queue it was not enqueued to.
+			c.completionFn(nil, err)
+		}
+	}
+
+	// Wait for all hosts to respond, each only holds a subset of the series
+	wg.Wait()
+
+	err = resultErr.FinalError()
+	if err != nil && accumulator.responded == 0 {
+		return nil, err
+	}
+	if err != nil {
+		// The estimate is only used to refuse expensive queries so a host
+		// failing should not fail every query, the postings of the hosts
+		// that responded are scaled up to estimate those of every host.
+		s.metrics.queryCostPartialResults.Inc(1)
+		s.log.Warnf("query cost estimated from %d of %d hosts: %v",
+			accumulator.responded, len(queues), err)
+	}
+	return accumulator.results(), nil
+}
+
 // NB(r): Excluding maligned struct check here as we can
 // live with a few extra bytes since this struct is only
 // ever passed by stack, its much more readable not optimized
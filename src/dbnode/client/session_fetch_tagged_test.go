@@ -85,6 +85,71 @@ func TestSessionFetchTaggedUnsupportedQuery(t *testing.T) {
 	assert.NoError(t, session.Close())
 }
 
+func TestSessionFetchTaggedRejectsUnboundedQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSessionTestOptions().
+		SetRejectUnboundedTagQueries(true)
+	s, err := newSession(opts)
+	assert.NoError(t, err)
+
+	session, ok := s.(*session)
+	assert.True(t, ok)
+
+	mockHostQueues(ctrl, session, sessionTestReplicas, nil)
+	assert.NoError(t, session.Open())
+
+	var (
+		t0    = time.Now()
+		query = index.Query{idx.NewNegationQuery(idx.NewTermQuery([]byte("a"), []byte("b")))}
+	)
+	_, _, err = s.FetchTagged(ident.StringID("namespace"),
+		query, testSessionFetchTaggedQueryOpts(t0, t0))
+	assert.Error(t, err)
+	assert.True(t, IsBadRequestError(err))
+
+	_, _, err = s.FetchTaggedIDs(ident.StringID("namespace"),
+		query, testSessionFetchTaggedQueryOpts(t0, t0))
+	assert.Error(t, err)
+	assert.True(t, IsBadRequestError(err))
+
+	_, err = s.FetchTaggedPages(ident.StringID("namespace"),
+		query, testSessionFetchTaggedQueryOpts(t0, t0), 10)
+	assert.Error(t, err)
+	assert.True(t, IsBadRequestError(err))
+
+	assert.NoError(t, session.Close())
+}
+
+func TestSessionFetchTaggedUnboundedQueryLogInterval(t *testing.T) {
+	opts := newSessionTestOptions()
+	s, err := newSession(opts)
+	assert.NoError(t, err)
+
+	session, ok := s.(*session)
+	assert.True(t, ok)
+
+	var (
+		now   = time.Now()
+		query = index.Query{idx.NewNegationQuery(idx.NewTermQuery([]byte("a"), []byte("b")))}
+	)
+	session.nowFn = func() time.Time { return now }
+
+	require.NoError(t, session.validateTagQuery(query))
+	require.Equal(t, now.UnixNano(), session.unboundedTagQueryLoggedNanos)
+
+	// Queries within the log interval of the last logged query are not logged.
+	logged := now
+	now = now.Add(unboundedTagQueryLogInterval / 2)
+	require.NoError(t, session.validateTagQuery(query))
+	require.Equal(t, logged.UnixNano(), session.unboundedTagQueryLoggedNanos)
+
+	now = logged.Add(unboundedTagQueryLogInterval)
+	require.NoError(t, session.validateTagQuery(query))
+	require.Equal(t, now.UnixNano(), session.unboundedTagQueryLoggedNanos)
+}
+
 func TestSessionFetchTaggedNotOpenError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -29,26 +29,27 @@ import (
 )
 
 type sessionPools struct {
-	context                     context.Pool
-	id                          ident.Pool
-	writeOperation              *writeOperationPool
-	writeTaggedOperation        *writeTaggedOperationPool
-	fetchBatchOp                *fetchBatchOpPool
-	fetchBatchOpArrayArray      *fetchBatchOpArrayArrayPool
-	fetchTaggedOp               fetchTaggedOpPool
-	fetchState                  fetchStatePool
-	multiReaderIteratorArray    encoding.MultiReaderIteratorArrayPool
-	tagEncoder                  serialize.TagEncoderPool
-	tagDecoder                  serialize.TagDecoderPool
-	readerSliceOfSlicesIterator *readerSliceOfSlicesIteratorPool
-	multiReaderIterator         encoding.MultiReaderIteratorPool
-	seriesIterator              encoding.SeriesIteratorPool
-	seriesIterators             encoding.MutableSeriesIteratorsPool
-	writeAttempt                *writeAttemptPool
-	writeState                  *writeStatePool
-	fetchAttempt                *fetchAttemptPool
-	fetchTaggedAttempt          fetchTaggedAttemptPool
-	checkedBytesWrapper         xpool.CheckedBytesWrapperPool
+	context                       context.Pool
+	id                            ident.Pool
+	writeOperation                *writeOperationPool
+	writeTaggedOperation          *writeTaggedOperationPool
+	fetchBatchOp                  *fetchBatchOpPool
+	fetchBatchOpArrayArray        *fetchBatchOpArrayArrayPool
+	fetchTaggedOp                 fetchTaggedOpPool
+	fetchState                    fetchStatePool
+	multiReaderIteratorArray      encoding.MultiReaderIteratorArrayPool
+	tagEncoder                    serialize.TagEncoderPool
+	tagDecoder                    serialize.TagDecoderPool
+	readerSliceOfSlicesIterator   *readerSliceOfSlicesIteratorPool
+	multiReaderIterator           encoding.MultiReaderIteratorPool
+	namespaceMultiReaderIterators map[string]encoding.MultiReaderIteratorPool
+	seriesIterator                encoding.SeriesIteratorPool
+	seriesIterators               encoding.MutableSeriesIteratorsPool
+	writeAttempt                  *writeAttemptPool
+	writeState                    *writeStatePool
+	fetchAttempt                  *fetchAttemptPool
+	fetchTaggedAttempt            fetchTaggedAttemptPool
+	checkedBytesWrapper           xpool.CheckedBytesWrapperPool
 }
 
 // NB: ensure sessionPools satisfies the fetchTaggedPools interface.
@@ -82,6 +83,15 @@ func (s sessionPools) MultiReaderIterator() encoding.MultiReaderIteratorPool {
 	return s.multiReaderIterator
 }
 
+// NamespaceMultiReaderIterator returns the multi reader iterator pool for the
+// given namespace, namespaces without their own encoding use the default pool.
+func (s sessionPools) NamespaceMultiReaderIterator(namespace []byte) encoding.MultiReaderIteratorPool {
+	if p, ok := s.namespaceMultiReaderIterators[string(namespace)]; ok {
+		return p
+	}
+	return s.multiReaderIterator
+}
+
 func (s sessionPools) CheckedBytesWrapper() xpool.CheckedBytesWrapperPool {
 	return s.checkedBytesWrapper
 }
@@ -159,6 +159,57 @@ func TestSessionWriteTagged(t *testing.T) {
 	assert.NoError(t, session.Close())
 }
 
+func TestSessionWriteTaggedAsyncNotOpenError(t *testing.T) {
+	s := newDefaultTestSession(t).(*session)
+
+	err := s.WriteTaggedAsync(ident.StringID("namespace"), ident.StringID("foo"),
+		ident.EmptyTagIterator, time.Now(), 1.337, xtime.Second, nil,
+		func(err error) { require.Fail(t, "unexpected completion") })
+	assert.Equal(t, errSessionStatusNotOpen, err)
+	assert.Equal(t, int64(0), s.asyncWrites.inFlightBytes)
+}
+
+func TestSessionWriteTaggedAsync(t *testing.T) {
+	ctrl := gomock.NewController(xtest.Reporter{t})
+	defer ctrl.Finish()
+
+	w := newWriteTaggedStub()
+	session := newDefaultTestSession(t).(*session)
+
+	var completionFn completionFn
+	enqueueWg := mockHostQueues(ctrl, session, sessionTestReplicas, []testEnqueueFn{func(idx int, op op) {
+		completionFn = op.CompletionFn()
+	}})
+
+	assert.NoError(t, session.Open())
+
+	var (
+		numCompleted int
+		resultErr    error
+	)
+	err := session.WriteTaggedAsync(w.ns, w.id, ident.NewTagsIterator(w.tags),
+		w.t, w.value, w.unit, w.annotation, func(err error) {
+			numCompleted++
+			resultErr = err
+		})
+	require.NoError(t, err)
+
+	// Returns before any host has responded with the bytes in flight.
+	enqueueWg.Wait()
+	assert.Equal(t, 0, numCompleted)
+	assert.True(t, session.asyncWrites.inFlightBytes > 0)
+
+	for i := 0; i < session.state.topoMap.Replicas(); i++ {
+		completionFn(session.state.topoMap.Hosts()[0], nil)
+	}
+
+	assert.Equal(t, 1, numCompleted)
+	assert.NoError(t, resultErr)
+	assert.Equal(t, int64(0), session.asyncWrites.inFlightBytes)
+
+	assert.NoError(t, session.Close())
+}
+
 func TestSessionWriteTaggedDoesNotCloneNoFinalize(t *testing.T) {
 	ctrl := gomock.NewController(xtest.Reporter{t})
 	defer ctrl.Finish()
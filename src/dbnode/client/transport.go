@@ -0,0 +1,114 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/network/server/grpcthrift"
+	xclose "github.com/m3db/m3x/close"
+
+	"google.golang.org/grpc"
+)
+
+// Transport is the transport used to send requests to hosts.
+type Transport int
+
+const (
+	// TransportTChannel sends requests with TChannel thrift.
+	TransportTChannel Transport = iota
+
+	// TransportGRPC sends requests with gRPC, hosts must serve the node
+	// service over gRPC alongside TChannel.
+	TransportGRPC
+)
+
+var validTransports = []Transport{
+	TransportTChannel,
+	TransportGRPC,
+}
+
+func (t Transport) String() string {
+	switch t {
+	case TransportTChannel:
+		return "tchannel"
+	case TransportGRPC:
+		return "grpc"
+	}
+	return "unknown"
+}
+
+// ValidTransports returns a copy of all the valid transports.
+func ValidTransports() []Transport {
+	result := make([]Transport, len(validTransports))
+	copy(result, validTransports)
+	return result
+}
+
+// UnmarshalYAML unmarshals a Transport into a valid type from string.
+func (t *Transport) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+	if str == "" {
+		*t = TransportTChannel
+		return nil
+	}
+	strs := make([]string, 0, len(validTransports))
+	for _, valid := range validTransports {
+		if str == valid.String() {
+			*t = valid
+			return nil
+		}
+		strs = append(strs, "'"+valid.String()+"'")
+	}
+	return fmt.Errorf("invalid Transport '%s' valid types are: %s",
+		str, strings.Join(strs, ", "))
+}
+
+type grpcConn struct {
+	*grpc.ClientConn
+}
+
+func (c grpcConn) Close() {
+	c.ClientConn.Close()
+}
+
+func newGRPCConn(address string, opts Options) (xclose.SimpleCloser, rpc.TChanNode, error) {
+	// NB: Connecting happens in the background, the connection pool health
+	// checks the connection before it is used.
+	conn, err := grpc.Dial(address,
+		grpc.WithInsecure(),
+		grpc.WithCodec(grpcthrift.NewCodec()),
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(math.MaxInt32),
+			grpc.MaxCallSendMsgSize(math.MaxInt32),
+		),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return grpcConn{conn}, grpcthrift.NewNodeClient(conn), nil
+}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/clock"
 	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/proto"
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
 	"github.com/m3db/m3/src/dbnode/runtime"
 	"github.com/m3db/m3/src/dbnode/storage/block"
@@ -58,6 +59,9 @@ type Client interface {
 	DefaultSessionActive() bool
 }
 
+// WriteCompletionFn is called with the result of an async write.
+type WriteCompletionFn func(err error)
+
 // Session can write and read to a cluster
 type Session interface {
 	// Write value to the database for an ID
@@ -66,6 +70,13 @@ type Session interface {
 	// WriteTagged value to the database for an ID and given tags.
 	WriteTagged(namespace, id ident.ID, tags ident.TagIterator, t time.Time, value float64, unit xtime.Unit, annotation []byte) error
 
+	// WriteTaggedAsync enqueues a write of a value for an ID and given tags
+	// and returns without waiting for the write consistency level to be met,
+	// onComplete is called once it is met or can no longer be met. It blocks
+	// while the bytes of async writes in flight exceed the configured limit.
+	// Async writes are not retried nor spooled and onComplete must not block.
+	WriteTaggedAsync(namespace, id ident.ID, tags ident.TagIterator, t time.Time, value float64, unit xtime.Unit, annotation []byte, onComplete WriteCompletionFn) error
+
 	// Fetch values from the database for an ID
 	Fetch(namespace, id ident.ID, startInclusive, endExclusive time.Time) (encoding.SeriesIterator, error)
 
@@ -78,6 +89,22 @@ type Session interface {
 	// FetchTaggedIDs resolves the provided query to known IDs.
 	FetchTaggedIDs(namespace ident.ID, q index.Query, opts index.QueryOptions) (iter TaggedIDsIterator, exhaustive bool, err error)
 
+	// FetchTaggedPages resolves the provided query to known IDs, and returns a
+	// cursor that fetches the data for them a page at a time, ordered by ID.
+	// The query limit is ignored, instead each host returns at most pageSize
+	// series per page.
+	FetchTaggedPages(namespace ident.ID, q index.Query, opts index.QueryOptions, pageSize int) (SeriesIteratorsCursor, error)
+
+	// Cardinality returns the cardinality of the series of the namespace for
+	// each index block within the time range, merged across all hosts.
+	Cardinality(namespace ident.ID, opts index.CardinalityOptions) ([]index.CardinalityResult, error)
+
+	// QueryCost returns an estimate of the cost of the query for each index
+	// block within the time range, i.e. the postings list sizes of each of
+	// the terms of the query merged across all hosts. If only some of the
+	// hosts respond the estimate is extrapolated from those that did.
+	QueryCost(namespace ident.ID, q index.Query, opts index.QueryCostOptions) ([]index.QueryCostResult, error)
+
 	// ShardID returns the given shard for an ID for callers
 	// to easily discern what shard is failing when operations
 	// for given IDs begin failing
@@ -106,6 +133,20 @@ type TaggedIDsIterator interface {
 	Finalize()
 }
 
+// SeriesIteratorsCursor iterates over the pages of series of a paginated fetch.
+type SeriesIteratorsCursor interface {
+	// Next fetches the next page, returning false once all pages have been
+	// fetched or an error was encountered.
+	Next() bool
+
+	// Current returns the series of the current page, the caller takes
+	// ownership of the series and must close them.
+	Current() encoding.SeriesIterators
+
+	// Err returns any error encountered.
+	Err() error
+}
+
 // AdminClient can create administration sessions
 type AdminClient interface {
 	Client
@@ -210,6 +251,15 @@ type Options interface {
 	// SetEncodingM3TSZ sets m3tsz encoding
 	SetEncodingM3TSZ() Options
 
+	// SetEncodingProto sets protobuf encoding with the given schema for the
+	// given namespace, fetched datapoints of the namespace carry the decoded
+	// message as their annotation while other namespaces keep their encoding
+	SetEncodingProto(namespace ident.ID, schema *proto.Schema) Options
+
+	// NamespaceReaderIteratorAllocates returns the reader iterator allocators
+	// of namespaces that do not use the default encoding, keyed by namespace
+	NamespaceReaderIteratorAllocates() map[string]encoding.ReaderIteratorAllocate
+
 	// SetRuntimeOptionsManager sets the runtime options manager, it is optional
 	SetRuntimeOptionsManager(value runtime.OptionsManager) Options
 
@@ -252,6 +302,13 @@ type Options interface {
 	// ChannelOptions returns the channelOptions
 	ChannelOptions() *tchannel.ChannelOptions
 
+	// SetTransport sets the transport used to send requests to hosts, the
+	// channel options only apply to the TChannel transport
+	SetTransport(value Transport) Options
+
+	// Transport returns the transport used to send requests to hosts
+	Transport() Transport
+
 	// SetMaxConnectionCount sets the maxConnectionCount
 	SetMaxConnectionCount(value int) Options
 
@@ -360,6 +417,153 @@ type Options interface {
 	// a fetch operation. Only retryable errors are retried.
 	FetchRetrier() xretry.Retrier
 
+	// SetHedgedReadsEnabled sets whether fetch tagged requests are first sent
+	// to just enough hosts to meet the read consistency level, with hedged
+	// requests sent to the remaining hosts after a delay.
+	SetHedgedReadsEnabled(value bool) Options
+
+	// HedgedReadsEnabled returns whether hedged reads are enabled.
+	HedgedReadsEnabled() bool
+
+	// SetHedgedReadsDelayPercentile sets the percentile of recent host response
+	// latencies to wait for before sending hedged requests.
+	SetHedgedReadsDelayPercentile(value float64) Options
+
+	// HedgedReadsDelayPercentile returns the percentile of recent host response
+	// latencies to wait for before sending hedged requests.
+	HedgedReadsDelayPercentile() float64
+
+	// SetHedgedReadsMinDelay sets the minimum delay before sending hedged
+	// requests, also used until enough latencies have been observed.
+	SetHedgedReadsMinDelay(value time.Duration) Options
+
+	// HedgedReadsMinDelay returns the minimum delay before sending hedged
+	// requests, also used until enough latencies have been observed.
+	HedgedReadsMinDelay() time.Duration
+
+	// SetReadIsolationGroup sets the isolation group of replicas that fetch
+	// and fetch tagged requests are sent to first when the read consistency
+	// level can be met without replicas in other isolation groups.
+	SetReadIsolationGroup(value string) Options
+
+	// ReadIsolationGroup returns the isolation group of replicas that fetch
+	// and fetch tagged requests are sent to first.
+	ReadIsolationGroup() string
+
+	// SetReadIsolationGroupFallbackTimeout sets how long to wait for replicas
+	// in the read isolation group before also reading from other replicas,
+	// used by fetch tagged requests when hedged reads are disabled.
+	SetReadIsolationGroupFallbackTimeout(value time.Duration) Options
+
+	// ReadIsolationGroupFallbackTimeout returns how long to wait for replicas
+	// in the read isolation group before also reading from other replicas.
+	ReadIsolationGroupFallbackTimeout() time.Duration
+
+	// SetReadYourWritesEnabled sets whether reads with a consistency level
+	// weaker than majority are routed to the replicas that acknowledged the
+	// session's recent writes to the same shards, or bumped to majority reads
+	// when no single replica acknowledged all of them.
+	SetReadYourWritesEnabled(value bool) Options
+
+	// ReadYourWritesEnabled returns whether read your writes is enabled.
+	ReadYourWritesEnabled() bool
+
+	// SetReadYourWritesWindow sets how long the replicas that acknowledged
+	// writes are tracked for in read your writes sessions.
+	SetReadYourWritesWindow(value time.Duration) Options
+
+	// ReadYourWritesWindow returns how long the replicas that acknowledged
+	// writes are tracked for in read your writes sessions.
+	ReadYourWritesWindow() time.Duration
+
+	// SetWriteSpoolPath sets the directory writes that fail because too few
+	// hosts could be written to are spooled to and replayed from once hosts
+	// recover, writes are not spooled if empty. Only Write and WriteTagged
+	// spool failed writes, WriteTaggedAsync writes are never spooled.
+	SetWriteSpoolPath(value string) Options
+
+	// WriteSpoolPath returns the directory failed writes are spooled to.
+	WriteSpoolPath() string
+
+	// SetWriteSpoolMaxBytes sets the maximum bytes of writes spooled to disk,
+	// writes that fail once the spool is full return their error.
+	SetWriteSpoolMaxBytes(value int64) Options
+
+	// WriteSpoolMaxBytes returns the maximum bytes of writes spooled to disk.
+	WriteSpoolMaxBytes() int64
+
+	// SetWriteSpoolReplayInterval sets the interval spooled writes are
+	// replayed and synced to disk at, writes spooled since the last sync can
+	// be lost if the machine crashes.
+	SetWriteSpoolReplayInterval(value time.Duration) Options
+
+	// WriteSpoolReplayInterval returns the interval spooled writes are
+	// replayed at.
+	WriteSpoolReplayInterval() time.Duration
+
+	// SetWriteSpoolBufferPast sets the buffer past of the namespaces written
+	// to, spooled points older than this when replayed are dropped since
+	// dbnodes would reject them. The session does not know the retention
+	// options of namespaces so it must be no greater than the smallest buffer
+	// past of any namespace written to through the session, points past a
+	// namespace's buffer past are otherwise replayed and rejected.
+	SetWriteSpoolBufferPast(value time.Duration) Options
+
+	// WriteSpoolBufferPast returns the buffer past of the namespaces written
+	// to, spooled points older than this when replayed are dropped.
+	WriteSpoolBufferPast() time.Duration
+
+	// SetRejectUnboundedTagQueries sets whether tagged fetches with a query
+	// that has no positive term, such as a lone negation or a regexp starting
+	// with a wildcard, are rejected rather than only counted and periodically
+	// logged, since such queries scan the postings of every series in the
+	// index of every node.
+	SetRejectUnboundedTagQueries(value bool) Options
+
+	// RejectUnboundedTagQueries returns whether tagged fetches with a query
+	// that has no positive term are rejected.
+	RejectUnboundedTagQueries() bool
+
+	// SetHostCircuitBreakerEnabled sets whether write and fetch requests to a
+	// host are fast-failed after consecutive requests to it fail or are slow.
+	SetHostCircuitBreakerEnabled(value bool) Options
+
+	// HostCircuitBreakerEnabled returns whether host circuit breakers are
+	// enabled.
+	HostCircuitBreakerEnabled() bool
+
+	// SetHostCircuitBreakerFailureThreshold sets the number of consecutive
+	// failed or slow requests to a host that open its circuit breaker.
+	SetHostCircuitBreakerFailureThreshold(value int) Options
+
+	// HostCircuitBreakerFailureThreshold returns the number of consecutive
+	// failed or slow requests to a host that open its circuit breaker.
+	HostCircuitBreakerFailureThreshold() int
+
+	// SetHostCircuitBreakerSlowRequestThreshold sets the latency at which a
+	// request to a host counts as failed, zero disables latency tracking.
+	SetHostCircuitBreakerSlowRequestThreshold(value time.Duration) Options
+
+	// HostCircuitBreakerSlowRequestThreshold returns the latency at which a
+	// request to a host counts as failed.
+	HostCircuitBreakerSlowRequestThreshold() time.Duration
+
+	// SetHostCircuitBreakerOpenDuration sets how long a host circuit breaker
+	// stays open before letting a probe request through.
+	SetHostCircuitBreakerOpenDuration(value time.Duration) Options
+
+	// HostCircuitBreakerOpenDuration returns how long a host circuit breaker
+	// stays open before letting a probe request through.
+	HostCircuitBreakerOpenDuration() time.Duration
+
+	// SetAsyncWriteMaxInFlightBytes sets the maximum bytes of async writes
+	// that can be in flight before async writes block.
+	SetAsyncWriteMaxInFlightBytes(value int64) Options
+
+	// AsyncWriteMaxInFlightBytes returns the maximum bytes of async writes
+	// that can be in flight before async writes block.
+	AsyncWriteMaxInFlightBytes() int64
+
 	// SetTagEncoderOptions sets the TagEncoderOptions.
 	SetTagEncoderOptions(value serialize.TagEncoderOptions) Options
 
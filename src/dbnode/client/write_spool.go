@@ -0,0 +1,609 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/adler32"
+	"io/ioutil"
+	"math"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/x/serialize"
+	"github.com/m3db/m3x/checked"
+	"github.com/m3db/m3x/ident"
+	xlog "github.com/m3db/m3x/log"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/uber-go/tally"
+)
+
+const (
+	// writeSpoolMaxSegmentBytes is the maximum size of a single spool segment
+	// file, segments are replayed and removed whole.
+	writeSpoolMaxSegmentBytes = 16 * 1024 * 1024
+
+	// writeSpoolRecordHeaderBytes is the size of the length and checksum
+	// that precede each spooled write.
+	writeSpoolRecordHeaderBytes = 8
+
+	// writeSpoolRecordFixedBytes is the size of the fixed size fields of a
+	// spooled write: the type, spooled at time, timestamp, value and unit.
+	writeSpoolRecordFixedBytes = 1 + 8 + 8 + 8 + 1
+
+	writeSpoolSegmentPrefix = "write-spool-"
+	writeSpoolSegmentSuffix = ".db"
+)
+
+var (
+	errWriteSpoolFull          = errors.New("write spool is full")
+	errWriteSpoolRecordCorrupt = errors.New("write spool record is corrupt")
+)
+
+// writeSpoolWriteFn performs a single write attempt of a spooled write.
+type writeSpoolWriteFn func(
+	wType writeAttemptType,
+	namespace, id ident.ID,
+	tags ident.TagIterator,
+	t time.Time,
+	value float64,
+	unit xtime.Unit,
+	annotation []byte,
+) error
+
+type writeSpoolMetrics struct {
+	depth           tally.Gauge
+	bytes           tally.Gauge
+	age             tally.Gauge
+	spooled         tally.Counter
+	full            tally.Counter
+	replayed        tally.Counter
+	droppedTooOld   tally.Counter
+	droppedRejected tally.Counter
+	droppedCorrupt  tally.Counter
+}
+
+func newWriteSpoolMetrics(scope tally.Scope) writeSpoolMetrics {
+	return writeSpoolMetrics{
+		depth:           scope.Gauge("depth"),
+		bytes:           scope.Gauge("bytes"),
+		age:             scope.Gauge("age"),
+		spooled:         scope.Counter("spooled"),
+		full:            scope.Counter("full"),
+		replayed:        scope.Counter("replayed"),
+		droppedTooOld:   scope.Counter("dropped-too-old"),
+		droppedRejected: scope.Counter("dropped-rejected"),
+		droppedCorrupt:  scope.Counter("dropped-corrupt"),
+	}
+}
+
+// writeSpool persists writes that failed because too few hosts could be
+// reached to disk, and replays them in the order they were spooled once
+// hosts recover. Points that are older than the buffer past by the time
+// they're replayed are dropped since dbnodes would reject them, as are writes
+// that are corrupt or fail for reasons other than hosts being unavailable.
+// Segments are synced to disk at every replay interval and when they're
+// sealed, writes spooled since the last sync can be lost if the machine
+// crashes.
+type writeSpool struct {
+	sync.Mutex
+
+	path           string
+	maxBytes       int64
+	segmentBytes   int64
+	replayInterval time.Duration
+	bufferPast     time.Duration
+	nowFn          clock.NowFn
+	writeFn        writeSpoolWriteFn
+	tagEncoderPool serialize.TagEncoderPool
+	tagDecoderPool serialize.TagDecoderPool
+	log            xlog.Logger
+	metrics        writeSpoolMetrics
+
+	segments []*writeSpoolSegment
+	writer   *os.File
+	nextSeq  int
+	buf      []byte
+
+	started bool
+	closeCh chan struct{}
+	doneCh  chan struct{}
+}
+
+type writeSpoolSegment struct {
+	seq     int
+	path    string
+	bytes   int64
+	records int
+	// replayed is the offset of the first write not yet replayed and oldest
+	// is the time it was spooled at.
+	replayed int64
+	oldest   time.Time
+}
+
+type writeSpoolRecord struct {
+	wType      writeAttemptType
+	spooledAt  time.Time
+	t          time.Time
+	value      float64
+	unit       xtime.Unit
+	namespace  []byte
+	id         []byte
+	tags       []byte
+	annotation []byte
+}
+
+func newWriteSpool(
+	opts Options,
+	writeFn writeSpoolWriteFn,
+	tagEncoderPool serialize.TagEncoderPool,
+	tagDecoderPool serialize.TagDecoderPool,
+	scope tally.Scope,
+) (*writeSpool, error) {
+	w := &writeSpool{
+		path:           opts.WriteSpoolPath(),
+		maxBytes:       opts.WriteSpoolMaxBytes(),
+		segmentBytes:   opts.WriteSpoolMaxBytes(),
+		replayInterval: opts.WriteSpoolReplayInterval(),
+		bufferPast:     opts.WriteSpoolBufferPast(),
+		nowFn:          opts.ClockOptions().NowFn(),
+		writeFn:        writeFn,
+		tagEncoderPool: tagEncoderPool,
+		tagDecoderPool: tagDecoderPool,
+		log:            opts.InstrumentOptions().Logger(),
+		metrics:        newWriteSpoolMetrics(scope),
+		closeCh:        make(chan struct{}),
+		doneCh:         make(chan struct{}),
+	}
+	if w.segmentBytes > writeSpoolMaxSegmentBytes {
+		w.segmentBytes = writeSpoolMaxSegmentBytes
+	}
+	if err := os.MkdirAll(w.path, 0755); err != nil {
+		return nil, err
+	}
+	if err := w.loadSegments(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// loadSegments loads the segments left over from a previous session, these
+// are replayed from the start as how far they were replayed isn't persisted.
+func (w *writeSpool) loadSegments() error {
+	matches, err := filepath.Glob(filepath.Join(w.path,
+		writeSpoolSegmentPrefix+"*"+writeSpoolSegmentSuffix))
+	if err != nil {
+		return err
+	}
+
+	for _, path := range matches {
+		var seq int
+		name := filepath.Base(path)
+		if _, err := fmt.Sscanf(name, writeSpoolSegmentPrefix+"%d"+writeSpoolSegmentSuffix, &seq); err != nil {
+			continue
+		}
+
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		segment := &writeSpoolSegment{seq: seq, path: path}
+		for segment.bytes < int64(len(data)) {
+			record, n, err := decodeWriteSpoolRecord(data[segment.bytes:])
+			if err != nil && n == 0 {
+				// Ignore a partially written write at the end of the segment.
+				break
+			}
+			if err == nil && segment.oldest.IsZero() {
+				segment.oldest = record.spooledAt
+			}
+			segment.bytes += int64(n)
+			segment.records++
+		}
+		w.segments = append(w.segments, segment)
+		if seq >= w.nextSeq {
+			w.nextSeq = seq + 1
+		}
+	}
+
+	sort.Slice(w.segments, func(i, j int) bool {
+		return w.segments[i].seq < w.segments[j].seq
+	})
+	return nil
+}
+
+// shouldSpool returns whether a failed write should be spooled, which is
+// only when it failed because too few hosts could be written to rather
+// than because the write itself was rejected.
+func (w *writeSpool) shouldSpool(err error) bool {
+	return IsConsistencyResultError(err) &&
+		!IsBadRequestError(err) &&
+		!IsResourceExhaustedError(err)
+}
+
+// shouldRetry returns whether a replayed write failed because hosts are still
+// unavailable, in which case replaying stops until the next replay. Writes
+// that fail for any other reason are dropped so they don't hold up the writes
+// spooled after them.
+func (w *writeSpool) shouldRetry(err error) bool {
+	if err == errSessionStatusNotOpen {
+		// The session is closing, the write is replayed by the next session.
+		return true
+	}
+	if _, ok := err.(net.Error); ok {
+		return true
+	}
+	return w.shouldSpool(err)
+}
+
+// spool persists a write to be replayed once hosts recover.
+func (w *writeSpool) spool(
+	wType writeAttemptType,
+	namespace, id ident.ID,
+	tags ident.TagIterator,
+	t time.Time,
+	value float64,
+	unit xtime.Unit,
+	annotation []byte,
+) error {
+	var (
+		encodedTags []byte
+		tagEncoder  serialize.TagEncoder
+	)
+	if wType == taggedWriteAttemptType {
+		tagEncoder = w.tagEncoderPool.Get()
+		defer tagEncoder.Finalize()
+		if err := tagEncoder.Encode(tags); err != nil {
+			return err
+		}
+		data, ok := tagEncoder.Data()
+		if !ok {
+			return errUnableToEncodeTags
+		}
+		encodedTags = data.Bytes()
+	}
+
+	w.Lock()
+	defer w.Unlock()
+
+	w.buf = encodeWriteSpoolRecord(w.buf[:0], writeSpoolRecord{
+		wType:      wType,
+		spooledAt:  w.nowFn(),
+		t:          t,
+		value:      value,
+		unit:       unit,
+		namespace:  namespace.Bytes(),
+		id:         id.Bytes(),
+		tags:       encodedTags,
+		annotation: annotation,
+	})
+
+	size := int64(len(w.buf))
+	if bytes, _ := w.sizeWithLock(); bytes+size > w.maxBytes {
+		w.metrics.full.Inc(1)
+		return errWriteSpoolFull
+	}
+
+	var segment *writeSpoolSegment
+	if len(w.segments) > 0 {
+		segment = w.segments[len(w.segments)-1]
+	}
+	if w.writer == nil || segment.bytes+size > w.segmentBytes {
+		if err := w.rotateWithLock(); err != nil {
+			return err
+		}
+		segment = w.segments[len(w.segments)-1]
+	}
+
+	if _, err := w.writer.Write(w.buf); err != nil {
+		// Start a new segment rather than append after a partial write.
+		w.closeWriterWithLock()
+		return err
+	}
+	if segment.records == 0 {
+		segment.oldest = w.nowFn()
+	}
+	segment.bytes += size
+	segment.records++
+	w.metrics.spooled.Inc(1)
+	return nil
+}
+
+func (w *writeSpool) rotateWithLock() error {
+	w.closeWriterWithLock()
+
+	seq := w.nextSeq
+	path := filepath.Join(w.path,
+		fmt.Sprintf("%s%d%s", writeSpoolSegmentPrefix, seq, writeSpoolSegmentSuffix))
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	w.nextSeq++
+	w.writer = f
+	w.segments = append(w.segments, &writeSpoolSegment{seq: seq, path: path})
+	return nil
+}
+
+func (w *writeSpool) syncWriterWithLock() {
+	if w.writer == nil {
+		return
+	}
+	if err := w.writer.Sync(); err != nil {
+		w.log.Errorf("failed to sync write spool segment: %v", err)
+	}
+}
+
+func (w *writeSpool) closeWriterWithLock() {
+	if w.writer == nil {
+		return
+	}
+	w.syncWriterWithLock()
+	if err := w.writer.Close(); err != nil {
+		w.log.Errorf("failed to close write spool segment: %v", err)
+	}
+	w.writer = nil
+}
+
+// sizeWithLock returns the bytes and number of writes not yet replayed.
+func (w *writeSpool) sizeWithLock() (int64, int) {
+	var (
+		bytes   int64
+		records int
+	)
+	for _, segment := range w.segments {
+		bytes += segment.bytes - segment.replayed
+		records += segment.records
+	}
+	return bytes, records
+}
+
+func (w *writeSpool) start() {
+	w.Lock()
+	w.started = true
+	w.Unlock()
+	go w.replayLoop()
+}
+
+func (w *writeSpool) replayLoop() {
+	defer close(w.doneCh)
+
+	ticker := time.NewTicker(w.replayInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.closeCh:
+			return
+		case <-ticker.C:
+			w.Lock()
+			w.syncWriterWithLock()
+			w.Unlock()
+			w.replay()
+			w.reportMetrics()
+		}
+	}
+}
+
+// replay replays spooled writes in order until they're all replayed or a
+// write fails because hosts are still unavailable, writes that can't be
+// replayed for any other reason are dropped.
+func (w *writeSpool) replay() {
+	for {
+		w.Lock()
+		if len(w.segments) == 0 {
+			w.Unlock()
+			return
+		}
+		segment := w.segments[0]
+		if len(w.segments) == 1 {
+			// Seal the segment being spooled to so it can be removed once
+			// replayed, new writes are spooled to a new segment.
+			w.closeWriterWithLock()
+		}
+		w.Unlock()
+
+		data, err := ioutil.ReadFile(segment.path)
+		if err != nil {
+			w.log.Errorf("failed to read write spool segment: %v", err)
+			return
+		}
+		if int64(len(data)) > segment.bytes {
+			data = data[:segment.bytes]
+		}
+
+		for segment.replayed < int64(len(data)) {
+			select {
+			case <-w.closeCh:
+				return
+			default:
+			}
+
+			record, n, err := decodeWriteSpoolRecord(data[segment.replayed:])
+			if err != nil && n == 0 {
+				// The length of the write is corrupt so the writes after it
+				// can't be found.
+				w.log.Errorf("dropping remainder of write spool segment: %v", err)
+				w.metrics.droppedCorrupt.Inc(int64(segment.records))
+				break
+			}
+
+			if err != nil {
+				w.log.Errorf("dropping write spool write: %v", err)
+				w.metrics.droppedCorrupt.Inc(1)
+			} else if record.t.Before(w.nowFn().Add(-w.bufferPast)) {
+				w.metrics.droppedTooOld.Inc(1)
+			} else if err := w.replayRecord(record); err == nil {
+				w.metrics.replayed.Inc(1)
+			} else if err == errWriteSpoolRecordCorrupt {
+				w.log.Errorf("dropping write spool write: %v", err)
+				w.metrics.droppedCorrupt.Inc(1)
+			} else if w.shouldRetry(err) {
+				// Hosts are still unavailable, retry on the next replay.
+				return
+			} else {
+				w.log.Errorf("dropping write spool write: %v", err)
+				w.metrics.droppedRejected.Inc(1)
+			}
+
+			w.Lock()
+			segment.replayed += int64(n)
+			segment.records--
+			if next, _, err := decodeWriteSpoolRecord(data[segment.replayed:]); err == nil {
+				segment.oldest = next.spooledAt
+			}
+			w.Unlock()
+		}
+
+		if err := os.Remove(segment.path); err != nil {
+			w.log.Errorf("failed to remove write spool segment: %v", err)
+			return
+		}
+		w.Lock()
+		w.segments = w.segments[1:]
+		w.Unlock()
+	}
+}
+
+func (w *writeSpool) replayRecord(record writeSpoolRecord) error {
+	var tags ident.TagIterator = ident.EmptyTagIterator
+	if record.wType == taggedWriteAttemptType {
+		decoder := w.tagDecoderPool.Get()
+		decoder.Reset(checked.NewBytes(record.tags, nil))
+		if decoder.Err() != nil {
+			decoder.Close()
+			return errWriteSpoolRecordCorrupt
+		}
+		defer decoder.Close()
+		tags = decoder
+	}
+	return w.writeFn(record.wType, ident.BytesID(record.namespace),
+		ident.BytesID(record.id), tags, record.t, record.value, record.unit,
+		record.annotation)
+}
+
+func (w *writeSpool) reportMetrics() {
+	w.Lock()
+	bytes, records := w.sizeWithLock()
+	var age time.Duration
+	if records > 0 {
+		for _, segment := range w.segments {
+			if segment.records > 0 {
+				age = w.nowFn().Sub(segment.oldest)
+				break
+			}
+		}
+	}
+	w.Unlock()
+
+	w.metrics.depth.Update(float64(records))
+	w.metrics.bytes.Update(float64(bytes))
+	w.metrics.age.Update(age.Seconds())
+}
+
+func (w *writeSpool) close() {
+	close(w.closeCh)
+
+	w.Lock()
+	started := w.started
+	w.Unlock()
+	if started {
+		<-w.doneCh
+	}
+
+	w.Lock()
+	w.closeWriterWithLock()
+	w.Unlock()
+}
+
+// encodeWriteSpoolRecord appends a write to the buffer, preceded by its
+// length and checksum.
+func encodeWriteSpoolRecord(buf []byte, r writeSpoolRecord) []byte {
+	start := len(buf)
+	buf = append(buf, make([]byte, writeSpoolRecordHeaderBytes)...)
+
+	var scratch [binary.MaxVarintLen64]byte
+	buf = append(buf, byte(r.wType))
+	binary.BigEndian.PutUint64(scratch[:8], uint64(r.spooledAt.UnixNano()))
+	buf = append(buf, scratch[:8]...)
+	binary.BigEndian.PutUint64(scratch[:8], uint64(r.t.UnixNano()))
+	buf = append(buf, scratch[:8]...)
+	binary.BigEndian.PutUint64(scratch[:8], math.Float64bits(r.value))
+	buf = append(buf, scratch[:8]...)
+	buf = append(buf, byte(r.unit))
+	for _, b := range [][]byte{r.namespace, r.id, r.tags, r.annotation} {
+		n := binary.PutUvarint(scratch[:], uint64(len(b)))
+		buf = append(buf, scratch[:n]...)
+		buf = append(buf, b...)
+	}
+
+	payload := buf[start+writeSpoolRecordHeaderBytes:]
+	binary.BigEndian.PutUint32(buf[start:], uint32(len(payload)))
+	binary.BigEndian.PutUint32(buf[start+4:], adler32.Checksum(payload))
+	return buf
+}
+
+// decodeWriteSpoolRecord decodes the write at the start of the buffer and
+// returns the number of bytes it takes up, the byte slices of the returned
+// write reference the buffer. The number of bytes is also returned for a
+// corrupt write if its length fits in the buffer so that it can be skipped.
+func decodeWriteSpoolRecord(buf []byte) (writeSpoolRecord, int, error) {
+	var r writeSpoolRecord
+	if len(buf) < writeSpoolRecordHeaderBytes {
+		return r, 0, errWriteSpoolRecordCorrupt
+	}
+	size := int(binary.BigEndian.Uint32(buf))
+	checksum := binary.BigEndian.Uint32(buf[4:])
+	if size < writeSpoolRecordFixedBytes || len(buf)-writeSpoolRecordHeaderBytes < size {
+		return r, 0, errWriteSpoolRecordCorrupt
+	}
+	n := writeSpoolRecordHeaderBytes + size
+	payload := buf[writeSpoolRecordHeaderBytes:n]
+	if adler32.Checksum(payload) != checksum {
+		return r, n, errWriteSpoolRecordCorrupt
+	}
+
+	r.wType = writeAttemptType(payload[0])
+	r.spooledAt = time.Unix(0, int64(binary.BigEndian.Uint64(payload[1:])))
+	r.t = time.Unix(0, int64(binary.BigEndian.Uint64(payload[9:])))
+	r.value = math.Float64frombits(binary.BigEndian.Uint64(payload[17:]))
+	r.unit = xtime.Unit(payload[25])
+
+	rest := payload[writeSpoolRecordFixedBytes:]
+	for _, b := range []*[]byte{&r.namespace, &r.id, &r.tags, &r.annotation} {
+		length, read := binary.Uvarint(rest)
+		if read <= 0 || uint64(len(rest)-read) < length {
+			return r, n, errWriteSpoolRecordCorrupt
+		}
+		*b = rest[read : read+int(length)]
+		rest = rest[read+int(length):]
+	}
+	return r, n, nil
+}
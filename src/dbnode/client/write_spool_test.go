@@ -0,0 +1,312 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/topology"
+	"github.com/m3db/m3/src/x/serialize"
+	"github.com/m3db/m3x/ident"
+	"github.com/m3db/m3x/pool"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+)
+
+type testSpooledWrite struct {
+	wType      writeAttemptType
+	namespace  string
+	id         string
+	tags       map[string]string
+	t          time.Time
+	value      float64
+	unit       xtime.Unit
+	annotation string
+}
+
+type testWriteSpool struct {
+	spool  *writeSpool
+	now    *time.Time
+	err    error
+	writes []testSpooledWrite
+}
+
+func newTestWriteSpool(t *testing.T, dir string, now *time.Time) *testWriteSpool {
+	opts := newSessionTestOptions().
+		SetWriteSpoolPath(dir).
+		SetWriteSpoolMaxBytes(1024).
+		SetWriteSpoolBufferPast(10 * time.Minute)
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time {
+		return *now
+	}))
+
+	poolOpts := pool.NewObjectPoolOptions().SetSize(1)
+	tagEncoderPool := serialize.NewTagEncoderPool(
+		serialize.NewTagEncoderOptions(), poolOpts)
+	tagEncoderPool.Init()
+	tagDecoderPool := serialize.NewTagDecoderPool(
+		serialize.NewTagDecoderOptions(), poolOpts)
+	tagDecoderPool.Init()
+
+	ts := &testWriteSpool{now: now}
+	writeFn := func(
+		wType writeAttemptType,
+		namespace, id ident.ID,
+		tags ident.TagIterator,
+		t time.Time,
+		value float64,
+		unit xtime.Unit,
+		annotation []byte,
+	) error {
+		if ts.err != nil {
+			return ts.err
+		}
+		write := testSpooledWrite{
+			wType:      wType,
+			namespace:  namespace.String(),
+			id:         id.String(),
+			tags:       make(map[string]string),
+			t:          t,
+			value:      value,
+			unit:       unit,
+			annotation: string(annotation),
+		}
+		for tags.Next() {
+			tag := tags.Current()
+			write.tags[tag.Name.String()] = tag.Value.String()
+		}
+		if err := tags.Err(); err != nil {
+			return err
+		}
+		ts.writes = append(ts.writes, write)
+		return nil
+	}
+
+	spool, err := newWriteSpool(opts, writeFn, tagEncoderPool, tagDecoderPool,
+		tally.NoopScope)
+	require.NoError(t, err)
+	ts.spool = spool
+	return ts
+}
+
+func (ts *testWriteSpool) spoolWrite(t *testing.T, id string, value float64) error {
+	tags := ident.NewTagsIterator(ident.NewTags(ident.StringTag("id", id)))
+	return ts.spool.spool(taggedWriteAttemptType, ident.StringID("testNs"),
+		ident.StringID(id), tags, *ts.now, value, xtime.Second, []byte("annotation"))
+}
+
+func newTestWriteSpoolConsistencyError() error {
+	return newConsistencyResultError(topology.ConsistencyLevelMajority, 3, 3,
+		[]error{errors.New("a"), errors.New("b"), errors.New("c")})
+}
+
+func TestWriteSpoolReplaysInOrder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "write-spool")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	now := time.Now().Truncate(time.Second)
+	ts := newTestWriteSpool(t, dir, &now)
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, ts.spoolWrite(t, fmt.Sprintf("foo%d", i), float64(i)))
+	}
+	bytes, records := ts.spool.sizeWithLock()
+	require.Equal(t, 5, records)
+	require.True(t, bytes > 0)
+
+	// Hosts are still unavailable so nothing is replayed.
+	ts.err = newTestWriteSpoolConsistencyError()
+	ts.spool.replay()
+	_, records = ts.spool.sizeWithLock()
+	require.Equal(t, 5, records)
+
+	ts.err = nil
+	ts.spool.replay()
+	require.Equal(t, 5, len(ts.writes))
+	for i, write := range ts.writes {
+		id := fmt.Sprintf("foo%d", i)
+		require.Equal(t, taggedWriteAttemptType, write.wType)
+		require.Equal(t, "testNs", write.namespace)
+		require.Equal(t, id, write.id)
+		require.Equal(t, map[string]string{"id": id}, write.tags)
+		require.True(t, now.Equal(write.t))
+		require.Equal(t, float64(i), write.value)
+		require.Equal(t, xtime.Second, write.unit)
+		require.Equal(t, "annotation", write.annotation)
+	}
+
+	bytes, records = ts.spool.sizeWithLock()
+	require.Equal(t, 0, records)
+	require.Equal(t, int64(0), bytes)
+	matches, err := filepath.Glob(filepath.Join(dir, "*"))
+	require.NoError(t, err)
+	require.Equal(t, 0, len(matches))
+
+	ts.spool.close()
+}
+
+func TestWriteSpoolDropsPointsOlderThanBufferPast(t *testing.T) {
+	dir, err := ioutil.TempDir("", "write-spool")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	now := time.Now().Truncate(time.Second)
+	ts := newTestWriteSpool(t, dir, &now)
+
+	require.NoError(t, ts.spoolWrite(t, "old", 1))
+	now = now.Add(5 * time.Minute)
+	require.NoError(t, ts.spoolWrite(t, "new", 2))
+
+	now = now.Add(6 * time.Minute)
+	ts.spool.replay()
+	require.Equal(t, 1, len(ts.writes))
+	require.Equal(t, "new", ts.writes[0].id)
+
+	ts.spool.close()
+}
+
+func TestWriteSpoolDropsCorruptWrites(t *testing.T) {
+	dir, err := ioutil.TempDir("", "write-spool")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	now := time.Now().Truncate(time.Second)
+	ts := newTestWriteSpool(t, dir, &now)
+	for _, id := range []string{"foo", "bar", "baz"} {
+		require.NoError(t, ts.spoolWrite(t, id, 1))
+	}
+
+	// Corrupt the last byte of the second write so that its checksum doesn't
+	// match but its length is intact.
+	path := ts.spool.segments[0].path
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	_, first, err := decodeWriteSpoolRecord(data)
+	require.NoError(t, err)
+	_, second, err := decodeWriteSpoolRecord(data[first:])
+	require.NoError(t, err)
+	data[first+second-1]++
+	require.NoError(t, ioutil.WriteFile(path, data, 0644))
+
+	ts.spool.replay()
+	require.Equal(t, 2, len(ts.writes))
+	require.Equal(t, "foo", ts.writes[0].id)
+	require.Equal(t, "baz", ts.writes[1].id)
+	_, records := ts.spool.sizeWithLock()
+	require.Equal(t, 0, records)
+
+	ts.spool.close()
+}
+
+func TestWriteSpoolDropsWritesThatFailWithOtherErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "write-spool")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	now := time.Now().Truncate(time.Second)
+	ts := newTestWriteSpool(t, dir, &now)
+	require.NoError(t, ts.spoolWrite(t, "foo", 1))
+	require.NoError(t, ts.spoolWrite(t, "bar", 2))
+
+	// Network errors mean hosts are still unavailable so nothing is replayed.
+	ts.err = &net.OpError{Op: "dial", Err: errors.New("connection refused")}
+	ts.spool.replay()
+	_, records := ts.spool.sizeWithLock()
+	require.Equal(t, 2, records)
+
+	// Other errors don't hold up the writes spooled after them.
+	ts.err = errors.New("unable to write")
+	ts.spool.replay()
+	_, records = ts.spool.sizeWithLock()
+	require.Equal(t, 0, records)
+	require.Equal(t, 0, len(ts.writes))
+
+	ts.spool.close()
+}
+
+func TestWriteSpoolFull(t *testing.T) {
+	dir, err := ioutil.TempDir("", "write-spool")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	now := time.Now()
+	ts := newTestWriteSpool(t, dir, &now)
+
+	for {
+		err := ts.spoolWrite(t, "foo", 1)
+		if err != nil {
+			require.Equal(t, errWriteSpoolFull, err)
+			break
+		}
+	}
+	bytes, _ := ts.spool.sizeWithLock()
+	require.True(t, bytes <= 1024)
+
+	ts.spool.close()
+}
+
+func TestWriteSpoolLoadsSegmentsOnOpen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "write-spool")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	now := time.Now()
+	ts := newTestWriteSpool(t, dir, &now)
+	require.NoError(t, ts.spoolWrite(t, "foo", 1))
+	require.NoError(t, ts.spoolWrite(t, "bar", 2))
+	ts.spool.close()
+
+	ts = newTestWriteSpool(t, dir, &now)
+	_, records := ts.spool.sizeWithLock()
+	require.Equal(t, 2, records)
+
+	// New writes are spooled after the loaded writes.
+	require.NoError(t, ts.spoolWrite(t, "baz", 3))
+	ts.spool.replay()
+	require.Equal(t, 3, len(ts.writes))
+	require.Equal(t, "foo", ts.writes[0].id)
+	require.Equal(t, "bar", ts.writes[1].id)
+	require.Equal(t, "baz", ts.writes[2].id)
+
+	ts.spool.close()
+}
+
+func TestWriteSpoolShouldSpool(t *testing.T) {
+	dir, err := ioutil.TempDir("", "write-spool")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	now := time.Now()
+	ts := newTestWriteSpool(t, dir, &now)
+	require.True(t, ts.spool.shouldSpool(newTestWriteSpoolConsistencyError()))
+	require.False(t, ts.spool.shouldSpool(errors.New("not a consistency error")))
+	ts.spool.close()
+}
@@ -54,13 +54,46 @@ type writeState struct {
 	nsID              ident.ID
 	tsID              ident.ID
 	tagEncoder        serialize.TagEncoder
+	replicas          int32
 	majority, pending int32
 	success           int32
 	errors            []error
 
+	// The following track acks from the owners of a shard that's being moved
+	// between hosts, a leaving owner and the initializing owner streaming the
+	// shard from it count as a single replica once both have acked the write.
+	ownersResolved    bool
+	hasLeaving        bool
+	hasInitializing   bool
+	leavingAcked      []string
+	initializingAcked []initializingAck
+
 	queues         []hostQueue
 	tagEncoderPool serialize.TagEncoderPool
 	pool           *writeStatePool
+
+	// The following are set for async writes, onComplete is called with the
+	// result of resultFn once the consistency level is met or can no longer
+	// be met, and the bytes in flight are released once the state is closed.
+	async      *asyncWrites
+	asyncBytes int64
+	enqueued   int32
+	resultFn   writeStateResultFn
+	onComplete WriteCompletionFn
+	completed  bool
+
+	// The following are set for read your writes sessions, the hosts that
+	// acknowledged the write by the time it's done are recorded once.
+	readYourWrites *readYourWrites
+	acked          []string
+	recorded       bool
+}
+
+type writeStateResultFn func(w *writeState) error
+
+type initializingAck struct {
+	hostID   string
+	sourceID string
 }
 
 func newWriteState(
@@ -86,7 +119,29 @@ func (w *writeState) close() {
 		enc.Finalize()
 	}
 
-	w.op, w.majority, w.pending, w.success = nil, 0, 0, 0
+	if w.async != nil {
+		w.async.release(w.asyncBytes)
+	}
+	w.async, w.asyncBytes, w.enqueued = nil, 0, 0
+	w.resultFn, w.onComplete, w.completed = nil, nil, false
+
+	w.readYourWrites, w.recorded = nil, false
+	for i := range w.acked {
+		w.acked[i] = ""
+	}
+	w.acked = w.acked[:0]
+
+	w.ownersResolved, w.hasLeaving, w.hasInitializing = false, false, false
+	for i := range w.leavingAcked {
+		w.leavingAcked[i] = ""
+	}
+	w.leavingAcked = w.leavingAcked[:0]
+	for i := range w.initializingAcked {
+		w.initializingAcked[i] = initializingAck{}
+	}
+	w.initializingAcked = w.initializingAcked[:0]
+
+	w.op, w.replicas, w.majority, w.pending, w.success = nil, 0, 0, 0, 0
 	w.nsID, w.tsID, w.tagEncoder = nil, nil, nil
 
 	for i := range w.errors {
@@ -119,48 +174,155 @@ func (w *writeState) completionFn(result interface{}, err error) {
 	} else if hostShardSet, ok := w.topoMap.LookupHostShardSet(hostID); !ok {
 		errStr := "missing host shard in writeState completionFn: %s"
 		wErr = xerrors.NewRetryableError(fmt.Errorf(errStr, hostID))
-	} else if shardState, err := hostShardSet.ShardSet().LookupStateByID(w.op.ShardID()); err != nil {
+	} else if hostShard, err := hostShardSet.ShardSet().LookupShard(w.op.ShardID()); err != nil {
 		errStr := "missing shard %d in host %s"
 		wErr = xerrors.NewRetryableError(fmt.Errorf(errStr, w.op.ShardID(), hostID))
-	} else if shardState != shard.Available {
-		// NB(bl): only count writes to available shards towards success
-		var errStr string
-		switch shardState {
-		case shard.Initializing:
-			errStr = "shard %d in host %s is not available (initializing)"
+	} else {
+		switch hostShard.State() {
+		case shard.Available:
+			w.ackWithLock(hostID)
 		case shard.Leaving:
-			errStr = "shard %d in host %s not available (leaving)"
+			w.leavingAckWithLock(hostID)
+		case shard.Initializing:
+			if !w.initializingAckWithLock(hostID, hostShard.SourceID()) {
+				// NB: an initializing shard that's not replacing a leaving
+				// shard adds a replica that's not counted until it's available.
+				errStr := "shard %d in host %s is not available (initializing)"
+				wErr = xerrors.NewRetryableError(fmt.Errorf(errStr, w.op.ShardID(), hostID))
+			}
 		default:
-			errStr = "shard %d in host %s not available (unknown state)"
+			errStr := "shard %d in host %s not available (unknown state)"
+			wErr = xerrors.NewRetryableError(fmt.Errorf(errStr, w.op.ShardID(), hostID))
 		}
-		wErr = xerrors.NewRetryableError(fmt.Errorf(errStr, w.op.ShardID(), hostID))
-	} else {
-		w.success++
 	}
 
 	if wErr != nil {
 		w.errors = append(w.errors, wErr)
 	}
 
+	var done bool
 	switch w.consistencyLevel {
 	case topology.ConsistencyLevelOne:
-		if w.success > 0 || w.pending == 0 {
-			w.Signal()
-		}
+		done = w.success > 0 || w.pending == 0
 	case topology.ConsistencyLevelMajority:
-		if w.success >= w.majority || w.pending == 0 {
-			w.Signal()
-		}
+		done = w.success >= w.majority || w.pending == 0
 	case topology.ConsistencyLevelAll:
-		if w.pending == 0 {
-			w.Signal()
-		}
+		done = w.pending == 0
+	}
+
+	var (
+		onComplete WriteCompletionFn
+		result     error
+	)
+	if done {
+		w.Signal()
+		onComplete, result = w.completeAsyncWithLock()
+		w.recordReadYourWritesWithLock()
 	}
 
 	w.Unlock()
+	if onComplete != nil {
+		onComplete(result)
+	}
 	w.decRef()
 }
 
+// ackWithLock counts a write acked by the given hosts as a single replica.
+func (w *writeState) ackWithLock(hostIDs ...string) {
+	w.success++
+	if w.readYourWrites != nil {
+		w.acked = append(w.acked, hostIDs...)
+	}
+}
+
+// leavingAckWithLock counts an ack from a leaving owner of the shard, it's
+// paired with an ack from the initializing owner replacing it if there is one.
+func (w *writeState) leavingAckWithLock(hostID string) {
+	w.resolveOwnersWithLock()
+	if !w.hasInitializing {
+		// The shard is being removed without a replacement so the leaving
+		// owner is still a replica in its own right.
+		w.ackWithLock(hostID)
+		return
+	}
+	for i, ack := range w.initializingAcked {
+		if ack.sourceID == "" || ack.sourceID == hostID {
+			w.initializingAcked = append(w.initializingAcked[:i], w.initializingAcked[i+1:]...)
+			w.ackWithLock(hostID, ack.hostID)
+			return
+		}
+	}
+	w.leavingAcked = append(w.leavingAcked, hostID)
+}
+
+// initializingAckWithLock counts an ack from an initializing owner of the
+// shard and returns whether the ack counts towards a replica, it's paired
+// with an ack from the leaving owner it's replacing if it already acked.
+func (w *writeState) initializingAckWithLock(hostID, sourceID string) bool {
+	w.resolveOwnersWithLock()
+	if !w.hasLeaving {
+		return false
+	}
+	for i, leavingHostID := range w.leavingAcked {
+		if sourceID == "" || sourceID == leavingHostID {
+			w.leavingAcked = append(w.leavingAcked[:i], w.leavingAcked[i+1:]...)
+			w.ackWithLock(leavingHostID, hostID)
+			return true
+		}
+	}
+	w.initializingAcked = append(w.initializingAcked, initializingAck{
+		hostID:   hostID,
+		sourceID: sourceID,
+	})
+	return true
+}
+
+// resolveOwnersWithLock resolves whether the shard has leaving and
+// initializing owners the first time it's called.
+func (w *writeState) resolveOwnersWithLock() {
+	if w.ownersResolved {
+		return
+	}
+	w.ownersResolved = true
+	shardID := w.op.ShardID()
+	w.topoMap.RouteShardForEach(shardID, func(_ int, host topology.Host) {
+		hostShardSet, ok := w.topoMap.LookupHostShardSet(host.ID())
+		if !ok {
+			return
+		}
+		state, err := hostShardSet.ShardSet().LookupStateByID(shardID)
+		if err != nil {
+			return
+		}
+		switch state {
+		case shard.Leaving:
+			w.hasLeaving = true
+		case shard.Initializing:
+			w.hasInitializing = true
+		}
+	})
+}
+
+// completeAsyncWithLock returns the completion fn and result of an async
+// write the first time it's called once the write is done.
+func (w *writeState) completeAsyncWithLock() (WriteCompletionFn, error) {
+	if w.onComplete == nil || w.completed {
+		return nil, nil
+	}
+	w.completed = true
+	return w.onComplete, w.resultFn(w)
+}
+
+// recordReadYourWritesWithLock records the hosts that acknowledged the write
+// the first time it's called once the write is done.
+func (w *writeState) recordReadYourWritesWithLock() {
+	if w.readYourWrites == nil || w.recorded {
+		return
+	}
+	w.recorded = true
+	w.readYourWrites.recordWrite(w.nsID, w.op.ShardID(), w.acked)
+}
+
 type writeStatePool struct {
 	pool           pool.ObjectPool
 	tagEncoderPool serialize.TagEncoderPool
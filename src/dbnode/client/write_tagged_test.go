@@ -63,7 +63,8 @@ func TestWriteTaggedToInitializingShards(t *testing.T) {
 }
 
 func TestWriteTaggedToLeavingShards(t *testing.T) {
-	testWriteTaggedSuccess(t, shard.Leaving, false)
+	// A leaving shard with no initializing replacement is still a replica.
+	testWriteTaggedSuccess(t, shard.Leaving, true)
 }
 
 // retryability test
@@ -27,8 +27,10 @@ import (
 
 	"github.com/m3db/m3/src/cluster/shard"
 	tterrors "github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/errors"
+	"github.com/m3db/m3/src/dbnode/sharding"
 	"github.com/m3db/m3/src/dbnode/topology"
 	xerrors "github.com/m3db/m3x/errors"
+	"github.com/m3db/m3x/ident"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -62,7 +64,76 @@ func TestWriteToInitializingShards(t *testing.T) {
 }
 
 func TestWriteToLeavingShards(t *testing.T) {
-	testWriteSuccess(t, shard.Leaving, false)
+	// A leaving shard with no initializing replacement is still a replica.
+	testWriteSuccess(t, shard.Leaving, true)
+}
+
+func testWriteToMovingShard(t *testing.T, sourceID string, leavingFirst bool, success bool) {
+	var writeWg sync.WaitGroup
+
+	wState, s, _ := writeTestSetup(t, &writeWg)
+	hosts := s.state.topoMap.Hosts()
+	leaving, initializing := hosts[0], hosts[1]
+	wState.topoMap = movingShardTopoMap(s, map[string]shard.Shard{
+		leaving.ID():      shard.NewShard(0).SetState(shard.Leaving),
+		initializing.ID(): shard.NewShard(0).SetState(shard.Initializing).SetSourceID(sourceID),
+	})
+
+	first, second := initializing, leaving
+	if leavingFirst {
+		first, second = leaving, initializing
+	}
+
+	// Neither owner counts as a replica until both have acked.
+	wState.completionFn(first, nil)
+	assert.Equal(t, int32(0), wState.success)
+	assert.Equal(t, 0, len(wState.errors))
+
+	wState.completionFn(second, nil)
+	if success {
+		assert.Equal(t, int32(1), wState.success)
+	} else {
+		assert.Equal(t, int32(0), wState.success)
+	}
+	assert.Equal(t, 0, len(wState.errors))
+
+	writeTestTeardown(wState, &writeWg)
+}
+
+func TestWriteToMovingShardLeavingAckFirst(t *testing.T) {
+	testWriteToMovingShard(t, "", true, true)
+}
+
+func TestWriteToMovingShardInitializingAckFirst(t *testing.T) {
+	testWriteToMovingShard(t, "", false, true)
+}
+
+func TestWriteToMovingShardWithSourceID(t *testing.T) {
+	testWriteToMovingShard(t, testHostName(0), true, true)
+	testWriteToMovingShard(t, testHostName(0), false, true)
+}
+
+func TestWriteToMovingShardWithOtherSourceID(t *testing.T) {
+	testWriteToMovingShard(t, testHostName(2), true, false)
+	testWriteToMovingShard(t, testHostName(2), false, false)
+}
+
+func TestWriteToInitializingShardAddingReplica(t *testing.T) {
+	var writeWg sync.WaitGroup
+
+	wState, s, _ := writeTestSetup(t, &writeWg)
+	initializing := s.state.topoMap.Hosts()[0]
+	wState.topoMap = movingShardTopoMap(s, map[string]shard.Shard{
+		initializing.ID(): shard.NewShard(0).SetState(shard.Initializing),
+	})
+
+	// An initializing shard that's not replacing a leaving shard only counts
+	// once it's available.
+	wState.completionFn(initializing, nil)
+	assert.Equal(t, int32(0), wState.success)
+	retryabilityCheck(t, wState, xerrors.IsRetryableError)
+
+	writeTestTeardown(wState, &writeWg)
 }
 
 // retryability test
@@ -94,6 +165,10 @@ func TestBadRequestError(t *testing.T) {
 	simpleRetryableTest(t, tterrors.NewBadRequestError(errors.New("")), nil, IsBadRequestError)
 }
 
+func TestResourceExhaustedError(t *testing.T) {
+	simpleRetryableTest(t, tterrors.NewResourceExhaustedError(errors.New("")), nil, IsResourceExhaustedError)
+}
+
 func TestRetryableError(t *testing.T) {
 	simpleRetryableTest(t, xerrors.NewRetryableError(errors.New("")), nil, xerrors.IsRetryableError)
 }
@@ -150,11 +225,37 @@ func setShardStates(t *testing.T, s *session, host topology.Host, state shard.St
 	}
 }
 
+// movingShardTopoMap returns a topology map with the session's hosts where
+// each host owns shard 0 as available unless another shard is given for it.
+func movingShardTopoMap(s *session, shards map[string]shard.Shard) topology.Map {
+	s.state.RLock()
+	hosts := s.state.topoMap.Hosts()
+	replicas := s.state.topoMap.Replicas()
+	s.state.RUnlock()
+
+	hashFn := func(id ident.ID) uint32 { return 0 }
+	var hostShardSets []topology.HostShardSet
+	for _, host := range hosts {
+		hostShard, ok := shards[host.ID()]
+		if !ok {
+			hostShard = shard.NewShard(0).SetState(shard.Available)
+		}
+		shardSet, _ := sharding.NewShardSet([]shard.Shard{hostShard}, hashFn)
+		hostShardSets = append(hostShardSets, topology.NewHostShardSet(host, shardSet))
+	}
+	allShardSet, _ := sharding.NewShardSet(sharding.NewShards([]uint32{0}, shard.Available), hashFn)
+	return topology.NewStaticMap(topology.NewStaticOptions().
+		SetReplicas(replicas).
+		SetShardSet(allShardSet).
+		SetHostShardSets(hostShardSets))
+}
+
 type fakeHost struct{ id string }
 
-func (f fakeHost) ID() string      { return f.id }
-func (f fakeHost) Address() string { return "" }
-func (f fakeHost) String() string  { return "" }
+func (f fakeHost) ID() string             { return f.id }
+func (f fakeHost) Address() string        { return "" }
+func (f fakeHost) IsolationGroup() string { return "" }
+func (f fakeHost) String() string         { return "" }
 
 func writeTestSetup(t *testing.T, writeWg *sync.WaitGroup) (*writeState, *session, topology.Host) {
 	ctrl := gomock.NewController(t)
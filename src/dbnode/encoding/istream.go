@@ -38,6 +38,7 @@ type istream struct {
 	err       error         // error encountered
 	current   byte          // current byte we are working off of
 	remaining int           // bits remaining in current to be read
+	bytesRead int           // bytes read from the encoded stream
 }
 
 // NewIStream creates a new Istream
@@ -142,13 +143,22 @@ func (is *istream) consumeBuffer(numBits int) byte {
 func (is *istream) readByteFromStream() error {
 	is.current, is.err = is.r.ReadByte()
 	is.remaining = 8
+	if is.err == nil {
+		is.bytesRead++
+	}
 	return is.err
 }
 
+// BitsRead returns the number of bits read since the Istream was last reset
+func (is *istream) BitsRead() int {
+	return is.bytesRead*8 - is.remaining
+}
+
 // Reset resets the Istream
 func (is *istream) Reset(r io.Reader) {
 	is.r.Reset(r)
 	is.err = nil
 	is.current = 0
 	is.remaining = 0
+	is.bytesRead = 0
 }
@@ -76,6 +76,7 @@ func TestPeekBitsSuccess(t *testing.T) {
 	require.NoError(t, is.err)
 	require.Equal(t, byte(0), is.current)
 	require.Equal(t, 0, is.remaining)
+	require.Equal(t, 0, is.bytesRead)
 }
 
 func TestPeekBitsError(t *testing.T) {
@@ -113,6 +114,34 @@ func TestReadAfterPeekBits(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestBitsRead(t *testing.T) {
+	byteStream := []byte{0xab, 0xcd, 0xef}
+	is := NewIStream(bytes.NewReader(byteStream))
+	require.Equal(t, 0, is.BitsRead())
+
+	_, err := is.PeekBits(12)
+	require.NoError(t, err)
+	require.Equal(t, 0, is.BitsRead())
+
+	inputs := []struct {
+		numBits  int
+		expected int
+	}{
+		{3, 3},
+		{8, 11},
+		{5, 16},
+		{2, 18},
+	}
+	for _, input := range inputs {
+		_, err := is.ReadBits(input.numBits)
+		require.NoError(t, err)
+		require.Equal(t, input.expected, is.BitsRead())
+	}
+
+	is.Reset(bytes.NewReader(byteStream))
+	require.Equal(t, 0, is.BitsRead())
+}
+
 func TestResetIStream(t *testing.T) {
 	o := NewIStream(bytes.NewReader(nil))
 	is := o.(*istream)
@@ -122,4 +151,5 @@ func TestResetIStream(t *testing.T) {
 	require.NoError(t, is.err)
 	require.Equal(t, byte(0), is.current)
 	require.Equal(t, 0, is.remaining)
+	require.Equal(t, 0, is.bytesRead)
 }
@@ -60,14 +60,14 @@ func (i *iterators) current() (ts.Datapoint, xtime.Unit, ts.Annotation) {
 
 	switch i.equalTimesStrategy {
 	case IterateHighestValue:
-		sort.Slice(i.earliest, func(a, b int) bool {
+		sort.SliceStable(i.earliest, func(a, b int) bool {
 			currA, _, _ := i.earliest[a].Current()
 			currB, _, _ := i.earliest[b].Current()
 			return currA.Value < currB.Value
 		})
 
 	case IterateLowestValue:
-		sort.Slice(i.earliest, func(a, b int) bool {
+		sort.SliceStable(i.earliest, func(a, b int) bool {
 			currA, _, _ := i.earliest[a].Current()
 			currB, _, _ := i.earliest[b].Current()
 			return currA.Value > currB.Value
@@ -84,7 +84,7 @@ func (i *iterators) current() (ts.Datapoint, xtime.Unit, ts.Annotation) {
 		}
 
 		// Sort
-		sort.Slice(i.earliest, func(a, b int) bool {
+		sort.SliceStable(i.earliest, func(a, b int) bool {
 			currA, _, _ := i.earliest[a].Current()
 			currB, _, _ := i.earliest[b].Current()
 			freqA := i.valueFrequencies[currA.Value]
@@ -97,6 +97,9 @@ func (i *iterators) current() (ts.Datapoint, xtime.Unit, ts.Annotation) {
 			delete(i.valueFrequencies, key)
 		}
 
+	case IterateFirstPushed:
+		return i.earliest[0].Current()
+
 	default:
 		// IterateLastPushed or unknown strategy code path, don't panic on unknown
 		// as this is an internal data structure and this option is validated at a
@@ -173,7 +176,9 @@ func (i *iterators) moveToValidNext() (bool, error) {
 			continue
 		}
 
-		// No next so swap tail in and shrink by one
+		// No next so remove and shrink by one, the order of the remaining
+		// iterators is retained so that strategies which depend on the order
+		// the iterators were pushed in select values deterministically.
 		iter.Close()
 		idx := -1
 		for i, curr := range i.values {
@@ -182,7 +187,7 @@ func (i *iterators) moveToValidNext() (bool, error) {
 				break
 			}
 		}
-		i.values[idx] = i.values[n-1]
+		copy(i.values[idx:], i.values[idx+1:])
 		i.values[n-1] = nil
 		i.values = i.values[:n-1]
 		n = n - 1
@@ -88,6 +88,45 @@ func TestIteratorsIterateLowestValue(t *testing.T) {
 	assertIteratorsValues(t, iters, testValues, lastTestValues)
 }
 
+func TestIteratorsIterateFirstPushed(t *testing.T) {
+	testValues := commonTestValues
+	firstTestValues := commonTestValues[0]
+
+	iters := iterators{equalTimesStrategy: IterateFirstPushed}
+	iters.reset()
+
+	assertIteratorsValues(t, iters, testValues, firstTestValues)
+}
+
+func TestIteratorsIterateLastPushedRetainsOrder(t *testing.T) {
+	testValues := [][]testValue{
+		[]testValue{
+			{t: at, value: 1.0, unit: xtime.Second},
+			{t: at.Add(time.Second), value: 4.0, unit: xtime.Second},
+		},
+		[]testValue{
+			{t: at, value: 2.0, unit: xtime.Second},
+		},
+		[]testValue{
+			{t: at, value: 3.0, unit: xtime.Second},
+			{t: at.Add(time.Second), value: 5.0, unit: xtime.Second},
+		},
+		[]testValue{
+			{t: at, value: 6.0, unit: xtime.Second},
+			{t: at.Add(time.Second), value: 7.0, unit: xtime.Second},
+		},
+	}
+	lastTestValues := []testValue{
+		testValues[3][0],
+		testValues[3][1],
+	}
+
+	iters := iterators{equalTimesStrategy: IterateLastPushed}
+	iters.reset()
+
+	assertIteratorsValues(t, iters, testValues, lastTestValues)
+}
+
 func TestIteratorsIterateHighestFrequencyValue(t *testing.T) {
 	testValues := [][]testValue{
 		[]testValue{
@@ -50,6 +50,10 @@ const (
 	// reliably if you wait for values from all replicas to be retrieved, i.e.
 	// you cannot use this reliably with quorum/majority consistency.
 	IterateHighestFrequencyValue
+	// IterateFirstPushed is useful for within a single replica when the value
+	// that arrived first should be kept, it is the inverse of IterateLastPushed
+	// and similarly relies on the order of the buffers passed to the iterators.
+	IterateFirstPushed
 
 	// DefaultIterateEqualTimestampStrategy is the default iterate
 	// equal timestamp strategy.
@@ -62,6 +66,7 @@ var (
 		IterateHighestValue,
 		IterateLowestValue,
 		IterateHighestFrequencyValue,
+		IterateFirstPushed,
 	}
 )
 
@@ -85,6 +90,8 @@ func (s IterateEqualTimestampStrategy) String() string {
 		return "iterate_lowest_value"
 	case IterateHighestFrequencyValue:
 		return "iterate_highest_frequency_value"
+	case IterateFirstPushed:
+		return "iterate_first_pushed"
 	}
 	return "unknown"
 }
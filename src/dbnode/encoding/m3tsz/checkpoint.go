@@ -0,0 +1,227 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package m3tsz
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	xtime "github.com/m3db/m3x/time"
+)
+
+var (
+	errCheckpointIntervalInvalid = errors.New("checkpoint interval must be positive")
+	errCheckpointsCorrupt        = errors.New("checkpoints are corrupt")
+)
+
+// Checkpoint is the state of a reader iterator in between two datapoints of an
+// encoded stream, it allows a reader iterator to resume decoding the stream at
+// the checkpoint without decoding the datapoints that precede it.
+type Checkpoint struct {
+	// BitOffset is the offset of the checkpoint into the stream in bits.
+	BitOffset int
+	// Timestamp is the timestamp of the datapoint preceding the checkpoint.
+	Timestamp time.Time
+	TimeDelta time.Duration
+	TimeUnit  xtime.Unit
+	FloatBits uint64
+	FloatXOR  uint64
+	IntVal    float64
+	Mult      uint8
+	Sig       uint8
+	IsFloat   bool
+}
+
+// ByteOffset returns the offset of the byte of the stream the checkpoint is in.
+func (c Checkpoint) ByteOffset() int {
+	return c.BitOffset / 8
+}
+
+// Checkpoints decodes a stream and returns a checkpoint for each interval
+// boundary crossed by its datapoints, placed right before the first datapoint
+// at or after the boundary.
+func Checkpoints(
+	reader io.Reader,
+	interval time.Duration,
+	intOptimized bool,
+	opts encoding.Options,
+) ([]Checkpoint, error) {
+	if interval <= 0 {
+		return nil, errCheckpointIntervalInvalid
+	}
+
+	var (
+		it          = NewReaderIterator(reader, intOptimized, opts).(*readerIterator)
+		checkpoints []Checkpoint
+		prevBucket  time.Time
+	)
+	for {
+		checkpoint := it.checkpoint()
+		if !it.Next() {
+			break
+		}
+		bucket := it.t.Truncate(interval)
+		if !checkpoint.Timestamp.IsZero() && !bucket.Equal(prevBucket) {
+			checkpoints = append(checkpoints, checkpoint)
+		}
+		prevBucket = bucket
+	}
+	if err := it.Err(); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return checkpoints, nil
+}
+
+// CheckpointBefore returns the last checkpoint preceded by datapoints before
+// the given time only, which is where decoding datapoints at or after the
+// time can resume from.
+func CheckpointBefore(checkpoints []Checkpoint, t time.Time) (Checkpoint, bool) {
+	idx := sort.Search(len(checkpoints), func(i int) bool {
+		return !checkpoints[i].Timestamp.Before(t)
+	})
+	if idx == 0 {
+		return Checkpoint{}, false
+	}
+	return checkpoints[idx-1], true
+}
+
+// NewReaderIteratorFromCheckpoint returns a new iterator that resumes decoding
+// a stream at the given checkpoint, the reader must start at the byte of the
+// stream that the checkpoint is in.
+func NewReaderIteratorFromCheckpoint(
+	reader io.Reader,
+	checkpoint Checkpoint,
+	intOptimized bool,
+	opts encoding.Options,
+) encoding.ReaderIterator {
+	it := NewReaderIterator(reader, intOptimized, opts).(*readerIterator)
+	it.t = checkpoint.Timestamp
+	it.dt = checkpoint.TimeDelta
+	it.tu = checkpoint.TimeUnit
+	it.vb = checkpoint.FloatBits
+	it.xor = checkpoint.FloatXOR
+	it.intVal = checkpoint.IntVal
+	it.mult = checkpoint.Mult
+	it.sig = checkpoint.Sig
+	it.isFloat = checkpoint.IsFloat
+	if numBits := checkpoint.BitOffset % 8; numBits > 0 {
+		it.readBits(numBits)
+	}
+	return it
+}
+
+func (it *readerIterator) checkpoint() Checkpoint {
+	return Checkpoint{
+		BitOffset: it.is.BitsRead(),
+		Timestamp: it.t,
+		TimeDelta: it.dt,
+		TimeUnit:  it.tu,
+		FloatBits: it.vb,
+		FloatXOR:  it.xor,
+		IntVal:    it.intVal,
+		Mult:      it.mult,
+		Sig:       it.sig,
+		IsFloat:   it.isFloat,
+	}
+}
+
+// MarshalCheckpoints appends the binary representation of checkpoints to b.
+func MarshalCheckpoints(b []byte, checkpoints []Checkpoint) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	putUvarint := func(v uint64) {
+		n := binary.PutUvarint(buf[:], v)
+		b = append(b, buf[:n]...)
+	}
+	putVarint := func(v int64) {
+		n := binary.PutVarint(buf[:], v)
+		b = append(b, buf[:n]...)
+	}
+
+	putUvarint(uint64(len(checkpoints)))
+	for _, c := range checkpoints {
+		putUvarint(uint64(c.BitOffset))
+		putVarint(c.Timestamp.UnixNano())
+		putVarint(int64(c.TimeDelta))
+		putUvarint(c.FloatBits)
+		putUvarint(c.FloatXOR)
+		putUvarint(math.Float64bits(c.IntVal))
+		isFloat := byte(0)
+		if c.IsFloat {
+			isFloat = 1
+		}
+		b = append(b, byte(c.TimeUnit), c.Mult, c.Sig, isFloat)
+	}
+	return b
+}
+
+// UnmarshalCheckpoints returns the checkpoints from their binary representation.
+func UnmarshalCheckpoints(b []byte) ([]Checkpoint, error) {
+	var corrupt bool
+	uvarint := func() uint64 {
+		v, n := binary.Uvarint(b)
+		if n <= 0 {
+			corrupt = true
+			return 0
+		}
+		b = b[n:]
+		return v
+	}
+	varint := func() int64 {
+		v, n := binary.Varint(b)
+		if n <= 0 {
+			corrupt = true
+			return 0
+		}
+		b = b[n:]
+		return v
+	}
+
+	n := uvarint()
+	if corrupt || n > uint64(len(b)) {
+		return nil, errCheckpointsCorrupt
+	}
+	checkpoints := make([]Checkpoint, 0, n)
+	for i := uint64(0); i < n; i++ {
+		c := Checkpoint{
+			BitOffset: int(uvarint()),
+			Timestamp: time.Unix(0, varint()),
+			TimeDelta: time.Duration(varint()),
+			FloatBits: uvarint(),
+			FloatXOR:  uvarint(),
+			IntVal:    math.Float64frombits(uvarint()),
+		}
+		if corrupt || len(b) < 4 {
+			return nil, errCheckpointsCorrupt
+		}
+		c.TimeUnit = xtime.Unit(b[0])
+		c.Mult = b[1]
+		c.Sig = b[2]
+		c.IsFloat = b[3] == 1
+		b = b[4:]
+		checkpoints = append(checkpoints, c)
+	}
+	return checkpoints, nil
+}
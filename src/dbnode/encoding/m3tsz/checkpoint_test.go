@@ -0,0 +1,193 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package m3tsz
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/ts"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testCheckpointDatapoint struct {
+	dp  ts.Datapoint
+	tu  xtime.Unit
+	ant ts.Annotation
+}
+
+func generateCheckpointDatapoints(t *testing.T) []testCheckpointDatapoint {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	var (
+		res   []testCheckpointDatapoint
+		curr  = testStartTime
+		value = 100.0
+	)
+	for i := 0; i < 1000; i++ {
+		curr = curr.Add(time.Duration(1+r.Intn(10)) * time.Second)
+		switch r.Intn(4) {
+		case 0:
+			value = float64(r.Intn(1000))
+		case 1:
+			value = r.Float64() * 1000
+		case 2:
+			value = float64(r.Intn(100000)) / 100
+		}
+		dp := testCheckpointDatapoint{
+			dp: ts.Datapoint{Timestamp: curr, Value: value},
+			tu: xtime.Second,
+		}
+		if i%100 == 50 {
+			dp.dp.Timestamp = dp.dp.Timestamp.Add(time.Millisecond)
+			dp.tu = xtime.Millisecond
+			dp.ant = ts.Annotation{byte(i)}
+		}
+		curr = dp.dp.Timestamp
+		res = append(res, dp)
+	}
+	return res
+}
+
+func encodeCheckpointDatapoints(
+	t *testing.T,
+	input []testCheckpointDatapoint,
+	intOpt bool,
+) []byte {
+	encoder := NewEncoder(testStartTime, nil, intOpt, nil)
+	for _, v := range input {
+		require.NoError(t, encoder.Encode(v.dp, v.tu, v.ant))
+	}
+	data, err := ioutil.ReadAll(encoder.Stream())
+	require.NoError(t, err)
+	return data
+}
+
+func decodeCheckpointDatapoints(
+	t *testing.T,
+	it encoding.ReaderIterator,
+) []testCheckpointDatapoint {
+	var res []testCheckpointDatapoint
+	for it.Next() {
+		dp, tu, ant := it.Current()
+		res = append(res, testCheckpointDatapoint{dp: dp, tu: tu, ant: ant})
+	}
+	require.NoError(t, it.Err())
+	return res
+}
+
+func TestCheckpointsResumeDecoding(t *testing.T) {
+	for _, intOpt := range []bool{true, false} {
+		input := generateCheckpointDatapoints(t)
+		data := encodeCheckpointDatapoints(t, input, intOpt)
+
+		interval := 10 * time.Minute
+		checkpoints, err := Checkpoints(bytes.NewReader(data), interval, intOpt, encoding.NewOptions())
+		require.NoError(t, err)
+		require.True(t, len(checkpoints) > 0)
+
+		all := decodeCheckpointDatapoints(t,
+			NewReaderIterator(bytes.NewReader(data), intOpt, encoding.NewOptions()))
+		require.Equal(t, len(input), len(all))
+
+		for _, checkpoint := range checkpoints {
+			it := NewReaderIteratorFromCheckpoint(bytes.NewReader(data[checkpoint.ByteOffset():]),
+				checkpoint, intOpt, encoding.NewOptions())
+			resumed := decodeCheckpointDatapoints(t, it)
+			require.True(t, len(resumed) > 0)
+
+			// The checkpoint is right before the first datapoint of its interval.
+			first := resumed[0].dp.Timestamp
+			require.True(t, checkpoint.Timestamp.Truncate(interval).Before(first.Truncate(interval)))
+			require.Equal(t, all[len(all)-len(resumed):], resumed)
+		}
+	}
+}
+
+func TestCheckpointsInvalidInterval(t *testing.T) {
+	_, err := Checkpoints(bytes.NewReader(nil), 0, true, encoding.NewOptions())
+	require.Equal(t, errCheckpointIntervalInvalid, err)
+}
+
+func TestCheckpointBefore(t *testing.T) {
+	checkpoints := []Checkpoint{
+		{BitOffset: 100, Timestamp: testStartTime.Add(10 * time.Minute)},
+		{BitOffset: 200, Timestamp: testStartTime.Add(20 * time.Minute)},
+	}
+
+	_, ok := CheckpointBefore(checkpoints, testStartTime.Add(10*time.Minute))
+	require.False(t, ok)
+
+	checkpoint, ok := CheckpointBefore(checkpoints, testStartTime.Add(15*time.Minute))
+	require.True(t, ok)
+	require.Equal(t, checkpoints[0], checkpoint)
+
+	checkpoint, ok = CheckpointBefore(checkpoints, testStartTime.Add(time.Hour))
+	require.True(t, ok)
+	require.Equal(t, checkpoints[1], checkpoint)
+
+	_, ok = CheckpointBefore(nil, testStartTime)
+	require.False(t, ok)
+}
+
+func TestCheckpointsMarshalRoundTrip(t *testing.T) {
+	checkpoints := []Checkpoint{
+		{
+			BitOffset: 1234,
+			Timestamp: testStartTime.Add(10 * time.Minute),
+			TimeDelta: 10 * time.Second,
+			TimeUnit:  xtime.Second,
+			FloatBits: 0xdeadbeef,
+			FloatXOR:  0xbeef,
+			IntVal:    -42,
+			Mult:      2,
+			Sig:       12,
+		},
+		{
+			BitOffset: 4321,
+			Timestamp: testStartTime.Add(20 * time.Minute),
+			TimeDelta: -time.Millisecond,
+			TimeUnit:  xtime.Millisecond,
+			FloatBits: 0xcafe,
+			IsFloat:   true,
+		},
+	}
+
+	b := MarshalCheckpoints([]byte("prefix"), checkpoints)
+	require.Equal(t, []byte("prefix"), b[:6])
+
+	res, err := UnmarshalCheckpoints(b[6:])
+	require.NoError(t, err)
+	require.Equal(t, len(checkpoints), len(res))
+	for i := range checkpoints {
+		require.True(t, checkpoints[i].Timestamp.Equal(res[i].Timestamp))
+		res[i].Timestamp = checkpoints[i].Timestamp
+		require.Equal(t, checkpoints[i], res[i])
+	}
+
+	_, err = UnmarshalCheckpoints(b[6 : len(b)-1])
+	require.Equal(t, errCheckpointsCorrupt, err)
+}
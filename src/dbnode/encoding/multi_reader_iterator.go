@@ -159,6 +159,10 @@ func (it *multiReaderIterator) Readers() xio.ReaderSliceOfSlicesIterator {
 	return it.slicesIter
 }
 
+func (it *multiReaderIterator) SetIterateEqualTimestampStrategy(strategy IterateEqualTimestampStrategy) {
+	it.iters.equalTimesStrategy = strategy
+}
+
 func (it *multiReaderIterator) Reset(blocks []xio.SegmentReader, start time.Time, blockSize time.Duration) {
 	it.singleSlicesIter.readers = blocks
 	it.singleSlicesIter.firstNext = true
@@ -184,6 +188,7 @@ func (it *multiReaderIterator) Close() {
 	}
 	it.closed = true
 	it.iters.reset()
+	it.iters.equalTimesStrategy = DefaultIterateEqualTimestampStrategy
 	if it.slicesIter != nil {
 		it.slicesIter.Close()
 	}
@@ -0,0 +1,332 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package proto
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3x/checked"
+	xtime "github.com/m3db/m3x/time"
+)
+
+var (
+	errEncoderClosed       = errors.New("encoder is closed")
+	errEncoderSchemaNil    = errors.New("encoder schema is not set")
+	errNoEncodedDatapoints = errors.New("encoder has no encoded datapoints")
+)
+
+type encoder struct {
+	os     encoding.OStream
+	opts   encoding.Options
+	schema *Schema
+
+	// internal bookkeeping
+	t  time.Time     // current time
+	dt time.Duration // current time delta
+	vb uint64        // current value as float bits
+	tu xtime.Unit    // current time unit
+
+	msg        []byte  // current marshalled message
+	fields     []field // current message fields, aliases msg
+	nextMsg    []byte
+	nextFields []field
+
+	numEncoded int
+	closed     bool
+}
+
+// NewEncoder creates a new protobuf encoder for messages of the given schema.
+func NewEncoder(
+	start time.Time,
+	bytes checked.Bytes,
+	schema *Schema,
+	opts encoding.Options,
+) encoding.Encoder {
+	if opts == nil {
+		opts = encoding.NewOptions()
+	}
+	// Only perform an initial allocation if there is no pool that will be
+	// used for this encoder, if pooled alloc when Reset is called.
+	initAllocIfEmpty := opts.EncoderPool() == nil
+	return &encoder{
+		os:     encoding.NewOStream(bytes, initAllocIfEmpty, opts.BytesPool()),
+		opts:   opts,
+		schema: schema,
+		t:      start,
+	}
+}
+
+// Encode encodes the timestamp and value of a datapoint along with the
+// marshalled protobuf message provided as the annotation.
+func (enc *encoder) Encode(dp ts.Datapoint, tu xtime.Unit, ant ts.Annotation) error {
+	if enc.closed {
+		return errEncoderClosed
+	}
+	if enc.schema == nil {
+		return errEncoderSchemaNil
+	}
+
+	// Parse and validate the message before writing anything so that an
+	// invalid message leaves the stream untouched.
+	nextMsg := append(enc.nextMsg[:0], ant...)
+	nextFields, err := parseMessage(nextMsg, enc.nextFields)
+	if err != nil {
+		return err
+	}
+	for _, f := range nextFields {
+		if err := enc.schema.validate(f); err != nil {
+			return err
+		}
+	}
+
+	enc.os.WriteBit(opcodeDatapoint)
+	enc.writeTime(dp.Timestamp, tu)
+	enc.writeValue(math.Float64bits(dp.Value))
+	enc.writeMessage(nextFields)
+
+	enc.msg, enc.nextMsg = nextMsg, enc.msg
+	enc.fields, enc.nextFields = nextFields, enc.fields
+	enc.numEncoded++
+	return nil
+}
+
+func (enc *encoder) writeTime(t time.Time, tu xtime.Unit) {
+	if enc.numEncoded == 0 {
+		enc.os.WriteBits(uint64(xtime.ToNormalizedTime(t, time.Nanosecond)), 64)
+	} else {
+		dt := t.Sub(enc.t)
+		writeVarint(enc.os, int64(dt-enc.dt))
+		enc.dt = dt
+	}
+	enc.t = t
+
+	if tu == enc.tu {
+		enc.os.WriteBit(opcodeNoChange)
+		return
+	}
+	enc.os.WriteBit(opcodeChange)
+	enc.os.WriteByte(byte(tu))
+	enc.tu = tu
+}
+
+func (enc *encoder) writeValue(vb uint64) {
+	if enc.numEncoded > 0 && vb == enc.vb {
+		enc.os.WriteBit(opcodeNoChange)
+		return
+	}
+	enc.os.WriteBit(opcodeChange)
+	enc.os.WriteBits(vb, 64)
+	enc.vb = vb
+}
+
+func (enc *encoder) writeMessage(fields []field) {
+	if enc.numEncoded > 0 && fieldsEqual(fields, enc.fields) {
+		enc.os.WriteBit(opcodeNoChange)
+		return
+	}
+
+	enc.os.WriteBit(opcodeChange)
+	writeUvarint(enc.os, uint64(len(fields)))
+	for i, f := range fields {
+		var prev field
+		hasPrev := i < len(enc.fields)
+		if hasPrev {
+			prev = enc.fields[i]
+		}
+		if hasPrev && f.equal(prev) {
+			enc.os.WriteBit(opcodeNoChange)
+			continue
+		}
+		enc.os.WriteBit(opcodeChange)
+
+		sameTag := hasPrev && f.sameTag(prev)
+		if sameTag {
+			enc.os.WriteBit(opcodeNoChange)
+		} else {
+			enc.os.WriteBit(opcodeChange)
+			writeUvarint(enc.os, f.tag())
+		}
+		enc.writeFieldValue(f, prev, sameTag)
+	}
+}
+
+func (enc *encoder) writeFieldValue(f, prev field, sameTag bool) {
+	switch f.wireType {
+	case wireTypeVarint:
+		if sameTag {
+			writeVarint(enc.os, int64(f.value-prev.value))
+			return
+		}
+		writeUvarint(enc.os, f.value)
+	case wireTypeFixed64:
+		if sameTag {
+			enc.writeXOR(f.value ^ prev.value)
+			return
+		}
+		enc.os.WriteBits(f.value, 64)
+	case wireTypeFixed32:
+		enc.os.WriteBits(f.value, 32)
+	case wireTypeLengthDelimited:
+		writeUvarint(enc.os, uint64(len(f.bytes)))
+		enc.os.WriteBytes(f.bytes)
+	}
+}
+
+// writeXOR writes the meaningful bits of a non-zero XOR of two values.
+func (enc *encoder) writeXOR(xor uint64) {
+	leading, trailing := encoding.LeadingAndTrailingZeros(xor)
+	numMeaningfulBits := 64 - leading - trailing
+	enc.os.WriteBits(uint64(leading), numXORLeadingBits)
+	// numMeaningfulBits is at least 1 so subtract 1 to fit it in 6 bits.
+	enc.os.WriteBits(uint64(numMeaningfulBits-1), numXORMeaningfulBits)
+	enc.os.WriteBits(xor>>uint(trailing), numMeaningfulBits)
+}
+
+func fieldsEqual(a, b []field) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (enc *encoder) newBuffer(capacity int) checked.Bytes {
+	if bytesPool := enc.opts.BytesPool(); bytesPool != nil {
+		return bytesPool.Get(capacity)
+	}
+	return checked.NewBytes(make([]byte, 0, capacity), nil)
+}
+
+func (enc *encoder) Reset(start time.Time, capacity int) {
+	enc.os.Reset(enc.newBuffer(capacity))
+	enc.t = start
+	enc.dt = 0
+	enc.vb = 0
+	enc.tu = xtime.None
+	enc.msg = enc.msg[:0]
+	enc.fields = enc.fields[:0]
+	enc.numEncoded = 0
+	enc.closed = false
+}
+
+func (enc *encoder) Stream() xio.SegmentReader {
+	segment := enc.segment(byCopyResultType)
+	if segment.Len() == 0 {
+		return nil
+	}
+	if readerPool := enc.opts.SegmentReaderPool(); readerPool != nil {
+		reader := readerPool.Get()
+		reader.Reset(segment)
+		return reader
+	}
+	return xio.NewSegmentReader(segment)
+}
+
+func (enc *encoder) NumEncoded() int {
+	return enc.numEncoded
+}
+
+func (enc *encoder) LastEncoded() (ts.Datapoint, error) {
+	if enc.numEncoded == 0 {
+		return ts.Datapoint{}, errNoEncodedDatapoints
+	}
+	return ts.Datapoint{
+		Timestamp: enc.t,
+		Value:     math.Float64frombits(enc.vb),
+	}, nil
+}
+
+func (enc *encoder) Len() int {
+	return enc.os.Len()
+}
+
+func (enc *encoder) Close() {
+	if enc.closed {
+		return
+	}
+
+	enc.closed = true
+
+	// Ensure to free ref to ostream bytes
+	enc.os.Reset(nil)
+
+	if pool := enc.opts.EncoderPool(); pool != nil {
+		pool.Put(enc)
+	}
+}
+
+func (enc *encoder) Discard() ts.Segment {
+	segment := enc.segment(byRefResultType)
+
+	// Close the encoder no longer needed
+	enc.Close()
+
+	return segment
+}
+
+func (enc *encoder) DiscardReset(start time.Time, capacity int) ts.Segment {
+	segment := enc.segment(byRefResultType)
+	enc.Reset(start, capacity)
+	return segment
+}
+
+// segment returns the encoded bytes, unlike M3TSZ no tail is required since
+// the unused bits of the last byte are zero and read as the end of stream.
+func (enc *encoder) segment(resType resultType) ts.Segment {
+	length := enc.os.Len()
+	if length == 0 {
+		return ts.Segment{}
+	}
+
+	var head checked.Bytes
+	if resType == byRefResultType {
+		// Take ref from the ostream
+		head = enc.os.Discard()
+	} else {
+		buffer, _ := enc.os.Rawbytes()
+
+		// Copy into new buffer
+		head = enc.newBuffer(length)
+
+		head.IncRef()
+		defer head.DecRef()
+
+		head.AppendAll(buffer.Bytes()[:length])
+	}
+
+	return ts.NewSegment(head, nil, ts.FinalizeHead)
+}
+
+type resultType int
+
+const (
+	byCopyResultType resultType = iota
+	byRefResultType
+)
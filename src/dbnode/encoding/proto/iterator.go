@@ -0,0 +1,292 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package proto
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/ts"
+	xtime "github.com/m3db/m3x/time"
+)
+
+var (
+	errIteratorInvalidWireType = errors.New("iterator read field with invalid wire type")
+	errIteratorFieldNotChanged = errors.New("iterator read unchanged field with no previous value")
+)
+
+// readerIterator reads datapoints and their protobuf messages off of a
+// stream written by the protobuf encoder.
+type readerIterator struct {
+	is     encoding.IStream
+	opts   encoding.Options
+	schema *Schema
+
+	// internal bookkeeping
+	t   time.Time     // current time
+	dt  time.Duration // current time delta
+	vb  uint64        // current value as float bits
+	tu  xtime.Unit    // current time unit
+	err error         // current error
+
+	fields []field // current message fields
+	msg    []byte  // current marshalled message
+
+	numRead int
+	done    bool
+	closed  bool
+}
+
+// NewReaderIterator returns a new iterator for a stream written by the
+// protobuf encoder, the schema is used to validate the decoded messages.
+func NewReaderIterator(
+	reader io.Reader,
+	schema *Schema,
+	opts encoding.Options,
+) encoding.ReaderIterator {
+	if opts == nil {
+		opts = encoding.NewOptions()
+	}
+	return &readerIterator{
+		is:     encoding.NewIStream(reader),
+		opts:   opts,
+		schema: schema,
+	}
+}
+
+// Next moves to the next item
+func (it *readerIterator) Next() bool {
+	if !it.hasNext() {
+		return false
+	}
+
+	opcode, err := it.is.ReadBit()
+	if err == io.EOF || (err == nil && opcode == opcodeEndOfStream) {
+		it.done = true
+		return false
+	}
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	it.readTime()
+	it.readValue()
+	it.readMessage()
+	if it.err != nil {
+		return false
+	}
+	it.numRead++
+	return true
+}
+
+func (it *readerIterator) readTime() {
+	if it.numRead == 0 {
+		nt := int64(it.readBits(64))
+		it.t = xtime.FromNormalizedTime(nt, time.Nanosecond)
+	} else {
+		it.dt += time.Duration(it.readVarint())
+		it.t = it.t.Add(it.dt)
+	}
+
+	if it.readBits(1) == opcodeChange {
+		it.tu = xtime.Unit(it.readBits(8))
+	}
+}
+
+func (it *readerIterator) readValue() {
+	if it.readBits(1) == opcodeChange {
+		it.vb = it.readBits(64)
+	}
+}
+
+func (it *readerIterator) readMessage() {
+	if it.readBits(1) == opcodeNoChange {
+		return
+	}
+
+	numFields := int(it.readUvarint())
+	if it.err != nil {
+		return
+	}
+	if numFields < len(it.fields) {
+		it.fields = it.fields[:numFields]
+	}
+	for i := 0; i < numFields && it.err == nil; i++ {
+		hasPrev := i < len(it.fields)
+		if it.readBits(1) == opcodeNoChange {
+			if !hasPrev {
+				// Fields past the end of the previous message are always
+				// written as changed.
+				it.err = errIteratorFieldNotChanged
+			}
+			continue
+		}
+
+		var prev field
+		if hasPrev {
+			prev = it.fields[i]
+		}
+		f := prev
+		if it.readBits(1) == opcodeChange {
+			tag := it.readUvarint()
+			f = field{num: int32(tag >> 3), wireType: int8(tag & 0x7)}
+		}
+		it.readFieldValue(&f, prev, hasPrev && f.sameTag(prev))
+
+		if it.err == nil && it.schema != nil {
+			it.err = it.schema.validate(f)
+		}
+		if hasPrev {
+			it.fields[i] = f
+		} else {
+			it.fields = append(it.fields, f)
+		}
+	}
+	if it.err != nil {
+		return
+	}
+
+	it.msg = appendMessage(it.msg[:0], it.fields)
+}
+
+func (it *readerIterator) readFieldValue(f *field, prev field, sameTag bool) {
+	switch f.wireType {
+	case wireTypeVarint:
+		if sameTag {
+			f.value = prev.value + uint64(it.readVarint())
+			return
+		}
+		f.value = it.readUvarint()
+	case wireTypeFixed64:
+		if sameTag {
+			f.value = prev.value ^ it.readXOR()
+			return
+		}
+		f.value = it.readBits(64)
+	case wireTypeFixed32:
+		f.value = it.readBits(32)
+	case wireTypeLengthDelimited:
+		length := it.readUvarint()
+		if it.err != nil {
+			return
+		}
+		// Allocate a new slice since the previous value may still be
+		// referenced by the message of a previous datapoint.
+		f.bytes = make([]byte, length)
+		for i := range f.bytes {
+			f.bytes[i] = byte(it.readBits(8))
+		}
+	default:
+		it.err = errIteratorInvalidWireType
+	}
+}
+
+func (it *readerIterator) readXOR() uint64 {
+	leading := it.readBits(numXORLeadingBits)
+	numMeaningfulBits := int(it.readBits(numXORMeaningfulBits)) + 1
+	meaningful := it.readBits(numMeaningfulBits)
+	trailing := 64 - int(leading) - numMeaningfulBits
+	if trailing < 0 {
+		it.err = errIteratorInvalidWireType
+		return 0
+	}
+	return meaningful << uint(trailing)
+}
+
+func (it *readerIterator) readBits(numBits int) uint64 {
+	if it.err != nil {
+		return 0
+	}
+	var res uint64
+	res, it.err = it.is.ReadBits(numBits)
+	return res
+}
+
+func (it *readerIterator) readUvarint() uint64 {
+	if it.err != nil {
+		return 0
+	}
+	var res uint64
+	res, it.err = binary.ReadUvarint(byteReader{is: it.is})
+	return res
+}
+
+func (it *readerIterator) readVarint() int64 {
+	if it.err != nil {
+		return 0
+	}
+	var res int64
+	res, it.err = binary.ReadVarint(byteReader{is: it.is})
+	return res
+}
+
+// Current returns the value as well as the marshalled protobuf message of
+// the current datapoint as its annotation. Users should not hold on to the
+// returned Annotation object as it may get invalidated when the iterator
+// calls Next().
+func (it *readerIterator) Current() (ts.Datapoint, xtime.Unit, ts.Annotation) {
+	var ant ts.Annotation
+	if len(it.msg) > 0 {
+		ant = it.msg
+	}
+	return ts.Datapoint{
+		Timestamp: it.t,
+		Value:     math.Float64frombits(it.vb),
+	}, it.tu, ant
+}
+
+// Err returns the error encountered
+func (it *readerIterator) Err() error {
+	return it.err
+}
+
+func (it *readerIterator) hasNext() bool {
+	return it.err == nil && !it.done && !it.closed
+}
+
+func (it *readerIterator) Reset(reader io.Reader) {
+	it.is.Reset(reader)
+	it.t = time.Time{}
+	it.dt = 0
+	it.vb = 0
+	it.tu = xtime.None
+	it.err = nil
+	it.fields = it.fields[:0]
+	it.msg = it.msg[:0]
+	it.numRead = 0
+	it.done = false
+	it.closed = false
+}
+
+func (it *readerIterator) Close() {
+	if it.closed {
+		return
+	}
+	it.closed = true
+	if pool := it.opts.ReaderIteratorPool(); pool != nil {
+		pool.Put(it)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package proto implements an encoding for namespaces whose values are
+// protobuf messages of a declared schema. Each datapoint carries the
+// marshalled message as its annotation; successive messages are delta
+// encoded field by field against the previous message, so slowly changing
+// messages compress to a handful of bits per datapoint. Readers receive the
+// reconstructed marshalled message as the annotation of each datapoint and
+// unmarshal it into the generated type for the schema.
+package proto
+
+import (
+	"encoding/binary"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+)
+
+const (
+	opcodeEndOfStream = 0
+	opcodeDatapoint   = 1
+
+	opcodeNoChange = 0
+	opcodeChange   = 1
+
+	numXORLeadingBits    = 6
+	numXORMeaningfulBits = 6
+)
+
+func writeUvarint(os encoding.OStream, v uint64) {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], v)
+	os.WriteBytes(buf[:n])
+}
+
+func writeVarint(os encoding.OStream, v int64) {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(buf[:], v)
+	os.WriteBytes(buf[:n])
+}
+
+// byteReader adapts an IStream for use with the binary varint readers.
+type byteReader struct {
+	is encoding.IStream
+}
+
+func (r byteReader) ReadByte() (byte, error) {
+	return r.is.ReadByte()
+}
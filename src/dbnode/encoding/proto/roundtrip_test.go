@@ -0,0 +1,228 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package proto
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/ts"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/require"
+)
+
+type testEvent struct {
+	status  int64
+	latency float64
+	host    string
+	codes   []int32
+}
+
+func (e testEvent) marshal() []byte {
+	buf := proto.NewBuffer(nil)
+	if e.status != 0 {
+		buf.EncodeVarint(1<<3 | wireTypeVarint)
+		buf.EncodeVarint(uint64(e.status))
+	}
+	if e.latency != 0 {
+		buf.EncodeVarint(2<<3 | wireTypeFixed64)
+		buf.EncodeFixed64(math.Float64bits(e.latency))
+	}
+	if e.host != "" {
+		buf.EncodeVarint(3<<3 | wireTypeLengthDelimited)
+		buf.EncodeStringBytes(e.host)
+	}
+	for _, code := range e.codes {
+		buf.EncodeVarint(4<<3 | wireTypeVarint)
+		buf.EncodeVarint(uint64(code))
+	}
+	return buf.Bytes()
+}
+
+type testValue struct {
+	dp  ts.Datapoint
+	tu  xtime.Unit
+	ant ts.Annotation
+}
+
+func testValues(start time.Time) []testValue {
+	var (
+		values = make([]testValue, 0, 100)
+		t      = start
+		hosts  = []string{"host-a", "host-b"}
+	)
+	for i := 0; i < 100; i++ {
+		t = t.Add(10 * time.Second)
+		if i%7 == 0 {
+			t = t.Add(time.Duration(i) * time.Millisecond)
+		}
+		tu := xtime.Second
+		if i > 50 {
+			tu = xtime.Millisecond
+		}
+		event := testEvent{
+			status:  int64(200 + (i/10)*100),
+			latency: float64(i%3) * 1.5,
+			host:    hosts[(i/5)%len(hosts)],
+		}
+		if i%4 == 0 {
+			event.codes = []int32{int32(i), -1}
+		}
+		values = append(values, testValue{
+			dp:  ts.Datapoint{Timestamp: t, Value: float64(i % 2)},
+			tu:  tu,
+			ant: event.marshal(),
+		})
+	}
+	// Messages with no fields set must also round trip.
+	values = append(values, testValue{
+		dp: ts.Datapoint{Timestamp: t.Add(time.Second), Value: 1},
+		tu: xtime.Millisecond,
+	})
+	return values
+}
+
+func requireRoundTrip(t *testing.T, schema *Schema, enc encoding.Encoder, expected []testValue) {
+	stream := enc.Stream()
+	require.NotNil(t, stream)
+
+	iter := NewReaderIterator(stream, schema, nil)
+	defer iter.Close()
+
+	i := 0
+	for iter.Next() {
+		require.True(t, i < len(expected))
+		dp, tu, ant := iter.Current()
+		require.True(t, expected[i].dp.Timestamp.Equal(dp.Timestamp),
+			"expected %v, actual %v", expected[i].dp.Timestamp, dp.Timestamp)
+		require.Equal(t, expected[i].dp.Value, dp.Value)
+		require.Equal(t, expected[i].tu, tu)
+		require.Equal(t, expected[i].ant, ant)
+		i++
+	}
+	require.NoError(t, iter.Err())
+	require.Equal(t, len(expected), i)
+}
+
+func TestRoundTrip(t *testing.T) {
+	var (
+		schema = testSchema(t)
+		start  = time.Unix(1427162400, 0)
+		values = testValues(start)
+		enc    = NewEncoder(start, nil, schema, nil)
+	)
+	for _, v := range values {
+		require.NoError(t, enc.Encode(v.dp, v.tu, v.ant))
+	}
+	require.Equal(t, len(values), enc.NumEncoded())
+
+	last, err := enc.LastEncoded()
+	require.NoError(t, err)
+	require.Equal(t, values[len(values)-1].dp.Timestamp, last.Timestamp)
+	require.Equal(t, values[len(values)-1].dp.Value, last.Value)
+
+	requireRoundTrip(t, schema, enc, values)
+}
+
+func TestRoundTripStreamWhileEncoding(t *testing.T) {
+	var (
+		schema = testSchema(t)
+		start  = time.Unix(1427162400, 0)
+		values = testValues(start)
+		enc    = NewEncoder(start, nil, schema, nil)
+	)
+	for i, v := range values {
+		require.NoError(t, enc.Encode(v.dp, v.tu, v.ant))
+		if i%10 == 0 {
+			requireRoundTrip(t, schema, enc, values[:i+1])
+		}
+	}
+	requireRoundTrip(t, schema, enc, values)
+}
+
+func TestEncodeInvalidMessage(t *testing.T) {
+	var (
+		schema = testSchema(t)
+		start  = time.Unix(1427162400, 0)
+		values = testValues(start)[:2]
+		enc    = NewEncoder(start, nil, schema, nil)
+	)
+	require.NoError(t, enc.Encode(values[0].dp, values[0].tu, values[0].ant))
+
+	// Unknown field number.
+	buf := proto.NewBuffer(nil)
+	buf.EncodeVarint(9<<3 | wireTypeVarint)
+	buf.EncodeVarint(1)
+	require.Error(t, enc.Encode(values[1].dp, values[1].tu, buf.Bytes()))
+
+	// Mismatched wire type.
+	buf = proto.NewBuffer(nil)
+	buf.EncodeVarint(3<<3 | wireTypeVarint)
+	buf.EncodeVarint(1)
+	require.Error(t, enc.Encode(values[1].dp, values[1].tu, buf.Bytes()))
+
+	// Truncated message.
+	truncated := values[1].ant[:len(values[1].ant)-1]
+	require.Error(t, enc.Encode(values[1].dp, values[1].tu, truncated))
+
+	// Rejected messages must leave the stream untouched.
+	require.Equal(t, 1, enc.NumEncoded())
+	require.NoError(t, enc.Encode(values[1].dp, values[1].tu, values[1].ant))
+	requireRoundTrip(t, schema, enc, values)
+}
+
+func TestEncoderReset(t *testing.T) {
+	var (
+		schema = testSchema(t)
+		start  = time.Unix(1427162400, 0)
+		values = testValues(start)
+		enc    = NewEncoder(start, nil, schema, nil)
+	)
+	for _, v := range values[:10] {
+		require.NoError(t, enc.Encode(v.dp, v.tu, v.ant))
+	}
+
+	enc.Reset(start, 0)
+	require.Equal(t, 0, enc.NumEncoded())
+	require.Nil(t, enc.Stream())
+	_, err := enc.LastEncoded()
+	require.Equal(t, errNoEncodedDatapoints, err)
+
+	for _, v := range values[10:] {
+		require.NoError(t, enc.Encode(v.dp, v.tu, v.ant))
+	}
+	requireRoundTrip(t, schema, enc, values[10:])
+}
+
+func TestEncoderClosed(t *testing.T) {
+	var (
+		schema = testSchema(t)
+		start  = time.Unix(1427162400, 0)
+		enc    = NewEncoder(start, nil, schema, nil)
+	)
+	enc.Close()
+	err := enc.Encode(ts.Datapoint{Timestamp: start}, xtime.Second, nil)
+	require.Equal(t, errEncoderClosed, err)
+}
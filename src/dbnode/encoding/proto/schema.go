@@ -0,0 +1,173 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package proto
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/protoc-gen-gogo/descriptor"
+)
+
+const (
+	wireTypeVarint          = 0
+	wireTypeFixed64         = 1
+	wireTypeLengthDelimited = 2
+	wireTypeStartGroup      = 3
+	wireTypeEndGroup        = 4
+	wireTypeFixed32         = 5
+)
+
+var (
+	errSchemaMessageNameEmpty = errors.New("schema message name must be set")
+	errSchemaDescriptorEmpty  = errors.New("schema file descriptor set must be set")
+)
+
+// Schema describes the protobuf message type that values encoded for a
+// namespace must conform to.
+type Schema struct {
+	messageName string
+	fields      map[int32]schemaField
+}
+
+type schemaField struct {
+	name     string
+	wireType int8
+	packed   bool
+}
+
+// NewSchema parses a serialized FileDescriptorSet and returns the schema
+// for the message with the fully qualified messageName, i.e. "pkg.Message".
+func NewSchema(fileDescriptorSet []byte, messageName string) (*Schema, error) {
+	if messageName == "" {
+		return nil, errSchemaMessageNameEmpty
+	}
+	if len(fileDescriptorSet) == 0 {
+		return nil, errSchemaDescriptorEmpty
+	}
+
+	var fds descriptor.FileDescriptorSet
+	if err := proto.Unmarshal(fileDescriptorSet, &fds); err != nil {
+		return nil, fmt.Errorf("unable to parse schema file descriptor set: %v", err)
+	}
+
+	name := strings.TrimPrefix(messageName, ".")
+	for _, file := range fds.File {
+		prefix := ""
+		if pkg := file.GetPackage(); pkg != "" {
+			prefix = pkg + "."
+		}
+		if msg, ok := findMessage(prefix, name, file.MessageType); ok {
+			return newSchema(name, msg)
+		}
+	}
+
+	return nil, fmt.Errorf("schema message %s not found in file descriptor set", messageName)
+}
+
+func findMessage(
+	prefix string,
+	name string,
+	msgs []*descriptor.DescriptorProto,
+) (*descriptor.DescriptorProto, bool) {
+	for _, msg := range msgs {
+		qualified := prefix + msg.GetName()
+		if qualified == name {
+			return msg, true
+		}
+		if nested, ok := findMessage(qualified+".", name, msg.NestedType); ok {
+			return nested, true
+		}
+	}
+	return nil, false
+}
+
+func newSchema(name string, msg *descriptor.DescriptorProto) (*Schema, error) {
+	fields := make(map[int32]schemaField, len(msg.Field))
+	for _, f := range msg.Field {
+		wireType, err := fieldWireType(f.GetType())
+		if err != nil {
+			return nil, fmt.Errorf("schema message %s field %s: %v",
+				name, f.GetName(), err)
+		}
+		repeated := f.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REPEATED
+		fields[f.GetNumber()] = schemaField{
+			name:     f.GetName(),
+			wireType: wireType,
+			packed:   repeated && wireType != wireTypeLengthDelimited,
+		}
+	}
+	return &Schema{messageName: name, fields: fields}, nil
+}
+
+func fieldWireType(t descriptor.FieldDescriptorProto_Type) (int8, error) {
+	switch t {
+	case descriptor.FieldDescriptorProto_TYPE_INT32,
+		descriptor.FieldDescriptorProto_TYPE_INT64,
+		descriptor.FieldDescriptorProto_TYPE_UINT32,
+		descriptor.FieldDescriptorProto_TYPE_UINT64,
+		descriptor.FieldDescriptorProto_TYPE_SINT32,
+		descriptor.FieldDescriptorProto_TYPE_SINT64,
+		descriptor.FieldDescriptorProto_TYPE_BOOL,
+		descriptor.FieldDescriptorProto_TYPE_ENUM:
+		return wireTypeVarint, nil
+	case descriptor.FieldDescriptorProto_TYPE_DOUBLE,
+		descriptor.FieldDescriptorProto_TYPE_FIXED64,
+		descriptor.FieldDescriptorProto_TYPE_SFIXED64:
+		return wireTypeFixed64, nil
+	case descriptor.FieldDescriptorProto_TYPE_FLOAT,
+		descriptor.FieldDescriptorProto_TYPE_FIXED32,
+		descriptor.FieldDescriptorProto_TYPE_SFIXED32:
+		return wireTypeFixed32, nil
+	case descriptor.FieldDescriptorProto_TYPE_STRING,
+		descriptor.FieldDescriptorProto_TYPE_BYTES,
+		descriptor.FieldDescriptorProto_TYPE_MESSAGE:
+		return wireTypeLengthDelimited, nil
+	default:
+		return 0, fmt.Errorf("unsupported field type %s", t.String())
+	}
+}
+
+// MessageName returns the fully qualified name of the schema message.
+func (s *Schema) MessageName() string {
+	return s.messageName
+}
+
+// validate returns an error if a field is not declared by the schema or
+// was written with a wire type the schema does not allow for it.
+func (s *Schema) validate(f field) error {
+	sf, ok := s.fields[f.num]
+	if !ok {
+		return fmt.Errorf("field number %d not declared by schema message %s",
+			f.num, s.messageName)
+	}
+	if f.wireType == sf.wireType {
+		return nil
+	}
+	// Packed repeated scalars are written as a single length delimited field.
+	if sf.packed && f.wireType == wireTypeLengthDelimited {
+		return nil
+	}
+	return fmt.Errorf("field %s has wire type %d, schema message %s expects %d",
+		sf.name, f.wireType, s.messageName, sf.wireType)
+}
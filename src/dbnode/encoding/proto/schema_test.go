@@ -0,0 +1,138 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package proto
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/protoc-gen-gogo/descriptor"
+	"github.com/stretchr/testify/require"
+)
+
+func testField(
+	name string,
+	num int32,
+	t descriptor.FieldDescriptorProto_Type,
+	label descriptor.FieldDescriptorProto_Label,
+) *descriptor.FieldDescriptorProto {
+	return &descriptor.FieldDescriptorProto{
+		Name:   proto.String(name),
+		Number: proto.Int32(num),
+		Type:   t.Enum(),
+		Label:  label.Enum(),
+	}
+}
+
+func testFileDescriptorSet(t *testing.T) []byte {
+	optional := descriptor.FieldDescriptorProto_LABEL_OPTIONAL
+	fds := &descriptor.FileDescriptorSet{
+		File: []*descriptor.FileDescriptorProto{
+			{
+				Name:    proto.String("event.proto"),
+				Package: proto.String("test"),
+				MessageType: []*descriptor.DescriptorProto{
+					{
+						Name: proto.String("Event"),
+						Field: []*descriptor.FieldDescriptorProto{
+							testField("status", 1, descriptor.FieldDescriptorProto_TYPE_INT64, optional),
+							testField("latency", 2, descriptor.FieldDescriptorProto_TYPE_DOUBLE, optional),
+							testField("host", 3, descriptor.FieldDescriptorProto_TYPE_STRING, optional),
+							testField("codes", 4, descriptor.FieldDescriptorProto_TYPE_INT32,
+								descriptor.FieldDescriptorProto_LABEL_REPEATED),
+						},
+						NestedType: []*descriptor.DescriptorProto{
+							{
+								Name: proto.String("Attribute"),
+								Field: []*descriptor.FieldDescriptorProto{
+									testField("key", 1, descriptor.FieldDescriptorProto_TYPE_STRING, optional),
+								},
+							},
+						},
+					},
+					{
+						Name: proto.String("Legacy"),
+						Field: []*descriptor.FieldDescriptorProto{
+							testField("group", 1, descriptor.FieldDescriptorProto_TYPE_GROUP, optional),
+						},
+					},
+				},
+			},
+		},
+	}
+	b, err := proto.Marshal(fds)
+	require.NoError(t, err)
+	return b
+}
+
+func testSchema(t *testing.T) *Schema {
+	schema, err := NewSchema(testFileDescriptorSet(t), "test.Event")
+	require.NoError(t, err)
+	return schema
+}
+
+func TestNewSchema(t *testing.T) {
+	fds := testFileDescriptorSet(t)
+
+	schema, err := NewSchema(fds, "test.Event")
+	require.NoError(t, err)
+	require.Equal(t, "test.Event", schema.MessageName())
+	require.Len(t, schema.fields, 4)
+
+	schema, err = NewSchema(fds, ".test.Event.Attribute")
+	require.NoError(t, err)
+	require.Equal(t, "test.Event.Attribute", schema.MessageName())
+	require.Len(t, schema.fields, 1)
+}
+
+func TestNewSchemaErrors(t *testing.T) {
+	fds := testFileDescriptorSet(t)
+
+	_, err := NewSchema(fds, "")
+	require.Equal(t, errSchemaMessageNameEmpty, err)
+
+	_, err = NewSchema(nil, "test.Event")
+	require.Equal(t, errSchemaDescriptorEmpty, err)
+
+	_, err = NewSchema([]byte{0xff}, "test.Event")
+	require.Error(t, err)
+
+	_, err = NewSchema(fds, "test.Missing")
+	require.Error(t, err)
+
+	_, err = NewSchema(fds, "test.Legacy")
+	require.Error(t, err)
+}
+
+func TestSchemaValidate(t *testing.T) {
+	schema := testSchema(t)
+
+	require.NoError(t, schema.validate(field{num: 1, wireType: wireTypeVarint}))
+	require.NoError(t, schema.validate(field{num: 2, wireType: wireTypeFixed64}))
+	require.NoError(t, schema.validate(field{num: 3, wireType: wireTypeLengthDelimited}))
+
+	// Repeated scalars may be packed or unpacked.
+	require.NoError(t, schema.validate(field{num: 4, wireType: wireTypeVarint}))
+	require.NoError(t, schema.validate(field{num: 4, wireType: wireTypeLengthDelimited}))
+
+	require.Error(t, schema.validate(field{num: 1, wireType: wireTypeFixed64}))
+	require.Error(t, schema.validate(field{num: 5, wireType: wireTypeVarint}))
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package proto
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+var (
+	errMessageTruncated  = errors.New("protobuf message is truncated")
+	errMessageGroupField = errors.New("protobuf message contains unsupported group field")
+)
+
+// field is a single wire format field of a marshalled protobuf message.
+type field struct {
+	num      int32
+	wireType int8
+	// value holds varint, fixed64 and fixed32 field values.
+	value uint64
+	// bytes holds length delimited field values.
+	bytes []byte
+}
+
+func (f field) sameTag(other field) bool {
+	return f.num == other.num && f.wireType == other.wireType
+}
+
+func (f field) equal(other field) bool {
+	return f.sameTag(other) && f.value == other.value &&
+		bytes.Equal(f.bytes, other.bytes)
+}
+
+func (f field) tag() uint64 {
+	return uint64(f.num)<<3 | uint64(f.wireType)
+}
+
+// parseMessage splits a marshalled protobuf message into its fields in the
+// order they were written, length delimited values alias b.
+func parseMessage(b []byte, fields []field) ([]field, error) {
+	fields = fields[:0]
+	for len(b) > 0 {
+		tag, n := binary.Uvarint(b)
+		if n <= 0 {
+			return nil, errMessageTruncated
+		}
+		b = b[n:]
+
+		f := field{num: int32(tag >> 3), wireType: int8(tag & 0x7)}
+		switch f.wireType {
+		case wireTypeVarint:
+			f.value, n = binary.Uvarint(b)
+			if n <= 0 {
+				return nil, errMessageTruncated
+			}
+			b = b[n:]
+		case wireTypeFixed64:
+			if len(b) < 8 {
+				return nil, errMessageTruncated
+			}
+			f.value = binary.LittleEndian.Uint64(b)
+			b = b[8:]
+		case wireTypeFixed32:
+			if len(b) < 4 {
+				return nil, errMessageTruncated
+			}
+			f.value = uint64(binary.LittleEndian.Uint32(b))
+			b = b[4:]
+		case wireTypeLengthDelimited:
+			length, n := binary.Uvarint(b)
+			if n <= 0 || uint64(len(b)-n) < length {
+				return nil, errMessageTruncated
+			}
+			b = b[n:]
+			f.bytes = b[:length:length]
+			b = b[length:]
+		case wireTypeStartGroup, wireTypeEndGroup:
+			return nil, errMessageGroupField
+		default:
+			return nil, fmt.Errorf("protobuf message has invalid wire type %d", f.wireType)
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// appendMessage marshals fields back into the protobuf wire format.
+func appendMessage(b []byte, fields []field) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	for _, f := range fields {
+		n := binary.PutUvarint(buf[:], f.tag())
+		b = append(b, buf[:n]...)
+		switch f.wireType {
+		case wireTypeVarint:
+			n = binary.PutUvarint(buf[:], f.value)
+			b = append(b, buf[:n]...)
+		case wireTypeFixed64:
+			binary.LittleEndian.PutUint64(buf[:8], f.value)
+			b = append(b, buf[:8]...)
+		case wireTypeFixed32:
+			binary.LittleEndian.PutUint32(buf[:4], uint32(f.value))
+			b = append(b, buf[:4]...)
+		case wireTypeLengthDelimited:
+			n = binary.PutUvarint(buf[:], uint64(len(f.bytes)))
+			b = append(b, buf[:n]...)
+			b = append(b, f.bytes...)
+		}
+	}
+	return b
+}
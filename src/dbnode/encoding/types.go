@@ -152,6 +152,12 @@ type MultiReaderIterator interface {
 
 	// Readers exposes the underlying ReaderSliceOfSlicesIterator for this MultiReaderIterator
 	Readers() xio.ReaderSliceOfSlicesIterator
+
+	// SetIterateEqualTimestampStrategy sets the equal timestamp strategy of how
+	// to select a value when the timestamp matches differing values with the same
+	// timestamp from different readers, the strategy is reset to the default
+	// when the iterator is closed.
+	SetIterateEqualTimestampStrategy(strategy IterateEqualTimestampStrategy)
 }
 
 // SeriesIterator is an iterator that iterates over a set of iterators from different replicas
@@ -250,6 +256,7 @@ type IStream interface {
 	ReadByte() (byte, error)
 	ReadBits(numBits int) (uint64, error)
 	PeekBits(numBits int) (uint64, error)
+	BitsRead() int
 	Reset(r io.Reader)
 }
 
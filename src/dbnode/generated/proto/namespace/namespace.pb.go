@@ -30,6 +30,9 @@
 	It has these top-level messages:
 		RetentionOptions
 		IndexOptions
+		ColdStorageOptions
+		CompactionOptions
+		SchemaOptions
 		NamespaceOptions
 		Registry
 */
@@ -52,6 +55,33 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
+type DuplicatePolicy int32
+
+const (
+	DuplicatePolicy_LAST_WRITE_WINS  DuplicatePolicy = 0
+	DuplicatePolicy_MAX_VALUE        DuplicatePolicy = 1
+	DuplicatePolicy_MIN_VALUE        DuplicatePolicy = 2
+	DuplicatePolicy_REJECT_DUPLICATE DuplicatePolicy = 3
+)
+
+var DuplicatePolicy_name = map[int32]string{
+	0: "LAST_WRITE_WINS",
+	1: "MAX_VALUE",
+	2: "MIN_VALUE",
+	3: "REJECT_DUPLICATE",
+}
+var DuplicatePolicy_value = map[string]int32{
+	"LAST_WRITE_WINS":  0,
+	"MAX_VALUE":        1,
+	"MIN_VALUE":        2,
+	"REJECT_DUPLICATE": 3,
+}
+
+func (x DuplicatePolicy) String() string {
+	return proto.EnumName(DuplicatePolicy_name, int32(x))
+}
+func (DuplicatePolicy) EnumDescriptor() ([]byte, []int) { return fileDescriptorNamespace, []int{0} }
+
 type RetentionOptions struct {
 	RetentionPeriodNanos                     int64 `protobuf:"varint,1,opt,name=retentionPeriodNanos,proto3" json:"retentionPeriodNanos,omitempty"`
 	BlockSizeNanos                           int64 `protobuf:"varint,2,opt,name=blockSizeNanos,proto3" json:"blockSizeNanos,omitempty"`
@@ -132,21 +162,105 @@ func (m *IndexOptions) GetBlockSizeNanos() int64 {
 	return 0
 }
 
+type ColdStorageOptions struct {
+	Enabled           bool  `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	OffloadAfterNanos int64 `protobuf:"varint,2,opt,name=offloadAfterNanos,proto3" json:"offloadAfterNanos,omitempty"`
+}
+
+func (m *ColdStorageOptions) Reset()                    { *m = ColdStorageOptions{} }
+func (m *ColdStorageOptions) String() string            { return proto.CompactTextString(m) }
+func (*ColdStorageOptions) ProtoMessage()               {}
+func (*ColdStorageOptions) Descriptor() ([]byte, []int) { return fileDescriptorNamespace, []int{2} }
+
+func (m *ColdStorageOptions) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+func (m *ColdStorageOptions) GetOffloadAfterNanos() int64 {
+	if m != nil {
+		return m.OffloadAfterNanos
+	}
+	return 0
+}
+
+type CompactionOptions struct {
+	Enabled           bool  `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	BlockSizeNanos    int64 `protobuf:"varint,2,opt,name=blockSizeNanos,proto3" json:"blockSizeNanos,omitempty"`
+	CompactAfterNanos int64 `protobuf:"varint,3,opt,name=compactAfterNanos,proto3" json:"compactAfterNanos,omitempty"`
+}
+
+func (m *CompactionOptions) Reset()                    { *m = CompactionOptions{} }
+func (m *CompactionOptions) String() string            { return proto.CompactTextString(m) }
+func (*CompactionOptions) ProtoMessage()               {}
+func (*CompactionOptions) Descriptor() ([]byte, []int) { return fileDescriptorNamespace, []int{3} }
+
+func (m *CompactionOptions) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+func (m *CompactionOptions) GetBlockSizeNanos() int64 {
+	if m != nil {
+		return m.BlockSizeNanos
+	}
+	return 0
+}
+
+func (m *CompactionOptions) GetCompactAfterNanos() int64 {
+	if m != nil {
+		return m.CompactAfterNanos
+	}
+	return 0
+}
+
+type SchemaOptions struct {
+	FileDescriptorSet []byte `protobuf:"bytes,1,opt,name=fileDescriptorSet,proto3" json:"fileDescriptorSet,omitempty"`
+	MessageName       string `protobuf:"bytes,2,opt,name=messageName,proto3" json:"messageName,omitempty"`
+}
+
+func (m *SchemaOptions) Reset()                    { *m = SchemaOptions{} }
+func (m *SchemaOptions) String() string            { return proto.CompactTextString(m) }
+func (*SchemaOptions) ProtoMessage()               {}
+func (*SchemaOptions) Descriptor() ([]byte, []int) { return fileDescriptorNamespace, []int{4} }
+
+func (m *SchemaOptions) GetFileDescriptorSet() []byte {
+	if m != nil {
+		return m.FileDescriptorSet
+	}
+	return nil
+}
+
+func (m *SchemaOptions) GetMessageName() string {
+	if m != nil {
+		return m.MessageName
+	}
+	return ""
+}
+
 type NamespaceOptions struct {
-	BootstrapEnabled  bool              `protobuf:"varint,1,opt,name=bootstrapEnabled,proto3" json:"bootstrapEnabled,omitempty"`
-	FlushEnabled      bool              `protobuf:"varint,2,opt,name=flushEnabled,proto3" json:"flushEnabled,omitempty"`
-	WritesToCommitLog bool              `protobuf:"varint,3,opt,name=writesToCommitLog,proto3" json:"writesToCommitLog,omitempty"`
-	CleanupEnabled    bool              `protobuf:"varint,4,opt,name=cleanupEnabled,proto3" json:"cleanupEnabled,omitempty"`
-	RepairEnabled     bool              `protobuf:"varint,5,opt,name=repairEnabled,proto3" json:"repairEnabled,omitempty"`
-	RetentionOptions  *RetentionOptions `protobuf:"bytes,6,opt,name=retentionOptions" json:"retentionOptions,omitempty"`
-	SnapshotEnabled   bool              `protobuf:"varint,7,opt,name=snapshotEnabled,proto3" json:"snapshotEnabled,omitempty"`
-	IndexOptions      *IndexOptions     `protobuf:"bytes,8,opt,name=indexOptions" json:"indexOptions,omitempty"`
+	BootstrapEnabled   bool                `protobuf:"varint,1,opt,name=bootstrapEnabled,proto3" json:"bootstrapEnabled,omitempty"`
+	FlushEnabled       bool                `protobuf:"varint,2,opt,name=flushEnabled,proto3" json:"flushEnabled,omitempty"`
+	WritesToCommitLog  bool                `protobuf:"varint,3,opt,name=writesToCommitLog,proto3" json:"writesToCommitLog,omitempty"`
+	CleanupEnabled     bool                `protobuf:"varint,4,opt,name=cleanupEnabled,proto3" json:"cleanupEnabled,omitempty"`
+	RepairEnabled      bool                `protobuf:"varint,5,opt,name=repairEnabled,proto3" json:"repairEnabled,omitempty"`
+	RetentionOptions   *RetentionOptions   `protobuf:"bytes,6,opt,name=retentionOptions" json:"retentionOptions,omitempty"`
+	SnapshotEnabled    bool                `protobuf:"varint,7,opt,name=snapshotEnabled,proto3" json:"snapshotEnabled,omitempty"`
+	IndexOptions       *IndexOptions       `protobuf:"bytes,8,opt,name=indexOptions" json:"indexOptions,omitempty"`
+	ColdStorageOptions *ColdStorageOptions `protobuf:"bytes,9,opt,name=coldStorageOptions" json:"coldStorageOptions,omitempty"`
+	SchemaOptions      *SchemaOptions      `protobuf:"bytes,10,opt,name=schemaOptions" json:"schemaOptions,omitempty"`
+	CompactionOptions  *CompactionOptions  `protobuf:"bytes,11,opt,name=compactionOptions" json:"compactionOptions,omitempty"`
+	DuplicatePolicy    DuplicatePolicy     `protobuf:"varint,12,opt,name=duplicatePolicy,proto3,enum=namespace.DuplicatePolicy" json:"duplicatePolicy,omitempty"`
 }
 
 func (m *NamespaceOptions) Reset()                    { *m = NamespaceOptions{} }
 func (m *NamespaceOptions) String() string            { return proto.CompactTextString(m) }
 func (*NamespaceOptions) ProtoMessage()               {}
-func (*NamespaceOptions) Descriptor() ([]byte, []int) { return fileDescriptorNamespace, []int{2} }
+func (*NamespaceOptions) Descriptor() ([]byte, []int) { return fileDescriptorNamespace, []int{5} }
 
 func (m *NamespaceOptions) GetBootstrapEnabled() bool {
 	if m != nil {
@@ -204,6 +318,34 @@ func (m *NamespaceOptions) GetIndexOptions() *IndexOptions {
 	return nil
 }
 
+func (m *NamespaceOptions) GetColdStorageOptions() *ColdStorageOptions {
+	if m != nil {
+		return m.ColdStorageOptions
+	}
+	return nil
+}
+
+func (m *NamespaceOptions) GetSchemaOptions() *SchemaOptions {
+	if m != nil {
+		return m.SchemaOptions
+	}
+	return nil
+}
+
+func (m *NamespaceOptions) GetCompactionOptions() *CompactionOptions {
+	if m != nil {
+		return m.CompactionOptions
+	}
+	return nil
+}
+
+func (m *NamespaceOptions) GetDuplicatePolicy() DuplicatePolicy {
+	if m != nil {
+		return m.DuplicatePolicy
+	}
+	return DuplicatePolicy_LAST_WRITE_WINS
+}
+
 type Registry struct {
 	Namespaces map[string]*NamespaceOptions `protobuf:"bytes,1,rep,name=namespaces" json:"namespaces,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
 }
@@ -211,7 +353,7 @@ type Registry struct {
 func (m *Registry) Reset()                    { *m = Registry{} }
 func (m *Registry) String() string            { return proto.CompactTextString(m) }
 func (*Registry) ProtoMessage()               {}
-func (*Registry) Descriptor() ([]byte, []int) { return fileDescriptorNamespace, []int{3} }
+func (*Registry) Descriptor() ([]byte, []int) { return fileDescriptorNamespace, []int{6} }
 
 func (m *Registry) GetNamespaces() map[string]*NamespaceOptions {
 	if m != nil {
@@ -223,8 +365,12 @@ func (m *Registry) GetNamespaces() map[string]*NamespaceOptions {
 func init() {
 	proto.RegisterType((*RetentionOptions)(nil), "namespace.RetentionOptions")
 	proto.RegisterType((*IndexOptions)(nil), "namespace.IndexOptions")
+	proto.RegisterType((*ColdStorageOptions)(nil), "namespace.ColdStorageOptions")
+	proto.RegisterType((*CompactionOptions)(nil), "namespace.CompactionOptions")
+	proto.RegisterType((*SchemaOptions)(nil), "namespace.SchemaOptions")
 	proto.RegisterType((*NamespaceOptions)(nil), "namespace.NamespaceOptions")
 	proto.RegisterType((*Registry)(nil), "namespace.Registry")
+	proto.RegisterEnum("namespace.DuplicatePolicy", DuplicatePolicy_name, DuplicatePolicy_value)
 }
 func (m *RetentionOptions) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
@@ -312,6 +458,107 @@ func (m *IndexOptions) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *ColdStorageOptions) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ColdStorageOptions) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Enabled {
+		dAtA[i] = 0x8
+		i++
+		if m.Enabled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.OffloadAfterNanos != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(m.OffloadAfterNanos))
+	}
+	return i, nil
+}
+
+func (m *CompactionOptions) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CompactionOptions) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Enabled {
+		dAtA[i] = 0x8
+		i++
+		if m.Enabled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.BlockSizeNanos != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(m.BlockSizeNanos))
+	}
+	if m.CompactAfterNanos != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(m.CompactAfterNanos))
+	}
+	return i, nil
+}
+
+func (m *SchemaOptions) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SchemaOptions) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.FileDescriptorSet) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(len(m.FileDescriptorSet)))
+		i += copy(dAtA[i:], m.FileDescriptorSet)
+	}
+	if len(m.MessageName) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(len(m.MessageName)))
+		i += copy(dAtA[i:], m.MessageName)
+	}
+	return i, nil
+}
+
 func (m *NamespaceOptions) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -407,6 +654,41 @@ func (m *NamespaceOptions) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n2
 	}
+	if m.ColdStorageOptions != nil {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(m.ColdStorageOptions.Size()))
+		n3, err := m.ColdStorageOptions.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n3
+	}
+	if m.SchemaOptions != nil {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(m.SchemaOptions.Size()))
+		n4, err := m.SchemaOptions.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n4
+	}
+	if m.CompactionOptions != nil {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(m.CompactionOptions.Size()))
+		n5, err := m.CompactionOptions.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n5
+	}
+	if m.DuplicatePolicy != 0 {
+		dAtA[i] = 0x60
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(m.DuplicatePolicy))
+	}
 	return i, nil
 }
 
@@ -445,11 +727,11 @@ func (m *Registry) MarshalTo(dAtA []byte) (int, error) {
 				dAtA[i] = 0x12
 				i++
 				i = encodeVarintNamespace(dAtA, i, uint64(v.Size()))
-				n3, err := v.MarshalTo(dAtA[i:])
+				n6, err := v.MarshalTo(dAtA[i:])
 				if err != nil {
 					return 0, err
 				}
-				i += n3
+				i += n6
 			}
 		}
 	}
@@ -501,6 +783,47 @@ func (m *IndexOptions) Size() (n int) {
 	return n
 }
 
+func (m *ColdStorageOptions) Size() (n int) {
+	var l int
+	_ = l
+	if m.Enabled {
+		n += 2
+	}
+	if m.OffloadAfterNanos != 0 {
+		n += 1 + sovNamespace(uint64(m.OffloadAfterNanos))
+	}
+	return n
+}
+
+func (m *CompactionOptions) Size() (n int) {
+	var l int
+	_ = l
+	if m.Enabled {
+		n += 2
+	}
+	if m.BlockSizeNanos != 0 {
+		n += 1 + sovNamespace(uint64(m.BlockSizeNanos))
+	}
+	if m.CompactAfterNanos != 0 {
+		n += 1 + sovNamespace(uint64(m.CompactAfterNanos))
+	}
+	return n
+}
+
+func (m *SchemaOptions) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.FileDescriptorSet)
+	if l > 0 {
+		n += 1 + l + sovNamespace(uint64(l))
+	}
+	l = len(m.MessageName)
+	if l > 0 {
+		n += 1 + l + sovNamespace(uint64(l))
+	}
+	return n
+}
+
 func (m *NamespaceOptions) Size() (n int) {
 	var l int
 	_ = l
@@ -530,6 +853,21 @@ func (m *NamespaceOptions) Size() (n int) {
 		l = m.IndexOptions.Size()
 		n += 1 + l + sovNamespace(uint64(l))
 	}
+	if m.ColdStorageOptions != nil {
+		l = m.ColdStorageOptions.Size()
+		n += 1 + l + sovNamespace(uint64(l))
+	}
+	if m.SchemaOptions != nil {
+		l = m.SchemaOptions.Size()
+		n += 1 + l + sovNamespace(uint64(l))
+	}
+	if m.CompactionOptions != nil {
+		l = m.CompactionOptions.Size()
+		n += 1 + l + sovNamespace(uint64(l))
+	}
+	if m.DuplicatePolicy != 0 {
+		n += 1 + sovNamespace(uint64(m.DuplicatePolicy))
+	}
 	return n
 }
 
@@ -819,7 +1157,7 @@ func (m *IndexOptions) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *NamespaceOptions) Unmarshal(dAtA []byte) error {
+func (m *ColdStorageOptions) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -842,15 +1180,15 @@ func (m *NamespaceOptions) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: NamespaceOptions: wiretype end group for non-group")
+			return fmt.Errorf("proto: ColdStorageOptions: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: NamespaceOptions: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ColdStorageOptions: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BootstrapEnabled", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Enabled", wireType)
 			}
 			var v int
 			for shift := uint(0); ; shift += 7 {
@@ -867,12 +1205,12 @@ func (m *NamespaceOptions) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-			m.BootstrapEnabled = bool(v != 0)
+			m.Enabled = bool(v != 0)
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FlushEnabled", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field OffloadAfterNanos", wireType)
 			}
-			var v int
+			m.OffloadAfterNanos = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowNamespace
@@ -882,41 +1220,348 @@ func (m *NamespaceOptions) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				m.OffloadAfterNanos |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.FlushEnabled = bool(v != 0)
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field WritesToCommitLog", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNamespace(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowNamespace
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			if skippy < 0 {
+				return ErrInvalidLengthNamespace
 			}
-			m.WritesToCommitLog = bool(v != 0)
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CleanupEnabled", wireType)
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
 			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowNamespace
-				}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CompactionOptions) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNamespace
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CompactionOptions: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CompactionOptions: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Enabled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Enabled = bool(v != 0)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockSizeNanos", wireType)
+			}
+			m.BlockSizeNanos = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlockSizeNanos |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompactAfterNanos", wireType)
+			}
+			m.CompactAfterNanos = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CompactAfterNanos |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNamespace(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SchemaOptions) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNamespace
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SchemaOptions: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SchemaOptions: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FileDescriptorSet", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FileDescriptorSet = append(m.FileDescriptorSet[:0], dAtA[iNdEx:postIndex]...)
+			if m.FileDescriptorSet == nil {
+				m.FileDescriptorSet = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MessageName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MessageName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNamespace(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NamespaceOptions) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNamespace
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NamespaceOptions: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NamespaceOptions: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BootstrapEnabled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.BootstrapEnabled = bool(v != 0)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FlushEnabled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.FlushEnabled = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WritesToCommitLog", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.WritesToCommitLog = bool(v != 0)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CleanupEnabled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
 				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
@@ -1034,6 +1679,124 @@ func (m *NamespaceOptions) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ColdStorageOptions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ColdStorageOptions == nil {
+				m.ColdStorageOptions = &ColdStorageOptions{}
+			}
+			if err := m.ColdStorageOptions.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SchemaOptions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SchemaOptions == nil {
+				m.SchemaOptions = &SchemaOptions{}
+			}
+			if err := m.SchemaOptions.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompactionOptions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.CompactionOptions == nil {
+				m.CompactionOptions = &CompactionOptions{}
+			}
+			if err := m.CompactionOptions.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DuplicatePolicy", wireType)
+			}
+			m.DuplicatePolicy = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DuplicatePolicy |= (DuplicatePolicy(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipNamespace(dAtA[iNdEx:])
@@ -1338,38 +2101,53 @@ func init() {
 }
 
 var fileDescriptorNamespace = []byte{
-	// 513 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x94, 0xdf, 0x6e, 0xd3, 0x30,
-	0x14, 0xc6, 0x49, 0xbb, 0x3f, 0xdd, 0x59, 0x61, 0xc1, 0x42, 0xa2, 0x02, 0xa9, 0x9a, 0x0a, 0x42,
-	0x15, 0x42, 0x8d, 0x68, 0x6f, 0x10, 0x5c, 0x8d, 0x51, 0x26, 0x24, 0x54, 0x2a, 0xc3, 0xd5, 0xee,
-	0x9c, 0xe4, 0xb4, 0xb5, 0xd6, 0xd8, 0x91, 0xed, 0xc0, 0xca, 0x43, 0x20, 0xde, 0x83, 0x17, 0xe1,
-	0x82, 0x0b, 0x1e, 0x01, 0x95, 0x17, 0x41, 0x71, 0x48, 0x97, 0xb8, 0xbb, 0xd8, 0x4d, 0xe5, 0x7e,
-	0xe7, 0x67, 0x1f, 0xe7, 0x7c, 0x5f, 0x02, 0x67, 0x73, 0x6e, 0x16, 0x59, 0x38, 0x88, 0x64, 0x12,
-	0x24, 0xa3, 0x38, 0x0c, 0x92, 0x51, 0xa0, 0x55, 0x14, 0xc4, 0xa1, 0x90, 0x31, 0x06, 0x73, 0x14,
-	0xa8, 0x98, 0xc1, 0x38, 0x48, 0x95, 0x34, 0x32, 0x10, 0x2c, 0x41, 0x9d, 0xb2, 0x08, 0xaf, 0x56,
-	0x03, 0x5b, 0x21, 0x07, 0x1b, 0xa1, 0xf7, 0xab, 0x01, 0x3e, 0x45, 0x83, 0xc2, 0x70, 0x29, 0x3e,
-	0xa4, 0xf9, 0xaf, 0x26, 0x43, 0xb8, 0xa7, 0x4a, 0x6d, 0x8a, 0x8a, 0xcb, 0x78, 0xc2, 0x84, 0xd4,
-	0x1d, 0xef, 0xd8, 0xeb, 0x37, 0xe9, 0xb5, 0x35, 0xf2, 0x04, 0xee, 0x84, 0x4b, 0x19, 0x5d, 0x7c,
-	0xe4, 0x5f, 0xb1, 0xa0, 0x1b, 0x96, 0x76, 0x54, 0xf2, 0x0c, 0xee, 0x86, 0xd9, 0x6c, 0x86, 0xea,
-	0x6d, 0x66, 0x32, 0xf5, 0x1f, 0x6d, 0x5a, 0x74, 0xbb, 0x40, 0xfa, 0x70, 0x54, 0x88, 0x53, 0xa6,
-	0x4d, 0xc1, 0xee, 0x58, 0xd6, 0x95, 0x2d, 0x99, 0x77, 0x7a, 0xc3, 0x0c, 0x1b, 0x5f, 0xa6, 0x5c,
-	0xad, 0x3a, 0xbb, 0xc7, 0x5e, 0xbf, 0x45, 0x5d, 0x99, 0x9c, 0x43, 0xdf, 0x91, 0x4e, 0x66, 0x06,
-	0xd5, 0x44, 0x9a, 0x93, 0x28, 0x42, 0xad, 0xab, 0x4f, 0xbc, 0x67, 0x9b, 0xdd, 0x98, 0xef, 0x4d,
-	0xa1, 0xfd, 0x4e, 0xc4, 0x78, 0x59, 0x4e, 0xb2, 0x03, 0xfb, 0x28, 0x58, 0xb8, 0xc4, 0xd8, 0x0e,
-	0xaf, 0x45, 0xcb, 0xbf, 0x37, 0x9d, 0x57, 0xef, 0x5b, 0x13, 0xfc, 0x49, 0x69, 0x57, 0x79, 0xec,
-	0x53, 0xf0, 0x43, 0x29, 0x8d, 0x36, 0x8a, 0xa5, 0xe3, 0xda, 0xf9, 0x5b, 0x3a, 0xe9, 0x41, 0x7b,
-	0xb6, 0xcc, 0xf4, 0xa2, 0xe4, 0x1a, 0x96, 0xab, 0x69, 0xb9, 0x29, 0x5f, 0x14, 0x37, 0xa8, 0x3f,
-	0xc9, 0x53, 0x99, 0x24, 0xdc, 0xbc, 0x97, 0x73, 0x6b, 0x4a, 0x8b, 0x6e, 0x17, 0xf2, 0xab, 0x47,
-	0x4b, 0x64, 0x22, 0xdb, 0xf4, 0xde, 0xb1, 0xa8, 0xa3, 0x92, 0xc7, 0x70, 0x5b, 0x61, 0xca, 0xb8,
-	0x2a, 0xb1, 0xc2, 0x90, 0xba, 0x48, 0xce, 0xc0, 0x57, 0x4e, 0x00, 0xed, 0xd8, 0x0f, 0x87, 0x0f,
-	0x07, 0x57, 0xc1, 0x75, 0x33, 0x4a, 0xb7, 0x36, 0xe5, 0x09, 0xd0, 0x82, 0xa5, 0x7a, 0x21, 0x4d,
-	0xd9, 0x70, 0xbf, 0x48, 0x80, 0x23, 0x93, 0x57, 0xd0, 0xe6, 0x15, 0x97, 0x3a, 0x2d, 0xdb, 0xee,
-	0x7e, 0xa5, 0x5d, 0xd5, 0x44, 0x5a, 0x83, 0x7b, 0x3f, 0x3c, 0x68, 0x51, 0x9c, 0x73, 0x6d, 0xd4,
-	0x8a, 0x9c, 0x02, 0x6c, 0x36, 0xe5, 0xef, 0x47, 0xb3, 0x7f, 0x38, 0x7c, 0x54, 0xbb, 0x76, 0x01,
-	0x0e, 0x36, 0x16, 0xea, 0xb1, 0x30, 0x6a, 0x45, 0x2b, 0xdb, 0x1e, 0x9c, 0xc3, 0x91, 0x53, 0x26,
-	0x3e, 0x34, 0x2f, 0x70, 0x65, 0x3d, 0x3d, 0xa0, 0xf9, 0x92, 0x3c, 0x87, 0xdd, 0xcf, 0x6c, 0x99,
-	0xa1, 0xf5, 0xaf, 0x3e, 0x1b, 0x37, 0x1e, 0xb4, 0x20, 0x5f, 0x36, 0x5e, 0x78, 0xaf, 0xfd, 0x9f,
-	0xeb, 0xae, 0xf7, 0x7b, 0xdd, 0xf5, 0xfe, 0xac, 0xbb, 0xde, 0xf7, 0xbf, 0xdd, 0x5b, 0xe1, 0x9e,
-	0xfd, 0x06, 0x8c, 0xfe, 0x05, 0x00, 0x00, 0xff, 0xff, 0x30, 0x42, 0xc6, 0x09, 0x4e, 0x04, 0x00,
-	0x00,
+	// 766 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0xdd, 0x8e, 0xdb, 0x44,
+	0x14, 0xae, 0x93, 0x6e, 0x9b, 0x9c, 0x24, 0x8d, 0x33, 0x54, 0xc2, 0x2a, 0x10, 0x45, 0x06, 0xa1,
+	0xa8, 0x5a, 0x25, 0x22, 0x7b, 0x83, 0x40, 0x42, 0x0a, 0x89, 0xa9, 0x52, 0xa5, 0x21, 0x9a, 0xa4,
+	0x14, 0xad, 0x90, 0xa2, 0xb1, 0x3d, 0x49, 0xac, 0xda, 0x1e, 0x6b, 0x66, 0x0c, 0x0d, 0xb7, 0xbc,
+	0x00, 0xef, 0xc1, 0x05, 0xaf, 0xc1, 0x05, 0x17, 0x3c, 0x02, 0x5a, 0x5e, 0x04, 0x79, 0xbc, 0x4e,
+	0xfd, 0x53, 0x89, 0x95, 0xb8, 0x59, 0x79, 0xbe, 0xf3, 0x9d, 0x73, 0xbe, 0x39, 0xf3, 0x9d, 0x0d,
+	0x3c, 0x3b, 0x78, 0xf2, 0x18, 0xdb, 0x23, 0x87, 0x05, 0xe3, 0xe0, 0xca, 0xb5, 0xc7, 0xc1, 0xd5,
+	0x58, 0x70, 0x67, 0xec, 0xda, 0x21, 0x73, 0xe9, 0xf8, 0x40, 0x43, 0xca, 0x89, 0xa4, 0xee, 0x38,
+	0xe2, 0x4c, 0xb2, 0x71, 0x48, 0x02, 0x2a, 0x22, 0xe2, 0xd0, 0xb7, 0x5f, 0x23, 0x15, 0x41, 0xcd,
+	0x33, 0x60, 0xfe, 0x59, 0x03, 0x1d, 0x53, 0x49, 0x43, 0xe9, 0xb1, 0xf0, 0xdb, 0x28, 0xf9, 0x2b,
+	0xd0, 0x04, 0x1e, 0xf3, 0x0c, 0x5b, 0x53, 0xee, 0x31, 0x77, 0x45, 0x42, 0x26, 0x0c, 0x6d, 0xa0,
+	0x0d, 0xeb, 0xf8, 0x9d, 0x31, 0xf4, 0x29, 0x3c, 0xb2, 0x7d, 0xe6, 0xbc, 0xde, 0x78, 0x3f, 0xd3,
+	0x94, 0x5d, 0x53, 0xec, 0x12, 0x8a, 0x2e, 0xa1, 0x67, 0xc7, 0xfb, 0x3d, 0xe5, 0xdf, 0xc4, 0x32,
+	0xe6, 0xb7, 0xd4, 0xba, 0xa2, 0x56, 0x03, 0x68, 0x08, 0xdd, 0x14, 0x5c, 0x13, 0x21, 0x53, 0xee,
+	0x7d, 0xc5, 0x2d, 0xc3, 0x8a, 0x99, 0x74, 0x9a, 0x13, 0x49, 0xac, 0x37, 0x91, 0xc7, 0x4f, 0xc6,
+	0xc5, 0x40, 0x1b, 0x36, 0x70, 0x19, 0x46, 0xd7, 0x30, 0x2c, 0x41, 0xd3, 0xbd, 0xa4, 0x7c, 0xc5,
+	0xe4, 0xd4, 0x71, 0xa8, 0x10, 0xf9, 0x1b, 0x3f, 0x50, 0xcd, 0xee, 0xcc, 0x37, 0xd7, 0xd0, 0x5e,
+	0x84, 0x2e, 0x7d, 0x93, 0x4d, 0xd2, 0x80, 0x87, 0x34, 0x24, 0xb6, 0x4f, 0x5d, 0x35, 0xbc, 0x06,
+	0xce, 0x8e, 0x77, 0x9d, 0x97, 0xf9, 0x03, 0xa0, 0x19, 0xf3, 0xdd, 0x8d, 0x64, 0x9c, 0x1c, 0xe8,
+	0x7f, 0xd7, 0xbd, 0x84, 0x1e, 0xdb, 0xef, 0x7d, 0x46, 0xdc, 0x54, 0x65, 0xae, 0x74, 0x35, 0x60,
+	0xfe, 0xa2, 0x41, 0x6f, 0xc6, 0x82, 0x88, 0x38, 0xf9, 0xf7, 0xff, 0xdf, 0xaa, 0x13, 0x15, 0x4e,
+	0x5a, 0x36, 0xa7, 0xe2, 0xf6, 0x95, 0x2b, 0x01, 0x73, 0x07, 0x9d, 0x8d, 0x73, 0xa4, 0x01, 0xc9,
+	0x04, 0x5c, 0x42, 0x6f, 0xef, 0xf9, 0x74, 0x4e, 0x85, 0xc3, 0xbd, 0x48, 0x32, 0xbe, 0xa1, 0x52,
+	0x49, 0x69, 0xe3, 0x6a, 0x00, 0x0d, 0xa0, 0x15, 0x50, 0x21, 0xc8, 0x81, 0xae, 0x48, 0x40, 0x95,
+	0xa2, 0x26, 0xce, 0x43, 0xe6, 0xef, 0x17, 0xa0, 0xaf, 0x32, 0xcf, 0x67, 0x4d, 0x9e, 0x82, 0x6e,
+	0x33, 0x26, 0x85, 0xe4, 0x24, 0xb2, 0x0a, 0xd7, 0xad, 0xe0, 0xc8, 0x84, 0xf6, 0xde, 0x8f, 0xc5,
+	0x31, 0xe3, 0xd5, 0x14, 0xaf, 0x80, 0x25, 0xa2, 0x7f, 0xe2, 0x9e, 0xa4, 0x62, 0xcb, 0x66, 0x2c,
+	0x08, 0x3c, 0xb9, 0x64, 0x07, 0x75, 0xe7, 0x06, 0xae, 0x06, 0x92, 0x49, 0x3a, 0x3e, 0x25, 0x61,
+	0x7c, 0xee, 0x7d, 0x5f, 0x51, 0x4b, 0x28, 0xfa, 0x04, 0x3a, 0x9c, 0x46, 0xc4, 0xe3, 0x19, 0x2d,
+	0x75, 0x75, 0x11, 0x44, 0xcf, 0x40, 0xe7, 0xa5, 0x2d, 0x56, 0xde, 0x6d, 0x4d, 0x3e, 0x18, 0xbd,
+	0xdd, 0xfe, 0xf2, 0xa2, 0xe3, 0x4a, 0x52, 0xb2, 0x46, 0x22, 0x24, 0x91, 0x38, 0x32, 0x99, 0x35,
+	0x7c, 0x98, 0xae, 0x51, 0x09, 0x46, 0x5f, 0x42, 0xdb, 0xcb, 0x59, 0xdd, 0x68, 0xa8, 0x76, 0xef,
+	0xe7, 0xda, 0xe5, 0x37, 0x01, 0x17, 0xc8, 0xe8, 0x05, 0x20, 0xa7, 0xe2, 0x6a, 0xa3, 0xa9, 0x4a,
+	0x7c, 0x94, 0x2b, 0x51, 0xb5, 0x3e, 0x7e, 0x47, 0x22, 0xfa, 0x0a, 0x3a, 0x22, 0x6f, 0x20, 0x03,
+	0x54, 0x25, 0x23, 0x57, 0xa9, 0x60, 0x30, 0x5c, 0xa4, 0xa3, 0xe7, 0x67, 0xbb, 0xe6, 0xe6, 0xd7,
+	0x52, 0x35, 0x3e, 0x2c, 0xa8, 0x29, 0x71, 0x70, 0x35, 0x0d, 0xcd, 0xa1, 0xeb, 0xc6, 0x91, 0xef,
+	0x39, 0x44, 0xd2, 0x35, 0xf3, 0x3d, 0xe7, 0x64, 0xb4, 0x07, 0xda, 0xf0, 0xd1, 0xe4, 0x49, 0xae,
+	0xd2, 0xbc, 0xc8, 0xc0, 0xe5, 0x14, 0xf3, 0x37, 0x0d, 0x1a, 0x98, 0x1e, 0x3c, 0x21, 0xf9, 0x09,
+	0xcd, 0x00, 0xce, 0xa9, 0xc9, 0x7f, 0xe1, 0xfa, 0xb0, 0x35, 0xf9, 0xb8, 0xf0, 0xae, 0x29, 0x71,
+	0x74, 0xf6, 0xb8, 0xb0, 0x42, 0xc9, 0x4f, 0x38, 0x97, 0xf6, 0xe4, 0x1a, 0xba, 0xa5, 0x30, 0xd2,
+	0xa1, 0xfe, 0x9a, 0x9e, 0x94, 0xe9, 0x9b, 0x38, 0xf9, 0x44, 0x9f, 0xc1, 0xc5, 0x8f, 0xc4, 0x8f,
+	0xd3, 0x25, 0x2a, 0x9a, 0xa7, 0xbc, 0x3f, 0x38, 0x65, 0x7e, 0x51, 0xfb, 0x5c, 0x7b, 0x7a, 0x0d,
+	0xdd, 0xd2, 0x8d, 0xd0, 0x7b, 0xd0, 0x5d, 0x4e, 0x37, 0xdb, 0xdd, 0x2b, 0xbc, 0xd8, 0x5a, 0xbb,
+	0x57, 0x8b, 0xd5, 0x46, 0xbf, 0x87, 0x3a, 0xd0, 0x7c, 0x31, 0xfd, 0x7e, 0xf7, 0xdd, 0x74, 0xf9,
+	0xd2, 0xd2, 0x35, 0x75, 0x5c, 0xac, 0x6e, 0x8f, 0x35, 0xf4, 0x18, 0x74, 0x6c, 0x3d, 0xb7, 0x66,
+	0xdb, 0xdd, 0xfc, 0xe5, 0x7a, 0xb9, 0x98, 0x4d, 0xb7, 0x96, 0x5e, 0xff, 0x5a, 0xff, 0xe3, 0xa6,
+	0xaf, 0xfd, 0x75, 0xd3, 0xd7, 0xfe, 0xbe, 0xe9, 0x6b, 0xbf, 0xfe, 0xd3, 0xbf, 0x67, 0x3f, 0x50,
+	0xbf, 0x62, 0x57, 0xff, 0x0e, 0x00, 0x34, 0xbb, 0x53, 0x8e, 0x10, 0x07, 0x00, 0x00,
 }
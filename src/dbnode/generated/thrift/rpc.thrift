@@ -29,7 +29,8 @@ enum TimeType {
 
 enum ErrorType {
 	INTERNAL_ERROR,
-	BAD_REQUEST
+	BAD_REQUEST,
+	RESOURCE_EXHAUSTED
 }
 
 exception Error {
@@ -46,6 +47,7 @@ service Node {
 	QueryResult query(1: QueryRequest req) throws (1: Error err)
 	FetchResult fetch(1: FetchRequest req) throws (1: Error err)
 	FetchTaggedResult fetchTagged(1: FetchTaggedRequest req) throws (1: Error err)
+	FetchTaggedPageResult fetchTaggedPage(1: FetchTaggedPageRequest req) throws (1: Error err)
 	void write(1: WriteRequest req) throws (1: Error err)
 	void writeTagged(1: WriteTaggedRequest req) throws (1: Error err)
 
@@ -58,6 +60,9 @@ service Node {
 	void writeTaggedBatchRaw(1: WriteTaggedBatchRawRequest req) throws (1: WriteBatchRawErrors err)
 	void repair() throws (1: Error err)
 	TruncateResult truncate(1: TruncateRequest req) throws (1: Error err)
+	BackupResult backup(1: BackupRequest req) throws (1: Error err)
+	CardinalityResult cardinality(1: CardinalityRequest req) throws (1: Error err)
+	QueryCostResult queryCost(1: QueryCostRequest req) throws (1: Error err)
 
 	// Management endpoints
 	NodeHealthResult health() throws (1: Error err)
@@ -149,6 +154,17 @@ struct FetchTaggedResult {
 	2: required bool exhaustive
 }
 
+struct FetchTaggedPageRequest {
+	1: required FetchTaggedRequest fetchTagged
+	2: required i64 pageSize
+	3: optional binary pageToken
+}
+
+struct FetchTaggedPageResult {
+	1: required list<FetchTaggedIDResult> elements
+	2: optional binary nextPageToken
+}
+
 struct FetchTaggedIDResult {
 	1: required binary id
 	2: required binary nameSpace
@@ -251,6 +267,61 @@ struct TruncateResult {
 	1: required i64 numSeries
 }
 
+struct BackupRequest {
+	1: required string destinationPath
+}
+
+struct BackupResult {
+	1: required i64 snapshotIndex
+	2: required string snapshotUUID
+	3: required i64 numFiles
+}
+
+struct CardinalityRequest {
+	1: required binary nameSpace
+	2: required i64 rangeStart
+	3: required i64 rangeEnd
+	4: optional TimeType rangeTimeType = TimeType.UNIX_SECONDS
+	5: optional i64 limit
+	6: optional binary nameTag
+	7: optional i64 maxTerms
+}
+
+struct CardinalityResult {
+	1: required list<CardinalityBlock> blocks
+}
+
+struct CardinalityBlock {
+	1: required i64 blockStart
+	2: required i64 numSeries
+	3: required list<CardinalityEntry> metricNames
+	4: required list<CardinalityEntry> tagNames
+	5: required list<CardinalityEntry> tagValues
+	6: required bool exhaustive
+}
+
+struct CardinalityEntry {
+	1: required binary name
+	2: required i64 count
+}
+
+struct QueryCostRequest {
+	1: required binary nameSpace
+	2: required binary query
+	3: required i64 rangeStart
+	4: required i64 rangeEnd
+	5: optional TimeType rangeTimeType = TimeType.UNIX_SECONDS
+}
+
+struct QueryCostResult {
+	1: required list<QueryCostBlock> blocks
+}
+
+struct QueryCostBlock {
+	1: required i64 blockStart
+	2: required list<i64> termPostings
+}
+
 struct NodeHealthResult {
 	1: required bool ok
 	2: required string status
@@ -345,6 +416,12 @@ struct RegexpQuery {
   2: required string regexp
 }
 
+struct FieldQuery {
+  1: required string field
+}
+
+struct AllQuery {}
+
 struct NegationQuery {
   1: required Query query
 }
@@ -363,4 +440,6 @@ struct Query {
   3: optional NegationQuery negation
   4: optional ConjunctionQuery conjunction
   5: optional DisjunctionQuery disjunction
+  6: optional FieldQuery field
+  7: optional AllQuery all
 }
@@ -30,7 +30,6 @@ import (
 
 	"github.com/m3db/m3/src/cluster/services"
 	"github.com/m3db/m3/src/cluster/shard"
-	"github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/dbnode/integration/fake"
 	"github.com/m3db/m3/src/dbnode/integration/generate"
 	"github.com/m3db/m3/src/dbnode/retention"
@@ -263,10 +262,11 @@ func testClusterAddOneNode(t *testing.T, verifyCommitlogCanBootstrapAfterNodeJoi
 	go func() {
 		for _, testData := range seriesReceivedDuringPeerStreaming {
 			err := setups[1].writeBatch(namesp.ID(), testData)
-			// We expect consistency errors because we're only running with
-			// R.F = 2 and one node is leaving and one node is joining for
-			// each of the shards that is changing hands.
-			if !client.IsConsistencyResultError(err) {
+			// We expect no errors even though we're only running with
+			// R.F = 1 because the node that is leaving and the node that is
+			// joining count as a single replica for each of the shards that
+			// is changing hands.
+			if err != nil {
 				panic(err)
 			}
 		}
@@ -0,0 +1,184 @@
+// +build integration
+
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/services"
+	"github.com/m3db/m3/src/cluster/shard"
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/integration/fake"
+	"github.com/m3db/m3/src/dbnode/storage/bootstrap/bootstrapper"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/dbnode/storage/namespace"
+	"github.com/m3db/m3/src/dbnode/topology"
+	"github.com/m3db/m3/src/dbnode/topology/testutil"
+	"github.com/m3db/m3/src/m3ninx/idx"
+	"github.com/m3db/m3x/context"
+	"github.com/m3db/m3x/ident"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterMoveShardsMidTrafficFetchTagged(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+
+	numShards := defaultNumShards
+	minShard := uint32(0)
+	maxShard := uint32(numShards - 1)
+
+	// Shards move from the first node to the last node while the series are
+	// read, the last node is written to directly so that reads that hit its
+	// initializing shards return series the other nodes don't have.
+	initializing := testutil.ShardsRange(minShard, maxShard, shard.Initializing)
+	for _, s := range initializing {
+		s.SetSourceID("testhost0")
+	}
+	start := []services.ServiceInstance{
+		node(t, 0, newClusterShardsRange(minShard, maxShard, shard.Available)),
+		node(t, 1, newClusterShardsRange(minShard, maxShard, shard.Available)),
+		node(t, 2, newClusterShardsRange(minShard, maxShard, shard.Available)),
+		node(t, 3, newClusterEmptyShardsRange()),
+	}
+	moving := []services.ServiceInstance{
+		node(t, 0, newClusterShardsRange(minShard, maxShard, shard.Leaving)),
+		node(t, 1, newClusterShardsRange(minShard, maxShard, shard.Available)),
+		node(t, 2, newClusterShardsRange(minShard, maxShard, shard.Available)),
+		node(t, 3, shard.NewShards(initializing)),
+	}
+
+	var (
+		nsOpts  = namespace.NewOptions()
+		md, err = namespace.NewMetadata(testNamespaces[0],
+			nsOpts.SetRetentionOptions(nsOpts.RetentionOptions().SetRetentionPeriod(6*time.Hour)).
+				SetIndexOptions(namespace.NewIndexOptions().SetEnabled(true)))
+	)
+	require.NoError(t, err)
+
+	opts := newTestOptions(t).
+		SetNamespaces([]namespace.Metadata{md}).
+		SetNumShards(numShards)
+
+	svc := fake.NewM3ClusterService().
+		SetInstances(start).
+		SetReplication(services.NewServiceReplication().SetReplicas(3)).
+		SetSharding(services.NewServiceSharding().SetNumShards(numShards))
+
+	svcs := fake.NewM3ClusterServices()
+	svcs.RegisterService("m3db", svc)
+
+	topoOpts := topology.NewDynamicOptions().
+		SetConfigServiceClient(fake.NewM3ClusterClient(svcs, nil))
+	topoInit := topology.NewDynamicInitializer(topoOpts)
+
+	setupOpts := make([]bootstrappableTestSetupOptions, len(start))
+	for i := range setupOpts {
+		setupOpts[i] = bootstrappableTestSetupOptions{
+			disablePeersBootstrapper: true,
+			finalBootstrapper:        bootstrapper.NoOpAllBootstrapperName,
+			topologyInitializer:      topoInit,
+		}
+	}
+	nodes, closeFn := newDefaultBootstrappableTestSetups(t, opts, setupOpts)
+	defer closeFn()
+
+	for _, n := range nodes {
+		require.NoError(t, n.startServer())
+	}
+	defer func() {
+		nodes.parallel(func(s *testSetup) {
+			require.NoError(t, s.stopServer())
+		})
+	}()
+
+	clientOpts := client.NewOptions().
+		SetClusterConnectConsistencyLevel(topology.ConnectConsistencyLevelNone).
+		SetClusterConnectTimeout(2 * time.Second).
+		SetWriteRequestTimeout(2 * time.Second).
+		SetFetchRequestTimeout(2 * time.Second).
+		SetTopologyInitializer(topoInit)
+	c, err := client.NewClient(clientOpts)
+	require.NoError(t, err)
+	session, err := c.NewSession()
+	require.NoError(t, err)
+	defer session.Close()
+
+	now := nodes[0].getNowFn()
+	require.NoError(t, session.WriteTagged(md.ID(), ident.StringID("written"),
+		ident.NewTagsIterator(ident.NewTags(ident.StringTag("foo", "bar"))),
+		now, 42, xtime.Second, nil))
+
+	// Start moving the shards to the last node.
+	svc.SetInstances(moving)
+	svcs.NotifyServiceUpdate("m3db")
+	waitUntilHasBootstrappedShardsExactly(nodes[3].db,
+		testutil.Uint32Range(minShard, maxShard))
+
+	ctx := context.NewContext()
+	defer ctx.BlockingClose()
+	require.NoError(t, nodes[3].db.WriteTagged(ctx, md.ID(), ident.StringID("written"),
+		ident.NewTagsIterator(ident.NewTags(ident.StringTag("foo", "bar"))),
+		now.Add(time.Second), 1, xtime.Second, nil))
+	require.NoError(t, nodes[3].db.WriteTagged(ctx, md.ID(), ident.StringID("initializing"),
+		ident.NewTagsIterator(ident.NewTags(ident.StringTag("foo", "baz"))),
+		now, 1, xtime.Second, nil))
+
+	q, err := idx.NewRegexpQuery([]byte("foo"), []byte("b.*"))
+	require.NoError(t, err)
+	for _, level := range []topology.ReadConsistencyLevel{
+		topology.ReadConsistencyLevelOne,
+		topology.ReadConsistencyLevelUnstrictMajority,
+		topology.ReadConsistencyLevelMajority,
+	} {
+		c, err := client.NewClient(clientOpts.SetReadConsistencyLevel(level))
+		require.NoError(t, err)
+		s, err := c.NewSession()
+		require.NoError(t, err)
+
+		// Only the series and datapoint written before the move are read, the
+		// initializing shards of the last node are never read from.
+		iters, exhaustive, err := s.FetchTagged(md.ID(), index.Query{q},
+			index.QueryOptions{
+				StartInclusive: now.Add(-time.Minute),
+				EndExclusive:   now.Add(time.Minute),
+			})
+		require.NoError(t, err)
+		require.True(t, exhaustive)
+		require.Equal(t, 1, iters.Len())
+		iter := iters.Iters()[0]
+		require.Equal(t, "written", iter.ID().String())
+		require.True(t, iter.Next())
+		dp, _, _ := iter.Current()
+		require.Equal(t, 42., dp.Value)
+		require.False(t, iter.Next())
+		require.NoError(t, iter.Err())
+		iters.Close()
+
+		require.NoError(t, s.Close())
+	}
+}
@@ -0,0 +1,230 @@
+// +build integration
+
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package integration
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/services"
+	"github.com/m3db/m3/src/cluster/shard"
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/integration/fake"
+	"github.com/m3db/m3/src/dbnode/storage/bootstrap/bootstrapper"
+	"github.com/m3db/m3/src/dbnode/storage/namespace"
+	"github.com/m3db/m3/src/dbnode/topology"
+	"github.com/m3db/m3/src/dbnode/topology/testutil"
+	"github.com/m3db/m3x/ident"
+	xretry "github.com/m3db/m3x/retry"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterMoveShardsMidTrafficWrites(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+
+	numShards := defaultNumShards
+	minShard := uint32(0)
+	maxShard := uint32(numShards - 1)
+
+	// Shards move from the first node to the last node, the third node stays
+	// down so that majority writes rely on the leaving and initializing pair
+	// counting as a single replica while the shards are moving.
+	initializing := testutil.ShardsRange(minShard, maxShard, shard.Initializing)
+	for _, s := range initializing {
+		s.SetSourceID("testhost0")
+	}
+	instances := struct {
+		start  []services.ServiceInstance
+		moving []services.ServiceInstance
+		moved  []services.ServiceInstance
+	}{
+		start: []services.ServiceInstance{
+			node(t, 0, newClusterShardsRange(minShard, maxShard, shard.Available)),
+			node(t, 1, newClusterShardsRange(minShard, maxShard, shard.Available)),
+			node(t, 2, newClusterShardsRange(minShard, maxShard, shard.Available)),
+			node(t, 3, newClusterEmptyShardsRange()),
+		},
+		moving: []services.ServiceInstance{
+			node(t, 0, newClusterShardsRange(minShard, maxShard, shard.Leaving)),
+			node(t, 1, newClusterShardsRange(minShard, maxShard, shard.Available)),
+			node(t, 2, newClusterShardsRange(minShard, maxShard, shard.Available)),
+			node(t, 3, shard.NewShards(initializing)),
+		},
+		moved: []services.ServiceInstance{
+			node(t, 0, newClusterEmptyShardsRange()),
+			node(t, 1, newClusterShardsRange(minShard, maxShard, shard.Available)),
+			node(t, 2, newClusterShardsRange(minShard, maxShard, shard.Available)),
+			node(t, 3, newClusterShardsRange(minShard, maxShard, shard.Available)),
+		},
+	}
+
+	nsOpts := namespace.NewOptions()
+	md, err := namespace.NewMetadata(testNamespaces[0],
+		nsOpts.SetRetentionOptions(nsOpts.RetentionOptions().SetRetentionPeriod(6*time.Hour)))
+	require.NoError(t, err)
+
+	opts := newTestOptions(t).
+		SetNamespaces([]namespace.Metadata{md}).
+		SetNumShards(numShards)
+
+	svc := fake.NewM3ClusterService().
+		SetInstances(instances.start).
+		SetReplication(services.NewServiceReplication().SetReplicas(3)).
+		SetSharding(services.NewServiceSharding().SetNumShards(numShards))
+
+	svcs := fake.NewM3ClusterServices()
+	svcs.RegisterService("m3db", svc)
+
+	topoOpts := topology.NewDynamicOptions().
+		SetConfigServiceClient(fake.NewM3ClusterClient(svcs, nil))
+	topoInit := topology.NewDynamicInitializer(topoOpts)
+
+	setupOpts := make([]bootstrappableTestSetupOptions, len(instances.start))
+	for i := range setupOpts {
+		setupOpts[i] = bootstrappableTestSetupOptions{
+			disablePeersBootstrapper: true,
+			finalBootstrapper:        bootstrapper.NoOpAllBootstrapperName,
+			topologyInitializer:      topoInit,
+		}
+	}
+	nodes, closeFn := newDefaultBootstrappableTestSetups(t, opts, setupOpts)
+	defer closeFn()
+
+	up := testSetups{nodes[0], nodes[1], nodes[3]}
+	for _, n := range up {
+		require.NoError(t, n.startServer())
+	}
+	defer func() {
+		up.parallel(func(s *testSetup) {
+			require.NoError(t, s.stopServer())
+		})
+	}()
+
+	// Retry for long enough to ride out the nodes and the client observing a
+	// placement change at slightly different times.
+	retrier := xretry.NewRetrier(xretry.NewOptions().
+		SetInitialBackoff(100 * time.Millisecond).
+		SetMaxRetries(5))
+	clientOpts := client.NewOptions().
+		SetClusterConnectConsistencyLevel(topology.ConnectConsistencyLevelNone).
+		SetClusterConnectTimeout(2 * time.Second).
+		SetWriteRequestTimeout(2 * time.Second).
+		SetFetchRequestTimeout(2 * time.Second).
+		SetWriteRetrier(retrier).
+		SetWriteConsistencyLevel(topology.ConsistencyLevelMajority).
+		SetReadConsistencyLevel(topology.ReadConsistencyLevelMajority).
+		SetTopologyInitializer(topoInit)
+	c, err := client.NewClient(clientOpts)
+	require.NoError(t, err)
+	session, err := c.NewSession()
+	require.NoError(t, err)
+	defer session.Close()
+
+	// Keep writing throughout the placement changes.
+	var (
+		now      = nodes[0].getNowFn()
+		stopCh   = make(chan struct{})
+		doneCh   = make(chan struct{})
+		lock     sync.Mutex
+		written  []string
+		writeErr error
+	)
+	go func() {
+		defer close(doneCh)
+		for i := 0; ; i++ {
+			select {
+			case <-stopCh:
+				return
+			default:
+			}
+
+			id := fmt.Sprintf("series%d", i)
+			err := session.Write(md.ID(), ident.StringID(id), now, float64(i), xtime.Second, nil)
+
+			lock.Lock()
+			if err != nil && writeErr == nil {
+				writeErr = err
+			}
+			if err == nil {
+				written = append(written, id)
+			}
+			lock.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+
+	numWritten := func() int {
+		lock.Lock()
+		defer lock.Unlock()
+		return len(written)
+	}
+	waitForWrites := func(n int) {
+		for numWritten() < n {
+			lock.Lock()
+			err := writeErr
+			lock.Unlock()
+			require.NoError(t, err)
+			time.Sleep(100 * time.Millisecond)
+		}
+	}
+
+	waitForWrites(10)
+
+	// Start moving the shards to the last node.
+	svc.SetInstances(instances.moving)
+	svcs.NotifyServiceUpdate("m3db")
+	waitUntilHasBootstrappedShardsExactly(nodes[3].db,
+		testutil.Uint32Range(minShard, maxShard))
+	waitForWrites(numWritten() + 50)
+
+	// Reads never hit the initializing shards, the series written before the
+	// move only exist on the first two nodes.
+	lock.Lock()
+	readID := written[0]
+	lock.Unlock()
+	iter, err := session.Fetch(md.ID(), ident.StringID(readID),
+		now.Add(-time.Minute), now.Add(time.Minute))
+	require.NoError(t, err)
+	require.True(t, iter.Next())
+	iter.Close()
+
+	// Finish moving the shards.
+	svc.SetInstances(instances.moved)
+	svcs.NotifyServiceUpdate("m3db")
+	waitUntilHasBootstrappedShardsExactly(nodes[0].db, nil)
+	waitForWrites(numWritten() + 50)
+
+	close(stopCh)
+	<-doneCh
+
+	lock.Lock()
+	defer lock.Unlock()
+	require.NoError(t, writeErr)
+}
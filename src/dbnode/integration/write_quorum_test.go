@@ -146,8 +146,9 @@ func TestAddNodeQuorumOnlyLeavingInitializingUp(t *testing.T) {
 	require.NoError(t, nodes[3].startServerDontWaitBootstrap())
 	defer func() { require.NoError(t, nodes[3].stopServer()) }()
 
-	// No writes succeed to available nodes
-	assert.Error(t, testWrite(topology.ConsistencyLevelOne))
+	// Writes succeed to the leaving and initializing pair which count as a
+	// single replica, but no writes succeed to available nodes
+	assert.NoError(t, testWrite(topology.ConsistencyLevelOne))
 	assert.Error(t, testWrite(topology.ConsistencyLevelMajority))
 	assert.Error(t, testWrite(topology.ConsistencyLevelAll))
 }
@@ -177,9 +178,10 @@ func TestAddNodeQuorumOnlyOneNormalAndLeavingInitializingUp(t *testing.T) {
 	require.NoError(t, nodes[3].startServerDontWaitBootstrap())
 	defer func() { require.NoError(t, nodes[3].stopServer()) }()
 
-	// Writes succeed to one available node
+	// Writes succeed to one available node and the leaving and initializing
+	// pair which count as a single replica
 	assert.NoError(t, testWrite(topology.ConsistencyLevelOne))
-	assert.Error(t, testWrite(topology.ConsistencyLevelMajority))
+	assert.NoError(t, testWrite(topology.ConsistencyLevelMajority))
 	assert.Error(t, testWrite(topology.ConsistencyLevelAll))
 }
 
@@ -210,10 +212,11 @@ func TestAddNodeQuorumAllUp(t *testing.T) {
 	require.NoError(t, nodes[3].startServerDontWaitBootstrap())
 	defer func() { require.NoError(t, nodes[3].stopServer()) }()
 
-	// Writes succeed to two available nodes
+	// Writes succeed to two available nodes and the leaving and initializing
+	// pair which count as a single replica
 	assert.NoError(t, testWrite(topology.ConsistencyLevelOne))
 	assert.NoError(t, testWrite(topology.ConsistencyLevelMajority))
-	assert.Error(t, testWrite(topology.ConsistencyLevelAll))
+	assert.NoError(t, testWrite(topology.ConsistencyLevelAll))
 }
 
 type testWriteFn func(topology.ConsistencyLevel) error
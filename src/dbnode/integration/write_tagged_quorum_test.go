@@ -156,8 +156,9 @@ func TestWriteTaggedAddNodeQuorumOnlyLeavingInitializingUp(t *testing.T) {
 	require.NoError(t, nodes[3].startServerDontWaitBootstrap())
 	defer func() { require.NoError(t, nodes[3].stopServer()) }()
 
-	// No writes succeed to available nodes
-	assert.Error(t, testWrite(topology.ConsistencyLevelOne))
+	// Writes succeed to the leaving and initializing pair which count as a
+	// single replica, but no writes succeed to available nodes
+	assert.NoError(t, testWrite(topology.ConsistencyLevelOne))
 	numWrites := numNodesWithTaggedWrite(t, []*testSetup{nodes[1], nodes[2]})
 	assert.True(t, numWrites == 0)
 
@@ -195,12 +196,13 @@ func TestWriteTaggedAddNodeQuorumOnlyOneNormalAndLeavingInitializingUp(t *testin
 	require.NoError(t, nodes[3].startServerDontWaitBootstrap())
 	defer func() { require.NoError(t, nodes[3].stopServer()) }()
 
-	// Writes succeed to one available node
+	// Writes succeed to one available node and the leaving and initializing
+	// pair which count as a single replica
 	assert.NoError(t, testWrite(topology.ConsistencyLevelOne))
 	numWrites := numNodesWithTaggedWrite(t, []*testSetup{nodes[1], nodes[2]})
 	assert.True(t, numWrites == 1)
 
-	assert.Error(t, testWrite(topology.ConsistencyLevelMajority))
+	assert.NoError(t, testWrite(topology.ConsistencyLevelMajority))
 	numWrites = numNodesWithTaggedWrite(t, []*testSetup{nodes[1], nodes[2]})
 	assert.True(t, numWrites == 1)
 
@@ -236,7 +238,8 @@ func TestWriteTaggedAddNodeQuorumAllUp(t *testing.T) {
 	require.NoError(t, nodes[3].startServerDontWaitBootstrap())
 	defer func() { require.NoError(t, nodes[3].stopServer()) }()
 
-	// Writes succeed to two available nodes
+	// Writes succeed to two available nodes and the leaving and initializing
+	// pair which count as a single replica
 	assert.NoError(t, testWrite(topology.ConsistencyLevelOne))
 	numWrites := numNodesWithTaggedWrite(t, []*testSetup{nodes[1], nodes[2]})
 	assert.True(t, numWrites >= 1, numWrites)
@@ -245,7 +248,7 @@ func TestWriteTaggedAddNodeQuorumAllUp(t *testing.T) {
 	numWrites = numNodesWithTaggedWrite(t, []*testSetup{nodes[1], nodes[2]})
 	assert.Equal(t, 2, numWrites)
 
-	assert.Error(t, testWrite(topology.ConsistencyLevelAll))
+	assert.NoError(t, testWrite(topology.ConsistencyLevelAll))
 }
 
 func makeTestWriteTagged(
@@ -33,6 +33,11 @@ const (
 	// configuration specifying a hard limit for a cluster new series insertions.
 	ClusterNewSeriesInsertLimitKey = "m3db.node.cluster-new-series-insert-limit"
 
+	// NamespaceWriteQuotasKey is the KV config key for the runtime
+	// configuration specifying the write quotas enforced by each node
+	// per namespace.
+	NamespaceWriteQuotasKey = "m3db.node.namespace-write-quotas"
+
 	// ClientBootstrapConsistencyLevel is the KV config key for the runtime
 	// configuration specifying the client bootstrap consistency level
 	ClientBootstrapConsistencyLevel = "m3db.client.bootstrap-consistency-level"
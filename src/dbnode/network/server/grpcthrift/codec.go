@@ -0,0 +1,91 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package grpcthrift
+
+import (
+	"fmt"
+
+	apachethrift "github.com/apache/thrift/lib/go/thrift"
+	"github.com/gogo/protobuf/proto"
+	"google.golang.org/grpc"
+)
+
+const codecName = "thrift"
+
+// encodedMessage is a message that has already been encoded, the node
+// service encodes responses before releasing the resources they reference.
+type encodedMessage struct {
+	bytes []byte
+}
+
+// emptyMessage is the message sent for methods without a request or a
+// response.
+type emptyMessage struct{}
+
+type codec struct{}
+
+var thriftCodec codec
+
+// NewCodec returns a gRPC codec that encodes protobuf messages with protobuf
+// and thrift structs with the thrift binary protocol, the methods of the node
+// service with a protobuf definition send protobuf messages and the remaining
+// methods send the existing thrift requests and responses.
+func NewCodec() grpc.Codec {
+	return codec{}
+}
+
+func (c codec) Marshal(v interface{}) ([]byte, error) {
+	switch msg := v.(type) {
+	case *encodedMessage:
+		return msg.bytes, nil
+	case *emptyMessage:
+		return nil, nil
+	case proto.Message:
+		return proto.Marshal(msg)
+	case apachethrift.TStruct:
+		buffer := apachethrift.NewTMemoryBuffer()
+		if err := msg.Write(apachethrift.NewTBinaryProtocolTransport(buffer)); err != nil {
+			return nil, err
+		}
+		return buffer.Bytes(), nil
+	}
+	return nil, fmt.Errorf("grpcthrift: unable to marshal type %T", v)
+}
+
+func (c codec) Unmarshal(data []byte, v interface{}) error {
+	switch msg := v.(type) {
+	case *emptyMessage:
+		return nil
+	case proto.Message:
+		return proto.Unmarshal(data, msg)
+	case apachethrift.TStruct:
+		buffer := apachethrift.NewTMemoryBufferLen(len(data))
+		if _, err := buffer.Write(data); err != nil {
+			return err
+		}
+		return msg.Read(apachethrift.NewTBinaryProtocolTransport(buffer))
+	}
+	return fmt.Errorf("grpcthrift: unable to unmarshal type %T", v)
+}
+
+func (c codec) String() string {
+	return codecName
+}
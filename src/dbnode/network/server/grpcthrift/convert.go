@@ -0,0 +1,498 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package grpcthrift
+
+import (
+	"github.com/m3db/m3/src/dbnode/generated/proto/rpcpb"
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+)
+
+// The conversions below between the thrift structs and the protobuf messages
+// of the node service share byte slices rather than copying them, the
+// converted values are only valid as long as the values they were converted
+// from are.
+
+func toProtoWriteRequest(req *rpc.WriteRequest) *rpcpb.WriteRequest {
+	return &rpcpb.WriteRequest{
+		NameSpace: req.NameSpace,
+		Id:        req.ID,
+		Datapoint: toProtoDatapoint(req.Datapoint),
+	}
+}
+
+func fromProtoWriteRequest(req *rpcpb.WriteRequest) *rpc.WriteRequest {
+	return &rpc.WriteRequest{
+		NameSpace: req.NameSpace,
+		ID:        req.Id,
+		Datapoint: fromProtoDatapoint(req.Datapoint),
+	}
+}
+
+func toProtoWriteTaggedRequest(req *rpc.WriteTaggedRequest) *rpcpb.WriteTaggedRequest {
+	tags := make([]*rpcpb.Tag, 0, len(req.Tags))
+	for _, tag := range req.Tags {
+		tags = append(tags, &rpcpb.Tag{Name: tag.Name, Value: tag.Value})
+	}
+	return &rpcpb.WriteTaggedRequest{
+		NameSpace: req.NameSpace,
+		Id:        req.ID,
+		Tags:      tags,
+		Datapoint: toProtoDatapoint(req.Datapoint),
+	}
+}
+
+func fromProtoWriteTaggedRequest(req *rpcpb.WriteTaggedRequest) *rpc.WriteTaggedRequest {
+	tags := make([]*rpc.Tag, 0, len(req.Tags))
+	for _, tag := range req.Tags {
+		tags = append(tags, &rpc.Tag{Name: tag.Name, Value: tag.Value})
+	}
+	return &rpc.WriteTaggedRequest{
+		NameSpace: req.NameSpace,
+		ID:        req.Id,
+		Tags:      tags,
+		Datapoint: fromProtoDatapoint(req.Datapoint),
+	}
+}
+
+func toProtoWriteBatchRawRequest(req *rpc.WriteBatchRawRequest) *rpcpb.WriteBatchRawRequest {
+	elements := make([]*rpcpb.WriteBatchRawRequestElement, 0, len(req.Elements))
+	for _, elem := range req.Elements {
+		elements = append(elements, &rpcpb.WriteBatchRawRequestElement{
+			Id:        elem.ID,
+			Datapoint: toProtoDatapoint(elem.Datapoint),
+		})
+	}
+	return &rpcpb.WriteBatchRawRequest{
+		NameSpace: req.NameSpace,
+		Elements:  elements,
+	}
+}
+
+func fromProtoWriteBatchRawRequest(req *rpcpb.WriteBatchRawRequest) *rpc.WriteBatchRawRequest {
+	elements := make([]*rpc.WriteBatchRawRequestElement, 0, len(req.Elements))
+	for _, elem := range req.Elements {
+		elements = append(elements, &rpc.WriteBatchRawRequestElement{
+			ID:        elem.Id,
+			Datapoint: fromProtoDatapoint(elem.Datapoint),
+		})
+	}
+	return &rpc.WriteBatchRawRequest{
+		NameSpace: req.NameSpace,
+		Elements:  elements,
+	}
+}
+
+func toProtoWriteTaggedBatchRawRequest(req *rpc.WriteTaggedBatchRawRequest) *rpcpb.WriteTaggedBatchRawRequest {
+	elements := make([]*rpcpb.WriteTaggedBatchRawRequestElement, 0, len(req.Elements))
+	for _, elem := range req.Elements {
+		elements = append(elements, &rpcpb.WriteTaggedBatchRawRequestElement{
+			Id:          elem.ID,
+			EncodedTags: elem.EncodedTags,
+			Datapoint:   toProtoDatapoint(elem.Datapoint),
+		})
+	}
+	return &rpcpb.WriteTaggedBatchRawRequest{
+		NameSpace: req.NameSpace,
+		Elements:  elements,
+	}
+}
+
+func fromProtoWriteTaggedBatchRawRequest(req *rpcpb.WriteTaggedBatchRawRequest) *rpc.WriteTaggedBatchRawRequest {
+	elements := make([]*rpc.WriteTaggedBatchRawRequestElement, 0, len(req.Elements))
+	for _, elem := range req.Elements {
+		elements = append(elements, &rpc.WriteTaggedBatchRawRequestElement{
+			ID:          elem.Id,
+			EncodedTags: elem.EncodedTags,
+			Datapoint:   fromProtoDatapoint(elem.Datapoint),
+		})
+	}
+	return &rpc.WriteTaggedBatchRawRequest{
+		NameSpace: req.NameSpace,
+		Elements:  elements,
+	}
+}
+
+func toProtoWriteBatchRawErrors(errs *rpc.WriteBatchRawErrors) *rpcpb.WriteBatchRawErrors {
+	result := make([]*rpcpb.WriteBatchRawError, 0, len(errs.Errors))
+	for _, err := range errs.Errors {
+		result = append(result, &rpcpb.WriteBatchRawError{
+			Index: err.Index,
+			Err:   toProtoError(err.Err),
+		})
+	}
+	return &rpcpb.WriteBatchRawErrors{Errors: result}
+}
+
+func fromProtoWriteBatchRawErrors(errs *rpcpb.WriteBatchRawErrors) *rpc.WriteBatchRawErrors {
+	result := make([]*rpc.WriteBatchRawError, 0, len(errs.Errors))
+	for _, err := range errs.Errors {
+		result = append(result, &rpc.WriteBatchRawError{
+			Index: err.Index,
+			Err:   fromProtoError(err.Err),
+		})
+	}
+	return &rpc.WriteBatchRawErrors{Errors: result}
+}
+
+func toProtoFetchRequest(req *rpc.FetchRequest) *rpcpb.FetchRequest {
+	return &rpcpb.FetchRequest{
+		RangeStart:     req.RangeStart,
+		RangeEnd:       req.RangeEnd,
+		NameSpace:      req.NameSpace,
+		Id:             req.ID,
+		RangeType:      rpcpb.TimeType(req.RangeType),
+		ResultTimeType: rpcpb.TimeType(req.ResultTimeType),
+	}
+}
+
+func fromProtoFetchRequest(req *rpcpb.FetchRequest) *rpc.FetchRequest {
+	return &rpc.FetchRequest{
+		RangeStart:     req.RangeStart,
+		RangeEnd:       req.RangeEnd,
+		NameSpace:      req.NameSpace,
+		ID:             req.Id,
+		RangeType:      rpc.TimeType(req.RangeType),
+		ResultTimeType: rpc.TimeType(req.ResultTimeType),
+	}
+}
+
+func toProtoFetchResult(result *rpc.FetchResult_) *rpcpb.FetchResult {
+	datapoints := make([]*rpcpb.Datapoint, 0, len(result.Datapoints))
+	for _, dp := range result.Datapoints {
+		datapoints = append(datapoints, toProtoDatapoint(dp))
+	}
+	return &rpcpb.FetchResult{Datapoints: datapoints}
+}
+
+func fromProtoFetchResult(result *rpcpb.FetchResult) *rpc.FetchResult_ {
+	datapoints := make([]*rpc.Datapoint, 0, len(result.Datapoints))
+	for _, dp := range result.Datapoints {
+		datapoints = append(datapoints, fromProtoDatapoint(dp))
+	}
+	return &rpc.FetchResult_{Datapoints: datapoints}
+}
+
+func toProtoFetchBatchRawRequest(req *rpc.FetchBatchRawRequest) *rpcpb.FetchBatchRawRequest {
+	return &rpcpb.FetchBatchRawRequest{
+		RangeStart:    req.RangeStart,
+		RangeEnd:      req.RangeEnd,
+		NameSpace:     req.NameSpace,
+		Ids:           req.Ids,
+		RangeTimeType: rpcpb.TimeType(req.RangeTimeType),
+	}
+}
+
+func fromProtoFetchBatchRawRequest(req *rpcpb.FetchBatchRawRequest) *rpc.FetchBatchRawRequest {
+	return &rpc.FetchBatchRawRequest{
+		RangeStart:    req.RangeStart,
+		RangeEnd:      req.RangeEnd,
+		NameSpace:     req.NameSpace,
+		Ids:           req.Ids,
+		RangeTimeType: rpc.TimeType(req.RangeTimeType),
+	}
+}
+
+func toProtoFetchBatchRawResult(result *rpc.FetchBatchRawResult_) *rpcpb.FetchBatchRawResult {
+	elements := make([]*rpcpb.FetchRawResult, 0, len(result.Elements))
+	for _, elem := range result.Elements {
+		elements = append(elements, &rpcpb.FetchRawResult{
+			Segments: toProtoSegmentsList(elem.Segments),
+			Err:      toProtoError(elem.Err),
+		})
+	}
+	return &rpcpb.FetchBatchRawResult{Elements: elements}
+}
+
+func fromProtoFetchBatchRawResult(result *rpcpb.FetchBatchRawResult) *rpc.FetchBatchRawResult_ {
+	elements := make([]*rpc.FetchRawResult_, 0, len(result.Elements))
+	for _, elem := range result.Elements {
+		segments := fromProtoSegmentsList(elem.Segments)
+		if segments == nil {
+			// Segments are required by the thrift struct.
+			segments = []*rpc.Segments{}
+		}
+		elements = append(elements, &rpc.FetchRawResult_{
+			Segments: segments,
+			Err:      fromProtoError(elem.Err),
+		})
+	}
+	return &rpc.FetchBatchRawResult_{Elements: elements}
+}
+
+func toProtoFetchTaggedRequest(req *rpc.FetchTaggedRequest) *rpcpb.FetchTaggedRequest {
+	result := &rpcpb.FetchTaggedRequest{
+		NameSpace:     req.NameSpace,
+		Query:         req.Query,
+		RangeStart:    req.RangeStart,
+		RangeEnd:      req.RangeEnd,
+		FetchData:     req.FetchData,
+		RangeTimeType: rpcpb.TimeType(req.RangeTimeType),
+	}
+	if req.Limit != nil {
+		result.LimitOptional = &rpcpb.FetchTaggedRequest_Limit{Limit: *req.Limit}
+	}
+	return result
+}
+
+func fromProtoFetchTaggedRequest(req *rpcpb.FetchTaggedRequest) *rpc.FetchTaggedRequest {
+	result := &rpc.FetchTaggedRequest{
+		NameSpace:     req.NameSpace,
+		Query:         req.Query,
+		RangeStart:    req.RangeStart,
+		RangeEnd:      req.RangeEnd,
+		FetchData:     req.FetchData,
+		RangeTimeType: rpc.TimeType(req.RangeTimeType),
+	}
+	if v, ok := req.LimitOptional.(*rpcpb.FetchTaggedRequest_Limit); ok {
+		limit := v.Limit
+		result.Limit = &limit
+	}
+	return result
+}
+
+func toProtoFetchTaggedResult(result *rpc.FetchTaggedResult_) *rpcpb.FetchTaggedResult {
+	elements := make([]*rpcpb.FetchTaggedIDResult, 0, len(result.Elements))
+	for _, elem := range result.Elements {
+		elements = append(elements, &rpcpb.FetchTaggedIDResult{
+			Id:          elem.ID,
+			NameSpace:   elem.NameSpace,
+			EncodedTags: elem.EncodedTags,
+			Segments:    toProtoSegmentsList(elem.Segments),
+			Err:         toProtoError(elem.Err),
+		})
+	}
+	return &rpcpb.FetchTaggedResult{
+		Elements:   elements,
+		Exhaustive: result.Exhaustive,
+	}
+}
+
+func fromProtoFetchTaggedResult(result *rpcpb.FetchTaggedResult) *rpc.FetchTaggedResult_ {
+	elements := make([]*rpc.FetchTaggedIDResult_, 0, len(result.Elements))
+	for _, elem := range result.Elements {
+		elements = append(elements, &rpc.FetchTaggedIDResult_{
+			ID:          elem.Id,
+			NameSpace:   elem.NameSpace,
+			EncodedTags: elem.EncodedTags,
+			Segments:    fromProtoSegmentsList(elem.Segments),
+			Err:         fromProtoError(elem.Err),
+		})
+	}
+	return &rpc.FetchTaggedResult_{
+		Elements:   elements,
+		Exhaustive: result.Exhaustive,
+	}
+}
+
+func toProtoFetchBlocksRawRequest(req *rpc.FetchBlocksRawRequest) *rpcpb.FetchBlocksRawRequest {
+	elements := make([]*rpcpb.FetchBlocksRawRequestElement, 0, len(req.Elements))
+	for _, elem := range req.Elements {
+		elements = append(elements, &rpcpb.FetchBlocksRawRequestElement{
+			Id:     elem.ID,
+			Starts: elem.Starts,
+		})
+	}
+	return &rpcpb.FetchBlocksRawRequest{
+		NameSpace: req.NameSpace,
+		Shard:     req.Shard,
+		Elements:  elements,
+	}
+}
+
+func fromProtoFetchBlocksRawRequest(req *rpcpb.FetchBlocksRawRequest) *rpc.FetchBlocksRawRequest {
+	elements := make([]*rpc.FetchBlocksRawRequestElement, 0, len(req.Elements))
+	for _, elem := range req.Elements {
+		elements = append(elements, &rpc.FetchBlocksRawRequestElement{
+			ID:     elem.Id,
+			Starts: elem.Starts,
+		})
+	}
+	return &rpc.FetchBlocksRawRequest{
+		NameSpace: req.NameSpace,
+		Shard:     req.Shard,
+		Elements:  elements,
+	}
+}
+
+func toProtoFetchBlocksRawResult(result *rpc.FetchBlocksRawResult_) *rpcpb.FetchBlocksRawResult {
+	elements := make([]*rpcpb.Blocks, 0, len(result.Elements))
+	for _, elem := range result.Elements {
+		blocks := make([]*rpcpb.Block, 0, len(elem.Blocks))
+		for _, block := range elem.Blocks {
+			protoBlock := &rpcpb.Block{
+				Start:    block.Start,
+				Segments: toProtoSegments(block.Segments),
+				Err:      toProtoError(block.Err),
+			}
+			if block.Checksum != nil {
+				protoBlock.ChecksumOptional = &rpcpb.Block_Checksum{Checksum: *block.Checksum}
+			}
+			blocks = append(blocks, protoBlock)
+		}
+		elements = append(elements, &rpcpb.Blocks{Id: elem.ID, Blocks: blocks})
+	}
+	return &rpcpb.FetchBlocksRawResult{Elements: elements}
+}
+
+func fromProtoFetchBlocksRawResult(result *rpcpb.FetchBlocksRawResult) *rpc.FetchBlocksRawResult_ {
+	elements := make([]*rpc.Blocks, 0, len(result.Elements))
+	for _, elem := range result.Elements {
+		blocks := make([]*rpc.Block, 0, len(elem.Blocks))
+		for _, block := range elem.Blocks {
+			thriftBlock := &rpc.Block{
+				Start:    block.Start,
+				Segments: fromProtoSegments(block.Segments),
+				Err:      fromProtoError(block.Err),
+			}
+			if v, ok := block.ChecksumOptional.(*rpcpb.Block_Checksum); ok {
+				checksum := v.Checksum
+				thriftBlock.Checksum = &checksum
+			}
+			blocks = append(blocks, thriftBlock)
+		}
+		elements = append(elements, &rpc.Blocks{ID: elem.Id, Blocks: blocks})
+	}
+	return &rpc.FetchBlocksRawResult_{Elements: elements}
+}
+
+func toProtoDatapoint(dp *rpc.Datapoint) *rpcpb.Datapoint {
+	if dp == nil {
+		return nil
+	}
+	return &rpcpb.Datapoint{
+		Timestamp:         dp.Timestamp,
+		Value:             dp.Value,
+		Annotation:        dp.Annotation,
+		TimestampTimeType: rpcpb.TimeType(dp.TimestampTimeType),
+	}
+}
+
+func fromProtoDatapoint(dp *rpcpb.Datapoint) *rpc.Datapoint {
+	if dp == nil {
+		return nil
+	}
+	return &rpc.Datapoint{
+		Timestamp:         dp.Timestamp,
+		Value:             dp.Value,
+		Annotation:        dp.Annotation,
+		TimestampTimeType: rpc.TimeType(dp.TimestampTimeType),
+	}
+}
+
+func toProtoSegmentsList(segments []*rpc.Segments) []*rpcpb.Segments {
+	if segments == nil {
+		return nil
+	}
+	result := make([]*rpcpb.Segments, 0, len(segments))
+	for _, s := range segments {
+		result = append(result, toProtoSegments(s))
+	}
+	return result
+}
+
+func fromProtoSegmentsList(segments []*rpcpb.Segments) []*rpc.Segments {
+	if segments == nil {
+		return nil
+	}
+	result := make([]*rpc.Segments, 0, len(segments))
+	for _, s := range segments {
+		result = append(result, fromProtoSegments(s))
+	}
+	return result
+}
+
+func toProtoSegments(segments *rpc.Segments) *rpcpb.Segments {
+	if segments == nil {
+		return nil
+	}
+	result := &rpcpb.Segments{Merged: toProtoSegment(segments.Merged)}
+	if segments.Unmerged != nil {
+		result.Unmerged = make([]*rpcpb.Segment, 0, len(segments.Unmerged))
+		for _, s := range segments.Unmerged {
+			result.Unmerged = append(result.Unmerged, toProtoSegment(s))
+		}
+	}
+	return result
+}
+
+func fromProtoSegments(segments *rpcpb.Segments) *rpc.Segments {
+	if segments == nil {
+		return nil
+	}
+	result := &rpc.Segments{Merged: fromProtoSegment(segments.Merged)}
+	if segments.Unmerged != nil {
+		result.Unmerged = make([]*rpc.Segment, 0, len(segments.Unmerged))
+		for _, s := range segments.Unmerged {
+			result.Unmerged = append(result.Unmerged, fromProtoSegment(s))
+		}
+	}
+	return result
+}
+
+func toProtoSegment(segment *rpc.Segment) *rpcpb.Segment {
+	if segment == nil {
+		return nil
+	}
+	result := &rpcpb.Segment{Head: segment.Head, Tail: segment.Tail}
+	if segment.StartTime != nil {
+		result.StartTimeOptional = &rpcpb.Segment_StartTime{StartTime: *segment.StartTime}
+	}
+	if segment.BlockSize != nil {
+		result.BlockSizeOptional = &rpcpb.Segment_BlockSize{BlockSize: *segment.BlockSize}
+	}
+	return result
+}
+
+func fromProtoSegment(segment *rpcpb.Segment) *rpc.Segment {
+	if segment == nil {
+		return nil
+	}
+	result := &rpc.Segment{Head: segment.Head, Tail: segment.Tail}
+	if v, ok := segment.StartTimeOptional.(*rpcpb.Segment_StartTime); ok {
+		startTime := v.StartTime
+		result.StartTime = &startTime
+	}
+	if v, ok := segment.BlockSizeOptional.(*rpcpb.Segment_BlockSize); ok {
+		blockSize := v.BlockSize
+		result.BlockSize = &blockSize
+	}
+	return result
+}
+
+func toProtoError(err *rpc.Error) *rpcpb.Error {
+	if err == nil {
+		return nil
+	}
+	return &rpcpb.Error{
+		Type:    rpcpb.ErrorType(err.Type),
+		Message: err.Message,
+	}
+}
+
+func fromProtoError(err *rpcpb.Error) *rpc.Error {
+	if err == nil {
+		return nil
+	}
+	return &rpc.Error{
+		Type:    rpc.ErrorType(err.Type),
+		Message: err.Message,
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package grpcthrift
+
+import (
+	"testing"
+
+	"github.com/m3db/m3/src/dbnode/generated/proto/rpcpb"
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/require"
+)
+
+// roundTrip encodes and decodes a protobuf message so that conversions are
+// tested against what is received over the wire.
+func roundTrip(t *testing.T, msg proto.Message, result proto.Message) {
+	data, err := proto.Marshal(msg)
+	require.NoError(t, err)
+	require.NoError(t, proto.Unmarshal(data, result))
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func TestConvertWriteRequests(t *testing.T) {
+	dp := &rpc.Datapoint{
+		Timestamp:         1,
+		Value:             42.5,
+		Annotation:        []byte("annotation"),
+		TimestampTimeType: rpc.TimeType_UNIX_NANOSECONDS,
+	}
+
+	write := &rpc.WriteRequest{NameSpace: "testNs", ID: "foo", Datapoint: dp}
+	var writeProto rpcpb.WriteRequest
+	roundTrip(t, toProtoWriteRequest(write), &writeProto)
+	require.Equal(t, write, fromProtoWriteRequest(&writeProto))
+
+	writeTagged := &rpc.WriteTaggedRequest{
+		NameSpace: "testNs",
+		ID:        "foo",
+		Tags: []*rpc.Tag{
+			{Name: "city", Value: "nyc"},
+			{Name: "host", Value: "a"},
+		},
+		Datapoint: dp,
+	}
+	var writeTaggedProto rpcpb.WriteTaggedRequest
+	roundTrip(t, toProtoWriteTaggedRequest(writeTagged), &writeTaggedProto)
+	require.Equal(t, writeTagged, fromProtoWriteTaggedRequest(&writeTaggedProto))
+}
+
+func TestConvertWriteBatchRawRequests(t *testing.T) {
+	dp := &rpc.Datapoint{Timestamp: 1, Value: 42.5, Annotation: []byte("annotation")}
+
+	writeBatch := &rpc.WriteBatchRawRequest{
+		NameSpace: []byte("testNs"),
+		Elements: []*rpc.WriteBatchRawRequestElement{
+			{ID: []byte("foo"), Datapoint: dp},
+			{ID: []byte("bar"), Datapoint: &rpc.Datapoint{Timestamp: 2, Value: 1}},
+		},
+	}
+	var writeBatchProto rpcpb.WriteBatchRawRequest
+	roundTrip(t, toProtoWriteBatchRawRequest(writeBatch), &writeBatchProto)
+	require.Equal(t, writeBatch, fromProtoWriteBatchRawRequest(&writeBatchProto))
+
+	writeTaggedBatch := &rpc.WriteTaggedBatchRawRequest{
+		NameSpace: []byte("testNs"),
+		Elements: []*rpc.WriteTaggedBatchRawRequestElement{
+			{ID: []byte("foo"), EncodedTags: []byte("tags"), Datapoint: dp},
+		},
+	}
+	var writeTaggedBatchProto rpcpb.WriteTaggedBatchRawRequest
+	roundTrip(t, toProtoWriteTaggedBatchRawRequest(writeTaggedBatch), &writeTaggedBatchProto)
+	require.Equal(t, writeTaggedBatch, fromProtoWriteTaggedBatchRawRequest(&writeTaggedBatchProto))
+
+	errs := &rpc.WriteBatchRawErrors{Errors: []*rpc.WriteBatchRawError{
+		{Index: 1, Err: &rpc.Error{Type: rpc.ErrorType_BAD_REQUEST, Message: "bad"}},
+		{Index: 3, Err: &rpc.Error{Type: rpc.ErrorType_INTERNAL_ERROR, Message: "failed"}},
+	}}
+	var errsProto rpcpb.WriteBatchRawErrors
+	roundTrip(t, toProtoWriteBatchRawErrors(errs), &errsProto)
+	require.Equal(t, errs, fromProtoWriteBatchRawErrors(&errsProto))
+}
+
+func TestConvertFetch(t *testing.T) {
+	req := &rpc.FetchRequest{
+		RangeStart:     1,
+		RangeEnd:       2,
+		NameSpace:      "testNs",
+		ID:             "foo",
+		RangeType:      rpc.TimeType_UNIX_MILLISECONDS,
+		ResultTimeType: rpc.TimeType_UNIX_NANOSECONDS,
+	}
+	var reqProto rpcpb.FetchRequest
+	roundTrip(t, toProtoFetchRequest(req), &reqProto)
+	require.Equal(t, req, fromProtoFetchRequest(&reqProto))
+
+	result := &rpc.FetchResult_{Datapoints: []*rpc.Datapoint{
+		{Timestamp: 1, Value: 1},
+		{Timestamp: 2, Value: 2, Annotation: []byte("annotation")},
+	}}
+	var resultProto rpcpb.FetchResult
+	roundTrip(t, toProtoFetchResult(result), &resultProto)
+	require.Equal(t, result, fromProtoFetchResult(&resultProto))
+}
+
+func TestConvertFetchBatchRaw(t *testing.T) {
+	req := &rpc.FetchBatchRawRequest{
+		RangeStart:    1,
+		RangeEnd:      2,
+		NameSpace:     []byte("testNs"),
+		Ids:           [][]byte{[]byte("foo"), []byte("bar")},
+		RangeTimeType: rpc.TimeType_UNIX_MILLISECONDS,
+	}
+	var reqProto rpcpb.FetchBatchRawRequest
+	roundTrip(t, toProtoFetchBatchRawRequest(req), &reqProto)
+	require.Equal(t, req, fromProtoFetchBatchRawRequest(&reqProto))
+
+	result := &rpc.FetchBatchRawResult_{
+		Elements: []*rpc.FetchRawResult_{
+			{
+				Segments: []*rpc.Segments{{
+					Merged: &rpc.Segment{Head: []byte("head"), Tail: []byte("tail")},
+				}},
+			},
+			{
+				Segments: []*rpc.Segments{},
+				Err:      &rpc.Error{Type: rpc.ErrorType_INTERNAL_ERROR, Message: "failed"},
+			},
+		},
+	}
+	var resultProto rpcpb.FetchBatchRawResult
+	roundTrip(t, toProtoFetchBatchRawResult(result), &resultProto)
+	require.Equal(t, result, fromProtoFetchBatchRawResult(&resultProto))
+}
+
+func TestConvertFetchTagged(t *testing.T) {
+	for _, limit := range []*int64{nil, int64Ptr(0), int64Ptr(100)} {
+		req := &rpc.FetchTaggedRequest{
+			NameSpace:     []byte("testNs"),
+			Query:         []byte("query"),
+			RangeStart:    1,
+			RangeEnd:      2,
+			FetchData:     true,
+			Limit:         limit,
+			RangeTimeType: rpc.TimeType_UNIX_NANOSECONDS,
+		}
+		var reqProto rpcpb.FetchTaggedRequest
+		roundTrip(t, toProtoFetchTaggedRequest(req), &reqProto)
+		require.Equal(t, req, fromProtoFetchTaggedRequest(&reqProto))
+	}
+
+	result := &rpc.FetchTaggedResult_{
+		Elements: []*rpc.FetchTaggedIDResult_{
+			{
+				ID:          []byte("foo"),
+				NameSpace:   []byte("testNs"),
+				EncodedTags: []byte("tags"),
+				Segments: []*rpc.Segments{
+					{
+						Merged: &rpc.Segment{
+							Head:      []byte("head"),
+							Tail:      []byte("tail"),
+							StartTime: int64Ptr(0),
+							BlockSize: int64Ptr(7200),
+						},
+					},
+					{
+						Unmerged: []*rpc.Segment{
+							{Head: []byte("a"), Tail: []byte("b")},
+							{Head: []byte("c"), Tail: []byte("d"), StartTime: int64Ptr(10)},
+						},
+					},
+				},
+			},
+			{
+				ID:          []byte("bar"),
+				NameSpace:   []byte("testNs"),
+				EncodedTags: []byte("tags"),
+				Err: &rpc.Error{
+					Type:    rpc.ErrorType_RESOURCE_EXHAUSTED,
+					Message: "too many series",
+				},
+			},
+		},
+		Exhaustive: true,
+	}
+	var resultProto rpcpb.FetchTaggedResult
+	roundTrip(t, toProtoFetchTaggedResult(result), &resultProto)
+	require.Equal(t, result, fromProtoFetchTaggedResult(&resultProto))
+}
+
+func TestConvertFetchBlocksRaw(t *testing.T) {
+	req := &rpc.FetchBlocksRawRequest{
+		NameSpace: []byte("testNs"),
+		Shard:     3,
+		Elements: []*rpc.FetchBlocksRawRequestElement{
+			{ID: []byte("foo"), Starts: []int64{1, 2}},
+			{ID: []byte("bar"), Starts: []int64{3}},
+		},
+	}
+	var reqProto rpcpb.FetchBlocksRawRequest
+	roundTrip(t, toProtoFetchBlocksRawRequest(req), &reqProto)
+	require.Equal(t, req, fromProtoFetchBlocksRawRequest(&reqProto))
+
+	result := &rpc.FetchBlocksRawResult_{
+		Elements: []*rpc.Blocks{
+			{
+				ID: []byte("foo"),
+				Blocks: []*rpc.Block{
+					{
+						Start: 1,
+						Segments: &rpc.Segments{
+							Merged: &rpc.Segment{Head: []byte("head"), Tail: []byte("tail")},
+						},
+						Checksum: int64Ptr(0),
+					},
+					{
+						Start: 2,
+						Err:   &rpc.Error{Type: rpc.ErrorType_INTERNAL_ERROR, Message: "failed"},
+					},
+				},
+			},
+		},
+	}
+	var resultProto rpcpb.FetchBlocksRawResult
+	roundTrip(t, toProtoFetchBlocksRawResult(result), &resultProto)
+	require.Equal(t, result, fromProtoFetchBlocksRawResult(&resultProto))
+}
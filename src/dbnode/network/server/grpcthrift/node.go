@@ -0,0 +1,631 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package grpcthrift
+
+import (
+	"github.com/m3db/m3/src/dbnode/generated/proto/rpcpb"
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3x/context"
+
+	"github.com/uber/tchannel-go/thrift"
+	xnetcontext "golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// NodeServiceName is the name of the gRPC node service.
+	NodeServiceName = "m3db.rpc.Node"
+
+	contextKey = "m3dbcontext"
+
+	// errorTrailerKey and writeBatchRawErrorsTrailerKey are the trailer keys
+	// errors returned by the node service are sent with, binary trailers are
+	// required to have the "-bin" suffix.
+	errorTrailerKey               = "m3db-error-bin"
+	writeBatchRawErrorsTrailerKey = "m3db-write-batch-raw-errors-bin"
+)
+
+type nodeMethod struct {
+	name   string
+	newReq func() interface{}
+	call   func(s rpc.TChanNode, ctx thrift.Context, req interface{}) (interface{}, error)
+}
+
+// nodeMethods are the methods of the gRPC node service. The methods defined by
+// the protobuf node service in rpcpb take and return its protobuf messages,
+// which are converted to and from the thrift structs of the equivalent
+// TChannel node service method, the remaining methods take the same thrift
+// request and return the same thrift response as the TChannel method.
+var nodeMethods = []nodeMethod{
+	{
+		name:   "Backup",
+		newReq: func() interface{} { return &rpc.BackupRequest{} },
+		call: func(s rpc.TChanNode, ctx thrift.Context, req interface{}) (interface{}, error) {
+			return s.Backup(ctx, req.(*rpc.BackupRequest))
+		},
+	},
+	{
+		name:   "Bootstrapped",
+		newReq: func() interface{} { return &emptyMessage{} },
+		call: func(s rpc.TChanNode, ctx thrift.Context, req interface{}) (interface{}, error) {
+			return s.Bootstrapped(ctx)
+		},
+	},
+	{
+		name:   "Cardinality",
+		newReq: func() interface{} { return &rpc.CardinalityRequest{} },
+		call: func(s rpc.TChanNode, ctx thrift.Context, req interface{}) (interface{}, error) {
+			return s.Cardinality(ctx, req.(*rpc.CardinalityRequest))
+		},
+	},
+	{
+		name:   "Fetch",
+		newReq: func() interface{} { return &rpcpb.FetchRequest{} },
+		call: func(s rpc.TChanNode, ctx thrift.Context, req interface{}) (interface{}, error) {
+			result, err := s.Fetch(ctx, fromProtoFetchRequest(req.(*rpcpb.FetchRequest)))
+			if err != nil {
+				return nil, err
+			}
+			return toProtoFetchResult(result), nil
+		},
+	},
+	{
+		name:   "FetchBatchRaw",
+		newReq: func() interface{} { return &rpcpb.FetchBatchRawRequest{} },
+		call: func(s rpc.TChanNode, ctx thrift.Context, req interface{}) (interface{}, error) {
+			result, err := s.FetchBatchRaw(ctx, fromProtoFetchBatchRawRequest(req.(*rpcpb.FetchBatchRawRequest)))
+			if err != nil {
+				return nil, err
+			}
+			return toProtoFetchBatchRawResult(result), nil
+		},
+	},
+	{
+		name:   "FetchBlocksMetadataRawV2",
+		newReq: func() interface{} { return &rpc.FetchBlocksMetadataRawV2Request{} },
+		call: func(s rpc.TChanNode, ctx thrift.Context, req interface{}) (interface{}, error) {
+			return s.FetchBlocksMetadataRawV2(ctx, req.(*rpc.FetchBlocksMetadataRawV2Request))
+		},
+	},
+	{
+		name:   "FetchBlocksRaw",
+		newReq: func() interface{} { return &rpcpb.FetchBlocksRawRequest{} },
+		call: func(s rpc.TChanNode, ctx thrift.Context, req interface{}) (interface{}, error) {
+			result, err := s.FetchBlocksRaw(ctx, fromProtoFetchBlocksRawRequest(req.(*rpcpb.FetchBlocksRawRequest)))
+			if err != nil {
+				return nil, err
+			}
+			return toProtoFetchBlocksRawResult(result), nil
+		},
+	},
+	{
+		name:   "FetchTagged",
+		newReq: func() interface{} { return &rpcpb.FetchTaggedRequest{} },
+		call: func(s rpc.TChanNode, ctx thrift.Context, req interface{}) (interface{}, error) {
+			result, err := s.FetchTagged(ctx, fromProtoFetchTaggedRequest(req.(*rpcpb.FetchTaggedRequest)))
+			if err != nil {
+				return nil, err
+			}
+			return toProtoFetchTaggedResult(result), nil
+		},
+	},
+	{
+		name:   "FetchTaggedPage",
+		newReq: func() interface{} { return &rpc.FetchTaggedPageRequest{} },
+		call: func(s rpc.TChanNode, ctx thrift.Context, req interface{}) (interface{}, error) {
+			return s.FetchTaggedPage(ctx, req.(*rpc.FetchTaggedPageRequest))
+		},
+	},
+	{
+		name:   "GetPersistRateLimit",
+		newReq: func() interface{} { return &emptyMessage{} },
+		call: func(s rpc.TChanNode, ctx thrift.Context, req interface{}) (interface{}, error) {
+			return s.GetPersistRateLimit(ctx)
+		},
+	},
+	{
+		name:   "GetWriteNewSeriesAsync",
+		newReq: func() interface{} { return &emptyMessage{} },
+		call: func(s rpc.TChanNode, ctx thrift.Context, req interface{}) (interface{}, error) {
+			return s.GetWriteNewSeriesAsync(ctx)
+		},
+	},
+	{
+		name:   "GetWriteNewSeriesBackoffDuration",
+		newReq: func() interface{} { return &emptyMessage{} },
+		call: func(s rpc.TChanNode, ctx thrift.Context, req interface{}) (interface{}, error) {
+			return s.GetWriteNewSeriesBackoffDuration(ctx)
+		},
+	},
+	{
+		name:   "GetWriteNewSeriesLimitPerShardPerSecond",
+		newReq: func() interface{} { return &emptyMessage{} },
+		call: func(s rpc.TChanNode, ctx thrift.Context, req interface{}) (interface{}, error) {
+			return s.GetWriteNewSeriesLimitPerShardPerSecond(ctx)
+		},
+	},
+	{
+		name:   "Health",
+		newReq: func() interface{} { return &emptyMessage{} },
+		call: func(s rpc.TChanNode, ctx thrift.Context, req interface{}) (interface{}, error) {
+			return s.Health(ctx)
+		},
+	},
+	{
+		name:   "Query",
+		newReq: func() interface{} { return &rpc.QueryRequest{} },
+		call: func(s rpc.TChanNode, ctx thrift.Context, req interface{}) (interface{}, error) {
+			return s.Query(ctx, req.(*rpc.QueryRequest))
+		},
+	},
+	{
+		name:   "QueryCost",
+		newReq: func() interface{} { return &rpc.QueryCostRequest{} },
+		call: func(s rpc.TChanNode, ctx thrift.Context, req interface{}) (interface{}, error) {
+			return s.QueryCost(ctx, req.(*rpc.QueryCostRequest))
+		},
+	},
+	{
+		name:   "Repair",
+		newReq: func() interface{} { return &emptyMessage{} },
+		call: func(s rpc.TChanNode, ctx thrift.Context, req interface{}) (interface{}, error) {
+			return &emptyMessage{}, s.Repair(ctx)
+		},
+	},
+	{
+		name:   "SetPersistRateLimit",
+		newReq: func() interface{} { return &rpc.NodeSetPersistRateLimitRequest{} },
+		call: func(s rpc.TChanNode, ctx thrift.Context, req interface{}) (interface{}, error) {
+			return s.SetPersistRateLimit(ctx, req.(*rpc.NodeSetPersistRateLimitRequest))
+		},
+	},
+	{
+		name:   "SetWriteNewSeriesAsync",
+		newReq: func() interface{} { return &rpc.NodeSetWriteNewSeriesAsyncRequest{} },
+		call: func(s rpc.TChanNode, ctx thrift.Context, req interface{}) (interface{}, error) {
+			return s.SetWriteNewSeriesAsync(ctx, req.(*rpc.NodeSetWriteNewSeriesAsyncRequest))
+		},
+	},
+	{
+		name:   "SetWriteNewSeriesBackoffDuration",
+		newReq: func() interface{} { return &rpc.NodeSetWriteNewSeriesBackoffDurationRequest{} },
+		call: func(s rpc.TChanNode, ctx thrift.Context, req interface{}) (interface{}, error) {
+			return s.SetWriteNewSeriesBackoffDuration(ctx, req.(*rpc.NodeSetWriteNewSeriesBackoffDurationRequest))
+		},
+	},
+	{
+		name:   "SetWriteNewSeriesLimitPerShardPerSecond",
+		newReq: func() interface{} { return &rpc.NodeSetWriteNewSeriesLimitPerShardPerSecondRequest{} },
+		call: func(s rpc.TChanNode, ctx thrift.Context, req interface{}) (interface{}, error) {
+			return s.SetWriteNewSeriesLimitPerShardPerSecond(ctx, req.(*rpc.NodeSetWriteNewSeriesLimitPerShardPerSecondRequest))
+		},
+	},
+	{
+		name:   "Truncate",
+		newReq: func() interface{} { return &rpc.TruncateRequest{} },
+		call: func(s rpc.TChanNode, ctx thrift.Context, req interface{}) (interface{}, error) {
+			return s.Truncate(ctx, req.(*rpc.TruncateRequest))
+		},
+	},
+	{
+		name:   "Write",
+		newReq: func() interface{} { return &rpcpb.WriteRequest{} },
+		call: func(s rpc.TChanNode, ctx thrift.Context, req interface{}) (interface{}, error) {
+			return &rpcpb.WriteResult{}, s.Write(ctx, fromProtoWriteRequest(req.(*rpcpb.WriteRequest)))
+		},
+	},
+	{
+		name:   "WriteBatchRaw",
+		newReq: func() interface{} { return &rpcpb.WriteBatchRawRequest{} },
+		call: func(s rpc.TChanNode, ctx thrift.Context, req interface{}) (interface{}, error) {
+			return &rpcpb.WriteBatchRawResult{}, s.WriteBatchRaw(ctx, fromProtoWriteBatchRawRequest(req.(*rpcpb.WriteBatchRawRequest)))
+		},
+	},
+	{
+		name:   "WriteTagged",
+		newReq: func() interface{} { return &rpcpb.WriteTaggedRequest{} },
+		call: func(s rpc.TChanNode, ctx thrift.Context, req interface{}) (interface{}, error) {
+			return &rpcpb.WriteTaggedResult{}, s.WriteTagged(ctx, fromProtoWriteTaggedRequest(req.(*rpcpb.WriteTaggedRequest)))
+		},
+	},
+	{
+		name:   "WriteTaggedBatchRaw",
+		newReq: func() interface{} { return &rpcpb.WriteTaggedBatchRawRequest{} },
+		call: func(s rpc.TChanNode, ctx thrift.Context, req interface{}) (interface{}, error) {
+			return &rpcpb.WriteTaggedBatchRawResult{}, s.WriteTaggedBatchRaw(ctx, fromProtoWriteTaggedBatchRawRequest(req.(*rpcpb.WriteTaggedBatchRawRequest)))
+		},
+	},
+}
+
+func nodeMethodPath(name string) string {
+	return "/" + NodeServiceName + "/" + name
+}
+
+func (m nodeMethod) desc() grpc.MethodDesc {
+	return grpc.MethodDesc{
+		MethodName: m.name,
+		Handler: func(
+			srv interface{},
+			ctx xnetcontext.Context,
+			dec func(interface{}) error,
+			interceptor grpc.UnaryServerInterceptor,
+		) (interface{}, error) {
+			req := m.newReq()
+			if err := dec(req); err != nil {
+				return nil, err
+			}
+			s := srv.(*nodeServer)
+			if interceptor == nil {
+				return s.handle(ctx, m, req)
+			}
+			info := &grpc.UnaryServerInfo{
+				Server:     srv,
+				FullMethod: nodeMethodPath(m.name),
+			}
+			return interceptor(ctx, req, info, func(ctx xnetcontext.Context, req interface{}) (interface{}, error) {
+				return s.handle(ctx, m, req)
+			})
+		},
+	}
+}
+
+type nodeServer struct {
+	rpc.TChanNode
+
+	contextPool context.Pool
+}
+
+// RegisterNodeServer registers the node service with a gRPC server, the server
+// must use the codec returned by NewCodec. M3DB contexts are created and
+// closed per request, the same as for the TChannel node service.
+func RegisterNodeServer(
+	server *grpc.Server,
+	service rpc.TChanNode,
+	contextPool context.Pool,
+) {
+	methods := make([]grpc.MethodDesc, 0, len(nodeMethods))
+	for _, m := range nodeMethods {
+		methods = append(methods, m.desc())
+	}
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: NodeServiceName,
+		HandlerType: (*rpc.TChanNode)(nil),
+		Methods:     methods,
+		Streams:     []grpc.StreamDesc{},
+		Metadata:    "rpc.thrift",
+	}, &nodeServer{
+		TChanNode:   service,
+		contextPool: contextPool,
+	})
+}
+
+func (s *nodeServer) handle(
+	ctx xnetcontext.Context,
+	m nodeMethod,
+	req interface{},
+) (interface{}, error) {
+	m3dbCtx := s.contextPool.Get()
+	defer m3dbCtx.Close()
+
+	tctx := thrift.WithHeaders(xnetcontext.WithValue(ctx, contextKey, m3dbCtx), nil)
+	resp, err := m.call(s.TChanNode, tctx, req)
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	// NB: Responses can reference resources owned by the M3DB context, so
+	// they must be encoded before the context is closed.
+	encoded, err := thriftCodec.Marshal(resp)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &encodedMessage{bytes: encoded}, nil
+}
+
+// toStatusError returns a gRPC status error for an error returned by the node
+// service, errors declared by the thrift service are also sent encoded as their
+// protobuf messages in the trailer so that clients receive the same errors as
+// over TChannel.
+func toStatusError(ctx xnetcontext.Context, err error) error {
+	var (
+		key   string
+		value interface{}
+		code  = codes.Internal
+	)
+	switch v := err.(type) {
+	case *rpc.Error:
+		key, value = errorTrailerKey, toProtoError(v)
+		if v.Type == rpc.ErrorType_BAD_REQUEST {
+			code = codes.InvalidArgument
+		}
+	case *rpc.WriteBatchRawErrors:
+		key, value = writeBatchRawErrorsTrailerKey, toProtoWriteBatchRawErrors(v)
+	default:
+		return status.Error(code, err.Error())
+	}
+
+	if encoded, encodeErr := thriftCodec.Marshal(value); encodeErr == nil {
+		grpc.SetTrailer(ctx, metadata.Pairs(key, string(encoded)))
+	}
+	return status.Error(code, err.Error())
+}
+
+// fromStatusError returns the error sent in the trailer of a failed request,
+// or the gRPC status error if the trailer does not include one.
+func fromStatusError(trailer metadata.MD, err error) error {
+	if values := trailer[errorTrailerKey]; len(values) > 0 {
+		rpcErr := &rpcpb.Error{}
+		if thriftCodec.Unmarshal([]byte(values[0]), rpcErr) == nil {
+			return fromProtoError(rpcErr)
+		}
+	}
+	if values := trailer[writeBatchRawErrorsTrailerKey]; len(values) > 0 {
+		batchErrs := &rpcpb.WriteBatchRawErrors{}
+		if thriftCodec.Unmarshal([]byte(values[0]), batchErrs) == nil {
+			return fromProtoWriteBatchRawErrors(batchErrs)
+		}
+	}
+	return err
+}
+
+type nodeClient struct {
+	conn  *grpc.ClientConn
+	proto rpcpb.NodeClient
+}
+
+// NewNodeClient returns a node client that sends requests to the gRPC node
+// service, the connection must use the codec returned by NewCodec.
+func NewNodeClient(conn *grpc.ClientConn) rpc.TChanNode {
+	return &nodeClient{
+		conn:  conn,
+		proto: rpcpb.NewNodeClient(conn),
+	}
+}
+
+func (c *nodeClient) invoke(
+	ctx thrift.Context,
+	name string,
+	req interface{},
+	resp interface{},
+) error {
+	var trailer metadata.MD
+	err := grpc.Invoke(ctx, nodeMethodPath(name), req, resp, c.conn,
+		grpc.Trailer(&trailer))
+	if err != nil {
+		return fromStatusError(trailer, err)
+	}
+	return nil
+}
+
+func (c *nodeClient) Backup(ctx thrift.Context, req *rpc.BackupRequest) (*rpc.BackupResult_, error) {
+	resp := &rpc.BackupResult_{}
+	if err := c.invoke(ctx, "Backup", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *nodeClient) Bootstrapped(ctx thrift.Context) (*rpc.NodeBootstrappedResult_, error) {
+	resp := &rpc.NodeBootstrappedResult_{}
+	if err := c.invoke(ctx, "Bootstrapped", &emptyMessage{}, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *nodeClient) Cardinality(ctx thrift.Context, req *rpc.CardinalityRequest) (*rpc.CardinalityResult_, error) {
+	resp := &rpc.CardinalityResult_{}
+	if err := c.invoke(ctx, "Cardinality", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *nodeClient) Fetch(ctx thrift.Context, req *rpc.FetchRequest) (*rpc.FetchResult_, error) {
+	var trailer metadata.MD
+	resp, err := c.proto.Fetch(ctx, toProtoFetchRequest(req), grpc.Trailer(&trailer))
+	if err != nil {
+		return nil, fromStatusError(trailer, err)
+	}
+	return fromProtoFetchResult(resp), nil
+}
+
+func (c *nodeClient) FetchBatchRaw(ctx thrift.Context, req *rpc.FetchBatchRawRequest) (*rpc.FetchBatchRawResult_, error) {
+	var trailer metadata.MD
+	resp, err := c.proto.FetchBatchRaw(ctx, toProtoFetchBatchRawRequest(req), grpc.Trailer(&trailer))
+	if err != nil {
+		return nil, fromStatusError(trailer, err)
+	}
+	return fromProtoFetchBatchRawResult(resp), nil
+}
+
+func (c *nodeClient) FetchBlocksMetadataRawV2(ctx thrift.Context, req *rpc.FetchBlocksMetadataRawV2Request) (*rpc.FetchBlocksMetadataRawV2Result_, error) {
+	resp := &rpc.FetchBlocksMetadataRawV2Result_{}
+	if err := c.invoke(ctx, "FetchBlocksMetadataRawV2", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *nodeClient) FetchBlocksRaw(ctx thrift.Context, req *rpc.FetchBlocksRawRequest) (*rpc.FetchBlocksRawResult_, error) {
+	var trailer metadata.MD
+	resp, err := c.proto.FetchBlocksRaw(ctx, toProtoFetchBlocksRawRequest(req), grpc.Trailer(&trailer))
+	if err != nil {
+		return nil, fromStatusError(trailer, err)
+	}
+	return fromProtoFetchBlocksRawResult(resp), nil
+}
+
+func (c *nodeClient) FetchTagged(ctx thrift.Context, req *rpc.FetchTaggedRequest) (*rpc.FetchTaggedResult_, error) {
+	var trailer metadata.MD
+	resp, err := c.proto.FetchTagged(ctx, toProtoFetchTaggedRequest(req), grpc.Trailer(&trailer))
+	if err != nil {
+		return nil, fromStatusError(trailer, err)
+	}
+	return fromProtoFetchTaggedResult(resp), nil
+}
+
+func (c *nodeClient) FetchTaggedPage(ctx thrift.Context, req *rpc.FetchTaggedPageRequest) (*rpc.FetchTaggedPageResult_, error) {
+	resp := &rpc.FetchTaggedPageResult_{}
+	if err := c.invoke(ctx, "FetchTaggedPage", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *nodeClient) GetPersistRateLimit(ctx thrift.Context) (*rpc.NodePersistRateLimitResult_, error) {
+	resp := &rpc.NodePersistRateLimitResult_{}
+	if err := c.invoke(ctx, "GetPersistRateLimit", &emptyMessage{}, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *nodeClient) GetWriteNewSeriesAsync(ctx thrift.Context) (*rpc.NodeWriteNewSeriesAsyncResult_, error) {
+	resp := &rpc.NodeWriteNewSeriesAsyncResult_{}
+	if err := c.invoke(ctx, "GetWriteNewSeriesAsync", &emptyMessage{}, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *nodeClient) GetWriteNewSeriesBackoffDuration(ctx thrift.Context) (*rpc.NodeWriteNewSeriesBackoffDurationResult_, error) {
+	resp := &rpc.NodeWriteNewSeriesBackoffDurationResult_{}
+	if err := c.invoke(ctx, "GetWriteNewSeriesBackoffDuration", &emptyMessage{}, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *nodeClient) GetWriteNewSeriesLimitPerShardPerSecond(ctx thrift.Context) (*rpc.NodeWriteNewSeriesLimitPerShardPerSecondResult_, error) {
+	resp := &rpc.NodeWriteNewSeriesLimitPerShardPerSecondResult_{}
+	if err := c.invoke(ctx, "GetWriteNewSeriesLimitPerShardPerSecond", &emptyMessage{}, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *nodeClient) Health(ctx thrift.Context) (*rpc.NodeHealthResult_, error) {
+	resp := &rpc.NodeHealthResult_{}
+	if err := c.invoke(ctx, "Health", &emptyMessage{}, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *nodeClient) Query(ctx thrift.Context, req *rpc.QueryRequest) (*rpc.QueryResult_, error) {
+	resp := &rpc.QueryResult_{}
+	if err := c.invoke(ctx, "Query", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *nodeClient) QueryCost(ctx thrift.Context, req *rpc.QueryCostRequest) (*rpc.QueryCostResult_, error) {
+	resp := &rpc.QueryCostResult_{}
+	if err := c.invoke(ctx, "QueryCost", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *nodeClient) Repair(ctx thrift.Context) error {
+	return c.invoke(ctx, "Repair", &emptyMessage{}, &emptyMessage{})
+}
+
+func (c *nodeClient) SetPersistRateLimit(ctx thrift.Context, req *rpc.NodeSetPersistRateLimitRequest) (*rpc.NodePersistRateLimitResult_, error) {
+	resp := &rpc.NodePersistRateLimitResult_{}
+	if err := c.invoke(ctx, "SetPersistRateLimit", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *nodeClient) SetWriteNewSeriesAsync(ctx thrift.Context, req *rpc.NodeSetWriteNewSeriesAsyncRequest) (*rpc.NodeWriteNewSeriesAsyncResult_, error) {
+	resp := &rpc.NodeWriteNewSeriesAsyncResult_{}
+	if err := c.invoke(ctx, "SetWriteNewSeriesAsync", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *nodeClient) SetWriteNewSeriesBackoffDuration(ctx thrift.Context, req *rpc.NodeSetWriteNewSeriesBackoffDurationRequest) (*rpc.NodeWriteNewSeriesBackoffDurationResult_, error) {
+	resp := &rpc.NodeWriteNewSeriesBackoffDurationResult_{}
+	if err := c.invoke(ctx, "SetWriteNewSeriesBackoffDuration", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *nodeClient) SetWriteNewSeriesLimitPerShardPerSecond(ctx thrift.Context, req *rpc.NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) (*rpc.NodeWriteNewSeriesLimitPerShardPerSecondResult_, error) {
+	resp := &rpc.NodeWriteNewSeriesLimitPerShardPerSecondResult_{}
+	if err := c.invoke(ctx, "SetWriteNewSeriesLimitPerShardPerSecond", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *nodeClient) Truncate(ctx thrift.Context, req *rpc.TruncateRequest) (*rpc.TruncateResult_, error) {
+	resp := &rpc.TruncateResult_{}
+	if err := c.invoke(ctx, "Truncate", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *nodeClient) Write(ctx thrift.Context, req *rpc.WriteRequest) error {
+	var trailer metadata.MD
+	_, err := c.proto.Write(ctx, toProtoWriteRequest(req), grpc.Trailer(&trailer))
+	if err != nil {
+		return fromStatusError(trailer, err)
+	}
+	return nil
+}
+
+func (c *nodeClient) WriteBatchRaw(ctx thrift.Context, req *rpc.WriteBatchRawRequest) error {
+	var trailer metadata.MD
+	_, err := c.proto.WriteBatchRaw(ctx, toProtoWriteBatchRawRequest(req), grpc.Trailer(&trailer))
+	if err != nil {
+		return fromStatusError(trailer, err)
+	}
+	return nil
+}
+
+func (c *nodeClient) WriteTagged(ctx thrift.Context, req *rpc.WriteTaggedRequest) error {
+	var trailer metadata.MD
+	_, err := c.proto.WriteTagged(ctx, toProtoWriteTaggedRequest(req), grpc.Trailer(&trailer))
+	if err != nil {
+		return fromStatusError(trailer, err)
+	}
+	return nil
+}
+
+func (c *nodeClient) WriteTaggedBatchRaw(ctx thrift.Context, req *rpc.WriteTaggedBatchRawRequest) error {
+	var trailer metadata.MD
+	_, err := c.proto.WriteTaggedBatchRaw(ctx, toProtoWriteTaggedBatchRawRequest(req), grpc.Trailer(&trailer))
+	if err != nil {
+		return fromStatusError(trailer, err)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package node
+
+import (
+	"math"
+	"net"
+
+	ns "github.com/m3db/m3/src/dbnode/network/server"
+	"github.com/m3db/m3/src/dbnode/network/server/grpcthrift"
+	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift"
+	ttnode "github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/node"
+	"github.com/m3db/m3/src/dbnode/storage"
+	"github.com/m3db/m3x/context"
+
+	"google.golang.org/grpc"
+)
+
+type server struct {
+	db          storage.Database
+	listener    net.Listener
+	contextPool context.Pool
+	ttopts      tchannelthrift.Options
+}
+
+// NewServer creates a node gRPC network service that serves the node service
+// on the listener, the listener can be shared with the TChannel node service
+// by multiplexing connections.
+func NewServer(
+	db storage.Database,
+	listener net.Listener,
+	contextPool context.Pool,
+	ttopts tchannelthrift.Options,
+) ns.NetworkService {
+	if ttopts == nil {
+		ttopts = tchannelthrift.NewOptions()
+	}
+	return &server{
+		db:          db,
+		listener:    listener,
+		contextPool: contextPool,
+		ttopts:      ttopts,
+	}
+}
+
+func (s *server) ListenAndServe() (ns.Close, error) {
+	server := grpc.NewServer(
+		grpc.CustomCodec(grpcthrift.NewCodec()),
+		grpc.MaxRecvMsgSize(math.MaxInt32),
+		grpc.MaxSendMsgSize(math.MaxInt32),
+	)
+	grpcthrift.RegisterNodeServer(server, ttnode.NewService(s.db, s.ttopts),
+		s.contextPool)
+
+	go func() {
+		server.Serve(s.listener)
+	}()
+
+	return server.Stop, nil
+}
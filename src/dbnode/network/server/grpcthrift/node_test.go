@@ -0,0 +1,186 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package grpcthrift
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift"
+	tterrors "github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/errors"
+	"github.com/m3db/m3x/context"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/uber/tchannel-go/thrift"
+	"google.golang.org/grpc"
+)
+
+func newTestNodeClient(
+	t *testing.T,
+	service rpc.TChanNode,
+) (rpc.TChanNode, func()) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer(grpc.CustomCodec(NewCodec()))
+	RegisterNodeServer(server, service, context.NewPool(context.NewOptions()))
+	go server.Serve(listener)
+
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithInsecure(),
+		grpc.WithCodec(NewCodec()))
+	require.NoError(t, err)
+
+	return NewNodeClient(conn), func() {
+		conn.Close()
+		server.Stop()
+	}
+}
+
+func TestNodeClientRoundTrip(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := rpc.NewMockTChanNode(ctrl)
+	client, closer := newTestNodeClient(t, service)
+	defer closer()
+
+	ctx, cancel := thrift.NewContext(time.Minute)
+	defer cancel()
+
+	service.EXPECT().Health(gomock.Any()).Return(&rpc.NodeHealthResult_{
+		Ok:           true,
+		Status:       "up",
+		Bootstrapped: true,
+	}, nil)
+	health, err := client.Health(ctx)
+	require.NoError(t, err)
+	require.Equal(t, &rpc.NodeHealthResult_{
+		Ok:           true,
+		Status:       "up",
+		Bootstrapped: true,
+	}, health)
+
+	req := &rpc.WriteRequest{
+		NameSpace: "testNs",
+		ID:        "foo",
+		Datapoint: &rpc.Datapoint{Timestamp: 1, Value: 42},
+	}
+	service.EXPECT().Write(gomock.Any(), req).Do(
+		func(ctx thrift.Context, _ *rpc.WriteRequest) {
+			// Requests are served with an M3DB context like over TChannel.
+			require.NotNil(t, tchannelthrift.Context(ctx))
+		}).Return(nil)
+	require.NoError(t, client.Write(ctx, req))
+
+	// Methods with a protobuf definition are converted to and from the
+	// thrift structs the service and clients use.
+	limit := int64(10)
+	fetchTaggedReq := &rpc.FetchTaggedRequest{
+		NameSpace:  []byte("testNs"),
+		Query:      []byte("query"),
+		RangeStart: 1,
+		RangeEnd:   2,
+		FetchData:  true,
+		Limit:      &limit,
+	}
+	fetchTaggedResult := &rpc.FetchTaggedResult_{
+		Elements: []*rpc.FetchTaggedIDResult_{{
+			ID:          []byte("foo"),
+			NameSpace:   []byte("testNs"),
+			EncodedTags: []byte("tags"),
+			Segments: []*rpc.Segments{{
+				Merged: &rpc.Segment{Head: []byte("head"), Tail: []byte("tail")},
+			}},
+		}},
+		Exhaustive: true,
+	}
+	service.EXPECT().FetchTagged(gomock.Any(), fetchTaggedReq).
+		Return(fetchTaggedResult, nil)
+	result, err := client.FetchTagged(ctx, fetchTaggedReq)
+	require.NoError(t, err)
+	require.Equal(t, fetchTaggedResult, result)
+
+	writeBatchReq := &rpc.WriteBatchRawRequest{
+		NameSpace: []byte("testNs"),
+		Elements: []*rpc.WriteBatchRawRequestElement{
+			{ID: []byte("foo"), Datapoint: &rpc.Datapoint{Timestamp: 1, Value: 42}},
+		},
+	}
+	service.EXPECT().WriteBatchRaw(gomock.Any(), writeBatchReq).Return(nil)
+	require.NoError(t, client.WriteBatchRaw(ctx, writeBatchReq))
+
+	fetchBatchReq := &rpc.FetchBatchRawRequest{
+		RangeStart: 1,
+		RangeEnd:   2,
+		NameSpace:  []byte("testNs"),
+		Ids:        [][]byte{[]byte("foo")},
+	}
+	fetchBatchResult := &rpc.FetchBatchRawResult_{
+		Elements: []*rpc.FetchRawResult_{{
+			Segments: []*rpc.Segments{{
+				Merged: &rpc.Segment{Head: []byte("head"), Tail: []byte("tail")},
+			}},
+		}},
+	}
+	service.EXPECT().FetchBatchRaw(gomock.Any(), fetchBatchReq).
+		Return(fetchBatchResult, nil)
+	batchResult, err := client.FetchBatchRaw(ctx, fetchBatchReq)
+	require.NoError(t, err)
+	require.Equal(t, fetchBatchResult, batchResult)
+}
+
+func TestNodeClientErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := rpc.NewMockTChanNode(ctrl)
+	client, closer := newTestNodeClient(t, service)
+	defer closer()
+
+	ctx, cancel := thrift.NewContext(time.Minute)
+	defer cancel()
+
+	badRequestErr := tterrors.NewBadRequestError(errors.New("bad request"))
+	service.EXPECT().FetchTagged(gomock.Any(), gomock.Any()).
+		Return(nil, badRequestErr)
+	_, err := client.FetchTagged(ctx, &rpc.FetchTaggedRequest{})
+	require.Equal(t, badRequestErr, err)
+
+	batchErrs := &rpc.WriteBatchRawErrors{Errors: []*rpc.WriteBatchRawError{
+		tterrors.NewWriteBatchRawError(1, errors.New("write failed")),
+	}}
+	service.EXPECT().WriteBatchRaw(gomock.Any(), gomock.Any()).
+		Return(batchErrs)
+	err = client.WriteBatchRaw(ctx, &rpc.WriteBatchRawRequest{})
+	require.Equal(t, batchErrs, err)
+
+	// Errors not declared by the thrift service are returned as is.
+	service.EXPECT().Truncate(gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("unexpected"))
+	_, err = client.Truncate(ctx, &rpc.TruncateRequest{})
+	require.Error(t, err)
+	_, ok := err.(*rpc.Error)
+	require.False(t, ok)
+}
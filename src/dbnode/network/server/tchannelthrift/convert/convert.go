@@ -28,6 +28,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/digest"
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
 	tterrors "github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/errors"
+	m3dberrors "github.com/m3db/m3/src/dbnode/storage/errors"
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/x/xio"
 	"github.com/m3db/m3/src/dbnode/x/xpool"
@@ -43,12 +44,20 @@ var (
 	errUnknownTimeType  = errors.New("unknown time type")
 	errUnknownUnit      = errors.New("unknown unit")
 	errNilTaggedRequest = errors.New("nil write tagged request")
+	errInvalidPageToken = errors.New("invalid fetch tagged page token")
+
+	errQueryCostTermsMismatch = errors.New("query cost result does not match query terms")
 
 	timeZero time.Time
 )
 
 const (
 	fetchTaggedTimeType = rpc.TimeType_UNIX_NANOSECONDS
+	cardinalityTimeType = rpc.TimeType_UNIX_NANOSECONDS
+	queryCostTimeType   = rpc.TimeType_UNIX_NANOSECONDS
+
+	fetchTaggedPageTokenVersion = 2
+	fetchTaggedPageTokenHeader  = 1
 )
 
 // ToTime converts a value to a time
@@ -190,6 +199,9 @@ func ToRPCError(err error) *rpc.Error {
 	if xerrors.IsInvalidParams(err) {
 		return tterrors.NewBadRequestError(err)
 	}
+	if m3dberrors.IsResourceExhaustedError(err) {
+		return tterrors.NewResourceExhaustedError(err)
+	}
 	return tterrors.NewInternalError(err)
 }
 
@@ -277,6 +289,272 @@ func ToRPCFetchTaggedRequest(
 	return request, nil
 }
 
+// ToRPCFetchTaggedPageToken converts the ID of the last series returned in a
+// fetch tagged page, pages return series ordered by ID, into the page token to
+// fetch the next page with.
+func ToRPCFetchTaggedPageToken(id []byte) []byte {
+	token := make([]byte, fetchTaggedPageTokenHeader+len(id))
+	token[0] = fetchTaggedPageTokenVersion
+	copy(token[fetchTaggedPageTokenHeader:], id)
+	return token
+}
+
+// FromRPCFetchTaggedPageToken converts a fetch tagged page token into the ID
+// of the last series returned in the previous page.
+func FromRPCFetchTaggedPageToken(token []byte) ([]byte, error) {
+	if len(token) < fetchTaggedPageTokenHeader || token[0] != fetchTaggedPageTokenVersion {
+		return nil, errInvalidPageToken
+	}
+	return token[fetchTaggedPageTokenHeader:], nil
+}
+
+// FromRPCCardinalityRequest converts the rpc request type for CardinalityRequest
+// into the Go `index/` types, applying defaults for unset options.
+func FromRPCCardinalityRequest(
+	req *rpc.CardinalityRequest,
+) (ident.ID, index.CardinalityOptions, error) {
+	start, err := ToTime(req.RangeStart, req.RangeTimeType)
+	if err != nil {
+		return nil, index.CardinalityOptions{}, err
+	}
+
+	end, err := ToTime(req.RangeEnd, req.RangeTimeType)
+	if err != nil {
+		return nil, index.CardinalityOptions{}, err
+	}
+
+	opts := index.CardinalityOptions{
+		StartInclusive: start,
+		EndExclusive:   end,
+		NameTag:        index.DefaultCardinalityNameTag,
+		Limit:          index.DefaultCardinalityLimit,
+		MaxTerms:       index.DefaultCardinalityMaxTerms,
+	}
+	if len(req.NameTag) > 0 {
+		opts.NameTag = req.NameTag
+	}
+	if l := req.Limit; l != nil {
+		opts.Limit = int(*l)
+	}
+	if m := req.MaxTerms; m != nil {
+		opts.MaxTerms = int(*m)
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, index.CardinalityOptions{}, err
+	}
+
+	return ident.StringID(string(req.NameSpace)), opts, nil
+}
+
+// ToRPCCardinalityRequest converts the Go `client/` types into rpc request type for CardinalityRequest.
+func ToRPCCardinalityRequest(
+	ns ident.ID,
+	opts index.CardinalityOptions,
+) (rpc.CardinalityRequest, error) {
+	rangeStart, err := ToValue(opts.StartInclusive, cardinalityTimeType)
+	if err != nil {
+		return rpc.CardinalityRequest{}, err
+	}
+
+	rangeEnd, err := ToValue(opts.EndExclusive, cardinalityTimeType)
+	if err != nil {
+		return rpc.CardinalityRequest{}, err
+	}
+
+	request := rpc.CardinalityRequest{
+		NameSpace:     ns.Bytes(),
+		RangeStart:    rangeStart,
+		RangeEnd:      rangeEnd,
+		RangeTimeType: cardinalityTimeType,
+		NameTag:       opts.NameTag,
+	}
+	if opts.Limit > 0 {
+		l := int64(opts.Limit)
+		request.Limit = &l
+	}
+	if opts.MaxTerms > 0 {
+		m := int64(opts.MaxTerms)
+		request.MaxTerms = &m
+	}
+
+	return request, nil
+}
+
+// ToRPCCardinalityResult converts the Go `index/` cardinality results into the
+// rpc result type, block starts are returned in the given time type.
+func ToRPCCardinalityResult(
+	results []index.CardinalityResult,
+	timeType rpc.TimeType,
+) (*rpc.CardinalityResult_, error) {
+	res := rpc.NewCardinalityResult_()
+	res.Blocks = make([]*rpc.CardinalityBlock, 0, len(results))
+	for _, r := range results {
+		blockStart, err := ToValue(r.BlockStart, timeType)
+		if err != nil {
+			return nil, err
+		}
+		res.Blocks = append(res.Blocks, &rpc.CardinalityBlock{
+			BlockStart:  blockStart,
+			NumSeries:   r.NumSeries,
+			MetricNames: toRPCCardinalityEntries(r.MetricNames),
+			TagNames:    toRPCCardinalityEntries(r.TagNames),
+			TagValues:   toRPCCardinalityEntries(r.TagValues),
+			Exhaustive:  r.Exhaustive,
+		})
+	}
+	return res, nil
+}
+
+func toRPCCardinalityEntries(entries []index.CardinalityEntry) []*rpc.CardinalityEntry {
+	result := make([]*rpc.CardinalityEntry, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, &rpc.CardinalityEntry{Name: e.Name, Count: e.Count})
+	}
+	return result
+}
+
+// FromRPCCardinalityResult converts the rpc result type for CardinalityResult into
+// the Go `index/` types, block starts are expected in the request time type.
+func FromRPCCardinalityResult(
+	res *rpc.CardinalityResult_,
+) ([]index.CardinalityResult, error) {
+	results := make([]index.CardinalityResult, 0, len(res.Blocks))
+	for _, b := range res.Blocks {
+		blockStart, err := ToTime(b.BlockStart, cardinalityTimeType)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, index.CardinalityResult{
+			BlockStart:  blockStart,
+			NumSeries:   b.NumSeries,
+			MetricNames: fromRPCCardinalityEntries(b.MetricNames),
+			TagNames:    fromRPCCardinalityEntries(b.TagNames),
+			TagValues:   fromRPCCardinalityEntries(b.TagValues),
+			Exhaustive:  b.Exhaustive,
+		})
+	}
+	return results, nil
+}
+
+func fromRPCCardinalityEntries(entries []*rpc.CardinalityEntry) []index.CardinalityEntry {
+	result := make([]index.CardinalityEntry, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, index.CardinalityEntry{Name: e.Name, Count: e.Count})
+	}
+	return result
+}
+
+// FromRPCQueryCostRequest converts the rpc request type for QueryCostRequest
+// into the Go `index/` types.
+func FromRPCQueryCostRequest(
+	req *rpc.QueryCostRequest,
+) (ident.ID, index.Query, index.QueryCostOptions, error) {
+	start, err := ToTime(req.RangeStart, req.RangeTimeType)
+	if err != nil {
+		return nil, index.Query{}, index.QueryCostOptions{}, err
+	}
+
+	end, err := ToTime(req.RangeEnd, req.RangeTimeType)
+	if err != nil {
+		return nil, index.Query{}, index.QueryCostOptions{}, err
+	}
+
+	q, err := idx.Unmarshal(req.Query)
+	if err != nil {
+		return nil, index.Query{}, index.QueryCostOptions{}, err
+	}
+
+	opts := index.QueryCostOptions{
+		StartInclusive: start,
+		EndExclusive:   end,
+	}
+	return ident.StringID(string(req.NameSpace)), index.Query{Query: q}, opts, nil
+}
+
+// ToRPCQueryCostRequest converts the Go `client/` types into rpc request type for QueryCostRequest.
+func ToRPCQueryCostRequest(
+	ns ident.ID,
+	q index.Query,
+	opts index.QueryCostOptions,
+) (rpc.QueryCostRequest, error) {
+	rangeStart, err := ToValue(opts.StartInclusive, queryCostTimeType)
+	if err != nil {
+		return rpc.QueryCostRequest{}, err
+	}
+
+	rangeEnd, err := ToValue(opts.EndExclusive, queryCostTimeType)
+	if err != nil {
+		return rpc.QueryCostRequest{}, err
+	}
+
+	query, err := idx.Marshal(q.Query)
+	if err != nil {
+		return rpc.QueryCostRequest{}, err
+	}
+
+	return rpc.QueryCostRequest{
+		NameSpace:     ns.Bytes(),
+		Query:         query,
+		RangeStart:    rangeStart,
+		RangeEnd:      rangeEnd,
+		RangeTimeType: queryCostTimeType,
+	}, nil
+}
+
+// ToRPCQueryCostResult converts the Go `index/` query cost results into the
+// rpc result type, block starts are returned in the given time type. Term
+// postings are returned in the order of the terms of the analysed query.
+func ToRPCQueryCostResult(
+	results []index.QueryCostResult,
+	timeType rpc.TimeType,
+) (*rpc.QueryCostResult_, error) {
+	res := rpc.NewQueryCostResult_()
+	res.Blocks = make([]*rpc.QueryCostBlock, 0, len(results))
+	for _, r := range results {
+		blockStart, err := ToValue(r.BlockStart, timeType)
+		if err != nil {
+			return nil, err
+		}
+		postings := make([]int64, 0, len(r.Terms))
+		for _, t := range r.Terms {
+			postings = append(postings, t.Postings)
+		}
+		res.Blocks = append(res.Blocks, &rpc.QueryCostBlock{
+			BlockStart:   blockStart,
+			TermPostings: postings,
+		})
+	}
+	return res, nil
+}
+
+// FromRPCQueryCostResult converts the rpc result type for QueryCostResult into
+// the Go `index/` types, pairing the term postings with the terms of the
+// analysed query. Block starts are expected in the request time type.
+func FromRPCQueryCostResult(
+	res *rpc.QueryCostResult_,
+	terms []index.QueryTerm,
+) ([]index.QueryCostResult, error) {
+	results := make([]index.QueryCostResult, 0, len(res.Blocks))
+	for _, b := range res.Blocks {
+		if len(b.TermPostings) != len(terms) {
+			return nil, errQueryCostTermsMismatch
+		}
+		blockStart, err := ToTime(b.BlockStart, queryCostTimeType)
+		if err != nil {
+			return nil, err
+		}
+		costs := make([]index.QueryTermCost, 0, len(terms))
+		for i, t := range terms {
+			costs = append(costs, index.QueryTermCost{Term: t, Postings: b.TermPostings[i]})
+		}
+		results = append(results, index.QueryCostResult{
+			BlockStart: blockStart,
+			Terms:      costs,
+		})
+	}
+	return results, nil
+}
+
 // ToTagsIter returns a tag iterator over the given request.
 func ToTagsIter(r *rpc.WriteTaggedRequest) (ident.TagIterator, error) {
 	if r == nil {
@@ -396,6 +674,24 @@ func parseQuery(query *rpc.Query) (*querypb.Query, error) {
 			},
 		}
 	}
+	if query.Field != nil {
+		if result.Query != nil {
+			return nil, xerrors.NewInvalidParamsError(fmt.Errorf("multiple query types specified"))
+		}
+		result.Query = &querypb.Query_Field{
+			Field: &querypb.FieldQuery{
+				Field: []byte(query.Field.Field),
+			},
+		}
+	}
+	if query.All != nil {
+		if result.Query != nil {
+			return nil, xerrors.NewInvalidParamsError(fmt.Errorf("multiple query types specified"))
+		}
+		result.Query = &querypb.Query_All{
+			All: &querypb.AllQuery{},
+		}
+	}
 	if query.Negation != nil {
 		if result.Query != nil {
 			return nil, xerrors.NewInvalidParamsError(fmt.Errorf("multiple query types specified"))
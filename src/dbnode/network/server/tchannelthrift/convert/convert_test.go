@@ -171,3 +171,139 @@ var _ convert.FetchTaggedConversionPools = &testPools{}
 
 func (t *testPools) ID() ident.Pool                                     { return t.id }
 func (t *testPools) CheckedBytesWrapper() xpool.CheckedBytesWrapperPool { return t.wrapper }
+
+func TestConvertFetchTaggedPageToken(t *testing.T) {
+	id := []byte("foo")
+	token := convert.ToRPCFetchTaggedPageToken(id)
+	parsed, err := convert.FromRPCFetchTaggedPageToken(token)
+	require.NoError(t, err)
+	require.Equal(t, id, parsed)
+
+	_, err = convert.FromRPCFetchTaggedPageToken([]byte("bad"))
+	require.Error(t, err)
+	_, err = convert.FromRPCFetchTaggedPageToken(nil)
+	require.Error(t, err)
+}
+
+func TestConvertCardinalityRequest(t *testing.T) {
+	ns := ident.StringID("abc")
+	opts := index.CardinalityOptions{
+		StartInclusive: time.Unix(0, 100),
+		EndExclusive:   time.Unix(0, 200),
+		NameTag:        []byte("name"),
+		Limit:          5,
+		MaxTerms:       100,
+	}
+
+	req, err := convert.ToRPCCardinalityRequest(ns, opts)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), req.RangeStart)
+	require.Equal(t, int64(200), req.RangeEnd)
+
+	observedNs, observedOpts, err := convert.FromRPCCardinalityRequest(&req)
+	require.NoError(t, err)
+	require.Equal(t, ns.String(), observedNs.String())
+	require.True(t, opts.StartInclusive.Equal(observedOpts.StartInclusive))
+	require.True(t, opts.EndExclusive.Equal(observedOpts.EndExclusive))
+	require.Equal(t, opts.NameTag, observedOpts.NameTag)
+	require.Equal(t, opts.Limit, observedOpts.Limit)
+	require.Equal(t, opts.MaxTerms, observedOpts.MaxTerms)
+}
+
+func TestConvertCardinalityRequestDefaults(t *testing.T) {
+	_, opts, err := convert.FromRPCCardinalityRequest(&rpc.CardinalityRequest{
+		NameSpace:     []byte("abc"),
+		RangeStart:    1,
+		RangeEnd:      2,
+		RangeTimeType: rpc.TimeType_UNIX_SECONDS,
+	})
+	require.NoError(t, err)
+	require.Equal(t, index.DefaultCardinalityNameTag, opts.NameTag)
+	require.Equal(t, index.DefaultCardinalityLimit, opts.Limit)
+	require.Equal(t, index.DefaultCardinalityMaxTerms, opts.MaxTerms)
+
+	limit := int64(index.MaxCardinalityLimit + 1)
+	_, _, err = convert.FromRPCCardinalityRequest(&rpc.CardinalityRequest{
+		NameSpace: []byte("abc"),
+		Limit:     &limit,
+	})
+	require.Error(t, err)
+}
+
+func TestConvertCardinalityResult(t *testing.T) {
+	results := []index.CardinalityResult{
+		{
+			BlockStart:  time.Unix(0, 7200),
+			NumSeries:   3,
+			MetricNames: []index.CardinalityEntry{{Name: []byte("cpu"), Count: 2}},
+			TagNames:    []index.CardinalityEntry{{Name: []byte("host"), Count: 3}},
+			TagValues:   []index.CardinalityEntry{{Name: []byte("host"), Count: 1}},
+			Exhaustive:  true,
+		},
+	}
+
+	res, err := convert.ToRPCCardinalityResult(results, rpc.TimeType_UNIX_NANOSECONDS)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(res.Blocks))
+	require.Equal(t, int64(7200), res.Blocks[0].BlockStart)
+
+	observed, err := convert.FromRPCCardinalityResult(res)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(observed))
+	require.True(t, results[0].BlockStart.Equal(observed[0].BlockStart))
+	observed[0].BlockStart = results[0].BlockStart
+	require.Equal(t, results, observed)
+}
+
+func TestConvertQueryCostRequest(t *testing.T) {
+	ns := ident.StringID("abc")
+	q, _ := regexpQueryTestCase(t)
+	opts := index.QueryCostOptions{
+		StartInclusive: time.Unix(0, 100),
+		EndExclusive:   time.Unix(0, 200),
+	}
+
+	req, err := convert.ToRPCQueryCostRequest(ns, index.Query{Query: q}, opts)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), req.RangeStart)
+	require.Equal(t, int64(200), req.RangeEnd)
+
+	observedNs, observedQuery, observedOpts, err := convert.FromRPCQueryCostRequest(&req)
+	require.NoError(t, err)
+	require.Equal(t, ns.String(), observedNs.String())
+	require.True(t, q.Equal(observedQuery.Query))
+	require.True(t, opts.StartInclusive.Equal(observedOpts.StartInclusive))
+	require.True(t, opts.EndExclusive.Equal(observedOpts.EndExclusive))
+}
+
+func TestConvertQueryCostResult(t *testing.T) {
+	terms := []index.QueryTerm{
+		{Type: index.QueryTermTypeTerm, Field: []byte("host"), Pattern: []byte("a")},
+		{Type: index.QueryTermTypeField, Field: []byte("dc"), Negated: true},
+	}
+	results := []index.QueryCostResult{
+		{
+			BlockStart: time.Unix(0, 7200),
+			Terms: []index.QueryTermCost{
+				{Term: terms[0], Postings: 3},
+				{Term: terms[1], Postings: 10},
+			},
+		},
+	}
+
+	res, err := convert.ToRPCQueryCostResult(results, rpc.TimeType_UNIX_NANOSECONDS)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(res.Blocks))
+	require.Equal(t, int64(7200), res.Blocks[0].BlockStart)
+	require.Equal(t, []int64{3, 10}, res.Blocks[0].TermPostings)
+
+	observed, err := convert.FromRPCQueryCostResult(res, terms)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(observed))
+	require.True(t, results[0].BlockStart.Equal(observed[0].BlockStart))
+	observed[0].BlockStart = results[0].BlockStart
+	require.Equal(t, results, observed)
+
+	_, err = convert.FromRPCQueryCostResult(res, terms[:1])
+	require.Error(t, err)
+}
@@ -21,6 +21,8 @@
 package node
 
 import (
+	"net"
+
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
 	ns "github.com/m3db/m3/src/dbnode/network/server"
 	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift"
@@ -34,6 +36,7 @@ import (
 type server struct {
 	db          storage.Database
 	address     string
+	listener    net.Listener
 	contextPool context.Pool
 	opts        *tchannel.ChannelOptions
 	ttopts      tchannelthrift.Options
@@ -64,6 +67,21 @@ func NewServer(
 	}
 }
 
+// NewServerWithListener creates a new node TChannel Thrift network service
+// that serves on an existing listener, such as a listener multiplexed with
+// other network services.
+func NewServerWithListener(
+	db storage.Database,
+	listener net.Listener,
+	contextPool context.Pool,
+	opts *tchannel.ChannelOptions,
+	ttopts tchannelthrift.Options,
+) ns.NetworkService {
+	s := NewServer(db, listener.Addr().String(), contextPool, opts, ttopts).(*server)
+	s.listener = listener
+	return s
+}
+
 func (s *server) ListenAndServe() (ns.Close, error) {
 	channel, err := tchannel.NewChannel(channel.ChannelName, s.opts)
 	if err != nil {
@@ -73,7 +91,14 @@ func (s *server) ListenAndServe() (ns.Close, error) {
 	service := NewService(s.db, s.ttopts)
 	tchannelthrift.RegisterServer(channel, rpc.NewTChanNodeServer(service), s.contextPool)
 
-	channel.ListenAndServe(s.address)
+	if s.listener != nil {
+		if err := channel.Serve(s.listener); err != nil {
+			channel.Close()
+			return nil, err
+		}
+	} else {
+		channel.ListenAndServe(s.address)
+	}
 
 	return channel.Close, nil
 }
@@ -21,19 +21,23 @@
 package node
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
 	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift"
 	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/convert"
 	tterrors "github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/errors"
 	"github.com/m3db/m3/src/dbnode/storage"
 	"github.com/m3db/m3/src/dbnode/storage/block"
+	m3dberrors "github.com/m3db/m3/src/dbnode/storage/errors"
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/dbnode/x/xio"
@@ -80,17 +84,27 @@ var (
 
 	// errNodeIsNotBootstrapped
 	errNodeIsNotBootstrapped = errors.New("node is not bootstrapped")
+
+	// errInvalidPageSize raised when a fetch tagged page size is not positive
+	errInvalidPageSize = errors.New("fetch tagged page size must be positive")
+
+	// errNilFetchTaggedRequest raised when a fetch tagged page request has no fetch tagged request
+	errNilFetchTaggedRequest = errors.New("nil fetch tagged request")
 )
 
 type serviceMetrics struct {
 	fetch               instrument.MethodMetrics
 	fetchTagged         instrument.MethodMetrics
+	fetchTaggedPage     instrument.MethodMetrics
 	write               instrument.MethodMetrics
 	writeTagged         instrument.MethodMetrics
 	fetchBlocks         instrument.MethodMetrics
 	fetchBlocksMetadata instrument.MethodMetrics
 	repair              instrument.MethodMetrics
 	truncate            instrument.MethodMetrics
+	backup              instrument.MethodMetrics
+	cardinality         instrument.MethodMetrics
+	queryCost           instrument.MethodMetrics
 	fetchBatchRaw       instrument.BatchMethodMetrics
 	writeBatchRaw       instrument.BatchMethodMetrics
 	writeTaggedBatchRaw instrument.BatchMethodMetrics
@@ -101,12 +115,16 @@ func newServiceMetrics(scope tally.Scope, samplingRate float64) serviceMetrics {
 	return serviceMetrics{
 		fetch:               instrument.NewMethodMetrics(scope, "fetch", samplingRate),
 		fetchTagged:         instrument.NewMethodMetrics(scope, "fetchTagged", samplingRate),
+		fetchTaggedPage:     instrument.NewMethodMetrics(scope, "fetchTaggedPage", samplingRate),
 		write:               instrument.NewMethodMetrics(scope, "write", samplingRate),
 		writeTagged:         instrument.NewMethodMetrics(scope, "writeTagged", samplingRate),
 		fetchBlocks:         instrument.NewMethodMetrics(scope, "fetchBlocks", samplingRate),
 		fetchBlocksMetadata: instrument.NewMethodMetrics(scope, "fetchBlocksMetadata", samplingRate),
 		repair:              instrument.NewMethodMetrics(scope, "repair", samplingRate),
 		truncate:            instrument.NewMethodMetrics(scope, "truncate", samplingRate),
+		backup:              instrument.NewMethodMetrics(scope, "backup", samplingRate),
+		cardinality:         instrument.NewMethodMetrics(scope, "cardinality", samplingRate),
+		queryCost:           instrument.NewMethodMetrics(scope, "queryCost", samplingRate),
 		fetchBatchRaw:       instrument.NewBatchMethodMetrics(scope, "fetchBatchRaw", samplingRate),
 		writeBatchRaw:       instrument.NewBatchMethodMetrics(scope, "writeBatchRaw", samplingRate),
 		writeTaggedBatchRaw: instrument.NewBatchMethodMetrics(scope, "writeTaggedBatchRaw", samplingRate),
@@ -293,6 +311,10 @@ func (s *service) Query(tctx thrift.Context, req *rpc.QueryRequest) (*rpc.QueryR
 	if req.NoData != nil && *req.NoData {
 		fetchData = false
 	}
+	var multiItPool encoding.MultiReaderIteratorPool
+	if fetchData {
+		multiItPool = s.multiReaderIteratorPool(nsID)
+	}
 	for _, entry := range queryResult.Results.Map().Iter() {
 		elem := &rpc.QueryResultElement{
 			ID:   entry.Key().String(),
@@ -310,7 +332,7 @@ func (s *service) Query(tctx thrift.Context, req *rpc.QueryRequest) (*rpc.QueryR
 		}
 		tsID := entry.Key()
 		datapoints, err := s.readDatapoints(ctx, nsID, tsID, start, end,
-			req.ResultTimeType)
+			req.ResultTimeType, multiItPool)
 		if err != nil {
 			return nil, convert.ToRPCError(err)
 		}
@@ -342,7 +364,7 @@ func (s *service) Fetch(tctx thrift.Context, req *rpc.FetchRequest) (*rpc.FetchR
 
 	// Make datapoints an initialized empty array for JSON serialization as empty array than null
 	datapoints, err := s.readDatapoints(ctx, nsID, tsID, start, end,
-		req.ResultTimeType)
+		req.ResultTimeType, s.multiReaderIteratorPool(nsID))
 	if err != nil {
 		s.metrics.fetch.ReportError(s.nowFn().Sub(callStart))
 		return nil, convert.ToRPCError(err)
@@ -352,11 +374,21 @@ func (s *service) Fetch(tctx thrift.Context, req *rpc.FetchRequest) (*rpc.FetchR
 	return &rpc.FetchResult_{Datapoints: datapoints}, nil
 }
 
+// multiReaderIteratorPool returns the pool of iterators that decode the series
+// of a namespace, namespaces with a schema have their own encoding.
+func (s *service) multiReaderIteratorPool(nsID ident.ID) encoding.MultiReaderIteratorPool {
+	if ns, ok := s.db.Namespace(nsID); ok {
+		return ns.MultiReaderIteratorPool()
+	}
+	return s.db.Options().MultiReaderIteratorPool()
+}
+
 func (s *service) readDatapoints(
 	ctx context.Context,
 	nsID, tsID ident.ID,
 	start, end time.Time,
 	timeType rpc.TimeType,
+	multiItPool encoding.MultiReaderIteratorPool,
 ) ([]*rpc.Datapoint, error) {
 	encoded, err := s.db.ReadEncoded(ctx, nsID, tsID, start, end)
 	if err != nil {
@@ -366,7 +398,7 @@ func (s *service) readDatapoints(
 	// Make datapoints an initialized empty array for JSON serialization as empty array than null
 	datapoints := make([]*rpc.Datapoint, 0)
 
-	multiIt := s.db.Options().MultiReaderIteratorPool().Get()
+	multiIt := multiItPool.Get()
 	multiIt.ResetSliceOfSlices(xio.NewReaderSliceOfSlicesFromBlockReadersIterator(encoded))
 	defer multiIt.Close()
 
@@ -420,38 +452,142 @@ func (s *service) FetchTagged(tctx thrift.Context, req *rpc.FetchTaggedRequest)
 	nsID := results.Namespace()
 	tagsIter := ident.NewTagsIterator(ident.Tags{})
 	for _, entry := range results.Map().Iter() {
-		tsID := entry.Key()
-		tags := entry.Value()
-		enc := s.pools.tagEncoder.Get()
-		ctx.RegisterFinalizer(enc)
-		tagsIter.Reset(tags)
-		encodedTags, err := s.encodeTags(enc, tagsIter)
+		elem, err := s.fetchTaggedIDResult(ctx, tagsIter, nsID, entry.Key(),
+			entry.Value(), fetchData, opts)
 		if err != nil { // This is an invariant, should never happen
 			s.metrics.fetchTagged.ReportError(s.nowFn().Sub(callStart))
 			return nil, tterrors.NewInternalError(err)
 		}
-
-		elem := &rpc.FetchTaggedIDResult_{
-			NameSpace:   nsID.Bytes(),
-			ID:          tsID.Bytes(),
-			EncodedTags: encodedTags.Bytes(),
-		}
 		response.Elements = append(response.Elements, elem)
-		if !fetchData {
-			continue
+	}
+
+	s.metrics.fetchTagged.ReportSuccess(s.nowFn().Sub(callStart))
+	return response, nil
+}
+
+type fetchTaggedPageEntry struct {
+	id   ident.ID
+	tags ident.Tags
+}
+
+// FetchTaggedPage returns a page of the series matching a fetch tagged
+// request, iterating series ordered by ID. The request limit is ignored as
+// the page size bounds the response instead.
+func (s *service) FetchTaggedPage(tctx thrift.Context, req *rpc.FetchTaggedPageRequest) (*rpc.FetchTaggedPageResult_, error) {
+	if s.isOverloaded() {
+		s.metrics.overloadRejected.Inc(1)
+		return nil, tterrors.NewInternalError(errServerIsOverloaded)
+	}
+
+	callStart := s.nowFn()
+	ctx := tchannelthrift.Context(tctx)
+	if req.FetchTagged == nil {
+		s.metrics.fetchTaggedPage.ReportError(s.nowFn().Sub(callStart))
+		return nil, tterrors.NewBadRequestError(errNilFetchTaggedRequest)
+	}
+	if req.PageSize <= 0 {
+		s.metrics.fetchTaggedPage.ReportError(s.nowFn().Sub(callStart))
+		return nil, tterrors.NewBadRequestError(errInvalidPageSize)
+	}
+
+	var after []byte
+	if req.PageToken != nil {
+		var err error
+		after, err = convert.FromRPCFetchTaggedPageToken(req.PageToken)
+		if err != nil {
+			s.metrics.fetchTaggedPage.ReportError(s.nowFn().Sub(callStart))
+			return nil, tterrors.NewBadRequestError(err)
 		}
-		segments, rpcErr := s.readEncoded(ctx, nsID, tsID, opts.StartInclusive, opts.EndExclusive)
-		if rpcErr != nil {
-			elem.Err = rpcErr
-			continue
+	}
+
+	ns, query, opts, fetchData, err := convert.FromRPCFetchTaggedRequest(req.FetchTagged, s.pools)
+	if err != nil {
+		s.metrics.fetchTaggedPage.ReportError(s.nowFn().Sub(callStart))
+		return nil, tterrors.NewBadRequestError(err)
+	}
+	// NB: query one more than the page size to find out if there are more
+	// series after the page.
+	opts.Limit = 0
+	opts.PageSize = int(req.PageSize) + 1
+	opts.PageAfter = after
+
+	queryResult, err := s.db.QueryIDs(ctx, ns, query, opts)
+	if err != nil {
+		s.metrics.fetchTaggedPage.ReportError(s.nowFn().Sub(callStart))
+		return nil, tterrors.NewInternalError(err)
+	}
+
+	// Each index block returns at most a page of series after the page
+	// position, merge them to the series with the smallest IDs.
+	results := queryResult.Results
+	entries := make([]fetchTaggedPageEntry, 0, results.Size())
+	for _, entry := range results.Map().Iter() {
+		entries = append(entries, fetchTaggedPageEntry{
+			id:   entry.Key(),
+			tags: entry.Value(),
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return bytes.Compare(entries[i].id.Bytes(), entries[j].id.Bytes()) < 0
+	})
+
+	response := &rpc.FetchTaggedPageResult_{}
+	if int64(len(entries)) > req.PageSize {
+		entries = entries[:req.PageSize]
+		response.NextPageToken = convert.ToRPCFetchTaggedPageToken(entries[len(entries)-1].id.Bytes())
+	}
+
+	nsID := results.Namespace()
+	tagsIter := ident.NewTagsIterator(ident.Tags{})
+	response.Elements = make([]*rpc.FetchTaggedIDResult_, 0, len(entries))
+	for _, entry := range entries {
+		elem, err := s.fetchTaggedIDResult(ctx, tagsIter, nsID, entry.id,
+			entry.tags, fetchData, opts)
+		if err != nil { // This is an invariant, should never happen
+			s.metrics.fetchTaggedPage.ReportError(s.nowFn().Sub(callStart))
+			return nil, tterrors.NewInternalError(err)
 		}
-		elem.Segments = segments
+		response.Elements = append(response.Elements, elem)
 	}
 
-	s.metrics.fetchTagged.ReportSuccess(s.nowFn().Sub(callStart))
+	s.metrics.fetchTaggedPage.ReportSuccess(s.nowFn().Sub(callStart))
 	return response, nil
 }
 
+func (s *service) fetchTaggedIDResult(
+	ctx context.Context,
+	tagsIter ident.TagsIterator,
+	nsID ident.ID,
+	tsID ident.ID,
+	tags ident.Tags,
+	fetchData bool,
+	opts index.QueryOptions,
+) (*rpc.FetchTaggedIDResult_, error) {
+	enc := s.pools.tagEncoder.Get()
+	ctx.RegisterFinalizer(enc)
+	tagsIter.Reset(tags)
+	encodedTags, err := s.encodeTags(enc, tagsIter)
+	if err != nil {
+		return nil, err
+	}
+
+	elem := &rpc.FetchTaggedIDResult_{
+		NameSpace:   nsID.Bytes(),
+		ID:          tsID.Bytes(),
+		EncodedTags: encodedTags.Bytes(),
+	}
+	if !fetchData {
+		return elem, nil
+	}
+	segments, rpcErr := s.readEncoded(ctx, nsID, tsID, opts.StartInclusive, opts.EndExclusive)
+	if rpcErr != nil {
+		elem.Err = rpcErr
+		return elem, nil
+	}
+	elem.Segments = segments
+	return elem, nil
+}
+
 func (s *service) encodeTags(
 	enc serialize.TagEncoder,
 	tags ident.TagIterator,
@@ -1008,6 +1144,95 @@ func (s *service) Truncate(tctx thrift.Context, req *rpc.TruncateRequest) (r *rp
 	return res, nil
 }
 
+func (s *service) Backup(tctx thrift.Context, req *rpc.BackupRequest) (*rpc.BackupResult_, error) {
+	callStart := s.nowFn()
+	manifest, err := s.db.Backup(req.DestinationPath)
+
+	if err != nil {
+		s.metrics.backup.ReportError(s.nowFn().Sub(callStart))
+		return nil, convert.ToRPCError(err)
+	}
+
+	numFiles := len(manifest.SnapshotFiles)
+	for _, ns := range manifest.Namespaces {
+		for _, shard := range ns.Shards {
+			numFiles += len(shard.Files)
+		}
+		numFiles += len(ns.IndexFiles)
+	}
+
+	res := rpc.NewBackupResult_()
+	res.SnapshotIndex = manifest.SnapshotIndex
+	res.SnapshotUUID = manifest.SnapshotUUID
+	res.NumFiles = int64(numFiles)
+
+	s.metrics.backup.ReportSuccess(s.nowFn().Sub(callStart))
+
+	return res, nil
+}
+
+func (s *service) Cardinality(tctx thrift.Context, req *rpc.CardinalityRequest) (*rpc.CardinalityResult_, error) {
+	if s.isOverloaded() {
+		s.metrics.overloadRejected.Inc(1)
+		return nil, tterrors.NewInternalError(errServerIsOverloaded)
+	}
+
+	callStart := s.nowFn()
+	ctx := tchannelthrift.Context(tctx)
+	ns, opts, err := convert.FromRPCCardinalityRequest(req)
+	if err != nil {
+		s.metrics.cardinality.ReportError(s.nowFn().Sub(callStart))
+		return nil, tterrors.NewBadRequestError(err)
+	}
+
+	results, err := s.db.Cardinality(ctx, ns, opts)
+	if err != nil {
+		s.metrics.cardinality.ReportError(s.nowFn().Sub(callStart))
+		return nil, convert.ToRPCError(err)
+	}
+
+	res, err := convert.ToRPCCardinalityResult(results, req.RangeTimeType)
+	if err != nil {
+		s.metrics.cardinality.ReportError(s.nowFn().Sub(callStart))
+		return nil, tterrors.NewInternalError(err)
+	}
+
+	s.metrics.cardinality.ReportSuccess(s.nowFn().Sub(callStart))
+
+	return res, nil
+}
+
+func (s *service) QueryCost(tctx thrift.Context, req *rpc.QueryCostRequest) (*rpc.QueryCostResult_, error) {
+	if s.isOverloaded() {
+		s.metrics.overloadRejected.Inc(1)
+		return nil, tterrors.NewInternalError(errServerIsOverloaded)
+	}
+
+	callStart := s.nowFn()
+	ctx := tchannelthrift.Context(tctx)
+	ns, query, opts, err := convert.FromRPCQueryCostRequest(req)
+	if err != nil {
+		s.metrics.queryCost.ReportError(s.nowFn().Sub(callStart))
+		return nil, tterrors.NewBadRequestError(err)
+	}
+
+	results, err := s.db.QueryCost(ctx, ns, query, opts)
+	if err != nil {
+		s.metrics.queryCost.ReportError(s.nowFn().Sub(callStart))
+		return nil, convert.ToRPCError(err)
+	}
+
+	res, err := convert.ToRPCQueryCostResult(results, req.RangeTimeType)
+	if err != nil {
+		s.metrics.queryCost.ReportError(s.nowFn().Sub(callStart))
+		return nil, tterrors.NewInternalError(err)
+	}
+
+	s.metrics.queryCost.ReportSuccess(s.nowFn().Sub(callStart))
+
+	return res, nil
+}
+
 func (s *service) GetPersistRateLimit(
 	ctx thrift.Context,
 ) (*rpc.NodePersistRateLimitResult_, error) {
@@ -1330,6 +1555,13 @@ func (r *writeBatchPooledReq) HandleError(index int, err error) {
 	}
 
 	r.retryableErrors++
+	if m3dberrors.IsResourceExhaustedError(err) {
+		r.errs = append(
+			r.errs,
+			tterrors.NewResourceExhaustedWriteBatchRawError(index, err))
+		return
+	}
+
 	r.errs = append(
 		r.errs,
 		tterrors.NewWriteBatchRawError(index, err))
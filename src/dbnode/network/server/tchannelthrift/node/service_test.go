@@ -184,6 +184,10 @@ func TestServiceQuery(t *testing.T) {
 			}}, nil)
 	}
 
+	mockNs := storage.NewMockNamespace(ctrl)
+	mockNs.EXPECT().MultiReaderIteratorPool().Return(testStorageOpts.MultiReaderIteratorPool())
+	mockDB.EXPECT().Namespace(ident.NewIDMatcher(nsID)).Return(mockNs, true)
+
 	req, err := idx.NewRegexpQuery([]byte("foo"), []byte("b.*"))
 	require.NoError(t, err)
 	qry := index.Query{Query: req}
@@ -341,6 +345,10 @@ func TestServiceFetch(t *testing.T) {
 			},
 		}, nil)
 
+	mockNs := storage.NewMockNamespace(ctrl)
+	mockNs.EXPECT().MultiReaderIteratorPool().Return(testStorageOpts.MultiReaderIteratorPool())
+	mockDB.EXPECT().Namespace(ident.NewIDMatcher(nsID)).Return(mockNs, true)
+
 	r, err := service.Fetch(tctx, &rpc.FetchRequest{
 		RangeStart:     start.Unix(),
 		RangeEnd:       end.Unix(),
@@ -1169,6 +1177,138 @@ func TestServiceFetchTaggedErrs(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestServiceFetchTaggedPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockDB.EXPECT().IsOverloaded().Return(false).Times(2)
+
+	service := NewService(mockDB, nil).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	start := time.Now().Add(-2 * time.Hour)
+	end := start.Add(2 * time.Hour)
+
+	start, end = start.Truncate(time.Second), end.Truncate(time.Second)
+	nsID := "metrics"
+
+	req, err := idx.NewRegexpQuery([]byte("foo"), []byte("b.*"))
+	require.NoError(t, err)
+	qry := index.Query{Query: req}
+
+	newResults := func(ids ...string) index.Results {
+		resMap := index.NewResults(index.NewOptions())
+		resMap.Reset(ident.StringID(nsID))
+		for _, id := range ids {
+			resMap.Map().Set(ident.StringID(id), ident.NewTags(
+				ident.StringTag("foo", "bar"),
+			))
+		}
+		return resMap
+	}
+
+	// The request limit is ignored when fetching pages, the index is queried
+	// for one more than the page size after the page token.
+	gomock.InOrder(
+		mockDB.EXPECT().QueryIDs(
+			ctx,
+			ident.NewIDMatcher(nsID),
+			index.NewQueryMatcher(qry),
+			index.QueryOptions{
+				StartInclusive: start,
+				EndExclusive:   end,
+				PageSize:       3,
+			}).Return(index.QueryResults{
+			Results:    newResults("b1", "a2", "a1"),
+			Exhaustive: true,
+		}, nil),
+		mockDB.EXPECT().QueryIDs(
+			ctx,
+			ident.NewIDMatcher(nsID),
+			index.NewQueryMatcher(qry),
+			index.QueryOptions{
+				StartInclusive: start,
+				EndExclusive:   end,
+				PageSize:       3,
+				PageAfter:      []byte("a2"),
+			}).Return(index.QueryResults{
+			Results:    newResults("b1"),
+			Exhaustive: true,
+		}, nil),
+	)
+
+	startNanos, err := convert.ToValue(start, rpc.TimeType_UNIX_NANOSECONDS)
+	require.NoError(t, err)
+	endNanos, err := convert.ToValue(end, rpc.TimeType_UNIX_NANOSECONDS)
+	require.NoError(t, err)
+	var limit int64 = 1
+	data, err := idx.Marshal(req)
+	require.NoError(t, err)
+	pageReq := &rpc.FetchTaggedPageRequest{
+		FetchTagged: &rpc.FetchTaggedRequest{
+			NameSpace:  []byte(nsID),
+			Query:      data,
+			RangeStart: startNanos,
+			RangeEnd:   endNanos,
+			FetchData:  false,
+			Limit:      &limit,
+		},
+		PageSize: 2,
+	}
+
+	r, err := service.FetchTaggedPage(tctx, pageReq)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(r.Elements))
+	require.Equal(t, "a1", string(r.Elements[0].ID))
+	require.Equal(t, "a2", string(r.Elements[1].ID))
+	require.NotNil(t, r.NextPageToken)
+
+	pageReq.PageToken = r.NextPageToken
+	r, err = service.FetchTaggedPage(tctx, pageReq)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(r.Elements))
+	require.Equal(t, "b1", string(r.Elements[0].ID))
+	require.Nil(t, r.NextPageToken)
+}
+
+func TestServiceFetchTaggedPageErrs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockDB.EXPECT().IsOverloaded().Return(false).AnyTimes()
+
+	service := NewService(mockDB, nil).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	_, err := service.FetchTaggedPage(tctx, &rpc.FetchTaggedPageRequest{PageSize: 1})
+	require.Error(t, err)
+	require.True(t, tterrors.IsBadRequestError(err.(*rpc.Error)))
+
+	_, err = service.FetchTaggedPage(tctx, &rpc.FetchTaggedPageRequest{
+		FetchTagged: &rpc.FetchTaggedRequest{},
+	})
+	require.Error(t, err)
+	require.True(t, tterrors.IsBadRequestError(err.(*rpc.Error)))
+
+	_, err = service.FetchTaggedPage(tctx, &rpc.FetchTaggedPageRequest{
+		FetchTagged: &rpc.FetchTaggedRequest{},
+		PageSize:    1,
+		PageToken:   []byte("bad"),
+	})
+	require.Error(t, err)
+	require.True(t, tterrors.IsBadRequestError(err.(*rpc.Error)))
+}
+
 func TestServiceWrite(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1415,6 +1555,125 @@ func TestServiceTruncate(t *testing.T) {
 	assert.Equal(t, truncated, r.NumSeries)
 }
 
+func TestServiceCardinality(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockDB.EXPECT().IsOverloaded().Return(false).AnyTimes()
+
+	service := NewService(mockDB, nil).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	nsID := "metrics"
+	start := time.Now().Add(-2 * time.Hour).Truncate(time.Hour)
+	end := start.Add(2 * time.Hour)
+
+	mockDB.EXPECT().
+		Cardinality(ctx, ident.NewIDMatcher(nsID), index.CardinalityOptions{
+			StartInclusive: start,
+			EndExclusive:   end,
+			NameTag:        index.DefaultCardinalityNameTag,
+			Limit:          5,
+			MaxTerms:       index.DefaultCardinalityMaxTerms,
+		}).
+		Return([]index.CardinalityResult{{
+			BlockStart: start,
+			NumSeries:  2,
+			MetricNames: []index.CardinalityEntry{
+				{Name: []byte("cpu"), Count: 2},
+			},
+			Exhaustive: true,
+		}}, nil)
+
+	limit := int64(5)
+	r, err := service.Cardinality(tctx, &rpc.CardinalityRequest{
+		NameSpace:     []byte(nsID),
+		RangeStart:    start.Unix(),
+		RangeEnd:      end.Unix(),
+		RangeTimeType: rpc.TimeType_UNIX_SECONDS,
+		Limit:         &limit,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(r.Blocks))
+	assert.Equal(t, start.Unix(), r.Blocks[0].BlockStart)
+	assert.Equal(t, int64(2), r.Blocks[0].NumSeries)
+	assert.True(t, r.Blocks[0].Exhaustive)
+	require.Equal(t, 1, len(r.Blocks[0].MetricNames))
+	assert.Equal(t, []byte("cpu"), r.Blocks[0].MetricNames[0].Name)
+
+	limit = int64(index.MaxCardinalityLimit + 1)
+	_, err = service.Cardinality(tctx, &rpc.CardinalityRequest{
+		NameSpace:     []byte(nsID),
+		RangeStart:    start.Unix(),
+		RangeEnd:      end.Unix(),
+		RangeTimeType: rpc.TimeType_UNIX_SECONDS,
+		Limit:         &limit,
+	})
+	require.Error(t, err)
+}
+
+func TestServiceQueryCost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockDB.EXPECT().IsOverloaded().Return(false).AnyTimes()
+
+	service := NewService(mockDB, nil).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	nsID := "metrics"
+	start := time.Now().Add(-2 * time.Hour).Truncate(time.Hour)
+	end := start.Add(2 * time.Hour)
+
+	req, err := idx.NewRegexpQuery([]byte("foo"), []byte("b.*"))
+	require.NoError(t, err)
+	qry := index.Query{Query: req}
+	terms := index.AnalyzeQuery(qry).Terms
+
+	mockDB.EXPECT().
+		QueryCost(ctx, ident.NewIDMatcher(nsID), index.NewQueryMatcher(qry), index.QueryCostOptions{
+			StartInclusive: start,
+			EndExclusive:   end,
+		}).
+		Return([]index.QueryCostResult{{
+			BlockStart: start,
+			Terms:      []index.QueryTermCost{{Term: terms[0], Postings: 42}},
+		}}, nil)
+
+	data, err := idx.Marshal(req)
+	require.NoError(t, err)
+	r, err := service.QueryCost(tctx, &rpc.QueryCostRequest{
+		NameSpace:     []byte(nsID),
+		Query:         data,
+		RangeStart:    start.Unix(),
+		RangeEnd:      end.Unix(),
+		RangeTimeType: rpc.TimeType_UNIX_SECONDS,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(r.Blocks))
+	assert.Equal(t, start.Unix(), r.Blocks[0].BlockStart)
+	assert.Equal(t, []int64{42}, r.Blocks[0].TermPostings)
+
+	_, err = service.QueryCost(tctx, &rpc.QueryCostRequest{
+		NameSpace:     []byte(nsID),
+		Query:         []byte("invalid"),
+		RangeStart:    start.Unix(),
+		RangeEnd:      end.Unix(),
+		RangeTimeType: rpc.TimeType_UNIX_SECONDS,
+	})
+	require.Error(t, err)
+}
+
 func TestServiceSetPersistRateLimit(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -0,0 +1,363 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package backup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/persist/fs/objectstore"
+	xerrors "github.com/m3db/m3x/errors"
+	"github.com/m3db/m3x/ident"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/pborman/uuid"
+)
+
+const (
+	// ManifestFileName is the name of the manifest file at the root of a backup,
+	// it is written last so that a backup is only complete once it exists.
+	ManifestFileName = "manifest.json"
+
+	manifestVersion = 1
+)
+
+var (
+	errBackupAlreadyExists      = errors.New("backup already exists at destination")
+	errSnapshotMetadataNotFound = errors.New("snapshot metadata not found")
+)
+
+type backuper struct {
+	opts  Options
+	nowFn clock.NowFn
+}
+
+// NewBackuper returns a new backuper.
+func NewBackuper(opts Options) Backuper {
+	return &backuper{
+		opts:  opts,
+		nowFn: opts.ClockOptions().NowFn(),
+	}
+}
+
+func (b *backuper) Backup(args BackupArgs) (Manifest, error) {
+	if err := b.opts.Validate(); err != nil {
+		return Manifest{}, err
+	}
+
+	manifestPath := path.Join(args.DestinationPath, ManifestFileName)
+	exists, err := fs.FileExists(manifestPath)
+	if err != nil {
+		return Manifest{}, err
+	}
+	if exists {
+		return Manifest{}, errBackupAlreadyExists
+	}
+
+	snapshotFilePaths, err := b.snapshotMetadataFilePaths(args.Snapshot)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	manifest := Manifest{
+		Version:       manifestVersion,
+		CreatedAt:     b.nowFn(),
+		SnapshotIndex: args.Snapshot.Index,
+		SnapshotUUID:  args.Snapshot.UUID.String(),
+	}
+	manifest.SnapshotFiles, err = b.backupFiles(args.DestinationPath, snapshotFilePaths)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	for _, ns := range args.Namespaces {
+		nsManifest, err := b.backupNamespace(args.DestinationPath, ns)
+		if err != nil {
+			return Manifest{}, fmt.Errorf("unable to backup namespace %s: %v",
+				ns.Namespace.String(), err)
+		}
+		manifest.Namespaces = append(manifest.Namespaces, nsManifest)
+	}
+
+	if err := writeManifest(manifestPath, manifest, b.opts.FilesystemOptions()); err != nil {
+		return Manifest{}, err
+	}
+	return manifest, nil
+}
+
+func (b *backuper) snapshotMetadataFilePaths(id fs.SnapshotMetadataIdentifier) ([]string, error) {
+	metadatas, _, err := fs.SortedSnapshotMetadataFiles(b.opts.FilesystemOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, metadata := range metadatas {
+		if metadata.ID.Index == id.Index && uuid.Equal(metadata.ID.UUID, id.UUID) {
+			return []string{metadata.MetadataFilePath, metadata.CheckpointFilePath}, nil
+		}
+	}
+	return nil, errSnapshotMetadataNotFound
+}
+
+func (b *backuper) backupNamespace(dest string, ns NamespaceShards) (NamespaceManifest, error) {
+	var (
+		prefix     = b.opts.FilesystemOptions().FilePathPrefix()
+		nsManifest = NamespaceManifest{ID: ns.Namespace.String()}
+	)
+	for _, shard := range ns.Shards {
+		dataFiles, err := fs.DataFiles(prefix, ns.Namespace, shard)
+		if err != nil {
+			return NamespaceManifest{}, err
+		}
+		snapshotFiles, err := fs.SnapshotFiles(prefix, ns.Namespace, shard)
+		if err != nil {
+			return NamespaceManifest{}, err
+		}
+
+		completeDataFiles := completeFileSets(dataFiles)
+		filePaths := completeDataFiles.Filepaths()
+		filePaths = append(filePaths, latestCompleteVolumes(snapshotFiles).Filepaths()...)
+		files, err := b.backupFiles(dest, filePaths)
+		if err != nil {
+			return NamespaceManifest{}, err
+		}
+		offloadedFiles, err := b.backupOffloadedDataFileSets(dest, ns.Namespace, shard, completeDataFiles)
+		if err != nil {
+			return NamespaceManifest{}, err
+		}
+		files = append(files, offloadedFiles...)
+		nsManifest.Shards = append(nsManifest.Shards, ShardManifest{
+			Shard: shard,
+			Files: files,
+		})
+	}
+
+	indexFiles, err := fs.IndexFiles(prefix, ns.Namespace)
+	if err != nil {
+		return NamespaceManifest{}, err
+	}
+	nsManifest.IndexFiles, err = b.backupFiles(dest, completeFileSets(indexFiles).Filepaths())
+	if err != nil {
+		return NamespaceManifest{}, err
+	}
+	return nsManifest, nil
+}
+
+func (b *backuper) backupFiles(dest string, filePaths []string) ([]FileManifest, error) {
+	var (
+		fsOpts = b.opts.FilesystemOptions()
+		prefix = fsOpts.FilePathPrefix()
+		files  = make([]FileManifest, 0, len(filePaths))
+	)
+	for _, filePath := range filePaths {
+		relPath, err := filepath.Rel(prefix, filePath)
+		if err != nil {
+			return nil, err
+		}
+		size, fileDigest, err := copyFile(filePath, path.Join(dest, relPath), fsOpts)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, FileManifest{
+			Path:   relPath,
+			Size:   size,
+			Digest: fileDigest,
+		})
+	}
+	return files, nil
+}
+
+// backupOffloadedDataFileSets copies the data filesets of a shard that have been
+// offloaded to the object store and are no longer on local disk, they are written
+// to the same paths relative to the backup as local filesets so they are restored
+// to local disk.
+func (b *backuper) backupOffloadedDataFileSets(
+	dest string,
+	namespace ident.ID,
+	shard uint32,
+	localDataFiles fs.FileSetFilesSlice,
+) ([]FileManifest, error) {
+	var (
+		fsOpts = b.opts.FilesystemOptions()
+		store  = fsOpts.ObjectStore()
+	)
+	if store == nil {
+		return nil, nil
+	}
+
+	blockStarts, err := fs.OffloadedDataFileSetBlockStarts(store, namespace, shard)
+	if err != nil {
+		return nil, err
+	}
+
+	local := make(map[xtime.UnixNano]struct{}, len(localDataFiles))
+	for _, file := range localDataFiles {
+		local[xtime.ToUnixNano(file.ID.BlockStart)] = struct{}{}
+	}
+
+	var files []FileManifest
+	for _, blockStart := range blockStarts {
+		if _, ok := local[xtime.ToUnixNano(blockStart)]; ok {
+			continue
+		}
+		for _, key := range fs.OffloadedDataFileSetObjectKeys(namespace, shard, blockStart) {
+			file, err := copyObject(store, key, dest, fsOpts)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// completeFileSets returns the filesets that have a checkpoint file.
+func completeFileSets(files fs.FileSetFilesSlice) fs.FileSetFilesSlice {
+	complete := make(fs.FileSetFilesSlice, 0, len(files))
+	for _, file := range files {
+		if file.HasCheckpointFile() {
+			complete = append(complete, file)
+		}
+	}
+	return complete
+}
+
+// latestCompleteVolumes returns the complete fileset with the highest volume
+// index for each block start.
+func latestCompleteVolumes(files fs.FileSetFilesSlice) fs.FileSetFilesSlice {
+	var (
+		latest    fs.FileSetFilesSlice
+		positions = make(map[xtime.UnixNano]int)
+	)
+	for _, file := range completeFileSets(files) {
+		blockStart := xtime.ToUnixNano(file.ID.BlockStart)
+		pos, ok := positions[blockStart]
+		if !ok {
+			positions[blockStart] = len(latest)
+			latest = append(latest, file)
+			continue
+		}
+		if file.ID.VolumeIndex > latest[pos].ID.VolumeIndex {
+			latest[pos] = file
+		}
+	}
+	return latest
+}
+
+// copyFile copies the file at src to dest, returning the size and digest of the copied contents.
+func copyFile(src, dest string, fsOpts fs.Options) (int64, uint32, error) {
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer srcFile.Close()
+
+	reader := digest.NewReaderWithDigest(srcFile)
+	size, err := writeFile(dest, reader, fsOpts)
+	if err != nil {
+		return 0, 0, err
+	}
+	return size, reader.Digest().Sum32(), nil
+}
+
+// copyObject copies the object at the given key to the same path relative to dest.
+func copyObject(store objectstore.Store, key string, dest string, fsOpts fs.Options) (FileManifest, error) {
+	r, err := store.Get(key)
+	if err != nil {
+		return FileManifest{}, err
+	}
+	defer r.Close()
+
+	reader := digest.NewReaderWithDigest(r)
+	size, err := writeFile(path.Join(dest, key), reader, fsOpts)
+	if err != nil {
+		return FileManifest{}, err
+	}
+	return FileManifest{
+		Path:   key,
+		Size:   size,
+		Digest: reader.Digest().Sum32(),
+	}, nil
+}
+
+func writeFile(filePath string, r io.Reader, fsOpts fs.Options) (n int64, finalErr error) {
+	if err := os.MkdirAll(path.Dir(filePath), fsOpts.NewDirectoryMode()); err != nil {
+		return 0, err
+	}
+
+	fd, err := fs.OpenWritable(filePath, fsOpts.NewFileMode())
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		multiErr := xerrors.NewMultiError().
+			Add(finalErr).
+			Add(fd.Close())
+		finalErr = multiErr.FinalError()
+	}()
+
+	n, err = io.Copy(fd, r)
+	if err != nil {
+		return 0, err
+	}
+	return n, fd.Sync()
+}
+
+func writeManifest(manifestPath string, manifest Manifest, fsOpts fs.Options) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first and rename it into place so that a
+	// partially written manifest is never mistaken for a complete backup.
+	tmpPath := manifestPath + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, data, fsOpts.NewFileMode()); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, manifestPath)
+}
+
+// ReadManifest reads the manifest of the backup at the given directory.
+func ReadManifest(backupPath string) (Manifest, error) {
+	data, err := ioutil.ReadFile(path.Join(backupPath, ManifestFileName))
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return Manifest{}, err
+	}
+	if manifest.Version != manifestVersion {
+		return Manifest{}, fmt.Errorf("unsupported backup manifest version: %d", manifest.Version)
+	}
+	return manifest, nil
+}
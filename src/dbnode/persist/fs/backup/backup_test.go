@@ -0,0 +1,342 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package backup
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/persist/fs/objectstore"
+	"github.com/m3db/m3x/ident"
+
+	"github.com/pborman/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testNs         = ident.StringID("testns")
+	testBlockStart = time.Now().Truncate(2 * time.Hour)
+)
+
+func createTempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "backup")
+	require.NoError(t, err)
+	return dir
+}
+
+func testOptions(filePathPrefix string) Options {
+	return NewOptions().
+		SetFilesystemOptions(fs.NewOptions().SetFilePathPrefix(filePathPrefix))
+}
+
+func writeTestFile(t *testing.T, filePath string, contents string) {
+	require.NoError(t, os.MkdirAll(path.Dir(filePath), 0755))
+	require.NoError(t, ioutil.WriteFile(filePath, []byte(contents), 0666))
+}
+
+func testFileContents(suffix string, desc string) string {
+	if suffix == "checkpoint" {
+		// Checkpoint files are only considered complete with a digest worth of bytes.
+		return string(make([]byte, fs.CheckpointFileSizeBytes))
+	}
+	return desc
+}
+
+func writeTestDataFileSet(t *testing.T, prefix string, shard uint32, blockStart time.Time, complete bool) {
+	dir := fs.ShardDataDirPath(prefix, testNs, shard)
+	suffixes := []string{"info", "data"}
+	if complete {
+		suffixes = append(suffixes, "checkpoint")
+	}
+	for _, suffix := range suffixes {
+		name := fmt.Sprintf("fileset-%d-%s.db", blockStart.UnixNano(), suffix)
+		writeTestFile(t, path.Join(dir, name), testFileContents(suffix, fmt.Sprintf("data %d %s", shard, suffix)))
+	}
+}
+
+func writeTestSnapshotFileSet(t *testing.T, prefix string, shard uint32, blockStart time.Time, volume int) {
+	dir := fs.ShardSnapshotsDirPath(prefix, testNs, shard)
+	for _, suffix := range []string{"info", "data", "checkpoint"} {
+		name := fmt.Sprintf("fileset-%d-%d-%s.db", blockStart.UnixNano(), volume, suffix)
+		writeTestFile(t, path.Join(dir, name), testFileContents(suffix, fmt.Sprintf("snapshot %d %d %s", shard, volume, suffix)))
+	}
+}
+
+func writeTestIndexFileSet(t *testing.T, prefix string, blockStart time.Time) {
+	dir := fs.NamespaceIndexDataDirPath(prefix, testNs)
+	for _, suffix := range []string{"info", "checkpoint"} {
+		name := fmt.Sprintf("fileset-%d-0-%s.db", blockStart.UnixNano(), suffix)
+		writeTestFile(t, path.Join(dir, name), testFileContents(suffix, fmt.Sprintf("index %s", suffix)))
+	}
+}
+
+func writeTestSnapshotMetadata(t *testing.T, prefix string) fs.SnapshotMetadataIdentifier {
+	id := fs.SnapshotMetadataIdentifier{Index: 0, UUID: uuid.NewRandom()}
+	writer := fs.NewSnapshotMetadataWriter(fs.NewOptions().SetFilePathPrefix(prefix))
+	require.NoError(t, writer.Write(fs.SnapshotMetadataWriteArgs{
+		ID:                  id,
+		CommitlogIdentifier: []byte("commitlog"),
+	}))
+	return id
+}
+
+// writeTestNode writes the files of a node owning the given shards and returns
+// the snapshot metadata identifier of its snapshot.
+func writeTestNode(t *testing.T, prefix string, shards []uint32) fs.SnapshotMetadataIdentifier {
+	for _, shard := range shards {
+		writeTestDataFileSet(t, prefix, shard, testBlockStart, true)
+		writeTestDataFileSet(t, prefix, shard, testBlockStart.Add(2*time.Hour), false)
+		writeTestSnapshotFileSet(t, prefix, shard, testBlockStart.Add(4*time.Hour), 0)
+		writeTestSnapshotFileSet(t, prefix, shard, testBlockStart.Add(4*time.Hour), 1)
+	}
+	writeTestIndexFileSet(t, prefix, testBlockStart)
+	return writeTestSnapshotMetadata(t, prefix)
+}
+
+func backupTestNode(t *testing.T, prefix, dest string, shards []uint32) Manifest {
+	id := writeTestNode(t, prefix, shards)
+	manifest, err := NewBackuper(testOptions(prefix)).Backup(BackupArgs{
+		DestinationPath: dest,
+		Snapshot:        id,
+		Namespaces:      []NamespaceShards{{Namespace: testNs, Shards: shards}},
+	})
+	require.NoError(t, err)
+	return manifest
+}
+
+func requireSameFile(t *testing.T, srcPrefix, destPrefix, relPath string) {
+	expected, err := ioutil.ReadFile(path.Join(srcPrefix, relPath))
+	require.NoError(t, err)
+	actual, err := ioutil.ReadFile(path.Join(destPrefix, relPath))
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+}
+
+func TestBackupAndRestore(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	var (
+		prefix  = path.Join(dir, "node")
+		dest    = path.Join(dir, "backup")
+		restore = path.Join(dir, "restore")
+		shards  = []uint32{0, 1}
+	)
+	manifest := backupTestNode(t, prefix, dest, shards)
+
+	readManifest, err := ReadManifest(dest)
+	require.NoError(t, err)
+	require.Equal(t, manifest.SnapshotUUID, readManifest.SnapshotUUID)
+	require.Equal(t, 2, len(readManifest.SnapshotFiles))
+
+	// Only the complete data fileset and the latest snapshot volume are backed up.
+	require.Equal(t, 1, len(readManifest.Namespaces))
+	nsManifest := readManifest.Namespaces[0]
+	require.Equal(t, testNs.String(), nsManifest.ID)
+	require.Equal(t, 2, len(nsManifest.Shards))
+	for _, shard := range nsManifest.Shards {
+		require.Equal(t, 6, len(shard.Files))
+		for _, file := range shard.Files {
+			requireSameFile(t, prefix, dest, file.Path)
+		}
+	}
+	require.Equal(t, 2, len(nsManifest.IndexFiles))
+
+	result, err := NewRestorer(testOptions(restore)).Restore(RestoreArgs{
+		SourcePaths: []string{dest},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 14, result.NumFiles)
+	require.Equal(t, map[string][]uint32{testNs.String(): shards}, result.Shards)
+
+	for _, shard := range nsManifest.Shards {
+		for _, file := range shard.Files {
+			requireSameFile(t, prefix, restore, file.Path)
+		}
+	}
+	for _, file := range nsManifest.IndexFiles {
+		requireSameFile(t, prefix, restore, file.Path)
+	}
+
+	// Restoring over existing files fails.
+	_, err = NewRestorer(testOptions(restore)).Restore(RestoreArgs{
+		SourcePaths: []string{dest},
+	})
+	require.Error(t, err)
+}
+
+func TestBackupOffloadedDataFileSets(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	var (
+		prefix     = path.Join(dir, "node")
+		dest       = path.Join(dir, "backup")
+		restore    = path.Join(dir, "restore")
+		store      = objectstore.NewLocalStore(path.Join(dir, "store"))
+		offloaded  = testBlockStart.Add(-2 * time.Hour)
+		shards     = []uint32{0}
+		objectKeys = fs.OffloadedDataFileSetObjectKeys(testNs, 0, offloaded)
+	)
+	id := writeTestNode(t, prefix, shards)
+
+	// Offload a fileset that is no longer on local disk and one that still is,
+	// only the former is backed up from the object store.
+	for _, blockStart := range []time.Time{offloaded, testBlockStart} {
+		for _, key := range fs.OffloadedDataFileSetObjectKeys(testNs, 0, blockStart) {
+			require.NoError(t, store.Put(key, strings.NewReader("object "+key)))
+		}
+	}
+
+	opts := NewOptions().SetFilesystemOptions(fs.NewOptions().
+		SetFilePathPrefix(prefix).
+		SetObjectStore(store))
+	manifest, err := NewBackuper(opts).Backup(BackupArgs{
+		DestinationPath: dest,
+		Snapshot:        id,
+		Namespaces:      []NamespaceShards{{Namespace: testNs, Shards: shards}},
+	})
+	require.NoError(t, err)
+
+	files := manifest.Namespaces[0].Shards[0].Files
+	require.Equal(t, 6+len(objectKeys), len(files))
+	for i, key := range objectKeys {
+		require.Equal(t, key, files[6+i].Path)
+	}
+
+	_, err = NewRestorer(testOptions(restore)).Restore(RestoreArgs{
+		SourcePaths: []string{dest},
+	})
+	require.NoError(t, err)
+	for _, key := range objectKeys {
+		data, err := ioutil.ReadFile(path.Join(restore, key))
+		require.NoError(t, err)
+		require.Equal(t, "object "+key, string(data))
+	}
+}
+
+func TestBackupAlreadyExists(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	var (
+		prefix = path.Join(dir, "node")
+		dest   = path.Join(dir, "backup")
+	)
+	manifest := backupTestNode(t, prefix, dest, []uint32{0})
+
+	id := fs.SnapshotMetadataIdentifier{
+		Index: manifest.SnapshotIndex,
+		UUID:  uuid.Parse(manifest.SnapshotUUID),
+	}
+	_, err := NewBackuper(testOptions(prefix)).Backup(BackupArgs{
+		DestinationPath: dest,
+		Snapshot:        id,
+	})
+	require.Equal(t, errBackupAlreadyExists, err)
+}
+
+func TestBackupSnapshotMetadataNotFound(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	_, err := NewBackuper(testOptions(dir)).Backup(BackupArgs{
+		DestinationPath: path.Join(dir, "backup"),
+		Snapshot:        fs.SnapshotMetadataIdentifier{UUID: uuid.NewRandom()},
+	})
+	require.Equal(t, errSnapshotMetadataNotFound, err)
+}
+
+func TestRestoreDifferentTopology(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	var (
+		prefixA = path.Join(dir, "nodeA")
+		prefixB = path.Join(dir, "nodeB")
+		destA   = path.Join(dir, "backupA")
+		destB   = path.Join(dir, "backupB")
+		restore = path.Join(dir, "restore")
+	)
+	backupTestNode(t, prefixA, destA, []uint32{0, 1})
+	manifestB := backupTestNode(t, prefixB, destB, []uint32{1, 2})
+
+	result, err := NewRestorer(testOptions(restore)).Restore(RestoreArgs{
+		SourcePaths: []string{destA, destB},
+		Shards:      []uint32{1, 2},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 12, result.NumFiles)
+	require.Equal(t, map[string][]uint32{testNs.String(): {1, 2}}, result.Shards)
+
+	for _, shard := range []uint32{0, 1, 2} {
+		exists, err := fs.DataFileSetExistsAt(restore, testNs, shard, testBlockStart)
+		require.NoError(t, err)
+		require.Equal(t, shard != 0, exists)
+	}
+
+	// The index filesets of either backup do not cover the restored shards.
+	for _, file := range manifestB.Namespaces[0].IndexFiles {
+		exists, err := fs.FileExists(path.Join(restore, file.Path))
+		require.NoError(t, err)
+		require.False(t, exists)
+	}
+}
+
+func TestRestoreVerifiesDigests(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	var (
+		prefix  = path.Join(dir, "node")
+		dest    = path.Join(dir, "backup")
+		restore = path.Join(dir, "restore")
+	)
+	manifest := backupTestNode(t, prefix, dest, []uint32{0})
+
+	var dataFile FileManifest
+	for _, file := range manifest.Namespaces[0].Shards[0].Files {
+		if path.Base(file.Path) == fmt.Sprintf("fileset-%d-data.db", testBlockStart.UnixNano()) {
+			dataFile = file
+		}
+	}
+	require.NotEmpty(t, dataFile.Path)
+	writeTestFile(t, path.Join(dest, dataFile.Path), "corrupt")
+
+	_, err := NewRestorer(testOptions(restore)).Restore(RestoreArgs{
+		SourcePaths: []string{dest},
+	})
+	require.Error(t, err)
+
+	// The corrupt file is removed and its fileset is never checkpointed.
+	exists, err := fs.FileExists(path.Join(restore, dataFile.Path))
+	require.NoError(t, err)
+	require.False(t, exists)
+	exists, err = fs.DataFileSetExistsAt(restore, testNs, 0, testBlockStart)
+	require.NoError(t, err)
+	require.False(t, exists)
+}
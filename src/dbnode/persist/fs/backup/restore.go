@@ -0,0 +1,214 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package backup
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+)
+
+// checkpointFileNameSuffix is the suffix of fileset checkpoint file names, the
+// checkpoint files of a restore are written last so that filesets are only
+// considered complete once all of their files are restored.
+const checkpointFileNameSuffix = "-checkpoint.db"
+
+type restoreFile struct {
+	sourcePath string
+	file       FileManifest
+}
+
+type restorer struct {
+	opts Options
+}
+
+// NewRestorer returns a new restorer.
+func NewRestorer(opts Options) Restorer {
+	return &restorer{
+		opts: opts,
+	}
+}
+
+func (r *restorer) Restore(args RestoreArgs) (RestoreResult, error) {
+	if err := r.opts.Validate(); err != nil {
+		return RestoreResult{}, err
+	}
+
+	manifests := make([]Manifest, 0, len(args.SourcePaths))
+	for _, sourcePath := range args.SourcePaths {
+		manifest, err := ReadManifest(sourcePath)
+		if err != nil {
+			return RestoreResult{}, fmt.Errorf("unable to read backup manifest at %s: %v",
+				sourcePath, err)
+		}
+		manifests = append(manifests, manifest)
+	}
+
+	files, shards := r.plan(args, manifests)
+
+	prefix := r.opts.FilesystemOptions().FilePathPrefix()
+	for _, f := range files {
+		exists, err := fs.FileExists(path.Join(prefix, f.file.Path))
+		if err != nil {
+			return RestoreResult{}, err
+		}
+		if exists {
+			return RestoreResult{}, fmt.Errorf("unable to restore file %s: file already exists",
+				f.file.Path)
+		}
+	}
+
+	for _, f := range files {
+		if err := r.restoreFile(f); err != nil {
+			return RestoreResult{}, err
+		}
+	}
+
+	return RestoreResult{
+		NumFiles: len(files),
+		Shards:   shards,
+	}, nil
+}
+
+// plan returns the files to restore, ordered such that checkpoint files are
+// restored last, along with the shards restored for each namespace.
+func (r *restorer) plan(
+	args RestoreArgs,
+	manifests []Manifest,
+) ([]restoreFile, map[string][]uint32) {
+	var (
+		namespaces = make(map[string]struct{}, len(args.Namespaces))
+		shards     = make(map[uint32]struct{}, len(args.Shards))
+		restored   = make(map[string]map[uint32]int)
+		files      []restoreFile
+	)
+	for _, ns := range args.Namespaces {
+		namespaces[ns.String()] = struct{}{}
+	}
+	for _, shard := range args.Shards {
+		shards[shard] = struct{}{}
+	}
+
+	// Restore each shard from the first backup that contains it.
+	for i, manifest := range manifests {
+		for _, ns := range manifest.Namespaces {
+			if _, ok := namespaces[ns.ID]; len(namespaces) > 0 && !ok {
+				continue
+			}
+			if _, ok := restored[ns.ID]; !ok {
+				restored[ns.ID] = make(map[uint32]int)
+			}
+			for _, shard := range ns.Shards {
+				if _, ok := shards[shard.Shard]; len(shards) > 0 && !ok {
+					continue
+				}
+				if _, ok := restored[ns.ID][shard.Shard]; ok {
+					continue
+				}
+				restored[ns.ID][shard.Shard] = i
+				for _, file := range shard.Files {
+					files = append(files, restoreFile{sourcePath: args.SourcePaths[i], file: file})
+				}
+			}
+		}
+	}
+
+	// Index filesets cover every shard of the backed up node, so they can only be
+	// restored when the node is restored with exactly the shards of a single backup.
+	// Otherwise the index is rebuilt from the restored data filesets when bootstrapping.
+	for i, manifest := range manifests {
+		for _, ns := range manifest.Namespaces {
+			restoredShards, ok := restored[ns.ID]
+			if !ok || len(restoredShards) != len(ns.Shards) {
+				continue
+			}
+			restoreIndex := true
+			for _, shard := range ns.Shards {
+				if idx, ok := restoredShards[shard.Shard]; !ok || idx != i {
+					restoreIndex = false
+					break
+				}
+			}
+			if !restoreIndex {
+				continue
+			}
+			for _, file := range ns.IndexFiles {
+				files = append(files, restoreFile{sourcePath: args.SourcePaths[i], file: file})
+			}
+		}
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		return !isCheckpointFile(files[i].file.Path) && isCheckpointFile(files[j].file.Path)
+	})
+
+	result := make(map[string][]uint32, len(restored))
+	for ns, restoredShards := range restored {
+		if len(restoredShards) == 0 {
+			continue
+		}
+		nsShards := make([]uint32, 0, len(restoredShards))
+		for shard := range restoredShards {
+			nsShards = append(nsShards, shard)
+		}
+		sort.Slice(nsShards, func(i, j int) bool {
+			return nsShards[i] < nsShards[j]
+		})
+		result[ns] = nsShards
+	}
+	return files, result
+}
+
+func (r *restorer) restoreFile(f restoreFile) error {
+	var (
+		fsOpts   = r.opts.FilesystemOptions()
+		destPath = path.Join(fsOpts.FilePathPrefix(), f.file.Path)
+	)
+	srcFile, err := os.Open(path.Join(f.sourcePath, f.file.Path))
+	if err != nil {
+		return err
+	}
+	defer srcFile.Close()
+
+	reader := digest.NewReaderWithDigest(srcFile)
+	size, err := writeFile(destPath, reader, fsOpts)
+	if err == nil && size != f.file.Size {
+		err = fmt.Errorf("restored %d bytes but expected %d bytes", size, f.file.Size)
+	}
+	if err == nil {
+		err = reader.Validate(f.file.Digest)
+	}
+	if err != nil {
+		// Remove the invalid file so that it is never mistaken for a restored file.
+		os.Remove(destPath)
+		return fmt.Errorf("unable to restore file %s: %v", f.file.Path, err)
+	}
+	return nil
+}
+
+func isCheckpointFile(filePath string) bool {
+	return strings.HasSuffix(filePath, checkpointFileNameSuffix)
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package backup
+
+import (
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3x/ident"
+)
+
+// Manifest describes the contents of a backup, every file is recorded with
+// its path relative to the root of the backup along with its size and digest.
+type Manifest struct {
+	Version       int                 `json:"version"`
+	CreatedAt     time.Time           `json:"createdAt"`
+	SnapshotIndex int64               `json:"snapshotIndex"`
+	SnapshotUUID  string              `json:"snapshotUUID"`
+	SnapshotFiles []FileManifest      `json:"snapshotFiles"`
+	Namespaces    []NamespaceManifest `json:"namespaces"`
+}
+
+// NamespaceManifest describes the files backed up for a namespace.
+type NamespaceManifest struct {
+	ID         string          `json:"id"`
+	Shards     []ShardManifest `json:"shards"`
+	IndexFiles []FileManifest  `json:"indexFiles"`
+}
+
+// ShardManifest describes the data and snapshot fileset files backed up for a shard.
+type ShardManifest struct {
+	Shard uint32         `json:"shard"`
+	Files []FileManifest `json:"files"`
+}
+
+// FileManifest describes a single backed up file.
+type FileManifest struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	Digest uint32 `json:"digest"`
+}
+
+// NamespaceShards is a namespace along with the shards to backup for it.
+type NamespaceShards struct {
+	Namespace ident.ID
+	Shards    []uint32
+}
+
+// BackupArgs are the arguments for a backup.
+type BackupArgs struct {
+	// DestinationPath is the directory the backup is written to, it must not
+	// already contain a backup.
+	DestinationPath string
+
+	// Snapshot identifies the snapshot metadata of the snapshot taken for the backup.
+	Snapshot fs.SnapshotMetadataIdentifier
+
+	// Namespaces are the namespaces and shards to backup.
+	Namespaces []NamespaceShards
+}
+
+// Backuper copies the filesets of a node to a backup destination.
+type Backuper interface {
+	// Backup copies the complete data filesets, including those only present
+	// in the object store, index filesets and the latest snapshot filesets of
+	// the given namespaces and shards, along with the snapshot metadata, to the
+	// destination and returns the written manifest.
+	Backup(args BackupArgs) (Manifest, error)
+}
+
+// RestoreArgs are the arguments for a restore.
+type RestoreArgs struct {
+	// SourcePaths are the directories of the backups to restore from, typically
+	// one per node of the cluster that was backed up.
+	SourcePaths []string
+
+	// Namespaces restricts the namespaces restored, all namespaces are restored if empty.
+	Namespaces []ident.ID
+
+	// Shards restricts the shards restored, all shards are restored if empty.
+	Shards []uint32
+}
+
+// RestoreResult is the result of a restore.
+type RestoreResult struct {
+	// NumFiles is the number of files restored.
+	NumFiles int
+
+	// Shards is the shards restored for each namespace.
+	Shards map[string][]uint32
+}
+
+// Restorer rehydrates the filesets of a node from one or more backups.
+type Restorer interface {
+	// Restore copies the filesets of the requested namespaces and shards from
+	// the backups to the file path prefix, verifying each file against its
+	// digest. Each shard is restored from the first backup that contains it.
+	// Snapshot metadata is not restored as commit logs are not part of a backup.
+	Restore(args RestoreArgs) (RestoreResult, error)
+}
+
+// Options represents the options for backups and restores.
+type Options interface {
+	// Validate validates the options.
+	Validate() error
+
+	// SetClockOptions sets the clock options.
+	SetClockOptions(value clock.Options) Options
+
+	// ClockOptions returns the clock options.
+	ClockOptions() clock.Options
+
+	// SetFilesystemOptions sets the filesystem options, the file path prefix is
+	// the source of backups and the destination of restores.
+	SetFilesystemOptions(value fs.Options) Options
+
+	// FilesystemOptions returns the filesystem options.
+	FilesystemOptions() fs.Options
+}
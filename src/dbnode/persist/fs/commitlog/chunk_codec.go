@@ -0,0 +1,258 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package commitlog
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/golang/snappy"
+)
+
+var (
+	errEncryptionKeyRequired  = errors.New("commit log is encrypted but no encryption key is set")
+	errEncryptedChunkTooShort = errors.New("commit log encrypted chunk is too short")
+)
+
+// CompressionType is the compression applied to commit log chunks.
+type CompressionType int
+
+const (
+	// CompressionNone writes commit log chunks uncompressed.
+	CompressionNone CompressionType = iota
+
+	// CompressionSnappy compresses each commit log chunk with snappy.
+	CompressionSnappy
+)
+
+// ValidCompressionTypes returns the valid commit log compression types.
+func ValidCompressionTypes() []CompressionType {
+	return []CompressionType{CompressionNone, CompressionSnappy}
+}
+
+func (t CompressionType) String() string {
+	switch t {
+	case CompressionNone:
+		return "none"
+	case CompressionSnappy:
+		return "snappy"
+	}
+	return "unknown"
+}
+
+// ParseCompressionType parses a CompressionType from a string.
+func ParseCompressionType(str string) (CompressionType, error) {
+	for _, valid := range ValidCompressionTypes() {
+		if str == valid.String() {
+			return valid, nil
+		}
+	}
+	return CompressionNone, fmt.Errorf(
+		"invalid commit log compression type '%s' valid types are: %v",
+		str, ValidCompressionTypes())
+}
+
+// UnmarshalYAML unmarshals a CompressionType into a valid type from string.
+func (t *CompressionType) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+	if str == "" {
+		*t = CompressionNone
+		return nil
+	}
+	v, err := ParseCompressionType(str)
+	if err != nil {
+		return err
+	}
+	*t = v
+	return nil
+}
+
+// MarshalYAML marshals a CompressionType as a string.
+func (t CompressionType) MarshalYAML() (interface{}, error) {
+	return t.String(), nil
+}
+
+// EncryptionType is the encryption applied to commit log chunks.
+type EncryptionType int
+
+const (
+	// EncryptionNone writes commit log chunks unencrypted.
+	EncryptionNone EncryptionType = iota
+
+	// EncryptionAESGCM encrypts each commit log chunk with AES-GCM using
+	// a random nonce that is stored at the start of the chunk.
+	EncryptionAESGCM
+)
+
+func (t EncryptionType) String() string {
+	switch t {
+	case EncryptionNone:
+		return "none"
+	case EncryptionAESGCM:
+		return "aes-gcm"
+	}
+	return "unknown"
+}
+
+// ReadEncryptionKeyFile reads a hex encoded commit log encryption key from a
+// file, the key must decode to 16, 24 or 32 bytes to select AES-128, AES-192
+// or AES-256.
+func ReadEncryptionKeyFile(filePath string) ([]byte, error) {
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	key, err := hex.DecodeString(string(bytes.TrimSpace(data)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid commit log encryption key file %s: %v", filePath, err)
+	}
+	switch len(key) {
+	case 16, 24, 32:
+	default:
+		return nil, errEncryptionKeyLength
+	}
+	return key, nil
+}
+
+// chunkCodec compresses and then encrypts the chunks of a commit log, the
+// info at the start of a commit log records the compression and encryption
+// used so that readers can construct the same codec to decode the chunks.
+type chunkCodec struct {
+	compression CompressionType
+	aead        cipher.AEAD
+	compressBuf []byte
+	decryptBuf  []byte
+}
+
+// newChunkCodec returns a codec for the compression and encryption, or nil
+// if chunks are written as is.
+func newChunkCodec(
+	compression CompressionType,
+	encryption EncryptionType,
+	key []byte,
+) (*chunkCodec, error) {
+	switch compression {
+	case CompressionNone, CompressionSnappy:
+	default:
+		return nil, fmt.Errorf("unknown commit log compression type: %d", compression)
+	}
+
+	c := &chunkCodec{compression: compression}
+	switch encryption {
+	case EncryptionNone:
+	case EncryptionAESGCM:
+		if len(key) == 0 {
+			return nil, errEncryptionKeyRequired
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		c.aead, err = cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown commit log encryption type: %d", encryption)
+	}
+
+	if c.compression == CompressionNone && c.aead == nil {
+		return nil, nil
+	}
+	return c, nil
+}
+
+// encode appends the encoded chunk to dst.
+func (c *chunkCodec) encode(dst, p []byte) ([]byte, error) {
+	data := p
+	if c.compression == CompressionSnappy {
+		c.compressBuf = snappy.Encode(c.compressBuf[:cap(c.compressBuf)], p)
+		data = c.compressBuf
+	}
+
+	if c.aead == nil {
+		return append(dst, data...), nil
+	}
+
+	start := len(dst)
+	nonceSize := c.aead.NonceSize()
+	for i := 0; i < nonceSize; i++ {
+		dst = append(dst, 0)
+	}
+	nonce := dst[start : start+nonceSize]
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return c.aead.Seal(dst, nonce, data, nil), nil
+}
+
+// decode returns the decoded chunk, using dst as the target if it has
+// enough capacity.
+func (c *chunkCodec) decode(dst, p []byte) ([]byte, error) {
+	data := p
+	if c.aead != nil {
+		nonceSize := c.aead.NonceSize()
+		if len(p) < nonceSize {
+			return nil, errEncryptedChunkTooShort
+		}
+		var err error
+		c.decryptBuf, err = c.aead.Open(c.decryptBuf[:0], p[:nonceSize], p[nonceSize:], nil)
+		if err != nil {
+			return nil, err
+		}
+		data = c.decryptBuf
+	}
+
+	if c.compression == CompressionSnappy {
+		return snappy.Decode(dst[:cap(dst)], data)
+	}
+	return append(dst[:0], data...), nil
+}
+
+// chunkEncodingWriter encodes each chunk flushed by the writer buffer with
+// the chunk codec before passing it to the chunk writer.
+type chunkEncodingWriter struct {
+	codec *chunkCodec
+	next  io.Writer
+	buff  []byte
+}
+
+func (w *chunkEncodingWriter) Write(p []byte) (int, error) {
+	var err error
+	w.buff, err = w.codec.encode(w.buff[:0], p)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := w.next.Write(w.buff); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
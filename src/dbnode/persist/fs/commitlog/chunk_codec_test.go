@@ -0,0 +1,120 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package commitlog
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunkCodecRoundTrip(t *testing.T) {
+	key := bytes.Repeat([]byte{0x1}, 16)
+	data := bytes.Repeat([]byte("commit log chunk "), 128)
+
+	for _, tc := range []struct {
+		compression CompressionType
+		encryption  EncryptionType
+	}{
+		{CompressionSnappy, EncryptionNone},
+		{CompressionNone, EncryptionAESGCM},
+		{CompressionSnappy, EncryptionAESGCM},
+	} {
+		codec, err := newChunkCodec(tc.compression, tc.encryption, key)
+		require.NoError(t, err)
+		require.NotNil(t, codec)
+
+		encoded, err := codec.encode(nil, data)
+		require.NoError(t, err)
+		require.NotEqual(t, data, encoded)
+
+		decoded, err := codec.decode(nil, encoded)
+		require.NoError(t, err)
+		require.Equal(t, data, decoded)
+	}
+}
+
+func TestChunkCodecNoneIsNil(t *testing.T) {
+	codec, err := newChunkCodec(CompressionNone, EncryptionNone, nil)
+	require.NoError(t, err)
+	require.Nil(t, codec)
+}
+
+func TestChunkCodecEncryptionRequiresKey(t *testing.T) {
+	_, err := newChunkCodec(CompressionNone, EncryptionAESGCM, nil)
+	require.Equal(t, errEncryptionKeyRequired, err)
+}
+
+func TestChunkCodecDecodeWithWrongKeyFails(t *testing.T) {
+	codec, err := newChunkCodec(CompressionSnappy, EncryptionAESGCM,
+		bytes.Repeat([]byte{0x1}, 32))
+	require.NoError(t, err)
+	encoded, err := codec.encode(nil, []byte("foo"))
+	require.NoError(t, err)
+
+	other, err := newChunkCodec(CompressionSnappy, EncryptionAESGCM,
+		bytes.Repeat([]byte{0x2}, 32))
+	require.NoError(t, err)
+	_, err = other.decode(nil, encoded)
+	require.Error(t, err)
+
+	_, err = other.decode(nil, encoded[:4])
+	require.Equal(t, errEncryptedChunkTooShort, err)
+}
+
+func TestReadEncryptionKeyFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "commitlog-key")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	key := bytes.Repeat([]byte{0xab}, 32)
+	filePath := path.Join(dir, "key")
+	require.NoError(t, ioutil.WriteFile(filePath,
+		[]byte(hex.EncodeToString(key)+"\n"), 0600))
+
+	result, err := ReadEncryptionKeyFile(filePath)
+	require.NoError(t, err)
+	require.Equal(t, key, result)
+
+	require.NoError(t, ioutil.WriteFile(filePath, []byte("abcd"), 0600))
+	_, err = ReadEncryptionKeyFile(filePath)
+	require.Equal(t, errEncryptionKeyLength, err)
+
+	require.NoError(t, ioutil.WriteFile(filePath, []byte("not hex"), 0600))
+	_, err = ReadEncryptionKeyFile(filePath)
+	require.Error(t, err)
+}
+
+func TestParseCompressionType(t *testing.T) {
+	for _, valid := range ValidCompressionTypes() {
+		v, err := ParseCompressionType(valid.String())
+		require.NoError(t, err)
+		require.Equal(t, valid, v)
+	}
+
+	_, err := ParseCompressionType("zstd")
+	require.Error(t, err)
+}
@@ -22,6 +22,7 @@ package commitlog
 
 import (
 	"bufio"
+	"io"
 	"os"
 
 	"github.com/m3db/m3/src/dbnode/digest"
@@ -41,6 +42,13 @@ type chunkReader struct {
 	buffer    *bufio.Reader
 	remaining int
 	charBuff  []byte
+
+	// codec is set when chunks are compressed or encrypted, in which case
+	// each chunk is decoded in full and reads are served from decoded.
+	codec         *chunkCodec
+	encoded       []byte
+	decoded       []byte
+	decodedOffset int
 }
 
 func newChunkReader(bufferLen int) *chunkReader {
@@ -54,6 +62,15 @@ func (r *chunkReader) reset(fd *os.File) {
 	r.fd = fd
 	r.buffer.Reset(fd)
 	r.remaining = 0
+	r.codec = nil
+	r.decoded = r.decoded[:0]
+	r.decodedOffset = 0
+}
+
+// setCodec sets the codec used to decode the chunks that follow, it must
+// only be called at a chunk boundary.
+func (r *chunkReader) setCodec(codec *chunkCodec) {
+	r.codec = codec
 }
 
 func (r *chunkReader) readHeader() error {
@@ -80,6 +97,10 @@ func (r *chunkReader) readHeader() error {
 		return err
 	}
 
+	if r.codec != nil {
+		return r.readEncodedChunk(int(size), checksumData)
+	}
+
 	// Verify data checksum
 	data, err := r.buffer.Peek(int(size))
 	if err != nil {
@@ -96,6 +117,44 @@ func (r *chunkReader) readHeader() error {
 	return nil
 }
 
+func (r *chunkReader) readEncodedChunk(size int, checksumData uint32) error {
+	// Encoded chunks can be larger than the buffer so read them in full
+	// rather than peeking.
+	if cap(r.encoded) < size {
+		r.encoded = make([]byte, size)
+	}
+	r.encoded = r.encoded[:size]
+	if _, err := io.ReadFull(r.buffer, r.encoded); err != nil {
+		return err
+	}
+
+	// Verify data checksum, which is taken over the encoded chunk
+	if digest.Checksum(r.encoded) != checksumData {
+		return errCommitLogReaderChunkSizeChecksumMismatch
+	}
+
+	decoded, err := r.codec.decode(r.decoded, r.encoded)
+	if err != nil {
+		return err
+	}
+
+	// Set remaining data to be consumed
+	r.decoded = decoded
+	r.decodedOffset = 0
+	r.remaining = len(decoded)
+
+	return nil
+}
+
+func (r *chunkReader) readRemaining(p []byte) (int, error) {
+	if r.codec == nil {
+		return r.buffer.Read(p)
+	}
+	n := copy(p, r.decoded[r.decodedOffset:])
+	r.decodedOffset += n
+	return n, nil
+}
+
 func (r *chunkReader) Read(p []byte) (int, error) {
 	size := len(p)
 	read := 0
@@ -103,7 +162,7 @@ func (r *chunkReader) Read(p []byte) (int, error) {
 	if r.remaining < size {
 		// Copy any remaining
 		if r.remaining > 0 {
-			n, err := r.buffer.Read(p[:r.remaining])
+			n, err := r.readRemaining(p[:r.remaining])
 			r.remaining -= n
 			read += n
 			if err != nil {
@@ -125,7 +184,7 @@ func (r *chunkReader) Read(p []byte) (int, error) {
 		return read, err
 	}
 
-	n, err := r.buffer.Read(p)
+	n, err := r.readRemaining(p)
 	r.remaining -= n
 	read += n
 	return read, err
@@ -21,6 +21,7 @@
 package commitlog
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
 	"math/rand"
@@ -369,6 +370,59 @@ func TestCommitLogWrite(t *testing.T) {
 	assertCommitLogWritesByIterating(t, commitLog, writes)
 }
 
+func TestCommitLogWriteCompressedAndEncryptedMixedWithPlain(t *testing.T) {
+	opts, scope := newTestOptions(t, overrides{
+		strategy: StrategyWriteWait,
+	})
+	defer cleanup(t, opts)
+
+	var writes []testWrite
+	newWrites := func(prefix string) []testWrite {
+		var result []testWrite
+		for i := 0; i < 200; i++ {
+			id := fmt.Sprintf("%s.%d", prefix, i)
+			tags := ident.NewTags(ident.StringTag("name", id))
+			result = append(result, testWrite{
+				testSeries(uint64(len(writes)+i), id, tags, uint32(i)),
+				time.Now(), float64(i), xtime.Second, []byte{1, 2, 3}, nil,
+			})
+		}
+		return result
+	}
+
+	// Write a plain commit log
+	plainWrites := newWrites("plain")
+	commitLog := newTestCommitLog(t, opts)
+	writeCommitLogs(t, scope, commitLog, plainWrites).Wait()
+	require.NoError(t, commitLog.Close())
+	writes = append(writes, plainWrites...)
+
+	// Write a compressed and encrypted commit log to the same directory
+	key := bytes.Repeat([]byte{0x42}, 32)
+	opts = opts.SetCompression(CompressionSnappy).SetEncryptionKey(key)
+	encodedWrites := newWrites("encoded")
+	commitLogI, err := NewCommitLog(opts)
+	require.NoError(t, err)
+	commitLog = commitLogI.(*commitLog)
+	require.NoError(t, commitLog.Open())
+	writeCommitLogs(t, scope, commitLog, encodedWrites).Wait()
+	require.NoError(t, commitLog.Close())
+	writes = append(writes, encodedWrites...)
+
+	files, corruptFiles, err := Files(opts)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(corruptFiles))
+	require.Equal(t, 2, len(files))
+
+	// Both commit logs are read back with the same options
+	assertCommitLogWritesByIterating(t, commitLog, writes)
+
+	// The encrypted commit log cannot be read without the key
+	r := newCommitLogReader(opts.SetEncryptionKey(nil), ReadAllSeriesPredicate())
+	_, _, _, err = r.Open(files[1].FilePath)
+	require.Equal(t, errEncryptionKeyRequired, err)
+}
+
 func TestReadCommitLogMissingMetadata(t *testing.T) {
 	readConc := 4
 	// Make sure we're not leaking goroutines
@@ -67,6 +67,7 @@ var (
 	errBlockSizePositive        = errors.New("block size must be a positive duration")
 	errReadConcurrencyPositive  = errors.New("read concurrency must be a positive integer")
 	errBacklogQueueChannelSize  = errors.New("read concurrency must be a positive integer")
+	errEncryptionKeyLength      = errors.New("encryption key must be 16, 24 or 32 bytes")
 )
 
 type options struct {
@@ -82,6 +83,8 @@ type options struct {
 	bytesPool               pool.CheckedBytesPool
 	identPool               ident.Pool
 	readConcurrency         int
+	compression             CompressionType
+	encryptionKey           []byte
 }
 
 // NewOptions creates new commit log options
@@ -125,6 +128,16 @@ func (o *options) Validate() error {
 			MaximumQueueSizeQueueChannelSizeRatio, float64(o.BacklogQueueSize())/float64(o.BacklogQueueChannelSize()))
 	}
 
+	switch len(o.EncryptionKey()) {
+	case 0, 16, 24, 32:
+	default:
+		return errEncryptionKeyLength
+	}
+
+	if _, err := newChunkCodec(o.Compression(), EncryptionNone, nil); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -247,3 +260,23 @@ func (o *options) SetIdentifierPool(value ident.Pool) Options {
 func (o *options) IdentifierPool() ident.Pool {
 	return o.identPool
 }
+
+func (o *options) SetCompression(value CompressionType) Options {
+	opts := *o
+	opts.compression = value
+	return &opts
+}
+
+func (o *options) Compression() CompressionType {
+	return o.compression
+}
+
+func (o *options) SetEncryptionKey(value []byte) Options {
+	opts := *o
+	opts.encryptionKey = value
+	return &opts
+}
+
+func (o *options) EncryptionKey() []byte {
+	return o.encryptionKey
+}
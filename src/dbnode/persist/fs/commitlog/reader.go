@@ -177,6 +177,17 @@ func (r *reader) Open(filePath string) (time.Time, time.Duration, int64, error)
 		r.Close()
 		return timeZero, 0, 0, err
 	}
+
+	// The chunks following the info are encoded as described by the info,
+	// commit logs written before compression and encryption were supported
+	// have no codec and are read as is.
+	codec, err := newChunkCodec(CompressionType(info.Compression),
+		EncryptionType(info.Encryption), r.opts.EncryptionKey())
+	if err != nil {
+		r.Close()
+		return timeZero, 0, 0, err
+	}
+	r.chunkReader.setCodec(codec)
 	start := time.Unix(0, info.Start)
 	duration := time.Duration(info.Duration)
 	index := info.Index
@@ -179,6 +179,22 @@ type Options interface {
 
 	// IdentifierPool returns the IdentifierPool to use for pooling identifiers.
 	IdentifierPool() ident.Pool
+
+	// SetCompression sets the compression used for new commit logs.
+	SetCompression(value CompressionType) Options
+
+	// Compression returns the compression used for new commit logs.
+	Compression() CompressionType
+
+	// SetEncryptionKey sets the AES key used to encrypt new commit logs and
+	// decrypt encrypted commit logs, new commit logs are not encrypted when
+	// no key is set.
+	SetEncryptionKey(value []byte) Options
+
+	// EncryptionKey returns the AES key used to encrypt new commit logs and
+	// decrypt encrypted commit logs, new commit logs are not encrypted when
+	// no key is set.
+	EncryptionKey() []byte
 }
 
 // FileFilterPredicate is a predicate that allows the caller to determine
@@ -113,6 +113,10 @@ type writer struct {
 	metadataEncoderBuff []byte
 	tagEncoder          serialize.TagEncoder
 	tagSliceIter        ident.TagsIterator
+	compression         CompressionType
+	encryption          EncryptionType
+	encryptionKey       []byte
+	encodingWriter      *chunkEncodingWriter
 }
 
 func newCommitLogWriter(
@@ -121,6 +125,11 @@ func newCommitLogWriter(
 ) commitLogWriter {
 	shouldFsync := opts.Strategy() == StrategyWriteWait
 
+	encryption := EncryptionNone
+	if len(opts.EncryptionKey()) > 0 {
+		encryption = EncryptionAESGCM
+	}
+
 	return &writer{
 		filePathPrefix:      opts.FilesystemOptions().FilePathPrefix(),
 		newFileMode:         opts.FilesystemOptions().NewFileMode(),
@@ -136,6 +145,9 @@ func newCommitLogWriter(
 		metadataEncoderBuff: make([]byte, 0, defaultEncoderBuffSize),
 		tagEncoder:          opts.FilesystemOptions().TagEncoderPool().Get(),
 		tagSliceIter:        ident.NewTagsIterator(ident.Tags{}),
+		compression:         opts.Compression(),
+		encryption:          encryption,
+		encryptionKey:       opts.EncryptionKey(),
 	}
 }
 
@@ -153,6 +165,19 @@ func (w *writer) Open(start time.Time, duration time.Duration) (File, error) {
 		w.metadataEncoderBuff = make([]byte, 0, defaultEncoderBuffSize)
 	}
 
+	if w.encodingWriter == nil {
+		codec, err := newChunkCodec(w.compression, w.encryption, w.encryptionKey)
+		if err != nil {
+			return File{}, err
+		}
+		if codec != nil {
+			w.encodingWriter = &chunkEncodingWriter{
+				codec: codec,
+				next:  w.chunkWriter,
+			}
+		}
+	}
+
 	commitLogsDir := fs.CommitLogsDirPath(w.filePathPrefix)
 	if err := os.MkdirAll(commitLogsDir, w.newDirectoryMode); err != nil {
 		return File{}, err
@@ -163,9 +188,11 @@ func (w *writer) Open(start time.Time, duration time.Duration) (File, error) {
 		return File{}, err
 	}
 	logInfo := schema.LogInfo{
-		Start:    start.UnixNano(),
-		Duration: int64(duration),
-		Index:    int64(index),
+		Start:       start.UnixNano(),
+		Duration:    int64(duration),
+		Index:       int64(index),
+		Compression: int64(w.compression),
+		Encryption:  int64(w.encryption),
 	}
 	w.logEncoder.Reset()
 	if err := w.logEncoder.EncodeLogInfo(logInfo); err != nil {
@@ -182,6 +209,16 @@ func (w *writer) Open(start time.Time, duration time.Duration) (File, error) {
 		w.Close()
 		return File{}, err
 	}
+	if w.encodingWriter != nil {
+		// The log info is always written as a plain chunk of its own so that
+		// readers can decode it to find out how the rest of the chunks are
+		// encoded.
+		if err := w.buffer.Flush(); err != nil {
+			w.Close()
+			return File{}, err
+		}
+		w.buffer.Reset(w.encodingWriter)
+	}
 
 	w.start = start
 	w.duration = duration
@@ -0,0 +1,510 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/schema"
+	"github.com/m3db/m3/src/dbnode/storage/namespace"
+	"github.com/m3db/m3x/checked"
+	xerrors "github.com/m3db/m3x/errors"
+	"github.com/m3db/m3x/ident"
+	xtime "github.com/m3db/m3x/time"
+)
+
+const (
+	// compactionStagingDirName is the directory beneath the file path prefix
+	// that compacted filesets are written to before they are installed.
+	compactionStagingDirName = "compaction"
+
+	// subBlockEntryLenBytes is the number of bytes used to describe each sub-block
+	// of a series in a compacted fileset, the size of the sub-block data followed by
+	// its checksum, both as big endian uint32s.
+	subBlockEntryLenBytes = 8
+)
+
+var (
+	errWriterNotOpenedWithSubBlockSize = errors.New("writer was not opened with a sub-block size")
+	errWriterSubBlocksMismatch         = errors.New("number of sub-blocks does not match the block size")
+	errSubBlocksInvalid                = errors.New("index entry sub-blocks are invalid")
+	errSubBlockStartInvalid            = errors.New("sub-block start is not a sub-block of the fileset")
+	errFileSetNotCompacted             = errors.New("fileset is not compacted")
+)
+
+// CompactBlockStartBefore returns the time before which the flushed data filesets of
+// a namespace with compaction enabled are eligible to be compacted at the given time.
+func CompactBlockStartBefore(nsOpts namespace.Options, t time.Time) time.Time {
+	return t.Add(-nsOpts.CompactionOptions().CompactAfter())
+}
+
+// CompactDataFileSets merges the flushed data filesets for the given namespace and shard
+// into compacted filesets of the compaction block size, each of which holds a sub-block
+// per block of the namespace block size. Only groups of filesets that end before the
+// given time are compacted. Compacted filesets are written to a staging directory and
+// then moved into place before the filesets they replace are deleted, so that a failed
+// compaction can be completed the next time this is called. Returns the number of
+// filesets that were removed.
+func CompactDataFileSets(
+	opts Options,
+	namespace ident.ID,
+	shard uint32,
+	compactBefore time.Time,
+	blockSize time.Duration,
+	subBlockSize time.Duration,
+) (int, error) {
+	if err := validateSubBlockSize(blockSize, subBlockSize); err != nil {
+		return 0, err
+	}
+
+	// Complete the installation of any compacted filesets that were fully staged
+	// by a compaction that failed before they were moved into place.
+	staged, err := DataFiles(compactionStagingPathPrefix(opts.FilePathPrefix()), namespace, shard)
+	if err != nil {
+		return 0, err
+	}
+	for _, fileset := range staged {
+		if !fileset.HasCheckpointFile() {
+			continue
+		}
+		err := installStagedDataFileSet(opts, compactionStagingPathPrefix(opts.FilePathPrefix()),
+			namespace, shard, fileset.ID.BlockStart)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	var (
+		removed  int
+		multiErr = xerrors.NewMultiError()
+	)
+	// Groups that could be read are still compacted if others could not be.
+	groups, err := compactionGroups(opts, namespace, shard, blockSize, subBlockSize)
+	multiErr = multiErr.Add(err)
+	for _, group := range groups {
+		if group.start.Add(blockSize).After(compactBefore) {
+			continue
+		}
+
+		if !group.compacted {
+			if len(group.blockStarts) < 2 {
+				// Nothing to be gained from rewriting a single fileset.
+				continue
+			}
+			err := compactDataFileSetGroup(opts, namespace, shard, group, blockSize, subBlockSize)
+			if err != nil {
+				multiErr = multiErr.Add(fmt.Errorf(
+					"failed to compact filesets for shard %d at %s: %v", shard, group.start, err))
+				continue
+			}
+		}
+
+		// Remove the filesets that are now contained in the compacted fileset, this
+		// also removes any left behind by a compaction that failed part way through.
+		for _, blockStart := range group.blockStarts {
+			if blockStart.Equal(group.start) {
+				continue
+			}
+			err := deleteDataFileSetAt(opts.FilePathPrefix(), namespace, shard, blockStart)
+			if err != nil {
+				multiErr = multiErr.Add(err)
+				continue
+			}
+			removed++
+		}
+	}
+
+	return removed, multiErr.FinalError()
+}
+
+type compactionGroup struct {
+	start       time.Time
+	compacted   bool
+	blockStarts []time.Time
+}
+
+// compactionGroups returns the flushed data filesets grouped by the start of the
+// compacted block that they belong to in ascending order. Groups that contain
+// filesets with unreadable info files or unexpected block sizes are omitted.
+func compactionGroups(
+	opts Options,
+	namespace ident.ID,
+	shard uint32,
+	blockSize time.Duration,
+	subBlockSize time.Duration,
+) ([]compactionGroup, error) {
+	var (
+		groupsByStart = make(map[xtime.UnixNano]*compactionGroup)
+		invalid       = make(map[xtime.UnixNano]struct{})
+		multiErr      = xerrors.NewMultiError()
+	)
+	infoFiles := ReadInfoFiles(opts.FilePathPrefix(), namespace, shard,
+		opts.InfoReaderBufferSize(), opts.DecodingOptions())
+	for _, result := range infoFiles {
+		if err := result.Err.Error(); err != nil {
+			multiErr = multiErr.Add(fmt.Errorf(
+				"unable to read info file %s: %v", result.Err.Filepath(), err))
+			if blockStart, err := TimeFromFileName(path.Base(result.Err.Filepath())); err == nil {
+				invalid[xtime.ToUnixNano(blockStart.Truncate(blockSize))] = struct{}{}
+			}
+			continue
+		}
+
+		var (
+			info       = result.Info
+			blockStart = xtime.FromNanoseconds(info.BlockStart)
+			start      = blockStart.Truncate(blockSize)
+			key        = xtime.ToUnixNano(start)
+		)
+		group, ok := groupsByStart[key]
+		if !ok {
+			group = &compactionGroup{start: start}
+			groupsByStart[key] = group
+		}
+		group.blockStarts = append(group.blockStarts, blockStart)
+
+		switch {
+		case info.SubBlockSize > 0 && blockStart.Equal(start) &&
+			time.Duration(info.BlockSize) == blockSize &&
+			time.Duration(info.SubBlockSize) == subBlockSize:
+			group.compacted = true
+		case info.SubBlockSize == 0 && time.Duration(info.BlockSize) == subBlockSize:
+		default:
+			// Written with different block sizes, leave the group untouched.
+			invalid[key] = struct{}{}
+		}
+	}
+
+	groups := make([]compactionGroup, 0, len(groupsByStart))
+	for key, group := range groupsByStart {
+		if _, ok := invalid[key]; ok {
+			continue
+		}
+		groups = append(groups, *group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].start.Before(groups[j].start)
+	})
+	return groups, multiErr.FinalError()
+}
+
+func compactDataFileSetGroup(
+	opts Options,
+	namespace ident.ID,
+	shard uint32,
+	group compactionGroup,
+	blockSize time.Duration,
+	subBlockSize time.Duration,
+) error {
+	var (
+		numSubBlocks = int(blockSize / subBlockSize)
+		seekers      = make([]DataFileSetSeeker, numSubBlocks)
+	)
+	defer func() {
+		for _, seeker := range seekers {
+			if seeker != nil {
+				seeker.Close()
+			}
+		}
+	}()
+
+	series := make(map[string]compactionSeries)
+	for _, blockStart := range group.blockStarts {
+		idx := int(blockStart.Sub(group.start) / subBlockSize)
+		if err := readCompactionSeries(opts, namespace, shard, blockStart, series); err != nil {
+			return err
+		}
+
+		seeker := NewSeeker(opts.FilePathPrefix(), opts.DataReaderBufferSize(),
+			opts.InfoReaderBufferSize(), opts.SeekReaderBufferSize(), nil, false,
+			opts.DecodingOptions(), opts)
+		if err := seeker.Open(namespace, shard, blockStart); err != nil {
+			return err
+		}
+		seekers[idx] = seeker
+	}
+
+	ids := make([]string, 0, len(series))
+	for id := range series {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	writer, err := NewWriter(opts.SetFilePathPrefix(compactionStagingPathPrefix(opts.FilePathPrefix())))
+	if err != nil {
+		return err
+	}
+	err = writer.Open(DataWriterOpenOptions{
+		Identifier: FileSetFileIdentifier{
+			Namespace:  namespace,
+			Shard:      shard,
+			BlockStart: group.start,
+		},
+		BlockSize:    blockSize,
+		SubBlockSize: subBlockSize,
+		FileSetType:  persist.FileSetFlushType,
+	})
+	if err != nil {
+		return err
+	}
+
+	var (
+		data      = make([]checked.Bytes, numSubBlocks)
+		checksums = make([]uint32, numSubBlocks)
+	)
+	for _, id := range ids {
+		s := series[id]
+		for i, seeker := range seekers {
+			data[i], checksums[i] = nil, 0
+			if seeker == nil {
+				continue
+			}
+			entry, err := seeker.SeekIndexEntry(s.id)
+			if err == errSeekIDNotFound {
+				continue
+			}
+			if err != nil {
+				writer.Close()
+				return err
+			}
+			segment, err := seeker.SeekByIndexEntry(entry)
+			if err != nil {
+				writer.Close()
+				return err
+			}
+			segment.IncRef()
+			data[i], checksums[i] = segment, entry.Checksum
+		}
+
+		err := writer.WriteSubBlocks(s.id, s.tags, data, checksums)
+		for _, segment := range data {
+			if segment != nil {
+				segment.DecRef()
+			}
+		}
+		if err != nil {
+			writer.Close()
+			return err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	return installStagedDataFileSet(opts, compactionStagingPathPrefix(opts.FilePathPrefix()),
+		namespace, shard, group.start)
+}
+
+type compactionSeries struct {
+	id   ident.ID
+	tags ident.Tags
+}
+
+// readCompactionSeries adds the ID and tags of every series in the data fileset at
+// the given block start to the series map.
+func readCompactionSeries(
+	opts Options,
+	namespace ident.ID,
+	shard uint32,
+	blockStart time.Time,
+	series map[string]compactionSeries,
+) error {
+	reader, err := NewReader(nil, opts)
+	if err != nil {
+		return err
+	}
+	err = reader.Open(DataReaderOpenOptions{
+		Identifier: FileSetFileIdentifier{
+			Namespace:  namespace,
+			Shard:      shard,
+			BlockStart: blockStart,
+		},
+		FileSetType: persist.FileSetFlushType,
+	})
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	for {
+		id, tagsIter, _, _, err := reader.ReadMetadata()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if _, ok := series[id.String()]; ok {
+			tagsIter.Close()
+			continue
+		}
+
+		var tags ident.Tags
+		for tagsIter.Next() {
+			curr := tagsIter.Current()
+			tags.Append(ident.StringTag(curr.Name.String(), curr.Value.String()))
+		}
+		err = tagsIter.Err()
+		tagsIter.Close()
+		if err != nil {
+			return err
+		}
+		series[id.String()] = compactionSeries{id: id, tags: tags}
+	}
+}
+
+// installStagedDataFileSet moves a fileset from the given staging directory into
+// place. The checkpoint file of any existing fileset is removed first and the staged
+// checkpoint file is moved last, so the fileset is never considered complete while its
+// files are being replaced. Staged files that are missing have already been moved by
+// a previous attempt.
+func installStagedDataFileSet(
+	opts Options,
+	stagingPathPrefix string,
+	namespace ident.ID,
+	shard uint32,
+	blockStart time.Time,
+) error {
+	var (
+		stagingDir = ShardDataDirPath(stagingPathPrefix, namespace, shard)
+		shardDir   = ShardDataDirPath(opts.FilePathPrefix(), namespace, shard)
+	)
+	err := os.Remove(filesetPathFromTime(shardDir, blockStart, checkpointFileSuffix))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	suffixes := append([]string{digestFileSuffix}, dataFileSetDigestedFileSuffixes...)
+	for _, suffix := range suffixes {
+		err := os.Rename(filesetPathFromTime(stagingDir, blockStart, suffix),
+			filesetPathFromTime(shardDir, blockStart, suffix))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return os.Rename(filesetPathFromTime(stagingDir, blockStart, checkpointFileSuffix),
+		filesetPathFromTime(shardDir, blockStart, checkpointFileSuffix))
+}
+
+// deleteDataFileSetAt deletes the data fileset for the given namespace, shard and
+// block start, the checkpoint file is deleted first so that the fileset is no longer
+// considered complete if deleting any other file fails.
+func deleteDataFileSetAt(
+	filePathPrefix string,
+	namespace ident.ID,
+	shard uint32,
+	blockStart time.Time,
+) error {
+	shardDir := ShardDataDirPath(filePathPrefix, namespace, shard)
+	err := os.Remove(filesetPathFromTime(shardDir, blockStart, checkpointFileSuffix))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	multiErr := xerrors.NewMultiError()
+	suffixes := append([]string{digestFileSuffix}, dataFileSetDigestedFileSuffixes...)
+	for _, suffix := range suffixes {
+		err := os.Remove(filesetPathFromTime(shardDir, blockStart, suffix))
+		if err != nil && !os.IsNotExist(err) {
+			multiErr = multiErr.Add(err)
+		}
+	}
+	return multiErr.FinalError()
+}
+
+func compactionStagingPathPrefix(filePathPrefix string) string {
+	return path.Join(filePathPrefix, compactionStagingDirName)
+}
+
+func validateSubBlockSize(blockSize, subBlockSize time.Duration) error {
+	if subBlockSize <= 0 || blockSize <= subBlockSize || blockSize%subBlockSize != 0 {
+		return fmt.Errorf("block size %s is not a multiple of sub-block size %s",
+			blockSize, subBlockSize)
+	}
+	return nil
+}
+
+// subBlockIndex returns the index of the sub-block with the given start within a
+// fileset, isSubBlock is false if the fileset is not compacted and the start is the
+// start of the fileset itself.
+func subBlockIndex(
+	start time.Time,
+	blockSize time.Duration,
+	subBlockSize time.Duration,
+	subBlockStart time.Time,
+) (idx int, isSubBlock bool, err error) {
+	if subBlockSize <= 0 {
+		if subBlockStart.Equal(start) {
+			return 0, false, nil
+		}
+		return 0, false, errFileSetNotCompacted
+	}
+
+	offset := subBlockStart.Sub(start)
+	if offset < 0 || offset >= blockSize || offset%subBlockSize != 0 {
+		return 0, false, errSubBlockStartInvalid
+	}
+	return int(offset / subBlockSize), true, nil
+}
+
+func appendSubBlock(b []byte, size uint32, checksum uint32) []byte {
+	var buf [subBlockEntryLenBytes]byte
+	binary.BigEndian.PutUint32(buf[:4], size)
+	binary.BigEndian.PutUint32(buf[4:], checksum)
+	return append(b, buf[:]...)
+}
+
+// subBlockIndexEntry returns the index entry for the sub-block at the given index of
+// an index entry from a compacted fileset, ok is false if the sub-block is empty.
+func subBlockIndexEntry(entry schema.IndexEntry, idx int) (schema.IndexEntry, bool, error) {
+	if len(entry.SubBlocks) < (idx+1)*subBlockEntryLenBytes {
+		return schema.IndexEntry{}, false, errSubBlocksInvalid
+	}
+
+	offset := entry.Offset
+	for i := 0; i < idx; i++ {
+		offset += int64(binary.BigEndian.Uint32(entry.SubBlocks[i*subBlockEntryLenBytes:]))
+	}
+
+	b := entry.SubBlocks[idx*subBlockEntryLenBytes:]
+	size := binary.BigEndian.Uint32(b)
+	if size == 0 {
+		return schema.IndexEntry{}, false, nil
+	}
+	if offset+int64(size) > entry.Offset+entry.Size {
+		return schema.IndexEntry{}, false, errSubBlocksInvalid
+	}
+
+	entry.Offset = offset
+	entry.Size = int64(size)
+	entry.Checksum = int64(binary.BigEndian.Uint32(b[4:]))
+	entry.SubBlocks = nil
+	return entry, true, nil
+}
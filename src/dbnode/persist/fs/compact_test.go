@@ -0,0 +1,259 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3x/checked"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCompactionBlockSize = 3 * testBlockSize
+
+var testCompactionStart = time.Unix(0, 0).Add(100 * testCompactionBlockSize)
+
+// testCompactionEntries are the entries written to each sub-block.
+var testCompactionEntries = [][]testEntry{
+	{
+		{"bar", nil, []byte{1, 2, 3}},
+		{"foo", map[string]string{"name": "foo"}, []byte{4, 5, 6}},
+	},
+	{
+		{"baz", nil, []byte{7, 8}},
+		{"foo", map[string]string{"name": "foo"}, []byte{9, 10, 11, 12}},
+	},
+	{
+		{"qux", nil, []byte{13}},
+	},
+}
+
+func newTestCompactionOptions(t *testing.T) (Options, string) {
+	dir, err := ioutil.TempDir("", "testcompact")
+	require.NoError(t, err)
+	return testDefaultOpts.SetFilePathPrefix(dir), dir
+}
+
+func writeTestCompactionData(t *testing.T, filePathPrefix string) {
+	for i, entries := range testCompactionEntries {
+		w := newTestWriter(t, filePathPrefix)
+		blockStart := testCompactionStart.Add(time.Duration(i) * testBlockSize)
+		writeTestData(t, w, 0, blockStart, entries, persist.FileSetFlushType)
+	}
+}
+
+func TestCompactDataFileSets(t *testing.T) {
+	opts, dir := newTestCompactionOptions(t)
+	defer os.RemoveAll(dir)
+
+	writeTestCompactionData(t, opts.FilePathPrefix())
+
+	compactBefore := testCompactionStart.Add(testCompactionBlockSize)
+	removed, err := CompactDataFileSets(opts, testNs1ID, 0, compactBefore,
+		testCompactionBlockSize, testBlockSize)
+	require.NoError(t, err)
+	require.Equal(t, 2, removed)
+
+	for i := range testCompactionEntries {
+		blockStart := testCompactionStart.Add(time.Duration(i) * testBlockSize)
+		exists, err := DataFileSetExistsAt(opts.FilePathPrefix(), testNs1ID, 0, blockStart)
+		require.NoError(t, err)
+		require.Equal(t, i == 0, exists)
+	}
+
+	infoFiles := ReadInfoFiles(opts.FilePathPrefix(), testNs1ID, 0,
+		opts.InfoReaderBufferSize(), opts.DecodingOptions())
+	require.Equal(t, 1, len(infoFiles))
+	require.NoError(t, infoFiles[0].Err.Error())
+	require.Equal(t, int64(testCompactionBlockSize), infoFiles[0].Info.BlockSize)
+	require.Equal(t, int64(testBlockSize), infoFiles[0].Info.SubBlockSize)
+
+	// Compacting again should be a no-op.
+	removed, err = CompactDataFileSets(opts, testNs1ID, 0, compactBefore,
+		testCompactionBlockSize, testBlockSize)
+	require.NoError(t, err)
+	require.Equal(t, 0, removed)
+}
+
+func TestCompactDataFileSetsOnlyBefore(t *testing.T) {
+	opts, dir := newTestCompactionOptions(t)
+	defer os.RemoveAll(dir)
+
+	writeTestCompactionData(t, opts.FilePathPrefix())
+
+	compactBefore := testCompactionStart.Add(testCompactionBlockSize - time.Nanosecond)
+	removed, err := CompactDataFileSets(opts, testNs1ID, 0, compactBefore,
+		testCompactionBlockSize, testBlockSize)
+	require.NoError(t, err)
+	require.Equal(t, 0, removed)
+
+	infoFiles := ReadInfoFiles(opts.FilePathPrefix(), testNs1ID, 0,
+		opts.InfoReaderBufferSize(), opts.DecodingOptions())
+	require.Equal(t, len(testCompactionEntries), len(infoFiles))
+}
+
+func TestCompactDataFileSetsCompletesStagedCompaction(t *testing.T) {
+	opts, dir := newTestCompactionOptions(t)
+	defer os.RemoveAll(dir)
+
+	writeTestCompactionData(t, opts.FilePathPrefix())
+
+	// Stage a compacted fileset as if a previous compaction failed before
+	// installing it.
+	w := newTestWriter(t, compactionStagingPathPrefix(opts.FilePathPrefix()))
+	require.NoError(t, w.Open(DataWriterOpenOptions{
+		Identifier: FileSetFileIdentifier{
+			Namespace:  testNs1ID,
+			Shard:      0,
+			BlockStart: testCompactionStart,
+		},
+		BlockSize:    testCompactionBlockSize,
+		SubBlockSize: testBlockSize,
+		FileSetType:  persist.FileSetFlushType,
+	}))
+	data := []byte{1, 2, 3}
+	require.NoError(t, w.WriteSubBlocks(testEntry{id: "bar"}.ID(), testEntry{}.Tags(),
+		[]checked.Bytes{bytesRefd(data), nil, nil},
+		[]uint32{digest.Checksum(data), 0, 0}))
+	require.NoError(t, w.Close())
+
+	// Only the group before the compaction boundary is eligible, but the staged
+	// fileset is installed regardless.
+	removed, err := CompactDataFileSets(opts, testNs1ID, 0, testCompactionStart,
+		testCompactionBlockSize, testBlockSize)
+	require.NoError(t, err)
+	require.Equal(t, 0, removed)
+
+	staged, err := DataFiles(compactionStagingPathPrefix(opts.FilePathPrefix()), testNs1ID, 0)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(staged))
+
+	// The sub-block filesets left behind are removed once eligible.
+	removed, err = CompactDataFileSets(opts, testNs1ID, 0,
+		testCompactionStart.Add(testCompactionBlockSize), testCompactionBlockSize, testBlockSize)
+	require.NoError(t, err)
+	require.Equal(t, 2, removed)
+
+	s := newTestSeeker(opts.FilePathPrefix())
+	require.NoError(t, s.Open(testNs1ID, 0, testCompactionStart))
+	result, err := s.SeekByID(testEntry{id: "bar"}.ID())
+	require.NoError(t, err)
+	result.IncRef()
+	assert.Equal(t, data, result.Bytes())
+	result.DecRef()
+	require.NoError(t, s.Close())
+}
+
+func TestCompactedDataFileSetSeekSubBlocks(t *testing.T) {
+	opts, dir := newTestCompactionOptions(t)
+	defer os.RemoveAll(dir)
+
+	writeTestCompactionData(t, opts.FilePathPrefix())
+	_, err := CompactDataFileSets(opts, testNs1ID, 0, testCompactionStart.Add(testCompactionBlockSize),
+		testCompactionBlockSize, testBlockSize)
+	require.NoError(t, err)
+
+	for i, entries := range testCompactionEntries {
+		subBlockStart := testCompactionStart.Add(time.Duration(i) * testBlockSize)
+		s := newTestSeeker(opts.FilePathPrefix()).(fileSetSeeker)
+		require.NoError(t, s.openSubBlock(testNs1ID, 0, testCompactionStart, subBlockStart))
+		assert.True(t, subBlockStart.Equal(s.Range().Start))
+		assert.Equal(t, testBlockSize, s.Range().End.Sub(s.Range().Start))
+
+		found := make(map[string]struct{})
+		for _, entry := range entries {
+			data, err := s.SeekByID(entry.ID())
+			require.NoError(t, err)
+
+			data.IncRef()
+			assert.Equal(t, entry.data, data.Bytes())
+			data.DecRef()
+			found[entry.id] = struct{}{}
+		}
+		for _, id := range []string{"bar", "baz", "foo", "qux"} {
+			if _, ok := found[id]; ok {
+				continue
+			}
+			_, err := s.SeekByID(testEntry{id: id}.ID())
+			assert.Equal(t, errSeekIDNotFound, err)
+		}
+		require.NoError(t, s.Close())
+	}
+
+	s := newTestSeeker(opts.FilePathPrefix()).(fileSetSeeker)
+	err = s.openSubBlock(testNs1ID, 0, testCompactionStart, testCompactionStart.Add(time.Minute))
+	assert.Equal(t, errSubBlockStartInvalid, err)
+}
+
+func TestCompactedDataFileSetReadSubBlocks(t *testing.T) {
+	opts, dir := newTestCompactionOptions(t)
+	defer os.RemoveAll(dir)
+
+	writeTestCompactionData(t, opts.FilePathPrefix())
+	_, err := CompactDataFileSets(opts, testNs1ID, 0, testCompactionStart.Add(testCompactionBlockSize),
+		testCompactionBlockSize, testBlockSize)
+	require.NoError(t, err)
+
+	for i, entries := range testCompactionEntries {
+		subBlockStart := testCompactionStart.Add(time.Duration(i) * testBlockSize)
+		r := newTestReader(t, opts.FilePathPrefix())
+		require.NoError(t, r.Open(DataReaderOpenOptions{
+			Identifier: FileSetFileIdentifier{
+				Namespace:  testNs1ID,
+				Shard:      0,
+				BlockStart: testCompactionStart,
+			},
+			FileSetType:   persist.FileSetFlushType,
+			SubBlockStart: subBlockStart,
+		}))
+		assert.True(t, subBlockStart.Equal(r.Range().Start))
+		assert.Equal(t, testBlockSize, r.Range().End.Sub(r.Range().Start))
+		require.Equal(t, len(entries), r.Entries())
+
+		read := make(map[string][]byte)
+		for {
+			id, tags, data, checksum, err := r.Read()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+
+			data.IncRef()
+			assert.Equal(t, digest.Checksum(data.Bytes()), checksum)
+			read[id.String()] = append([]byte(nil), data.Bytes()...)
+			data.DecRef()
+			tags.Close()
+		}
+		for _, entry := range entries {
+			assert.Equal(t, entry.data, read[entry.id])
+		}
+		require.NoError(t, r.Validate())
+		require.NoError(t, r.Close())
+	}
+}
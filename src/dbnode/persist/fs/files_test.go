@@ -708,6 +708,16 @@ func TestNextSnapshotMetadataFileIndex(t *testing.T) {
 	require.Equal(t, int64(numMetadataFiles), nextIdx)
 }
 
+func TestNextSnapshotMetadataFileIndexNoFiles(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	opts := testDefaultOpts.SetFilePathPrefix(dir)
+	nextIdx, err := NextSnapshotMetadataFileIndex(opts)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), nextIdx)
+}
+
 func TestNextIndexFileSetVolumeIndex(t *testing.T) {
 	// Make empty directory
 	dir := createTempDir(t)
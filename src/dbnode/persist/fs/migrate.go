@@ -0,0 +1,394 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3x/checked"
+	xerrors "github.com/m3db/m3x/errors"
+	"github.com/m3db/m3x/ident"
+	xtime "github.com/m3db/m3x/time"
+)
+
+const (
+	// migrationPendingDirName is the directory beneath the file path prefix that
+	// migrated filesets are written to while a migration of a shard is in progress.
+	migrationPendingDirName = "migration-pending"
+
+	// migrationStagingDirName is the directory beneath the file path prefix that
+	// the migrated filesets of a shard are moved to once all of them are written,
+	// and from which they are installed.
+	migrationStagingDirName = "migration"
+)
+
+var (
+	errMigrationBlockSizeInvalid = errors.New("migration block size must be positive")
+)
+
+// MigrateDataFileSets rewrites the flushed data filesets for the given namespace and
+// shard that were written with a block size other than the given block size into
+// filesets of the given block size. Series are decoded and re-encoded so that each
+// new fileset only holds the datapoints that fall within its block. The new filesets
+// of a shard are all written before any of them are moved into place and the
+// filesets they replace are only deleted once all of them are installed, so that a
+// failed migration can be completed the next time this is called. Compacted filesets
+// are left untouched. Returns the number of filesets that were migrated.
+func MigrateDataFileSets(
+	opts Options,
+	encoderPool encoding.EncoderPool,
+	iterPool encoding.MultiReaderIteratorPool,
+	namespace ident.ID,
+	shard uint32,
+	blockSize time.Duration,
+) (int, error) {
+	if blockSize <= 0 {
+		return 0, errMigrationBlockSizeInvalid
+	}
+
+	// Filesets left behind by a migration that failed while they were being
+	// written are incomplete and are discarded, those of a migration that failed
+	// while they were being installed are complete and are installed.
+	var (
+		pendingDir = ShardDataDirPath(path.Join(opts.FilePathPrefix(), migrationPendingDirName), namespace, shard)
+		stagingDir = ShardDataDirPath(migrationStagingPathPrefix(opts.FilePathPrefix()), namespace, shard)
+	)
+	if err := os.RemoveAll(pendingDir); err != nil {
+		return 0, err
+	}
+	if err := installMigratedDataFileSets(opts, namespace, shard); err != nil {
+		return 0, err
+	}
+
+	sources, err := migrationSources(opts, namespace, shard)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		migrate     = make(map[xtime.UnixNano]struct{})
+		blockStarts []time.Time
+	)
+	for _, source := range sources {
+		if source.blockSize == blockSize {
+			continue
+		}
+		for t := source.start.Truncate(blockSize); t.Before(source.end()); t = t.Add(blockSize) {
+			key := xtime.ToUnixNano(t)
+			if _, ok := migrate[key]; ok {
+				continue
+			}
+			migrate[key] = struct{}{}
+			blockStarts = append(blockStarts, t)
+		}
+	}
+	if len(blockStarts) == 0 {
+		return 0, nil
+	}
+	sort.Slice(blockStarts, func(i, j int) bool {
+		return blockStarts[i].Before(blockStarts[j])
+	})
+
+	pendingOpts := opts.SetFilePathPrefix(path.Join(opts.FilePathPrefix(), migrationPendingDirName))
+	for _, blockStart := range blockStarts {
+		err := migrateDataFileSet(pendingOpts, opts, encoderPool, iterPool,
+			namespace, shard, sources, blockStart, blockSize)
+		if err != nil {
+			return 0, fmt.Errorf(
+				"failed to migrate filesets for shard %d at %s: %v", shard, blockStart, err)
+		}
+	}
+
+	// Moving the directory marks all of the migrated filesets as complete at once.
+	if err := os.MkdirAll(filepath.Dir(stagingDir), opts.NewDirectoryMode()); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(pendingDir, stagingDir); err != nil {
+		return 0, err
+	}
+	if err := installMigratedDataFileSets(opts, namespace, shard); err != nil {
+		return 0, err
+	}
+
+	var (
+		migrated int
+		multiErr = xerrors.NewMultiError()
+	)
+	for _, source := range sources {
+		if source.blockSize == blockSize {
+			continue
+		}
+		migrated++
+		if _, ok := migrate[xtime.ToUnixNano(source.start)]; ok {
+			// Already replaced by the migrated fileset with the same block start.
+			continue
+		}
+		err := deleteDataFileSetAt(opts.FilePathPrefix(), namespace, shard, source.start)
+		multiErr = multiErr.Add(err)
+	}
+
+	return migrated, multiErr.FinalError()
+}
+
+type migrationSource struct {
+	start     time.Time
+	blockSize time.Duration
+}
+
+func (s migrationSource) end() time.Time {
+	return s.start.Add(s.blockSize)
+}
+
+// migrationSources returns the flushed data filesets that are not compacted, an
+// error is returned if any info file cannot be read since migrating without all of
+// the data of a block would lose the data that could not be read.
+func migrationSources(
+	opts Options,
+	namespace ident.ID,
+	shard uint32,
+) ([]migrationSource, error) {
+	infoFiles := ReadInfoFiles(opts.FilePathPrefix(), namespace, shard,
+		opts.InfoReaderBufferSize(), opts.DecodingOptions())
+	sources := make([]migrationSource, 0, len(infoFiles))
+	for _, result := range infoFiles {
+		if err := result.Err.Error(); err != nil {
+			return nil, fmt.Errorf(
+				"unable to read info file %s: %v", result.Err.Filepath(), err)
+		}
+		if result.Info.SubBlockSize > 0 {
+			continue
+		}
+		sources = append(sources, migrationSource{
+			start:     xtime.FromNanoseconds(result.Info.BlockStart),
+			blockSize: time.Duration(result.Info.BlockSize),
+		})
+	}
+	return sources, nil
+}
+
+type migrationSeries struct {
+	id       ident.ID
+	tags     ident.Tags
+	segments []xio.SegmentReader
+}
+
+// migrateDataFileSet writes the fileset of the given block size at the given block
+// start from the datapoints of every source fileset that overlaps it.
+func migrateDataFileSet(
+	pendingOpts Options,
+	opts Options,
+	encoderPool encoding.EncoderPool,
+	iterPool encoding.MultiReaderIteratorPool,
+	namespace ident.ID,
+	shard uint32,
+	sources []migrationSource,
+	blockStart time.Time,
+	blockSize time.Duration,
+) error {
+	blockEnd := blockStart.Add(blockSize)
+	series := make(map[string]*migrationSeries)
+	for _, source := range sources {
+		if !source.start.Before(blockEnd) || !source.end().After(blockStart) {
+			continue
+		}
+		if err := readMigrationSeries(opts, namespace, shard, source.start, series); err != nil {
+			return err
+		}
+	}
+
+	ids := make([]string, 0, len(series))
+	for id := range series {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	writer, err := NewWriter(pendingOpts)
+	if err != nil {
+		return err
+	}
+	err = writer.Open(DataWriterOpenOptions{
+		Identifier: FileSetFileIdentifier{
+			Namespace:  namespace,
+			Shard:      shard,
+			BlockStart: blockStart,
+		},
+		BlockSize:   blockSize,
+		FileSetType: persist.FileSetFlushType,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		s := series[id]
+		segment, err := encodeMigrationSeries(encoderPool, iterPool, s, blockStart, blockSize)
+		if err != nil {
+			writer.Close()
+			return err
+		}
+
+		checksum := digest.SegmentChecksum(segment)
+		err = writer.WriteAll(s.id, s.tags, []checked.Bytes{segment.Head, segment.Tail}, checksum)
+		segment.Finalize()
+		if err != nil {
+			writer.Close()
+			return err
+		}
+	}
+
+	return writer.Close()
+}
+
+// encodeMigrationSeries merges the segments of a series and re-encodes the
+// datapoints that fall within the given block.
+func encodeMigrationSeries(
+	encoderPool encoding.EncoderPool,
+	iterPool encoding.MultiReaderIteratorPool,
+	s *migrationSeries,
+	blockStart time.Time,
+	blockSize time.Duration,
+) (ts.Segment, error) {
+	iter := iterPool.Get()
+	iter.Reset(s.segments, blockStart, blockSize)
+	defer iter.Close()
+
+	var (
+		encoder  = encoderPool.Get()
+		blockEnd = blockStart.Add(blockSize)
+	)
+	encoder.Reset(blockStart, 0)
+	for iter.Next() {
+		dp, unit, annotation := iter.Current()
+		if dp.Timestamp.Before(blockStart) || !dp.Timestamp.Before(blockEnd) {
+			continue
+		}
+		if err := encoder.Encode(dp, unit, annotation); err != nil {
+			encoder.Close()
+			return ts.Segment{}, err
+		}
+	}
+	if err := iter.Err(); err != nil {
+		encoder.Close()
+		return ts.Segment{}, err
+	}
+	return encoder.Discard(), nil
+}
+
+// readMigrationSeries adds the data of every series in the data fileset at the
+// given block start to the series map.
+func readMigrationSeries(
+	opts Options,
+	namespace ident.ID,
+	shard uint32,
+	blockStart time.Time,
+	series map[string]*migrationSeries,
+) error {
+	reader, err := NewReader(nil, opts)
+	if err != nil {
+		return err
+	}
+	err = reader.Open(DataReaderOpenOptions{
+		Identifier: FileSetFileIdentifier{
+			Namespace:  namespace,
+			Shard:      shard,
+			BlockStart: blockStart,
+		},
+		FileSetType: persist.FileSetFlushType,
+	})
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	for {
+		id, tagsIter, data, _, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		segment := xio.NewSegmentReader(ts.NewSegment(data, nil, ts.FinalizeNone))
+		if s, ok := series[id.String()]; ok {
+			tagsIter.Close()
+			s.segments = append(s.segments, segment)
+			continue
+		}
+
+		var tags ident.Tags
+		for tagsIter.Next() {
+			curr := tagsIter.Current()
+			tags.Append(ident.StringTag(curr.Name.String(), curr.Value.String()))
+		}
+		err = tagsIter.Err()
+		tagsIter.Close()
+		if err != nil {
+			return err
+		}
+		series[id.String()] = &migrationSeries{
+			id:       id,
+			tags:     tags,
+			segments: []xio.SegmentReader{segment},
+		}
+	}
+}
+
+// installMigratedDataFileSets moves the filesets of a shard that were fully written
+// by a migration into place and removes the staging directory of the shard.
+func installMigratedDataFileSets(
+	opts Options,
+	namespace ident.ID,
+	shard uint32,
+) error {
+	stagingPathPrefix := migrationStagingPathPrefix(opts.FilePathPrefix())
+	staged, err := DataFiles(stagingPathPrefix, namespace, shard)
+	if err != nil {
+		return err
+	}
+	for _, fileset := range staged {
+		if !fileset.HasCheckpointFile() {
+			continue
+		}
+		err := installStagedDataFileSet(opts, stagingPathPrefix, namespace, shard,
+			fileset.ID.BlockStart)
+		if err != nil {
+			return err
+		}
+	}
+	return os.RemoveAll(ShardDataDirPath(stagingPathPrefix, namespace, shard))
+}
+
+func migrationStagingPathPrefix(filePathPrefix string) string {
+	return path.Join(filePathPrefix, migrationStagingDirName)
+}
@@ -0,0 +1,225 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3x/ident"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testMigrationStart = time.Unix(0, 0).Add(100 * 2 * testBlockSize)
+
+func newTestMigrationPools() (encoding.EncoderPool, encoding.MultiReaderIteratorPool) {
+	encodingOpts := encoding.NewOptions()
+	encoderPool := encoding.NewEncoderPool(nil)
+	encoderPool.Init(func() encoding.Encoder {
+		return m3tsz.NewEncoder(time.Time{}, nil, m3tsz.DefaultIntOptimizationEnabled, encodingOpts)
+	})
+	iterPool := encoding.NewMultiReaderIteratorPool(nil)
+	iterPool.Init(func(r io.Reader) encoding.ReaderIterator {
+		return m3tsz.NewReaderIterator(r, m3tsz.DefaultIntOptimizationEnabled, encodingOpts)
+	})
+	return encoderPool, iterPool
+}
+
+// writeTestMigrationData writes a fileset of the given block size holding the
+// given datapoints for each series.
+func writeTestMigrationData(
+	t *testing.T,
+	filePathPrefix string,
+	blockStart time.Time,
+	blockSize time.Duration,
+	series map[string][]ts.Datapoint,
+) {
+	w := newTestWriter(t, filePathPrefix)
+	err := w.Open(DataWriterOpenOptions{
+		Identifier: FileSetFileIdentifier{
+			Namespace:  testNs1ID,
+			BlockStart: blockStart,
+		},
+		BlockSize:   blockSize,
+		FileSetType: persist.FileSetFlushType,
+	})
+	require.NoError(t, err)
+
+	for id, dps := range series {
+		encoder := m3tsz.NewEncoder(blockStart, nil, m3tsz.DefaultIntOptimizationEnabled, nil)
+		for _, dp := range dps {
+			require.NoError(t, encoder.Encode(dp, xtime.Second, nil))
+		}
+		data, err := ioutil.ReadAll(encoder.Stream())
+		require.NoError(t, err)
+		require.NoError(t, w.Write(ident.StringID(id), ident.Tags{},
+			bytesRefd(data), digest.Checksum(data)))
+	}
+	require.NoError(t, w.Close())
+}
+
+// readTestMigrationData returns the datapoints of each series in the fileset at
+// the given block start and verifies its block size.
+func readTestMigrationData(
+	t *testing.T,
+	opts Options,
+	blockStart time.Time,
+	blockSize time.Duration,
+) map[string][]ts.Datapoint {
+	r, err := NewReader(nil, opts)
+	require.NoError(t, err)
+	err = r.Open(DataReaderOpenOptions{
+		Identifier: FileSetFileIdentifier{
+			Namespace:  testNs1ID,
+			BlockStart: blockStart,
+		},
+		FileSetType: persist.FileSetFlushType,
+	})
+	require.NoError(t, err)
+	defer r.Close()
+
+	require.Equal(t, xtime.Range{Start: blockStart, End: blockStart.Add(blockSize)}, r.Range())
+
+	result := make(map[string][]ts.Datapoint)
+	for {
+		id, tags, data, _, err := r.Read()
+		if err == io.EOF {
+			return result
+		}
+		require.NoError(t, err)
+		tags.Close()
+
+		iter := m3tsz.NewReaderIterator(bytes.NewReader(data.Bytes()),
+			m3tsz.DefaultIntOptimizationEnabled, encoding.NewOptions())
+		var dps []ts.Datapoint
+		for iter.Next() {
+			dp, _, _ := iter.Current()
+			dps = append(dps, ts.Datapoint{Timestamp: dp.Timestamp, Value: dp.Value})
+		}
+		require.NoError(t, iter.Err())
+		result[id.String()] = dps
+	}
+}
+
+func testMigrationDatapoint(offset time.Duration, value float64) ts.Datapoint {
+	return ts.Datapoint{Timestamp: testMigrationStart.Add(offset), Value: value}
+}
+
+func TestMigrateDataFileSetsToLargerBlockSize(t *testing.T) {
+	opts, dir := newTestCompactionOptions(t)
+	defer os.RemoveAll(dir)
+
+	writeTestMigrationData(t, opts.FilePathPrefix(), testMigrationStart, testBlockSize,
+		map[string][]ts.Datapoint{
+			"foo": {testMigrationDatapoint(time.Minute, 1)},
+		})
+	writeTestMigrationData(t, opts.FilePathPrefix(), testMigrationStart.Add(testBlockSize), testBlockSize,
+		map[string][]ts.Datapoint{
+			"foo": {testMigrationDatapoint(testBlockSize+time.Minute, 2)},
+			"bar": {testMigrationDatapoint(testBlockSize+2*time.Minute, 3)},
+		})
+
+	encoderPool, iterPool := newTestMigrationPools()
+	migrated, err := MigrateDataFileSets(opts, encoderPool, iterPool, testNs1ID, 0, 2*testBlockSize)
+	require.NoError(t, err)
+	require.Equal(t, 2, migrated)
+
+	files, err := DataFiles(opts.FilePathPrefix(), testNs1ID, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(files))
+
+	series := readTestMigrationData(t, opts, testMigrationStart, 2*testBlockSize)
+	require.Equal(t, map[string][]ts.Datapoint{
+		"foo": {testMigrationDatapoint(time.Minute, 1), testMigrationDatapoint(testBlockSize+time.Minute, 2)},
+		"bar": {testMigrationDatapoint(testBlockSize+2*time.Minute, 3)},
+	}, series)
+
+	// Migrating again is a no-op.
+	migrated, err = MigrateDataFileSets(opts, encoderPool, iterPool, testNs1ID, 0, 2*testBlockSize)
+	require.NoError(t, err)
+	require.Equal(t, 0, migrated)
+}
+
+func TestMigrateDataFileSetsToSmallerBlockSize(t *testing.T) {
+	opts, dir := newTestCompactionOptions(t)
+	defer os.RemoveAll(dir)
+
+	writeTestMigrationData(t, opts.FilePathPrefix(), testMigrationStart, 2*testBlockSize,
+		map[string][]ts.Datapoint{
+			"foo": {testMigrationDatapoint(time.Minute, 1), testMigrationDatapoint(testBlockSize+time.Minute, 2)},
+		})
+
+	encoderPool, iterPool := newTestMigrationPools()
+	migrated, err := MigrateDataFileSets(opts, encoderPool, iterPool, testNs1ID, 0, testBlockSize)
+	require.NoError(t, err)
+	require.Equal(t, 1, migrated)
+
+	files, err := DataFiles(opts.FilePathPrefix(), testNs1ID, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(files))
+
+	series := readTestMigrationData(t, opts, testMigrationStart, testBlockSize)
+	require.Equal(t, map[string][]ts.Datapoint{
+		"foo": {testMigrationDatapoint(time.Minute, 1)},
+	}, series)
+	series = readTestMigrationData(t, opts, testMigrationStart.Add(testBlockSize), testBlockSize)
+	require.Equal(t, map[string][]ts.Datapoint{
+		"foo": {testMigrationDatapoint(testBlockSize+time.Minute, 2)},
+	}, series)
+}
+
+func TestMigrateDataFileSetsDiscardsPendingMigration(t *testing.T) {
+	opts, dir := newTestCompactionOptions(t)
+	defer os.RemoveAll(dir)
+
+	// A fileset left behind by a migration that failed before all of the
+	// migrated filesets of the shard were written.
+	pendingPrefix := path.Join(opts.FilePathPrefix(), migrationPendingDirName)
+	writeTestMigrationData(t, pendingPrefix, testMigrationStart, 2*testBlockSize,
+		map[string][]ts.Datapoint{
+			"foo": {testMigrationDatapoint(time.Minute, 1)},
+		})
+
+	encoderPool, iterPool := newTestMigrationPools()
+	migrated, err := MigrateDataFileSets(opts, encoderPool, iterPool, testNs1ID, 0, 2*testBlockSize)
+	require.NoError(t, err)
+	require.Equal(t, 0, migrated)
+
+	files, err := DataFiles(opts.FilePathPrefix(), testNs1ID, 0)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(files))
+
+	files, err = DataFiles(pendingPrefix, testNs1ID, 0)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(files))
+}
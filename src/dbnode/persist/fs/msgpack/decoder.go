@@ -236,6 +236,11 @@ func (dec *Decoder) decodeIndexInfo() schema.IndexInfo {
 		opts.override = true
 		opts.numExpectedMinFields = 8
 		opts.numExpectedCurrFields = 8
+	} else if dec.legacy.decodeLegacyIndexInfoVersion == legacyEncodingIndexVersionV3 {
+		// V3 had 9 fields.
+		opts.override = true
+		opts.numExpectedMinFields = 9
+		opts.numExpectedCurrFields = 9
 	}
 
 	numFieldsToSkip, actual, ok := dec.checkNumFieldsFor(indexInfoType, opts)
@@ -270,6 +275,15 @@ func (dec *Decoder) decodeIndexInfo() schema.IndexInfo {
 	// Decode fields added in V3.
 	indexInfo.SnapshotID, _, _ = dec.decodeBytes()
 
+	// At this point if its a V3 file we've decoded all the available fields.
+	if dec.legacy.decodeLegacyIndexInfoVersion == legacyEncodingIndexVersionV3 || actual < 10 {
+		dec.skip(numFieldsToSkip)
+		return indexInfo
+	}
+
+	// Decode fields added in V4.
+	indexInfo.SubBlockSize = dec.decodeVarint()
+
 	dec.skip(numFieldsToSkip)
 	return indexInfo
 }
@@ -310,6 +324,16 @@ func (dec *Decoder) decodeIndexEntry() schema.IndexEntry {
 		opts.override = true
 		opts.numExpectedMinFields = 5
 		opts.numExpectedCurrFields = 5
+	} else if dec.legacy.decodeLegacyV2IndexEntry {
+		// V2 had 6 fields.
+		opts.override = true
+		opts.numExpectedMinFields = 6
+		opts.numExpectedCurrFields = 6
+	} else if dec.legacy.decodeLegacyV3IndexEntry {
+		// V3 had 7 fields.
+		opts.override = true
+		opts.numExpectedMinFields = 7
+		opts.numExpectedCurrFields = 7
 	}
 	numFieldsToSkip, actual, ok := dec.checkNumFieldsFor(indexEntryType, opts)
 	if !ok {
@@ -330,6 +354,20 @@ func (dec *Decoder) decodeIndexEntry() schema.IndexEntry {
 
 	indexEntry.EncodedTags, _, _ = dec.decodeBytes()
 
+	if dec.legacy.decodeLegacyV2IndexEntry || actual < 7 {
+		dec.skip(numFieldsToSkip)
+		return indexEntry
+	}
+
+	indexEntry.SubBlocks, _, _ = dec.decodeBytes()
+
+	if dec.legacy.decodeLegacyV3IndexEntry || actual < 8 {
+		dec.skip(numFieldsToSkip)
+		return indexEntry
+	}
+
+	indexEntry.Checkpoints, _, _ = dec.decodeBytes()
+
 	dec.skip(numFieldsToSkip)
 	return indexEntry
 }
@@ -363,7 +401,7 @@ func (dec *Decoder) decodeIndexSummary() (schema.IndexSummary, IndexSummaryToken
 }
 
 func (dec *Decoder) decodeLogInfo() schema.LogInfo {
-	numFieldsToSkip, _, ok := dec.checkNumFieldsFor(logInfoType, checkNumFieldsOptions{})
+	numFieldsToSkip, actual, ok := dec.checkNumFieldsFor(logInfoType, checkNumFieldsOptions{})
 	if !ok {
 		return emptyLogInfo
 	}
@@ -371,6 +409,13 @@ func (dec *Decoder) decodeLogInfo() schema.LogInfo {
 	logInfo.Start = dec.decodeVarint()
 	logInfo.Duration = dec.decodeVarint()
 	logInfo.Index = dec.decodeVarint()
+
+	// Commit logs written before compression and encryption were added
+	// only have the first three fields and are plain.
+	if actual >= 5 {
+		logInfo.Compression = dec.decodeVarint()
+		logInfo.Encryption = dec.decodeVarint()
+	}
 	dec.skip(numFieldsToSkip)
 	if dec.err != nil {
 		return emptyLogInfo
@@ -155,6 +155,31 @@ func TestDecodeLogInfoMoreFieldsThanExpected(t *testing.T) {
 	require.Equal(t, testLogInfo, res)
 }
 
+func TestDecodeLogInfoWithoutCompressionAndEncryptionFields(t *testing.T) {
+	var (
+		enc = NewEncoder()
+		dec = NewDecoder(nil)
+	)
+
+	// Encode the log info as written before compression and encryption were
+	// added which only had the start, duration and index fields
+	enc.encodeRootObject(logInfoVersion, logInfoType)
+	enc.encodeArrayLenFn(minNumLogInfoFields)
+	enc.encodeVarintFn(testLogInfo.Start)
+	enc.encodeVarintFn(testLogInfo.Duration)
+	enc.encodeVarintFn(testLogInfo.Index)
+	require.NoError(t, enc.err)
+
+	dec.Reset(NewDecoderStream(enc.Bytes()))
+	res, err := dec.DecodeLogInfo()
+	require.NoError(t, err)
+
+	expected := testLogInfo
+	expected.Compression = 0
+	expected.Encryption = 0
+	require.Equal(t, expected, res)
+}
+
 func TestDecodeLogEntryMoreFieldsThanExpected(t *testing.T) {
 	var (
 		enc = NewEncoder()
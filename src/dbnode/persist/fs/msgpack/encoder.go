@@ -58,6 +58,7 @@ type legacyEncodingIndexInfoVersion int
 const (
 	// List in reverse order to ensure default value is current version.
 	legacyEncodingIndexVersionCurrent legacyEncodingIndexInfoVersion = iota
+	legacyEncodingIndexVersionV3
 	legacyEncodingIndexVersionV2
 	legacyEncodingIndexVersionV1
 )
@@ -68,6 +69,12 @@ type legacyEncodingOptions struct {
 
 	encodeLegacyV1IndexEntry bool
 	decodeLegacyV1IndexEntry bool
+
+	encodeLegacyV2IndexEntry bool
+	decodeLegacyV2IndexEntry bool
+
+	encodeLegacyV3IndexEntry bool
+	decodeLegacyV3IndexEntry bool
 }
 
 var defaultlegacyEncodingOptions = legacyEncodingOptions{
@@ -76,6 +83,12 @@ var defaultlegacyEncodingOptions = legacyEncodingOptions{
 
 	encodeLegacyV1IndexEntry: false,
 	decodeLegacyV1IndexEntry: false,
+
+	encodeLegacyV2IndexEntry: false,
+	decodeLegacyV2IndexEntry: false,
+
+	encodeLegacyV3IndexEntry: false,
+	decodeLegacyV3IndexEntry: false,
 }
 
 // NewEncoder creates a new encoder.
@@ -123,8 +136,10 @@ func (enc *Encoder) EncodeIndexInfo(info schema.IndexInfo) error {
 		enc.encodeIndexInfoV1(info)
 	} else if enc.legacy.encodeLegacyIndexInfoVersion == legacyEncodingIndexVersionV2 {
 		enc.encodeIndexInfoV2(info)
-	} else {
+	} else if enc.legacy.encodeLegacyIndexInfoVersion == legacyEncodingIndexVersionV3 {
 		enc.encodeIndexInfoV3(info)
+	} else {
+		enc.encodeIndexInfoV4(info)
 	}
 	return enc.err
 }
@@ -137,8 +152,12 @@ func (enc *Encoder) EncodeIndexEntry(entry schema.IndexEntry) error {
 	enc.encodeRootObject(indexEntryVersion, indexEntryType)
 	if enc.legacy.encodeLegacyV1IndexEntry {
 		enc.encodeIndexEntryV1(entry)
-	} else {
+	} else if enc.legacy.encodeLegacyV2IndexEntry {
 		enc.encodeIndexEntryV2(entry)
+	} else if enc.legacy.encodeLegacyV3IndexEntry {
+		enc.encodeIndexEntryV3(entry)
+	} else {
+		enc.encodeIndexEntryV4(entry)
 	}
 	return enc.err
 }
@@ -211,7 +230,23 @@ func (enc *Encoder) encodeIndexInfoV2(info schema.IndexInfo) {
 	enc.encodeVarintFn(int64(info.FileType))
 }
 
+// We only keep this method around for the sake of testing
+// backwards-compatbility.
 func (enc *Encoder) encodeIndexInfoV3(info schema.IndexInfo) {
+	// Manually encode num fields for testing purposes.
+	enc.encodeArrayLenFn(9) // V3 had 9 fields.
+	enc.encodeVarintFn(info.BlockStart)
+	enc.encodeVarintFn(info.BlockSize)
+	enc.encodeVarintFn(info.Entries)
+	enc.encodeVarintFn(info.MajorVersion)
+	enc.encodeIndexSummariesInfo(info.Summaries)
+	enc.encodeIndexBloomFilterInfo(info.BloomFilter)
+	enc.encodeVarintFn(info.SnapshotTime)
+	enc.encodeVarintFn(int64(info.FileType))
+	enc.encodeBytesFn(info.SnapshotID)
+}
+
+func (enc *Encoder) encodeIndexInfoV4(info schema.IndexInfo) {
 	enc.encodeNumObjectFieldsForFn(indexInfoType)
 	enc.encodeVarintFn(info.BlockStart)
 	enc.encodeVarintFn(info.BlockSize)
@@ -222,6 +257,7 @@ func (enc *Encoder) encodeIndexInfoV3(info schema.IndexInfo) {
 	enc.encodeVarintFn(info.SnapshotTime)
 	enc.encodeVarintFn(int64(info.FileType))
 	enc.encodeBytesFn(info.SnapshotID)
+	enc.encodeVarintFn(info.SubBlockSize)
 }
 
 func (enc *Encoder) encodeIndexSummariesInfo(info schema.IndexSummariesInfo) {
@@ -247,7 +283,34 @@ func (enc *Encoder) encodeIndexEntryV1(entry schema.IndexEntry) {
 	enc.encodeVarintFn(entry.Checksum)
 }
 
+// We only keep this method around for the sake of testing
+// backwards-compatbility.
 func (enc *Encoder) encodeIndexEntryV2(entry schema.IndexEntry) {
+	// Manually encode num fields for testing purposes.
+	enc.encodeArrayLenFn(6) // V2 had 6 fields.
+	enc.encodeVarintFn(entry.Index)
+	enc.encodeBytesFn(entry.ID)
+	enc.encodeVarintFn(entry.Size)
+	enc.encodeVarintFn(entry.Offset)
+	enc.encodeVarintFn(entry.Checksum)
+	enc.encodeBytesFn(entry.EncodedTags)
+}
+
+// We only keep this method around for the sake of testing
+// backwards-compatbility.
+func (enc *Encoder) encodeIndexEntryV3(entry schema.IndexEntry) {
+	// Manually encode num fields for testing purposes.
+	enc.encodeArrayLenFn(7) // V3 had 7 fields.
+	enc.encodeVarintFn(entry.Index)
+	enc.encodeBytesFn(entry.ID)
+	enc.encodeVarintFn(entry.Size)
+	enc.encodeVarintFn(entry.Offset)
+	enc.encodeVarintFn(entry.Checksum)
+	enc.encodeBytesFn(entry.EncodedTags)
+	enc.encodeBytesFn(entry.SubBlocks)
+}
+
+func (enc *Encoder) encodeIndexEntryV4(entry schema.IndexEntry) {
 	enc.encodeNumObjectFieldsForFn(indexEntryType)
 	enc.encodeVarintFn(entry.Index)
 	enc.encodeBytesFn(entry.ID)
@@ -255,6 +318,8 @@ func (enc *Encoder) encodeIndexEntryV2(entry schema.IndexEntry) {
 	enc.encodeVarintFn(entry.Offset)
 	enc.encodeVarintFn(entry.Checksum)
 	enc.encodeBytesFn(entry.EncodedTags)
+	enc.encodeBytesFn(entry.SubBlocks)
+	enc.encodeBytesFn(entry.Checkpoints)
 }
 
 func (enc *Encoder) encodeIndexSummary(summary schema.IndexSummary) {
@@ -269,6 +334,8 @@ func (enc *Encoder) encodeLogInfo(info schema.LogInfo) {
 	enc.encodeVarintFn(info.Start)
 	enc.encodeVarintFn(info.Duration)
 	enc.encodeVarintFn(info.Index)
+	enc.encodeVarintFn(info.Compression)
+	enc.encodeVarintFn(info.Encryption)
 }
 
 func (enc *Encoder) encodeLogEntry(entry schema.LogEntry) {
@@ -81,6 +81,7 @@ func testExpectedResultForIndexInfo(t *testing.T, indexInfo schema.IndexInfo) []
 		indexInfo.SnapshotTime,
 		int64(indexInfo.FileType),
 		indexInfo.SnapshotID,
+		indexInfo.SubBlockSize,
 	}
 }
 
@@ -98,6 +99,8 @@ func testExpectedResultForIndexEntry(t *testing.T, indexEntry schema.IndexEntry)
 		indexEntry.Offset,
 		indexEntry.Checksum,
 		indexEntry.EncodedTags,
+		indexEntry.SubBlocks,
+		indexEntry.Checkpoints,
 	}
 }
 
@@ -112,6 +115,8 @@ func testExpectedResultForLogInfo(t *testing.T, logInfo schema.LogInfo) []interf
 		logInfo.Start,
 		logInfo.Duration,
 		logInfo.Index,
+		logInfo.Compression,
+		logInfo.Encryption,
 	}
 }
 
@@ -46,6 +46,7 @@ var (
 		SnapshotTime: time.Now().UnixNano(),
 		FileType:     persist.FileSetSnapshotType,
 		SnapshotID:   []byte("some_bytes"),
+		SubBlockSize: int64(time.Hour),
 	}
 
 	testIndexEntry = schema.IndexEntry{
@@ -55,6 +56,8 @@ var (
 		Offset:      2390423,
 		Checksum:    134245634534,
 		EncodedTags: []byte("testEncodedTags"),
+		SubBlocks:   []byte("testSubBlocks"),
+		Checkpoints: []byte("testCheckpoints"),
 	}
 
 	testIndexSummary = schema.IndexSummary{
@@ -64,9 +67,11 @@ var (
 	}
 
 	testLogInfo = schema.LogInfo{
-		Start:    time.Now().UnixNano(),
-		Duration: int64(2 * time.Hour),
-		Index:    234,
+		Start:       time.Now().UnixNano(),
+		Duration:    int64(2 * time.Hour),
+		Index:       234,
+		Compression: 1,
+		Encryption:  1,
 	}
 
 	testLogEntry = schema.LogEntry{
@@ -116,14 +121,17 @@ func TestIndexInfoRoundTripBackwardsCompatibilityV1(t *testing.T) {
 		currSnapshotTime = testIndexInfo.SnapshotTime
 		currFileType     = testIndexInfo.FileType
 		currSnapshotID   = testIndexInfo.SnapshotID
+		currSubBlockSize = testIndexInfo.SubBlockSize
 	)
 	testIndexInfo.SnapshotTime = 0
 	testIndexInfo.FileType = 0
 	testIndexInfo.SnapshotID = nil
+	testIndexInfo.SubBlockSize = 0
 	defer func() {
 		testIndexInfo.SnapshotTime = currSnapshotTime
 		testIndexInfo.FileType = currFileType
 		testIndexInfo.SnapshotID = currSnapshotID
+		testIndexInfo.SubBlockSize = currSubBlockSize
 	}()
 
 	enc.EncodeIndexInfo(testIndexInfo)
@@ -148,6 +156,7 @@ func TestIndexInfoRoundTripForwardsCompatibilityV2(t *testing.T) {
 		currSnapshotTime = testIndexInfo.SnapshotTime
 		currFileType     = testIndexInfo.FileType
 		currSnapshotID   = testIndexInfo.SnapshotID
+		currSubBlockSize = testIndexInfo.SubBlockSize
 	)
 
 	enc.EncodeIndexInfo(testIndexInfo)
@@ -157,10 +166,12 @@ func TestIndexInfoRoundTripForwardsCompatibilityV2(t *testing.T) {
 	testIndexInfo.SnapshotTime = 0
 	testIndexInfo.FileType = 0
 	testIndexInfo.SnapshotID = nil
+	testIndexInfo.SubBlockSize = 0
 	defer func() {
 		testIndexInfo.SnapshotTime = currSnapshotTime
 		testIndexInfo.FileType = currFileType
 		testIndexInfo.SnapshotID = currSnapshotID
+		testIndexInfo.SubBlockSize = currSubBlockSize
 	}()
 
 	dec.Reset(NewDecoderStream(enc.Bytes()))
@@ -185,14 +196,17 @@ func TestIndexInfoRoundTripBackwardsCompatibilityV2(t *testing.T) {
 		currSnapshotTime = testIndexInfo.SnapshotTime
 		currFileType     = testIndexInfo.FileType
 		currSnapshotID   = testIndexInfo.SnapshotID
+		currSubBlockSize = testIndexInfo.SubBlockSize
 	)
 	testIndexInfo.SnapshotTime = 0
 	testIndexInfo.FileType = 0
 	testIndexInfo.SnapshotID = nil
+	testIndexInfo.SubBlockSize = 0
 	defer func() {
 		testIndexInfo.SnapshotTime = currSnapshotTime
 		testIndexInfo.FileType = currFileType
 		testIndexInfo.SnapshotID = currSnapshotID
+		testIndexInfo.SubBlockSize = currSubBlockSize
 	}()
 
 	enc.EncodeIndexInfo(testIndexInfo)
@@ -213,15 +227,73 @@ func TestIndexInfoRoundTripForwardsCompatibilityV3(t *testing.T) {
 	// Set the default values on the fields that did not exist in V2
 	// and then restore them at the end of the test - This is required
 	// because the old decoder won't read the new fields.
-	currSnapshotID := testIndexInfo.SnapshotID
+	var (
+		currSnapshotID   = testIndexInfo.SnapshotID
+		currSubBlockSize = testIndexInfo.SubBlockSize
+	)
 
 	enc.EncodeIndexInfo(testIndexInfo)
 
 	// Make sure to zero them before we compare, but after we have
 	// encoded the data.
 	testIndexInfo.SnapshotID = nil
+	testIndexInfo.SubBlockSize = 0
 	defer func() {
 		testIndexInfo.SnapshotID = currSnapshotID
+		testIndexInfo.SubBlockSize = currSubBlockSize
+	}()
+
+	dec.Reset(NewDecoderStream(enc.Bytes()))
+	res, err := dec.DecodeIndexInfo()
+	require.NoError(t, err)
+	require.Equal(t, testIndexInfo, res)
+}
+
+// Make sure the V4 decoding code can handle the V3 file format.
+func TestIndexInfoRoundTripBackwardsCompatibilityV3(t *testing.T) {
+	var (
+		opts = legacyEncodingOptions{encodeLegacyIndexInfoVersion: legacyEncodingIndexVersionV3}
+		enc  = newEncoder(opts)
+		dec  = newDecoder(opts, nil)
+	)
+
+	// Set the default values on the fields that did not exist in V3,
+	// and then restore them at the end of the test - This is required
+	// because the new decoder won't try and read the new fields from
+	// the old file format.
+	currSubBlockSize := testIndexInfo.SubBlockSize
+	testIndexInfo.SubBlockSize = 0
+	defer func() {
+		testIndexInfo.SubBlockSize = currSubBlockSize
+	}()
+
+	enc.EncodeIndexInfo(testIndexInfo)
+	dec.Reset(NewDecoderStream(enc.Bytes()))
+	res, err := dec.DecodeIndexInfo()
+	require.NoError(t, err)
+	require.Equal(t, testIndexInfo, res)
+}
+
+// Make sure the V3 decoder code can handle the V4 file format.
+func TestIndexInfoRoundTripForwardsCompatibilityV4(t *testing.T) {
+	var (
+		opts = legacyEncodingOptions{decodeLegacyIndexInfoVersion: legacyEncodingIndexVersionV3}
+		enc  = newEncoder(opts)
+		dec  = newDecoder(opts, nil)
+	)
+
+	// Set the default values on the fields that did not exist in V3
+	// and then restore them at the end of the test - This is required
+	// because the old decoder won't read the new fields.
+	currSubBlockSize := testIndexInfo.SubBlockSize
+
+	enc.EncodeIndexInfo(testIndexInfo)
+
+	// Make sure to zero them before we compare, but after we have
+	// encoded the data.
+	testIndexInfo.SubBlockSize = 0
+	defer func() {
+		testIndexInfo.SubBlockSize = currSubBlockSize
 	}()
 
 	dec.Reset(NewDecoderStream(enc.Bytes()))
@@ -254,10 +326,18 @@ func TestIndexEntryRoundTripBackwardsCompatibilityV1(t *testing.T) {
 	// and then restore them at the end of the test - This is required
 	// because the new decoder won't try and read the new fields from
 	// the old file format.
-	currEncodedTags := testIndexEntry.EncodedTags
+	var (
+		currEncodedTags = testIndexEntry.EncodedTags
+		currSubBlocks   = testIndexEntry.SubBlocks
+		currCheckpoints = testIndexEntry.Checkpoints
+	)
 	testIndexEntry.EncodedTags = nil
+	testIndexEntry.SubBlocks = nil
+	testIndexEntry.Checkpoints = nil
 	defer func() {
 		testIndexEntry.EncodedTags = currEncodedTags
+		testIndexEntry.SubBlocks = currSubBlocks
+		testIndexEntry.Checkpoints = currCheckpoints
 	}()
 
 	enc.EncodeIndexEntry(testIndexEntry)
@@ -278,15 +358,139 @@ func TestIndexEntryRoundTripForwardsCompatibilityV2(t *testing.T) {
 	// Set the default values on the fields that did not exist in V1
 	// and then restore them at the end of the test - This is required
 	// because the old decoder won't read the new fields.
-	currEncodedTags := testIndexEntry.EncodedTags
+	var (
+		currEncodedTags = testIndexEntry.EncodedTags
+		currSubBlocks   = testIndexEntry.SubBlocks
+		currCheckpoints = testIndexEntry.Checkpoints
+	)
 
 	enc.EncodeIndexEntry(testIndexEntry)
 
 	// Make sure to zero them before we compare, but after we have
 	// encoded the data.
 	testIndexEntry.EncodedTags = nil
+	testIndexEntry.SubBlocks = nil
+	testIndexEntry.Checkpoints = nil
 	defer func() {
 		testIndexEntry.EncodedTags = currEncodedTags
+		testIndexEntry.SubBlocks = currSubBlocks
+		testIndexEntry.Checkpoints = currCheckpoints
+	}()
+
+	dec.Reset(NewDecoderStream(enc.Bytes()))
+	res, err := dec.DecodeIndexEntry()
+	require.NoError(t, err)
+	require.Equal(t, testIndexEntry, res)
+}
+
+// Make sure the V3 decoding code can handle the V2 file format.
+func TestIndexEntryRoundTripBackwardsCompatibilityV2(t *testing.T) {
+	var (
+		opts = legacyEncodingOptions{encodeLegacyV2IndexEntry: true}
+		enc  = newEncoder(opts)
+		dec  = newDecoder(opts, nil)
+	)
+
+	// Set the default values on the fields that did not exist in V2
+	// and then restore them at the end of the test - This is required
+	// because the new decoder won't try and read the new fields from
+	// the old file format.
+	var (
+		currSubBlocks   = testIndexEntry.SubBlocks
+		currCheckpoints = testIndexEntry.Checkpoints
+	)
+	testIndexEntry.SubBlocks = nil
+	testIndexEntry.Checkpoints = nil
+	defer func() {
+		testIndexEntry.SubBlocks = currSubBlocks
+		testIndexEntry.Checkpoints = currCheckpoints
+	}()
+
+	enc.EncodeIndexEntry(testIndexEntry)
+	dec.Reset(NewDecoderStream(enc.Bytes()))
+	res, err := dec.DecodeIndexEntry()
+	require.NoError(t, err)
+	require.Equal(t, testIndexEntry, res)
+}
+
+// Make sure the V2 decoder code can handle the V3 file format.
+func TestIndexEntryRoundTripForwardsCompatibilityV3(t *testing.T) {
+	var (
+		opts = legacyEncodingOptions{decodeLegacyV2IndexEntry: true}
+		enc  = newEncoder(opts)
+		dec  = newDecoder(opts, nil)
+	)
+
+	// Set the default values on the fields that did not exist in V2
+	// and then restore them at the end of the test - This is required
+	// because the old decoder won't read the new fields.
+	var (
+		currSubBlocks   = testIndexEntry.SubBlocks
+		currCheckpoints = testIndexEntry.Checkpoints
+	)
+
+	enc.EncodeIndexEntry(testIndexEntry)
+
+	// Make sure to zero them before we compare, but after we have
+	// encoded the data.
+	testIndexEntry.SubBlocks = nil
+	testIndexEntry.Checkpoints = nil
+	defer func() {
+		testIndexEntry.SubBlocks = currSubBlocks
+		testIndexEntry.Checkpoints = currCheckpoints
+	}()
+
+	dec.Reset(NewDecoderStream(enc.Bytes()))
+	res, err := dec.DecodeIndexEntry()
+	require.NoError(t, err)
+	require.Equal(t, testIndexEntry, res)
+}
+
+// Make sure the V4 decoding code can handle the V3 file format.
+func TestIndexEntryRoundTripBackwardsCompatibilityV3(t *testing.T) {
+	var (
+		opts = legacyEncodingOptions{encodeLegacyV3IndexEntry: true}
+		enc  = newEncoder(opts)
+		dec  = newDecoder(opts, nil)
+	)
+
+	// Set the default values on the fields that did not exist in V3
+	// and then restore them at the end of the test - This is required
+	// because the new decoder won't try and read the new fields from
+	// the old file format.
+	currCheckpoints := testIndexEntry.Checkpoints
+	testIndexEntry.Checkpoints = nil
+	defer func() {
+		testIndexEntry.Checkpoints = currCheckpoints
+	}()
+
+	enc.EncodeIndexEntry(testIndexEntry)
+	dec.Reset(NewDecoderStream(enc.Bytes()))
+	res, err := dec.DecodeIndexEntry()
+	require.NoError(t, err)
+	require.Equal(t, testIndexEntry, res)
+}
+
+// Make sure the V3 decoder code can handle the V4 file format.
+func TestIndexEntryRoundTripForwardsCompatibilityV4(t *testing.T) {
+	var (
+		opts = legacyEncodingOptions{decodeLegacyV3IndexEntry: true}
+		enc  = newEncoder(opts)
+		dec  = newDecoder(opts, nil)
+	)
+
+	// Set the default values on the fields that did not exist in V3
+	// and then restore them at the end of the test - This is required
+	// because the old decoder won't read the new fields.
+	currCheckpoints := testIndexEntry.Checkpoints
+
+	enc.EncodeIndexEntry(testIndexEntry)
+
+	// Make sure to zero them before we compare, but after we have
+	// encoded the data.
+	testIndexEntry.Checkpoints = nil
+	defer func() {
+		testIndexEntry.Checkpoints = currCheckpoints
 	}()
 
 	dec.Reset(NewDecoderStream(enc.Bytes()))
@@ -0,0 +1,241 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"container/list"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	xerrors "github.com/m3db/m3x/errors"
+	"github.com/m3db/m3x/ident"
+)
+
+const (
+	// DefaultObjectStoreCacheMaxBytes is the default maximum size of the
+	// offloaded filesets fetched into the object store cache
+	DefaultObjectStoreCacheMaxBytes = 8 << 30 // 8gb
+
+	// DefaultObjectStoreFetchTimeout is the default time a read waits for an
+	// offloaded fileset to be fetched into the object store cache
+	DefaultObjectStoreFetchTimeout = 10 * time.Second
+)
+
+var (
+	errObjectStoreFileSetNotOffloaded = errors.New("fileset is not offloaded to the object store")
+	errObjectStoreFetchTimeout        = errors.New("timed out fetching fileset from the object store")
+)
+
+type objectStoreCacheKey struct {
+	namespace  string
+	shard      uint32
+	blockStart int64
+}
+
+type objectStoreCacheEntry struct {
+	key        objectStoreCacheKey
+	blockStart time.Time
+	sizeBytes  int64
+}
+
+type objectStoreFetch struct {
+	done chan struct{}
+	err  error
+}
+
+type objectStoreCache struct {
+	sync.Mutex
+
+	maxBytes     int64
+	fetchTimeout time.Duration
+	sizeBytes    int64
+	lru          *list.List
+	entries      map[objectStoreCacheKey]*list.Element
+	fetches      map[objectStoreCacheKey]*objectStoreFetch
+}
+
+// NewObjectStoreCache returns a new object store cache that evicts the least
+// recently read filesets once the cached filesets exceed the max bytes and
+// waits up to the fetch timeout for a fileset to be fetched before failing
+// the read, the fetch itself always runs to completion in the background.
+func NewObjectStoreCache(maxBytes int64, fetchTimeout time.Duration) ObjectStoreCache {
+	return &objectStoreCache{
+		maxBytes:     maxBytes,
+		fetchTimeout: fetchTimeout,
+		lru:          list.New(),
+		entries:      make(map[objectStoreCacheKey]*list.Element),
+		fetches:      make(map[objectStoreCacheKey]*objectStoreFetch),
+	}
+}
+
+func (c *objectStoreCache) Fetch(
+	opts Options,
+	namespace ident.ID,
+	shard uint32,
+	blockStart time.Time,
+) error {
+	var (
+		cachePathPrefix = opts.ObjectStoreCachePathPrefix()
+		key             = objectStoreCacheKey{
+			namespace:  namespace.String(),
+			shard:      shard,
+			blockStart: blockStart.UnixNano(),
+		}
+	)
+	exists, err := DataFileSetExistsAt(cachePathPrefix, namespace, shard, blockStart)
+	if err != nil {
+		return err
+	}
+
+	c.Lock()
+	elem, ok := c.entries[key]
+	switch {
+	case ok && exists:
+		c.lru.MoveToFront(elem)
+		c.Unlock()
+		return nil
+	case ok:
+		// Removed from the cache path prefix outside of the cache, for
+		// instance by retention cleanup.
+		c.removeWithLock(elem)
+	case exists:
+		// Fetched before the process started, start tracking it.
+		c.addWithLock(opts, key, namespace, blockStart)
+		c.Unlock()
+		return nil
+	}
+
+	fetch, ok := c.fetches[key]
+	if !ok {
+		fetch = &objectStoreFetch{done: make(chan struct{})}
+		c.fetches[key] = fetch
+		go c.fetch(opts, key, namespace, shard, blockStart, fetch)
+	}
+	c.Unlock()
+
+	timer := time.NewTimer(c.fetchTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-fetch.done:
+		return fetch.err
+	case <-timer.C:
+		return errObjectStoreFetchTimeout
+	}
+}
+
+func (c *objectStoreCache) fetch(
+	opts Options,
+	key objectStoreCacheKey,
+	namespace ident.ID,
+	shard uint32,
+	blockStart time.Time,
+	fetch *objectStoreFetch,
+) {
+	offloaded, err := DataFileSetOffloadedAt(opts.ObjectStore(), namespace, shard, blockStart)
+	if err == nil && !offloaded {
+		err = errObjectStoreFileSetNotOffloaded
+	}
+	if err == nil {
+		err = FetchOffloadedDataFileSet(opts, namespace, shard, blockStart)
+	}
+
+	c.Lock()
+	if err == nil {
+		c.addWithLock(opts, key, namespace, blockStart)
+	}
+	delete(c.fetches, key)
+	c.Unlock()
+
+	fetch.err = err
+	close(fetch.done)
+}
+
+func (c *objectStoreCache) addWithLock(
+	opts Options,
+	key objectStoreCacheKey,
+	namespace ident.ID,
+	blockStart time.Time,
+) {
+	var (
+		shardDir  = ShardDataDirPath(opts.ObjectStoreCachePathPrefix(), namespace, key.shard)
+		sizeBytes int64
+	)
+	for _, suffix := range cachedDataFileSetSuffixes() {
+		info, err := os.Stat(filesetPathFromTime(shardDir, blockStart, suffix))
+		if err == nil {
+			sizeBytes += info.Size()
+		}
+	}
+
+	elem := c.lru.PushFront(&objectStoreCacheEntry{
+		key:        key,
+		blockStart: blockStart,
+		sizeBytes:  sizeBytes,
+	})
+	c.entries[key] = elem
+	c.sizeBytes += sizeBytes
+
+	// Evict the least recently read filesets, never the one just added. Open
+	// seekers of an evicted fileset keep reading from their open descriptors.
+	for c.sizeBytes > c.maxBytes && c.lru.Len() > 1 {
+		oldest := c.lru.Back()
+		entry := oldest.Value.(*objectStoreCacheEntry)
+		c.removeWithLock(oldest)
+
+		entryShardDir := ShardDataDirPath(opts.ObjectStoreCachePathPrefix(),
+			ident.StringID(entry.key.namespace), entry.key.shard)
+		if err := deleteCachedDataFileSet(entryShardDir, entry.blockStart); err != nil {
+			opts.InstrumentOptions().Logger().Errorf(
+				"failed to evict cached fileset: namespace=%s, shard=%d, blockStart=%v, err=%v",
+				entry.key.namespace, entry.key.shard, entry.blockStart, err)
+		}
+	}
+}
+
+func (c *objectStoreCache) removeWithLock(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*objectStoreCacheEntry)
+	delete(c.entries, entry.key)
+	c.sizeBytes -= entry.sizeBytes
+}
+
+// cachedDataFileSetSuffixes returns the suffixes of every file of a cached
+// data fileset with the checkpoint file first.
+func cachedDataFileSetSuffixes() []string {
+	return append([]string{checkpointFileSuffix, digestFileSuffix},
+		dataFileSetDigestedFileSuffixes...)
+}
+
+// deleteCachedDataFileSet deletes a cached data fileset, the checkpoint file
+// is deleted first so the fileset is no longer considered complete if deleting
+// any other file fails.
+func deleteCachedDataFileSet(shardDir string, blockStart time.Time) error {
+	multiErr := xerrors.NewMultiError()
+	for _, suffix := range cachedDataFileSetSuffixes() {
+		err := os.Remove(filesetPathFromTime(shardDir, blockStart, suffix))
+		if err != nil && !os.IsNotExist(err) {
+			multiErr = multiErr.Add(err)
+		}
+	}
+	return multiErr.FinalError()
+}
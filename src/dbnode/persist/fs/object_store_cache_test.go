@@ -0,0 +1,126 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs/objectstore"
+
+	"github.com/stretchr/testify/require"
+)
+
+// blockingStore blocks reading objects until unblocked.
+type blockingStore struct {
+	objectstore.Store
+	unblock chan struct{}
+}
+
+func (s blockingStore) Get(key string) (io.ReadCloser, error) {
+	<-s.unblock
+	return s.Store.Get(key)
+}
+
+func requireCachedDataFileSet(t *testing.T, opts Options, blockStart time.Time, expected bool) {
+	exists, err := DataFileSetExistsAt(opts.ObjectStoreCachePathPrefix(), testNs1ID, 0, blockStart)
+	require.NoError(t, err)
+	require.Equal(t, expected, exists)
+}
+
+func TestObjectStoreCacheEvictsLeastRecentlyRead(t *testing.T) {
+	opts, dir := newTestOffloadOptions(t)
+	defer os.RemoveAll(dir)
+
+	blockStarts := []time.Time{
+		testWriterStart,
+		testWriterStart.Add(time.Hour),
+		testWriterStart.Add(2 * time.Hour),
+	}
+	for _, blockStart := range blockStarts {
+		writeTestOffloadData(t, opts, blockStart)
+		require.NoError(t, OffloadDataFileSet(opts, testNs1ID, 0, blockStart))
+	}
+
+	// Size the cache to fit two filesets.
+	require.NoError(t, FetchOffloadedDataFileSet(opts, testNs1ID, 0, blockStarts[0]))
+	var sizeBytes int64
+	shardDir := ShardDataDirPath(opts.ObjectStoreCachePathPrefix(), testNs1ID, 0)
+	for _, suffix := range cachedDataFileSetSuffixes() {
+		info, err := os.Stat(filesetPathFromTime(shardDir, blockStarts[0], suffix))
+		require.NoError(t, err)
+		sizeBytes += info.Size()
+	}
+	cache := NewObjectStoreCache(2*sizeBytes, time.Minute)
+
+	require.NoError(t, cache.Fetch(opts, testNs1ID, 0, blockStarts[0]))
+	require.NoError(t, cache.Fetch(opts, testNs1ID, 0, blockStarts[1]))
+	require.NoError(t, cache.Fetch(opts, testNs1ID, 0, blockStarts[0]))
+	require.NoError(t, cache.Fetch(opts, testNs1ID, 0, blockStarts[2]))
+
+	requireCachedDataFileSet(t, opts, blockStarts[0], true)
+	requireCachedDataFileSet(t, opts, blockStarts[1], false)
+	requireCachedDataFileSet(t, opts, blockStarts[2], true)
+
+	// Evicted filesets are fetched again when read.
+	require.NoError(t, cache.Fetch(opts, testNs1ID, 0, blockStarts[1]))
+	requireCachedDataFileSet(t, opts, blockStarts[0], false)
+	requireCachedDataFileSet(t, opts, blockStarts[1], true)
+}
+
+func TestObjectStoreCacheFetchNotOffloaded(t *testing.T) {
+	opts, dir := newTestOffloadOptions(t)
+	defer os.RemoveAll(dir)
+
+	cache := NewObjectStoreCache(DefaultObjectStoreCacheMaxBytes, time.Minute)
+	err := cache.Fetch(opts, testNs1ID, 0, testWriterStart)
+	require.Equal(t, errObjectStoreFileSetNotOffloaded, err)
+}
+
+func TestObjectStoreCacheFetchTimeout(t *testing.T) {
+	opts, dir := newTestOffloadOptions(t)
+	defer os.RemoveAll(dir)
+
+	writeTestOffloadData(t, opts, testWriterStart)
+	require.NoError(t, OffloadDataFileSet(opts, testNs1ID, 0, testWriterStart))
+
+	unblock := make(chan struct{})
+	opts = opts.SetObjectStore(blockingStore{Store: opts.ObjectStore(), unblock: unblock})
+
+	cache := NewObjectStoreCache(DefaultObjectStoreCacheMaxBytes, 10*time.Millisecond)
+	err := cache.Fetch(opts, testNs1ID, 0, testWriterStart)
+	require.Equal(t, errObjectStoreFetchTimeout, err)
+	requireCachedDataFileSet(t, opts, testWriterStart, false)
+
+	// The fetch completes in the background once the object store responds.
+	close(unblock)
+	for {
+		exists, err := DataFileSetExistsAt(opts.ObjectStoreCachePathPrefix(), testNs1ID, 0, testWriterStart)
+		require.NoError(t, err)
+		if exists {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	require.NoError(t, cache.Fetch(opts, testNs1ID, 0, testWriterStart))
+}
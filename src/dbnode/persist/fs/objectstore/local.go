@@ -0,0 +1,186 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package objectstore
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	localStoreTempFilePrefix = ".tmp-"
+)
+
+var (
+	defaultLocalStoreNewFileMode      = os.FileMode(0666)
+	defaultLocalStoreNewDirectoryMode = os.ModeDir | os.FileMode(0755)
+
+	errInvalidKey = errors.New("object key must be a relative path")
+)
+
+type localStore struct {
+	rootDir string
+}
+
+// NewLocalStore returns a new Store that keeps objects as files in a local
+// directory, the key of an object is its path relative to the directory.
+// It is primarily intended for testing and for object stores that are
+// mounted as a filesystem.
+func NewLocalStore(rootDir string) Store {
+	return &localStore{rootDir: rootDir}
+}
+
+func (s *localStore) Put(key string, r io.Reader) error {
+	filePath, err := s.filePath(key)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(filePath)
+	if err := os.MkdirAll(dir, defaultLocalStoreNewDirectoryMode); err != nil {
+		return err
+	}
+
+	// Write to a temporary file and then rename it into place so that
+	// readers never observe a partially written object.
+	fd, err := ioutil.TempFile(dir, localStoreTempFilePrefix)
+	if err != nil {
+		return err
+	}
+	tempPath := fd.Name()
+
+	if err := writeAndSync(fd, r); err != nil {
+		os.Remove(tempPath)
+		return err
+	}
+	if err := os.Chmod(tempPath, defaultLocalStoreNewFileMode); err != nil {
+		os.Remove(tempPath)
+		return err
+	}
+	if err := os.Rename(tempPath, filePath); err != nil {
+		os.Remove(tempPath)
+		return err
+	}
+	return nil
+}
+
+func (s *localStore) Get(key string) (io.ReadCloser, error) {
+	filePath, err := s.filePath(key)
+	if err != nil {
+		return nil, err
+	}
+
+	fd, err := os.Open(filePath)
+	if os.IsNotExist(err) {
+		return nil, ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return fd, nil
+}
+
+func (s *localStore) Exists(key string) (bool, error) {
+	filePath, err := s.filePath(key)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = os.Stat(filePath)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *localStore) List(prefix string) ([]string, error) {
+	var keys []string
+	err := filepath.Walk(s.rootDir, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() || strings.HasPrefix(info.Name(), localStoreTempFilePrefix) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.rootDir, filePath)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (s *localStore) Delete(key string) error {
+	filePath, err := s.filePath(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(filePath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (s *localStore) filePath(key string) (string, error) {
+	cleaned := path.Clean(key)
+	if cleaned == "." || path.IsAbs(cleaned) || cleaned == ".." ||
+		strings.HasPrefix(cleaned, "../") {
+		return "", errInvalidKey
+	}
+	return filepath.Join(s.rootDir, filepath.FromSlash(cleaned)), nil
+}
+
+func writeAndSync(fd *os.File, r io.Reader) error {
+	if _, err := io.Copy(fd, r); err != nil {
+		fd.Close()
+		return err
+	}
+	if err := fd.Sync(); err != nil {
+		fd.Close()
+		return err
+	}
+	return fd.Close()
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package objectstore
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLocalStore(t *testing.T) (Store, string) {
+	dir, err := ioutil.TempDir("", "objectstore")
+	require.NoError(t, err)
+	return NewLocalStore(dir), dir
+}
+
+func readObject(t *testing.T, store Store, key string) []byte {
+	r, err := store.Get(key)
+	require.NoError(t, err)
+	defer r.Close()
+
+	data, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	return data
+}
+
+func TestLocalStorePutGet(t *testing.T) {
+	store, dir := newTestLocalStore(t)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, store.Put("a/b/c", bytes.NewReader([]byte("foo"))))
+	assert.Equal(t, []byte("foo"), readObject(t, store, "a/b/c"))
+
+	// Put replaces existing objects.
+	require.NoError(t, store.Put("a/b/c", bytes.NewReader([]byte("bar"))))
+	assert.Equal(t, []byte("bar"), readObject(t, store, "a/b/c"))
+}
+
+func TestLocalStoreGetNotFound(t *testing.T) {
+	store, dir := newTestLocalStore(t)
+	defer os.RemoveAll(dir)
+
+	_, err := store.Get("a/b/c")
+	require.Equal(t, ErrObjectNotFound, err)
+}
+
+func TestLocalStoreExistsAndDelete(t *testing.T) {
+	store, dir := newTestLocalStore(t)
+	defer os.RemoveAll(dir)
+
+	exists, err := store.Exists("a")
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	require.NoError(t, store.Put("a", bytes.NewReader([]byte("foo"))))
+	exists, err = store.Exists("a")
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	require.NoError(t, store.Delete("a"))
+	exists, err = store.Exists("a")
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	// Deleting a missing object is not an error.
+	require.NoError(t, store.Delete("a"))
+}
+
+func TestLocalStoreList(t *testing.T) {
+	store, dir := newTestLocalStore(t)
+	defer os.RemoveAll(dir)
+
+	keys, err := store.List("")
+	require.NoError(t, err)
+	require.Empty(t, keys)
+
+	for _, key := range []string{"data/ns/1/b", "data/ns/1/a", "data/ns/2/a", "other"} {
+		require.NoError(t, store.Put(key, bytes.NewReader(nil)))
+	}
+
+	keys, err = store.List("data/ns/1/")
+	require.NoError(t, err)
+	require.Equal(t, []string{"data/ns/1/a", "data/ns/1/b"}, keys)
+
+	keys, err = store.List("")
+	require.NoError(t, err)
+	require.Equal(t, []string{"data/ns/1/a", "data/ns/1/b", "data/ns/2/a", "other"}, keys)
+}
+
+func TestLocalStoreInvalidKeys(t *testing.T) {
+	store, dir := newTestLocalStore(t)
+	defer os.RemoveAll(dir)
+
+	for _, key := range []string{"", ".", "/abs", "..", "../escape", "a/../../escape"} {
+		require.Equal(t, errInvalidKey, store.Put(key, bytes.NewReader(nil)), key)
+		_, err := store.Get(key)
+		require.Equal(t, errInvalidKey, err, key)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package objectstore provides an abstraction over object stores that
+// fileset files can be offloaded to once they are no longer hot.
+package objectstore
+
+import (
+	"errors"
+	"io"
+)
+
+var (
+	// ErrObjectNotFound is returned when an object does not exist.
+	ErrObjectNotFound = errors.New("object not found")
+)
+
+// Store is a flat key value store of immutable objects, keys are slash
+// separated paths.
+type Store interface {
+	// Put writes the contents of the reader to the object at the given key,
+	// replacing any existing object. A partially written object is never
+	// visible to readers.
+	Put(key string, r io.Reader) error
+
+	// Get returns a reader for the object at the given key, the caller is
+	// responsible for closing the reader. Returns ErrObjectNotFound if the
+	// object does not exist.
+	Get(key string) (io.ReadCloser, error)
+
+	// Exists returns whether an object exists at the given key.
+	Exists(key string) (bool, error)
+
+	// List returns the keys of all objects that begin with the given
+	// prefix in ascending order.
+	List(prefix string) ([]string, error)
+
+	// Delete deletes the object at the given key, deleting an object that
+	// does not exist is not an error.
+	Delete(key string) error
+}
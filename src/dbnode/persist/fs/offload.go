@@ -0,0 +1,358 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/persist/fs/objectstore"
+	"github.com/m3db/m3/src/dbnode/storage/namespace"
+	xerrors "github.com/m3db/m3x/errors"
+	"github.com/m3db/m3x/ident"
+)
+
+var (
+	errObjectStoreNotSet = errors.New("object store is not set")
+
+	// dataFileSetDigestedFileSuffixes are the suffixes of the data fileset
+	// files that have their digests recorded in the digest file, in the order
+	// that the digests are recorded.
+	dataFileSetDigestedFileSuffixes = []string{
+		infoFileSuffix,
+		indexFileSuffix,
+		summariesFileSuffix,
+		bloomFilterFileSuffix,
+		dataFileSuffix,
+	}
+)
+
+// dataFileSetObjectKey returns the object store key of a data fileset file,
+// keys mirror the path of the file relative to the file path prefix.
+func dataFileSetObjectKey(namespace ident.ID, shard uint32, blockStart time.Time, suffix string) string {
+	return filesetPathFromTime(ShardDataDirPath("", namespace, shard), blockStart, suffix)
+}
+
+// OffloadedDataFileSetObjectKeys returns the object store keys of every file of
+// an offloaded data fileset with the checkpoint file last, keys mirror the path
+// of each file relative to the file path prefix.
+func OffloadedDataFileSetObjectKeys(namespace ident.ID, shard uint32, blockStart time.Time) []string {
+	keys := make([]string, 0, len(dataFileSetDigestedFileSuffixes)+2)
+	for _, suffix := range dataFileSetDigestedFileSuffixes {
+		keys = append(keys, dataFileSetObjectKey(namespace, shard, blockStart, suffix))
+	}
+	return append(keys,
+		dataFileSetObjectKey(namespace, shard, blockStart, digestFileSuffix),
+		dataFileSetObjectKey(namespace, shard, blockStart, checkpointFileSuffix))
+}
+
+// OffloadBlockStartBefore returns the block start before which the flushed data
+// filesets of a namespace are eligible to be offloaded at the given time.
+func OffloadBlockStartBefore(nsOpts namespace.Options, t time.Time) time.Time {
+	blockSize := nsOpts.RetentionOptions().BlockSize()
+	return t.Add(-nsOpts.ColdStorageOptions().OffloadAfter()).Truncate(blockSize)
+}
+
+// DataFileSetOffloadedAt determines whether the data fileset for the given namespace,
+// shard and block start has been completely offloaded to the object store.
+func DataFileSetOffloadedAt(
+	store objectstore.Store,
+	namespace ident.ID,
+	shard uint32,
+	blockStart time.Time,
+) (bool, error) {
+	return store.Exists(dataFileSetObjectKey(namespace, shard, blockStart, checkpointFileSuffix))
+}
+
+// OffloadedDataFileSetBlockStarts returns the block starts of all data filesets for
+// the given namespace and shard that have been completely offloaded to the object store.
+func OffloadedDataFileSetBlockStarts(
+	store objectstore.Store,
+	namespace ident.ID,
+	shard uint32,
+) ([]time.Time, error) {
+	keys, err := store.List(ShardDataDirPath("", namespace, shard) + "/")
+	if err != nil {
+		return nil, err
+	}
+
+	checkpointFileNameSuffix := separator + checkpointFileSuffix + fileSuffix
+	var blockStarts []time.Time
+	for _, key := range keys {
+		fileName := path.Base(key)
+		if !strings.HasPrefix(fileName, filesetFilePrefix+separator) ||
+			!strings.HasSuffix(fileName, checkpointFileNameSuffix) {
+			continue
+		}
+		blockStart, err := TimeFromFileName(fileName)
+		if err != nil {
+			return nil, err
+		}
+		blockStarts = append(blockStarts, blockStart)
+	}
+	return blockStarts, nil
+}
+
+// OffloadDataFileSet uploads the complete data fileset for the given namespace, shard
+// and block start from the file path prefix to the object store. Each uploaded file is
+// read back and verified against the fileset digests, the checkpoint file is uploaded
+// last so that the fileset is only considered offloaded once every file is verified.
+func OffloadDataFileSet(
+	opts Options,
+	namespace ident.ID,
+	shard uint32,
+	blockStart time.Time,
+) error {
+	store := opts.ObjectStore()
+	if store == nil {
+		return errObjectStoreNotSet
+	}
+
+	var (
+		shardDir       = ShardDataDirPath(opts.FilePathPrefix(), namespace, shard)
+		checkpointPath = filesetPathFromTime(shardDir, blockStart, checkpointFileSuffix)
+		digestPath     = filesetPathFromTime(shardDir, blockStart, digestFileSuffix)
+		digestBuf      = digest.NewBuffer()
+	)
+	digestOfDigest, err := readCheckpointFile(checkpointPath, digestBuf)
+	if err != nil {
+		return err
+	}
+	digestBytes, err := ioutil.ReadFile(digestPath)
+	if err != nil {
+		return err
+	}
+	digests, err := decodeDataFileSetDigests(digestBytes, digestOfDigest)
+	if err != nil {
+		return fmt.Errorf("local digest file %s invalid: %v", digestPath, err)
+	}
+
+	for i, suffix := range dataFileSetDigestedFileSuffixes {
+		key := dataFileSetObjectKey(namespace, shard, blockStart, suffix)
+		if err := putFile(store, key, filesetPathFromTime(shardDir, blockStart, suffix)); err != nil {
+			return err
+		}
+		if err := verifyObject(store, key, digests[i]); err != nil {
+			return err
+		}
+	}
+
+	key := dataFileSetObjectKey(namespace, shard, blockStart, digestFileSuffix)
+	if err := store.Put(key, bytes.NewReader(digestBytes)); err != nil {
+		return err
+	}
+	if err := verifyObject(store, key, digestOfDigest); err != nil {
+		return err
+	}
+
+	digestBuf.WriteDigest(digestOfDigest)
+	key = dataFileSetObjectKey(namespace, shard, blockStart, checkpointFileSuffix)
+	if err := store.Put(key, bytes.NewReader(digestBuf)); err != nil {
+		return err
+	}
+	return verifyObject(store, key, digest.Checksum(digestBuf))
+}
+
+// FetchOffloadedDataFileSet downloads the data fileset for the given namespace, shard
+// and block start from the object store into the object store cache path prefix. Each
+// file is verified against the fileset digests as it is downloaded and the checkpoint
+// file is written last, so a partially fetched fileset is never considered complete.
+func FetchOffloadedDataFileSet(
+	opts Options,
+	namespace ident.ID,
+	shard uint32,
+	blockStart time.Time,
+) error {
+	store := opts.ObjectStore()
+	if store == nil {
+		return errObjectStoreNotSet
+	}
+
+	checkpointBytes, err := getObject(store,
+		dataFileSetObjectKey(namespace, shard, blockStart, checkpointFileSuffix))
+	if err != nil {
+		return err
+	}
+	if len(checkpointBytes) != CheckpointFileSizeBytes {
+		return fmt.Errorf("offloaded checkpoint file has invalid size: %d", len(checkpointBytes))
+	}
+	digestOfDigest := digest.ToBuffer(checkpointBytes).ReadDigest()
+
+	digestBytes, err := getObject(store,
+		dataFileSetObjectKey(namespace, shard, blockStart, digestFileSuffix))
+	if err != nil {
+		return err
+	}
+	digests, err := decodeDataFileSetDigests(digestBytes, digestOfDigest)
+	if err != nil {
+		return fmt.Errorf("offloaded digest file invalid: %v", err)
+	}
+
+	shardDir := ShardDataDirPath(opts.ObjectStoreCachePathPrefix(), namespace, shard)
+	if err := os.MkdirAll(shardDir, opts.NewDirectoryMode()); err != nil {
+		return err
+	}
+
+	for i, suffix := range dataFileSetDigestedFileSuffixes {
+		var (
+			key      = dataFileSetObjectKey(namespace, shard, blockStart, suffix)
+			filePath = filesetPathFromTime(shardDir, blockStart, suffix)
+		)
+		if err := getFile(store, key, filePath, opts.NewFileMode(), digests[i]); err != nil {
+			return err
+		}
+	}
+
+	if err := writeAndSyncFile(filesetPathFromTime(shardDir, blockStart, digestFileSuffix),
+		bytes.NewReader(digestBytes), opts.NewFileMode()); err != nil {
+		return err
+	}
+	return writeAndSyncFile(filesetPathFromTime(shardDir, blockStart, checkpointFileSuffix),
+		bytes.NewReader(checkpointBytes), opts.NewFileMode())
+}
+
+// DeleteOffloadedDataFileSetAt deletes the data fileset for the given namespace, shard
+// and block start from the object store. The checkpoint file is deleted first so that
+// the fileset is no longer considered offloaded if deleting any other file fails.
+func DeleteOffloadedDataFileSetAt(
+	store objectstore.Store,
+	namespace ident.ID,
+	shard uint32,
+	blockStart time.Time,
+) error {
+	err := store.Delete(dataFileSetObjectKey(namespace, shard, blockStart, checkpointFileSuffix))
+	if err != nil {
+		return err
+	}
+
+	multiErr := xerrors.NewMultiError()
+	suffixes := append([]string{digestFileSuffix}, dataFileSetDigestedFileSuffixes...)
+	for _, suffix := range suffixes {
+		err := store.Delete(dataFileSetObjectKey(namespace, shard, blockStart, suffix))
+		multiErr = multiErr.Add(err)
+	}
+	return multiErr.FinalError()
+}
+
+func decodeDataFileSetDigests(digestBytes []byte, digestOfDigest uint32) ([]uint32, error) {
+	if digest.Checksum(digestBytes) != digestOfDigest {
+		return nil, errors.New("digest of digest file mismatch")
+	}
+	expectedLen := len(dataFileSetDigestedFileSuffixes) * digest.DigestLenBytes
+	if len(digestBytes) != expectedLen {
+		return nil, fmt.Errorf("digest file has invalid size: expected=%d, actual=%d",
+			expectedLen, len(digestBytes))
+	}
+
+	digests := make([]uint32, 0, len(dataFileSetDigestedFileSuffixes))
+	for i := 0; i < len(digestBytes); i += digest.DigestLenBytes {
+		digests = append(digests, digest.ToBuffer(digestBytes[i:]).ReadDigest())
+	}
+	return digests, nil
+}
+
+func putFile(store objectstore.Store, key string, filePath string) error {
+	fd, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+
+	return store.Put(key, fd)
+}
+
+func getObject(store objectstore.Store, key string) ([]byte, error) {
+	r, err := store.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return ioutil.ReadAll(r)
+}
+
+// verifyObject reads back the object at the given key and verifies its digest.
+func verifyObject(store objectstore.Store, key string, expectedDigest uint32) error {
+	r, err := store.Get(key)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	reader := digest.NewReaderWithDigest(r)
+	if _, err := io.Copy(ioutil.Discard, reader); err != nil {
+		return err
+	}
+	if err := reader.Validate(expectedDigest); err != nil {
+		return fmt.Errorf("offloaded object %s failed verification: %v", key, err)
+	}
+	return nil
+}
+
+// getFile downloads the object at the given key to a file, verifying its digest.
+func getFile(
+	store objectstore.Store,
+	key string,
+	filePath string,
+	perm os.FileMode,
+	expectedDigest uint32,
+) error {
+	r, err := store.Get(key)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	reader := digest.NewReaderWithDigest(r)
+	if err := writeAndSyncFile(filePath, reader, perm); err != nil {
+		return err
+	}
+	if err := reader.Validate(expectedDigest); err != nil {
+		return fmt.Errorf("offloaded object %s failed verification: %v", key, err)
+	}
+	return nil
+}
+
+func writeAndSyncFile(filePath string, r io.Reader, perm os.FileMode) error {
+	fd, err := OpenWritable(filePath, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(fd, r); err != nil {
+		// NB: intentionally skipping fd.Close() error, as failure
+		// to write takes precedence over failure to close the file
+		fd.Close()
+		return err
+	}
+	if err := fd.Sync(); err != nil {
+		fd.Close()
+		return err
+	}
+	return fd.Close()
+}
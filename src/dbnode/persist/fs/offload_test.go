@@ -0,0 +1,172 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs/objectstore"
+	"github.com/m3db/m3/src/dbnode/retention"
+	"github.com/m3db/m3/src/dbnode/storage/namespace"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// corruptingStore corrupts the contents of objects as they are written.
+type corruptingStore struct {
+	objectstore.Store
+}
+
+func (s corruptingStore) Put(key string, r io.Reader) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return s.Store.Put(key, bytes.NewReader(append(data, 0xff)))
+}
+
+func newTestOffloadOptions(t *testing.T) (Options, string) {
+	dir, err := ioutil.TempDir("", "testoffload")
+	require.NoError(t, err)
+
+	opts := testDefaultOpts.
+		SetFilePathPrefix(filepath.Join(dir, "local")).
+		SetObjectStore(objectstore.NewLocalStore(filepath.Join(dir, "store")))
+	return opts, dir
+}
+
+func writeTestOffloadData(t *testing.T, opts Options, blockStart time.Time) []testEntry {
+	entries := []testEntry{
+		{"foo", nil, []byte{1, 2, 3}},
+		{"bar", nil, []byte{4, 5, 6}},
+		{"baz", nil, []byte{7, 8, 9}},
+	}
+	w := newTestWriter(t, opts.FilePathPrefix())
+	writeTestData(t, w, 0, blockStart, entries, persist.FileSetFlushType)
+	return entries
+}
+
+func TestOffloadAndFetchDataFileSet(t *testing.T) {
+	opts, dir := newTestOffloadOptions(t)
+	defer os.RemoveAll(dir)
+
+	entries := writeTestOffloadData(t, opts, testWriterStart)
+
+	offloaded, err := DataFileSetOffloadedAt(opts.ObjectStore(), testNs1ID, 0, testWriterStart)
+	require.NoError(t, err)
+	require.False(t, offloaded)
+
+	require.NoError(t, OffloadDataFileSet(opts, testNs1ID, 0, testWriterStart))
+
+	offloaded, err = DataFileSetOffloadedAt(opts.ObjectStore(), testNs1ID, 0, testWriterStart)
+	require.NoError(t, err)
+	require.True(t, offloaded)
+
+	blockStarts, err := OffloadedDataFileSetBlockStarts(opts.ObjectStore(), testNs1ID, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(blockStarts))
+	require.True(t, testWriterStart.Equal(blockStarts[0]))
+
+	// Fetch into the cache and make sure the fetched fileset can be read.
+	require.NoError(t, FetchOffloadedDataFileSet(opts, testNs1ID, 0, testWriterStart))
+	exists, err := DataFileSetExistsAt(opts.ObjectStoreCachePathPrefix(), testNs1ID, 0, testWriterStart)
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	s := newTestSeeker(opts.ObjectStoreCachePathPrefix())
+	require.NoError(t, s.Open(testNs1ID, 0, testWriterStart))
+	for _, entry := range entries {
+		data, err := s.SeekByID(entry.ID())
+		require.NoError(t, err)
+
+		data.IncRef()
+		assert.Equal(t, entry.data, data.Bytes())
+		data.DecRef()
+	}
+	require.NoError(t, s.Close())
+}
+
+func TestOffloadDataFileSetVerifiesUpload(t *testing.T) {
+	opts, dir := newTestOffloadOptions(t)
+	defer os.RemoveAll(dir)
+
+	writeTestOffloadData(t, opts, testWriterStart)
+
+	opts = opts.SetObjectStore(corruptingStore{opts.ObjectStore()})
+	require.Error(t, OffloadDataFileSet(opts, testNs1ID, 0, testWriterStart))
+
+	offloaded, err := DataFileSetOffloadedAt(opts.ObjectStore(), testNs1ID, 0, testWriterStart)
+	require.NoError(t, err)
+	require.False(t, offloaded)
+}
+
+func TestFetchOffloadedDataFileSetVerifiesDownload(t *testing.T) {
+	opts, dir := newTestOffloadOptions(t)
+	defer os.RemoveAll(dir)
+
+	writeTestOffloadData(t, opts, testWriterStart)
+	require.NoError(t, OffloadDataFileSet(opts, testNs1ID, 0, testWriterStart))
+
+	// Corrupt the offloaded data file.
+	key := dataFileSetObjectKey(testNs1ID, 0, testWriterStart, dataFileSuffix)
+	data, err := getObject(opts.ObjectStore(), key)
+	require.NoError(t, err)
+	data[0]++
+	require.NoError(t, opts.ObjectStore().Put(key, bytes.NewReader(data)))
+
+	require.Error(t, FetchOffloadedDataFileSet(opts, testNs1ID, 0, testWriterStart))
+	exists, err := DataFileSetExistsAt(opts.ObjectStoreCachePathPrefix(), testNs1ID, 0, testWriterStart)
+	require.NoError(t, err)
+	require.False(t, exists)
+}
+
+func TestDeleteOffloadedDataFileSetAt(t *testing.T) {
+	opts, dir := newTestOffloadOptions(t)
+	defer os.RemoveAll(dir)
+
+	writeTestOffloadData(t, opts, testWriterStart)
+	require.NoError(t, OffloadDataFileSet(opts, testNs1ID, 0, testWriterStart))
+	require.NoError(t, DeleteOffloadedDataFileSetAt(opts.ObjectStore(), testNs1ID, 0, testWriterStart))
+
+	keys, err := opts.ObjectStore().List("")
+	require.NoError(t, err)
+	require.Empty(t, keys)
+}
+
+func TestOffloadBlockStartBefore(t *testing.T) {
+	nsOpts := namespace.NewOptions().
+		SetRetentionOptions(retention.NewOptions().SetBlockSize(2 * time.Hour)).
+		SetColdStorageOptions(namespace.NewColdStorageOptions().
+			SetEnabled(true).
+			SetOffloadAfter(24 * time.Hour))
+
+	now := time.Date(2018, 1, 10, 5, 0, 0, 0, time.UTC)
+	expected := time.Date(2018, 1, 9, 4, 0, 0, 0, time.UTC)
+	require.Equal(t, expected, OffloadBlockStartBefore(nsOpts, now))
+}
@@ -24,9 +24,13 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path"
+	"time"
 
 	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/persist/fs/msgpack"
+	"github.com/m3db/m3/src/dbnode/persist/fs/objectstore"
 	"github.com/m3db/m3/src/dbnode/runtime"
 	"github.com/m3db/m3/src/m3ninx/index/segment/fst"
 	"github.com/m3db/m3/src/x/serialize"
@@ -66,6 +70,10 @@ const (
 	// defaultForceIndexBloomFilterMmapMemory is the default configuration for whether the bytes for the bloom filter
 	// should be mmap'd as an anonymous region (forced completely into memory) or mmap'd as a file.
 	defaultForceIndexBloomFilterMmapMemory = false
+
+	// objectStoreCacheDirName is the name of the directory within the file path prefix
+	// that offloaded filesets are fetched into if no cache path prefix is set
+	objectStoreCacheDirName = "objectstore-cache"
 )
 
 var (
@@ -75,6 +83,8 @@ var (
 
 	errTagEncoderPoolNotSet = errors.New("tag encoder pool is not set")
 	errTagDecoderPoolNotSet = errors.New("tag decoder pool is not set")
+
+	errSeekCheckpointIntervalNegative = errors.New("seek checkpoint interval is negative")
 )
 
 type options struct {
@@ -98,6 +108,11 @@ type options struct {
 	tagEncoderPool                       serialize.TagEncoderPool
 	tagDecoderPool                       serialize.TagDecoderPool
 	fstOptions                           fst.Options
+	objectStore                          objectstore.Store
+	objectStoreCachePathPrefix           string
+	objectStoreCache                     ObjectStoreCache
+	seekCheckpointInterval               time.Duration
+	encodingOpts                         encoding.Options
 }
 
 // NewOptions creates a new set of fs options
@@ -131,6 +146,9 @@ func NewOptions() Options {
 		tagEncoderPool:                       tagEncoderPool,
 		tagDecoderPool:                       tagDecoderPool,
 		fstOptions:                           fstOptions,
+		objectStoreCache: NewObjectStoreCache(DefaultObjectStoreCacheMaxBytes,
+			DefaultObjectStoreFetchTimeout),
+		encodingOpts: encoding.NewOptions(),
 	}
 }
 
@@ -151,6 +169,9 @@ func (o *options) Validate() error {
 	if o.tagDecoderPool == nil {
 		return errTagDecoderPoolNotSet
 	}
+	if o.seekCheckpointInterval < 0 {
+		return errSeekCheckpointIntervalNegative
+	}
 	return nil
 }
 
@@ -353,3 +374,56 @@ func (o *options) SetFSTOptions(value fst.Options) Options {
 func (o *options) FSTOptions() fst.Options {
 	return o.fstOptions
 }
+
+func (o *options) SetObjectStore(value objectstore.Store) Options {
+	opts := *o
+	opts.objectStore = value
+	return &opts
+}
+
+func (o *options) ObjectStore() objectstore.Store {
+	return o.objectStore
+}
+
+func (o *options) SetObjectStoreCachePathPrefix(value string) Options {
+	opts := *o
+	opts.objectStoreCachePathPrefix = value
+	return &opts
+}
+
+func (o *options) ObjectStoreCachePathPrefix() string {
+	if o.objectStoreCachePathPrefix == "" {
+		return path.Join(o.filePathPrefix, objectStoreCacheDirName)
+	}
+	return o.objectStoreCachePathPrefix
+}
+
+func (o *options) SetObjectStoreCache(value ObjectStoreCache) Options {
+	opts := *o
+	opts.objectStoreCache = value
+	return &opts
+}
+
+func (o *options) ObjectStoreCache() ObjectStoreCache {
+	return o.objectStoreCache
+}
+
+func (o *options) SetSeekCheckpointInterval(value time.Duration) Options {
+	opts := *o
+	opts.seekCheckpointInterval = value
+	return &opts
+}
+
+func (o *options) SeekCheckpointInterval() time.Duration {
+	return o.seekCheckpointInterval
+}
+
+func (o *options) SetEncodingOptions(value encoding.Options) Options {
+	opts := *o
+	opts.encodingOpts = value
+	return &opts
+}
+
+func (o *options) EncodingOptions() encoding.Options {
+	return o.encodingOpts
+}
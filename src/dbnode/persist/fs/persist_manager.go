@@ -437,6 +437,12 @@ func (pm *persistManager) PrepareData(opts persist.DataPrepareOptions) (persist.
 			VolumeIndex: volumeIndex,
 		},
 	}
+	// Seek checkpoints are only placed in flushed M3TSZ encoded data since
+	// snapshots are never read by queries.
+	if opts.FileSetType == persist.FileSetFlushType &&
+		!nsMetadata.Options().SchemaOptions().Enabled() {
+		dataWriterOpts.SeekCheckpointInterval = pm.opts.SeekCheckpointInterval()
+	}
 	if err := pm.dataPM.writer.Open(dataWriterOpts); err != nil {
 		return prepared, err
 	}
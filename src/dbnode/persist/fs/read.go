@@ -48,6 +48,9 @@ var (
 
 	// errReadNotExpectedSize returned when the size of the next read does not match size specified by the index
 	errReadNotExpectedSize = errors.New("next read not expected size")
+
+	// errReadDataDigestMismatch returned when the digest of the data file does not match the expected digest
+	errReadDataDigestMismatch = errors.New("data file digest does not match the expected digest")
 )
 
 type reader struct {
@@ -60,6 +63,11 @@ type reader struct {
 	start     time.Time
 	blockSize time.Duration
 
+	// Sub-block of a compacted file set that is being read.
+	subBlockSize  time.Duration
+	subBlockIdx   int
+	readSubBlocks bool
+
 	infoFdWithDigest           digest.FdWithDigestReader
 	bloomFilterWithDigest      digest.FdWithDigestReader
 	digestFdWithDigestContents digest.FdWithDigestContentsReader
@@ -228,6 +236,14 @@ func (r *reader) Open(opts DataReaderOpenOptions) error {
 		r.Close()
 		return err
 	}
+	subBlockStart := opts.SubBlockStart
+	if subBlockStart.IsZero() {
+		subBlockStart = blockStart
+	}
+	if err := r.selectSubBlock(subBlockStart); err != nil {
+		r.Close()
+		return err
+	}
 	if err := r.readIndexAndSortByOffsetAsc(); err != nil {
 		r.Close()
 		return err
@@ -283,6 +299,7 @@ func (r *reader) readInfo(size int) error {
 	}
 	r.start = xtime.FromNanoseconds(info.BlockStart)
 	r.blockSize = time.Duration(info.BlockSize)
+	r.subBlockSize = time.Duration(info.SubBlockSize)
 	r.entries = int(info.Entries)
 	r.entriesRead = 0
 	r.metadataRead = 0
@@ -290,6 +307,21 @@ func (r *reader) readInfo(size int) error {
 	return nil
 }
 
+// selectSubBlock narrows the volume to the sub-block with the given start when
+// reading a compacted file set, the volume then appears as a regular file set
+// for the sub-block that only contains the series with data in the sub-block.
+func (r *reader) selectSubBlock(subBlockStart time.Time) error {
+	idx, ok, err := subBlockIndex(r.start, r.blockSize, r.subBlockSize, subBlockStart)
+	if err != nil || !ok {
+		return err
+	}
+	r.start = subBlockStart
+	r.blockSize = r.subBlockSize
+	r.subBlockIdx = idx
+	r.readSubBlocks = true
+	return nil
+}
+
 func (r *reader) readIndexAndSortByOffsetAsc() error {
 	r.decoder.Reset(r.indexDecoderStream)
 	for i := 0; i < r.entries; i++ {
@@ -297,8 +329,21 @@ func (r *reader) readIndexAndSortByOffsetAsc() error {
 		if err != nil {
 			return err
 		}
+		if r.readSubBlocks {
+			var ok bool
+			entry, ok, err = subBlockIndexEntry(entry, r.subBlockIdx)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+		}
 		r.indexEntriesByOffsetAsc = append(r.indexEntriesByOffsetAsc, entry)
 	}
+	if r.readSubBlocks {
+		r.entries = len(r.indexEntriesByOffsetAsc)
+	}
 	// NB(r): As we decode each block we need access to each index entry
 	// in the order we decode the data
 	sort.Sort(indexEntriesByOffsetAsc(r.indexEntriesByOffsetAsc))
@@ -332,13 +377,9 @@ func (r *reader) Read() (ident.ID, ident.TagIterator, checked.Bytes, uint32, err
 		defer data.DecRef()
 	}
 
-	n, err := r.dataReader.Read(data.Bytes())
-	if err != nil {
+	if err := r.readData(entry, data.Bytes()); err != nil {
 		return nil, nil, nil, 0, err
 	}
-	if n != int(entry.Size) {
-		return nil, nil, nil, 0, errReadNotExpectedSize
-	}
 
 	id := r.entryClonedID(entry.ID)
 	tags := r.entryClonedEncodedTagsIter(entry.EncodedTags)
@@ -347,6 +388,28 @@ func (r *reader) Read() (ident.ID, ident.TagIterator, checked.Bytes, uint32, err
 	return id, tags, data, uint32(entry.Checksum), nil
 }
 
+func (r *reader) readData(entry schema.IndexEntry, buf []byte) error {
+	if r.readSubBlocks {
+		// Sub-blocks are not contiguous in the data file so they are copied
+		// directly from the mmap rather than read through the digest reader.
+		end := entry.Offset + entry.Size
+		if entry.Offset < 0 || end > int64(len(r.dataMmap)) {
+			return errReadNotExpectedSize
+		}
+		copy(buf, r.dataMmap[entry.Offset:end])
+		return nil
+	}
+
+	n, err := r.dataReader.Read(buf)
+	if err != nil {
+		return err
+	}
+	if n != int(entry.Size) {
+		return errReadNotExpectedSize
+	}
+	return nil
+}
+
 func (r *reader) ReadMetadata() (ident.ID, ident.TagIterator, int, uint32, error) {
 	if r.metadataRead >= r.entries {
 		return nil, nil, 0, 0, io.EOF
@@ -422,6 +485,12 @@ func (r *reader) ValidateMetadata() error {
 // NB(xichen): ValidateData should be called after all data is read because
 // the digest is calculated for the entire data file.
 func (r *reader) ValidateData() error {
+	if r.readSubBlocks {
+		if digest.Checksum(r.dataMmap) != r.expectedDataDigest {
+			return fmt.Errorf("could not validate data file: %v", errReadDataDigestMismatch)
+		}
+		return nil
+	}
 	err := r.dataReader.Validate(r.expectedDataDigest)
 	if err != nil {
 		return fmt.Errorf("could not validate data file: %v", err)
@@ -0,0 +1,70 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package reshard
+
+import (
+	"errors"
+
+	"github.com/m3db/m3/src/cluster/placement"
+	"github.com/m3db/m3/src/cluster/placement/algo"
+	"github.com/m3db/m3/src/cluster/shard"
+)
+
+var (
+	errPlacementHasNoInstances = errors.New("placement has no instances")
+	errInvalidNumShards        = errors.New("number of shards must be positive")
+)
+
+// NewPlacement returns a placement with the instances and replica factor of the
+// given placement but with the given number of shards. Every shard is marked as
+// available since the resharded filesets are in place before the nodes start.
+func NewPlacement(p placement.Placement, numShards int) (placement.Placement, error) {
+	if numShards <= 0 {
+		return nil, errInvalidNumShards
+	}
+	existing := p.Instances()
+	if len(existing) == 0 {
+		return nil, errPlacementHasNoInstances
+	}
+
+	instances := make([]placement.Instance, 0, len(existing))
+	for _, instance := range existing {
+		instances = append(instances, instance.Clone().SetShards(shard.NewShards(nil)))
+	}
+	shardIDs := make([]uint32, 0, numShards)
+	for i := 0; i < numShards; i++ {
+		shardIDs = append(shardIDs, uint32(i))
+	}
+
+	opts := placement.NewOptions().
+		SetValidZone(existing[0].Zone()).
+		SetIsSharded(true)
+	a := algo.NewAlgorithm(opts)
+	newPlacement, err := a.InitialPlacement(instances, shardIDs, p.ReplicaFactor())
+	if err != nil {
+		return nil, err
+	}
+	newPlacement, _, err = a.MarkAllShardsAvailable(newPlacement)
+	if err != nil {
+		return nil, err
+	}
+	return newPlacement, nil
+}
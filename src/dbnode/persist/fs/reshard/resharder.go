@@ -0,0 +1,651 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package reshard
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/shard"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/sharding"
+	"github.com/m3db/m3/src/dbnode/storage/index/convert"
+	"github.com/m3db/m3/src/m3ninx/doc"
+	"github.com/m3db/m3/src/m3ninx/index/segment"
+	"github.com/m3db/m3/src/m3ninx/index/segment/mem"
+	m3ninxpersist "github.com/m3db/m3/src/m3ninx/persist"
+	xerrors "github.com/m3db/m3x/errors"
+	"github.com/m3db/m3x/ident"
+	xtime "github.com/m3db/m3x/time"
+)
+
+var (
+	errNamespaceNotSet             = errors.New("namespace not set")
+	errSourcePathPrefixesNotSet    = errors.New("source path prefixes not set")
+	errDestinationPathPrefixNotSet = errors.New("destination path prefix not set")
+	errPlacementNotSet             = errors.New("placement not set")
+	errPlacementHasNoShards        = errors.New("placement has no shards")
+)
+
+// sourceFileSet is a complete data fileset of a source shard.
+type sourceFileSet struct {
+	pathPrefix string
+	shard      uint32
+}
+
+// destFileSet identifies a data fileset written for an instance.
+type destFileSet struct {
+	pathPrefix string
+	shard      uint32
+}
+
+type destWriter struct {
+	writer  fs.DataFileSetWriter
+	entries int
+}
+
+type resharder struct {
+	opts Options
+}
+
+// NewResharder returns a new resharder.
+func NewResharder(opts Options) Resharder {
+	return &resharder{
+		opts: opts,
+	}
+}
+
+func (r *resharder) Reshard(args Args) (Result, error) {
+	if err := r.opts.Validate(); err != nil {
+		return Result{}, err
+	}
+	if err := validateArgs(args); err != nil {
+		return Result{}, err
+	}
+
+	numShards := args.Placement.NumShards()
+	shardIDs := make([]uint32, 0, numShards)
+	for i := 0; i < numShards; i++ {
+		shardIDs = append(shardIDs, uint32(i))
+	}
+	shardSet, err := sharding.NewShardSet(sharding.NewShards(shardIDs, shard.Available),
+		sharding.DefaultHashFn(numShards))
+	if err != nil {
+		return Result{}, err
+	}
+
+	// owners is the path prefixes of the instances owning each new shard.
+	var (
+		owners         = make([][]string, numShards)
+		instanceShards = make(map[string]map[uint32]struct{})
+	)
+	for _, instance := range args.Placement.Instances() {
+		pathPrefix := path.Join(args.DestinationPathPrefix, instance.ID())
+		instanceShards[pathPrefix] = make(map[uint32]struct{})
+		for _, id := range instance.Shards().AllIDs() {
+			if int(id) >= numShards {
+				return Result{}, fmt.Errorf("instance %s owns shard %d outside of the %d placement shards",
+					instance.ID(), id, numShards)
+			}
+			owners[id] = append(owners[id], pathPrefix)
+			instanceShards[pathPrefix][id] = struct{}{}
+		}
+	}
+
+	blocks, err := r.sourceDataFileSets(args)
+	if err != nil {
+		return Result{}, err
+	}
+
+	var result Result
+	for _, blockStart := range sortedBlockStarts(blocks) {
+		numSeries, numFileSets, err := r.reshardDataBlock(args.Namespace,
+			blockStart.ToTime(), blocks[blockStart], shardSet, owners)
+		if err != nil {
+			return Result{}, fmt.Errorf("unable to reshard data block %s: %v",
+				blockStart.ToTime().String(), err)
+		}
+		result.NumSeries += numSeries
+		result.NumDataFileSets += numFileSets
+	}
+
+	numIndexFileSets, err := r.reshardIndex(args, shardSet, owners, instanceShards)
+	if err != nil {
+		return Result{}, err
+	}
+	result.NumIndexFileSets = numIndexFileSets
+
+	return result, nil
+}
+
+func validateArgs(args Args) error {
+	if args.Namespace == nil {
+		return errNamespaceNotSet
+	}
+	if len(args.SourcePathPrefixes) == 0 {
+		return errSourcePathPrefixesNotSet
+	}
+	if args.DestinationPathPrefix == "" {
+		return errDestinationPathPrefixNotSet
+	}
+	if args.Placement == nil {
+		return errPlacementNotSet
+	}
+	if args.Placement.NumShards() == 0 {
+		return errPlacementHasNoShards
+	}
+	return nil
+}
+
+// sourceDataFileSets returns the complete source data filesets for each block
+// start, each shard is read from the first source path prefix that contains it.
+func (r *resharder) sourceDataFileSets(args Args) (map[xtime.UnixNano][]sourceFileSet, error) {
+	var (
+		seen   = make(map[uint32]struct{})
+		blocks = make(map[xtime.UnixNano][]sourceFileSet)
+	)
+	for _, pathPrefix := range args.SourcePathPrefixes {
+		shards, err := namespaceShards(pathPrefix, args.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		for _, shard := range shards {
+			if _, ok := seen[shard]; ok {
+				continue
+			}
+			seen[shard] = struct{}{}
+
+			files, err := fs.DataFiles(pathPrefix, args.Namespace, shard)
+			if err != nil {
+				return nil, err
+			}
+			for _, file := range files {
+				if !file.HasCheckpointFile() {
+					continue
+				}
+				blockStart := xtime.ToUnixNano(file.ID.BlockStart)
+				blocks[blockStart] = append(blocks[blockStart], sourceFileSet{
+					pathPrefix: pathPrefix,
+					shard:      shard,
+				})
+			}
+		}
+	}
+	return blocks, nil
+}
+
+// namespaceShards returns the shards with a data directory for the namespace.
+func namespaceShards(pathPrefix string, namespace ident.ID) ([]uint32, error) {
+	dir := fs.NamespaceDataDirPath(pathPrefix, namespace)
+	exists, err := fs.FileExists(dir)
+	if err != nil || !exists {
+		return nil, err
+	}
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	shards := make([]uint32, 0, len(infos))
+	for _, info := range infos {
+		if !info.IsDir() {
+			continue
+		}
+		shard, err := strconv.ParseUint(info.Name(), 10, 32)
+		if err != nil {
+			continue
+		}
+		shards = append(shards, uint32(shard))
+	}
+	return shards, nil
+}
+
+func sortedBlockStarts(blocks map[xtime.UnixNano][]sourceFileSet) []xtime.UnixNano {
+	blockStarts := make([]xtime.UnixNano, 0, len(blocks))
+	for blockStart := range blocks {
+		blockStarts = append(blockStarts, blockStart)
+	}
+	sort.Slice(blockStarts, func(i, j int) bool {
+		return blockStarts[i] < blockStarts[j]
+	})
+	return blockStarts
+}
+
+func (r *resharder) reshardDataBlock(
+	namespace ident.ID,
+	blockStart time.Time,
+	sources []sourceFileSet,
+	shardSet sharding.ShardSet,
+	owners [][]string,
+) (int64, int, error) {
+	var (
+		fsOpts    = r.opts.FilesystemOptions()
+		idPool    = r.opts.IdentifierPool()
+		writers   = make(map[destFileSet]*destWriter)
+		numSeries int64
+	)
+	closeWriters := func() error {
+		multiErr := xerrors.NewMultiError()
+		for _, w := range writers {
+			if w.writer == nil {
+				continue
+			}
+			multiErr = multiErr.Add(w.writer.Close())
+			w.writer = nil
+		}
+		return multiErr.FinalError()
+	}
+	defer closeWriters()
+
+	for _, source := range sources {
+		reader, err := fs.NewReader(r.opts.BytesPool(), fsOpts.SetFilePathPrefix(source.pathPrefix))
+		if err != nil {
+			return 0, 0, err
+		}
+		err = reader.Open(fs.DataReaderOpenOptions{
+			Identifier: fs.FileSetFileIdentifier{
+				Namespace:  namespace,
+				Shard:      source.shard,
+				BlockStart: blockStart,
+			},
+			FileSetType: persist.FileSetFlushType,
+		})
+		if err != nil {
+			return 0, 0, err
+		}
+		blockSize := reader.Range().End.Sub(reader.Range().Start)
+
+		for {
+			id, tagsIter, data, checksum, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				reader.Close()
+				return 0, 0, err
+			}
+
+			tags, err := convert.TagsFromTagsIter(id, tagsIter, idPool)
+			tagsIter.Close()
+			if err != nil {
+				reader.Close()
+				return 0, 0, err
+			}
+
+			shardID := shardSet.Lookup(id)
+			data.IncRef()
+			for _, pathPrefix := range owners[shardID] {
+				key := destFileSet{pathPrefix: pathPrefix, shard: shardID}
+				w, ok := writers[key]
+				if !ok {
+					w, err = r.openWriter(namespace, key, blockStart, blockSize)
+					if err != nil {
+						break
+					}
+					writers[key] = w
+				}
+				if err = w.writer.Write(id, tags, data, checksum); err != nil {
+					break
+				}
+				w.entries++
+			}
+			data.DecRef()
+			data.Finalize()
+			if err != nil {
+				reader.Close()
+				return 0, 0, err
+			}
+			numSeries++
+		}
+
+		// All entries have been read so the digests of every file can be validated.
+		if err := reader.Validate(); err != nil {
+			reader.Close()
+			return 0, 0, fmt.Errorf("invalid source fileset for shard %d at %s: %v",
+				source.shard, source.pathPrefix, err)
+		}
+		if err := reader.Close(); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	if err := closeWriters(); err != nil {
+		return 0, 0, err
+	}
+
+	for key, w := range writers {
+		if err := r.verifyDataFileSet(namespace, key, blockStart, w.entries); err != nil {
+			return 0, 0, fmt.Errorf("invalid resharded fileset for shard %d at %s: %v",
+				key.shard, key.pathPrefix, err)
+		}
+	}
+
+	return numSeries, len(writers), nil
+}
+
+func (r *resharder) openWriter(
+	namespace ident.ID,
+	key destFileSet,
+	blockStart time.Time,
+	blockSize time.Duration,
+) (*destWriter, error) {
+	writer, err := fs.NewWriter(r.opts.FilesystemOptions().SetFilePathPrefix(key.pathPrefix))
+	if err != nil {
+		return nil, err
+	}
+	err = writer.Open(fs.DataWriterOpenOptions{
+		BlockSize: blockSize,
+		Identifier: fs.FileSetFileIdentifier{
+			Namespace:  namespace,
+			Shard:      key.shard,
+			BlockStart: blockStart,
+		},
+		FileSetType: persist.FileSetFlushType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &destWriter{writer: writer}, nil
+}
+
+// verifyDataFileSet reads back a written data fileset and validates it against
+// its digests and the number of entries written.
+func (r *resharder) verifyDataFileSet(
+	namespace ident.ID,
+	key destFileSet,
+	blockStart time.Time,
+	expectedEntries int,
+) error {
+	reader, err := fs.NewReader(r.opts.BytesPool(),
+		r.opts.FilesystemOptions().SetFilePathPrefix(key.pathPrefix))
+	if err != nil {
+		return err
+	}
+	err = reader.Open(fs.DataReaderOpenOptions{
+		Identifier: fs.FileSetFileIdentifier{
+			Namespace:  namespace,
+			Shard:      key.shard,
+			BlockStart: blockStart,
+		},
+		FileSetType: persist.FileSetFlushType,
+	})
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	if reader.Entries() != expectedEntries {
+		return fmt.Errorf("fileset has %d entries but expected %d entries",
+			reader.Entries(), expectedEntries)
+	}
+	for {
+		id, tagsIter, data, _, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		id.Finalize()
+		tagsIter.Close()
+		data.Finalize()
+	}
+	return reader.Validate()
+}
+
+// reshardIndex rewrites the complete index filesets of the sources for each
+// instance, retaining only the documents of the series owned by the instance.
+func (r *resharder) reshardIndex(
+	args Args,
+	shardSet sharding.ShardSet,
+	owners [][]string,
+	instanceShards map[string]map[uint32]struct{},
+) (int, error) {
+	var (
+		fsOpts = r.opts.FilesystemOptions()
+		blocks = make(map[xtime.UnixNano][]fs.ReadIndexInfoFileResult)
+		paths  = make(map[fs.FileSetFileIdentifier]string)
+	)
+	for _, pathPrefix := range args.SourcePathPrefixes {
+		infoFiles := fs.ReadIndexInfoFiles(pathPrefix, args.Namespace,
+			fsOpts.InfoReaderBufferSize())
+		for _, infoFile := range infoFiles {
+			if err := infoFile.Err.Error(); err != nil {
+				return 0, fmt.Errorf("unable to read index info file %s: %v",
+					infoFile.Err.Filepath(), err)
+			}
+			blockStart := xtime.UnixNano(infoFile.Info.BlockStart)
+			blocks[blockStart] = append(blocks[blockStart], infoFile)
+			paths[infoFile.ID] = pathPrefix
+		}
+	}
+
+	blockStarts := make([]xtime.UnixNano, 0, len(blocks))
+	for blockStart := range blocks {
+		blockStarts = append(blockStarts, blockStart)
+	}
+	sort.Slice(blockStarts, func(i, j int) bool {
+		return blockStarts[i] < blockStarts[j]
+	})
+
+	numFileSets := 0
+	for _, blockStart := range blockStarts {
+		n, err := r.reshardIndexBlock(args.Namespace, blocks[blockStart], paths,
+			shardSet, owners, instanceShards)
+		if err != nil {
+			return 0, fmt.Errorf("unable to reshard index block %s: %v",
+				blockStart.ToTime().String(), err)
+		}
+		numFileSets += n
+	}
+	return numFileSets, nil
+}
+
+func (r *resharder) reshardIndexBlock(
+	namespace ident.ID,
+	infoFiles []fs.ReadIndexInfoFileResult,
+	paths map[fs.FileSetFileIdentifier]string,
+	shardSet sharding.ShardSet,
+	owners [][]string,
+	instanceShards map[string]map[uint32]struct{},
+) (int, error) {
+	var (
+		fsOpts    = r.opts.FilesystemOptions()
+		segments  = make(map[string]segment.MutableSegment)
+		blockSize time.Duration
+	)
+	defer func() {
+		for _, seg := range segments {
+			seg.Close()
+		}
+	}()
+
+	for _, infoFile := range infoFiles {
+		blockSize = time.Duration(infoFile.Info.BlockSize)
+		insert := func(d doc.Document) error {
+			for _, pathPrefix := range owners[shardSet.Lookup(ident.BytesID(d.ID))] {
+				seg, ok := segments[pathPrefix]
+				if !ok {
+					var err error
+					seg, err = mem.NewSegment(0, mem.NewOptions())
+					if err != nil {
+						return err
+					}
+					segments[pathPrefix] = seg
+				}
+				// Documents already inserted from another source, such as another
+				// replica of the same shard, are skipped. NB: mem segments insert an
+				// empty document rather than returning an error for duplicate IDs.
+				exists, err := seg.ContainsID(d.ID)
+				if err != nil {
+					return err
+				}
+				if exists {
+					continue
+				}
+				if _, err := seg.Insert(cloneDocument(d)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		err := r.readIndexFileSet(paths[infoFile.ID], infoFile.ID, insert)
+		if err != nil {
+			return 0, fmt.Errorf("invalid source index fileset at %s: %v",
+				paths[infoFile.ID], err)
+		}
+	}
+
+	for pathPrefix, seg := range segments {
+		writer, err := fs.NewIndexWriter(fsOpts.SetFilePathPrefix(pathPrefix))
+		if err != nil {
+			return 0, err
+		}
+		err = writer.Open(fs.IndexWriterOpenOptions{
+			Identifier: fs.FileSetFileIdentifier{
+				FileSetContentType: persist.FileSetIndexContentType,
+				Namespace:          namespace,
+				BlockStart:         infoFiles[0].ID.BlockStart,
+			},
+			BlockSize:   blockSize,
+			FileSetType: persist.FileSetFlushType,
+			Shards:      instanceShards[pathPrefix],
+		})
+		if err != nil {
+			return 0, err
+		}
+		segWriter, err := m3ninxpersist.NewMutableSegmentFileSetWriter()
+		if err != nil {
+			writer.Close()
+			return 0, err
+		}
+		if err := segWriter.Reset(seg); err != nil {
+			writer.Close()
+			return 0, err
+		}
+		if err := writer.WriteSegmentFileSet(segWriter); err != nil {
+			writer.Close()
+			return 0, err
+		}
+		if err := writer.Close(); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(segments), nil
+}
+
+// readIndexFileSet calls fn with every document of an index fileset, the
+// fileset is validated against its digests before any document is read.
+func (r *resharder) readIndexFileSet(
+	pathPrefix string,
+	id fs.FileSetFileIdentifier,
+	fn func(d doc.Document) error,
+) error {
+	fsOpts := r.opts.FilesystemOptions().SetFilePathPrefix(pathPrefix)
+	reader, err := fs.NewIndexReader(fsOpts)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	_, err = reader.Open(fs.IndexReaderOpenOptions{
+		Identifier:  id,
+		FileSetType: persist.FileSetFlushType,
+	})
+	if err != nil {
+		return err
+	}
+
+	var segments []segment.Segment
+	defer func() {
+		for _, seg := range segments {
+			seg.Close()
+		}
+	}()
+	for {
+		fileset, err := reader.ReadSegmentFileSet()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		seg, err := m3ninxpersist.NewSegment(fileset, fsOpts.FSTOptions())
+		if err != nil {
+			return err
+		}
+		segments = append(segments, seg)
+	}
+	if err := reader.Validate(); err != nil {
+		return err
+	}
+
+	for _, seg := range segments {
+		if err := readSegmentDocuments(seg, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readSegmentDocuments(seg segment.Segment, fn func(d doc.Document) error) error {
+	reader, err := seg.Reader()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	iter, err := reader.AllDocs()
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+
+	for iter.Next() {
+		if err := fn(iter.Current()); err != nil {
+			return err
+		}
+	}
+	return iter.Err()
+}
+
+// cloneDocument returns a copy of the document that does not reference the
+// bytes of the segment it was read from.
+func cloneDocument(d doc.Document) doc.Document {
+	fields := make([]doc.Field, 0, len(d.Fields))
+	for _, f := range d.Fields {
+		fields = append(fields, doc.Field{
+			Name:  append([]byte(nil), f.Name...),
+			Value: append([]byte(nil), f.Value...),
+		})
+	}
+	return doc.Document{
+		ID:     append([]byte(nil), d.ID...),
+		Fields: fields,
+	}
+}
@@ -0,0 +1,373 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package reshard
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/placement"
+	"github.com/m3db/m3/src/cluster/shard"
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/sharding"
+	"github.com/m3db/m3/src/dbnode/storage/index/convert"
+	"github.com/m3db/m3/src/m3ninx/index/segment/mem"
+	m3ninxpersist "github.com/m3db/m3/src/m3ninx/persist"
+	"github.com/m3db/m3x/checked"
+	"github.com/m3db/m3x/ident"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	numTestSeries = 100
+)
+
+var (
+	testNs         = ident.StringID("testns")
+	testBlockSize  = 2 * time.Hour
+	testBlockStart = time.Now().Truncate(testBlockSize)
+)
+
+func newTestShardSet(t *testing.T, numShards int) sharding.ShardSet {
+	ids := make([]uint32, 0, numShards)
+	for i := 0; i < numShards; i++ {
+		ids = append(ids, uint32(i))
+	}
+	shardSet, err := sharding.NewShardSet(sharding.NewShards(ids, shard.Available),
+		sharding.DefaultHashFn(numShards))
+	require.NoError(t, err)
+	return shardSet
+}
+
+func newTestPlacement(numShards int) placement.Placement {
+	var (
+		instances = make([]placement.Instance, 0, 2)
+		shardIDs  = make([]uint32, 0, numShards)
+	)
+	for i := 0; i < 2; i++ {
+		instances = append(instances, placement.NewEmptyInstance(
+			fmt.Sprintf("i%d", i), fmt.Sprintf("r%d", i), "z1", fmt.Sprintf("i%d:9000", i), 1))
+	}
+	for i := 0; i < numShards; i++ {
+		shardIDs = append(shardIDs, uint32(i))
+		instances[i%2].Shards().Add(shard.NewShard(uint32(i)).SetState(shard.Available))
+	}
+	return placement.NewPlacement().
+		SetInstances(instances).
+		SetShards(shardIDs).
+		SetReplicaFactor(1).
+		SetIsSharded(true)
+}
+
+func testSeriesID(i int) ident.ID {
+	return ident.StringID(fmt.Sprintf("series.%d", i))
+}
+
+func testSeriesTags(i int) ident.Tags {
+	return ident.NewTags(ident.StringTag("series", fmt.Sprintf("%d", i)))
+}
+
+// writeTestNode writes the data and index filesets of a node owning the given
+// shards of the source shard set.
+func writeTestNode(
+	t *testing.T,
+	pathPrefix string,
+	shardSet sharding.ShardSet,
+	shards []uint32,
+) {
+	fsOpts := fs.NewOptions().SetFilePathPrefix(pathPrefix)
+	owned := make(map[uint32]struct{}, len(shards))
+	for _, shard := range shards {
+		owned[shard] = struct{}{}
+	}
+
+	seg, err := mem.NewSegment(0, mem.NewOptions())
+	require.NoError(t, err)
+	for _, shard := range shards {
+		writer, err := fs.NewWriter(fsOpts)
+		require.NoError(t, err)
+		require.NoError(t, writer.Open(fs.DataWriterOpenOptions{
+			BlockSize: testBlockSize,
+			Identifier: fs.FileSetFileIdentifier{
+				Namespace:  testNs,
+				Shard:      shard,
+				BlockStart: testBlockStart,
+			},
+		}))
+		for i := 0; i < numTestSeries; i++ {
+			id := testSeriesID(i)
+			if shardSet.Lookup(id) != shard {
+				continue
+			}
+			data := []byte(id.String())
+			bytes := checked.NewBytes(data, nil)
+			bytes.IncRef()
+			require.NoError(t, writer.Write(id, testSeriesTags(i), bytes, digest.Checksum(data)))
+			bytes.DecRef()
+
+			d, err := convert.FromMetric(id, testSeriesTags(i))
+			require.NoError(t, err)
+			_, err = seg.Insert(d)
+			require.NoError(t, err)
+		}
+		require.NoError(t, writer.Close())
+	}
+
+	indexWriter, err := fs.NewIndexWriter(fsOpts)
+	require.NoError(t, err)
+	require.NoError(t, indexWriter.Open(fs.IndexWriterOpenOptions{
+		Identifier: fs.FileSetFileIdentifier{
+			FileSetContentType: persist.FileSetIndexContentType,
+			Namespace:          testNs,
+			BlockStart:         testBlockStart,
+		},
+		BlockSize:   testBlockSize,
+		FileSetType: persist.FileSetFlushType,
+		Shards:      owned,
+	}))
+	segWriter, err := m3ninxpersist.NewMutableSegmentFileSetWriter()
+	require.NoError(t, err)
+	require.NoError(t, segWriter.Reset(seg))
+	require.NoError(t, indexWriter.WriteSegmentFileSet(segWriter))
+	require.NoError(t, indexWriter.Close())
+}
+
+func readTestDataFileSet(t *testing.T, pathPrefix string, shard uint32) []string {
+	reader, err := fs.NewReader(nil, fs.NewOptions().SetFilePathPrefix(pathPrefix))
+	require.NoError(t, err)
+	require.NoError(t, reader.Open(fs.DataReaderOpenOptions{
+		Identifier: fs.FileSetFileIdentifier{
+			Namespace:  testNs,
+			Shard:      shard,
+			BlockStart: testBlockStart,
+		},
+		FileSetType: persist.FileSetFlushType,
+	}))
+	defer reader.Close()
+
+	var ids []string
+	for {
+		id, tagsIter, data, _, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		require.Equal(t, id.String(), string(data.Bytes()))
+		tagsIter.Close()
+		ids = append(ids, id.String())
+	}
+	require.NoError(t, reader.Validate())
+	return ids
+}
+
+func readTestIndexDocIDs(t *testing.T, pathPrefix string) ([]string, map[uint32]struct{}) {
+	fsOpts := fs.NewOptions().SetFilePathPrefix(pathPrefix)
+	infoFiles := fs.ReadIndexInfoFiles(pathPrefix, testNs, fsOpts.InfoReaderBufferSize())
+	require.Equal(t, 1, len(infoFiles))
+	require.NoError(t, infoFiles[0].Err.Error())
+
+	shards := make(map[uint32]struct{})
+	for _, shard := range infoFiles[0].Info.Shards {
+		shards[shard] = struct{}{}
+	}
+
+	segments, err := fs.ReadIndexSegments(fs.ReadIndexSegmentsOptions{
+		ReaderOptions: fs.IndexReaderOpenOptions{
+			Identifier:  infoFiles[0].ID,
+			FileSetType: persist.FileSetFlushType,
+		},
+		FilesystemOptions: fsOpts,
+	})
+	require.NoError(t, err)
+
+	var ids []string
+	for _, seg := range segments {
+		reader, err := seg.Reader()
+		require.NoError(t, err)
+		iter, err := reader.AllDocs()
+		require.NoError(t, err)
+		for iter.Next() {
+			ids = append(ids, string(iter.Current().ID))
+		}
+		require.NoError(t, iter.Err())
+		require.NoError(t, iter.Close())
+		require.NoError(t, reader.Close())
+		require.NoError(t, seg.Close())
+	}
+	return ids, shards
+}
+
+func TestReshard(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reshard")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var (
+		srcShardSet  = newTestShardSet(t, 2)
+		destShardSet = newTestShardSet(t, 8)
+		nodeA        = path.Join(dir, "nodeA")
+		nodeB        = path.Join(dir, "nodeB")
+		dest         = path.Join(dir, "dest")
+	)
+	writeTestNode(t, nodeA, srcShardSet, []uint32{0})
+	writeTestNode(t, nodeB, srcShardSet, []uint32{1})
+
+	p, err := NewPlacement(newTestPlacement(2), 8)
+	require.NoError(t, err)
+
+	result, err := NewResharder(NewOptions()).Reshard(Args{
+		Namespace:             testNs,
+		SourcePathPrefixes:    []string{nodeA, nodeB},
+		DestinationPathPrefix: dest,
+		Placement:             p,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(numTestSeries), result.NumSeries)
+	require.Equal(t, 2, result.NumIndexFileSets)
+
+	var (
+		dataIDs  = make(map[string]struct{})
+		indexIDs = make(map[string]struct{})
+	)
+	for _, instance := range p.Instances() {
+		pathPrefix := path.Join(dest, instance.ID())
+		owned := make(map[uint32]struct{})
+		for _, shard := range instance.Shards().AllIDs() {
+			owned[shard] = struct{}{}
+			for _, id := range readTestDataFileSet(t, pathPrefix, shard) {
+				require.Equal(t, shard, destShardSet.Lookup(ident.StringID(id)))
+				dataIDs[id] = struct{}{}
+			}
+		}
+
+		ids, shards := readTestIndexDocIDs(t, pathPrefix)
+		require.Equal(t, owned, shards)
+		for _, id := range ids {
+			_, ok := owned[destShardSet.Lookup(ident.StringID(id))]
+			require.True(t, ok)
+			indexIDs[id] = struct{}{}
+		}
+	}
+	require.Equal(t, numTestSeries, len(dataIDs))
+	require.Equal(t, dataIDs, indexIDs)
+}
+
+func TestReshardReplicatedSource(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reshard")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var (
+		srcShardSet = newTestShardSet(t, 2)
+		nodeA       = path.Join(dir, "nodeA")
+		nodeB       = path.Join(dir, "nodeB")
+		dest        = path.Join(dir, "dest")
+	)
+	// Both source nodes are replicas of every shard.
+	writeTestNode(t, nodeA, srcShardSet, []uint32{0, 1})
+	writeTestNode(t, nodeB, srcShardSet, []uint32{0, 1})
+
+	p, err := NewPlacement(newTestPlacement(2), 8)
+	require.NoError(t, err)
+
+	result, err := NewResharder(NewOptions()).Reshard(Args{
+		Namespace:             testNs,
+		SourcePathPrefixes:    []string{nodeA, nodeB},
+		DestinationPathPrefix: dest,
+		Placement:             p,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(numTestSeries), result.NumSeries)
+
+	// Every series is indexed exactly once, without empty documents for the
+	// duplicates read from the second replica.
+	indexIDs := make(map[string]struct{})
+	for _, instance := range p.Instances() {
+		ids, _ := readTestIndexDocIDs(t, path.Join(dest, instance.ID()))
+		for _, id := range ids {
+			require.NotEmpty(t, id)
+			_, ok := indexIDs[id]
+			require.False(t, ok)
+			indexIDs[id] = struct{}{}
+		}
+	}
+	require.Equal(t, numTestSeries, len(indexIDs))
+}
+
+func TestReshardInvalidSourceFileSet(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reshard")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var (
+		srcShardSet = newTestShardSet(t, 1)
+		node        = path.Join(dir, "node")
+	)
+	writeTestNode(t, node, srcShardSet, []uint32{0})
+
+	// Corrupt the data file of the source fileset.
+	dataFile := path.Join(fs.ShardDataDirPath(node, testNs, 0),
+		fmt.Sprintf("fileset-%d-data.db", testBlockStart.UnixNano()))
+	data, err := ioutil.ReadFile(dataFile)
+	require.NoError(t, err)
+	data[0]++
+	require.NoError(t, ioutil.WriteFile(dataFile, data, 0666))
+
+	p, err := NewPlacement(newTestPlacement(1), 4)
+	require.NoError(t, err)
+	_, err = NewResharder(NewOptions()).Reshard(Args{
+		Namespace:             testNs,
+		SourcePathPrefixes:    []string{node},
+		DestinationPathPrefix: path.Join(dir, "dest"),
+		Placement:             p,
+	})
+	require.Error(t, err)
+}
+
+func TestNewPlacement(t *testing.T) {
+	p, err := NewPlacement(newTestPlacement(2), 16)
+	require.NoError(t, err)
+	require.Equal(t, 16, p.NumShards())
+	require.Equal(t, 1, p.ReplicaFactor())
+	require.Equal(t, 2, p.NumInstances())
+
+	owned := make(map[uint32]struct{})
+	for _, instance := range p.Instances() {
+		for _, s := range instance.Shards().All() {
+			require.Equal(t, shard.Available, s.State())
+			_, ok := owned[s.ID()]
+			require.False(t, ok)
+			owned[s.ID()] = struct{}{}
+		}
+	}
+	require.Equal(t, 16, len(owned))
+
+	_, err = NewPlacement(newTestPlacement(2), 0)
+	require.Equal(t, errInvalidNumShards, err)
+}
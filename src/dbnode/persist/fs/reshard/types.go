@@ -0,0 +1,93 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package reshard
+
+import (
+	"github.com/m3db/m3/src/cluster/placement"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3x/ident"
+	"github.com/m3db/m3x/pool"
+)
+
+// Args are the arguments for a reshard.
+type Args struct {
+	// Namespace is the namespace to reshard.
+	Namespace ident.ID
+
+	// SourcePathPrefixes are the path prefixes of the nodes to read filesets
+	// from, each shard is read from the first path prefix that contains it.
+	SourcePathPrefixes []string
+
+	// DestinationPathPrefix is the directory the resharded filesets are written
+	// to, the filesets of each instance of the placement are written to a
+	// sub-directory named after the instance ID.
+	DestinationPathPrefix string
+
+	// Placement is the placement with the new number of shards, series are
+	// rehashed with the number of shards of the placement.
+	Placement placement.Placement
+}
+
+// Result is the result of a reshard.
+type Result struct {
+	// NumSeries is the number of series read from the source data filesets.
+	NumSeries int64
+
+	// NumDataFileSets is the number of data filesets written.
+	NumDataFileSets int
+
+	// NumIndexFileSets is the number of index filesets written.
+	NumIndexFileSets int
+}
+
+// Resharder rewrites the filesets of a namespace for a new number of shards.
+type Resharder interface {
+	// Reshard reads the complete data and index filesets of the namespace,
+	// rehashes every series to its shard in the new placement and writes new
+	// filesets for each instance of the placement. Every source fileset is
+	// validated against its digests once read and every written fileset is
+	// read back and validated against its digests.
+	Reshard(args Args) (Result, error)
+}
+
+// Options represents the options for resharding.
+type Options interface {
+	// Validate validates the options.
+	Validate() error
+
+	// SetFilesystemOptions sets the filesystem options.
+	SetFilesystemOptions(value fs.Options) Options
+
+	// FilesystemOptions returns the filesystem options.
+	FilesystemOptions() fs.Options
+
+	// SetBytesPool sets the bytes pool used when reading data filesets.
+	SetBytesPool(value pool.CheckedBytesPool) Options
+
+	// BytesPool returns the bytes pool used when reading data filesets.
+	BytesPool() pool.CheckedBytesPool
+
+	// SetIdentifierPool sets the identifier pool.
+	SetIdentifierPool(value ident.Pool) Options
+
+	// IdentifierPool returns the identifier pool.
+	IdentifierPool() ident.Pool
+}
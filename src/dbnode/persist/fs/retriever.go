@@ -32,12 +32,15 @@
 package fs
 
 import (
+	"bytes"
 	"errors"
 	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	"github.com/m3db/m3/src/dbnode/ts"
@@ -54,6 +57,7 @@ var (
 	errBlockRetrieverAlreadyOpenOrClosed = errors.New("block retriever already open or is closed")
 	errBlockRetrieverAlreadyClosed       = errors.New("block retriever already closed")
 	errNoSeekerMgr                       = errors.New("there is no open seeker manager")
+	errSeekCheckpointOutOfRange          = errors.New("seek checkpoint is out of range of the data")
 )
 
 const (
@@ -83,10 +87,11 @@ type blockRetriever struct {
 
 	newSeekerMgrFn newSeekerMgrFn
 
-	reqPool    retrieveRequestPool
-	bytesPool  pool.CheckedBytesPool
-	idPool     ident.Pool
-	nsMetadata namespace.Metadata
+	reqPool      retrieveRequestPool
+	bytesPool    pool.CheckedBytesPool
+	idPool       ident.Pool
+	encodingOpts encoding.Options
+	nsMetadata   namespace.Metadata
 
 	blockSize time.Duration
 
@@ -115,6 +120,7 @@ func NewBlockRetriever(
 		reqPool:        reqPool,
 		bytesPool:      opts.BytesPool(),
 		idPool:         opts.IdentifierPool(),
+		encodingOpts:   fsOpts.EncodingOptions().SetBytesPool(opts.BytesPool()),
 		status:         blockRetrieverNotOpen,
 		notifyFetch:    make(chan struct{}, 1),
 		// We just close this channel when the fetchLoops should shutdown, so no
@@ -303,6 +309,22 @@ func (r *blockRetriever) fetchBatch(
 			}
 		}
 
+		// Range requests of data written with seek checkpoints only decode the
+		// data from the checkpoint before the range, the partial block that
+		// results is not cached.
+		if data != nil && req.hasReadRange() {
+			seg, ok, err := r.readRangeFromCheckpoint(req, data)
+			if err != nil {
+				req.onError(err)
+				continue
+			}
+			if ok {
+				req.onRetrieved(seg)
+				req.onCallerOrRetrieverDone()
+				continue
+			}
+		}
+
 		var (
 			seg, onRetrieveSeg ts.Segment
 		)
@@ -358,12 +380,94 @@ func (r *blockRetriever) fetchBatch(
 	}
 }
 
+// readRangeFromCheckpoint re-encodes the datapoints of the read range of a
+// request, decoding the data from the last seek checkpoint before the range.
+// It returns false if the data has no such checkpoint.
+func (r *blockRetriever) readRangeFromCheckpoint(
+	req *retrieveRequest,
+	data checked.Bytes,
+) (ts.Segment, bool, error) {
+	if len(req.indexEntry.Checkpoints) == 0 {
+		return ts.Segment{}, false, nil
+	}
+	checkpoints, err := m3tsz.UnmarshalCheckpoints(req.indexEntry.Checkpoints)
+	if err != nil {
+		return ts.Segment{}, false, err
+	}
+	checkpoint, ok := m3tsz.CheckpointBefore(checkpoints, req.readStart)
+	if !ok {
+		return ts.Segment{}, false, nil
+	}
+
+	// The data is not handed to the request so release it once decoded.
+	data.IncRef()
+	defer func() {
+		data.DecRef()
+		data.Finalize()
+	}()
+
+	b := data.Bytes()
+	if checkpoint.ByteOffset() >= len(b) {
+		return ts.Segment{}, false, errSeekCheckpointOutOfRange
+	}
+
+	var (
+		intOptimized = m3tsz.DefaultIntOptimizationEnabled
+		iter         = m3tsz.NewReaderIteratorFromCheckpoint(
+			bytes.NewReader(b[checkpoint.ByteOffset():]), checkpoint, intOptimized, r.encodingOpts)
+		encoder = m3tsz.NewEncoder(req.start, nil, intOptimized, r.encodingOpts)
+	)
+	defer iter.Close()
+
+	for iter.Next() {
+		dp, unit, annotation := iter.Current()
+		if !dp.Timestamp.Before(req.readEnd) {
+			break
+		}
+		if dp.Timestamp.Before(req.readStart) {
+			continue
+		}
+		if err := encoder.Encode(dp, unit, annotation); err != nil {
+			encoder.Close()
+			return ts.Segment{}, false, err
+		}
+	}
+	if err := iter.Err(); err != nil {
+		encoder.Close()
+		return ts.Segment{}, false, err
+	}
+
+	return encoder.Discard(), true, nil
+}
+
 func (r *blockRetriever) Stream(
 	ctx context.Context,
 	shard uint32,
 	id ident.ID,
 	startTime time.Time,
 	onRetrieve block.OnRetrieveBlock,
+) (xio.BlockReader, error) {
+	return r.stream(ctx, shard, id, startTime, time.Time{}, time.Time{}, onRetrieve)
+}
+
+func (r *blockRetriever) StreamRange(
+	ctx context.Context,
+	shard uint32,
+	id ident.ID,
+	startTime time.Time,
+	readStart, readEnd time.Time,
+	onRetrieve block.OnRetrieveBlock,
+) (xio.BlockReader, error) {
+	return r.stream(ctx, shard, id, startTime, readStart, readEnd, onRetrieve)
+}
+
+func (r *blockRetriever) stream(
+	ctx context.Context,
+	shard uint32,
+	id ident.ID,
+	startTime time.Time,
+	readStart, readEnd time.Time,
+	onRetrieve block.OnRetrieveBlock,
 ) (xio.BlockReader, error) {
 	req := r.reqPool.Get()
 	req.shard = shard
@@ -372,6 +476,8 @@ func (r *blockRetriever) Stream(
 	req.id = r.idPool.Clone(id)
 	req.start = startTime
 	req.blockSize = r.blockSize
+	req.readStart = readStart
+	req.readEnd = readEnd
 
 	req.onRetrieve = onRetrieve
 	req.resultWg.Add(1)
@@ -521,6 +627,10 @@ type retrieveRequest struct {
 	blockSize  time.Duration
 	onRetrieve block.OnRetrieveBlock
 
+	// Only set for requests of a range of the block's datapoints.
+	readStart time.Time
+	readEnd   time.Time
+
 	indexEntry IndexEntry
 	reader     xio.SegmentReader
 
@@ -535,6 +645,10 @@ type retrieveRequest struct {
 	notFound bool
 }
 
+func (req *retrieveRequest) hasReadRange() bool {
+	return !req.readEnd.IsZero()
+}
+
 func (req *retrieveRequest) onError(err error) {
 	req.err = err
 	req.resultWg.Done()
@@ -621,6 +735,8 @@ func (req *retrieveRequest) resetForReuse() {
 	req.start = time.Time{}
 	req.blockSize = 0
 	req.onRetrieve = nil
+	req.readStart = time.Time{}
+	req.readEnd = time.Time{}
 	req.indexEntry = IndexEntry{}
 	req.reader = nil
 	req.err = nil
@@ -62,6 +62,7 @@ type seeker struct {
 	// Data read from the indexInfo file
 	start           time.Time
 	blockSize       time.Duration
+	subBlockSize    time.Duration
 	entries         int
 	bloomFilterInfo schema.IndexBloomFilterInfo
 	summariesInfo   schema.IndexSummariesInfo
@@ -82,6 +83,10 @@ type seeker struct {
 
 	keepUnreadBuf bool
 
+	// Sub-block of a compacted file set that is being seeked.
+	subBlockIdx   int
+	seekSubBlocks bool
+
 	isClone bool
 }
 
@@ -92,6 +97,7 @@ type IndexEntry struct {
 	Checksum    uint32
 	Offset      int64
 	EncodedTags []byte
+	Checkpoints []byte
 }
 
 // NewSeeker returns a new seeker.
@@ -139,6 +145,11 @@ type fileSetSeeker interface {
 
 	// setUnreadBuffer sets the unread buffer
 	setUnreadBuffer(buf []byte)
+
+	// openSubBlock opens the file set with the given start for seeking the
+	// sub-block with the given start, the sub-block start may only differ
+	// from the file set start if the file set is compacted.
+	openSubBlock(namespace ident.ID, shard uint32, fileSetStart, subBlockStart time.Time) error
 }
 
 func newSeeker(opts seekerOpts) fileSetSeeker {
@@ -157,6 +168,15 @@ func (s *seeker) ConcurrentIDBloomFilter() *ManagedConcurrentBloomFilter {
 }
 
 func (s *seeker) Open(namespace ident.ID, shard uint32, blockStart time.Time) error {
+	return s.openSubBlock(namespace, shard, blockStart, blockStart)
+}
+
+func (s *seeker) openSubBlock(
+	namespace ident.ID,
+	shard uint32,
+	blockStart time.Time,
+	subBlockStart time.Time,
+) error {
 	if s.isClone {
 		return errClonesShouldNotBeOpened
 	}
@@ -245,6 +265,10 @@ func (s *seeker) Open(namespace ident.ID, shard uint32, blockStart time.Time) er
 		s.Close()
 		return err
 	}
+	if err := s.selectSubBlock(subBlockStart); err != nil {
+		s.Close()
+		return err
+	}
 
 	if digest.Checksum(s.indexMmap) != expectedDigests.indexDigest {
 		s.Close()
@@ -320,6 +344,7 @@ func (s *seeker) readInfo(size int, infoDigestReader digest.FdWithDigestReader,
 
 	s.start = xtime.FromNanoseconds(info.BlockStart)
 	s.blockSize = time.Duration(info.BlockSize)
+	s.subBlockSize = time.Duration(info.SubBlockSize)
 	s.entries = int(info.Entries)
 	s.bloomFilterInfo = info.BloomFilter
 	s.summariesInfo = info.Summaries
@@ -327,6 +352,21 @@ func (s *seeker) readInfo(size int, infoDigestReader digest.FdWithDigestReader,
 	return nil
 }
 
+// selectSubBlock narrows the seeker to the sub-block with the given start when
+// seeking a compacted file set.
+func (s *seeker) selectSubBlock(subBlockStart time.Time) error {
+	s.subBlockIdx, s.seekSubBlocks = 0, false
+	idx, ok, err := subBlockIndex(s.start, s.blockSize, s.subBlockSize, subBlockStart)
+	if err != nil || !ok {
+		return err
+	}
+	s.start = subBlockStart
+	s.blockSize = s.subBlockSize
+	s.subBlockIdx = idx
+	s.seekSubBlocks = true
+	return nil
+}
+
 // SeekByID returns the data for the specified ID. An error will be returned if the
 // ID cannot be found.
 func (s *seeker) SeekByID(id ident.ID) (checked.Bytes, error) {
@@ -405,11 +445,22 @@ func (s *seeker) SeekIndexEntry(id ident.ID) (IndexEntry, error) {
 		}
 		comparison := bytes.Compare(entry.ID, idBytes)
 		if comparison == 0 {
+			if s.seekSubBlocks {
+				var ok bool
+				entry, ok, err = subBlockIndexEntry(entry, s.subBlockIdx)
+				if err != nil {
+					return IndexEntry{}, err
+				}
+				if !ok {
+					return IndexEntry{}, errSeekIDNotFound
+				}
+			}
 			return IndexEntry{
 				Size:        uint32(entry.Size),
 				Checksum:    uint32(entry.Checksum),
 				Offset:      entry.Offset,
 				EncodedTags: entry.EncodedTags,
+				Checkpoints: entry.Checkpoints,
 			}, nil
 		}
 
@@ -478,6 +529,9 @@ func (s *seeker) ConcurrentClone() (ConcurrentDataFileSetSeeker, error) {
 		// bloomFilter is concurrency safe
 		bloomFilter: s.bloomFilter,
 		indexLookup: indexLookupClone,
-		isClone:     true,
+		// Clones seek the same sub-block as the original
+		subBlockIdx:   s.subBlockIdx,
+		seekSubBlocks: s.seekSubBlocks,
+		isClone:       true,
 	}, nil
 }
@@ -342,6 +342,17 @@ func (m *seekerManager) openAnyUnopenSeekers(byTime *seekersByTime) error {
 	start := m.earliestSeekableBlockStart()
 	end := m.latestSeekableBlockStart()
 	blockSize := m.namespaceMetadata.Options().RetentionOptions().BlockSize()
+	// Blocks eligible to be offloaded are opened lazily on first access
+	// rather than eagerly, which would fetch every offloaded fileset.
+	if m.opts.ObjectStore() != nil {
+		nsOpts := m.namespaceMetadata.Options()
+		if nsOpts.ColdStorageOptions().Enabled() {
+			offloadBefore := OffloadBlockStartBefore(nsOpts, m.opts.ClockOptions().NowFn()())
+			if offloadBefore.After(start) {
+				start = offloadBefore
+			}
+		}
+	}
 	multiErr := xerrors.NewMultiError()
 
 	for t := start; !t.After(end); t = t.Add(blockSize) {
@@ -360,13 +371,20 @@ func (m *seekerManager) newOpenSeeker(
 	shard uint32,
 	blockStart time.Time,
 ) (DataFileSetSeeker, error) {
-	exists, err := DataFileSetExistsAt(m.filePathPrefix, m.namespace, shard, blockStart)
+	fileSetStart := blockStart
+	filePathPrefix, err := m.dataFileSetFilePathPrefix(shard, blockStart)
+	if err == errSeekerManagerFileSetNotFound {
+		// The block may have been compacted into the fileset of a larger block.
+		compactionOpts := m.namespaceMetadata.Options().CompactionOptions()
+		compactedStart := blockStart.Truncate(compactionOpts.BlockSize())
+		if compactionOpts.Enabled() && !compactedStart.Equal(blockStart) {
+			fileSetStart = compactedStart
+			filePathPrefix, err = m.dataFileSetFilePathPrefix(shard, fileSetStart)
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
-	if !exists {
-		return nil, errSeekerManagerFileSetNotFound
-	}
 
 	// NB(r): Use a lock on the unread buffer to avoid multiple
 	// goroutines reusing the unread buffer that we share between the seekers
@@ -375,7 +393,7 @@ func (m *seekerManager) newOpenSeeker(
 	defer m.unreadBuf.Unlock()
 
 	seekerIface := NewSeeker(
-		m.filePathPrefix,
+		filePathPrefix,
 		m.opts.DataReaderBufferSize(),
 		m.opts.InfoReaderBufferSize(),
 		m.opts.SeekReaderBufferSize(),
@@ -389,7 +407,11 @@ func (m *seekerManager) newOpenSeeker(
 	// Set the unread buffer to reuse it amongst all seekers.
 	seeker.setUnreadBuffer(m.unreadBuf.value)
 
-	if err := seeker.Open(m.namespace, shard, blockStart); err != nil {
+	err = seeker.openSubBlock(m.namespace, shard, fileSetStart, blockStart)
+	if err == errFileSetNotCompacted {
+		return nil, errSeekerManagerFileSetNotFound
+	}
+	if err != nil {
 		return nil, err
 	}
 
@@ -401,6 +423,36 @@ func (m *seekerManager) newOpenSeeker(
 	return seeker, nil
 }
 
+// dataFileSetFilePathPrefix returns the file path prefix that the data fileset
+// for the given shard and block start can be opened from. Filesets that have been
+// offloaded to the object store are fetched into the local cache on first access,
+// waiting at most the object store cache fetch timeout.
+func (m *seekerManager) dataFileSetFilePathPrefix(
+	shard uint32,
+	blockStart time.Time,
+) (string, error) {
+	exists, err := DataFileSetExistsAt(m.filePathPrefix, m.namespace, shard, blockStart)
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		return m.filePathPrefix, nil
+	}
+
+	if m.opts.ObjectStore() == nil {
+		return "", errSeekerManagerFileSetNotFound
+	}
+
+	err = m.opts.ObjectStoreCache().Fetch(m.opts, m.namespace, shard, blockStart)
+	if err == errObjectStoreFileSetNotOffloaded {
+		return "", errSeekerManagerFileSetNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return m.opts.ObjectStoreCachePathPrefix(), nil
+}
+
 func (m *seekerManager) seekersByTime(shard uint32) *seekersByTime {
 	m.RLock()
 	if int(shard) < len(m.seekersByShardIdx) {
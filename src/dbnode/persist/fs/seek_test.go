@@ -28,8 +28,11 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3x/ident"
 	"github.com/m3db/m3x/pool"
+	xtime "github.com/m3db/m3x/time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -227,6 +230,55 @@ func TestSeek(t *testing.T) {
 	assert.NoError(t, s.Close())
 }
 
+func TestSeekIndexEntryCheckpoints(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testdb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	filePathPrefix := filepath.Join(dir, "")
+	defer os.RemoveAll(dir)
+
+	w := newTestWriter(t, filePathPrefix)
+	writerOpts := DataWriterOpenOptions{
+		BlockSize: testBlockSize,
+		Identifier: FileSetFileIdentifier{
+			Namespace:  testNs1ID,
+			Shard:      0,
+			BlockStart: testWriterStart,
+		},
+		SeekCheckpointInterval: testBlockSize / 4,
+	}
+	err = w.Open(writerOpts)
+	require.NoError(t, err)
+
+	encoder := m3tsz.NewEncoder(testWriterStart, nil,
+		m3tsz.DefaultIntOptimizationEnabled, nil)
+	for curr := testWriterStart; curr.Before(testWriterStart.Add(testBlockSize)); curr = curr.Add(time.Minute) {
+		dp := ts.Datapoint{Timestamp: curr, Value: float64(curr.Minute())}
+		require.NoError(t, encoder.Encode(dp, xtime.Second, nil))
+	}
+	data, err := ioutil.ReadAll(encoder.Stream())
+	require.NoError(t, err)
+	require.NoError(t, w.Write(ident.StringID("foo"), ident.Tags{},
+		bytesRefd(data), digest.Checksum(data)))
+	require.NoError(t, w.Close())
+
+	s := newTestSeeker(filePathPrefix)
+	require.NoError(t, s.Open(testNs1ID, 0, testWriterStart))
+	defer s.Close()
+
+	entry, err := s.SeekIndexEntry(ident.StringID("foo"))
+	require.NoError(t, err)
+
+	checkpoints, err := m3tsz.UnmarshalCheckpoints(entry.Checkpoints)
+	require.NoError(t, err)
+	require.True(t, len(checkpoints) >= 3)
+	for i := 1; i < len(checkpoints); i++ {
+		assert.True(t, checkpoints[i].Timestamp.After(checkpoints[i-1].Timestamp))
+		assert.True(t, checkpoints[i].BitOffset > checkpoints[i-1].BitOffset)
+	}
+}
+
 // TestSeekIDNotExists is similar to TestSeek, but it covers more edge cases
 // around IDs not existing.
 func TestSeekIDNotExists(t *testing.T) {
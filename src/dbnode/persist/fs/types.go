@@ -26,8 +26,10 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs/msgpack"
+	"github.com/m3db/m3/src/dbnode/persist/fs/objectstore"
 	"github.com/m3db/m3/src/dbnode/runtime"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
@@ -59,6 +61,11 @@ type DataWriterOpenOptions struct {
 	FileSetContentType persist.FileSetContentType
 	Identifier         FileSetFileIdentifier
 	BlockSize          time.Duration
+	// Only set when writing compacted files, the block size of each sub-block
+	SubBlockSize time.Duration
+	// Only set when writing M3TSZ encoded data with seek checkpoints, the
+	// interval between the checkpoints of the data of each series
+	SeekCheckpointInterval time.Duration
 	// Only used when writing snapshot files
 	Snapshot DataWriterSnapshotOptions
 }
@@ -87,6 +94,11 @@ type DataFileSetWriter interface {
 	// WriteAll will write the id and all byte slices and returns an error on a write error.
 	// Callers must not call this method with a given ID more than once.
 	WriteAll(id ident.ID, tags ident.Tags, data []checked.Bytes, checksum uint32) error
+
+	// WriteSubBlocks will write the id and the data of each sub-block of a compacted file
+	// set, nil data denotes an empty sub-block. The writer must have been opened with a
+	// sub-block size. Callers must not call this method with a given ID more than once.
+	WriteSubBlocks(id ident.ID, tags ident.Tags, data []checked.Bytes, checksums []uint32) error
 }
 
 // DataFileSetReaderStatus describes the status of a file set reader
@@ -102,6 +114,9 @@ type DataFileSetReaderStatus struct {
 type DataReaderOpenOptions struct {
 	Identifier  FileSetFileIdentifier
 	FileSetType persist.FileSetType
+	// SubBlockStart selects the sub-block to read from a compacted file set,
+	// if not set the sub-block at the start of the file set is read
+	SubBlockStart time.Time
 }
 
 // DataFileSetReader provides an unsynchronized reader for a TSDB file set
@@ -212,6 +227,15 @@ type ConcurrentDataFileSetSeeker interface {
 	ConcurrentIDBloomFilter() *ManagedConcurrentBloomFilter
 }
 
+// ObjectStoreCache bounds the offloaded data filesets fetched into the object
+// store cache path prefix.
+type ObjectStoreCache interface {
+	// Fetch ensures the offloaded data fileset for the given namespace, shard
+	// and block start is in the object store cache path prefix, fetching it
+	// from the object store if required.
+	Fetch(opts Options, namespace ident.ID, shard uint32, blockStart time.Time) error
+}
+
 // DataFileSetSeekerManager provides management of seekers for a TSDB namespace.
 type DataFileSetSeekerManager interface {
 	io.Closer
@@ -443,6 +467,47 @@ type Options interface {
 
 	// FSTOptions returns the fst options
 	FSTOptions() fst.Options
+
+	// SetObjectStore sets the object store that filesets are offloaded to, offloading
+	// is disabled if not set
+	SetObjectStore(value objectstore.Store) Options
+
+	// ObjectStore returns the object store that filesets are offloaded to
+	ObjectStore() objectstore.Store
+
+	// SetObjectStoreCachePathPrefix sets the file path prefix that offloaded filesets
+	// are fetched into when read
+	SetObjectStoreCachePathPrefix(value string) Options
+
+	// ObjectStoreCachePathPrefix returns the file path prefix that offloaded filesets
+	// are fetched into when read
+	ObjectStoreCachePathPrefix() string
+
+	// SetObjectStoreCache sets the cache that bounds the offloaded filesets
+	// fetched into the object store cache path prefix
+	SetObjectStoreCache(value ObjectStoreCache) Options
+
+	// ObjectStoreCache returns the cache that bounds the offloaded filesets
+	// fetched into the object store cache path prefix
+	ObjectStoreCache() ObjectStoreCache
+
+	// SetSeekCheckpointInterval sets the interval at which seek checkpoints are
+	// written within the data of each series of flushed filesets, checkpoints
+	// are disabled if zero
+	SetSeekCheckpointInterval(value time.Duration) Options
+
+	// SeekCheckpointInterval returns the interval at which seek checkpoints are
+	// written within the data of each series of flushed filesets, checkpoints
+	// are disabled if zero
+	SeekCheckpointInterval() time.Duration
+
+	// SetEncodingOptions sets the encoding options used to decode the data
+	// of series when placing and reading from seek checkpoints
+	SetEncodingOptions(value encoding.Options) Options
+
+	// EncodingOptions returns the encoding options used to decode the data
+	// of series when placing and reading from seek checkpoints
+	EncodingOptions() encoding.Options
 }
 
 // BlockRetrieverOptions represents the options for block retrieval
@@ -24,6 +24,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"os"
 	"sort"
@@ -31,6 +32,8 @@ import (
 
 	"github.com/m3db/bloom"
 	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs/msgpack"
 	"github.com/m3db/m3/src/dbnode/persist/schema"
@@ -51,10 +54,12 @@ var (
 )
 
 type writer struct {
-	blockSize        time.Duration
-	filePathPrefix   string
-	newFileMode      os.FileMode
-	newDirectoryMode os.FileMode
+	blockSize              time.Duration
+	subBlockSize           time.Duration
+	seekCheckpointInterval time.Duration
+	filePathPrefix         string
+	newFileMode            os.FileMode
+	newDirectoryMode       os.FileMode
 
 	summariesPercent                float64
 	bloomFilterFalsePositivePercent float64
@@ -78,6 +83,7 @@ type writer struct {
 	digestBuf          digest.Buffer
 	singleCheckedBytes []checked.Bytes
 	tagEncoderPool     serialize.TagEncoderPool
+	encodingOpts       encoding.Options
 	err                error
 }
 
@@ -89,6 +95,8 @@ type indexEntry struct {
 	indexFileOffset int64
 	size            uint32
 	checksum        uint32
+	subBlocks       []byte
+	checkpoints     []byte
 }
 
 type indexEntries []indexEntry
@@ -96,6 +104,8 @@ type indexEntries []indexEntry
 func (e indexEntries) releaseRefs() {
 	for i := range e {
 		e[i].id = nil
+		e[i].subBlocks = nil
+		e[i].checkpoints = nil
 	}
 }
 
@@ -133,6 +143,7 @@ func NewWriter(opts Options) (DataFileSetWriter, error) {
 		digestBuf:                       digest.NewBuffer(),
 		singleCheckedBytes:              make([]checked.Bytes, 1),
 		tagEncoderPool:                  opts.TagEncoderPool(),
+		encodingOpts:                    opts.EncodingOptions(),
 	}, nil
 }
 
@@ -149,6 +160,8 @@ func (w *writer) Open(opts DataWriterOpenOptions) error {
 	)
 
 	w.blockSize = opts.BlockSize
+	w.subBlockSize = opts.SubBlockSize
+	w.seekCheckpointInterval = opts.SeekCheckpointInterval
 	w.start = blockStart
 	w.snapshotTime = opts.Snapshot.SnapshotTime
 	w.snapshotID = opts.Snapshot.SnapshotID
@@ -256,18 +269,67 @@ func (w *writer) WriteAll(
 		return w.err
 	}
 
-	if err := w.writeAll(id, tags, data, checksum); err != nil {
+	if err := w.writeAll(id, tags, data, checksum, nil); err != nil {
 		w.err = err
 		return err
 	}
 	return nil
 }
 
+func (w *writer) WriteSubBlocks(
+	id ident.ID,
+	tags ident.Tags,
+	data []checked.Bytes,
+	checksums []uint32,
+) error {
+	if w.err != nil {
+		return w.err
+	}
+
+	if err := w.writeSubBlocks(id, tags, data, checksums); err != nil {
+		w.err = err
+		return err
+	}
+	return nil
+}
+
+func (w *writer) writeSubBlocks(
+	id ident.ID,
+	tags ident.Tags,
+	data []checked.Bytes,
+	checksums []uint32,
+) error {
+	if w.subBlockSize <= 0 {
+		return errWriterNotOpenedWithSubBlockSize
+	}
+	numSubBlocks := int(w.blockSize / w.subBlockSize)
+	if len(data) != numSubBlocks || len(checksums) != numSubBlocks {
+		return errWriterSubBlocksMismatch
+	}
+
+	// The checksum of the entry covers the data of every sub-block so the
+	// data file can be read sequentially like that of any other fileset.
+	var (
+		subBlocks = make([]byte, 0, numSubBlocks*subBlockEntryLenBytes)
+		d         = digest.NewDigest()
+	)
+	for i := range data {
+		var size int
+		if data[i] != nil {
+			size = data[i].Len()
+			d = d.Update(data[i].Bytes())
+		}
+		subBlocks = appendSubBlock(subBlocks, uint32(size), checksums[i])
+	}
+	return w.writeAll(id, tags, data, d.Sum32(), subBlocks)
+}
+
 func (w *writer) writeAll(
 	id ident.ID,
 	tags ident.Tags,
 	data []checked.Bytes,
 	checksum uint32,
+	subBlocks []byte,
 ) error {
 	var size int64
 	for _, d := range data {
@@ -287,6 +349,10 @@ func (w *writer) writeAll(
 		dataFileOffset: w.currOffset,
 		size:           uint32(size),
 		checksum:       checksum,
+		subBlocks:      subBlocks,
+	}
+	if subBlocks == nil && w.seekCheckpointInterval > 0 {
+		entry.checkpoints = w.seekCheckpoints(data)
 	}
 	for _, d := range data {
 		if d == nil {
@@ -303,6 +369,26 @@ func (w *writer) writeAll(
 	return nil
 }
 
+// seekCheckpoints decodes the data of a series to place checkpoints at the seek
+// checkpoint interval, checkpoints only speed up reads so the data is written
+// without them if it cannot be decoded.
+func (w *writer) seekCheckpoints(data []checked.Bytes) []byte {
+	readers := make([]io.Reader, 0, len(data))
+	for _, d := range data {
+		if d == nil {
+			continue
+		}
+		readers = append(readers, bytes.NewReader(d.Bytes()))
+	}
+
+	checkpoints, err := m3tsz.Checkpoints(io.MultiReader(readers...),
+		w.seekCheckpointInterval, m3tsz.DefaultIntOptimizationEnabled, w.encodingOpts)
+	if err != nil || len(checkpoints) == 0 {
+		return nil
+	}
+	return m3tsz.MarshalCheckpoints(nil, checkpoints)
+}
+
 func (w *writer) Close() error {
 	err := w.close()
 	if w.err != nil {
@@ -451,6 +537,8 @@ func (w *writer) writeIndexFileContents(
 			Offset:      w.indexEntries[i].dataFileOffset,
 			Checksum:    int64(w.indexEntries[i].checksum),
 			EncodedTags: encodedTags,
+			SubBlocks:   w.indexEntries[i].subBlocks,
+			Checkpoints: w.indexEntries[i].checkpoints,
 		}
 
 		w.encoder.Reset()
@@ -528,6 +616,7 @@ func (w *writer) writeInfoFileContents(
 		SnapshotTime: xtime.ToNanoseconds(w.snapshotTime),
 		SnapshotID:   w.snapshotID,
 		BlockSize:    int64(w.blockSize),
+		SubBlockSize: int64(w.subBlockSize),
 		Entries:      w.currIdx,
 		MajorVersion: schema.MajorVersion,
 		Summaries: schema.IndexSummariesInfo{
@@ -40,6 +40,10 @@ type IndexInfo struct {
 	SnapshotTime int64
 	FileType     persist.FileSetType
 	SnapshotID   []byte
+
+	// SubBlockSize is set for filesets compacted from the filesets of
+	// consecutive blocks, it is the block size of each of those blocks.
+	SubBlockSize int64
 }
 
 // IndexSummariesInfo stores metadata about the summaries
@@ -61,6 +65,16 @@ type IndexEntry struct {
 	Offset      int64
 	Checksum    int64
 	EncodedTags []byte
+
+	// SubBlocks is set for entries of compacted filesets, it packs the size
+	// and checksum of the data of each sub-block that is concatenated to
+	// make up the entry's data.
+	SubBlocks []byte
+
+	// Checkpoints is set for entries of filesets written with seek checkpoints,
+	// it packs the decoder state at intervals within the entry's data so that
+	// reads of part of the block can skip decoding the data before them.
+	Checkpoints []byte
 }
 
 // IndexSummary stores a summary of an index entry to lookup
@@ -75,6 +89,11 @@ type LogInfo struct {
 	Start    int64
 	Duration int64
 	Index    int64
+
+	// Compression and Encryption describe how the chunks that follow the
+	// log info are encoded, both are zero for plain commit logs.
+	Compression int64
+	Encryption  int64
 }
 
 // LogEntry stores per-entry data in a commit log
@@ -22,6 +22,7 @@ package runtime
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/ratelimit"
@@ -65,6 +66,8 @@ var (
 		"tick series batch size must be positive")
 	errTickPerSeriesSleepDurationMustBePositive = errors.New(
 		"tick per series sleep duration must be positive")
+	errNamespaceWriteQuotaIsNegative = errors.New(
+		"namespace write quota cannot be negative")
 )
 
 type options struct {
@@ -76,11 +79,13 @@ type options struct {
 	tickPerSeriesSleepDuration           time.Duration
 	tickMinimumInterval                  time.Duration
 	maxWiredBlocks                       uint
+	maxWiredBytes                        uint64
 	clientBootstrapConsistencyLevel      topology.ReadConsistencyLevel
 	clientReadConsistencyLevel           topology.ReadConsistencyLevel
 	clientWriteConsistencyLevel          topology.ConsistencyLevel
 	indexDefaultQueryTimeout             time.Duration
 	flushIndexBlockNumSegments           uint
+	namespaceWriteQuotas                 map[string]WriteQuota
 }
 
 // NewOptions creates a new set of runtime options with defaults
@@ -124,6 +129,16 @@ func (o *options) Validate() error {
 
 	// tickMinimumInterval can be zero if user desires
 
+	// namespace write quota limits can be zero to specify that the
+	// limit should not be enforced
+	for ns, quota := range o.namespaceWriteQuotas {
+		if quota.DatapointsPerSecond < 0 ||
+			quota.NewSeriesPerSecond < 0 ||
+			quota.MaxSeries < 0 {
+			return fmt.Errorf("%v: namespace=%s", errNamespaceWriteQuotaIsNegative, ns)
+		}
+	}
+
 	return nil
 }
 
@@ -207,6 +222,16 @@ func (o *options) MaxWiredBlocks() uint {
 	return o.maxWiredBlocks
 }
 
+func (o *options) SetMaxWiredBytes(value uint64) Options {
+	opts := *o
+	opts.maxWiredBytes = value
+	return &opts
+}
+
+func (o *options) MaxWiredBytes() uint64 {
+	return o.maxWiredBytes
+}
+
 func (o *options) SetClientBootstrapConsistencyLevel(value topology.ReadConsistencyLevel) Options {
 	opts := *o
 	opts.clientBootstrapConsistencyLevel = value
@@ -256,3 +281,13 @@ func (o *options) SetFlushIndexBlockNumSegments(value uint) Options {
 func (o *options) FlushIndexBlockNumSegments() uint {
 	return o.flushIndexBlockNumSegments
 }
+
+func (o *options) SetNamespaceWriteQuotas(value map[string]WriteQuota) Options {
+	opts := *o
+	opts.namespaceWriteQuotas = value
+	return &opts
+}
+
+func (o *options) NamespaceWriteQuotas() map[string]WriteQuota {
+	return o.namespaceWriteQuotas
+}
@@ -30,3 +30,15 @@ func TestRuntimeOptionsDefaultsIsValid(t *testing.T) {
 	v := NewOptions()
 	assert.NoError(t, v.Validate())
 }
+
+func TestRuntimeOptionsNamespaceWriteQuotasValidate(t *testing.T) {
+	v := NewOptions().SetNamespaceWriteQuotas(map[string]WriteQuota{
+		"metrics": {DatapointsPerSecond: 1000, MaxSeries: 100},
+	})
+	assert.NoError(t, v.Validate())
+
+	v = v.SetNamespaceWriteQuotas(map[string]WriteQuota{
+		"metrics": {NewSeriesPerSecond: -1},
+	})
+	assert.Error(t, v.Validate())
+}
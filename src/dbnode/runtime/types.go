@@ -131,6 +131,16 @@ type Options interface {
 	// can also not be unwired. This means that the limit is best effort.
 	MaxWiredBlocks() uint
 
+	// SetMaxWiredBytes sets the max bytes of blocks retrieved from disk to
+	// keep wired; zero is used to specify no limit. Like the max wired blocks
+	// limit this is best effort.
+	SetMaxWiredBytes(value uint64) Options
+
+	// MaxWiredBytes returns the max bytes of blocks retrieved from disk to
+	// keep wired; zero is used to specify no limit. Like the max wired blocks
+	// limit this is best effort.
+	MaxWiredBytes() uint64
+
 	// SetClientBootstrapConsistencyLevel sets the client bootstrap
 	// consistency level used when bootstrapping from peers. Setting this
 	// will take effect immediately, and as such can be used to finish a
@@ -180,6 +190,27 @@ type Options interface {
 	// greater amount of segments that need to be searched independently but
 	// a higher number reduces the memory pressure when flushing an index block.
 	FlushIndexBlockNumSegments() uint
+
+	// SetNamespaceWriteQuotas sets the write quotas to enforce per namespace,
+	// keyed by namespace ID, namespaces without a quota are not limited.
+	SetNamespaceWriteQuotas(value map[string]WriteQuota) Options
+
+	// NamespaceWriteQuotas returns the write quotas to enforce per namespace,
+	// keyed by namespace ID, namespaces without a quota are not limited.
+	NamespaceWriteQuotas() map[string]WriteQuota
+}
+
+// WriteQuota is a set of write limits enforced for a namespace, a zero
+// value for any of the limits specifies that it should not be enforced.
+type WriteQuota struct {
+	// DatapointsPerSecond is the max number of datapoints written per second.
+	DatapointsPerSecond int64
+
+	// NewSeriesPerSecond is the max number of new series inserted per second.
+	NewSeriesPerSecond int64
+
+	// MaxSeries is the max number of series held in memory.
+	MaxSeries int64
 }
 
 // OptionsManager updates and supplies runtime options.
@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -43,7 +44,10 @@ import (
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
 	"github.com/m3db/m3/src/dbnode/environment"
+	"github.com/m3db/m3/src/dbnode/generated/proto/quota"
 	"github.com/m3db/m3/src/dbnode/kvconfig"
+	ns "github.com/m3db/m3/src/dbnode/network/server"
+	grpcnode "github.com/m3db/m3/src/dbnode/network/server/grpcthrift/node"
 	hjcluster "github.com/m3db/m3/src/dbnode/network/server/httpjson/cluster"
 	hjnode "github.com/m3db/m3/src/dbnode/network/server/httpjson/node"
 	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift"
@@ -76,6 +80,7 @@ import (
 	"github.com/m3db/m3x/pool"
 	xsync "github.com/m3db/m3x/sync"
 
+	"github.com/cockroachdb/cmux"
 	"github.com/coreos/etcd/embed"
 	"github.com/coreos/pkg/capnslog"
 	"github.com/uber-go/tally"
@@ -236,7 +241,9 @@ func Run(runOpts RunOptions) {
 		SetWriteNewSeriesAsync(cfg.WriteNewSeriesAsync).
 		SetWriteNewSeriesBackoffDuration(cfg.WriteNewSeriesBackoffDuration)
 	if lruCfg := cfg.Cache.SeriesConfiguration().LRU; lruCfg != nil {
-		runtimeOpts = runtimeOpts.SetMaxWiredBlocks(lruCfg.MaxBlocks)
+		runtimeOpts = runtimeOpts.
+			SetMaxWiredBlocks(lruCfg.MaxBlocks).
+			SetMaxWiredBytes(lruCfg.MaxBytes)
 	}
 
 	// FOLLOWUP(prateek): remove this once we have the runtime options<->index wiring done
@@ -245,8 +252,16 @@ func Run(runOpts RunOptions) {
 	if cfg.WriteNewSeriesAsync {
 		insertMode = index.InsertAsync
 	}
-	opts = opts.SetIndexOptions(
-		indexOpts.SetInsertMode(insertMode))
+	indexOpts = indexOpts.SetInsertMode(insertMode)
+	if v := cfg.Index.CompactionInterval; v != nil {
+		indexOpts = indexOpts.SetCompactionInterval(*v)
+	}
+	if v := cfg.Index.MaxCompactionConcurrency; v != 0 {
+		compactionWorkerPool := xsync.NewWorkerPool(v)
+		compactionWorkerPool.Init()
+		indexOpts = indexOpts.SetCompactionWorkerPool(compactionWorkerPool)
+	}
+	opts = opts.SetIndexOptions(indexOpts)
 
 	if tick := cfg.Tick; tick != nil {
 		runtimeOpts = runtimeOpts.
@@ -314,7 +329,16 @@ func Run(runOpts RunOptions) {
 		SetTagEncoderPool(tagEncoderPool).
 		SetTagDecoderPool(tagDecoderPool).
 		SetForceIndexSummariesMmapMemory(cfg.Filesystem.ForceIndexSummariesMmapMemory).
-		SetForceBloomFilterMmapMemory(cfg.Filesystem.ForceBloomFilterMmapMemory)
+		SetForceBloomFilterMmapMemory(cfg.Filesystem.ForceBloomFilterMmapMemory).
+		SetSeekCheckpointInterval(cfg.Filesystem.SeekCheckpointInterval)
+
+	if objectStoreCfg := cfg.Filesystem.ObjectStore; objectStoreCfg != nil {
+		fsopts = fsopts.SetObjectStore(objectStoreCfg.NewObjectStore()).
+			SetObjectStoreCache(objectStoreCfg.NewObjectStoreCache())
+		if objectStoreCfg.CachePathPrefix != "" {
+			fsopts = fsopts.SetObjectStoreCachePathPrefix(objectStoreCfg.CachePathPrefix)
+		}
+	}
 
 	var commitLogQueueSize int
 	specified := cfg.CommitLog.Queue.Size
@@ -352,7 +376,15 @@ func Run(runOpts RunOptions) {
 		SetFlushInterval(cfg.CommitLog.FlushEvery).
 		SetBacklogQueueSize(commitLogQueueSize).
 		SetBacklogQueueChannelSize(commitLogQueueChannelSize).
-		SetBlockSize(cfg.CommitLog.BlockSize))
+		SetBlockSize(cfg.CommitLog.BlockSize).
+		SetCompression(cfg.CommitLog.Compression))
+	if cfg.CommitLog.EncryptionKeyFile != "" {
+		key, err := commitlog.ReadEncryptionKeyFile(cfg.CommitLog.EncryptionKeyFile)
+		if err != nil {
+			logger.Fatalf("could not read commit log encryption key: %v", err)
+		}
+		opts = opts.SetCommitLogOptions(opts.CommitLogOptions().SetEncryptionKey(key))
+	}
 
 	// Set the series cache policy
 	seriesCachePolicy := cfg.Cache.SeriesConfiguration().Policy
@@ -360,6 +392,8 @@ func Run(runOpts RunOptions) {
 
 	// Apply pooling options
 	opts = withEncodingAndPoolingOptions(cfg, logger, opts, cfg.PoolingPolicy)
+	// Filesets are read and written with the encoding options of the pools
+	fsopts = opts.CommitLogOptions().FilesystemOptions()
 
 	// Setup the block retriever
 	switch seriesCachePolicy {
@@ -458,6 +492,7 @@ func Run(runOpts RunOptions) {
 	clientAdminOpts := m3dbClient.Options().(client.AdminOptions)
 	kvWatchClientConsistencyLevels(envCfg.KVStore, logger,
 		clientAdminOpts, runtimeOptsMgr)
+	kvWatchNamespaceWriteQuotas(envCfg.KVStore, logger, runtimeOptsMgr)
 
 	// Set repair options
 	hostBlockMetadataSlicePool := repair.NewHostBlockMetadataSlicePool(
@@ -532,8 +567,34 @@ func Run(runOpts RunOptions) {
 	contextPool := opts.ContextPool()
 
 	tchannelOpts := xtchannel.NewDefaultChannelOptions()
-	tchannelthriftNodeClose, err := ttnode.NewServer(db,
-		cfg.ListenAddress, contextPool, tchannelOpts, ttopts).ListenAndServe()
+	var tchannelthriftNodeServer ns.NetworkService
+	if cfg.GRPCEnabled {
+		// Serve the node service over both TChannel and gRPC on the listen
+		// address, gRPC connections are told apart by the HTTP/2 preface.
+		listener, err := net.Listen("tcp", cfg.ListenAddress)
+		if err != nil {
+			logger.Fatalf("could not listen on %s: %v", cfg.ListenAddress, err)
+		}
+		defer listener.Close()
+
+		mux := cmux.New(listener)
+		grpcNodeClose, err := grpcnode.NewServer(db,
+			mux.Match(cmux.HTTP2()), contextPool, ttopts).ListenAndServe()
+		if err != nil {
+			logger.Fatalf("could not open grpc interface on %s: %v",
+				cfg.ListenAddress, err)
+		}
+		defer grpcNodeClose()
+		logger.Infof("node grpc: listening on %v", cfg.ListenAddress)
+
+		tchannelthriftNodeServer = ttnode.NewServerWithListener(db,
+			mux.Match(cmux.Any()), contextPool, tchannelOpts, ttopts)
+		go mux.Serve()
+	} else {
+		tchannelthriftNodeServer = ttnode.NewServer(db,
+			cfg.ListenAddress, contextPool, tchannelOpts, ttopts)
+	}
+	tchannelthriftNodeClose, err := tchannelthriftNodeServer.ListenAndServe()
 	if err != nil {
 		logger.Fatalf("could not open tchannelthrift interface on %s: %v",
 			cfg.ListenAddress, err)
@@ -797,6 +858,69 @@ func kvWatchClientConsistencyLevels(
 		})
 }
 
+func kvWatchNamespaceWriteQuotas(
+	store kv.Store,
+	logger xlog.Logger,
+	runtimeOptsMgr m3dbruntime.OptionsManager,
+) {
+	setQuotas := func(protoValue *quota.NamespaceWriteQuotas) error {
+		var quotas map[string]m3dbruntime.WriteQuota
+		if protoValue != nil && len(protoValue.Quotas) > 0 {
+			quotas = make(map[string]m3dbruntime.WriteQuota, len(protoValue.Quotas))
+			for ns, q := range protoValue.Quotas {
+				if q == nil {
+					continue
+				}
+				quotas[ns] = m3dbruntime.WriteQuota{
+					DatapointsPerSecond: q.DatapointsPerSecond,
+					NewSeriesPerSecond:  q.NewSeriesPerSecond,
+					MaxSeries:           q.MaxSeries,
+				}
+			}
+		}
+		return runtimeOptsMgr.Update(runtimeOptsMgr.Get().
+			SetNamespaceWriteQuotas(quotas))
+	}
+
+	key := kvconfig.NamespaceWriteQuotasKey
+	value, err := store.Get(key)
+	if err != nil && err != kv.ErrNotFound {
+		logger.Errorf("could not resolve KV key %s: %v", key, err)
+	}
+	if err == nil {
+		protoValue := &quota.NamespaceWriteQuotas{}
+		if err := value.Unmarshal(protoValue); err != nil {
+			logger.Errorf("could not unmarshal KV key %s: %v", key, err)
+		} else if err := setQuotas(protoValue); err != nil {
+			logger.Errorf("could not process value of KV key %s: %v", key, err)
+		}
+	}
+
+	watch, err := store.Watch(key)
+	if err != nil {
+		logger.Errorf("could not watch KV key %s: %v", key, err)
+		return
+	}
+
+	go func() {
+		for range watch.C() {
+			var protoValue *quota.NamespaceWriteQuotas
+			if newValue := watch.Get(); newValue != nil {
+				protoValue = &quota.NamespaceWriteQuotas{}
+				if err := newValue.Unmarshal(protoValue); err != nil {
+					logger.Warnf("could not unmarshal KV key %s: %v", key, err)
+					continue
+				}
+			}
+			if err := setQuotas(protoValue); err != nil {
+				logger.Warnf("could not process change for KV key %s: %v", key, err)
+				continue
+			}
+			logger.Infof("set KV key %s: %v", key, protoValue)
+		}
+	}()
+}
+
 func kvWatchStringValue(
 	store kv.Store,
 	logger xlog.Logger,
@@ -1094,6 +1218,10 @@ func withEncodingAndPoolingOptions(
 		if lruCfg != nil && lruCfg.EventsChannelSize > 0 {
 			wiredListOpts.EventsChannelSize = int(lruCfg.EventsChannelSize)
 		}
+		if lruCfg != nil {
+			wiredListOpts.AdmissionPolicy = lruCfg.Admission
+			opts = opts.SetNamespaceWiredListMaxBytes(lruCfg.NamespaceMaxBytes())
+		}
 		wiredList := block.NewWiredList(wiredListOpts)
 		blockOpts = blockOpts.SetWiredList(wiredList)
 	}
@@ -1117,7 +1245,9 @@ func withEncodingAndPoolingOptions(
 		SetDatabaseSeriesPool(seriesPool)
 	opts = opts.SetCommitLogOptions(opts.CommitLogOptions().
 		SetBytesPool(bytesPool).
-		SetIdentifierPool(identifierPool))
+		SetIdentifierPool(identifierPool).
+		SetFilesystemOptions(opts.CommitLogOptions().FilesystemOptions().
+			SetEncodingOptions(encodingOpts)))
 
 	resultsPool := index.NewResultsPool(poolOptions(policy.IndexResultsPool,
 		scope.SubScope("index-results-pool")))
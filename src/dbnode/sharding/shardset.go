@@ -85,6 +85,14 @@ func (s *shardSet) LookupStateByID(shardID uint32) (shard.State, error) {
 	return hostShard.State(), nil
 }
 
+func (s *shardSet) LookupShard(shardID uint32) (shard.Shard, error) {
+	hostShard, ok := s.shardMap[shardID]
+	if !ok {
+		return nil, ErrInvalidShardID
+	}
+	return hostShard, nil
+}
+
 func (s *shardSet) All() []shard.Shard {
 	return s.shards[:]
 }
@@ -75,3 +75,24 @@ func TestLookupShardState(t *testing.T) {
 	require.Equal(t, ErrInvalidShardID, err)
 	require.Equal(t, noState, shardTwoState)
 }
+
+func TestLookupShard(t *testing.T) {
+	ss, err := NewShardSet(
+		[]shard.Shard{
+			shard.NewShard(1).SetState(shard.Available),
+			shard.NewShard(2).SetState(shard.Initializing).SetSourceID("source"),
+		},
+		func(id ident.ID) uint32 {
+			return 1
+		})
+	require.NoError(t, err)
+
+	shardTwo, err := ss.LookupShard(2)
+	require.NoError(t, err)
+	require.Equal(t, uint32(2), shardTwo.ID())
+	require.Equal(t, shard.Initializing, shardTwo.State())
+	require.Equal(t, "source", shardTwo.SourceID())
+
+	_, err = ss.LookupShard(3)
+	require.Equal(t, ErrInvalidShardID, err)
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/persist/fs/backup"
+	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
+
+	"github.com/pborman/uuid"
+	"github.com/uber-go/tally"
+)
+
+var (
+	errBackupInProgress              = errors.New("backup already in progress")
+	errBackupDatabaseNotBootstrapped = errors.New("unable to backup database that is not bootstrapped")
+)
+
+type rotatingCommitlogs interface {
+	RotateLogs() (commitlog.File, error)
+}
+
+type backupManagerMetrics struct {
+	success tally.Counter
+	errors  tally.Counter
+}
+
+func newBackupManagerMetrics(scope tally.Scope) backupManagerMetrics {
+	backupScope := scope.SubScope("backup")
+	return backupManagerMetrics{
+		success: backupScope.Counter("success"),
+		errors:  backupScope.Counter("errors"),
+	}
+}
+
+type backupManager struct {
+	sync.Mutex
+
+	database         database
+	flushManager     databaseFlushManager
+	commitlogs       rotatingCommitlogs
+	opts             Options
+	newBackuperFn    func(opts backup.Options) backup.Backuper
+	backupInProgress bool
+	metrics          backupManagerMetrics
+}
+
+func newBackupManager(
+	database database,
+	flushManager databaseFlushManager,
+	commitlogs rotatingCommitlogs,
+	scope tally.Scope,
+) databaseBackupManager {
+	return &backupManager{
+		database:      database,
+		flushManager:  flushManager,
+		commitlogs:    commitlogs,
+		opts:          database.Options(),
+		newBackuperFn: backup.NewBackuper,
+		metrics:       newBackupManagerMetrics(scope),
+	}
+}
+
+func (m *backupManager) Backup(
+	tickStart time.Time,
+	dbBootstrapStateAtTickStart DatabaseBootstrapState,
+	destinationPath string,
+) (backup.Manifest, error) {
+	m.Lock()
+	if m.backupInProgress {
+		m.Unlock()
+		return backup.Manifest{}, errBackupInProgress
+	}
+	m.backupInProgress = true
+	m.Unlock()
+
+	defer func() {
+		m.Lock()
+		m.backupInProgress = false
+		m.Unlock()
+	}()
+
+	manifest, err := m.backup(tickStart, dbBootstrapStateAtTickStart, destinationPath)
+	if err != nil {
+		m.metrics.errors.Inc(1)
+		return backup.Manifest{}, err
+	}
+	m.metrics.success.Inc(1)
+	return manifest, nil
+}
+
+func (m *backupManager) backup(
+	tickStart time.Time,
+	dbBootstrapStateAtTickStart DatabaseBootstrapState,
+	destinationPath string,
+) (backup.Manifest, error) {
+	if !m.database.IsBootstrapped() {
+		return backup.Manifest{}, errBackupDatabaseNotBootstrapped
+	}
+
+	// Rotate the commitlog before snapshotting so that the snapshot metadata
+	// identifies the first commitlog containing writes not in the snapshot.
+	commitlogFile, err := m.commitlogs.RotateLogs()
+	if err != nil {
+		return backup.Manifest{}, err
+	}
+
+	// Snapshot every shard so that all data received up until the tick start
+	// is captured in either a flushed or a snapshot fileset.
+	if err := m.flushManager.Snapshot(tickStart, dbBootstrapStateAtTickStart); err != nil {
+		return backup.Manifest{}, err
+	}
+
+	fsOpts := m.opts.CommitLogOptions().FilesystemOptions()
+	snapshotIndex, err := fs.NextSnapshotMetadataFileIndex(fsOpts)
+	if err != nil {
+		return backup.Manifest{}, err
+	}
+	snapshotID := fs.SnapshotMetadataIdentifier{
+		Index: snapshotIndex,
+		UUID:  uuid.NewRandom(),
+	}
+	err = fs.NewSnapshotMetadataWriter(fsOpts).Write(fs.SnapshotMetadataWriteArgs{
+		ID:                  snapshotID,
+		CommitlogIdentifier: []byte(commitlogFile.FilePath),
+	})
+	if err != nil {
+		return backup.Manifest{}, err
+	}
+
+	namespaces, err := m.database.GetOwnedNamespaces()
+	if err != nil {
+		return backup.Manifest{}, err
+	}
+
+	args := backup.BackupArgs{
+		DestinationPath: destinationPath,
+		Snapshot:        snapshotID,
+		Namespaces:      make([]backup.NamespaceShards, 0, len(namespaces)),
+	}
+	for _, ns := range namespaces {
+		shards := ns.GetOwnedShards()
+		shardIDs := make([]uint32, 0, len(shards))
+		for _, shard := range shards {
+			shardIDs = append(shardIDs, shard.ID())
+		}
+		args.Namespaces = append(args.Namespaces, backup.NamespaceShards{
+			Namespace: ns.ID(),
+			Shards:    shardIDs,
+		})
+	}
+
+	backupOpts := backup.NewOptions().
+		SetClockOptions(m.opts.ClockOptions()).
+		SetFilesystemOptions(fsOpts)
+	return m.newBackuperFn(backupOpts).Backup(args)
+}
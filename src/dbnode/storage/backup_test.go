@@ -0,0 +1,150 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
+	"github.com/m3db/m3x/ident"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+)
+
+func newTestBackupManager(
+	t *testing.T,
+	ctrl *gomock.Controller,
+	filePathPrefix string,
+) (*backupManager, *Mockdatabase, *MockdatabaseFlushManager, *commitlog.MockCommitLog) {
+	opts := testDatabaseOptions()
+	opts = opts.SetCommitLogOptions(opts.CommitLogOptions().SetFilesystemOptions(
+		opts.CommitLogOptions().FilesystemOptions().SetFilePathPrefix(filePathPrefix)))
+
+	db := NewMockdatabase(ctrl)
+	db.EXPECT().Options().Return(opts).AnyTimes()
+	fm := NewMockdatabaseFlushManager(ctrl)
+	cl := commitlog.NewMockCommitLog(ctrl)
+	mgr := newBackupManager(db, fm, cl, tally.NoopScope).(*backupManager)
+	return mgr, db, fm, cl
+}
+
+func TestBackupManagerBackup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir, err := ioutil.TempDir("", "backup")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var (
+		now                = time.Now()
+		prefix             = path.Join(dir, "data")
+		dest               = path.Join(dir, "backup")
+		mgr, db, fm, cl    = newTestBackupManager(t, ctrl, prefix)
+		commitlogFile      = commitlog.File{FilePath: "commitlog-0-0.db"}
+		shard              = NewMockdatabaseShard(ctrl)
+		ns                 = NewMockdatabaseNamespace(ctrl)
+		dbBootstrapStateAt = DatabaseBootstrapState{}
+	)
+	shard.EXPECT().ID().Return(uint32(3)).AnyTimes()
+	ns.EXPECT().ID().Return(ident.StringID("testns")).AnyTimes()
+	ns.EXPECT().GetOwnedShards().Return([]databaseShard{shard})
+
+	gomock.InOrder(
+		db.EXPECT().IsBootstrapped().Return(true),
+		cl.EXPECT().RotateLogs().Return(commitlogFile, nil),
+		fm.EXPECT().Snapshot(now, dbBootstrapStateAt).Return(nil),
+		db.EXPECT().GetOwnedNamespaces().Return([]databaseNamespace{ns}, nil),
+	)
+
+	manifest, err := mgr.Backup(now, dbBootstrapStateAt, dest)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(manifest.Namespaces))
+	require.Equal(t, "testns", manifest.Namespaces[0].ID)
+	require.Equal(t, 1, len(manifest.Namespaces[0].Shards))
+	require.Equal(t, uint32(3), manifest.Namespaces[0].Shards[0].Shard)
+
+	// The snapshot metadata identifies the rotated commitlog.
+	fsOpts := fs.NewOptions().SetFilePathPrefix(prefix)
+	metadatas, _, err := fs.SortedSnapshotMetadataFiles(fsOpts)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(metadatas))
+	require.Equal(t, []byte(commitlogFile.FilePath), metadatas[0].CommitlogIdentifier)
+	require.Equal(t, metadatas[0].ID.UUID.String(), manifest.SnapshotUUID)
+	require.Equal(t, metadatas[0].ID.Index, manifest.SnapshotIndex)
+}
+
+func TestBackupManagerBackupSnapshotError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir, err := ioutil.TempDir("", "backup")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var (
+		now             = time.Now()
+		prefix          = path.Join(dir, "data")
+		mgr, db, fm, cl = newTestBackupManager(t, ctrl, prefix)
+		snapshotErr     = errors.New("snapshot failed")
+	)
+	db.EXPECT().IsBootstrapped().Return(true)
+	cl.EXPECT().RotateLogs().Return(commitlog.File{}, nil)
+	fm.EXPECT().Snapshot(now, DatabaseBootstrapState{}).Return(snapshotErr)
+
+	_, err = mgr.Backup(now, DatabaseBootstrapState{}, path.Join(dir, "backup"))
+	require.Equal(t, snapshotErr, err)
+
+	// No snapshot metadata is written for a failed snapshot.
+	metadatas, _, err := fs.SortedSnapshotMetadataFiles(fs.NewOptions().SetFilePathPrefix(prefix))
+	require.NoError(t, err)
+	require.Empty(t, metadatas)
+}
+
+func TestBackupManagerBackupNotBootstrapped(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mgr, db, _, _ := newTestBackupManager(t, ctrl, "/var/lib/m3db")
+	db.EXPECT().IsBootstrapped().Return(false)
+
+	_, err := mgr.Backup(time.Now(), DatabaseBootstrapState{}, "/tmp/backup")
+	require.Equal(t, errBackupDatabaseNotBootstrapped, err)
+}
+
+func TestBackupManagerBackupInProgress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mgr, _, _, _ := newTestBackupManager(t, ctrl, "/var/lib/m3db")
+	mgr.backupInProgress = true
+
+	_, err := mgr.Backup(time.Now(), DatabaseBootstrapState{}, "/tmp/backup")
+	require.Equal(t, errBackupInProgress, err)
+}
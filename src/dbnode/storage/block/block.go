@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/dbnode/x/xio"
 	"github.com/m3db/m3x/context"
@@ -59,6 +60,8 @@ type dbBlock struct {
 
 	onEvicted OnEvictedFromWiredList
 
+	equalTimesStrategy encoding.IterateEqualTimestampStrategy
+
 	// listState contains state that the Wired List requires in order to track a block's
 	// position in the wired list. All the state in this struct is "owned" by the wired
 	// list and should only be accessed by the Wired List itself. Does not require any
@@ -75,6 +78,7 @@ type listState struct {
 	next                  DatabaseBlock
 	prev                  DatabaseBlock
 	enteredListAtUnixNano int64
+	wiredSize             int
 }
 
 // NewDatabaseBlock creates a new DatabaseBlock instance.
@@ -303,7 +307,7 @@ func (b *dbBlock) forceMergeWithLock(ctx context.Context, stream xio.SegmentRead
 	mergedBlockReader := newDatabaseMergedBlockReader(start, b.blockSize,
 		mergeableStream{stream: stream, finalize: false},       // Should have been marked for finalization by the caller
 		mergeableStream{stream: targetStream, finalize: false}, // Already marked for finalization by the Stream() call above
-		b.opts)
+		b.equalTimesStrategy, b.opts)
 	mergedSegment, err := mergedBlockReader.Segment()
 	if err != nil {
 		return err
@@ -319,6 +323,7 @@ func (b *dbBlock) resetNewBlockStartWithLock(start time.Time, blockSize time.Dur
 	b.blockSize = blockSize
 	atomic.StoreInt64(&b.lastReadUnixNanos, 0)
 	b.closed = false
+	b.equalTimesStrategy = encoding.DefaultIterateEqualTimestampStrategy
 	b.resetMergeTargetWithLock()
 }
 
@@ -414,11 +419,22 @@ func (b *dbBlock) setEnteredListAtUnixNano(value int64) {
 	b.listState.enteredListAtUnixNano = value
 }
 
+// Should only be used by the WiredList.
+func (b *dbBlock) wiredSize() int {
+	return b.listState.wiredSize
+}
+
+// Should only be used by the WiredList.
+func (b *dbBlock) setWiredSize(value int) {
+	b.listState.wiredSize = value
+}
+
 // wiredListEntry is a snapshot of a subset of the block's state that the WiredList
 // uses to determine if a block is eligible for inclusion in the WiredList.
 type wiredListEntry struct {
 	seriesID             ident.ID
 	startTime            time.Time
+	size                 int
 	closed               bool
 	wasRetrievedFromDisk bool
 }
@@ -432,6 +448,7 @@ func (b *dbBlock) wiredListEntry() wiredListEntry {
 		seriesID:             b.seriesID,
 		wasRetrievedFromDisk: b.wasRetrievedFromDisk,
 		startTime:            b.startWithRLock(),
+		size:                 b.length,
 	}
 	b.RUnlock()
 	return result
@@ -443,6 +460,12 @@ func (b *dbBlock) SetOnEvictedFromWiredList(onEvicted OnEvictedFromWiredList) {
 	b.Unlock()
 }
 
+func (b *dbBlock) SetIterateEqualTimestampStrategy(strategy encoding.IterateEqualTimestampStrategy) {
+	b.Lock()
+	b.equalTimesStrategy = strategy
+	b.Unlock()
+}
+
 func (b *dbBlock) OnEvictedFromWiredList() OnEvictedFromWiredList {
 	b.RLock()
 	onEvicted := b.onEvicted
@@ -200,6 +200,41 @@ func TestDatabaseBlockMerge(t *testing.T) {
 	}
 }
 
+func TestDatabaseBlockMergeIterateEqualTimestampStrategy(t *testing.T) {
+	var (
+		curr         = time.Now().Truncate(time.Second)
+		blockOpts    = NewOptions()
+		encodingOpts = encoding.NewOptions()
+	)
+	newBlock := func(value float64) DatabaseBlock {
+		encoder := m3tsz.NewEncoder(curr, nil, true, encodingOpts)
+		encoder.Encode(ts.Datapoint{Timestamp: curr, Value: value}, xtime.Second, nil)
+		return NewDatabaseBlock(curr, time.Hour, encoder.Discard(), blockOpts)
+	}
+	mergedValue := func(strategy encoding.IterateEqualTimestampStrategy) float64 {
+		block1, block2 := newBlock(5), newBlock(3)
+		block1.SetIterateEqualTimestampStrategy(strategy)
+		require.NoError(t, block1.Merge(block2))
+
+		ctx := blockOpts.ContextPool().Get()
+		defer ctx.Close()
+
+		stream, err := block1.Stream(ctx)
+		require.NoError(t, err)
+		iter := m3tsz.NewReaderIterator(stream, true, encodingOpts)
+		require.True(t, iter.Next())
+		dp, _, _ := iter.Current()
+		require.False(t, iter.Next())
+		require.NoError(t, iter.Err())
+		return dp.Value
+	}
+
+	require.Equal(t, 3.0, mergedValue(encoding.IterateLastPushed))
+	require.Equal(t, 5.0, mergedValue(encoding.IterateFirstPushed))
+	require.Equal(t, 5.0, mergedValue(encoding.IterateHighestValue))
+	require.Equal(t, 3.0, mergedValue(encoding.IterateLowestValue))
+}
+
 // TestDatabaseBlockMergeRace is similar to TestDatabaseBlockMerge, except it
 // tries to stream the data in multiple go-routines to ensure the merging isn't
 // racy, this is a regression test for a known issue.
@@ -32,14 +32,15 @@ import (
 
 type dbMergedBlockReader struct {
 	sync.RWMutex
-	opts       Options
-	blockStart time.Time
-	blockSize  time.Duration
-	streams    [2]mergeableStream
-	readers    [2]xio.SegmentReader
-	merged     xio.BlockReader
-	encoder    encoding.Encoder
-	err        error
+	opts               Options
+	blockStart         time.Time
+	blockSize          time.Duration
+	equalTimesStrategy encoding.IterateEqualTimestampStrategy
+	streams            [2]mergeableStream
+	readers            [2]xio.SegmentReader
+	merged             xio.BlockReader
+	encoder            encoding.Encoder
+	err                error
 }
 
 type mergeableStream struct {
@@ -62,12 +63,14 @@ func newDatabaseMergedBlockReader(
 	blockStart time.Time,
 	blockSize time.Duration,
 	streamA, streamB mergeableStream,
+	equalTimesStrategy encoding.IterateEqualTimestampStrategy,
 	opts Options,
 ) xio.BlockReader {
 	r := &dbMergedBlockReader{
-		opts:       opts,
-		blockStart: blockStart,
-		blockSize:  blockSize,
+		opts:               opts,
+		blockStart:         blockStart,
+		blockSize:          blockSize,
+		equalTimesStrategy: equalTimesStrategy,
 	}
 	r.streams[0] = streamA
 	r.streams[1] = streamB
@@ -97,6 +100,9 @@ func (r *dbMergedBlockReader) mergedReader() (xio.BlockReader, error) {
 
 	multiIter := r.opts.MultiReaderIteratorPool().Get()
 	multiIter.Reset(r.readers[:], r.blockStart, r.blockSize)
+	// NB: The first stream is that of the block being merged into and the
+	// second that of the block merged into it, so later values are last.
+	multiIter.SetIterateEqualTimestampStrategy(r.equalTimesStrategy)
 	defer multiIter.Close()
 
 	r.encoder = r.opts.EncoderPool().Get()
@@ -151,6 +157,7 @@ func (r *dbMergedBlockReader) Clone() (xio.SegmentReader, error) {
 		r.blockSize,
 		s0,
 		s1,
+		r.equalTimesStrategy,
 		r.opts,
 	), nil
 }
@@ -108,6 +108,17 @@ func (r *shardBlockRetriever) Stream(
 		blockStart, onRetrieve)
 }
 
+func (r *shardBlockRetriever) StreamRange(
+	ctx context.Context,
+	id ident.ID,
+	blockStart time.Time,
+	readStart, readEnd time.Time,
+	onRetrieve OnRetrieveBlock,
+) (xio.BlockReader, error) {
+	return r.DatabaseBlockRetriever.StreamRange(ctx, r.shard, id,
+		blockStart, readStart, readEnd, onRetrieve)
+}
+
 type shardBlockRetrieverManager struct {
 	sync.RWMutex
 	retriever       DatabaseBlockRetriever
@@ -0,0 +1,82 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package block
+
+import (
+	"io"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/proto"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3x/instrument"
+	"github.com/m3db/m3x/pool"
+)
+
+// NewSchemaOptions returns a copy of the block options with their own encoder,
+// iterator and block pools that encode values as protobuf messages of the given
+// schema rather than M3TSZ, the pools report metrics to the given instrument
+// options.
+func NewSchemaOptions(
+	opts Options,
+	schema *proto.Schema,
+	iopts instrument.Options,
+) Options {
+	scope := iopts.MetricsScope()
+	newPoolOpts := func(name string) pool.ObjectPoolOptions {
+		return pool.NewObjectPoolOptions().SetInstrumentOptions(
+			iopts.SetMetricsScope(scope.SubScope(name)))
+	}
+
+	var (
+		encoderPool             = encoding.NewEncoderPool(newPoolOpts("encoder-pool"))
+		readerIteratorPool      = encoding.NewReaderIteratorPool(newPoolOpts("reader-iterator-pool"))
+		multiReaderIteratorPool = encoding.NewMultiReaderIteratorPool(newPoolOpts("multi-reader-iterator-pool"))
+		blockPool               = NewDatabaseBlockPool(newPoolOpts("block-pool"))
+	)
+
+	encodingOpts := encoding.NewOptions().
+		SetEncoderPool(encoderPool).
+		SetReaderIteratorPool(readerIteratorPool).
+		SetBytesPool(opts.BytesPool()).
+		SetSegmentReaderPool(opts.SegmentReaderPool())
+
+	encoderPool.Init(func() encoding.Encoder {
+		return proto.NewEncoder(time.Time{}, nil, schema, encodingOpts)
+	})
+	readerIteratorPool.Init(func(r io.Reader) encoding.ReaderIterator {
+		return proto.NewReaderIterator(r, schema, encodingOpts)
+	})
+	multiReaderIteratorPool.Init(func(r io.Reader) encoding.ReaderIterator {
+		iter := readerIteratorPool.Get()
+		iter.Reset(r)
+		return iter
+	})
+
+	opts = opts.
+		SetEncoderPool(encoderPool).
+		SetReaderIteratorPool(readerIteratorPool).
+		SetMultiReaderIteratorPool(multiReaderIteratorPool).
+		SetDatabaseBlockPool(blockPool)
+	blockPool.Init(func() DatabaseBlock {
+		return NewDatabaseBlock(time.Time{}, 0, ts.Segment{}, opts)
+	})
+	return opts
+}
@@ -197,6 +197,11 @@ type DatabaseBlock interface {
 	// OnEvictedFromWiredList returns the owner of the block
 	OnEvictedFromWiredList() OnEvictedFromWiredList
 
+	// SetIterateEqualTimestampStrategy sets the strategy used to select a
+	// value when merging the block with values of equal timestamps from the
+	// blocks merged into it, the strategy is reset when the block is reset.
+	SetIterateEqualTimestampStrategy(strategy encoding.IterateEqualTimestampStrategy)
+
 	// Private methods because only the Wired List itself should use them.
 	databaseBlock
 }
@@ -209,6 +214,8 @@ type databaseBlock interface {
 	setPrev(block DatabaseBlock)
 	enteredListAtUnixNano() int64
 	setEnteredListAtUnixNano(value int64)
+	wiredSize() int
+	setWiredSize(value int)
 	wiredListEntry() wiredListEntry
 }
 
@@ -272,6 +279,19 @@ type DatabaseBlockRetriever interface {
 		blockStart time.Time,
 		onRetrieve OnRetrieveBlock,
 	) (xio.BlockReader, error)
+
+	// StreamRange will stream the datapoints within a range of a block for a
+	// given shard, id and start. The retrieved block is only cached if the
+	// whole block had to be read to stream the range, so it should only be
+	// used for blocks that are not cached.
+	StreamRange(
+		ctx context.Context,
+		shard uint32,
+		id ident.ID,
+		blockStart time.Time,
+		readStart, readEnd time.Time,
+		onRetrieve OnRetrieveBlock,
+	) (xio.BlockReader, error)
 }
 
 // DatabaseShardBlockRetriever is a block retriever bound to a shard.
@@ -283,6 +303,18 @@ type DatabaseShardBlockRetriever interface {
 		blockStart time.Time,
 		onRetrieve OnRetrieveBlock,
 	) (xio.BlockReader, error)
+
+	// StreamRange will stream the datapoints within a range of a block for a
+	// given id and start. The retrieved block is only cached if the whole
+	// block had to be read to stream the range, so it should only be used
+	// for blocks that are not cached.
+	StreamRange(
+		ctx context.Context,
+		id ident.ID,
+		blockStart time.Time,
+		readStart, readEnd time.Time,
+		onRetrieve OnRetrieveBlock,
+	) (xio.BlockReader, error)
 }
 
 // DatabaseBlockRetrieverManager creates and holds block retrievers
@@ -46,6 +46,12 @@
 // be provided to the WiredList if it wasn't read from disk. This prevents tricky
 // ownership semantics where both the background tick and and the WiredList are
 // competing for ownership / trying to close the same blocks.
+//
+// Besides the global list, a namespace may own a wired list of its own with a
+// fixed byte budget so that heavy reads against one namespace cannot evict the
+// cached blocks of another. Lists can be bounded by number of blocks, by bytes
+// of block data, or both, and can optionally use a TinyLFU admission policy so
+// that blocks read once do not displace blocks that are read frequently.
 
 package block
 
@@ -57,6 +63,7 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/clock"
 	"github.com/m3db/m3/src/dbnode/runtime"
+	xclose "github.com/m3db/m3x/close"
 	"github.com/m3db/m3x/instrument"
 	xlog "github.com/m3db/m3x/log"
 
@@ -81,23 +88,38 @@ type WiredList struct {
 
 	// Max wired blocks, must use atomic store and load to access.
 	maxWired int64
+	// Max wired bytes, must use atomic store and load to access.
+	maxWiredBytes int64
+	fixedMaxBytes bool
 
 	root          dbBlock
 	length        int
+	bytes         int64
+	sketch        *frequencySketch
+	windowHits    int64
+	windowMisses  int64
 	updatesChSize int
 	updatesCh     chan DatabaseBlock
 	doneCh        chan struct{}
 
-	metrics wiredListMetrics
-	iOpts   instrument.Options
+	metrics             wiredListMetrics
+	opts                WiredListOptions
+	iOpts               instrument.Options
+	runtimeOptsListener xclose.SimpleCloser
 }
 
 type wiredListMetrics struct {
 	unwireable           tally.Gauge
 	limit                tally.Gauge
+	bytes                tally.Gauge
+	limitBytes           tally.Gauge
+	hitRatio             tally.Gauge
 	evicted              tally.Counter
 	pushedBack           tally.Counter
 	inserted             tally.Counter
+	hits                 tally.Counter
+	misses               tally.Counter
+	rejected             tally.Counter
 	evictedAfterDuration tally.Timer
 }
 
@@ -106,6 +128,12 @@ func newWiredListMetrics(scope tally.Scope) wiredListMetrics {
 		// Keeps track of how many blocks are in the list
 		unwireable: scope.Gauge("unwireable"),
 		limit:      scope.Gauge("limit"),
+		// Keeps track of how many bytes of block data are in the list
+		bytes:      scope.Gauge("bytes"),
+		limitBytes: scope.Gauge("limit-bytes"),
+		// Ratio of reads served by blocks already in the list over all
+		// reads of blocks tracked by the list since the last sample
+		hitRatio: scope.Gauge("hit-ratio"),
 		// Incremented when a block is evicted
 		evicted: scope.Counter("evicted"),
 		// Incremented when a block is "pushed back" in the list, I.E
@@ -114,6 +142,13 @@ func newWiredListMetrics(scope tally.Scope) wiredListMetrics {
 		// Incremented when a block is inserted into the list, I.E
 		// it wasn't already present
 		inserted: scope.Counter("inserted"),
+		// Incremented when a block read is served by a block in the list
+		hits: scope.Counter("hits"),
+		// Incremented when a block had to be retrieved from disk
+		misses: scope.Counter("misses"),
+		// Incremented when a block retrieved from disk is evicted straight
+		// away because the admission policy rejected it
+		rejected: scope.Counter("rejected"),
 		// Measure how much time blocks spend in the list before being evicted
 		evictedAfterDuration: scope.Timer("evicted-after-duration"),
 	}
@@ -125,6 +160,12 @@ type WiredListOptions struct {
 	InstrumentOptions     instrument.Options
 	ClockOptions          clock.Options
 	EventsChannelSize     int
+	// MaxBytes is a fixed max bytes of block data to keep wired which takes
+	// precedence over the runtime max wired bytes when set.
+	MaxBytes int64
+	// AdmissionPolicy is the policy used to decide whether blocks retrieved
+	// from disk are admitted into the list when it is at capacity.
+	AdmissionPolicy WiredListAdmissionPolicy
 }
 
 // NewWiredList returns a new database block wired list.
@@ -134,8 +175,16 @@ func NewWiredList(opts WiredListOptions) *WiredList {
 	l := &WiredList{
 		nowFn:   opts.ClockOptions.NowFn(),
 		metrics: newWiredListMetrics(scope),
+		opts:    opts,
 		iOpts:   opts.InstrumentOptions,
 	}
+	if opts.MaxBytes > 0 {
+		l.maxWiredBytes = opts.MaxBytes
+		l.fixedMaxBytes = true
+	}
+	if opts.AdmissionPolicy == WiredListAdmitTinyLFU {
+		l.sketch = newFrequencySketch(defaultFrequencySketchWidth)
+	}
 	if opts.EventsChannelSize > 0 {
 		l.updatesChSize = opts.EventsChannelSize
 	} else {
@@ -143,14 +192,22 @@ func NewWiredList(opts WiredListOptions) *WiredList {
 	}
 	l.root.setNext(&l.root)
 	l.root.setPrev(&l.root)
-	opts.RuntimeOptionsManager.RegisterListener(l)
+	l.runtimeOptsListener = opts.RuntimeOptionsManager.RegisterListener(l)
 	return l
 }
 
+// Options returns the options the wired list was constructed with.
+func (l *WiredList) Options() WiredListOptions {
+	return l.opts
+}
+
 // SetRuntimeOptions sets the current runtime options to
 // be consumed by the wired list
 func (l *WiredList) SetRuntimeOptions(value runtime.Options) {
 	atomic.StoreInt64(&l.maxWired, int64(value.MaxWiredBlocks()))
+	if !l.fixedMaxBytes {
+		atomic.StoreInt64(&l.maxWiredBytes, int64(value.MaxWiredBytes()))
+	}
 }
 
 // Start starts processing the wired list
@@ -168,8 +225,7 @@ func (l *WiredList) Start() error {
 		for v := range l.updatesCh {
 			l.processUpdateBlock(v)
 			if i%wiredListSampleGaugesEvery == 0 {
-				l.metrics.unwireable.Update(float64(l.length))
-				l.metrics.limit.Update(float64(atomic.LoadInt64(&l.maxWired)))
+				l.sampleGauges()
 			}
 			i++
 		}
@@ -179,6 +235,17 @@ func (l *WiredList) Start() error {
 	return nil
 }
 
+func (l *WiredList) sampleGauges() {
+	l.metrics.unwireable.Update(float64(l.length))
+	l.metrics.limit.Update(float64(atomic.LoadInt64(&l.maxWired)))
+	l.metrics.bytes.Update(float64(l.bytes))
+	l.metrics.limitBytes.Update(float64(atomic.LoadInt64(&l.maxWiredBytes)))
+	if total := l.windowHits + l.windowMisses; total > 0 {
+		l.metrics.hitRatio.Update(float64(l.windowHits) / float64(total))
+	}
+	l.windowHits, l.windowMisses = 0, 0
+}
+
 // Stop stops processing the wired list
 func (l *WiredList) Stop() error {
 	l.Lock()
@@ -198,6 +265,18 @@ func (l *WiredList) Stop() error {
 	return nil
 }
 
+// Close stops processing the wired list if started and stops listening
+// for runtime options updates, the wired list cannot be used afterwards.
+func (l *WiredList) Close() error {
+	if l.runtimeOptsListener != nil {
+		l.runtimeOptsListener.Close()
+	}
+	if err := l.Stop(); err != nil && err != errAlreadyStopped {
+		return err
+	}
+	return nil
+}
+
 // BlockingUpdate places the block into the channel of blocks which are waiting to notify the
 // wired list that they were accessed. All updates must be processed through this channel
 // to force synchronization.
@@ -236,7 +315,10 @@ func (l *WiredList) processUpdateBlock(v DatabaseBlock) {
 	// If a block is still unwireable then its worth keeping track of in the wired list
 	// so we push it back.
 	if unwireable {
-		l.pushBack(v)
+		if l.sketch != nil && entry.seriesID != nil {
+			l.sketch.increment(frequencySketchHash(entry.seriesID, entry.startTime))
+		}
+		l.pushBack(v, entry)
 		return
 	}
 
@@ -247,7 +329,11 @@ func (l *WiredList) processUpdateBlock(v DatabaseBlock) {
 	l.remove(v)
 }
 
-func (l *WiredList) insertAfter(v, at DatabaseBlock) {
+// insertAfter links the block into the list after the given block and then
+// evicts blocks from the front of the list until it is within budget. If the
+// block is a newly admitted candidate then the admission policy may choose to
+// evict the candidate itself rather than a more frequently accessed block.
+func (l *WiredList) insertAfter(v, at DatabaseBlock, candidate bool) {
 	now := l.nowFn()
 
 	n := at.next()
@@ -256,73 +342,107 @@ func (l *WiredList) insertAfter(v, at DatabaseBlock) {
 	v.setNext(n)
 	n.setPrev(v)
 	l.length++
+	l.bytes += int64(v.wiredSize())
 
 	maxWired := int(atomic.LoadInt64(&l.maxWired))
-	if maxWired <= 0 {
-		// Not enforcing max wired blocks
+	maxWiredBytes := atomic.LoadInt64(&l.maxWiredBytes)
+	if maxWired <= 0 && maxWiredBytes <= 0 {
+		// Not enforcing max wired blocks or bytes
 		return
 	}
 
+	overBudget := func() bool {
+		return (maxWired > 0 && l.length > maxWired) ||
+			(maxWiredBytes > 0 && l.bytes > maxWiredBytes)
+	}
+
 	// Try to unwire all blocks possible
 	bl := l.root.next()
-	for l.length > maxWired && bl != &l.root {
-		entry := bl.wiredListEntry()
-		if !entry.wasRetrievedFromDisk {
-			// This should never happen because processUpdateBlock performs the same
-			// check, and a block should never be pooled in-between those steps because
-			// the wired list is supposed to have sole ownership over that lifecycle and
-			// is single-threaded.
-			instrument.EmitAndLogInvariantViolation(l.iOpts, func(l xlog.Logger) {
-				l.WithFields(
-					xlog.NewField("blockStart", entry.startTime),
-					xlog.NewField("closed", entry.closed),
-					xlog.NewField("wasRetrievedFromDisk", entry.wasRetrievedFromDisk),
-				).Errorf("wired list tried to process a block that was not retrieved from disk")
-			})
-
-		}
-
-		// Evict the block before closing it so that callers of series.ReadEncoded()
-		// don't get errors about trying to read from a closed block.
-		if onEvict := bl.OnEvictedFromWiredList(); onEvict != nil {
-			if entry.seriesID == nil {
-				// Entry should always have a series ID attached
-				instrument.EmitAndLogInvariantViolation(l.iOpts, func(l xlog.Logger) {
-					l.WithFields(
-						xlog.NewField("blockStart", entry.startTime),
-						xlog.NewField("closed", entry.closed),
-						xlog.NewField("wasRetrievedFromDisk", entry.wasRetrievedFromDisk),
-					).Errorf("wired list entry does not have seriesID set")
-				})
-
-			} else {
-				onEvict.OnEvictedFromWiredList(entry.seriesID, entry.startTime)
-			}
+	for overBudget() && bl != &l.root {
+		if candidate && bl != v && !l.admit(v, bl) {
+			// The candidate has been accessed less frequently than the least
+			// recently used block so evict the candidate instead.
+			l.metrics.rejected.Inc(1)
+			l.evict(v, now)
+			return
 		}
 
-		// bl.CloseIfFromDisk() will return the block to the pool. In order to avoid
-		// races with the pool itself, we capture the value of the next block and
-		// remove the block from the wired list before we close it.
+		// l.evict() will return the block to the pool. In order to avoid
+		// races with the pool itself, we capture the value of the next block
+		// before we evict it.
 		nextBl := bl.next()
-		l.remove(bl)
-		if wasFromDisk := bl.CloseIfFromDisk(); !wasFromDisk {
-			// Should never happen
+		l.evict(bl, now)
+		bl = nextBl
+	}
+}
+
+// admit returns whether the candidate block should be admitted to the list
+// at the expense of evicting the victim block.
+func (l *WiredList) admit(candidate, victim DatabaseBlock) bool {
+	if l.sketch == nil {
+		return true
+	}
+	c, vi := candidate.wiredListEntry(), victim.wiredListEntry()
+	if c.seriesID == nil || vi.seriesID == nil {
+		return true
+	}
+	return l.sketch.estimate(frequencySketchHash(c.seriesID, c.startTime)) >
+		l.sketch.estimate(frequencySketchHash(vi.seriesID, vi.startTime))
+}
+
+func (l *WiredList) evict(bl DatabaseBlock, now time.Time) {
+	entry := bl.wiredListEntry()
+	if !entry.wasRetrievedFromDisk {
+		// This should never happen because processUpdateBlock performs the same
+		// check, and a block should never be pooled in-between those steps because
+		// the wired list is supposed to have sole ownership over that lifecycle and
+		// is single-threaded.
+		instrument.EmitAndLogInvariantViolation(l.iOpts, func(l xlog.Logger) {
+			l.WithFields(
+				xlog.NewField("blockStart", entry.startTime),
+				xlog.NewField("closed", entry.closed),
+				xlog.NewField("wasRetrievedFromDisk", entry.wasRetrievedFromDisk),
+			).Errorf("wired list tried to process a block that was not retrieved from disk")
+		})
+
+	}
+
+	// Evict the block before closing it so that callers of series.ReadEncoded()
+	// don't get errors about trying to read from a closed block.
+	if onEvict := bl.OnEvictedFromWiredList(); onEvict != nil {
+		if entry.seriesID == nil {
+			// Entry should always have a series ID attached
 			instrument.EmitAndLogInvariantViolation(l.iOpts, func(l xlog.Logger) {
 				l.WithFields(
 					xlog.NewField("blockStart", entry.startTime),
 					xlog.NewField("closed", entry.closed),
 					xlog.NewField("wasRetrievedFromDisk", entry.wasRetrievedFromDisk),
-				).Errorf("wired list tried to close a block that was not from disk")
+				).Errorf("wired list entry does not have seriesID set")
 			})
-		}
 
-		l.metrics.evicted.Inc(1)
-
-		enteredListAt := time.Unix(0, bl.enteredListAtUnixNano())
-		l.metrics.evictedAfterDuration.Record(now.Sub(enteredListAt))
+		} else {
+			onEvict.OnEvictedFromWiredList(entry.seriesID, entry.startTime)
+		}
+	}
 
-		bl = nextBl
+	// bl.CloseIfFromDisk() will return the block to the pool. In order to avoid
+	// races with the pool itself, we remove the block from the wired list
+	// before we close it.
+	enteredListAt := time.Unix(0, bl.enteredListAtUnixNano())
+	l.remove(bl)
+	if wasFromDisk := bl.CloseIfFromDisk(); !wasFromDisk {
+		// Should never happen
+		instrument.EmitAndLogInvariantViolation(l.iOpts, func(l xlog.Logger) {
+			l.WithFields(
+				xlog.NewField("blockStart", entry.startTime),
+				xlog.NewField("closed", entry.closed),
+				xlog.NewField("wasRetrievedFromDisk", entry.wasRetrievedFromDisk),
+			).Errorf("wired list tried to close a block that was not from disk")
+		})
 	}
+
+	l.metrics.evicted.Inc(1)
+	l.metrics.evictedAfterDuration.Record(now.Sub(enteredListAt))
 }
 
 func (l *WiredList) remove(v DatabaseBlock) {
@@ -335,18 +455,24 @@ func (l *WiredList) remove(v DatabaseBlock) {
 	v.setNext(nil) // avoid memory leaks
 	v.setPrev(nil) // avoid memory leaks
 	l.length--
+	l.bytes -= int64(v.wiredSize())
 }
 
-func (l *WiredList) pushBack(v DatabaseBlock) {
+func (l *WiredList) pushBack(v DatabaseBlock, entry wiredListEntry) {
 	if l.exists(v) {
 		l.metrics.pushedBack.Inc(1)
+		l.metrics.hits.Inc(1)
+		l.windowHits++
 		l.moveToBack(v)
 		return
 	}
 
 	l.metrics.inserted.Inc(1)
-	l.insertAfter(v, l.root.prev())
+	l.metrics.misses.Inc(1)
+	l.windowMisses++
+	v.setWiredSize(entry.size)
 	v.setEnteredListAtUnixNano(l.nowFn().UnixNano())
+	l.insertAfter(v, l.root.prev(), true)
 }
 
 func (l *WiredList) moveToBack(v DatabaseBlock) {
@@ -354,7 +480,7 @@ func (l *WiredList) moveToBack(v DatabaseBlock) {
 		return
 	}
 	l.remove(v)
-	l.insertAfter(v, l.root.prev())
+	l.insertAfter(v, l.root.prev(), false)
 }
 
 func (l *WiredList) exists(v DatabaseBlock) bool {
@@ -0,0 +1,189 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package block
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/m3db/m3x/ident"
+
+	"github.com/cespare/xxhash"
+)
+
+const (
+	// defaultFrequencySketchWidth is the number of counters per row of the
+	// frequency sketch, must be a power of two.
+	defaultFrequencySketchWidth = 1 << 16
+	frequencySketchDepth        = 4
+	frequencySketchMaxCount     = 15
+	// frequencySketchResetFactor is the multiple of the sketch width of
+	// increments after which all counters are halved so that the sketch
+	// tracks recent popularity rather than all time popularity.
+	frequencySketchResetFactor = 10
+)
+
+var (
+	errWiredListAdmissionPolicyUnspecified = errors.New("wired list admission policy unspecified")
+
+	frequencySketchSeeds = [frequencySketchDepth]uint64{
+		0xc3a5c85c97cb3127, 0xb492b66fbe98f273, 0x9ae16a3b2f90404f, 0xcbf29ce484222325,
+	}
+)
+
+// WiredListAdmissionPolicy is the policy used to decide whether a block
+// retrieved from disk is admitted into a wired list that is at capacity.
+type WiredListAdmissionPolicy uint
+
+const (
+	// WiredListAdmitAll admits every block retrieved from disk, evicting the
+	// least recently used blocks to make room.
+	WiredListAdmitAll WiredListAdmissionPolicy = iota
+	// WiredListAdmitTinyLFU only admits a block retrieved from disk if it has
+	// been accessed more frequently than the least recently used block it
+	// would evict, as estimated by a TinyLFU frequency sketch. This stops one
+	// off scans over large amounts of data from flushing frequently read
+	// blocks out of the list.
+	WiredListAdmitTinyLFU
+
+	// DefaultWiredListAdmissionPolicy is the default wired list admission policy.
+	DefaultWiredListAdmissionPolicy = WiredListAdmitAll
+)
+
+// ValidWiredListAdmissionPolicies returns the valid wired list admission policies.
+func ValidWiredListAdmissionPolicies() []WiredListAdmissionPolicy {
+	return []WiredListAdmissionPolicy{WiredListAdmitAll, WiredListAdmitTinyLFU}
+}
+
+func (p WiredListAdmissionPolicy) String() string {
+	switch p {
+	case WiredListAdmitAll:
+		return "all"
+	case WiredListAdmitTinyLFU:
+		return "tinylfu"
+	}
+	return "unknown"
+}
+
+// ParseWiredListAdmissionPolicy parses a WiredListAdmissionPolicy from a string.
+func ParseWiredListAdmissionPolicy(str string) (WiredListAdmissionPolicy, error) {
+	var r WiredListAdmissionPolicy
+	if str == "" {
+		return r, errWiredListAdmissionPolicyUnspecified
+	}
+	for _, valid := range ValidWiredListAdmissionPolicies() {
+		if str == valid.String() {
+			r = valid
+			return r, nil
+		}
+	}
+	return r, fmt.Errorf("invalid wired list admission policy '%s' valid types are: %v",
+		str, ValidWiredListAdmissionPolicies())
+}
+
+// UnmarshalYAML unmarshals a WiredListAdmissionPolicy into a valid type from string.
+func (p *WiredListAdmissionPolicy) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+	r, err := ParseWiredListAdmissionPolicy(str)
+	if err != nil {
+		return err
+	}
+	*p = r
+	return nil
+}
+
+// frequencySketch is a count-min sketch of saturating counters that estimates
+// how often a block has been accessed recently, it is the TinyLFU frequency
+// histogram. It is not safe for concurrent use, which is fine since the wired
+// list processes updates from a single goroutine.
+type frequencySketch struct {
+	mask      uint64
+	counters  [frequencySketchDepth][]uint8
+	additions int
+	resetAt   int
+}
+
+func newFrequencySketch(width int) *frequencySketch {
+	// Round the width up to a power of two so indexes can be masked.
+	w := 1
+	for w < width {
+		w <<= 1
+	}
+	s := &frequencySketch{
+		mask:    uint64(w - 1),
+		resetAt: w * frequencySketchResetFactor,
+	}
+	for i := range s.counters {
+		s.counters[i] = make([]uint8, w)
+	}
+	return s
+}
+
+func frequencySketchHash(id ident.ID, start time.Time) uint64 {
+	h := xxhash.Sum64(id.Bytes())
+	return h ^ (uint64(start.UnixNano()) * 0x9e3779b97f4a7c15)
+}
+
+func (s *frequencySketch) index(h uint64, row int) uint64 {
+	h = (h ^ frequencySketchSeeds[row]) * 0xff51afd7ed558ccd
+	h ^= h >> 33
+	return h & s.mask
+}
+
+// increment records an access for the hashed key.
+func (s *frequencySketch) increment(h uint64) {
+	for i := range s.counters {
+		idx := s.index(h, i)
+		if s.counters[i][idx] < frequencySketchMaxCount {
+			s.counters[i][idx]++
+		}
+	}
+	s.additions++
+	if s.additions >= s.resetAt {
+		s.reset()
+	}
+}
+
+// estimate returns the estimated recent access count for the hashed key.
+func (s *frequencySketch) estimate(h uint64) uint8 {
+	min := uint8(frequencySketchMaxCount)
+	for i := range s.counters {
+		if v := s.counters[i][s.index(h, i)]; v < min {
+			min = v
+		}
+	}
+	return min
+}
+
+// reset halves all counters so that the sketch ages out stale popularity.
+func (s *frequencySketch) reset() {
+	for i := range s.counters {
+		row := s.counters[i]
+		for j := range row {
+			row[j] >>= 1
+		}
+	}
+	s.additions /= 2
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package block
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3x/ident"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrequencySketchEstimatesAndAges(t *testing.T) {
+	s := newFrequencySketch(64)
+	start := time.Unix(0, 0)
+	foo := frequencySketchHash(ident.StringID("foo"), start)
+	bar := frequencySketchHash(ident.StringID("bar"), start)
+
+	for i := 0; i < 4; i++ {
+		s.increment(foo)
+	}
+	s.increment(bar)
+
+	require.True(t, s.estimate(foo) >= 4)
+	require.True(t, s.estimate(foo) > s.estimate(bar))
+
+	// Counters saturate at the max count.
+	for i := 0; i < 2*frequencySketchMaxCount; i++ {
+		s.increment(foo)
+	}
+	require.Equal(t, uint8(frequencySketchMaxCount), s.estimate(foo))
+
+	// Resetting halves the counters.
+	s.reset()
+	require.Equal(t, uint8(frequencySketchMaxCount/2), s.estimate(foo))
+}
+
+func TestFrequencySketchHashDistinguishesBlockStarts(t *testing.T) {
+	id := ident.StringID("foo")
+	require.NotEqual(t,
+		frequencySketchHash(id, time.Unix(0, 0)),
+		frequencySketchHash(id, time.Unix(7200, 0)))
+}
+
+func TestParseWiredListAdmissionPolicy(t *testing.T) {
+	for _, valid := range ValidWiredListAdmissionPolicies() {
+		p, err := ParseWiredListAdmissionPolicy(valid.String())
+		require.NoError(t, err)
+		require.Equal(t, valid, p)
+	}
+
+	_, err := ParseWiredListAdmissionPolicy("")
+	require.Error(t, err)
+	_, err = ParseWiredListAdmissionPolicy("lfu")
+	require.Error(t, err)
+}
@@ -166,6 +166,89 @@ func TestWiredListRemovesUnwiredBlocks(t *testing.T) {
 	require.Equal(t, &l.root, l.root.prev())
 }
 
+func TestWiredListEvictsBlocksOverMaxWiredBytes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	l, runtimeOptsMgr := newTestWiredList(nil, nil)
+	require.NoError(t, runtimeOptsMgr.Update(runtime.NewOptions().
+		SetMaxWiredBytes(10)))
+
+	opts := testOptions.SetWiredList(l)
+
+	l.Start()
+
+	// Each block is 5 bytes so only two fit in the budget.
+	var blocks []*dbBlock
+	for i := 0; i < 3; i++ {
+		bl := newTestUnwireableBlock(ctrl, fmt.Sprintf("foo.%d", i), opts)
+		blocks = append(blocks, bl)
+	}
+
+	l.BlockingUpdate(blocks[0])
+	l.BlockingUpdate(blocks[1])
+	l.BlockingUpdate(blocks[2])
+
+	l.Stop()
+
+	require.Equal(t, 2, l.length)
+	require.Equal(t, int64(10), l.bytes)
+	require.True(t, blocks[0].closed)
+	require.Equal(t, blocks[1], l.root.next())
+	require.Equal(t, blocks[2], l.root.next().next())
+	require.Equal(t, &l.root, l.root.next().next().next())
+}
+
+func TestWiredListTinyLFURejectsInfrequentlyAccessedBlocks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	scope := tally.NewTestScope("", nil)
+	l := NewWiredList(WiredListOptions{
+		RuntimeOptionsManager: runtime.NewOptionsManager(),
+		InstrumentOptions:     instrument.NewOptions().SetMetricsScope(scope),
+		ClockOptions:          clock.NewOptions(),
+		EventsChannelSize:     1,
+		MaxBytes:              10,
+		AdmissionPolicy:       WiredListAdmitTinyLFU,
+	})
+
+	opts := testOptions.SetWiredList(l)
+
+	l.Start()
+
+	var blocks []*dbBlock
+	for i := 0; i < 3; i++ {
+		bl := newTestUnwireableBlock(ctrl, fmt.Sprintf("foo.%d", i), opts)
+		blocks = append(blocks, bl)
+	}
+
+	// Read the first two blocks a few times so they are popular.
+	for i := 0; i < 3; i++ {
+		l.BlockingUpdate(blocks[0])
+		l.BlockingUpdate(blocks[1])
+	}
+
+	// The third block has only been read once so it should be rejected
+	// rather than evicting the least recently used popular block.
+	l.BlockingUpdate(blocks[2])
+
+	l.Stop()
+
+	require.Equal(t, 2, l.length)
+	require.Equal(t, int64(10), l.bytes)
+	require.False(t, blocks[0].closed)
+	require.False(t, blocks[1].closed)
+	require.True(t, blocks[2].closed)
+	require.Equal(t, blocks[0], l.root.next())
+	require.Equal(t, blocks[1], l.root.next().next())
+
+	counters := scope.Snapshot().Counters()
+	require.Equal(t, int64(1), counters["wired-list.rejected+"].Value())
+	require.Equal(t, int64(4), counters["wired-list.hits+"].Value())
+	require.Equal(t, int64(3), counters["wired-list.misses+"].Value())
+}
+
 // wiredListTestWiredBlocksString is used to debug the order of the wired list
 func wiredListTestWiredBlocksString(l *WiredList) string { // nolint: unused
 	b := bytes.NewBuffer(nil)
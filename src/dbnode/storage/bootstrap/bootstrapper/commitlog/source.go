@@ -212,11 +212,11 @@ func (s *commitLogSource) ReadData(
 		return nil, err
 	}
 
-	var (
-		bOpts     = s.opts.ResultOptions()
-		blOpts    = bOpts.DatabaseBlockOptions()
-		blockSize = ns.Options().RetentionOptions().BlockSize()
-	)
+	blOpts, err := s.namespaceBlockOptions(ns)
+	if err != nil {
+		return nil, err
+	}
+	blockSize := ns.Options().RetentionOptions().BlockSize()
 
 	// Determine the minimum number of commit logs files that we
 	// must read based on the available snapshot files.
@@ -355,6 +355,7 @@ func (s *commitLogSource) ReadData(
 		int(numShards),
 		blockSize,
 		shardDataByShard,
+		blOpts,
 	)
 	if err != nil {
 		return nil, err
@@ -374,6 +375,26 @@ func (s *commitLogSource) ReadData(
 	return bootstrapResult, nil
 }
 
+// namespaceBlockOptions returns the block options used to encode the data of a
+// namespace, namespaces with a schema encode values as protobuf messages rather
+// than M3TSZ.
+func (s *commitLogSource) namespaceBlockOptions(ns namespace.Metadata) (block.Options, error) {
+	blOpts := s.opts.ResultOptions().DatabaseBlockOptions()
+	schema, err := ns.Options().SchemaOptions().Schema()
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema for namespace %s: %v", ns.ID().String(), err)
+	}
+	if schema == nil {
+		return blOpts, nil
+	}
+
+	iopts := s.opts.ResultOptions().InstrumentOptions()
+	iopts = iopts.SetMetricsScope(iopts.MetricsScope().
+		SubScope("bootstrapper-commitlog").
+		SubScope("proto-encoding"))
+	return block.NewSchemaOptions(blOpts, schema, iopts), nil
+}
+
 func (s *commitLogSource) snapshotFilesByShard(
 	nsID ident.ID,
 	filePathPrefix string,
@@ -531,6 +552,7 @@ func (s *commitLogSource) bootstrapShardSnapshots(
 	blockSize time.Duration,
 	snapshotFiles fs.FileSetFilesSlice,
 	mostRecentCompleteSnapshotByBlockShard map[xtime.UnixNano]map[uint32]fs.FileSetFile,
+	blOpts block.Options,
 ) (result.ShardResult, error) {
 	var (
 		shardResult    result.ShardResult
@@ -579,7 +601,7 @@ func (s *commitLogSource) bootstrapShardSnapshots(
 
 			shardResult, err = s.bootstrapShardBlockSnapshot(
 				nsID, shard, blockStart, metadataOnly, shardResult, allSeriesSoFar, blockSize,
-				snapshotFiles, mostRecentCompleteSnapshotForShardBlock)
+				snapshotFiles, mostRecentCompleteSnapshotForShardBlock, blOpts)
 			if err != nil {
 				return shardResult, err
 			}
@@ -602,10 +624,9 @@ func (s *commitLogSource) bootstrapShardBlockSnapshot(
 	blockSize time.Duration,
 	snapshotFiles fs.FileSetFilesSlice,
 	mostRecentCompleteSnapshot fs.FileSetFile,
+	blOpts block.Options,
 ) (result.ShardResult, error) {
 	var (
-		bOpts      = s.opts.ResultOptions()
-		blOpts     = bOpts.DatabaseBlockOptions()
 		blocksPool = blOpts.DatabaseBlockPool()
 		bytesPool  = blOpts.BytesPool()
 		fsOpts     = s.opts.CommitLogOptions().FilesystemOptions()
@@ -997,11 +1018,15 @@ func (s *commitLogSource) mergeAllShardsCommitLogEncodersAndSnapshots(
 	numShards int,
 	blockSize time.Duration,
 	unmerged []shardData,
+	blOpts block.Options,
 ) (result.DataBootstrapResult, error) {
 	var (
 		shardErrs       = make([]int, numShards)
 		shardEmptyErrs  = make([]int, numShards)
 		bootstrapResult = result.NewDataBootstrapResult()
+		// Values with equal timestamps are resolved the same way as writes
+		// to the buffer so that replaying the commit log is deterministic.
+		equalTimesStrategy = ns.Options().DuplicatePolicy().IterateEqualTimestampStrategy()
 		// Controls how many shards can be merged in parallel
 		workerPool          = xsync.NewWorkerPool(s.opts.MergeShardsConcurrency())
 		bootstrapResultLock sync.Mutex
@@ -1023,6 +1048,7 @@ func (s *commitLogSource) mergeAllShardsCommitLogEncodersAndSnapshots(
 			blockSize,
 			snapshotFiles[uint32(shard)],
 			mostRecentCompleteSnapshotByBlockShard,
+			blOpts,
 		)
 		if err != nil {
 			bootstrapResultLock.Lock()
@@ -1043,7 +1069,7 @@ func (s *commitLogSource) mergeAllShardsCommitLogEncodersAndSnapshots(
 		mergeShardFunc := func() {
 			var shardResult result.ShardResult
 			shardResult, shardEmptyErrs[shard], shardErrs[shard] = s.mergeShardCommitLogEncodersAndSnapshots(
-				shard, snapshotData, unmergedShard, blockSize)
+				shard, snapshotData, unmergedShard, blockSize, equalTimesStrategy, blOpts)
 
 			if shardResult != nil && shardResult.NumSeries() > 0 {
 				// Prevent race conditions while updating bootstrapResult from multiple go-routines
@@ -1073,10 +1099,10 @@ func (s *commitLogSource) mergeShardCommitLogEncodersAndSnapshots(
 	snapshotData result.ShardResult,
 	unmergedShard shardData,
 	blockSize time.Duration,
+	equalTimesStrategy encoding.IterateEqualTimestampStrategy,
+	blOpts block.Options,
 ) (result.ShardResult, int, int) {
 	var (
-		bOpts                   = s.opts.ResultOptions()
-		blOpts                  = bOpts.DatabaseBlockOptions()
 		blocksPool              = blOpts.DatabaseBlockPool()
 		multiReaderIteratorPool = blOpts.MultiReaderIteratorPool()
 		segmentReaderPool       = blOpts.SegmentReaderPool()
@@ -1108,6 +1134,7 @@ func (s *commitLogSource) mergeShardCommitLogEncodersAndSnapshots(
 				segmentReaderPool,
 				encoderPool,
 				blockSize,
+				equalTimesStrategy,
 				blOpts,
 			)
 
@@ -1148,6 +1175,7 @@ func (s *commitLogSource) mergeSeries(
 	segmentReaderPool xio.SegmentReaderPool,
 	encoderPool encoding.EncoderPool,
 	blockSize time.Duration,
+	equalTimesStrategy encoding.IterateEqualTimestampStrategy,
 	blopts block.Options,
 ) (block.DatabaseSeriesBlocks, int, int) {
 	var seriesBlocks block.DatabaseSeriesBlocks
@@ -1180,6 +1208,7 @@ func (s *commitLogSource) mergeSeries(
 
 		iter := multiReaderIteratorPool.Get()
 		iter.Reset(readers, time.Time{}, 0)
+		iter.SetIterateEqualTimestampStrategy(equalTimesStrategy)
 
 		enc := encoderPool.Get()
 		enc.Reset(start, blopts.DatabaseBlockAllocSize())
@@ -1366,7 +1395,7 @@ func (s *commitLogSource) ReadIndex(
 	for shard, tr := range shardsTimeRanges {
 		shardResult, err := s.bootstrapShardSnapshots(
 			ns.ID(), shard, true, tr, blockSize, snapshotFilesByShard[shard],
-			mostRecentCompleteSnapshotByBlockShard)
+			mostRecentCompleteSnapshotByBlockShard, s.opts.ResultOptions().DatabaseBlockOptions())
 		if err != nil {
 			return nil, err
 		}
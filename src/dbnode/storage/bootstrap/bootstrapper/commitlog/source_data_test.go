@@ -43,9 +43,12 @@ import (
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3x/checked"
 	"github.com/m3db/m3x/ident"
+	"github.com/m3db/m3x/instrument"
 	"github.com/m3db/m3x/pool"
 	xtime "github.com/m3db/m3x/time"
 
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/protoc-gen-gogo/descriptor"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 )
@@ -175,6 +178,92 @@ func TestReadOrderedValues(t *testing.T) {
 		values[:4], blockSize, res.ShardResults(), opts))
 }
 
+func testEventSchemaOptions(t *testing.T) namespace.SchemaOptions {
+	fds := &descriptor.FileDescriptorSet{
+		File: []*descriptor.FileDescriptorProto{
+			{
+				Name:    proto.String("event.proto"),
+				Package: proto.String("test"),
+				MessageType: []*descriptor.DescriptorProto{
+					{
+						Name: proto.String("Event"),
+						Field: []*descriptor.FieldDescriptorProto{
+							{
+								Name:   proto.String("status"),
+								Number: proto.Int32(1),
+								Type:   descriptor.FieldDescriptorProto_TYPE_INT64.Enum(),
+								Label:  descriptor.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	b, err := proto.Marshal(fds)
+	require.NoError(t, err)
+	return namespace.NewSchemaOptions().
+		SetFileDescriptorSet(b).
+		SetMessageName("test.Event")
+}
+
+func testEvent(t *testing.T, status uint64) ts.Annotation {
+	buf := proto.NewBuffer(nil)
+	require.NoError(t, buf.EncodeVarint(1<<3))
+	require.NoError(t, buf.EncodeVarint(status))
+	return buf.Bytes()
+}
+
+func TestReadProtoNamespaceValues(t *testing.T) {
+	opts := testDefaultOpts
+	sopts := testEventSchemaOptions(t)
+	md, err := namespace.NewMetadata(testNamespaceID,
+		namespace.NewOptions().SetSchemaOptions(sopts))
+	require.NoError(t, err)
+	schema, err := sopts.Schema()
+	require.NoError(t, err)
+	src := newCommitLogSource(opts, fs.Inspection{}).(*commitLogSource)
+
+	blockSize := md.Options().RetentionOptions().BlockSize()
+	now := time.Now()
+	start := now.Truncate(blockSize).Add(-blockSize)
+	end := now.Truncate(blockSize)
+
+	ranges := xtime.Ranges{}
+	ranges = ranges.AddRange(xtime.Range{
+		Start: start,
+		End:   end,
+	})
+
+	foo := ts.Series{Namespace: testNamespaceID, Shard: 0, ID: ident.StringID("foo")}
+	bar := ts.Series{Namespace: testNamespaceID, Shard: 1, ID: ident.StringID("bar")}
+
+	values := []testValue{
+		{foo, start, 1.0, xtime.Second, testEvent(t, 200)},
+		{foo, start.Add(1 * time.Minute), 2.0, xtime.Second, testEvent(t, 404)},
+		{bar, start.Add(2 * time.Minute), 1.0, xtime.Second, testEvent(t, 500)},
+		{bar, start.Add(3 * time.Minute), 2.0, xtime.Second, testEvent(t, 200)},
+	}
+	src.newIteratorFn = func(_ commitlog.IteratorOpts) (commitlog.Iterator, []commitlog.ErrorWithPath, error) {
+		return newTestCommitLogIterator(values, nil), nil, nil
+	}
+
+	targetRanges := result.ShardTimeRanges{0: ranges, 1: ranges}
+	res, err := src.ReadData(md, targetRanges, testDefaultRunOpts)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	require.Equal(t, 2, len(res.ShardResults()))
+	require.Equal(t, 0, len(res.Unfulfilled()))
+
+	// The replayed blocks are protobuf encoded, so they are only read back
+	// correctly with the pools of the namespace schema.
+	ropts := opts.ResultOptions()
+	protoOpts := opts.SetResultOptions(ropts.SetDatabaseBlockOptions(
+		block.NewSchemaOptions(ropts.DatabaseBlockOptions(), schema, instrument.NewOptions())))
+	require.NoError(t, verifyShardResultsAreCorrect(
+		values, blockSize, res.ShardResults(), protoOpts))
+}
+
 func TestReadUnorderedValues(t *testing.T) {
 	opts := testDefaultOpts
 	md := testNsMetadata(t)
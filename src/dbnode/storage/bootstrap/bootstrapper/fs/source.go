@@ -69,6 +69,7 @@ type fileSystemSource struct {
 	dataProcessors    xsync.WorkerPool
 	indexProcessors   xsync.WorkerPool
 	persistManager    persistManager
+	migrations        migrations
 	metrics           fileSystemSourceMetrics
 }
 
@@ -77,6 +78,17 @@ type persistManager struct {
 	mgr persist.Manager
 }
 
+type migrations struct {
+	sync.Mutex
+	done map[migrationKey]struct{}
+}
+
+type migrationKey struct {
+	namespace string
+	shard     uint32
+	blockSize time.Duration
+}
+
 type fileSystemSourceMetrics struct {
 	persistedIndexBlocksRead  tally.Counter
 	persistedIndexBlocksWrite tally.Counter
@@ -104,6 +116,9 @@ func newFileSystemSource(opts Options) bootstrap.Source {
 		persistManager: persistManager{
 			mgr: opts.PersistManager(),
 		},
+		migrations: migrations{
+			done: make(map[migrationKey]struct{}),
+		},
 		metrics: fileSystemSourceMetrics{
 			persistedIndexBlocksRead:  scope.Counter("persist-index-blocks-read"),
 			persistedIndexBlocksWrite: scope.Counter("persist-index-blocks-write"),
@@ -166,15 +181,19 @@ func (s *fileSystemSource) availability(
 	md namespace.Metadata,
 	shardsTimeRanges result.ShardTimeRanges,
 ) (result.ShardTimeRanges, error) {
+	if err := s.migrateDataFileSets(md, shardsTimeRanges); err != nil {
+		return nil, err
+	}
+
 	result := make(map[uint32]xtime.Ranges)
 	for shard, ranges := range shardsTimeRanges {
-		result[shard] = s.shardAvailability(md.ID(), shard, ranges)
+		result[shard] = s.shardAvailability(md, shard, ranges)
 	}
 	return result, nil
 }
 
 func (s *fileSystemSource) shardAvailability(
-	namespace ident.ID,
+	md namespace.Metadata,
 	shard uint32,
 	targetRangesForShard xtime.Ranges,
 ) xtime.Ranges {
@@ -182,6 +201,108 @@ func (s *fileSystemSource) shardAvailability(
 		return xtime.Ranges{}
 	}
 
+	tr := s.shardLocalAvailability(md, shard, targetRangesForShard)
+	return tr.AddRanges(s.shardOffloadedAvailability(md, shard, targetRangesForShard))
+}
+
+// migrateDataFileSets migrates the filesets of each shard to the block size of
+// the namespace before the availability of the shard is first determined, the
+// filesets of a shard are only migrated once for each block size.
+func (s *fileSystemSource) migrateDataFileSets(
+	md namespace.Metadata,
+	shardsTimeRanges result.ShardTimeRanges,
+) error {
+	s.migrations.Lock()
+	defer s.migrations.Unlock()
+
+	var (
+		blockOpts block.Options
+		blockSize = md.Options().RetentionOptions().BlockSize()
+	)
+	for shard, ranges := range shardsTimeRanges {
+		if ranges.IsEmpty() {
+			continue
+		}
+		key := migrationKey{
+			namespace: md.ID().String(),
+			shard:     shard,
+			blockSize: blockSize,
+		}
+		if _, ok := s.migrations.done[key]; ok {
+			continue
+		}
+
+		if blockOpts == nil {
+			var err error
+			blockOpts, err = s.namespaceBlockOptions(md)
+			if err != nil {
+				return err
+			}
+		}
+		s.migrateShardDataFileSets(md, shard, blockOpts)
+		s.migrations.done[key] = struct{}{}
+	}
+	return nil
+}
+
+// namespaceBlockOptions returns the block options used to encode the data of a
+// namespace, namespaces with a schema encode values as protobuf messages rather
+// than M3TSZ.
+func (s *fileSystemSource) namespaceBlockOptions(md namespace.Metadata) (block.Options, error) {
+	blockOpts := s.opts.ResultOptions().DatabaseBlockOptions()
+	schema, err := md.Options().SchemaOptions().Schema()
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema for namespace %s: %v", md.ID().String(), err)
+	}
+	if schema == nil {
+		return blockOpts, nil
+	}
+
+	iopts := s.opts.InstrumentOptions()
+	iopts = iopts.SetMetricsScope(iopts.MetricsScope().SubScope("proto-encoding"))
+	return block.NewSchemaOptions(blockOpts, schema, iopts), nil
+}
+
+// migrateShardDataFileSets rewrites the filesets of a shard that were flushed with
+// a block size other than the block size of the namespace, which is the case after
+// the block size of the namespace was changed with data migration. Filesets that
+// fail to migrate are left in place and are not bootstrapped.
+func (s *fileSystemSource) migrateShardDataFileSets(
+	md namespace.Metadata,
+	shard uint32,
+	blockOpts block.Options,
+) {
+	blockSize := md.Options().RetentionOptions().BlockSize()
+	migrated, err := fs.MigrateDataFileSets(s.fsopts, blockOpts.EncoderPool(),
+		blockOpts.MultiReaderIteratorPool(), md.ID(), shard, blockSize)
+	if err != nil {
+		s.log.WithFields(
+			xlog.NewField("shard", shard),
+			xlog.NewField("namespace", md.ID().String()),
+			xlog.NewField("blockSize", blockSize.String()),
+			xlog.NewField("error", err.Error()),
+		).Error("unable to migrate filesets to namespace block size")
+		return
+	}
+	if migrated > 0 {
+		s.log.WithFields(
+			xlog.NewField("shard", shard),
+			xlog.NewField("namespace", md.ID().String()),
+			xlog.NewField("blockSize", blockSize.String()),
+			xlog.NewField("filesets", migrated),
+		).Info("migrated filesets to namespace block size")
+	}
+}
+
+func (s *fileSystemSource) shardLocalAvailability(
+	md namespace.Metadata,
+	shard uint32,
+	targetRangesForShard xtime.Ranges,
+) xtime.Ranges {
+	var (
+		namespace = md.ID()
+		blockSize = md.Options().RetentionOptions().BlockSize()
+	)
 	readInfoFilesResults := fs.ReadInfoFiles(s.fsopts.FilePathPrefix(),
 		namespace, shard, s.fsopts.InfoReaderBufferSize(), s.fsopts.DecodingOptions())
 
@@ -201,6 +322,10 @@ func (s *fileSystemSource) shardAvailability(
 		info := result.Info
 		t := xtime.FromNanoseconds(info.BlockStart)
 		w := time.Duration(info.BlockSize)
+		if info.SubBlockSize == 0 && w != blockSize {
+			// Not yet migrated to the block size of the namespace.
+			continue
+		}
 		currRange := xtime.Range{Start: t, End: t.Add(w)}
 		if targetRangesForShard.Overlaps(currRange) {
 			tr = tr.AddRange(currRange)
@@ -209,6 +334,42 @@ func (s *fileSystemSource) shardAvailability(
 	return tr
 }
 
+// shardOffloadedAvailability returns the ranges of the shard whose filesets have
+// been offloaded to the object store. Offloaded filesets are only fetched lazily
+// by the block retriever, so they are not available when caching all series.
+func (s *fileSystemSource) shardOffloadedAvailability(
+	md namespace.Metadata,
+	shard uint32,
+	targetRangesForShard xtime.Ranges,
+) xtime.Ranges {
+	store := s.fsopts.ObjectStore()
+	if store == nil || s.opts.ResultOptions().SeriesCachePolicy() == series.CacheAll {
+		return xtime.Ranges{}
+	}
+
+	blockStarts, err := fs.OffloadedDataFileSetBlockStarts(store, md.ID(), shard)
+	if err != nil {
+		s.log.WithFields(
+			xlog.NewField("shard", shard),
+			xlog.NewField("namespace", md.ID().String()),
+			xlog.NewField("error", err.Error()),
+		).Error("unable to list offloaded filesets in shardAvailability")
+		return xtime.Ranges{}
+	}
+
+	var (
+		blockSize = md.Options().RetentionOptions().BlockSize()
+		tr        xtime.Ranges
+	)
+	for _, t := range blockStarts {
+		currRange := xtime.Range{Start: t, End: t.Add(blockSize)}
+		if targetRangesForShard.Overlaps(currRange) {
+			tr = tr.AddRange(currRange)
+		}
+	}
+	return tr
+}
+
 func (s *fileSystemSource) enqueueReaders(
 	ns namespace.Metadata,
 	run runType,
@@ -320,45 +481,60 @@ func (s *fileSystemSource) newShardReaders(
 			continue
 		}
 
-		info := result.Info
-		blockStart := xtime.FromNanoseconds(info.BlockStart)
-		if !tr.Overlaps(xtime.Range{
-			Start: blockStart,
-			End:   blockStart.Add(ns.Options().RetentionOptions().BlockSize()),
-		}) {
-			// Errors are marked unfulfilled by markRunResultErrorsAndUnfulfilled
-			// and will be re-attempted by the next bootstrapper
+		var (
+			info       = result.Info
+			blockStart = xtime.FromNanoseconds(info.BlockStart)
+			blockSize  = ns.Options().RetentionOptions().BlockSize()
+			end        = blockStart.Add(blockSize)
+		)
+		if info.SubBlockSize > 0 {
+			// Compacted filesets are read with a reader per block within them.
+			end = blockStart.Add(time.Duration(info.BlockSize))
+		} else if time.Duration(info.BlockSize) != blockSize {
+			// Filesets that were not migrated to the block size of the namespace
+			// are never available, see migrateShardDataFileSets.
 			continue
 		}
+		for subBlockStart := blockStart; subBlockStart.Before(end); subBlockStart = subBlockStart.Add(blockSize) {
+			if !tr.Overlaps(xtime.Range{
+				Start: subBlockStart,
+				End:   subBlockStart.Add(blockSize),
+			}) {
+				// Errors are marked unfulfilled by markRunResultErrorsAndUnfulfilled
+				// and will be re-attempted by the next bootstrapper
+				continue
+			}
 
-		r, err := readerPool.get()
-		if err != nil {
-			s.log.Errorf("unable to get reader from pool")
-			// Errors are marked unfulfilled by markRunResultErrorsAndUnfulfilled
-			// and will be re-attempted by the next bootstrapper
-			continue
-		}
+			r, err := readerPool.get()
+			if err != nil {
+				s.log.Errorf("unable to get reader from pool")
+				// Errors are marked unfulfilled by markRunResultErrorsAndUnfulfilled
+				// and will be re-attempted by the next bootstrapper
+				continue
+			}
 
-		openOpts := fs.DataReaderOpenOptions{
-			Identifier: fs.FileSetFileIdentifier{
-				Namespace:  ns.ID(),
-				Shard:      shard,
-				BlockStart: blockStart,
-			},
-		}
-		if err := r.Open(openOpts); err != nil {
-			s.log.WithFields(
-				xlog.NewField("shard", shard),
-				xlog.NewField("blockStart", blockStart.String()),
-				xlog.NewField("error", err.Error()),
-			).Error("unable to open fileset files")
-			readerPool.put(r)
-			// Errors are marked unfulfilled by markRunResultErrorsAndUnfulfilled
-			// and will be re-attempted by the next bootstrapper
-			continue
-		}
+			openOpts := fs.DataReaderOpenOptions{
+				Identifier: fs.FileSetFileIdentifier{
+					Namespace:  ns.ID(),
+					Shard:      shard,
+					BlockStart: blockStart,
+				},
+				SubBlockStart: subBlockStart,
+			}
+			if err := r.Open(openOpts); err != nil {
+				s.log.WithFields(
+					xlog.NewField("shard", shard),
+					xlog.NewField("blockStart", subBlockStart.String()),
+					xlog.NewField("error", err.Error()),
+				).Error("unable to open fileset files")
+				readerPool.put(r)
+				// Errors are marked unfulfilled by markRunResultErrorsAndUnfulfilled
+				// and will be re-attempted by the next bootstrapper
+				continue
+			}
 
-		readers = append(readers, r)
+			readers = append(readers, r)
+		}
 	}
 
 	return shardReaders{readers: readers}
@@ -1025,7 +1201,7 @@ func (s *fileSystemSource) bootstrapDataRunResultFromAvailability(
 		if ranges.IsEmpty() {
 			continue
 		}
-		availability := s.shardAvailability(md.ID(), shard, ranges)
+		availability := s.shardAvailability(md, shard, ranges)
 		remaining := ranges.RemoveRanges(availability)
 		runResult.data.Add(shard, nil, remaining)
 	}
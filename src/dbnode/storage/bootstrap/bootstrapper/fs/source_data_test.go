@@ -21,6 +21,7 @@
 package fs
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -31,12 +32,15 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/persist/fs/objectstore"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	"github.com/m3db/m3/src/dbnode/storage/series"
+	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3x/checked"
 	"github.com/m3db/m3x/context"
 	"github.com/m3db/m3x/ident"
@@ -190,6 +194,18 @@ func writeTSDBFiles(
 	shard uint32,
 	start time.Time,
 	series []testSeries,
+) {
+	writeTSDBFilesWithBlockSize(t, dir, namespace, shard, start, testBlockSize, series)
+}
+
+func writeTSDBFilesWithBlockSize(
+	t *testing.T,
+	dir string,
+	namespace ident.ID,
+	shard uint32,
+	start time.Time,
+	blockSize time.Duration,
+	series []testSeries,
 ) {
 	w, err := fs.NewWriter(newTestFsOptions(dir))
 	require.NoError(t, err)
@@ -199,7 +215,7 @@ func writeTSDBFiles(
 			Shard:      shard,
 			BlockStart: start,
 		},
-		BlockSize: testBlockSize,
+		BlockSize: blockSize,
 	}
 	require.NoError(t, w.Open(writerOpts))
 
@@ -336,6 +352,88 @@ func TestAvailableTimeRangeFilter(t *testing.T) {
 	validateTimeRanges(t, res[testShard], expected)
 }
 
+func TestAvailableMigratesFileSets(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	// Write a fileset with twice the block size of the namespace that holds
+	// data for both of the blocks of the namespace block size within it.
+	encoder := m3tsz.NewEncoder(testStart, nil, m3tsz.DefaultIntOptimizationEnabled, nil)
+	for _, dp := range []ts.Datapoint{
+		{Timestamp: testStart.Add(time.Minute), Value: 1},
+		{Timestamp: testStart.Add(testBlockSize + time.Minute), Value: 2},
+	} {
+		require.NoError(t, encoder.Encode(dp, xtime.Second, nil))
+	}
+	data, err := ioutil.ReadAll(encoder.Stream())
+	require.NoError(t, err)
+
+	shard := uint32(0)
+	writeTSDBFilesWithBlockSize(t, dir, testNs1ID, shard, testStart, 2*testBlockSize,
+		[]testSeries{{"foo", nil, data}})
+
+	src := newFileSystemSource(newTestOptions(dir))
+	res, err := src.AvailableData(
+		testNsMetadata(t),
+		testShardTimeRanges(),
+		testDefaultRunOpts,
+	)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	require.Equal(t, 1, len(res))
+
+	expected := xtime.Ranges{}.
+		AddRange(xtime.Range{Start: testStart, End: testStart.Add(2 * testBlockSize)})
+	validateTimeRanges(t, res[testShard], expected)
+
+	files, err := fs.DataFiles(dir, testNs1ID, shard)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(files))
+	for i, file := range files {
+		require.Equal(t, testStart.Add(time.Duration(i)*testBlockSize), file.ID.BlockStart)
+	}
+
+	// Assert the shard is only migrated once for the block size.
+	migrations := src.(*fileSystemSource).migrations.done
+	require.Equal(t, 1, len(migrations))
+	_, ok := migrations[migrationKey{
+		namespace: testNs1ID.String(),
+		shard:     shard,
+		blockSize: testBlockSize,
+	}]
+	require.True(t, ok)
+}
+
+func TestAvailableOffloadedFileSets(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	store := objectstore.NewLocalStore(path.Join(dir, "store"))
+	shardDir := fs.ShardDataDirPath("", testNs1ID, testShard)
+	offloadedStart := testStart.Add(4 * time.Hour)
+	key := path.Join(shardDir, fmt.Sprintf("fileset-%d-checkpoint.db", xtime.ToNanoseconds(offloadedStart)))
+	require.NoError(t, store.Put(key, bytes.NewReader([]byte("checkpoint"))))
+
+	opts := newTestOptions(dir)
+	opts = opts.SetFilesystemOptions(opts.FilesystemOptions().SetObjectStore(store))
+
+	// Offloaded filesets are not available when caching all series as
+	// they can only be retrieved lazily.
+	src := newFileSystemSource(opts)
+	res, err := src.AvailableData(testNsMetadata(t), testShardTimeRanges(), testDefaultRunOpts)
+	require.NoError(t, err)
+	validateTimeRanges(t, res[testShard], xtime.Ranges{})
+
+	opts = opts.SetResultOptions(testDefaultResultOpts.SetSeriesCachePolicy(series.CacheRecentlyRead))
+	src = newFileSystemSource(opts)
+	res, err = src.AvailableData(testNsMetadata(t), testShardTimeRanges(), testDefaultRunOpts)
+	require.NoError(t, err)
+
+	expected := xtime.Ranges{}.
+		AddRange(xtime.Range{Start: offloadedStart, End: offloadedStart.Add(testBlockSize)})
+	validateTimeRanges(t, res[testShard], expected)
+}
+
 func TestAvailableTimeRangePartialError(t *testing.T) {
 	dir := createTempDir(t)
 	defer os.RemoveAll(dir)
@@ -117,6 +117,16 @@ func (m *cleanupManager) Cleanup(t time.Time) error {
 			"encountered errors when cleaning up data files for %v: %v", t, err))
 	}
 
+	if err := m.offloadColdDataFiles(t); err != nil {
+		multiErr = multiErr.Add(fmt.Errorf(
+			"encountered errors when offloading data files for %v: %v", t, err))
+	}
+
+	if err := m.compactDataFiles(t); err != nil {
+		multiErr = multiErr.Add(fmt.Errorf(
+			"encountered errors when compacting data files for %v: %v", t, err))
+	}
+
 	if err := m.cleanupExpiredIndexFiles(t); err != nil {
 		multiErr = multiErr.Add(fmt.Errorf(
 			"encountered errors when cleaning up index files for %v: %v", t, err))
@@ -236,6 +246,45 @@ func (m *cleanupManager) cleanupExpiredDataFiles(t time.Time) error {
 	return multiErr.FinalError()
 }
 
+func (m *cleanupManager) offloadColdDataFiles(t time.Time) error {
+	if m.opts.CommitLogOptions().FilesystemOptions().ObjectStore() == nil {
+		return nil
+	}
+	namespaces, err := m.database.GetOwnedNamespaces()
+	if err != nil {
+		return err
+	}
+	multiErr := xerrors.NewMultiError()
+	for _, n := range namespaces {
+		if !n.Options().CleanupEnabled() || !n.Options().ColdStorageOptions().Enabled() {
+			continue
+		}
+		offloadBefore := fs.OffloadBlockStartBefore(n.Options(), t)
+		for _, shard := range n.GetOwnedShards() {
+			multiErr = multiErr.Add(shard.OffloadColdFileSets(offloadBefore))
+		}
+	}
+	return multiErr.FinalError()
+}
+
+func (m *cleanupManager) compactDataFiles(t time.Time) error {
+	namespaces, err := m.database.GetOwnedNamespaces()
+	if err != nil {
+		return err
+	}
+	multiErr := xerrors.NewMultiError()
+	for _, n := range namespaces {
+		if !n.Options().CleanupEnabled() || !n.Options().CompactionOptions().Enabled() {
+			continue
+		}
+		compactBefore := fs.CompactBlockStartBefore(n.Options(), t)
+		for _, shard := range n.GetOwnedShards() {
+			multiErr = multiErr.Add(shard.CompactFileSets(compactBefore))
+		}
+	}
+	return multiErr.FinalError()
+}
+
 func (m *cleanupManager) cleanupExpiredIndexFiles(t time.Time) error {
 	namespaces, err := m.database.GetOwnedNamespaces()
 	if err != nil {
@@ -28,6 +28,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
+	"github.com/m3db/m3/src/dbnode/persist/fs/objectstore"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	"github.com/m3db/m3x/ident"
@@ -180,6 +181,77 @@ func TestCleanupDataAndSnapshotFileSetFiles(t *testing.T) {
 	require.NoError(t, mgr.Cleanup(ts))
 }
 
+func TestCleanupManagerOffloadColdDataFiles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ts := timeFor(36000)
+	rOpts := retention.NewOptions().
+		SetRetentionPeriod(21600 * time.Second).
+		SetBlockSize(3600 * time.Second)
+	coldNsOpts := namespace.NewOptions().
+		SetRetentionOptions(rOpts).
+		SetColdStorageOptions(namespace.NewColdStorageOptions().
+			SetEnabled(true).
+			SetOffloadAfter(7200 * time.Second))
+	hotNsOpts := namespace.NewOptions().SetRetentionOptions(rOpts)
+
+	coldNs := NewMockdatabaseNamespace(ctrl)
+	coldNs.EXPECT().Options().Return(coldNsOpts).AnyTimes()
+	shard := NewMockdatabaseShard(ctrl)
+	shard.EXPECT().OffloadColdFileSets(timeFor(28800)).Return(nil)
+	coldNs.EXPECT().GetOwnedShards().Return([]databaseShard{shard})
+
+	// Namespaces without cold storage enabled are not offloaded.
+	hotNs := NewMockdatabaseNamespace(ctrl)
+	hotNs.EXPECT().Options().Return(hotNsOpts).AnyTimes()
+
+	namespaces := []databaseNamespace{coldNs, hotNs}
+	db := newMockdatabase(ctrl, namespaces...)
+	db.EXPECT().GetOwnedNamespaces().Return(namespaces, nil).AnyTimes()
+	mgr := newCleanupManager(db, newNoopFakeActiveLogs(), tally.NoopScope).(*cleanupManager)
+	mgr.opts = mgr.opts.SetCommitLogOptions(
+		mgr.opts.CommitLogOptions().SetFilesystemOptions(
+			mgr.opts.CommitLogOptions().FilesystemOptions().
+				SetObjectStore(objectstore.NewLocalStore("unused"))))
+
+	require.NoError(t, mgr.offloadColdDataFiles(ts))
+}
+
+func TestCleanupManagerCompactDataFiles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ts := timeFor(36000)
+	rOpts := retention.NewOptions().
+		SetRetentionPeriod(43200 * time.Second).
+		SetBlockSize(3600 * time.Second)
+	compactedNsOpts := namespace.NewOptions().
+		SetRetentionOptions(rOpts).
+		SetCompactionOptions(namespace.NewCompactionOptions().
+			SetEnabled(true).
+			SetBlockSize(7200 * time.Second).
+			SetCompactAfter(10800 * time.Second))
+	otherNsOpts := namespace.NewOptions().SetRetentionOptions(rOpts)
+
+	compactedNs := NewMockdatabaseNamespace(ctrl)
+	compactedNs.EXPECT().Options().Return(compactedNsOpts).AnyTimes()
+	shard := NewMockdatabaseShard(ctrl)
+	shard.EXPECT().CompactFileSets(timeFor(25200)).Return(nil)
+	compactedNs.EXPECT().GetOwnedShards().Return([]databaseShard{shard})
+
+	// Namespaces without compaction enabled are not compacted.
+	otherNs := NewMockdatabaseNamespace(ctrl)
+	otherNs.EXPECT().Options().Return(otherNsOpts).AnyTimes()
+
+	namespaces := []databaseNamespace{compactedNs, otherNs}
+	db := newMockdatabase(ctrl, namespaces...)
+	db.EXPECT().GetOwnedNamespaces().Return(namespaces, nil).AnyTimes()
+	mgr := newCleanupManager(db, newNoopFakeActiveLogs(), tally.NoopScope).(*cleanupManager)
+
+	require.NoError(t, mgr.compactDataFiles(ts))
+}
+
 type deleteInactiveDirectoriesCall struct {
 	parentDirPath  string
 	activeDirNames []string
@@ -29,6 +29,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/persist/fs/backup"
 	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
 	"github.com/m3db/m3/src/dbnode/sharding"
 	"github.com/m3db/m3/src/dbnode/storage/block"
@@ -117,6 +118,8 @@ type databaseMetrics struct {
 	unknownNamespaceFetchBlocks         tally.Counter
 	unknownNamespaceFetchBlocksMetadata tally.Counter
 	unknownNamespaceQueryIDs            tally.Counter
+	unknownNamespaceCardinality         tally.Counter
+	unknownNamespaceQueryCost           tally.Counter
 	errQueryIDsIndexDisabled            tally.Counter
 	errWriteTaggedIndexDisabled         tally.Counter
 }
@@ -134,6 +137,8 @@ func newDatabaseMetrics(scope tally.Scope) databaseMetrics {
 		unknownNamespaceFetchBlocks:         unknownNamespaceScope.Counter("fetch-blocks"),
 		unknownNamespaceFetchBlocksMetadata: unknownNamespaceScope.Counter("fetch-blocks-metadata"),
 		unknownNamespaceQueryIDs:            unknownNamespaceScope.Counter("query-ids"),
+		unknownNamespaceCardinality:         unknownNamespaceScope.Counter("cardinality"),
+		unknownNamespaceQueryCost:           unknownNamespaceScope.Counter("query-cost"),
 		errQueryIDsIndexDisabled:            indexDisabledScope.Counter("err-query-ids"),
 		errWriteTaggedIndexDisabled:         indexDisabledScope.Counter("err-write-tagged"),
 	}
@@ -237,6 +242,14 @@ func (d *db) UpdateOwnedNamespaces(newNamespaces namespace.Map) error {
 		return err
 	}
 
+	// apply any updates that can be made while running
+	updates, err := d.updateNamespacesWithLock(updates)
+	if err != nil {
+		enrichedErr := fmt.Errorf("unable to update namespaces: %v", err)
+		d.log.Errorf("%v", enrichedErr)
+		return err
+	}
+
 	// log that updates and removals are skipped
 	if len(removes) > 0 || len(updates) > 0 {
 		d.log.Warnf("skipping namespace removals and updates, restart process if you want changes to take effect.")
@@ -250,6 +263,25 @@ func (d *db) UpdateOwnedNamespaces(newNamespaces namespace.Map) error {
 	return nil
 }
 
+// updateNamespacesWithLock applies the namespace updates that only change the
+// retention period of a namespace and returns the updates that require a restart.
+func (d *db) updateNamespacesWithLock(updates []namespace.Metadata) ([]namespace.Metadata, error) {
+	var skipped []namespace.Metadata
+	for _, md := range updates {
+		n, ok := d.namespaces.Get(md.ID())
+		if !ok || !namespace.IsLiveUpdate(n.Options(), md.Options()) {
+			skipped = append(skipped, md)
+			continue
+		}
+		if err := n.UpdateOptions(md.Options()); err != nil {
+			return nil, err
+		}
+		d.log.Infof("updated retention period of namespace %s to %s",
+			md.ID().String(), md.Options().RetentionOptions().RetentionPeriod().String())
+	}
+	return skipped, nil
+}
+
 func (d *db) namespaceDeltaWithLock(newNamespaces namespace.Map) ([]ident.ID, []namespace.Metadata, []namespace.Metadata) {
 	var (
 		existing = d.namespaces
@@ -736,6 +768,35 @@ func (d *db) QueryIDs(
 	return n.QueryIDs(ctx, query, opts)
 }
 
+func (d *db) Cardinality(
+	ctx context.Context,
+	namespace ident.ID,
+	opts index.CardinalityOptions,
+) ([]index.CardinalityResult, error) {
+	n, err := d.namespaceFor(namespace)
+	if err != nil {
+		d.metrics.unknownNamespaceCardinality.Inc(1)
+		return nil, err
+	}
+
+	return n.Cardinality(ctx, opts)
+}
+
+func (d *db) QueryCost(
+	ctx context.Context,
+	namespace ident.ID,
+	query index.Query,
+	opts index.QueryCostOptions,
+) ([]index.QueryCostResult, error) {
+	n, err := d.namespaceFor(namespace)
+	if err != nil {
+		d.metrics.unknownNamespaceQueryCost.Inc(1)
+		return nil, err
+	}
+
+	return n.QueryCost(ctx, query, opts)
+}
+
 func (d *db) ReadEncoded(
 	ctx context.Context,
 	namespace ident.ID,
@@ -864,6 +925,10 @@ func (d *db) Truncate(namespace ident.ID) (int64, error) {
 	return n.Truncate()
 }
 
+func (d *db) Backup(destinationPath string) (backup.Manifest, error) {
+	return d.mediator.Backup(destinationPath)
+}
+
 func (d *db) IsOverloaded() bool {
 	return d.errors.Count(d.errWindow) > d.errThreshold
 }
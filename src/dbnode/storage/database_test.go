@@ -599,11 +599,14 @@ func TestDatabaseUpdateNamespace(t *testing.T) {
 	nses := d.Namespaces()
 	require.Len(t, nses, 2)
 
-	// construct new namespace Map
+	// construct new namespace Map, changing the retention period of the first
+	// namespace which is applied live and an option of the second namespace
+	// that requires a restart
 	ropts := defaultTestNs1Opts.RetentionOptions().SetRetentionPeriod(2000 * time.Hour)
 	md1, err := namespace.NewMetadata(defaultTestNs1ID, defaultTestNs1Opts.SetRetentionOptions(ropts))
 	require.NoError(t, err)
-	md2, err := namespace.NewMetadata(defaultTestNs2ID, defaultTestNs2Opts)
+	md2, err := namespace.NewMetadata(defaultTestNs2ID,
+		defaultTestNs2Opts.SetRepairEnabled(!defaultTestNs2Opts.RepairEnabled()))
 	require.NoError(t, err)
 	nsMap, err := namespace.NewMap([]namespace.Metadata{md1, md2})
 	require.NoError(t, err)
@@ -616,12 +619,12 @@ func TestDatabaseUpdateNamespace(t *testing.T) {
 	<-updateCh
 	time.Sleep(10 * time.Millisecond)
 
-	// ensure the namespaces have old properties
+	// ensure only the retention period update was applied
 	nses = d.Namespaces()
 	require.Len(t, nses, 2)
 	ns1, ok := d.Namespace(defaultTestNs1ID)
 	require.True(t, ok)
-	require.Equal(t, defaultTestNs1Opts, ns1.Options())
+	require.Equal(t, md1.Options(), ns1.Options())
 	ns2, ok := d.Namespace(defaultTestNs2ID)
 	require.True(t, ok)
 	require.Equal(t, defaultTestNs2Opts, ns2.Options())
@@ -32,4 +32,39 @@ var (
 
 	// ErrTooPast is returned for a write which is too far in the past.
 	ErrTooPast = xerrors.NewInvalidParamsError(errors.New("datapoint is too far in the past"))
+
+	// ErrDuplicateWrite is returned for a write with a value that differs from
+	// the value already written for the same timestamp when the namespace
+	// rejects duplicates.
+	ErrDuplicateWrite = xerrors.NewInvalidParamsError(errors.New("datapoint differs from existing datapoint at timestamp"))
 )
+
+type resourceExhaustedError struct {
+	err error
+}
+
+// NewResourceExhaustedError creates a new error signalling that a quota or
+// limit was exceeded, the request may succeed if retried after backing off.
+func NewResourceExhaustedError(err error) error {
+	return resourceExhaustedError{err: err}
+}
+
+func (e resourceExhaustedError) Error() string {
+	return e.err.Error()
+}
+
+func (e resourceExhaustedError) InnerError() error {
+	return e.err
+}
+
+// IsResourceExhaustedError returns whether the error or any of its inner
+// errors is a resource exhausted error.
+func IsResourceExhaustedError(err error) bool {
+	for err != nil {
+		if _, ok := err.(resourceExhaustedError); ok {
+			return true
+		}
+		err = xerrors.InnerError(err)
+	}
+	return false
+}
@@ -140,36 +140,7 @@ func (m *flushManager) Flush(
 	// snapshot "start time" has been persisted durably.
 	shouldSnapshot := tickStart.Sub(m.lastSuccessfulSnapshotStartTime) >= m.opts.MinimumSnapshotInterval()
 	if shouldSnapshot {
-		m.setState(flushManagerSnapshotInProgress)
-		maxBlocksSnapshottedByNamespace := 0
-		for _, ns := range namespaces {
-			var (
-				snapshotBlockStarts     = m.namespaceSnapshotTimes(ns, tickStart)
-				shardBootstrapTimes, ok = dbBootstrapStateAtTickStart.NamespaceBootstrapStates[ns.ID().String()]
-			)
-
-			if !ok {
-				// Could happen if namespaces are added / removed.
-				multiErr = multiErr.Add(fmt.Errorf(
-					"tried to flush ns: %s, but did not have shard bootstrap times", ns.ID().String()))
-				continue
-			}
-
-			if len(snapshotBlockStarts) > maxBlocksSnapshottedByNamespace {
-				maxBlocksSnapshottedByNamespace = len(snapshotBlockStarts)
-			}
-			for _, snapshotBlockStart := range snapshotBlockStarts {
-				err := ns.Snapshot(
-					snapshotBlockStart, tickStart, shardBootstrapTimes, flush)
-
-				if err != nil {
-					detailedErr := fmt.Errorf("namespace %s failed to snapshot data: %v",
-						ns.ID().String(), err)
-					multiErr = multiErr.Add(detailedErr)
-				}
-			}
-		}
-		m.maxBlocksSnapshottedByNamespace.Update(float64(maxBlocksSnapshottedByNamespace))
+		multiErr = m.snapshotNamespaces(namespaces, tickStart, dbBootstrapStateAtTickStart, flush, multiErr)
 	}
 
 	// mark data flush finished
@@ -206,6 +177,83 @@ func (m *flushManager) Flush(
 	return multiErr.FinalError()
 }
 
+func (m *flushManager) Snapshot(
+	tickStart time.Time,
+	dbBootstrapStateAtTickStart DatabaseBootstrapState,
+) error {
+	// ensure only a single flush or snapshot is happening at a time
+	m.Lock()
+	if m.state != flushManagerIdle {
+		m.Unlock()
+		return errFlushOperationsInProgress
+	}
+	m.state = flushManagerNotIdle
+	m.Unlock()
+
+	defer m.setState(flushManagerIdle)
+
+	namespaces, err := m.database.GetOwnedNamespaces()
+	if err != nil {
+		return err
+	}
+
+	flush, err := m.pm.StartDataPersist()
+	if err != nil {
+		return err
+	}
+
+	multiErr := xerrors.NewMultiError()
+	multiErr = m.snapshotNamespaces(namespaces, tickStart, dbBootstrapStateAtTickStart, flush, multiErr)
+	multiErr = multiErr.Add(flush.DoneData())
+	if multiErr.NumErrors() == 0 {
+		m.lastSuccessfulSnapshotStartTime = tickStart
+	}
+
+	return multiErr.FinalError()
+}
+
+// snapshotNamespaces snapshots all unflushed blocks of the given namespaces,
+// adding any errors encountered to the provided multi error.
+func (m *flushManager) snapshotNamespaces(
+	namespaces []databaseNamespace,
+	tickStart time.Time,
+	dbBootstrapStateAtTickStart DatabaseBootstrapState,
+	flush persist.DataFlush,
+	multiErr xerrors.MultiError,
+) xerrors.MultiError {
+	m.setState(flushManagerSnapshotInProgress)
+	maxBlocksSnapshottedByNamespace := 0
+	for _, ns := range namespaces {
+		var (
+			snapshotBlockStarts     = m.namespaceSnapshotTimes(ns, tickStart)
+			shardBootstrapTimes, ok = dbBootstrapStateAtTickStart.NamespaceBootstrapStates[ns.ID().String()]
+		)
+
+		if !ok {
+			// Could happen if namespaces are added / removed.
+			multiErr = multiErr.Add(fmt.Errorf(
+				"tried to flush ns: %s, but did not have shard bootstrap times", ns.ID().String()))
+			continue
+		}
+
+		if len(snapshotBlockStarts) > maxBlocksSnapshottedByNamespace {
+			maxBlocksSnapshottedByNamespace = len(snapshotBlockStarts)
+		}
+		for _, snapshotBlockStart := range snapshotBlockStarts {
+			err := ns.Snapshot(
+				snapshotBlockStart, tickStart, shardBootstrapTimes, flush)
+
+			if err != nil {
+				detailedErr := fmt.Errorf("namespace %s failed to snapshot data: %v",
+					ns.ID().String(), err)
+				multiErr = multiErr.Add(detailedErr)
+			}
+		}
+	}
+	m.maxBlocksSnapshottedByNamespace.Update(float64(maxBlocksSnapshottedByNamespace))
+	return multiErr
+}
+
 func (m *flushManager) Report() {
 	m.RLock()
 	state := m.state
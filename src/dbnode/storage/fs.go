@@ -59,13 +59,14 @@ const (
 type fileSystemManager struct {
 	databaseFlushManager
 	databaseCleanupManager
+	databaseBackupManager
 	sync.RWMutex
 
 	log      xlog.Logger
 	database database
 	opts     Options
 	status   fileOpStatus
-	enabled  bool
+	disabled int
 }
 
 func newFileSystemManager(
@@ -77,22 +78,23 @@ func newFileSystemManager(
 	scope := instrumentOpts.MetricsScope().SubScope("fs")
 	fm := newFlushManager(database, scope)
 	cm := newCleanupManager(database, commitLog, scope)
+	bm := newBackupManager(database, fm, commitLog, scope)
 
 	return &fileSystemManager{
 		databaseFlushManager:   fm,
 		databaseCleanupManager: cm,
-		log:      instrumentOpts.Logger(),
-		database: database,
-		opts:     opts,
-		status:   fileOpNotStarted,
-		enabled:  true,
+		databaseBackupManager:  bm,
+		log:                    instrumentOpts.Logger(),
+		database:               database,
+		opts:                   opts,
+		status:                 fileOpNotStarted,
 	}
 }
 
 func (m *fileSystemManager) Disable() fileOpStatus {
 	m.Lock()
 	status := m.status
-	m.enabled = false
+	m.disabled++
 	m.Unlock()
 	return status
 }
@@ -100,7 +102,9 @@ func (m *fileSystemManager) Disable() fileOpStatus {
 func (m *fileSystemManager) Enable() fileOpStatus {
 	m.Lock()
 	status := m.status
-	m.enabled = true
+	if m.disabled > 0 {
+		m.disabled--
+	}
 	m.Unlock()
 	return status
 }
@@ -153,5 +157,5 @@ func (m *fileSystemManager) Report() {
 }
 
 func (m *fileSystemManager) shouldRunWithLock() bool {
-	return m.enabled && m.status != fileOpInProgress && m.database.IsBootstrapped()
+	return m.disabled == 0 && m.status != fileOpInProgress && m.database.IsBootstrapped()
 }
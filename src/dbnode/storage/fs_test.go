@@ -70,6 +70,24 @@ func TestFileSystemManagerShouldRunEnableDisable(t *testing.T) {
 	require.True(t, mgr.shouldRunWithLock())
 }
 
+func TestFileSystemManagerShouldRunNestedDisable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	database := newMockdatabase(ctrl)
+	fsm := newFileSystemManager(database, nil, testDatabaseOptions())
+	mgr := fsm.(*fileSystemManager)
+	database.EXPECT().IsBootstrapped().Return(true).AnyTimes()
+
+	// A backup failing fast while a bootstrap has file operations disabled
+	// must not enable them again.
+	mgr.Disable()
+	mgr.Disable()
+	mgr.Enable()
+	require.False(t, mgr.shouldRunWithLock())
+	mgr.Enable()
+	require.True(t, mgr.shouldRunWithLock())
+}
+
 func TestFileSystemManagerRun(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
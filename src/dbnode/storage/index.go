@@ -75,11 +75,10 @@ type nsIndex struct {
 
 	// all the vars below this line are not modified past the ctor
 	// and don't require a lock when being accessed.
-	nowFn           clock.NowFn
-	blockSize       time.Duration
-	retentionPeriod time.Duration
-	bufferPast      time.Duration
-	bufferFuture    time.Duration
+	nowFn        clock.NowFn
+	blockSize    time.Duration
+	bufferPast   time.Duration
+	bufferFuture time.Duration
 
 	indexFilesetsBeforeFn indexFilesetsBeforeFn
 	deleteFilesFn         deleteFilesFn
@@ -106,9 +105,10 @@ type nsIndex struct {
 type nsIndexState struct {
 	sync.RWMutex // NB: guards all variables in this struct
 
-	closed         bool
-	bootstrapState BootstrapState
-	runtimeOpts    nsIndexRuntimeOptions
+	closed          bool
+	bootstrapState  BootstrapState
+	runtimeOpts     nsIndexRuntimeOptions
+	retentionPeriod time.Duration
 
 	insertQueue namespaceIndexInsertQueue
 
@@ -224,14 +224,14 @@ func newNamespaceIndexWithOptions(
 				insertMode:            indexOpts.InsertMode(), // FOLLOWUP(prateek): wire to allow this to be tweaked at runtime
 				flushBlockNumSegments: runtime.DefaultFlushIndexBlockNumSegments,
 			},
-			blocksByTime: make(map[xtime.UnixNano]index.Block),
+			blocksByTime:    make(map[xtime.UnixNano]index.Block),
+			retentionPeriod: nsMD.Options().RetentionOptions().RetentionPeriod(),
 		},
 
-		nowFn:           nowFn,
-		blockSize:       nsMD.Options().IndexOptions().BlockSize(),
-		retentionPeriod: nsMD.Options().RetentionOptions().RetentionPeriod(),
-		bufferPast:      nsMD.Options().RetentionOptions().BufferPast(),
-		bufferFuture:    nsMD.Options().RetentionOptions().BufferFuture(),
+		nowFn:        nowFn,
+		blockSize:    nsMD.Options().IndexOptions().BlockSize(),
+		bufferPast:   nsMD.Options().RetentionOptions().BufferPast(),
+		bufferFuture: nsMD.Options().RetentionOptions().BufferFuture(),
 
 		indexFilesetsBeforeFn: fs.IndexFileSetsBefore,
 		deleteFilesFn:         fs.DeleteFiles,
@@ -274,6 +274,12 @@ func (i *nsIndex) SetRuntimeOptions(value runtime.Options) {
 	i.state.Unlock()
 }
 
+func (i *nsIndex) SetRetentionPeriod(value time.Duration) {
+	i.state.Lock()
+	i.state.retentionPeriod = value
+	i.state.Unlock()
+}
+
 func (i *nsIndex) BlockStartForWriteTime(writeTime time.Time) xtime.UnixNano {
 	return xtime.ToUnixNano(writeTime.Truncate(i.blockSize))
 }
@@ -468,9 +474,8 @@ func (i *nsIndex) Bootstrap(
 
 func (i *nsIndex) Tick(c context.Cancellable, tickStart time.Time) (namespaceIndexTickResult, error) {
 	var (
-		result                     = namespaceIndexTickResult{}
-		earliestBlockStartToRetain = retention.FlushTimeStartForRetentionPeriod(i.retentionPeriod, i.blockSize, tickStart)
-		lastSealableBlockStart     = retention.FlushTimeEndForBlockSize(i.blockSize, tickStart.Add(-i.bufferPast))
+		result                 = namespaceIndexTickResult{}
+		lastSealableBlockStart = retention.FlushTimeEndForBlockSize(i.blockSize, tickStart.Add(-i.bufferPast))
 	)
 
 	i.state.Lock()
@@ -479,6 +484,9 @@ func (i *nsIndex) Tick(c context.Cancellable, tickStart time.Time) (namespaceInd
 		i.state.Unlock()
 	}()
 
+	earliestBlockStartToRetain := retention.FlushTimeStartForRetentionPeriod(
+		i.state.retentionPeriod, i.blockSize, tickStart)
+
 	result.NumBlocks = int64(len(i.state.blocksByTime))
 
 	var multiErr xerrors.MultiError
@@ -552,6 +560,7 @@ func (i *nsIndex) Flush(
 		}
 	}
 	i.metrics.FlushEvictedMutableSegments.Inc(evictResults.NumMutableSegments)
+	i.metrics.FlushEvictedCompactedSegments.Inc(evictResults.NumCompactedSegments)
 	return nil
 }
 
@@ -967,6 +976,100 @@ func (i *nsIndex) Query(
 	}, nil
 }
 
+func (i *nsIndex) Cardinality(
+	ctx context.Context,
+	opts index.CardinalityOptions,
+) ([]index.CardinalityResult, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	i.state.RLock()
+	if !i.isOpenWithRLock() {
+		i.state.RUnlock()
+		return nil, errDbIndexUnableToQueryClosed
+	}
+
+	// Track this as an inflight query that needs to finish
+	// when the index is closed.
+	i.queriesWg.Add(1)
+	defer i.queriesWg.Done()
+
+	blocks, err := i.blocksForQueryWithRLock(xtime.NewRanges(xtime.Range{
+		Start: opts.StartInclusive,
+		End:   opts.EndExclusive,
+	}))
+
+	// Release the lock before inspecting the blocks to avoid blocking ticks.
+	i.state.RUnlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]index.CardinalityResult, 0, len(blocks))
+	for _, block := range blocks {
+		blockResult, err := block.Cardinality(opts)
+		if err == index.ErrUnableToQueryBlockClosed {
+			// NB: The block slid out of retention since it was retrieved,
+			// its series are no longer queryable so it is skipped.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, blockResult)
+	}
+
+	return results, nil
+}
+
+func (i *nsIndex) QueryCost(
+	ctx context.Context,
+	query index.Query,
+	opts index.QueryCostOptions,
+) ([]index.QueryCostResult, error) {
+	i.state.RLock()
+	if !i.isOpenWithRLock() {
+		i.state.RUnlock()
+		return nil, errDbIndexUnableToQueryClosed
+	}
+
+	// Track this as an inflight query that needs to finish
+	// when the index is closed.
+	i.queriesWg.Add(1)
+	defer i.queriesWg.Done()
+
+	blocks, err := i.blocksForQueryWithRLock(xtime.NewRanges(xtime.Range{
+		Start: opts.StartInclusive,
+		End:   opts.EndExclusive,
+	}))
+
+	// Release the lock before inspecting the blocks to avoid blocking ticks.
+	i.state.RUnlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	terms := index.AnalyzeQuery(query).Terms
+	results := make([]index.QueryCostResult, 0, len(blocks))
+	for _, block := range blocks {
+		blockResult, err := block.QueryCost(terms)
+		if err == index.ErrUnableToQueryBlockClosed {
+			// NB: The block slid out of retention since it was retrieved,
+			// its series are no longer queryable so it is skipped.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, blockResult)
+	}
+
+	return results, nil
+}
+
 func (i *nsIndex) timeoutForQueryWithRLock(
 	ctx context.Context,
 ) time.Duration {
@@ -978,6 +1081,11 @@ func (i *nsIndex) timeoutForQueryWithRLock(
 func (i *nsIndex) overriddenOptsForQueryWithRLock(
 	opts index.QueryOptions,
 ) index.QueryOptions {
+	if opts.PageSize > 0 {
+		// The page size bounds the results of paged queries, truncating the
+		// results by the limit could skip series that belong in the page.
+		return opts
+	}
 	// Override query response limit if needed.
 	if i.state.runtimeOpts.maxQueryLimit > 0 && (opts.Limit == 0 ||
 		int64(opts.Limit) > i.state.runtimeOpts.maxQueryLimit) {
@@ -1113,7 +1221,7 @@ func (i *nsIndex) CleanupExpiredFileSets(t time.Time) error {
 	}
 
 	// earliest block to retain based on retention period
-	earliestBlockStartToRetain := retention.FlushTimeStartForRetentionPeriod(i.retentionPeriod, i.blockSize, t)
+	earliestBlockStartToRetain := retention.FlushTimeStartForRetentionPeriod(i.state.retentionPeriod, i.blockSize, t)
 
 	// now we loop through the blocks we hold, to ensure we don't delete any data for them.
 	for t := range i.state.blocksByTime {
@@ -1195,11 +1303,12 @@ func (i *nsIndex) unableToAllocBlockInvariantError(err error) error {
 }
 
 type nsIndexMetrics struct {
-	AsyncInsertErrors           tally.Counter
-	InsertAfterClose            tally.Counter
-	QueryAfterClose             tally.Counter
-	InsertEndToEndLatency       tally.Timer
-	FlushEvictedMutableSegments tally.Counter
+	AsyncInsertErrors             tally.Counter
+	InsertAfterClose              tally.Counter
+	QueryAfterClose               tally.Counter
+	InsertEndToEndLatency         tally.Timer
+	FlushEvictedMutableSegments   tally.Counter
+	FlushEvictedCompactedSegments tally.Counter
 }
 
 func newNamespaceIndexMetrics(
@@ -1219,7 +1328,8 @@ func newNamespaceIndexMetrics(
 		InsertEndToEndLatency: instrument.MustCreateSampledTimer(
 			scope.Timer("insert-end-to-end-latency"),
 			iopts.MetricsSamplingRate()),
-		FlushEvictedMutableSegments: scope.Counter("mutable-segment-evicted"),
+		FlushEvictedMutableSegments:   scope.Counter("mutable-segment-evicted"),
+		FlushEvictedCompactedSegments: scope.Counter("compacted-segment-evicted"),
 	}
 }
 
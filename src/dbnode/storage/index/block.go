@@ -26,7 +26,9 @@ import (
 	"sync"
 	"time"
 
+	"github.com/m3db/m3/src/dbnode/clock"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
+	"github.com/m3db/m3/src/dbnode/storage/index/segments"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	m3ninxindex "github.com/m3db/m3/src/m3ninx/index"
 	"github.com/m3db/m3/src/m3ninx/index/segment"
@@ -67,16 +69,30 @@ type newExecutorFn func() (search.Executor, error)
 
 type block struct {
 	sync.RWMutex
-	state               blockState
-	activeSegment       segment.MutableSegment
-	shardRangesSegments []blockShardRangesSegments
-
-	newExecutorFn newExecutorFn
-	startTime     time.Time
-	endTime       time.Time
-	blockSize     time.Duration
-	opts          Options
-	nsMD          namespace.Metadata
+	state                  blockState
+	activeSegment          segment.MutableSegment
+	activeSegmentCreatedAt time.Time
+	compactedSegments      []blockCompactedSegment
+	shardRangesSegments    []blockShardRangesSegments
+
+	newExecutorFn  newExecutorFn
+	startTime      time.Time
+	endTime        time.Time
+	blockSize      time.Duration
+	opts           Options
+	nsMD           namespace.Metadata
+	nowFn          clock.NowFn
+	compactCloseCh chan struct{}
+	metrics        blockMetrics
+}
+
+// blockCompactedSegment is a segment containing only data written to the
+// block, either a mutable segment rotated out from being the active segment
+// or a FST segment that rotated mutable segments have been compacted into.
+type blockCompactedSegment struct {
+	segment     segment.Segment
+	segmentType segments.Type
+	createdAt   time.Time
 }
 
 // blockShardsSegments is a collection of segments that has a mapping of what shards
@@ -105,18 +121,27 @@ func NewBlock(
 		return nil, err
 	}
 
+	nowFn := opts.ClockOptions().NowFn()
 	b := &block{
-		state:         blockStateOpen,
-		activeSegment: seg,
+		state:                  blockStateOpen,
+		activeSegment:          seg,
+		activeSegmentCreatedAt: nowFn(),
 
 		startTime: startTime,
 		endTime:   startTime.Add(blockSize),
 		blockSize: blockSize,
 		opts:      opts,
 		nsMD:      md,
+		nowFn:     nowFn,
+		metrics:   newBlockMetrics(opts.InstrumentOptions().MetricsScope()),
 	}
 	b.newExecutorFn = b.executorWithRLock
 
+	if interval := opts.CompactionInterval(); interval > 0 {
+		b.compactCloseCh = make(chan struct{})
+		go b.compactLoop(interval, b.compactCloseCh)
+	}
+
 	return b, nil
 }
 
@@ -188,6 +213,7 @@ func (b *block) executorWithRLock() (search.Executor, error) {
 	if b.activeSegment != nil {
 		expectedReaders++
 	}
+	expectedReaders += len(b.compactedSegments)
 	for _, group := range b.shardRangesSegments {
 		expectedReaders += len(group.segments)
 	}
@@ -215,6 +241,15 @@ func (b *block) executorWithRLock() (search.Executor, error) {
 		readers = append(readers, reader)
 	}
 
+	// then the segments rotated out of and compacted from the active segment
+	for _, seg := range b.compactedSegments {
+		reader, err := seg.segment.Reader()
+		if err != nil {
+			return nil, err
+		}
+		readers = append(readers, reader)
+	}
+
 	// loop over the segments associated to shard time ranges
 	for _, group := range b.shardRangesSegments {
 		for _, seg := range group.segments {
@@ -241,6 +276,10 @@ func (b *block) Query(
 		return false, ErrUnableToQueryBlockClosed
 	}
 
+	if opts.PageSize > 0 {
+		return b.queryPageWithRLock(query, opts, results)
+	}
+
 	exec, err := b.newExecutorFn()
 	if err != nil {
 		return false, err
@@ -294,6 +333,104 @@ func (b *block) Query(
 	return exhaustive, nil
 }
 
+// queryPageWithRLock adds to the results the matching documents of each
+// segment with the smallest IDs after the page position. Callers find out if
+// there are more results after a page by querying one more than the page size.
+func (b *block) queryPageWithRLock(
+	query Query,
+	opts QueryOptions,
+	results Results,
+) (bool, error) {
+	searcher, err := query.Query.SearchQuery().Searcher()
+	if err != nil {
+		return false, err
+	}
+
+	queryPage := func(seg segment.Segment) error {
+		return queryPageSegment(seg, searcher, opts.PageAfter, opts.PageSize, results)
+	}
+	if b.activeSegment != nil {
+		if err := queryPage(b.activeSegment); err != nil {
+			return false, err
+		}
+	}
+	for _, seg := range b.compactedSegments {
+		if err := queryPage(seg.segment); err != nil {
+			return false, err
+		}
+	}
+	for _, group := range b.shardRangesSegments {
+		for _, seg := range group.segments {
+			if err := queryPage(seg); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	return true, nil
+}
+
+func (b *block) Cardinality(opts CardinalityOptions) (CardinalityResult, error) {
+	b.RLock()
+	defer b.RUnlock()
+	if b.state == blockStateClosed {
+		return CardinalityResult{}, ErrUnableToQueryBlockClosed
+	}
+
+	agg := newCardinalityAggregator(opts)
+	if b.activeSegment != nil {
+		if err := agg.addSegment(b.activeSegment); err != nil {
+			return CardinalityResult{}, err
+		}
+	}
+	for _, seg := range b.compactedSegments {
+		if err := agg.addSegment(seg.segment); err != nil {
+			return CardinalityResult{}, err
+		}
+	}
+	for _, group := range b.shardRangesSegments {
+		for _, seg := range group.segments {
+			if err := agg.addSegment(seg); err != nil {
+				return CardinalityResult{}, err
+			}
+		}
+	}
+
+	return agg.result(b.startTime), nil
+}
+
+func (b *block) QueryCost(terms []QueryTerm) (QueryCostResult, error) {
+	b.RLock()
+	defer b.RUnlock()
+	if b.state == blockStateClosed {
+		return QueryCostResult{}, ErrUnableToQueryBlockClosed
+	}
+
+	agg, err := newQueryCostAggregator(terms, queryCostMaxTerms)
+	if err != nil {
+		return QueryCostResult{}, err
+	}
+	if b.activeSegment != nil {
+		if err := agg.addSegment(b.activeSegment); err != nil {
+			return QueryCostResult{}, err
+		}
+	}
+	for _, seg := range b.compactedSegments {
+		if err := agg.addSegment(seg.segment); err != nil {
+			return QueryCostResult{}, err
+		}
+	}
+	for _, group := range b.shardRangesSegments {
+		for _, seg := range group.segments {
+			if err := agg.addSegment(seg); err != nil {
+				return QueryCostResult{}, err
+			}
+		}
+	}
+
+	return agg.result(b.startTime), nil
+}
+
 func (b *block) AddResults(
 	results result.IndexBlock,
 ) error {
@@ -385,6 +522,12 @@ func (b *block) Tick(c context.Cancellable, tickStart time.Time) (BlockTickResul
 		result.NumDocs += b.activeSegment.Size()
 	}
 
+	// segments rotated out of and compacted from the active segment
+	for _, seg := range b.compactedSegments {
+		result.NumSegments++
+		result.NumDocs += seg.segment.Size()
+	}
+
 	// any other segments
 	for _, group := range b.shardRangesSegments {
 		for _, seg := range group.segments {
@@ -405,6 +548,7 @@ func (b *block) Seal() error {
 		return fmt.Errorf(errUnableToSealBlockIllegalStateFmtString, b.state)
 	}
 	b.state = blockStateSealed
+	b.stopCompactLoopWithLock()
 
 	var multiErr xerrors.MultiError
 
@@ -412,6 +556,15 @@ func (b *block) Seal() error {
 	_, err := b.activeSegment.Seal()
 	multiErr = multiErr.Add(err)
 
+	// rotated mutable segments are sealed when rotated, but ensure they are
+	// sealed regardless.
+	for _, seg := range b.compactedSegments {
+		if unsealed, ok := seg.segment.(segment.MutableSegment); ok && !unsealed.IsSealed() {
+			_, err := unsealed.Seal()
+			multiErr = multiErr.Add(err)
+		}
+	}
+
 	// loop over any added mutable segments and seal them too.
 	for _, group := range b.shardRangesSegments {
 		for _, seg := range group.segments {
@@ -441,7 +594,7 @@ func (b *block) NeedsMutableSegmentsEvicted() bool {
 	anyMutableSegmentNeedsEviction := b.activeSegment != nil && b.activeSegment.Size() > 0
 
 	// can early terminate if we already know we need to flush.
-	if anyMutableSegmentNeedsEviction {
+	if anyMutableSegmentNeedsEviction || len(b.compactedSegments) > 0 {
 		return true
 	}
 
@@ -475,6 +628,19 @@ func (b *block) EvictMutableSegments() (EvictMutableSegmentResults, error) {
 		b.activeSegment = nil
 	}
 
+	// close the segments rotated out of and compacted from the active
+	// segment as they only contain data written to the block.
+	for _, seg := range b.compactedSegments {
+		if seg.segmentType == segments.MutableType {
+			results.NumMutableSegments++
+		} else {
+			results.NumCompactedSegments++
+		}
+		results.NumDocs += seg.segment.Size()
+		multiErr = multiErr.Add(seg.segment.Close())
+	}
+	b.compactedSegments = nil
+
 	// close any other mutable segments too.
 	for idx := range b.shardRangesSegments {
 		segments := make([]segment.Segment, 0, len(b.shardRangesSegments[idx].segments))
@@ -501,6 +667,7 @@ func (b *block) Close() error {
 		return errBlockAlreadyClosed
 	}
 	b.state = blockStateClosed
+	b.stopCompactLoopWithLock()
 
 	var multiErr xerrors.MultiError
 
@@ -510,6 +677,12 @@ func (b *block) Close() error {
 		b.activeSegment = nil
 	}
 
+	// close segments rotated out of and compacted from the active segment.
+	for _, seg := range b.compactedSegments {
+		multiErr = multiErr.Add(seg.segment.Close())
+	}
+	b.compactedSegments = nil
+
 	// close any other added segments too.
 	for _, group := range b.shardRangesSegments {
 		for _, seg := range group.segments {
@@ -0,0 +1,362 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package index
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/storage/index/compaction"
+	"github.com/m3db/m3/src/dbnode/storage/index/segments"
+	"github.com/m3db/m3/src/m3ninx/index/segment"
+	"github.com/m3db/m3/src/m3ninx/index/segment/fst"
+	"github.com/m3db/m3/src/m3ninx/index/segment/mem"
+	"github.com/m3db/m3/src/m3ninx/postings"
+	xerrors "github.com/m3db/m3x/errors"
+
+	"github.com/uber-go/tally"
+)
+
+type blockMetrics struct {
+	rotatedSegments   tally.Counter
+	compactions       tally.Counter
+	compactionErrors  tally.Counter
+	compactionLatency tally.Timer
+	compactedSegments tally.Counter
+	compactedDocs     tally.Counter
+	discardedResults  tally.Counter
+}
+
+func newBlockMetrics(scope tally.Scope) blockMetrics {
+	scope = scope.SubScope("compaction")
+	return blockMetrics{
+		rotatedSegments:   scope.Counter("rotated-segments"),
+		compactions:       scope.Counter("compactions"),
+		compactionErrors:  scope.Counter("compaction-errors"),
+		compactionLatency: scope.Timer("compaction-latency"),
+		compactedSegments: scope.Counter("compacted-segments"),
+		compactedDocs:     scope.Counter("compacted-docs"),
+		discardedResults:  scope.Counter("discarded-results"),
+	}
+}
+
+// compactLoop periodically compacts the segments of the block until the
+// block is sealed or closed, compactions of all blocks share a worker pool
+// to limit the number of compactions running concurrently.
+func (b *block) compactLoop(interval time.Duration, closeCh chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	workers := b.opts.CompactionWorkerPool()
+	for {
+		select {
+		case <-closeCh:
+			return
+		case <-ticker.C:
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		workers.Go(func() {
+			defer wg.Done()
+			if err := b.compact(); err != nil {
+				b.opts.InstrumentOptions().Logger().
+					Errorf("error compacting index block %s: %v", b.startTime.String(), err)
+			}
+		})
+		wg.Wait()
+	}
+}
+
+func (b *block) stopCompactLoopWithLock() {
+	if b.compactCloseCh != nil {
+		close(b.compactCloseCh)
+		b.compactCloseCh = nil
+	}
+}
+
+// compact rotates out the active segment if it is eligible for compaction
+// and then executes a compaction plan over the segments rotated out of the
+// active segment. Segments are merged into FST segments outside of the block
+// lock and swapped in atomically once built.
+func (b *block) compact() error {
+	plannerOpts := b.opts.CompactionPlannerOptions()
+
+	b.Lock()
+	if b.state != blockStateOpen {
+		b.Unlock()
+		return nil
+	}
+
+	now := b.nowFn()
+	if err := b.rotateActiveSegmentIfCompactableWithLock(now, plannerOpts); err != nil {
+		b.Unlock()
+		return err
+	}
+
+	candidates := make([]compaction.Segment, 0, len(b.compactedSegments))
+	for _, seg := range b.compactedSegments {
+		candidates = append(candidates, compaction.Segment{
+			Age:     now.Sub(seg.createdAt),
+			Size:    seg.segment.Size(),
+			Type:    seg.segmentType,
+			Segment: seg.segment,
+		})
+	}
+	b.Unlock()
+
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	plan, err := compaction.NewPlan(candidates, plannerOpts)
+	if err != nil {
+		return err
+	}
+
+	var multiErr xerrors.MultiError
+	for _, task := range plan.Tasks {
+		if len(task.Segments) == 1 && task.Segments[0].Type == segments.FSTType {
+			// Nothing to gain from compacting a single FST segment.
+			continue
+		}
+
+		start := b.nowFn()
+		compacted, err := b.compactSegments(task.Segments)
+		b.metrics.compactionLatency.Record(b.nowFn().Sub(start))
+		if err != nil {
+			b.metrics.compactionErrors.Inc(1)
+			multiErr = multiErr.Add(err)
+			continue
+		}
+
+		if err := b.swapCompactedSegments(task.Segments, compacted); err != nil {
+			b.metrics.compactionErrors.Inc(1)
+			multiErr = multiErr.Add(err)
+			continue
+		}
+
+		b.metrics.compactions.Inc(1)
+		b.metrics.compactedSegments.Inc(int64(len(task.Segments)))
+		b.metrics.compactedDocs.Inc(compacted.Size())
+	}
+
+	return multiErr.FinalError()
+}
+
+func (b *block) rotateActiveSegmentIfCompactableWithLock(
+	now time.Time,
+	plannerOpts compaction.PlannerOptions,
+) error {
+	if b.activeSegment == nil || b.activeSegment.Size() == 0 {
+		return nil
+	}
+
+	active := compaction.Segment{
+		Age:  now.Sub(b.activeSegmentCreatedAt),
+		Size: b.activeSegment.Size(),
+		Type: segments.MutableType,
+	}
+	if !active.Compactable(plannerOpts) {
+		return nil
+	}
+
+	// Create the new active segment first so the block is left untouched
+	// if it cannot be created.
+	postingsOffset := postings.ID(0)
+	seg, err := mem.NewSegment(postingsOffset, b.opts.MemSegmentOptions())
+	if err != nil {
+		return err
+	}
+
+	if _, err := b.activeSegment.Seal(); err != nil {
+		seg.Close()
+		return err
+	}
+
+	b.compactedSegments = append(b.compactedSegments, blockCompactedSegment{
+		segment:     b.activeSegment,
+		segmentType: segments.MutableType,
+		createdAt:   b.activeSegmentCreatedAt,
+	})
+	b.activeSegment = seg
+	b.activeSegmentCreatedAt = now
+	b.metrics.rotatedSegments.Inc(1)
+	return nil
+}
+
+// compactSegments merges the documents of the provided segments into a
+// single FST segment held in memory.
+func (b *block) compactSegments(segs []compaction.Segment) (segment.Segment, error) {
+	postingsOffset := postings.ID(0)
+	merged, err := mem.NewSegment(postingsOffset, b.opts.MemSegmentOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	// NB: the merged segment references documents of the source segments,
+	// it is only required until the FST segment has been written.
+	defer merged.Close()
+
+	for _, seg := range segs {
+		if err := mergeSegmentInto(merged, seg.Segment); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := merged.Seal(); err != nil {
+		return nil, err
+	}
+
+	w := fst.NewWriter()
+	if err := w.Reset(merged); err != nil {
+		return nil, err
+	}
+
+	var (
+		docsDataBuffer  bytes.Buffer
+		docsIndexBuffer bytes.Buffer
+		postingsBuffer  bytes.Buffer
+		fstTermsBuffer  bytes.Buffer
+		fstFieldsBuffer bytes.Buffer
+	)
+	if err := w.WriteDocumentsData(&docsDataBuffer); err != nil {
+		return nil, err
+	}
+	if err := w.WriteDocumentsIndex(&docsIndexBuffer); err != nil {
+		return nil, err
+	}
+	if err := w.WritePostingsOffsets(&postingsBuffer); err != nil {
+		return nil, err
+	}
+	if err := w.WriteFSTTerms(&fstTermsBuffer); err != nil {
+		return nil, err
+	}
+	if err := w.WriteFSTFields(&fstFieldsBuffer); err != nil {
+		return nil, err
+	}
+
+	return fst.NewSegment(fst.SegmentData{
+		MajorVersion:  w.MajorVersion(),
+		MinorVersion:  w.MinorVersion(),
+		Metadata:      w.Metadata(),
+		DocsData:      docsDataBuffer.Bytes(),
+		DocsIdxData:   docsIndexBuffer.Bytes(),
+		PostingsData:  postingsBuffer.Bytes(),
+		FSTTermsData:  fstTermsBuffer.Bytes(),
+		FSTFieldsData: fstFieldsBuffer.Bytes(),
+	}, b.opts.FSTSegmentOptions())
+}
+
+func mergeSegmentInto(target segment.MutableSegment, src segment.Segment) error {
+	reader, err := src.Reader()
+	if err != nil {
+		return err
+	}
+
+	iter, err := reader.AllDocs()
+	if err != nil {
+		reader.Close()
+		return err
+	}
+
+	var multiErr xerrors.MultiError
+	for iter.Next() {
+		// NB: mem segments insert an empty document rather than returning an
+		// error for duplicate IDs, so documents already merged from another
+		// segment are skipped explicitly.
+		d := iter.Current()
+		exists, err := target.ContainsID(d.ID)
+		if err != nil {
+			multiErr = multiErr.Add(err)
+			break
+		}
+		if exists {
+			continue
+		}
+		if _, err := target.Insert(d); err != nil {
+			multiErr = multiErr.Add(err)
+			break
+		}
+	}
+	multiErr = multiErr.Add(iter.Err())
+	multiErr = multiErr.Add(iter.Close())
+	multiErr = multiErr.Add(reader.Close())
+	return multiErr.FinalError()
+}
+
+// swapCompactedSegments atomically replaces the compacted segments with the
+// segment they were compacted into, the result is discarded if any of the
+// compacted segments were evicted or the block closed during the compaction.
+func (b *block) swapCompactedSegments(
+	compacted []compaction.Segment,
+	result segment.Segment,
+) error {
+	b.Lock()
+	defer b.Unlock()
+
+	remove := make(map[segment.Segment]struct{}, len(compacted))
+	for _, seg := range compacted {
+		remove[seg.Segment] = struct{}{}
+	}
+
+	discard := b.state == blockStateClosed
+	if !discard {
+		found := 0
+		for _, seg := range b.compactedSegments {
+			if _, ok := remove[seg.segment]; ok {
+				found++
+			}
+		}
+		discard = found != len(remove)
+	}
+	if discard {
+		b.metrics.discardedResults.Inc(1)
+		return result.Close()
+	}
+
+	var (
+		multiErr xerrors.MultiError
+		retained = make([]blockCompactedSegment, 0, len(b.compactedSegments)-len(remove)+1)
+	)
+	for _, seg := range b.compactedSegments {
+		if _, ok := remove[seg.segment]; !ok {
+			retained = append(retained, seg)
+			continue
+		}
+		// NB: queries hold the block read lock for their entire duration so
+		// no readers of the compacted segments are open at this point.
+		multiErr = multiErr.Add(seg.segment.Close())
+	}
+	retained = append(retained, blockCompactedSegment{
+		segment:     result,
+		segmentType: segments.FSTType,
+		createdAt:   b.nowFn(),
+	})
+	b.compactedSegments = retained
+
+	if err := multiErr.FinalError(); err != nil {
+		return fmt.Errorf("unable to close compacted segments: %v", err)
+	}
+	return nil
+}
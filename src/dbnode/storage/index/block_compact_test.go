@@ -0,0 +1,235 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package index
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/storage/index/compaction"
+	"github.com/m3db/m3/src/dbnode/storage/index/segments"
+	"github.com/m3db/m3/src/m3ninx/doc"
+	"github.com/m3db/m3/src/m3ninx/idx"
+	"github.com/m3db/m3/src/m3ninx/index/segment"
+	"github.com/m3db/m3/src/m3ninx/index/segment/mem"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCompactBlock(
+	t *testing.T,
+	plannerOpts compaction.PlannerOptions,
+) *block {
+	opts := testOpts.
+		SetCompactionInterval(0).
+		SetCompactionPlannerOptions(plannerOpts)
+	start := time.Now().Truncate(time.Hour)
+	blk, err := NewBlock(start, newTestNSMetadata(t), opts)
+	require.NoError(t, err)
+	return blk.(*block)
+}
+
+func newTestCompactPlannerOptions() compaction.PlannerOptions {
+	plannerOpts := compaction.DefaultOptions
+	plannerOpts.MutableSegmentSizeThreshold = 1
+	return plannerOpts
+}
+
+func testCompactBlockWrite(
+	t *testing.T,
+	ctrl *gomock.Controller,
+	b *block,
+	docs ...doc.Document,
+) {
+	batch := NewWriteBatch(WriteBatchOptions{
+		IndexBlockSize: b.blockSize,
+	})
+	for _, d := range docs {
+		h := NewMockOnIndexSeries(ctrl)
+		h.EXPECT().OnIndexFinalize(xtime.ToUnixNano(b.startTime))
+		h.EXPECT().OnIndexSuccess(xtime.ToUnixNano(b.startTime))
+		batch.Append(WriteBatchEntry{
+			Timestamp:     b.startTime.Add(time.Minute),
+			OnIndexSeries: h,
+		}, d)
+	}
+	_, err := b.WriteBatch(batch)
+	require.NoError(t, err)
+}
+
+func testCompactBlockQueryIDs(t *testing.T, b *block) []string {
+	q, err := idx.NewRegexpQuery([]byte("bar"), []byte("b.*"))
+	require.NoError(t, err)
+	results := NewResults(testOpts)
+	exhaustive, err := b.Query(Query{q}, QueryOptions{}, results)
+	require.NoError(t, err)
+	require.True(t, exhaustive)
+
+	var ids []string
+	for _, entry := range results.Map().Iter() {
+		ids = append(ids, entry.Key().String())
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestBlockCompactRotatesAndCompactsActiveSegment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	b := newTestCompactBlock(t, newTestCompactPlannerOptions())
+	defer b.Close()
+
+	testCompactBlockWrite(t, ctrl, b, testDoc1(), testDoc2())
+	require.NoError(t, b.compact())
+
+	require.Equal(t, int64(0), b.activeSegment.Size())
+	require.Len(t, b.compactedSegments, 1)
+	require.Equal(t, segments.FSTType, b.compactedSegments[0].segmentType)
+	require.Equal(t, int64(2), b.compactedSegments[0].segment.Size())
+
+	require.Equal(t, []string{"foo", "something"},
+		testCompactBlockQueryIDs(t, b))
+}
+
+func TestBlockCompactMergesCompactedSegments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	b := newTestCompactBlock(t, newTestCompactPlannerOptions())
+	defer b.Close()
+
+	testCompactBlockWrite(t, ctrl, b, testDoc1())
+	require.NoError(t, b.compact())
+	require.Len(t, b.compactedSegments, 1)
+
+	testCompactBlockWrite(t, ctrl, b, testDoc2())
+	require.NoError(t, b.compact())
+	require.Len(t, b.compactedSegments, 1)
+	require.Equal(t, segments.FSTType, b.compactedSegments[0].segmentType)
+	require.Equal(t, int64(2), b.compactedSegments[0].segment.Size())
+
+	require.Equal(t, []string{"foo", "something"},
+		testCompactBlockQueryIDs(t, b))
+}
+
+func TestBlockCompactMergeSkipsDuplicateIDs(t *testing.T) {
+	var srcs []segment.Segment
+	for i := 0; i < 2; i++ {
+		src, err := mem.NewSegment(0, testOpts.MemSegmentOptions())
+		require.NoError(t, err)
+		_, err = src.Insert(testDoc1())
+		require.NoError(t, err)
+		srcs = append(srcs, src)
+	}
+
+	target, err := mem.NewSegment(0, testOpts.MemSegmentOptions())
+	require.NoError(t, err)
+	for _, src := range srcs {
+		require.NoError(t, mergeSegmentInto(target, src))
+	}
+	require.Equal(t, int64(1), target.Size())
+
+	// Only the single merged document is read back, no empty documents.
+	reader, err := target.Reader()
+	require.NoError(t, err)
+	iter, err := reader.AllDocs()
+	require.NoError(t, err)
+	var ids []string
+	for iter.Next() {
+		ids = append(ids, string(iter.Current().ID))
+	}
+	require.NoError(t, iter.Err())
+	require.NoError(t, iter.Close())
+	require.NoError(t, reader.Close())
+	require.Equal(t, []string{string(testDoc1().ID)}, ids)
+}
+
+func TestBlockCompactActiveSegmentNotCompactable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	plannerOpts := compaction.DefaultOptions
+	plannerOpts.MutableCompactionAgeThreshold = time.Hour
+	b := newTestCompactBlock(t, plannerOpts)
+	defer b.Close()
+
+	testCompactBlockWrite(t, ctrl, b, testDoc1(), testDoc2())
+	require.NoError(t, b.compact())
+
+	require.Equal(t, int64(2), b.activeSegment.Size())
+	require.Len(t, b.compactedSegments, 0)
+}
+
+func TestBlockCompactAfterSealIsNoop(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	b := newTestCompactBlock(t, newTestCompactPlannerOptions())
+	defer b.Close()
+
+	testCompactBlockWrite(t, ctrl, b, testDoc1())
+	require.NoError(t, b.Seal())
+	require.NoError(t, b.compact())
+	require.Len(t, b.compactedSegments, 0)
+}
+
+func TestBlockCompactSwapDiscardsEvictedSegments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	b := newTestCompactBlock(t, newTestCompactPlannerOptions())
+	defer b.Close()
+
+	evicted := segment.NewMockSegment(ctrl)
+	result := segment.NewMockSegment(ctrl)
+	result.EXPECT().Close().Return(nil)
+
+	require.NoError(t, b.swapCompactedSegments([]compaction.Segment{
+		{Segment: evicted, Type: segments.MutableType},
+	}, result))
+	require.Len(t, b.compactedSegments, 0)
+}
+
+func TestBlockEvictMutableSegmentsCompactedSegments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	b := newTestCompactBlock(t, newTestCompactPlannerOptions())
+	defer b.Close()
+
+	testCompactBlockWrite(t, ctrl, b, testDoc1())
+	require.NoError(t, b.compact())
+	testCompactBlockWrite(t, ctrl, b, testDoc2())
+
+	require.NoError(t, b.Seal())
+	require.True(t, b.NeedsMutableSegmentsEvicted())
+
+	res, err := b.EvictMutableSegments()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), res.NumMutableSegments)
+	require.Equal(t, int64(1), res.NumCompactedSegments)
+	require.Equal(t, int64(2), res.NumDocs)
+	require.Len(t, b.compactedSegments, 0)
+}
@@ -0,0 +1,236 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package index
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/m3db/m3/src/m3ninx/doc"
+	m3ninxindex "github.com/m3db/m3/src/m3ninx/index"
+	"github.com/m3db/m3/src/m3ninx/index/segment"
+	xerrors "github.com/m3db/m3x/errors"
+)
+
+const (
+	// DefaultCardinalityLimit is the default number of entries returned
+	// for each ranking of a cardinality query.
+	DefaultCardinalityLimit = 10
+
+	// MaxCardinalityLimit is the max number of entries that can be
+	// returned for each ranking of a cardinality query.
+	MaxCardinalityLimit = 1000
+
+	// DefaultCardinalityMaxTerms is the default max number of terms
+	// inspected per index block by a cardinality query.
+	DefaultCardinalityMaxTerms = 1000000
+
+	// MaxCardinalityMaxTerms is the max number of terms that can be
+	// inspected per index block by a cardinality query.
+	MaxCardinalityMaxTerms = 10000000
+)
+
+var (
+	// DefaultCardinalityNameTag is the default tag whose values are
+	// the metric names.
+	DefaultCardinalityNameTag = []byte("__name__")
+
+	errCardinalityLimitInvalid    = errors.New("cardinality limit must be positive and at most the max limit")
+	errCardinalityMaxTermsInvalid = errors.New("cardinality max terms must be positive and at most the max terms")
+)
+
+// Validate validates the cardinality options.
+func (o CardinalityOptions) Validate() error {
+	if o.Limit <= 0 || o.Limit > MaxCardinalityLimit {
+		return errCardinalityLimitInvalid
+	}
+	if o.MaxTerms <= 0 || o.MaxTerms > MaxCardinalityMaxTerms {
+		return errCardinalityMaxTermsInvalid
+	}
+	return nil
+}
+
+// cardinalityAggregator sums the postings list sizes of the terms of
+// each segment of an index block. A series that is present in more than
+// one segment of a block, e.g. while its segments are being compacted, is
+// counted once per segment so the series counts are an upper bound.
+type cardinalityAggregator struct {
+	opts        CardinalityOptions
+	numSeries   int64
+	metricNames map[string]int64
+	tagNames    map[string]int64
+	tagValues   map[string]map[string]struct{}
+	numTerms    int
+	exhaustive  bool
+}
+
+func newCardinalityAggregator(opts CardinalityOptions) *cardinalityAggregator {
+	return &cardinalityAggregator{
+		opts:        opts,
+		metricNames: make(map[string]int64),
+		tagNames:    make(map[string]int64),
+		tagValues:   make(map[string]map[string]struct{}),
+		exhaustive:  true,
+	}
+}
+
+func (a *cardinalityAggregator) addSegment(seg segment.Segment) (err error) {
+	a.numSeries += seg.Size()
+	if !a.exhaustive {
+		return nil
+	}
+
+	reader, err := seg.Reader()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = xerrors.FirstError(err, reader.Close())
+	}()
+
+	// NB: the fields and terms of a mutable segment can only be iterated
+	// once it is sealed, walk the documents of an unsealed one instead.
+	if mutable, ok := seg.(segment.MutableSegment); ok && !mutable.IsSealed() {
+		return a.addDocs(reader)
+	}
+
+	fields, err := seg.Fields()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = xerrors.FirstError(err, fields.Close())
+	}()
+
+	for a.exhaustive && fields.Next() {
+		field := fields.Current()
+		if bytes.Equal(field, doc.IDReservedFieldName) {
+			continue
+		}
+		if err := a.addField(seg, reader, field); err != nil {
+			return err
+		}
+	}
+	return fields.Err()
+}
+
+func (a *cardinalityAggregator) addDocs(reader m3ninxindex.Reader) (err error) {
+	docs, err := reader.AllDocs()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = xerrors.FirstError(err, docs.Close())
+	}()
+
+	for a.exhaustive && docs.Next() {
+		for _, field := range docs.Current().Fields {
+			if a.numTerms >= a.opts.MaxTerms {
+				a.exhaustive = false
+				break
+			}
+			a.numTerms++
+			a.addTerm(field.Name, field.Value, 1)
+		}
+	}
+	return docs.Err()
+}
+
+func (a *cardinalityAggregator) addField(
+	seg segment.Segment,
+	reader m3ninxindex.Reader,
+	field []byte,
+) (err error) {
+	terms, err := seg.Terms(field)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = xerrors.FirstError(err, terms.Close())
+	}()
+
+	for terms.Next() {
+		if a.numTerms >= a.opts.MaxTerms {
+			a.exhaustive = false
+			break
+		}
+		a.numTerms++
+
+		term := terms.Current()
+		pl, err := reader.MatchTerm(field, term)
+		if err != nil {
+			return err
+		}
+		a.addTerm(field, term, int64(pl.Len()))
+	}
+	return terms.Err()
+}
+
+func (a *cardinalityAggregator) addTerm(field, term []byte, count int64) {
+	name := string(field)
+	values, ok := a.tagValues[name]
+	if !ok {
+		values = make(map[string]struct{})
+		a.tagValues[name] = values
+	}
+
+	a.tagNames[name] += count
+	values[string(term)] = struct{}{}
+	if bytes.Equal(field, a.opts.NameTag) {
+		a.metricNames[string(term)] += count
+	}
+}
+
+func (a *cardinalityAggregator) result(blockStart time.Time) CardinalityResult {
+	tagValues := make(map[string]int64, len(a.tagValues))
+	for name, values := range a.tagValues {
+		tagValues[name] = int64(len(values))
+	}
+	return CardinalityResult{
+		BlockStart:  blockStart,
+		NumSeries:   a.numSeries,
+		MetricNames: TopCardinalityEntries(a.metricNames, a.opts.Limit),
+		TagNames:    TopCardinalityEntries(a.tagNames, a.opts.Limit),
+		TagValues:   TopCardinalityEntries(tagValues, a.opts.Limit),
+		Exhaustive:  a.exhaustive,
+	}
+}
+
+// TopCardinalityEntries returns the limit entries with the highest counts,
+// ordered by count descending and then by name.
+func TopCardinalityEntries(counts map[string]int64, limit int) []CardinalityEntry {
+	entries := make([]CardinalityEntry, 0, len(counts))
+	for name, count := range counts {
+		entries = append(entries, CardinalityEntry{Name: []byte(name), Count: count})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Count != entries[j].Count {
+			return entries[i].Count > entries[j].Count
+		}
+		return bytes.Compare(entries[i].Name, entries[j].Name) < 0
+	})
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries
+}
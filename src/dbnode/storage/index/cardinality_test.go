@@ -0,0 +1,182 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package index
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/m3ninx/doc"
+	"github.com/m3db/m3/src/m3ninx/index/segment"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testCardinalityOptions() CardinalityOptions {
+	return CardinalityOptions{
+		NameTag:  DefaultCardinalityNameTag,
+		Limit:    DefaultCardinalityLimit,
+		MaxTerms: DefaultCardinalityMaxTerms,
+	}
+}
+
+func testCardinalityDoc(id, name, host string) doc.Document {
+	return doc.Document{
+		ID: []byte(id),
+		Fields: []doc.Field{
+			{Name: DefaultCardinalityNameTag, Value: []byte(name)},
+			{Name: []byte("host"), Value: []byte(host)},
+		},
+	}
+}
+
+func TestCardinalityOptionsValidate(t *testing.T) {
+	opts := testCardinalityOptions()
+	require.NoError(t, opts.Validate())
+
+	opts.Limit = MaxCardinalityLimit + 1
+	require.Error(t, opts.Validate())
+
+	opts = testCardinalityOptions()
+	opts.MaxTerms = 0
+	require.Error(t, opts.Validate())
+
+	opts.MaxTerms = MaxCardinalityMaxTerms + 1
+	require.Error(t, opts.Validate())
+}
+
+func testSealedSegment(t *testing.T, docs ...doc.Document) segment.Segment {
+	seg, err := testSegment(t, docs...).(segment.MutableSegment).Seal()
+	require.NoError(t, err)
+	return seg
+}
+
+func TestCardinalityAggregatorSegments(t *testing.T) {
+	agg := newCardinalityAggregator(testCardinalityOptions())
+	require.NoError(t, agg.addSegment(testSealedSegment(t,
+		testCardinalityDoc("cpu.a", "cpu", "a"),
+		testCardinalityDoc("cpu.b", "cpu", "b"),
+		testCardinalityDoc("mem.a", "mem", "a"),
+	)))
+	require.NoError(t, agg.addSegment(testSegment(t,
+		testCardinalityDoc("cpu.c", "cpu", "c"),
+	)))
+
+	blockStart := time.Now().Truncate(time.Hour)
+	res := agg.result(blockStart)
+	require.Equal(t, blockStart, res.BlockStart)
+	require.Equal(t, int64(4), res.NumSeries)
+	require.True(t, res.Exhaustive)
+	require.Equal(t, []CardinalityEntry{
+		{Name: []byte("cpu"), Count: 3},
+		{Name: []byte("mem"), Count: 1},
+	}, res.MetricNames)
+	require.Equal(t, []CardinalityEntry{
+		{Name: []byte("__name__"), Count: 4},
+		{Name: []byte("host"), Count: 4},
+	}, res.TagNames)
+	require.Equal(t, []CardinalityEntry{
+		{Name: []byte("host"), Count: 3},
+		{Name: []byte("__name__"), Count: 2},
+	}, res.TagValues)
+}
+
+func TestCardinalityAggregatorMaxTerms(t *testing.T) {
+	opts := testCardinalityOptions()
+	opts.MaxTerms = 2
+	agg := newCardinalityAggregator(opts)
+	require.NoError(t, agg.addSegment(testSealedSegment(t,
+		testCardinalityDoc("cpu.a", "cpu", "a"),
+		testCardinalityDoc("mem.b", "mem", "b"),
+	)))
+
+	res := agg.result(time.Time{})
+	require.Equal(t, int64(2), res.NumSeries)
+	require.False(t, res.Exhaustive)
+	require.Equal(t, []CardinalityEntry{
+		{Name: []byte("cpu"), Count: 1},
+		{Name: []byte("mem"), Count: 1},
+	}, res.MetricNames)
+	require.Equal(t, 0, len(agg.tagValues["host"]))
+}
+
+func TestCardinalityAggregatorUnsealedSegmentMaxTerms(t *testing.T) {
+	opts := testCardinalityOptions()
+	opts.MaxTerms = 2
+	agg := newCardinalityAggregator(opts)
+	require.NoError(t, agg.addSegment(testSegment(t,
+		testCardinalityDoc("cpu.a", "cpu", "a"),
+		testCardinalityDoc("mem.b", "mem", "b"),
+	)))
+
+	res := agg.result(time.Time{})
+	require.Equal(t, int64(2), res.NumSeries)
+	require.False(t, res.Exhaustive)
+	require.Equal(t, []CardinalityEntry{
+		{Name: []byte("cpu"), Count: 1},
+	}, res.MetricNames)
+	require.Equal(t, 1, len(agg.tagValues["host"]))
+}
+
+func TestTopCardinalityEntriesLimit(t *testing.T) {
+	entries := TopCardinalityEntries(map[string]int64{
+		"a": 1,
+		"b": 3,
+		"c": 3,
+		"d": 2,
+	}, 3)
+	require.Equal(t, []CardinalityEntry{
+		{Name: []byte("b"), Count: 3},
+		{Name: []byte("c"), Count: 3},
+		{Name: []byte("d"), Count: 2},
+	}, entries)
+}
+
+func TestBlockCardinalityAfterClose(t *testing.T) {
+	testMD := newTestNSMetadata(t)
+	start := time.Now().Truncate(time.Hour)
+	b, err := NewBlock(start, testMD, testOpts)
+	require.NoError(t, err)
+	require.NoError(t, b.Close())
+
+	_, err = b.Cardinality(testCardinalityOptions())
+	require.Equal(t, ErrUnableToQueryBlockClosed, err)
+}
+
+func TestBlockCardinalityActiveSegment(t *testing.T) {
+	testMD := newTestNSMetadata(t)
+	start := time.Now().Truncate(time.Hour)
+	blk, err := NewBlock(start, testMD, testOpts)
+	require.NoError(t, err)
+	b, ok := blk.(*block)
+	require.True(t, ok)
+
+	_, err = b.activeSegment.Insert(testCardinalityDoc("cpu.a", "cpu", "a"))
+	require.NoError(t, err)
+
+	res, err := b.Cardinality(testCardinalityOptions())
+	require.NoError(t, err)
+	require.Equal(t, start, res.BlockStart)
+	require.Equal(t, int64(1), res.NumSeries)
+	require.Equal(t, []CardinalityEntry{
+		{Name: []byte("cpu"), Count: 1},
+	}, res.MetricNames)
+}
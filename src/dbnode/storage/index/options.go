@@ -22,13 +22,19 @@ package index
 
 import (
 	"errors"
+	"math"
+	"runtime"
+	"time"
 
 	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/storage/index/compaction"
 	"github.com/m3db/m3/src/m3ninx/doc"
+	"github.com/m3db/m3/src/m3ninx/index/segment/fst"
 	"github.com/m3db/m3/src/m3ninx/index/segment/mem"
 	"github.com/m3db/m3x/ident"
 	"github.com/m3db/m3x/instrument"
 	"github.com/m3db/m3x/pool"
+	xsync "github.com/m3db/m3x/sync"
 )
 
 const (
@@ -45,10 +51,12 @@ const (
 )
 
 var (
-	errOptionsIdentifierPoolUnspecified = errors.New("identifier pool is unset")
-	errOptionsBytesPoolUnspecified      = errors.New("checkedbytes pool is unset")
-	errOptionsResultsPoolUnspecified    = errors.New("results pool is unset")
-	errIDGenerationDisabled             = errors.New("id generation is disabled")
+	errOptionsIdentifierPoolUnspecified  = errors.New("identifier pool is unset")
+	errOptionsBytesPoolUnspecified       = errors.New("checkedbytes pool is unset")
+	errOptionsResultsPoolUnspecified     = errors.New("results pool is unset")
+	errIDGenerationDisabled              = errors.New("id generation is disabled")
+	errOptionsCompactionIntervalNegative = errors.New("compaction interval is negative")
+	errOptionsCompactionPoolUnspecified  = errors.New("compaction worker pool is unset")
 )
 
 type opts struct {
@@ -60,6 +68,10 @@ type opts struct {
 	bytesPool      pool.CheckedBytesPool
 	resultsPool    ResultsPool
 	docArrayPool   doc.DocumentArrayPool
+	fstOpts        fst.Options
+	plannerOpts    compaction.PlannerOptions
+	compactEvery   time.Duration
+	compactWorkers xsync.WorkerPool
 }
 
 var undefinedUUIDFn = func() ([]byte, error) { return nil, errIDGenerationDisabled }
@@ -83,6 +95,11 @@ func NewOptions() Options {
 	})
 	docArrayPool.Init()
 
+	// Limit background compactions to a fraction of the cores so that
+	// compactions do not starve the write and query paths.
+	compactWorkers := xsync.NewWorkerPool(int(math.Ceil(float64(runtime.NumCPU()) / 4)))
+	compactWorkers.Init()
+
 	opts := &opts{
 		insertMode:     defaultIndexInsertMode,
 		clockOpts:      clock.NewOptions(),
@@ -92,6 +109,9 @@ func NewOptions() Options {
 		idPool:         idPool,
 		resultsPool:    resultsPool,
 		docArrayPool:   docArrayPool,
+		fstOpts:        fst.NewOptions(),
+		plannerOpts:    compaction.DefaultOptions,
+		compactWorkers: compactWorkers,
 	}
 	resultsPool.Init(func() Results { return NewResults(opts) })
 	return opts
@@ -107,7 +127,13 @@ func (o *opts) Validate() error {
 	if o.resultsPool == nil {
 		return errOptionsResultsPoolUnspecified
 	}
-	return nil
+	if o.compactEvery < 0 {
+		return errOptionsCompactionIntervalNegative
+	}
+	if o.compactWorkers == nil {
+		return errOptionsCompactionPoolUnspecified
+	}
+	return o.plannerOpts.Validate()
 }
 
 func (o *opts) SetInsertMode(value InsertMode) Options {
@@ -191,3 +217,43 @@ func (o *opts) SetDocumentArrayPool(value doc.DocumentArrayPool) Options {
 func (o *opts) DocumentArrayPool() doc.DocumentArrayPool {
 	return o.docArrayPool
 }
+
+func (o *opts) SetFSTSegmentOptions(value fst.Options) Options {
+	opts := *o
+	opts.fstOpts = value
+	return &opts
+}
+
+func (o *opts) FSTSegmentOptions() fst.Options {
+	return o.fstOpts
+}
+
+func (o *opts) SetCompactionPlannerOptions(value compaction.PlannerOptions) Options {
+	opts := *o
+	opts.plannerOpts = value
+	return &opts
+}
+
+func (o *opts) CompactionPlannerOptions() compaction.PlannerOptions {
+	return o.plannerOpts
+}
+
+func (o *opts) SetCompactionInterval(value time.Duration) Options {
+	opts := *o
+	opts.compactEvery = value
+	return &opts
+}
+
+func (o *opts) CompactionInterval() time.Duration {
+	return o.compactEvery
+}
+
+func (o *opts) SetCompactionWorkerPool(value xsync.WorkerPool) Options {
+	opts := *o
+	opts.compactWorkers = value
+	return &opts
+}
+
+func (o *opts) CompactionWorkerPool() xsync.WorkerPool {
+	return o.compactWorkers
+}
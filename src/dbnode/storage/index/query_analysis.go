@@ -0,0 +1,212 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package index
+
+import (
+	"regexp/syntax"
+
+	"github.com/m3db/m3/src/m3ninx/generated/proto/querypb"
+)
+
+// QueryTermType is the type of a term of a query.
+type QueryTermType int
+
+const (
+	// QueryTermTypeTerm matches series with a tag value exactly.
+	QueryTermTypeTerm QueryTermType = iota
+
+	// QueryTermTypeRegexp matches series with a tag value matching a regexp.
+	QueryTermTypeRegexp
+
+	// QueryTermTypeField matches series with a tag, regardless of its value.
+	QueryTermTypeField
+
+	// QueryTermTypeAll matches all series.
+	QueryTermTypeAll
+)
+
+func (t QueryTermType) String() string {
+	switch t {
+	case QueryTermTypeTerm:
+		return "term"
+	case QueryTermTypeRegexp:
+		return "regexp"
+	case QueryTermTypeField:
+		return "field"
+	case QueryTermTypeAll:
+		return "all"
+	}
+	return "unknown"
+}
+
+// QueryTerm is a term of a query, i.e. a query that matches series on its
+// own rather than by combining other queries.
+type QueryTerm struct {
+	Type  QueryTermType
+	Field []byte
+
+	// Pattern is the tag value or regexp matched by the term.
+	Pattern []byte
+
+	// Negated is whether the term is negated within the query.
+	Negated bool
+
+	// Unanchored is whether the term is a regexp starting with a wildcard,
+	// such a regexp has to be matched against every value of the tag.
+	Unanchored bool
+}
+
+// Positive returns whether the term only matches the series with a given
+// tag value or the values of a tag matching an anchored regexp.
+func (t QueryTerm) Positive() bool {
+	if t.Negated {
+		return false
+	}
+	switch t.Type {
+	case QueryTermTypeTerm:
+		return true
+	case QueryTermTypeRegexp:
+		return !t.Unanchored
+	}
+	return false
+}
+
+// QueryAnalysis is the analysis of a query.
+type QueryAnalysis struct {
+	// Terms are the terms of the query in the order they appear.
+	Terms []QueryTerm
+
+	// HasPositiveTerm is whether every series matched by the query has to
+	// match a positive term, queries without one have to scan the postings
+	// of every series in the index.
+	HasPositiveTerm bool
+}
+
+// AnalyzeQuery returns the terms of a query and whether the series it
+// matches are bounded by a positive term.
+func AnalyzeQuery(q Query) QueryAnalysis {
+	var a QueryAnalysis
+	if q.SearchQuery() == nil {
+		return a
+	}
+	a.HasPositiveTerm = a.add(q.SearchQuery().ToProto(), false)
+	return a
+}
+
+// add adds the terms of the query and returns whether the series matched by
+// the query, negated if specified, are bounded by a positive term.
+func (a *QueryAnalysis) add(q *querypb.Query, negated bool) bool {
+	switch q := q.GetQuery().(type) {
+	case *querypb.Query_Term:
+		return a.addTerm(QueryTerm{
+			Type:    QueryTermTypeTerm,
+			Field:   q.Term.Field,
+			Pattern: q.Term.Term,
+			Negated: negated,
+		})
+	case *querypb.Query_Regexp:
+		return a.addTerm(QueryTerm{
+			Type:       QueryTermTypeRegexp,
+			Field:      q.Regexp.Field,
+			Pattern:    q.Regexp.Regexp,
+			Negated:    negated,
+			Unanchored: regexpUnanchored(q.Regexp.Regexp),
+		})
+	case *querypb.Query_Field:
+		return a.addTerm(QueryTerm{
+			Type:    QueryTermTypeField,
+			Field:   q.Field.Field,
+			Negated: negated,
+		})
+	case *querypb.Query_All:
+		return a.addTerm(QueryTerm{
+			Type:    QueryTermTypeAll,
+			Negated: negated,
+		})
+	case *querypb.Query_Negation:
+		return a.add(q.Negation.Query, !negated)
+	case *querypb.Query_Conjunction:
+		// A negated conjunction is a disjunction of the negated queries.
+		return a.addAll(q.Conjunction.Queries, negated, !negated)
+	case *querypb.Query_Disjunction:
+		// A negated disjunction is a conjunction of the negated queries.
+		return a.addAll(q.Disjunction.Queries, negated, negated)
+	}
+	return false
+}
+
+func (a *QueryAnalysis) addTerm(t QueryTerm) bool {
+	a.Terms = append(a.Terms, t)
+	return t.Positive()
+}
+
+// addAll adds the terms of the queries and returns whether any of them are
+// bounded by a positive term if specified, otherwise whether all of them are.
+func (a *QueryAnalysis) addAll(qs []*querypb.Query, negated, anyPositive bool) bool {
+	if len(qs) == 0 {
+		return false
+	}
+	result := !anyPositive
+	for _, q := range qs {
+		positive := a.add(q, negated)
+		if anyPositive {
+			result = result || positive
+		} else {
+			result = result && positive
+		}
+	}
+	return result
+}
+
+// regexpUnanchored returns whether a regexp starts with a wildcard.
+func regexpUnanchored(pattern []byte) bool {
+	re, err := syntax.Parse(string(pattern), syntax.Perl)
+	if err != nil {
+		// NB: invalid regexps are rejected when the query is executed.
+		return false
+	}
+	re = re.Simplify()
+	for {
+		switch re.Op {
+		case syntax.OpCapture:
+			re = re.Sub[0]
+			continue
+		case syntax.OpConcat:
+			// Skip any leading assertions, every regexp is anchored.
+			first := -1
+			for i, sub := range re.Sub {
+				if sub.Op != syntax.OpBeginLine && sub.Op != syntax.OpBeginText {
+					first = i
+					break
+				}
+			}
+			if first < 0 {
+				return false
+			}
+			re = re.Sub[first]
+			continue
+		case syntax.OpStar, syntax.OpPlus:
+			sub := re.Sub[0]
+			return sub.Op == syntax.OpAnyChar || sub.Op == syntax.OpAnyCharNotNL
+		}
+		return false
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package index
+
+import (
+	"testing"
+
+	"github.com/m3db/m3/src/m3ninx/idx"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeQueryPositiveTerms(t *testing.T) {
+	term := idx.NewTermQuery([]byte("city"), []byte("sf"))
+	anchored := idx.MustCreateRegexpQuery([]byte("host"), []byte("web.*"))
+	unanchored := idx.MustCreateRegexpQuery([]byte("host"), []byte(".*web"))
+	field := idx.NewFieldQuery([]byte("dc"))
+
+	tests := []struct {
+		name     string
+		query    idx.Query
+		positive bool
+	}{
+		{name: "term", query: term, positive: true},
+		{name: "anchored regexp", query: anchored, positive: true},
+		{name: "unanchored regexp", query: unanchored, positive: false},
+		{name: "field", query: field, positive: false},
+		{name: "all", query: idx.NewAllQuery(), positive: false},
+		{name: "negation", query: idx.NewNegationQuery(term), positive: false},
+		{
+			name:     "double negation",
+			query:    idx.NewNegationQuery(idx.NewNegationQuery(term)),
+			positive: true,
+		},
+		{
+			name:     "conjunction with positive term",
+			query:    idx.NewConjunctionQuery(idx.NewNegationQuery(anchored), term),
+			positive: true,
+		},
+		{
+			name:     "conjunction of negations",
+			query:    idx.NewConjunctionQuery(idx.NewNegationQuery(term), idx.NewNegationQuery(anchored)),
+			positive: false,
+		},
+		{
+			name:     "disjunction of positive terms",
+			query:    idx.NewDisjunctionQuery(term, anchored),
+			positive: true,
+		},
+		{
+			name:     "disjunction with unanchored regexp",
+			query:    idx.NewDisjunctionQuery(term, unanchored),
+			positive: false,
+		},
+		{
+			name:     "negated disjunction",
+			query:    idx.NewNegationQuery(idx.NewDisjunctionQuery(term, anchored)),
+			positive: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := AnalyzeQuery(Query{Query: test.query})
+			require.Equal(t, test.positive, a.HasPositiveTerm)
+		})
+	}
+}
+
+func TestAnalyzeQueryTerms(t *testing.T) {
+	q := idx.NewConjunctionQuery(
+		idx.NewTermQuery([]byte("city"), []byte("sf")),
+		idx.NewNegationQuery(idx.MustCreateRegexpQuery([]byte("host"), []byte(".*web"))),
+	)
+
+	a := AnalyzeQuery(Query{Query: q})
+	require.True(t, a.HasPositiveTerm)
+	require.Equal(t, []QueryTerm{
+		{
+			Type:    QueryTermTypeTerm,
+			Field:   []byte("city"),
+			Pattern: []byte("sf"),
+		},
+		{
+			Type:       QueryTermTypeRegexp,
+			Field:      []byte("host"),
+			Pattern:    []byte(".*web"),
+			Negated:    true,
+			Unanchored: true,
+		},
+	}, a.Terms)
+}
+
+func TestAnalyzeQueryEmpty(t *testing.T) {
+	a := AnalyzeQuery(Query{})
+	require.False(t, a.HasPositiveTerm)
+	require.Equal(t, 0, len(a.Terms))
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package index
+
+import (
+	"time"
+
+	m3ninxindex "github.com/m3db/m3/src/m3ninx/index"
+	"github.com/m3db/m3/src/m3ninx/index/segment"
+	xerrors "github.com/m3db/m3x/errors"
+)
+
+// Postings returns the sum of the postings list sizes of the terms, i.e. the
+// approximate number of postings a query has to scan.
+func (r QueryCostResult) Postings() int64 {
+	var postings int64
+	for _, t := range r.Terms {
+		postings += t.Postings
+	}
+	return postings
+}
+
+const (
+	// queryCostMaxTerms is the max number of terms of a field walked per
+	// segment to estimate the postings of a regexp or field query term.
+	queryCostMaxTerms = 10000
+)
+
+// queryCostAggregator sums the postings list sizes of the terms of a query
+// for each segment of an index block. Regexp and field query terms are
+// estimated by walking the terms of their field rather than resolving them,
+// past the max terms they are estimated as matching every series of the
+// segment so estimating a query never costs as much as running it.
+type queryCostAggregator struct {
+	terms    []QueryTerm
+	regexps  []m3ninxindex.CompiledRegex
+	maxTerms int
+	postings []int64
+}
+
+func newQueryCostAggregator(
+	terms []QueryTerm,
+	maxTerms int,
+) (*queryCostAggregator, error) {
+	regexps := make([]m3ninxindex.CompiledRegex, len(terms))
+	for i, t := range terms {
+		if t.Type != QueryTermTypeRegexp {
+			continue
+		}
+		re, err := m3ninxindex.CompileRegex(t.Pattern)
+		if err != nil {
+			return nil, err
+		}
+		regexps[i] = re
+	}
+	return &queryCostAggregator{
+		terms:    terms,
+		regexps:  regexps,
+		maxTerms: maxTerms,
+		postings: make([]int64, len(terms)),
+	}, nil
+}
+
+func (a *queryCostAggregator) addSegment(seg segment.Segment) (err error) {
+	reader, err := seg.Reader()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = xerrors.FirstError(err, reader.Close())
+	}()
+
+	// NB: the terms of a mutable segment can only be iterated once it is
+	// sealed, the query terms of an unsealed one are resolved instead which
+	// is bounded by the size the active segment is rotated at.
+	walkable := true
+	if mutable, ok := seg.(segment.MutableSegment); ok && !mutable.IsSealed() {
+		walkable = false
+	}
+
+	for i, t := range a.terms {
+		var count int64
+		switch {
+		case t.Type == QueryTermTypeAll:
+			count = seg.Size()
+		case t.Type == QueryTermTypeTerm:
+			pl, err := reader.MatchTerm(t.Field, t.Pattern)
+			if err != nil {
+				return err
+			}
+			count = int64(pl.Len())
+		case walkable:
+			count, err = a.fieldPostings(seg, reader, i)
+			if err != nil {
+				return err
+			}
+		case t.Type == QueryTermTypeRegexp:
+			pl, err := reader.MatchRegexp(t.Field, a.regexps[i])
+			if err != nil {
+				return err
+			}
+			count = int64(pl.Len())
+		case t.Type == QueryTermTypeField:
+			pl, err := reader.MatchField(t.Field)
+			if err != nil {
+				return err
+			}
+			count = int64(pl.Len())
+		}
+		a.postings[i] += count
+	}
+	return nil
+}
+
+// fieldPostings sums the postings list sizes of the terms of the field of
+// the query term that it matches, since a series has a single value per
+// field this is the number of series it matches in the segment.
+func (a *queryCostAggregator) fieldPostings(
+	seg segment.Segment,
+	reader m3ninxindex.Reader,
+	i int,
+) (count int64, err error) {
+	t := a.terms[i]
+	terms, err := seg.Terms(t.Field)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		err = xerrors.FirstError(err, terms.Close())
+	}()
+
+	var numTerms int
+	for terms.Next() {
+		if numTerms >= a.maxTerms {
+			// Upper bound as the query term can match at most every series.
+			return seg.Size(), nil
+		}
+		numTerms++
+
+		term := terms.Current()
+		if t.Type == QueryTermTypeRegexp && !a.regexps[i].Simple.Match(term) {
+			continue
+		}
+		pl, err := reader.MatchTerm(t.Field, term)
+		if err != nil {
+			return 0, err
+		}
+		count += int64(pl.Len())
+	}
+	return count, terms.Err()
+}
+
+func (a *queryCostAggregator) result(blockStart time.Time) QueryCostResult {
+	terms := make([]QueryTermCost, 0, len(a.terms))
+	for i, t := range a.terms {
+		terms = append(terms, QueryTermCost{
+			Term:     t,
+			Postings: a.postings[i],
+		})
+	}
+	return QueryCostResult{
+		BlockStart: blockStart,
+		Terms:      terms,
+	}
+}
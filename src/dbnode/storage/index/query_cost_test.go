@@ -0,0 +1,144 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package index
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/m3ninx/idx"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryCostAggregatorSegments(t *testing.T) {
+	q := idx.NewConjunctionQuery(
+		idx.NewTermQuery([]byte("__name__"), []byte("cpu")),
+		idx.MustCreateRegexpQuery([]byte("host"), []byte(".*")),
+		idx.NewNegationQuery(idx.NewFieldQuery([]byte("dc"))),
+	)
+	analysis := AnalyzeQuery(Query{Query: q})
+
+	agg, err := newQueryCostAggregator(analysis.Terms, queryCostMaxTerms)
+	require.NoError(t, err)
+	require.NoError(t, agg.addSegment(testSealedSegment(t,
+		testCardinalityDoc("cpu.a", "cpu", "a"),
+		testCardinalityDoc("cpu.b", "cpu", "b"),
+		testCardinalityDoc("mem.a", "mem", "a"),
+	)))
+	require.NoError(t, agg.addSegment(testSegment(t,
+		testCardinalityDoc("cpu.c", "cpu", "c"),
+	)))
+
+	blockStart := time.Now().Truncate(time.Hour)
+	res := agg.result(blockStart)
+	require.Equal(t, blockStart, res.BlockStart)
+	require.Equal(t, []QueryTermCost{
+		{Term: analysis.Terms[0], Postings: 3},
+		{Term: analysis.Terms[1], Postings: 4},
+		{Term: analysis.Terms[2], Postings: 0},
+	}, res.Terms)
+	require.Equal(t, int64(7), res.Postings())
+}
+
+func TestQueryCostAggregatorMaxTerms(t *testing.T) {
+	terms := []QueryTerm{
+		{Type: QueryTermTypeRegexp, Field: []byte("host"), Pattern: []byte("a|b")},
+		{Type: QueryTermTypeField, Field: []byte("host")},
+		{Type: QueryTermTypeRegexp, Field: []byte("host"), Pattern: []byte("d")},
+	}
+
+	agg, err := newQueryCostAggregator(terms, 2)
+	require.NoError(t, err)
+	require.NoError(t, agg.addSegment(testSealedSegment(t,
+		testCardinalityDoc("cpu.a", "cpu", "a"),
+		testCardinalityDoc("cpu.b", "cpu", "b"),
+		testCardinalityDoc("cpu.c", "cpu", "c"),
+		testCardinalityDoc("cpu.d", "cpu", "d"),
+	)))
+
+	// Each query term has to walk more terms than the max terms so they
+	// are all estimated as matching every series.
+	res := agg.result(time.Time{})
+	require.Equal(t, []QueryTermCost{
+		{Term: terms[0], Postings: 4},
+		{Term: terms[1], Postings: 4},
+		{Term: terms[2], Postings: 4},
+	}, res.Terms)
+
+	agg, err = newQueryCostAggregator(terms, 4)
+	require.NoError(t, err)
+	require.NoError(t, agg.addSegment(testSealedSegment(t,
+		testCardinalityDoc("cpu.a", "cpu", "a"),
+		testCardinalityDoc("cpu.b", "cpu", "b"),
+		testCardinalityDoc("cpu.c", "cpu", "c"),
+		testCardinalityDoc("cpu.d", "cpu", "d"),
+	)))
+
+	res = agg.result(time.Time{})
+	require.Equal(t, []QueryTermCost{
+		{Term: terms[0], Postings: 2},
+		{Term: terms[1], Postings: 4},
+		{Term: terms[2], Postings: 1},
+	}, res.Terms)
+}
+
+func TestQueryCostAggregatorInvalidRegexp(t *testing.T) {
+	_, err := newQueryCostAggregator([]QueryTerm{
+		{Type: QueryTermTypeRegexp, Field: []byte("host"), Pattern: []byte("(")},
+	}, queryCostMaxTerms)
+	require.Error(t, err)
+}
+
+func TestBlockQueryCostAfterClose(t *testing.T) {
+	testMD := newTestNSMetadata(t)
+	start := time.Now().Truncate(time.Hour)
+	b, err := NewBlock(start, testMD, testOpts)
+	require.NoError(t, err)
+	require.NoError(t, b.Close())
+
+	_, err = b.QueryCost(nil)
+	require.Equal(t, ErrUnableToQueryBlockClosed, err)
+}
+
+func TestBlockQueryCostActiveSegment(t *testing.T) {
+	testMD := newTestNSMetadata(t)
+	start := time.Now().Truncate(time.Hour)
+	blk, err := NewBlock(start, testMD, testOpts)
+	require.NoError(t, err)
+	b, ok := blk.(*block)
+	require.True(t, ok)
+
+	_, err = b.activeSegment.Insert(testCardinalityDoc("cpu.a", "cpu", "a"))
+	require.NoError(t, err)
+
+	terms := []QueryTerm{
+		{Type: QueryTermTypeTerm, Field: []byte("host"), Pattern: []byte("a")},
+		{Type: QueryTermTypeTerm, Field: []byte("host"), Pattern: []byte("b")},
+	}
+	res, err := b.QueryCost(terms)
+	require.NoError(t, err)
+	require.Equal(t, start, res.BlockStart)
+	require.Equal(t, []QueryTermCost{
+		{Term: terms[0], Postings: 1},
+		{Term: terms[1], Postings: 0},
+	}, res.Terms)
+}
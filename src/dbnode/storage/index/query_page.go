@@ -0,0 +1,197 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package index
+
+import (
+	"bytes"
+	"container/heap"
+
+	"github.com/m3db/m3/src/m3ninx/doc"
+	m3ninxindex "github.com/m3db/m3/src/m3ninx/index"
+	"github.com/m3db/m3/src/m3ninx/index/segment"
+	"github.com/m3db/m3/src/m3ninx/postings"
+	"github.com/m3db/m3/src/m3ninx/search"
+)
+
+// queryPageSegment adds to the results the documents of the segment matching
+// the searcher with the smallest IDs after the page position, at most
+// pageSize of them. Segments that can iterate their IDs in order from the
+// page position stop once a page of matching documents is found, others
+// have every matching document compared against the page position.
+func queryPageSegment(
+	seg segment.Segment,
+	searcher search.Searcher,
+	after []byte,
+	pageSize int,
+	results Results,
+) error {
+	reader, err := seg.Reader()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	pl, err := searcher.Search(reader)
+	if err != nil {
+		return err
+	}
+	if pl.IsEmpty() {
+		return nil
+	}
+
+	if seekable, ok := seg.(segment.SeekableTermsIterable); ok {
+		return queryPageSeekable(seekable, reader, pl, after, pageSize, results)
+	}
+	return queryPageUnordered(reader, pl, after, pageSize, results)
+}
+
+func queryPageSeekable(
+	seg segment.SeekableTermsIterable,
+	reader m3ninxindex.Reader,
+	pl postings.List,
+	after []byte,
+	pageSize int,
+	results Results,
+) error {
+	ids, err := seg.TermsFrom(doc.IDReservedFieldName, after)
+	if err != nil {
+		return err
+	}
+	defer ids.Close()
+
+	found := 0
+	for found < pageSize && ids.Next() {
+		id := ids.Current()
+		if after != nil && bytes.Equal(id, after) {
+			continue
+		}
+
+		idPL, err := reader.MatchTerm(doc.IDReservedFieldName, id)
+		if err != nil {
+			return err
+		}
+		iter := idPL.Iterator()
+		for iter.Next() {
+			postingsID := iter.Current()
+			if !pl.Contains(postingsID) {
+				continue
+			}
+			d, err := reader.Doc(postingsID)
+			if err != nil {
+				iter.Close()
+				return err
+			}
+			if _, _, err := results.AddDocument(d); err != nil {
+				iter.Close()
+				return err
+			}
+			found++
+		}
+		if err := iter.Err(); err != nil {
+			iter.Close()
+			return err
+		}
+		if err := iter.Close(); err != nil {
+			return err
+		}
+	}
+	return ids.Err()
+}
+
+func queryPageUnordered(
+	reader m3ninxindex.Reader,
+	pl postings.List,
+	after []byte,
+	pageSize int,
+	results Results,
+) error {
+	var (
+		page pageEntries
+		iter = pl.Iterator()
+	)
+	for iter.Next() {
+		postingsID := iter.Current()
+		d, err := reader.Doc(postingsID)
+		if err != nil {
+			iter.Close()
+			return err
+		}
+		if after != nil && bytes.Compare(d.ID, after) <= 0 {
+			continue
+		}
+		if len(page) < pageSize {
+			heap.Push(&page, pageEntry{
+				id:         append([]byte(nil), d.ID...),
+				postingsID: postingsID,
+			})
+			continue
+		}
+		if bytes.Compare(d.ID, page[0].id) < 0 {
+			// Replace the largest ID in the page.
+			page[0] = pageEntry{
+				id:         append(page[0].id[:0], d.ID...),
+				postingsID: postingsID,
+			}
+			heap.Fix(&page, 0)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		iter.Close()
+		return err
+	}
+	if err := iter.Close(); err != nil {
+		return err
+	}
+
+	for _, entry := range page {
+		d, err := reader.Doc(entry.postingsID)
+		if err != nil {
+			return err
+		}
+		if _, _, err := results.AddDocument(d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type pageEntry struct {
+	id         []byte
+	postingsID postings.ID
+}
+
+// pageEntries is a max heap of page entries ordered by ID.
+type pageEntries []pageEntry
+
+func (p pageEntries) Len() int           { return len(p) }
+func (p pageEntries) Less(i, j int) bool { return bytes.Compare(p[i].id, p[j].id) > 0 }
+func (p pageEntries) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+
+func (p *pageEntries) Push(x interface{}) {
+	*p = append(*p, x.(pageEntry))
+}
+
+func (p *pageEntries) Pop() interface{} {
+	old := *p
+	entry := old[len(old)-1]
+	*p = old[:len(old)-1]
+	return entry
+}
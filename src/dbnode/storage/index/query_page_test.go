@@ -0,0 +1,106 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package index
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/m3db/m3/src/dbnode/storage/index/segments"
+	"github.com/m3db/m3/src/m3ninx/doc"
+	"github.com/m3db/m3/src/m3ninx/idx"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func testPageDoc(id, value string) doc.Document {
+	return doc.Document{
+		ID: []byte(id),
+		Fields: []doc.Field{
+			doc.Field{Name: []byte("bar"), Value: []byte(value)},
+		},
+	}
+}
+
+func TestBlockQueryPages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	b := newTestCompactBlock(t, newTestCompactPlannerOptions())
+	defer b.Close()
+
+	// Half of the series are in an FST segment and the rest in the active
+	// segment, interleaving their IDs.
+	var expected []string
+	for i := 0; i < 20; i++ {
+		id := fmt.Sprintf("series-%02d", i)
+		value := "baz"
+		if i%5 == 0 {
+			value = "qux"
+		} else {
+			expected = append(expected, id)
+		}
+		if i%2 == 0 {
+			testCompactBlockWrite(t, ctrl, b, testPageDoc(id, value))
+		}
+	}
+	require.NoError(t, b.compact())
+	require.Len(t, b.compactedSegments, 1)
+	require.Equal(t, segments.FSTType, b.compactedSegments[0].segmentType)
+	for i := 1; i < 20; i += 2 {
+		value := "baz"
+		if i%5 == 0 {
+			value = "qux"
+		}
+		testCompactBlockWrite(t, ctrl, b, testPageDoc(fmt.Sprintf("series-%02d", i), value))
+	}
+
+	q := idx.NewTermQuery([]byte("bar"), []byte("baz"))
+	const pageSize = 3
+	var (
+		after  []byte
+		actual []string
+	)
+	for {
+		results := NewResults(testOpts)
+		_, err := b.Query(Query{q}, QueryOptions{
+			PageSize:  pageSize + 1,
+			PageAfter: after,
+		}, results)
+		require.NoError(t, err)
+
+		var ids []string
+		for _, entry := range results.Map().Iter() {
+			ids = append(ids, entry.Key().String())
+		}
+		sort.Strings(ids)
+		if len(ids) <= pageSize {
+			actual = append(actual, ids...)
+			break
+		}
+		ids = ids[:pageSize]
+		actual = append(actual, ids...)
+		after = []byte(ids[len(ids)-1])
+	}
+	require.Equal(t, expected, actual)
+}
@@ -27,13 +27,16 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/clock"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
+	"github.com/m3db/m3/src/dbnode/storage/index/compaction"
 	"github.com/m3db/m3/src/m3ninx/doc"
 	"github.com/m3db/m3/src/m3ninx/idx"
+	"github.com/m3db/m3/src/m3ninx/index/segment/fst"
 	"github.com/m3db/m3/src/m3ninx/index/segment/mem"
 	"github.com/m3db/m3x/context"
 	"github.com/m3db/m3x/ident"
 	"github.com/m3db/m3x/instrument"
 	"github.com/m3db/m3x/pool"
+	xsync "github.com/m3db/m3x/sync"
 	xtime "github.com/m3db/m3x/time"
 )
 
@@ -62,6 +65,12 @@ type QueryOptions struct {
 	StartInclusive time.Time
 	EndExclusive   time.Time
 	Limit          int
+
+	// PageSize when positive restricts the results of each index block to
+	// the PageSize matching documents with the smallest IDs after PageAfter,
+	// each block walks its IDs in order from PageAfter where it can.
+	PageSize  int
+	PageAfter []byte
 }
 
 // LimitExceeded returns whether a given size exceeds the limit
@@ -70,6 +79,69 @@ func (o QueryOptions) LimitExceeded(size int) bool {
 	return o.Limit > 0 && size >= o.Limit
 }
 
+// CardinalityOptions enables users to specify the constraints of a
+// cardinality query.
+type CardinalityOptions struct {
+	StartInclusive time.Time
+	EndExclusive   time.Time
+
+	// NameTag is the tag whose values are the metric names.
+	NameTag []byte
+
+	// Limit is the number of entries returned for each ranking.
+	Limit int
+
+	// MaxTerms is the max number of terms inspected per index block, once
+	// reached the results of the block are not exhaustive.
+	MaxTerms int
+}
+
+// CardinalityResult is the cardinality of the series in an index block.
+// The series counts are an upper bound since a series can be present in
+// more than one segment of a block.
+type CardinalityResult struct {
+	BlockStart time.Time
+	NumSeries  int64
+
+	// MetricNames are the top metric names by series count.
+	MetricNames []CardinalityEntry
+
+	// TagNames are the top tag names by series count.
+	TagNames []CardinalityEntry
+
+	// TagValues are the top tag names by distinct value count.
+	TagValues []CardinalityEntry
+
+	Exhaustive bool
+}
+
+// CardinalityEntry is a name and the count of series or values for it.
+type CardinalityEntry struct {
+	Name  []byte
+	Count int64
+}
+
+// QueryCostOptions enables users to specify the constraints of a query cost
+// estimate.
+type QueryCostOptions struct {
+	StartInclusive time.Time
+	EndExclusive   time.Time
+}
+
+// QueryCostResult is the estimated cost of a query within an index block.
+type QueryCostResult struct {
+	BlockStart time.Time
+
+	// Terms are the postings list sizes of the terms of the query.
+	Terms []QueryTermCost
+}
+
+// QueryTermCost is the postings list size of a term of a query.
+type QueryTermCost struct {
+	Term     QueryTerm
+	Postings int64
+}
+
 // QueryResults is the collection of results for a query.
 type QueryResults struct {
 	Results    Results
@@ -161,6 +233,14 @@ type Block interface {
 		results Results,
 	) (exhaustive bool, err error)
 
+	// Cardinality returns the top metric names and tag names by series count
+	// and the top tag names by distinct value count within the block.
+	Cardinality(opts CardinalityOptions) (CardinalityResult, error)
+
+	// QueryCost returns the postings list sizes of the given query terms
+	// within the block.
+	QueryCost(terms []QueryTerm) (QueryCostResult, error)
+
 	// AddResults adds bootstrap results to the block, if c.
 	AddResults(results result.IndexBlock) error
 
@@ -192,14 +272,16 @@ type Block interface {
 
 // EvictMutableSegmentResults returns statistics about the EvictMutableSegments execution.
 type EvictMutableSegmentResults struct {
-	NumMutableSegments int64
-	NumDocs            int64
+	NumMutableSegments   int64
+	NumCompactedSegments int64
+	NumDocs              int64
 }
 
 // Add adds the provided results to the receiver.
 func (e *EvictMutableSegmentResults) Add(o EvictMutableSegmentResults) {
 	e.NumDocs += o.NumDocs
 	e.NumMutableSegments += o.NumMutableSegments
+	e.NumCompactedSegments += o.NumCompactedSegments
 }
 
 // WriteBatchResult returns statistics about the WriteBatch execution.
@@ -574,4 +656,36 @@ type Options interface {
 
 	// DocumentArrayPool returns the document array pool.
 	DocumentArrayPool() doc.DocumentArrayPool
+
+	// SetFSTSegmentOptions sets the fst segment options.
+	SetFSTSegmentOptions(value fst.Options) Options
+
+	// FSTSegmentOptions returns the fst segment options.
+	FSTSegmentOptions() fst.Options
+
+	// SetCompactionPlannerOptions sets the compaction planner options used
+	// to compact the mutable segments of open blocks.
+	SetCompactionPlannerOptions(value compaction.PlannerOptions) Options
+
+	// CompactionPlannerOptions returns the compaction planner options used
+	// to compact the mutable segments of open blocks.
+	CompactionPlannerOptions() compaction.PlannerOptions
+
+	// SetCompactionInterval sets the interval at which open blocks attempt
+	// to compact their segments in the background, each open block runs its
+	// own compaction loop. Zero, the default, disables compaction.
+	SetCompactionInterval(value time.Duration) Options
+
+	// CompactionInterval returns the interval at which open blocks attempt
+	// to compact their segments in the background, each open block runs its
+	// own compaction loop. Zero, the default, disables compaction.
+	CompactionInterval() time.Duration
+
+	// SetCompactionWorkerPool sets the worker pool shared by all blocks to
+	// limit the number of concurrent background compactions.
+	SetCompactionWorkerPool(value xsync.WorkerPool) Options
+
+	// CompactionWorkerPool returns the worker pool shared by all blocks to
+	// limit the number of concurrent background compactions.
+	CompactionWorkerPool() xsync.WorkerPool
 }
@@ -31,6 +31,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	"github.com/m3db/m3/src/m3ninx/doc"
+	"github.com/m3db/m3/src/m3ninx/idx"
 	"github.com/m3db/m3/src/m3ninx/index/segment"
 	"github.com/m3db/m3x/context"
 	"github.com/m3db/m3x/ident"
@@ -510,3 +511,148 @@ func TestNamespaceIndexBlockQuery(t *testing.T) {
 	_, err = idx.Query(ctx, q, qOpts)
 	require.NoError(t, err)
 }
+
+func TestNamespaceIndexBlockCardinality(t *testing.T) {
+	ctrl := gomock.NewController(xtest.Reporter{t})
+	defer ctrl.Finish()
+
+	retention := 2 * time.Hour
+	blockSize := time.Hour
+	now := time.Now().Truncate(blockSize).Add(10 * time.Minute)
+	t0 := now.Truncate(blockSize)
+	t0Nanos := xtime.ToUnixNano(t0)
+	t1 := t0.Add(1 * blockSize)
+	t1Nanos := xtime.ToUnixNano(t1)
+	t2 := t1.Add(1 * blockSize)
+	var nowLock sync.Mutex
+	nowFn := func() time.Time {
+		nowLock.Lock()
+		defer nowLock.Unlock()
+		return now
+	}
+	opts := testDatabaseOptions()
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(nowFn))
+
+	b0 := index.NewMockBlock(ctrl)
+	b0.EXPECT().StartTime().Return(t0).AnyTimes()
+	b0.EXPECT().EndTime().Return(t0.Add(blockSize)).AnyTimes()
+	b1 := index.NewMockBlock(ctrl)
+	b1.EXPECT().StartTime().Return(t1).AnyTimes()
+	b1.EXPECT().EndTime().Return(t1.Add(blockSize)).AnyTimes()
+	newBlockFn := func(ts time.Time, md namespace.Metadata, io index.Options) (index.Block, error) {
+		if ts.Equal(t0) {
+			return b0, nil
+		}
+		if ts.Equal(t1) {
+			return b1, nil
+		}
+		panic("should never get here")
+	}
+	md := testNamespaceMetadata(blockSize, retention)
+	idx, err := newNamespaceIndexWithNewBlockFn(md, newBlockFn, opts)
+	require.NoError(t, err)
+
+	seg1 := segment.NewMockSegment(ctrl)
+	seg2 := segment.NewMockSegment(ctrl)
+	bootstrapResults := result.IndexResults{
+		t0Nanos: result.NewIndexBlock(t0, []segment.Segment{seg1}, result.NewShardTimeRanges(t0, t1, 1, 2, 3)),
+		t1Nanos: result.NewIndexBlock(t1, []segment.Segment{seg2}, result.NewShardTimeRanges(t1, t2, 1, 2, 3)),
+	}
+
+	b0.EXPECT().AddResults(bootstrapResults[t0Nanos]).Return(nil)
+	b1.EXPECT().AddResults(bootstrapResults[t1Nanos]).Return(nil)
+	require.NoError(t, idx.Bootstrap(bootstrapResults))
+
+	ctx := context.NewContext()
+	cOpts := index.CardinalityOptions{
+		StartInclusive: t0,
+		EndExclusive:   t2,
+		NameTag:        index.DefaultCardinalityNameTag,
+		Limit:          index.DefaultCardinalityLimit,
+		MaxTerms:       index.DefaultCardinalityMaxTerms,
+	}
+
+	// newest block first, closed blocks are skipped
+	r1 := index.CardinalityResult{BlockStart: t1, NumSeries: 2, Exhaustive: true}
+	b1.EXPECT().Cardinality(cOpts).Return(r1, nil)
+	b0.EXPECT().Cardinality(cOpts).Return(index.CardinalityResult{}, index.ErrUnableToQueryBlockClosed)
+	results, err := idx.Cardinality(ctx, cOpts)
+	require.NoError(t, err)
+	require.Equal(t, []index.CardinalityResult{r1}, results)
+
+	// invalid options are rejected
+	cOpts.Limit = 0
+	_, err = idx.Cardinality(ctx, cOpts)
+	require.Error(t, err)
+}
+
+func TestNamespaceIndexBlockQueryCost(t *testing.T) {
+	ctrl := gomock.NewController(xtest.Reporter{t})
+	defer ctrl.Finish()
+
+	retention := 2 * time.Hour
+	blockSize := time.Hour
+	now := time.Now().Truncate(blockSize).Add(10 * time.Minute)
+	t0 := now.Truncate(blockSize)
+	t0Nanos := xtime.ToUnixNano(t0)
+	t1 := t0.Add(1 * blockSize)
+	t1Nanos := xtime.ToUnixNano(t1)
+	t2 := t1.Add(1 * blockSize)
+	var nowLock sync.Mutex
+	nowFn := func() time.Time {
+		nowLock.Lock()
+		defer nowLock.Unlock()
+		return now
+	}
+	opts := testDatabaseOptions()
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(nowFn))
+
+	b0 := index.NewMockBlock(ctrl)
+	b0.EXPECT().StartTime().Return(t0).AnyTimes()
+	b0.EXPECT().EndTime().Return(t0.Add(blockSize)).AnyTimes()
+	b1 := index.NewMockBlock(ctrl)
+	b1.EXPECT().StartTime().Return(t1).AnyTimes()
+	b1.EXPECT().EndTime().Return(t1.Add(blockSize)).AnyTimes()
+	newBlockFn := func(ts time.Time, md namespace.Metadata, io index.Options) (index.Block, error) {
+		if ts.Equal(t0) {
+			return b0, nil
+		}
+		if ts.Equal(t1) {
+			return b1, nil
+		}
+		panic("should never get here")
+	}
+	md := testNamespaceMetadata(blockSize, retention)
+	nsIdx, err := newNamespaceIndexWithNewBlockFn(md, newBlockFn, opts)
+	require.NoError(t, err)
+
+	seg1 := segment.NewMockSegment(ctrl)
+	seg2 := segment.NewMockSegment(ctrl)
+	bootstrapResults := result.IndexResults{
+		t0Nanos: result.NewIndexBlock(t0, []segment.Segment{seg1}, result.NewShardTimeRanges(t0, t1, 1, 2, 3)),
+		t1Nanos: result.NewIndexBlock(t1, []segment.Segment{seg2}, result.NewShardTimeRanges(t1, t2, 1, 2, 3)),
+	}
+
+	b0.EXPECT().AddResults(bootstrapResults[t0Nanos]).Return(nil)
+	b1.EXPECT().AddResults(bootstrapResults[t1Nanos]).Return(nil)
+	require.NoError(t, nsIdx.Bootstrap(bootstrapResults))
+
+	ctx := context.NewContext()
+	q := index.Query{Query: idx.NewTermQuery([]byte("foo"), []byte("bar"))}
+	terms := index.AnalyzeQuery(q).Terms
+	qOpts := index.QueryCostOptions{
+		StartInclusive: t0,
+		EndExclusive:   t2,
+	}
+
+	// newest block first, closed blocks are skipped
+	r1 := index.QueryCostResult{
+		BlockStart: t1,
+		Terms:      []index.QueryTermCost{{Term: terms[0], Postings: 2}},
+	}
+	b1.EXPECT().QueryCost(terms).Return(r1, nil)
+	b0.EXPECT().QueryCost(terms).Return(index.QueryCostResult{}, index.ErrUnableToQueryBlockClosed)
+	results, err := nsIdx.QueryCost(ctx, q, qOpts)
+	require.NoError(t, err)
+	require.Equal(t, []index.QueryCostResult{r1}, results)
+}
@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/persist/fs/backup"
 	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
 
 	"github.com/uber-go/tally"
@@ -170,6 +171,17 @@ func (m *mediator) Tick(runType runType, forceType forceType) error {
 	return nil
 }
 
+func (m *mediator) Backup(destinationPath string) (backup.Manifest, error) {
+	// NB: file operations are disabled while backing up so that the filesets
+	// being copied are not concurrently flushed or cleaned up. Disabling is
+	// reference counted so a backup that fails fast never enables file
+	// operations while a bootstrap or another backup relies on them being off.
+	m.DisableFileOps()
+	defer m.EnableFileOps()
+
+	return m.databaseFileSystemManager.Backup(m.nowFn(), m.database.BootstrapState(), destinationPath)
+}
+
 func (m *mediator) Report() {
 	m.databaseBootstrapManager.Report()
 	m.databaseRepairer.Report()
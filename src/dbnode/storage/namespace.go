@@ -29,6 +29,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/sharding"
@@ -40,6 +41,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/series"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/dbnode/x/xio"
+	xclose "github.com/m3db/m3x/close"
 	"github.com/m3db/m3x/context"
 	xerrors "github.com/m3db/m3x/errors"
 	"github.com/m3db/m3x/ident"
@@ -54,6 +56,7 @@ import (
 var (
 	errNamespaceAlreadyClosed    = errors.New("namespace already closed")
 	errNamespaceIndexingDisabled = errors.New("namespace indexing is disabled")
+	errNamespaceUpdateNotLive    = errors.New("only the retention period of a namespace can be updated while it is running")
 )
 
 type commitLogWriter interface {
@@ -124,6 +127,13 @@ type dbNamespace struct {
 	tickWorkersConcurrency int
 	statsLastTick          databaseNamespaceStatsLastTick
 
+	writeQuota             *namespaceWriteQuota
+	writeQuotaListenCloser xclose.SimpleCloser
+
+	// wiredList is set when the namespace has a wired list byte budget of its
+	// own rather than sharing the database wired list.
+	wiredList *block.WiredList
+
 	metrics databaseNamespaceMetrics
 }
 
@@ -299,17 +309,29 @@ func newDatabaseNamespace(
 	tickWorkers.Init()
 
 	seriesOpts := NewSeriesOptionsFromOptions(opts, nopts.RetentionOptions()).
-		SetStats(series.NewStats(scope))
+		SetStats(series.NewStats(scope)).
+		SetDuplicatePolicy(nopts.DuplicatePolicy())
+	schema, err := nopts.SchemaOptions().Schema()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"unable to create namespace %v, invalid schema: %v",
+			metadata.ID().String(), err)
+	}
+	if schema != nil {
+		seriesOpts = newSchemaSeriesOptions(seriesOpts, schema, opts)
+	}
+	wiredList := newNamespaceWiredList(id, opts)
+	if wiredList != nil {
+		seriesOpts = seriesOpts.SetDatabaseBlockOptions(
+			seriesOpts.DatabaseBlockOptions().SetWiredList(wiredList))
+	}
 	if err := seriesOpts.Validate(); err != nil {
 		return nil, fmt.Errorf(
 			"unable to create namespace %v, invalid series options: %v",
 			metadata.ID().String(), err)
 	}
 
-	var (
-		index namespaceIndex
-		err   error
-	)
+	var index namespaceIndex
 	if metadata.Options().IndexOptions().Enabled() {
 		index, err = newNamespaceIndex(metadata, opts)
 		if err != nil {
@@ -335,8 +357,18 @@ func newDatabaseNamespace(
 		reverseIndex:           index,
 		tickWorkers:            tickWorkers,
 		tickWorkersConcurrency: tickWorkersConcurrency,
+		writeQuota:             newNamespaceWriteQuota(id, opts.ClockOptions().NowFn(), scope),
+		wiredList:              wiredList,
 		metrics:                newDatabaseNamespaceMetrics(scope, iops.MetricsSamplingRate()),
 	}
+	n.writeQuotaListenCloser = opts.RuntimeOptionsManager().
+		RegisterListener(n.writeQuota)
+
+	if wiredList != nil {
+		if err := wiredList.Start(); err != nil {
+			return nil, err
+		}
+	}
 
 	n.initShards(nopts.BootstrapEnabled())
 	go n.reportStatusLoop()
@@ -344,6 +376,29 @@ func newDatabaseNamespace(
 	return n, nil
 }
 
+// newNamespaceWiredList returns a wired list owned by the namespace if it has
+// a max wired bytes budget of its own and the database is using a wired list.
+func newNamespaceWiredList(id ident.ID, opts Options) *block.WiredList {
+	maxBytes, ok := opts.NamespaceWiredListMaxBytes()[id.String()]
+	if !ok || maxBytes <= 0 {
+		return nil
+	}
+	dbWiredList := opts.DatabaseBlockOptions().WiredList()
+	if dbWiredList == nil {
+		// Not using the LRU series cache policy.
+		return nil
+	}
+
+	wiredListOpts := dbWiredList.Options()
+	wiredListOpts.MaxBytes = maxBytes
+	iopts := wiredListOpts.InstrumentOptions
+	wiredListOpts.InstrumentOptions = iopts.SetMetricsScope(
+		iopts.MetricsScope().Tagged(map[string]string{
+			"namespace": id.String(),
+		}))
+	return block.NewWiredList(wiredListOpts)
+}
+
 func (n *dbNamespace) reportStatusLoop() {
 	reportInterval := n.opts.InstrumentOptions().ReportInterval()
 	ticker := time.NewTicker(reportInterval)
@@ -364,8 +419,18 @@ func (n *dbNamespace) reportStatusLoop() {
 	}
 }
 
+func (n *dbNamespace) MultiReaderIteratorPool() encoding.MultiReaderIteratorPool {
+	n.RLock()
+	pool := n.seriesOpts.MultiReaderIteratorPool()
+	n.RUnlock()
+	return pool
+}
+
 func (n *dbNamespace) Options() namespace.Options {
-	return n.nopts
+	n.RLock()
+	nopts := n.nopts
+	n.RUnlock()
+	return nopts
 }
 
 func (n *dbNamespace) ID() ident.ID {
@@ -420,7 +485,7 @@ func (n *dbNamespace) AssignShardSet(shardSet sharding.ShardSet) {
 			bootstrapEnabled := n.nopts.BootstrapEnabled()
 			n.shards[shard] = newDatabaseShard(n.metadata, shard, n.blockRetriever,
 				n.namespaceReaderMgr, n.increasingIndex, n.reverseIndex,
-				bootstrapEnabled, n.opts, n.seriesOpts)
+				n.writeQuota, bootstrapEnabled, n.opts, n.seriesOpts)
 			n.metrics.shards.add.Inc(1)
 		}
 	}
@@ -428,6 +493,39 @@ func (n *dbNamespace) AssignShardSet(shardSet sharding.ShardSet) {
 	n.closeShards(closing, false)
 }
 
+func (n *dbNamespace) UpdateOptions(opts namespace.Options) error {
+	metadata, err := namespace.NewMetadata(n.id, opts)
+	if err != nil {
+		return err
+	}
+
+	n.Lock()
+	if !namespace.IsLiveUpdate(n.nopts, opts) {
+		n.Unlock()
+		return errNamespaceUpdateNotLive
+	}
+	seriesOpts := n.seriesOpts.SetRetentionOptions(opts.RetentionOptions())
+	n.metadata = metadata
+	n.nopts = opts
+	n.seriesOpts = seriesOpts
+	shards := make([]databaseShard, 0, len(n.shards))
+	for _, shard := range n.shards {
+		if shard != nil {
+			shards = append(shards, shard)
+		}
+	}
+	n.Unlock()
+
+	// Shards assigned from this point on are created with the updated options.
+	for _, shard := range shards {
+		shard.UpdateSeriesOptions(seriesOpts)
+	}
+	if n.reverseIndex != nil {
+		n.reverseIndex.SetRetentionPeriod(opts.RetentionOptions().RetentionPeriod())
+	}
+	return nil
+}
+
 func (n *dbNamespace) closeShards(shards []databaseShard, blockUntilClosed bool) {
 	var wg sync.WaitGroup
 	// NB(r): There is a shard close deadline that controls how fast each
@@ -529,6 +627,8 @@ func (n *dbNamespace) Tick(c context.Cancellable, tickStart time.Time) error {
 	}
 	n.statsLastTick.Unlock()
 
+	n.writeQuota.SetNumSeries(int64(r.activeSeries))
+
 	n.metrics.tick.activeSeries.Update(float64(r.activeSeries))
 	n.metrics.tick.expiredSeries.Inc(int64(r.expiredSeries))
 	n.metrics.tick.activeBlocks.Update(float64(r.activeBlocks))
@@ -558,6 +658,10 @@ func (n *dbNamespace) Write(
 	annotation []byte,
 ) (ts.Series, error) {
 	callStart := n.nowFn()
+	if err := n.writeQuota.AllowWrite(); err != nil {
+		n.metrics.write.ReportError(n.nowFn().Sub(callStart))
+		return ts.Series{}, err
+	}
 	shard, err := n.shardFor(id)
 	if err != nil {
 		n.metrics.write.ReportError(n.nowFn().Sub(callStart))
@@ -582,6 +686,10 @@ func (n *dbNamespace) WriteTagged(
 		n.metrics.writeTagged.ReportError(n.nowFn().Sub(callStart))
 		return ts.Series{}, errNamespaceIndexingDisabled
 	}
+	if err := n.writeQuota.AllowWrite(); err != nil {
+		n.metrics.writeTagged.ReportError(n.nowFn().Sub(callStart))
+		return ts.Series{}, err
+	}
 	shard, err := n.shardFor(id)
 	if err != nil {
 		n.metrics.writeTagged.ReportError(n.nowFn().Sub(callStart))
@@ -607,6 +715,27 @@ func (n *dbNamespace) QueryIDs(
 	return res, err
 }
 
+func (n *dbNamespace) Cardinality(
+	ctx context.Context,
+	opts index.CardinalityOptions,
+) ([]index.CardinalityResult, error) {
+	if n.reverseIndex == nil {
+		return nil, errNamespaceIndexingDisabled
+	}
+	return n.reverseIndex.Cardinality(ctx, opts)
+}
+
+func (n *dbNamespace) QueryCost(
+	ctx context.Context,
+	query index.Query,
+	opts index.QueryCostOptions,
+) ([]index.QueryCostResult, error) {
+	if n.reverseIndex == nil {
+		return nil, errNamespaceIndexingDisabled
+	}
+	return n.reverseIndex.QueryCost(ctx, query, opts)
+}
+
 func (n *dbNamespace) ReadEncoded(
 	ctx context.Context,
 	id ident.ID,
@@ -688,7 +817,11 @@ func (n *dbNamespace) Bootstrap(start time.Time, process bootstrap.Process) erro
 		n.metrics.bootstrapEnd.Inc(1)
 	}()
 
-	if !n.nopts.BootstrapEnabled() {
+	n.RLock()
+	metadata := n.metadata
+	n.RUnlock()
+
+	if !metadata.Options().BootstrapEnabled() {
 		success = true
 		n.metrics.bootstrap.ReportSuccess(n.nowFn().Sub(callStart))
 		return nil
@@ -714,7 +847,7 @@ func (n *dbNamespace) Bootstrap(start time.Time, process bootstrap.Process) erro
 		shardIDs[i] = shard.ID()
 	}
 
-	bootstrapResult, err := process.Run(start, n.metadata, shardIDs)
+	bootstrapResult, err := process.Run(start, metadata, shardIDs)
 	if err != nil {
 		n.log.Errorf("bootstrap for namespace %s aborted due to error: %v",
 			n.id.String(), err)
@@ -805,13 +938,14 @@ func (n *dbNamespace) Flush(
 	}
 	n.RUnlock()
 
-	if !n.nopts.FlushEnabled() {
+	nopts := n.Options()
+	if !nopts.FlushEnabled() {
 		n.metrics.flush.ReportSuccess(n.nowFn().Sub(callStart))
 		return nil
 	}
 
 	// check if blockStart is aligned with the namespace's retention options
-	bs := n.nopts.RetentionOptions().BlockSize()
+	bs := nopts.RetentionOptions().BlockSize()
 	if t := blockStart.Truncate(bs); !blockStart.Equal(t) {
 		return fmt.Errorf("failed to flush at time %v, not aligned to blockSize", blockStart.String())
 	}
@@ -867,7 +1001,7 @@ func (n *dbNamespace) FlushIndex(
 	}
 	n.RUnlock()
 
-	if !n.nopts.FlushEnabled() || !n.nopts.IndexOptions().Enabled() {
+	if nopts := n.Options(); !nopts.FlushEnabled() || !nopts.IndexOptions().Enabled() {
 		n.metrics.flush.ReportSuccess(n.nowFn().Sub(callStart))
 		return nil
 	}
@@ -894,7 +1028,7 @@ func (n *dbNamespace) Snapshot(
 	}
 	n.RUnlock()
 
-	if !n.nopts.SnapshotEnabled() {
+	if !n.Options().SnapshotEnabled() {
 		n.metrics.snapshot.ReportSuccess(n.nowFn().Sub(callStart))
 		return nil
 	}
@@ -952,7 +1086,7 @@ func (n *dbNamespace) NeedsFlush(
 func (n *dbNamespace) IsCapturedBySnapshot(
 	alignedInclusiveStart, alignedInclusiveEnd, capturedUpTo time.Time) (bool, error) {
 	var (
-		blockSize      = n.nopts.RetentionOptions().BlockSize()
+		blockSize      = n.Options().RetentionOptions().BlockSize()
 		blockStarts    = timesInRange(alignedInclusiveStart, alignedInclusiveEnd, blockSize)
 		filePathPrefix = n.opts.CommitLogOptions().FilesystemOptions().FilePathPrefix()
 	)
@@ -1045,7 +1179,7 @@ func (n *dbNamespace) Repair(
 	repairer databaseShardRepairer,
 	tr xtime.Range,
 ) error {
-	if !n.nopts.RepairEnabled() {
+	if !n.Options().RepairEnabled() {
 		return nil
 	}
 
@@ -1197,7 +1331,7 @@ func (n *dbNamespace) initShards(needBootstrap bool) {
 	for _, shard := range shards {
 		dbShards[shard] = newDatabaseShard(n.metadata, shard, n.blockRetriever,
 			n.namespaceReaderMgr, n.increasingIndex, n.reverseIndex,
-			needBootstrap, n.opts, n.seriesOpts)
+			n.writeQuota, needBootstrap, n.opts, n.seriesOpts)
 	}
 	n.shards = dbShards
 	n.Unlock()
@@ -1214,9 +1348,15 @@ func (n *dbNamespace) Close() error {
 	n.shards = shards[:0]
 	n.shardSet = sharding.NewEmptyShardSet(sharding.DefaultHashFn(1))
 	n.Unlock()
+	n.writeQuotaListenCloser.Close()
 	n.namespaceReaderMgr.close()
 	n.closeShards(shards, true)
 	close(n.shutdownCh)
+	if n.wiredList != nil {
+		if err := n.wiredList.Close(); err != nil {
+			n.log.Errorf("error closing namespace wired list: %v", err)
+		}
+	}
 	if n.reverseIndex != nil {
 		return n.reverseIndex.Close()
 	}
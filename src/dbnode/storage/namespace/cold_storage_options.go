@@ -0,0 +1,72 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package namespace
+
+import (
+	"time"
+)
+
+var (
+	// defaultColdStorageEnabled disables offloading to cold storage by default.
+	defaultColdStorageEnabled = false
+
+	// defaultColdStorageOffloadAfter is the default age after which flushed
+	// blocks are offloaded to cold storage.
+	defaultColdStorageOffloadAfter = 7 * 24 * time.Hour
+)
+
+type coldStorageOpts struct {
+	enabled      bool
+	offloadAfter time.Duration
+}
+
+// NewColdStorageOptions returns a new ColdStorageOptions.
+func NewColdStorageOptions() ColdStorageOptions {
+	return &coldStorageOpts{
+		enabled:      defaultColdStorageEnabled,
+		offloadAfter: defaultColdStorageOffloadAfter,
+	}
+}
+
+func (c *coldStorageOpts) Equal(value ColdStorageOptions) bool {
+	return c.Enabled() == value.Enabled() &&
+		c.OffloadAfter() == value.OffloadAfter()
+}
+
+func (c *coldStorageOpts) SetEnabled(value bool) ColdStorageOptions {
+	co := *c
+	co.enabled = value
+	return &co
+}
+
+func (c *coldStorageOpts) Enabled() bool {
+	return c.enabled
+}
+
+func (c *coldStorageOpts) SetOffloadAfter(value time.Duration) ColdStorageOptions {
+	co := *c
+	co.offloadAfter = value
+	return &co
+}
+
+func (c *coldStorageOpts) OffloadAfter() time.Duration {
+	return c.offloadAfter
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package namespace
+
+import (
+	"time"
+)
+
+var (
+	// defaultCompactionEnabled disables compacting flushed filesets by default.
+	defaultCompactionEnabled = false
+
+	// defaultCompactionBlockSize is the default block size that flushed
+	// filesets are compacted into.
+	defaultCompactionBlockSize = 24 * time.Hour
+
+	// defaultCompactionCompactAfter is the default age after which flushed
+	// blocks are compacted.
+	defaultCompactionCompactAfter = 7 * 24 * time.Hour
+)
+
+type compactionOpts struct {
+	enabled      bool
+	blockSize    time.Duration
+	compactAfter time.Duration
+}
+
+// NewCompactionOptions returns a new CompactionOptions.
+func NewCompactionOptions() CompactionOptions {
+	return &compactionOpts{
+		enabled:      defaultCompactionEnabled,
+		blockSize:    defaultCompactionBlockSize,
+		compactAfter: defaultCompactionCompactAfter,
+	}
+}
+
+func (c *compactionOpts) Equal(value CompactionOptions) bool {
+	return c.Enabled() == value.Enabled() &&
+		c.BlockSize() == value.BlockSize() &&
+		c.CompactAfter() == value.CompactAfter()
+}
+
+func (c *compactionOpts) SetEnabled(value bool) CompactionOptions {
+	co := *c
+	co.enabled = value
+	return &co
+}
+
+func (c *compactionOpts) Enabled() bool {
+	return c.enabled
+}
+
+func (c *compactionOpts) SetBlockSize(value time.Duration) CompactionOptions {
+	co := *c
+	co.blockSize = value
+	return &co
+}
+
+func (c *compactionOpts) BlockSize() time.Duration {
+	return c.blockSize
+}
+
+func (c *compactionOpts) SetCompactAfter(value time.Duration) CompactionOptions {
+	co := *c
+	co.compactAfter = value
+	return &co
+}
+
+func (c *compactionOpts) CompactAfter() time.Duration {
+	return c.compactAfter
+}
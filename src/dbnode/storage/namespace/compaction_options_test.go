@@ -0,0 +1,54 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package namespace
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompactionOptionsEqual(t *testing.T) {
+	opts := NewCompactionOptions()
+	require.True(t, opts.Equal(opts.SetEnabled(false)))
+	require.False(t, opts.SetEnabled(true).Equal(opts.SetEnabled(false)))
+	require.False(t, opts.SetBlockSize(time.Hour).Equal(
+		opts.SetBlockSize(time.Hour*2)))
+	require.False(t, opts.SetCompactAfter(time.Hour).Equal(
+		opts.SetCompactAfter(time.Hour*2)))
+}
+
+func TestCompactionOptionsEnabled(t *testing.T) {
+	opts := NewCompactionOptions()
+	require.True(t, opts.SetEnabled(true).Enabled())
+	require.False(t, opts.SetEnabled(false).Enabled())
+}
+
+func TestCompactionOptionsBlockSize(t *testing.T) {
+	opts := NewCompactionOptions()
+	require.Equal(t, time.Hour, opts.SetBlockSize(time.Hour).BlockSize())
+}
+
+func TestCompactionOptionsCompactAfter(t *testing.T) {
+	opts := NewCompactionOptions()
+	require.Equal(t, time.Hour, opts.SetCompactAfter(time.Hour).CompactAfter())
+}
@@ -22,6 +22,7 @@ package namespace
 
 import (
 	"fmt"
+	"io/ioutil"
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/retention"
@@ -48,23 +49,35 @@ func (m *MapConfiguration) Map() (Map, error) {
 
 // MetadataConfiguration is the configuration for a single namespace
 type MetadataConfiguration struct {
-	ID                string                  `yaml:"id" validate:"nonzero"`
-	BootstrapEnabled  *bool                   `yaml:"bootstrapEnabled"`
-	FlushEnabled      *bool                   `yaml:"flushEnabled"`
-	WritesToCommitLog *bool                   `yaml:"writesToCommitLog"`
-	CleanupEnabled    *bool                   `yaml:"cleanupEnabled"`
-	RepairEnabled     *bool                   `yaml:"repairEnabled"`
-	Retention         retention.Configuration `yaml:"retention" validate:"nonzero"`
-	Index             IndexConfiguration      `yaml:"index"`
+	ID                string                   `yaml:"id" validate:"nonzero"`
+	BootstrapEnabled  *bool                    `yaml:"bootstrapEnabled"`
+	FlushEnabled      *bool                    `yaml:"flushEnabled"`
+	WritesToCommitLog *bool                    `yaml:"writesToCommitLog"`
+	CleanupEnabled    *bool                    `yaml:"cleanupEnabled"`
+	RepairEnabled     *bool                    `yaml:"repairEnabled"`
+	Retention         retention.Configuration  `yaml:"retention" validate:"nonzero"`
+	Index             IndexConfiguration       `yaml:"index"`
+	ColdStorage       ColdStorageConfiguration `yaml:"coldStorage"`
+	Schema            SchemaConfiguration      `yaml:"schema"`
+	Compaction        CompactionConfiguration  `yaml:"compaction"`
+	DuplicatePolicy   *DuplicatePolicy         `yaml:"duplicatePolicy"`
 }
 
 // Metadata returns a Metadata corresponding to the receiver struct
 func (mc *MetadataConfiguration) Metadata() (Metadata, error) {
 	iopts := mc.Index.Options()
+	copts := mc.ColdStorage.Options()
 	ropts := mc.Retention.Options()
+	sopts, err := mc.Schema.Options()
+	if err != nil {
+		return nil, err
+	}
 	opts := NewOptions().
 		SetRetentionOptions(ropts).
-		SetIndexOptions(iopts)
+		SetIndexOptions(iopts).
+		SetColdStorageOptions(copts).
+		SetSchemaOptions(sopts).
+		SetCompactionOptions(mc.Compaction.Options())
 	if v := mc.BootstrapEnabled; v != nil {
 		opts = opts.SetBootstrapEnabled(*v)
 	}
@@ -80,6 +93,9 @@ func (mc *MetadataConfiguration) Metadata() (Metadata, error) {
 	if v := mc.RepairEnabled; v != nil {
 		opts = opts.SetRepairEnabled(*v)
 	}
+	if v := mc.DuplicatePolicy; v != nil {
+		opts = opts.SetDuplicatePolicy(*v)
+	}
 	return NewMetadata(ident.StringID(mc.ID), opts)
 }
 
@@ -95,3 +111,66 @@ func (ic *IndexConfiguration) Options() IndexOptions {
 		SetEnabled(ic.Enabled).
 		SetBlockSize(ic.BlockSize)
 }
+
+// ColdStorageConfiguration controls the knobs to tweak offloading of flushed
+// filesets to cold storage.
+type ColdStorageConfiguration struct {
+	Enabled      bool          `yaml:"enabled"`
+	OffloadAfter time.Duration `yaml:"offloadAfter"`
+}
+
+// Options returns the ColdStorageOptions corresponding to the receiver struct.
+func (cc *ColdStorageConfiguration) Options() ColdStorageOptions {
+	opts := NewColdStorageOptions().SetEnabled(cc.Enabled)
+	if cc.OffloadAfter > 0 {
+		opts = opts.SetOffloadAfter(cc.OffloadAfter)
+	}
+	return opts
+}
+
+// CompactionConfiguration controls the knobs to tweak compaction of flushed
+// filesets into filesets of a larger block size.
+type CompactionConfiguration struct {
+	Enabled      bool          `yaml:"enabled"`
+	BlockSize    time.Duration `yaml:"blockSize"`
+	CompactAfter time.Duration `yaml:"compactAfter"`
+}
+
+// Options returns the CompactionOptions corresponding to the receiver struct.
+func (cc *CompactionConfiguration) Options() CompactionOptions {
+	opts := NewCompactionOptions().SetEnabled(cc.Enabled)
+	if cc.BlockSize > 0 {
+		opts = opts.SetBlockSize(cc.BlockSize)
+	}
+	if cc.CompactAfter > 0 {
+		opts = opts.SetCompactAfter(cc.CompactAfter)
+	}
+	return opts
+}
+
+// SchemaConfiguration declares the protobuf schema of the values written to
+// a namespace.
+type SchemaConfiguration struct {
+	// FileDescriptorSetPath is the path to a serialized FileDescriptorSet,
+	// as output by protoc --descriptor_set_out, containing the message.
+	FileDescriptorSetPath string `yaml:"fileDescriptorSetPath"`
+
+	// MessageName is the fully qualified name of the message.
+	MessageName string `yaml:"messageName"`
+}
+
+// Options returns the SchemaOptions corresponding to the receiver struct.
+func (sc *SchemaConfiguration) Options() (SchemaOptions, error) {
+	opts := NewSchemaOptions().SetMessageName(sc.MessageName)
+	if sc.FileDescriptorSetPath != "" {
+		fds, err := ioutil.ReadFile(sc.FileDescriptorSetPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read schema file descriptor set: %v", err)
+		}
+		opts = opts.SetFileDescriptorSet(fds)
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
@@ -101,7 +101,17 @@ func TestMetadataConfig(t *testing.T) {
 			Enabled:   true,
 			BlockSize: time.Hour,
 		}
-		config = &MetadataConfiguration{
+		coldStorage = ColdStorageConfiguration{
+			Enabled:      false,
+			OffloadAfter: 30 * time.Minute,
+		}
+		compaction = CompactionConfiguration{
+			Enabled:      false,
+			BlockSize:    4 * time.Hour,
+			CompactAfter: 30 * time.Minute,
+		}
+		duplicatePolicy = DuplicatePolicyMinValue
+		config          = &MetadataConfiguration{
 			ID:                id,
 			BootstrapEnabled:  &bootstrapEnabled,
 			FlushEnabled:      &flushEnabled,
@@ -110,6 +120,9 @@ func TestMetadataConfig(t *testing.T) {
 			RepairEnabled:     &repairEnabled,
 			Retention:         retention,
 			Index:             index,
+			ColdStorage:       coldStorage,
+			Compaction:        compaction,
+			DuplicatePolicy:   &duplicatePolicy,
 		}
 	)
 
@@ -125,6 +138,9 @@ func TestMetadataConfig(t *testing.T) {
 	require.Equal(t, repairEnabled, opts.RepairEnabled())
 	require.Equal(t, retention.Options(), opts.RetentionOptions())
 	require.Equal(t, index.Options(), opts.IndexOptions())
+	require.Equal(t, coldStorage.Options(), opts.ColdStorageOptions())
+	require.Equal(t, compaction.Options(), opts.CompactionOptions())
+	require.Equal(t, duplicatePolicy, opts.DuplicatePolicy())
 }
 
 func TestRegistryConfigFromBytes(t *testing.T) {
@@ -78,6 +78,62 @@ func ToIndexOptions(
 	return iopts, nil
 }
 
+// ToColdStorageOptions converts nsproto.ColdStorageOptions to ColdStorageOptions
+func ToColdStorageOptions(
+	co *nsproto.ColdStorageOptions,
+) (ColdStorageOptions, error) {
+	copts := NewColdStorageOptions().SetEnabled(false)
+	if co == nil {
+		return copts, nil
+	}
+
+	copts = copts.SetEnabled(co.Enabled).
+		SetOffloadAfter(fromNanos(co.OffloadAfterNanos))
+
+	return copts, nil
+}
+
+// ToCompactionOptions converts nsproto.CompactionOptions to CompactionOptions
+func ToCompactionOptions(
+	co *nsproto.CompactionOptions,
+) (CompactionOptions, error) {
+	copts := NewCompactionOptions().SetEnabled(false)
+	if co == nil {
+		return copts, nil
+	}
+
+	copts = copts.SetEnabled(co.Enabled).
+		SetBlockSize(fromNanos(co.BlockSizeNanos)).
+		SetCompactAfter(fromNanos(co.CompactAfterNanos))
+
+	return copts, nil
+}
+
+// ToDuplicatePolicy converts nsproto.DuplicatePolicy to DuplicatePolicy
+func ToDuplicatePolicy(p nsproto.DuplicatePolicy) DuplicatePolicy {
+	// NB: The values of the proto enum match those of DuplicatePolicy, unknown
+	// values are rejected when the options are validated.
+	return DuplicatePolicy(p)
+}
+
+// ToSchemaOptions converts nsproto.SchemaOptions to SchemaOptions
+func ToSchemaOptions(
+	so *nsproto.SchemaOptions,
+) (SchemaOptions, error) {
+	sopts := NewSchemaOptions()
+	if so == nil {
+		return sopts, nil
+	}
+
+	sopts = sopts.SetFileDescriptorSet(so.FileDescriptorSet).
+		SetMessageName(so.MessageName)
+	if err := sopts.Validate(); err != nil {
+		return nil, err
+	}
+
+	return sopts, nil
+}
+
 // ToMetadata converts nsproto.Options to Metadata
 func ToMetadata(
 	id string,
@@ -97,6 +153,21 @@ func ToMetadata(
 		return nil, err
 	}
 
+	copts, err := ToColdStorageOptions(opts.ColdStorageOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	sopts, err := ToSchemaOptions(opts.SchemaOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	compactionOpts, err := ToCompactionOptions(opts.CompactionOptions)
+	if err != nil {
+		return nil, err
+	}
+
 	mopts := NewOptions().
 		SetBootstrapEnabled(opts.BootstrapEnabled).
 		SetFlushEnabled(opts.FlushEnabled).
@@ -105,7 +176,11 @@ func ToMetadata(
 		SetWritesToCommitLog(opts.WritesToCommitLog).
 		SetSnapshotEnabled(opts.SnapshotEnabled).
 		SetRetentionOptions(ropts).
-		SetIndexOptions(iopts)
+		SetIndexOptions(iopts).
+		SetColdStorageOptions(copts).
+		SetSchemaOptions(sopts).
+		SetCompactionOptions(compactionOpts).
+		SetDuplicatePolicy(ToDuplicatePolicy(opts.DuplicatePolicy))
 
 	return NewMetadata(ident.StringID(id), mopts)
 }
@@ -140,6 +215,9 @@ func FromProto(protoRegistry nsproto.Registry) (Map, error) {
 func OptionsToProto(opts Options) *nsproto.NamespaceOptions {
 	ropts := opts.RetentionOptions()
 	iopts := opts.IndexOptions()
+	copts := opts.ColdStorageOptions()
+	sopts := opts.SchemaOptions()
+	compactionOpts := opts.CompactionOptions()
 
 	return &nsproto.NamespaceOptions{
 		BootstrapEnabled:  opts.BootstrapEnabled(),
@@ -160,5 +238,19 @@ func OptionsToProto(opts Options) *nsproto.NamespaceOptions {
 			Enabled:        iopts.Enabled(),
 			BlockSizeNanos: iopts.BlockSize().Nanoseconds(),
 		},
+		ColdStorageOptions: &nsproto.ColdStorageOptions{
+			Enabled:           copts.Enabled(),
+			OffloadAfterNanos: copts.OffloadAfter().Nanoseconds(),
+		},
+		SchemaOptions: &nsproto.SchemaOptions{
+			FileDescriptorSet: sopts.FileDescriptorSet(),
+			MessageName:       sopts.MessageName(),
+		},
+		CompactionOptions: &nsproto.CompactionOptions{
+			Enabled:           compactionOpts.Enabled(),
+			BlockSizeNanos:    compactionOpts.BlockSize().Nanoseconds(),
+			CompactAfterNanos: compactionOpts.CompactAfter().Nanoseconds(),
+		},
+		DuplicatePolicy: nsproto.DuplicatePolicy(opts.DuplicatePolicy()),
 	}
 }
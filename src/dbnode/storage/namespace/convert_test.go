@@ -29,6 +29,8 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	"github.com/m3db/m3x/ident"
 
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/protoc-gen-gogo/descriptor"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -43,6 +45,22 @@ var (
 		BlockSizeNanos: toNanos(600), // 10h
 	}
 
+	validColdStorageOpts = nsproto.ColdStorageOptions{
+		Enabled:           true,
+		OffloadAfterNanos: toNanos(600), // 10h
+	}
+
+	validCompactionOpts = nsproto.CompactionOptions{
+		Enabled:           true,
+		BlockSizeNanos:    toNanos(480), // 8h
+		CompactAfterNanos: toNanos(600), // 10h
+	}
+
+	validSchemaOpts = nsproto.SchemaOptions{
+		FileDescriptorSet: testFileDescriptorSet(),
+		MessageName:       "test.Event",
+	}
+
 	validRetentionOpts = nsproto.RetentionOptions{
 		RetentionPeriodNanos:                     toNanos(1200), // 20h
 		BlockSizeNanos:                           toNanos(120),  // 2h
@@ -70,6 +88,42 @@ var (
 			RetentionOptions:  &validRetentionOpts,
 			IndexOptions:      &validIndexOpts,
 		},
+		nsproto.NamespaceOptions{
+			BootstrapEnabled:   true,
+			FlushEnabled:       true,
+			WritesToCommitLog:  true,
+			CleanupEnabled:     true,
+			RepairEnabled:      true,
+			RetentionOptions:   &validRetentionOpts,
+			ColdStorageOptions: &validColdStorageOpts,
+		},
+		nsproto.NamespaceOptions{
+			BootstrapEnabled:  true,
+			FlushEnabled:      true,
+			WritesToCommitLog: true,
+			CleanupEnabled:    true,
+			RepairEnabled:     true,
+			RetentionOptions:  &validRetentionOpts,
+			SchemaOptions:     &validSchemaOpts,
+		},
+		nsproto.NamespaceOptions{
+			BootstrapEnabled:  true,
+			FlushEnabled:      true,
+			WritesToCommitLog: true,
+			CleanupEnabled:    true,
+			RepairEnabled:     true,
+			RetentionOptions:  &validRetentionOpts,
+			CompactionOptions: &validCompactionOpts,
+		},
+		nsproto.NamespaceOptions{
+			BootstrapEnabled:  true,
+			FlushEnabled:      true,
+			WritesToCommitLog: true,
+			CleanupEnabled:    true,
+			RepairEnabled:     true,
+			RetentionOptions:  &validRetentionOpts,
+			DuplicatePolicy:   nsproto.DuplicatePolicy_MAX_VALUE,
+		},
 	}
 
 	invalidRetentionOpts = []nsproto.RetentionOptions{
@@ -116,6 +170,70 @@ func TestToNamespaceValid(t *testing.T) {
 	}
 }
 
+func TestToNamespaceInvalidColdStorage(t *testing.T) {
+	for _, offloadAfter := range []int64{
+		0,
+		toNanos(60),   // 1h, less than block size plus buffer past
+		toNanos(1200), // 20h, not less than retention
+	} {
+		opts := validNamespaceOpts[2]
+		opts.ColdStorageOptions = &nsproto.ColdStorageOptions{
+			Enabled:           true,
+			OffloadAfterNanos: offloadAfter,
+		}
+		_, err := namespace.ToMetadata("abc", &opts)
+		require.Error(t, err)
+	}
+}
+
+func TestToNamespaceInvalidCompaction(t *testing.T) {
+	for _, co := range []nsproto.CompactionOptions{
+		{Enabled: true, BlockSizeNanos: toNanos(120), CompactAfterNanos: toNanos(600)},  // not > block size
+		{Enabled: true, BlockSizeNanos: toNanos(300), CompactAfterNanos: toNanos(600)},  // not a multiple of block size
+		{Enabled: true, BlockSizeNanos: toNanos(480), CompactAfterNanos: toNanos(60)},   // less than block size plus buffer past
+		{Enabled: true, BlockSizeNanos: toNanos(480), CompactAfterNanos: toNanos(1200)}, // not less than retention
+	} {
+		opts := validNamespaceOpts[4]
+		opts.CompactionOptions = &co
+		_, err := namespace.ToMetadata("abc", &opts)
+		require.Error(t, err)
+	}
+}
+
+func TestToNamespaceInvalidDuplicatePolicy(t *testing.T) {
+	opts := validNamespaceOpts[5]
+	opts.DuplicatePolicy = nsproto.DuplicatePolicy(len(nsproto.DuplicatePolicy_name))
+	_, err := namespace.ToMetadata("abc", &opts)
+	require.Error(t, err)
+}
+
+func TestToNamespaceInvalidSchema(t *testing.T) {
+	for _, so := range []nsproto.SchemaOptions{
+		{FileDescriptorSet: testFileDescriptorSet()},
+		{MessageName: "test.Event"},
+		{FileDescriptorSet: testFileDescriptorSet(), MessageName: "test.Missing"},
+		{FileDescriptorSet: []byte{0xff}, MessageName: "test.Event"},
+	} {
+		opts := validNamespaceOpts[3]
+		opts.SchemaOptions = &so
+		_, err := namespace.ToMetadata("abc", &opts)
+		require.Error(t, err)
+	}
+}
+
+func TestToProtoSchemaOptions(t *testing.T) {
+	md, err := namespace.ToMetadata("ns1", &validNamespaceOpts[3])
+	require.NoError(t, err)
+	require.True(t, md.Options().SchemaOptions().Enabled())
+
+	nsMap, err := namespace.NewMap([]namespace.Metadata{md})
+	require.NoError(t, err)
+
+	reg := namespace.ToProto(nsMap)
+	require.Len(t, reg.Namespaces, 1)
+	require.Equal(t, validSchemaOpts, *reg.Namespaces["ns1"].SchemaOptions)
+}
+
 func TestToNamespaceInvalid(t *testing.T) {
 	for _, nsopts := range validNamespaceOpts {
 		_, err := namespace.ToMetadata("", &nsopts)
@@ -228,6 +346,35 @@ func assertEqualMetadata(t *testing.T, name string, expected nsproto.NamespaceOp
 	require.Equal(t, expected.RepairEnabled, opts.RepairEnabled())
 
 	assertEqualRetentions(t, *expected.RetentionOptions, opts.RetentionOptions())
+
+	if expected.ColdStorageOptions != nil {
+		require.Equal(t, expected.ColdStorageOptions.Enabled, opts.ColdStorageOptions().Enabled())
+		require.Equal(t, expected.ColdStorageOptions.OffloadAfterNanos,
+			opts.ColdStorageOptions().OffloadAfter().Nanoseconds())
+	} else {
+		require.False(t, opts.ColdStorageOptions().Enabled())
+	}
+
+	if expected.SchemaOptions != nil {
+		require.Equal(t, expected.SchemaOptions.FileDescriptorSet,
+			opts.SchemaOptions().FileDescriptorSet())
+		require.Equal(t, expected.SchemaOptions.MessageName,
+			opts.SchemaOptions().MessageName())
+	} else {
+		require.False(t, opts.SchemaOptions().Enabled())
+	}
+
+	if expected.CompactionOptions != nil {
+		require.Equal(t, expected.CompactionOptions.Enabled, opts.CompactionOptions().Enabled())
+		require.Equal(t, expected.CompactionOptions.BlockSizeNanos,
+			opts.CompactionOptions().BlockSize().Nanoseconds())
+		require.Equal(t, expected.CompactionOptions.CompactAfterNanos,
+			opts.CompactionOptions().CompactAfter().Nanoseconds())
+	} else {
+		require.False(t, opts.CompactionOptions().Enabled())
+	}
+
+	require.Equal(t, namespace.ToDuplicatePolicy(expected.DuplicatePolicy), opts.DuplicatePolicy())
 }
 
 func assertEqualRetentions(t *testing.T, expected nsproto.RetentionOptions, observed retention.Options) {
@@ -239,3 +386,32 @@ func assertEqualRetentions(t *testing.T, expected nsproto.RetentionOptions, obse
 	require.Equal(t, expected.BlockDataExpiryAfterNotAccessPeriodNanos,
 		observed.BlockDataExpiryAfterNotAccessedPeriod().Nanoseconds())
 }
+
+func testFileDescriptorSet() []byte {
+	fds := &descriptor.FileDescriptorSet{
+		File: []*descriptor.FileDescriptorProto{
+			{
+				Name:    proto.String("event.proto"),
+				Package: proto.String("test"),
+				MessageType: []*descriptor.DescriptorProto{
+					{
+						Name: proto.String("Event"),
+						Field: []*descriptor.FieldDescriptorProto{
+							{
+								Name:   proto.String("status"),
+								Number: proto.Int32(1),
+								Type:   descriptor.FieldDescriptorProto_TYPE_INT64.Enum(),
+								Label:  descriptor.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	b, err := proto.Marshal(fds)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
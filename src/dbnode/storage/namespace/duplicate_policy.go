@@ -0,0 +1,143 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package namespace
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+)
+
+var (
+	errDuplicatePolicyNotSpecified = errors.New("duplicate policy not specified")
+)
+
+// DuplicatePolicy describes how values written for the same series and
+// timestamp are resolved.
+type DuplicatePolicy uint8
+
+const (
+	// DuplicatePolicyLastWriteWins keeps the value that arrived last.
+	DuplicatePolicyLastWriteWins DuplicatePolicy = iota
+	// DuplicatePolicyMaxValue keeps the highest value.
+	DuplicatePolicyMaxValue
+	// DuplicatePolicyMinValue keeps the lowest value.
+	DuplicatePolicyMinValue
+	// DuplicatePolicyRejectDuplicate rejects writes that differ from the value
+	// already written, when a duplicate cannot be rejected at write time (i.e.
+	// when replaying the commit log) the value that arrived first is kept.
+	// NB: at write time a value is only compared to the last value written
+	// to each of the in order encoders of a buffer bucket, a duplicate of any
+	// other value is accepted and resolved by keeping the first value.
+	DuplicatePolicyRejectDuplicate
+
+	// DefaultDuplicatePolicy is the default duplicate policy.
+	DefaultDuplicatePolicy = DuplicatePolicyLastWriteWins
+)
+
+var (
+	validDuplicatePolicies = []DuplicatePolicy{
+		DuplicatePolicyLastWriteWins,
+		DuplicatePolicyMaxValue,
+		DuplicatePolicyMinValue,
+		DuplicatePolicyRejectDuplicate,
+	}
+)
+
+// ValidDuplicatePolicies returns the valid duplicate policies.
+func ValidDuplicatePolicies() []DuplicatePolicy {
+	// Return a copy here so callers cannot mutate the known list.
+	src := validDuplicatePolicies
+	result := make([]DuplicatePolicy, len(src))
+	copy(result, src)
+	return result
+}
+
+// Validate returns an error if the duplicate policy is unknown.
+func (p DuplicatePolicy) Validate() error {
+	for _, valid := range validDuplicatePolicies {
+		if p == valid {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid DuplicatePolicy '%d' valid types are: %v",
+		p, ValidDuplicatePolicies())
+}
+
+// IterateEqualTimestampStrategy returns the strategy used to select a value
+// when merging streams that contain values with equal timestamps, the streams
+// must be ordered by the time their values arrived.
+func (p DuplicatePolicy) IterateEqualTimestampStrategy() encoding.IterateEqualTimestampStrategy {
+	switch p {
+	case DuplicatePolicyMaxValue:
+		return encoding.IterateHighestValue
+	case DuplicatePolicyMinValue:
+		return encoding.IterateLowestValue
+	case DuplicatePolicyRejectDuplicate:
+		return encoding.IterateFirstPushed
+	}
+	return encoding.IterateLastPushed
+}
+
+func (p DuplicatePolicy) String() string {
+	switch p {
+	case DuplicatePolicyLastWriteWins:
+		return "last_write_wins"
+	case DuplicatePolicyMaxValue:
+		return "max_value"
+	case DuplicatePolicyMinValue:
+		return "min_value"
+	case DuplicatePolicyRejectDuplicate:
+		return "reject_duplicate"
+	}
+	return "unknown"
+}
+
+// ParseDuplicatePolicy parses a DuplicatePolicy from a string.
+func ParseDuplicatePolicy(str string) (DuplicatePolicy, error) {
+	var r DuplicatePolicy
+	if str == "" {
+		return r, errDuplicatePolicyNotSpecified
+	}
+	for _, valid := range ValidDuplicatePolicies() {
+		if str == valid.String() {
+			r = valid
+			return r, nil
+		}
+	}
+	return r, fmt.Errorf("invalid DuplicatePolicy '%s' valid types are: %v",
+		str, ValidDuplicatePolicies())
+}
+
+// UnmarshalYAML unmarshals a DuplicatePolicy into a valid type from string.
+func (p *DuplicatePolicy) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+	r, err := ParseDuplicatePolicy(str)
+	if err != nil {
+		return err
+	}
+	*p = r
+	return nil
+}
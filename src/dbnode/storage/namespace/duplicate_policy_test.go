@@ -0,0 +1,67 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package namespace
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestDuplicatePolicyUnmarshalYAML(t *testing.T) {
+	type config struct {
+		Policy DuplicatePolicy `yaml:"policy"`
+	}
+
+	for _, value := range ValidDuplicatePolicies() {
+		str := fmt.Sprintf("policy: %s\n", value.String())
+
+		var cfg config
+		require.NoError(t, yaml.Unmarshal([]byte(str), &cfg))
+
+		assert.Equal(t, value, cfg.Policy)
+		assert.NoError(t, cfg.Policy.Validate())
+	}
+
+	var cfg config
+	require.Error(t, yaml.Unmarshal([]byte("policy: not_a_known_policy\n"), &cfg))
+}
+
+func TestDuplicatePolicyValidate(t *testing.T) {
+	require.Error(t, DuplicatePolicy(len(validDuplicatePolicies)).Validate())
+}
+
+func TestDuplicatePolicyIterateEqualTimestampStrategy(t *testing.T) {
+	expected := map[DuplicatePolicy]encoding.IterateEqualTimestampStrategy{
+		DuplicatePolicyLastWriteWins:   encoding.IterateLastPushed,
+		DuplicatePolicyMaxValue:        encoding.IterateHighestValue,
+		DuplicatePolicyMinValue:        encoding.IterateLowestValue,
+		DuplicatePolicyRejectDuplicate: encoding.IterateFirstPushed,
+	}
+	for _, policy := range ValidDuplicatePolicies() {
+		assert.Equal(t, expected[policy], policy.IterateEqualTimestampStrategy())
+	}
+}
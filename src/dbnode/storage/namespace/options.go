@@ -47,9 +47,18 @@ const (
 )
 
 var (
-	errIndexBlockSizePositive                       = errors.New("index block size must positive")
-	errIndexBlockSizeTooLarge                       = errors.New("index block size needs to be <= namespace retention period")
-	errIndexBlockSizeMustBeAMultipleOfDataBlockSize = errors.New("index block size must be a multiple of data block size")
+	errIndexBlockSizePositive                            = errors.New("index block size must positive")
+	errIndexBlockSizeTooLarge                            = errors.New("index block size needs to be <= namespace retention period")
+	errIndexBlockSizeMustBeAMultipleOfDataBlockSize      = errors.New("index block size must be a multiple of data block size")
+	errColdStorageOffloadAfterPositive                   = errors.New("cold storage offload after must be positive")
+	errColdStorageOffloadAfterTooLarge                   = errors.New("cold storage offload after needs to be < namespace retention period")
+	errColdStorageOffloadAfterTooSmall                   = errors.New("cold storage offload after needs to be >= data block size plus buffer past")
+	errCompactionBlockSizeTooSmall                       = errors.New("compaction block size needs to be > data block size")
+	errCompactionBlockSizeTooLarge                       = errors.New("compaction block size needs to be <= namespace retention period")
+	errCompactionBlockSizeMustBeAMultipleOfDataBlockSize = errors.New("compaction block size must be a multiple of data block size")
+	errCompactionCompactAfterTooLarge                    = errors.New("compaction compact after needs to be < namespace retention period")
+	errCompactionCompactAfterTooSmall                    = errors.New("compaction compact after needs to be >= data block size plus buffer past")
+	errCompactionWithColdStorage                         = errors.New("compaction cannot be enabled together with cold storage")
 )
 
 type options struct {
@@ -61,6 +70,10 @@ type options struct {
 	repairEnabled     bool
 	retentionOpts     retention.Options
 	indexOpts         IndexOptions
+	coldStorageOpts   ColdStorageOptions
+	schemaOpts        SchemaOptions
+	compactionOpts    CompactionOptions
+	duplicatePolicy   DuplicatePolicy
 }
 
 // NewOptions creates a new namespace options
@@ -74,6 +87,10 @@ func NewOptions() Options {
 		repairEnabled:     defaultRepairEnabled,
 		retentionOpts:     retention.NewOptions(),
 		indexOpts:         NewIndexOptions(),
+		coldStorageOpts:   NewColdStorageOptions(),
+		schemaOpts:        NewSchemaOptions(),
+		compactionOpts:    NewCompactionOptions(),
+		duplicatePolicy:   DefaultDuplicatePolicy,
 	}
 }
 
@@ -81,6 +98,22 @@ func (o *options) Validate() error {
 	if err := o.retentionOpts.Validate(); err != nil {
 		return err
 	}
+	if err := o.validateIndexOptions(); err != nil {
+		return err
+	}
+	if err := o.validateColdStorageOptions(); err != nil {
+		return err
+	}
+	if err := o.validateCompactionOptions(); err != nil {
+		return err
+	}
+	if err := o.duplicatePolicy.Validate(); err != nil {
+		return err
+	}
+	return o.schemaOpts.Validate()
+}
+
+func (o *options) validateIndexOptions() error {
 	if !o.indexOpts.Enabled() {
 		return nil
 	}
@@ -101,6 +134,63 @@ func (o *options) Validate() error {
 	return nil
 }
 
+func (o *options) validateColdStorageOptions() error {
+	if !o.coldStorageOpts.Enabled() {
+		return nil
+	}
+	var (
+		retention    = o.retentionOpts.RetentionPeriod()
+		offloadAfter = o.coldStorageOpts.OffloadAfter()
+	)
+	if offloadAfter <= 0 {
+		return errColdStorageOffloadAfterPositive
+	}
+	if offloadAfter >= retention {
+		return errColdStorageOffloadAfterTooLarge
+	}
+	// Only blocks that have been flushed and can no longer receive
+	// writes are eligible to be offloaded.
+	if offloadAfter < o.retentionOpts.BlockSize()+o.retentionOpts.BufferPast() {
+		return errColdStorageOffloadAfterTooSmall
+	}
+	return nil
+}
+
+func (o *options) validateCompactionOptions() error {
+	if !o.compactionOpts.Enabled() {
+		return nil
+	}
+	var (
+		retention           = o.retentionOpts.RetentionPeriod()
+		dataBlockSize       = o.retentionOpts.BlockSize()
+		compactionBlockSize = o.compactionOpts.BlockSize()
+		compactAfter        = o.compactionOpts.CompactAfter()
+	)
+	if compactionBlockSize <= dataBlockSize {
+		return errCompactionBlockSizeTooSmall
+	}
+	if compactionBlockSize > retention {
+		return errCompactionBlockSizeTooLarge
+	}
+	if compactionBlockSize%dataBlockSize != 0 {
+		return errCompactionBlockSizeMustBeAMultipleOfDataBlockSize
+	}
+	if compactAfter >= retention {
+		return errCompactionCompactAfterTooLarge
+	}
+	// Only blocks that have been flushed and can no longer receive
+	// writes are eligible to be compacted.
+	if compactAfter < dataBlockSize+o.retentionOpts.BufferPast() {
+		return errCompactionCompactAfterTooSmall
+	}
+	// Offloaded filesets are tracked by their block start alone, which
+	// does not distinguish compacted filesets from regular ones.
+	if o.coldStorageOpts.Enabled() {
+		return errCompactionWithColdStorage
+	}
+	return nil
+}
+
 func (o *options) Equal(value Options) bool {
 	return o.bootstrapEnabled == value.BootstrapEnabled() &&
 		o.flushEnabled == value.FlushEnabled() &&
@@ -109,7 +199,11 @@ func (o *options) Equal(value Options) bool {
 		o.cleanupEnabled == value.CleanupEnabled() &&
 		o.repairEnabled == value.RepairEnabled() &&
 		o.retentionOpts.Equal(value.RetentionOptions()) &&
-		o.indexOpts.Equal(value.IndexOptions())
+		o.indexOpts.Equal(value.IndexOptions()) &&
+		o.coldStorageOpts.Equal(value.ColdStorageOptions()) &&
+		o.schemaOpts.Equal(value.SchemaOptions()) &&
+		o.compactionOpts.Equal(value.CompactionOptions()) &&
+		o.duplicatePolicy == value.DuplicatePolicy()
 }
 
 func (o *options) SetBootstrapEnabled(value bool) Options {
@@ -191,3 +285,43 @@ func (o *options) SetIndexOptions(value IndexOptions) Options {
 func (o *options) IndexOptions() IndexOptions {
 	return o.indexOpts
 }
+
+func (o *options) SetColdStorageOptions(value ColdStorageOptions) Options {
+	opts := *o
+	opts.coldStorageOpts = value
+	return &opts
+}
+
+func (o *options) ColdStorageOptions() ColdStorageOptions {
+	return o.coldStorageOpts
+}
+
+func (o *options) SetSchemaOptions(value SchemaOptions) Options {
+	opts := *o
+	opts.schemaOpts = value
+	return &opts
+}
+
+func (o *options) SchemaOptions() SchemaOptions {
+	return o.schemaOpts
+}
+
+func (o *options) SetCompactionOptions(value CompactionOptions) Options {
+	opts := *o
+	opts.compactionOpts = value
+	return &opts
+}
+
+func (o *options) CompactionOptions() CompactionOptions {
+	return o.compactionOpts
+}
+
+func (o *options) SetDuplicatePolicy(value DuplicatePolicy) Options {
+	opts := *o
+	opts.duplicatePolicy = value
+	return &opts
+}
+
+func (o *options) DuplicatePolicy() DuplicatePolicy {
+	return o.duplicatePolicy
+}
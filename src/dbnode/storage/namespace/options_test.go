@@ -164,3 +164,46 @@ func TestOptionsValidateNoIndexing(t *testing.T) {
 	rOpts.EXPECT().Validate().Return(nil)
 	require.NoError(t, o1.Validate())
 }
+
+func TestOptionsValidateCompaction(t *testing.T) {
+	rOpts := retention.NewOptions().
+		SetRetentionPeriod(7 * 24 * time.Hour).
+		SetBlockSize(2 * time.Hour).
+		SetBufferPast(10 * time.Minute)
+	cOpts := NewCompactionOptions().
+		SetEnabled(true).
+		SetBlockSize(24 * time.Hour).
+		SetCompactAfter(2 * 24 * time.Hour)
+	o1 := NewOptions().
+		SetRetentionOptions(rOpts).
+		SetIndexOptions(NewIndexOptions().SetEnabled(false)).
+		SetCompactionOptions(cOpts)
+	require.NoError(t, o1.Validate())
+
+	for _, invalid := range []CompactionOptions{
+		cOpts.SetBlockSize(2 * time.Hour),
+		cOpts.SetBlockSize(5 * time.Hour),
+		cOpts.SetBlockSize(8 * 24 * time.Hour),
+		cOpts.SetCompactAfter(time.Hour),
+		cOpts.SetCompactAfter(7 * 24 * time.Hour),
+	} {
+		require.Error(t, o1.SetCompactionOptions(invalid).Validate())
+	}
+
+	withColdStorage := o1.SetColdStorageOptions(NewColdStorageOptions().
+		SetEnabled(true).
+		SetOffloadAfter(3 * 24 * time.Hour))
+	require.Equal(t, errCompactionWithColdStorage, withColdStorage.Validate())
+
+	require.NoError(t, o1.SetCompactionOptions(cOpts.SetEnabled(false).
+		SetBlockSize(time.Hour)).Validate())
+}
+
+func TestOptionsValidateDuplicatePolicy(t *testing.T) {
+	o1 := NewOptions().SetIndexOptions(NewIndexOptions().SetEnabled(false))
+	require.Equal(t, DefaultDuplicatePolicy, o1.DuplicatePolicy())
+	for _, policy := range ValidDuplicatePolicies() {
+		require.NoError(t, o1.SetDuplicatePolicy(policy).Validate())
+	}
+	require.Error(t, o1.SetDuplicatePolicy(DuplicatePolicy(0xff)).Validate())
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package namespace
+
+import (
+	"bytes"
+
+	"github.com/m3db/m3/src/dbnode/encoding/proto"
+)
+
+type schemaOpts struct {
+	fileDescriptorSet []byte
+	messageName       string
+}
+
+// NewSchemaOptions returns a new SchemaOptions, by default no schema is
+// declared and values are encoded with M3TSZ.
+func NewSchemaOptions() SchemaOptions {
+	return &schemaOpts{}
+}
+
+func (s *schemaOpts) Validate() error {
+	if !s.Enabled() {
+		return nil
+	}
+	_, err := s.Schema()
+	return err
+}
+
+func (s *schemaOpts) Equal(value SchemaOptions) bool {
+	return s.MessageName() == value.MessageName() &&
+		bytes.Equal(s.FileDescriptorSet(), value.FileDescriptorSet())
+}
+
+func (s *schemaOpts) Enabled() bool {
+	return s.messageName != "" || len(s.fileDescriptorSet) > 0
+}
+
+func (s *schemaOpts) SetFileDescriptorSet(value []byte) SchemaOptions {
+	so := *s
+	so.fileDescriptorSet = value
+	return &so
+}
+
+func (s *schemaOpts) FileDescriptorSet() []byte {
+	return s.fileDescriptorSet
+}
+
+func (s *schemaOpts) SetMessageName(value string) SchemaOptions {
+	so := *s
+	so.messageName = value
+	return &so
+}
+
+func (s *schemaOpts) MessageName() string {
+	return s.messageName
+}
+
+func (s *schemaOpts) Schema() (*proto.Schema, error) {
+	if !s.Enabled() {
+		return nil, nil
+	}
+	return proto.NewSchema(s.fileDescriptorSet, s.messageName)
+}
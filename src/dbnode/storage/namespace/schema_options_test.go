@@ -0,0 +1,55 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package namespace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaOptionsEqual(t *testing.T) {
+	opts := NewSchemaOptions()
+	require.True(t, opts.Equal(NewSchemaOptions()))
+	require.False(t, opts.SetMessageName("a.B").Equal(opts.SetMessageName("a.C")))
+	require.False(t, opts.SetFileDescriptorSet([]byte{1}).Equal(
+		opts.SetFileDescriptorSet([]byte{2})))
+}
+
+func TestSchemaOptionsEnabled(t *testing.T) {
+	opts := NewSchemaOptions()
+	require.False(t, opts.Enabled())
+	require.True(t, opts.SetMessageName("a.B").Enabled())
+	require.True(t, opts.SetFileDescriptorSet([]byte{1}).Enabled())
+}
+
+func TestSchemaOptionsValidate(t *testing.T) {
+	opts := NewSchemaOptions()
+	require.NoError(t, opts.Validate())
+
+	schema, err := opts.Schema()
+	require.NoError(t, err)
+	require.Nil(t, schema)
+
+	require.Error(t, opts.SetMessageName("a.B").Validate())
+	require.Error(t, opts.SetMessageName("a.B").
+		SetFileDescriptorSet([]byte{0xff}).Validate())
+}
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/dbnode/encoding/proto"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3x/ident"
 	"github.com/m3db/m3x/instrument"
@@ -84,6 +85,32 @@ type Options interface {
 
 	// IndexOptions returns the IndexOptions.
 	IndexOptions() IndexOptions
+
+	// SetColdStorageOptions sets the ColdStorageOptions.
+	SetColdStorageOptions(value ColdStorageOptions) Options
+
+	// ColdStorageOptions returns the ColdStorageOptions.
+	ColdStorageOptions() ColdStorageOptions
+
+	// SetSchemaOptions sets the SchemaOptions.
+	SetSchemaOptions(value SchemaOptions) Options
+
+	// SchemaOptions returns the SchemaOptions.
+	SchemaOptions() SchemaOptions
+
+	// SetCompactionOptions sets the CompactionOptions.
+	SetCompactionOptions(value CompactionOptions) Options
+
+	// CompactionOptions returns the CompactionOptions.
+	CompactionOptions() CompactionOptions
+
+	// SetDuplicatePolicy sets the policy used to resolve values written for
+	// the same series and timestamp.
+	SetDuplicatePolicy(value DuplicatePolicy) Options
+
+	// DuplicatePolicy returns the policy used to resolve values written for
+	// the same series and timestamp.
+	DuplicatePolicy() DuplicatePolicy
 }
 
 // IndexOptions controls the indexing options for a namespace.
@@ -104,6 +131,86 @@ type IndexOptions interface {
 	BlockSize() time.Duration
 }
 
+// ColdStorageOptions controls offloading of flushed filesets for a
+// namespace to an object store.
+type ColdStorageOptions interface {
+	// Equal returns true if the provide value is equal to this one.
+	Equal(value ColdStorageOptions) bool
+
+	// SetEnabled sets whether offloading to cold storage is enabled.
+	SetEnabled(value bool) ColdStorageOptions
+
+	// Enabled returns whether offloading to cold storage is enabled.
+	Enabled() bool
+
+	// SetOffloadAfter sets the age of a block after which its flushed
+	// filesets are offloaded to cold storage.
+	SetOffloadAfter(value time.Duration) ColdStorageOptions
+
+	// OffloadAfter returns the age of a block after which its flushed
+	// filesets are offloaded to cold storage.
+	OffloadAfter() time.Duration
+}
+
+// CompactionOptions controls compacting the flushed filesets of consecutive
+// blocks for a namespace into filesets of a larger block size.
+type CompactionOptions interface {
+	// Equal returns true if the provide value is equal to this one.
+	Equal(value CompactionOptions) bool
+
+	// SetEnabled sets whether compaction is enabled.
+	SetEnabled(value bool) CompactionOptions
+
+	// Enabled returns whether compaction is enabled.
+	Enabled() bool
+
+	// SetBlockSize sets the block size that flushed filesets are compacted
+	// into, it must be a multiple of the namespace block size.
+	SetBlockSize(value time.Duration) CompactionOptions
+
+	// BlockSize returns the block size that flushed filesets are compacted
+	// into, it must be a multiple of the namespace block size.
+	BlockSize() time.Duration
+
+	// SetCompactAfter sets the age of a compacted block after which the
+	// flushed filesets of the blocks it covers are compacted.
+	SetCompactAfter(value time.Duration) CompactionOptions
+
+	// CompactAfter returns the age of a compacted block after which the
+	// flushed filesets of the blocks it covers are compacted.
+	CompactAfter() time.Duration
+}
+
+// SchemaOptions declares the protobuf schema of the values written to a
+// namespace, namespaces without a schema encode values with M3TSZ.
+type SchemaOptions interface {
+	// Validate validates the schema can be parsed.
+	Validate() error
+
+	// Equal returns true if the provide value is equal to this one.
+	Equal(value SchemaOptions) bool
+
+	// Enabled returns whether a schema has been declared.
+	Enabled() bool
+
+	// SetFileDescriptorSet sets the serialized protobuf FileDescriptorSet
+	// containing the schema message.
+	SetFileDescriptorSet(value []byte) SchemaOptions
+
+	// FileDescriptorSet returns the serialized protobuf FileDescriptorSet
+	// containing the schema message.
+	FileDescriptorSet() []byte
+
+	// SetMessageName sets the fully qualified name of the schema message.
+	SetMessageName(value string) SchemaOptions
+
+	// MessageName returns the fully qualified name of the schema message.
+	MessageName() string
+
+	// Schema returns the parsed schema, or nil if no schema is declared.
+	Schema() (*proto.Schema, error)
+}
+
 // Metadata represents namespace metadata information
 type Metadata interface {
 	// Equal returns true if the provide value is equal to this one
@@ -0,0 +1,77 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package namespace
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	errUpdateCompactedBlockSize = errors.New(
+		"cannot migrate the data of a namespace with compaction enabled to a new block size")
+)
+
+// ValidateUpdate returns an error if the options of an existing namespace cannot be
+// updated to the given options. The retention period of a namespace can be changed
+// at any time, while its block size can only be changed if migrateData is set, in
+// which case the flushed data of the namespace is rewritten to the new block size
+// when each node restarts. The index block size of a namespace cannot be changed.
+func ValidateUpdate(existing, update Options, migrateData bool) error {
+	if err := update.Validate(); err != nil {
+		return err
+	}
+
+	var (
+		existingBlockSize = existing.RetentionOptions().BlockSize()
+		updateBlockSize   = update.RetentionOptions().BlockSize()
+	)
+	if existingBlockSize != updateBlockSize {
+		if !migrateData {
+			return fmt.Errorf(
+				"cannot change namespace block size from %s to %s without migrating its data",
+				existingBlockSize, updateBlockSize)
+		}
+		if existing.CompactionOptions().Enabled() {
+			return errUpdateCompactedBlockSize
+		}
+	}
+
+	var (
+		existingIndexBlockSize = existing.IndexOptions().BlockSize()
+		updateIndexBlockSize   = update.IndexOptions().BlockSize()
+	)
+	if existingIndexBlockSize != updateIndexBlockSize {
+		return fmt.Errorf("cannot change namespace index block size from %s to %s",
+			existingIndexBlockSize, updateIndexBlockSize)
+	}
+
+	return nil
+}
+
+// IsLiveUpdate returns whether the options of a running namespace can be updated to
+// the given options without restarting, which is the case when they differ by at
+// most the retention period.
+func IsLiveUpdate(existing, update Options) bool {
+	ropts := existing.RetentionOptions().
+		SetRetentionPeriod(update.RetentionOptions().RetentionPeriod())
+	return existing.SetRetentionOptions(ropts).Equal(update)
+}
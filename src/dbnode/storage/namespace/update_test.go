@@ -0,0 +1,78 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package namespace
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateUpdateRetentionPeriod(t *testing.T) {
+	existing := NewOptions()
+	ropts := existing.RetentionOptions()
+	update := existing.SetRetentionOptions(
+		ropts.SetRetentionPeriod(ropts.RetentionPeriod() * 2))
+	require.NoError(t, ValidateUpdate(existing, update, false))
+}
+
+func TestValidateUpdateBlockSize(t *testing.T) {
+	existing := NewOptions()
+	ropts := existing.RetentionOptions()
+	update := existing.SetRetentionOptions(
+		ropts.SetBlockSize(ropts.BlockSize() / 2))
+	require.Error(t, ValidateUpdate(existing, update, false))
+	require.NoError(t, ValidateUpdate(existing, update, true))
+
+	compacted := existing.SetCompactionOptions(NewCompactionOptions().
+		SetEnabled(true).
+		SetBlockSize(4 * ropts.BlockSize()).
+		SetCompactAfter(ropts.RetentionPeriod() / 2))
+	require.Equal(t, errUpdateCompactedBlockSize, ValidateUpdate(compacted,
+		compacted.SetRetentionOptions(update.RetentionOptions()), true))
+}
+
+func TestValidateUpdateIndexBlockSize(t *testing.T) {
+	existing := NewOptions()
+	update := existing.SetIndexOptions(existing.IndexOptions().
+		SetBlockSize(existing.IndexOptions().BlockSize() * 2))
+	require.Error(t, ValidateUpdate(existing, update, false))
+	require.Error(t, ValidateUpdate(existing, update, true))
+}
+
+func TestValidateUpdateInvalidOptions(t *testing.T) {
+	existing := NewOptions()
+	update := existing.SetRetentionOptions(
+		existing.RetentionOptions().SetRetentionPeriod(-time.Hour))
+	require.Error(t, ValidateUpdate(existing, update, false))
+}
+
+func TestIsLiveUpdate(t *testing.T) {
+	existing := NewOptions()
+	ropts := existing.RetentionOptions()
+	require.True(t, IsLiveUpdate(existing, existing))
+	require.True(t, IsLiveUpdate(existing, existing.SetRetentionOptions(
+		ropts.SetRetentionPeriod(ropts.RetentionPeriod()*2))))
+	require.False(t, IsLiveUpdate(existing, existing.SetRetentionOptions(
+		ropts.SetBlockSize(ropts.BlockSize()/2))))
+	require.False(t, IsLiveUpdate(existing, existing.SetFlushEnabled(!existing.FlushEnabled())))
+}
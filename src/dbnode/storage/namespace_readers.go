@@ -164,8 +164,31 @@ func (m *namespaceReaderManager) filesetExistsAt(
 	shard uint32,
 	blockStart time.Time,
 ) (bool, error) {
-	return m.filesetExistsAtFn(m.fsOpts.FilePathPrefix(),
+	_, exists, err := m.filesetStartAt(shard, blockStart)
+	return exists, err
+}
+
+// filesetStartAt returns the start of the fileset holding the data for the given
+// block start, which is the start of the compacted fileset if the block has been
+// compacted into a larger block.
+func (m *namespaceReaderManager) filesetStartAt(
+	shard uint32,
+	blockStart time.Time,
+) (time.Time, bool, error) {
+	exists, err := m.filesetExistsAtFn(m.fsOpts.FilePathPrefix(),
 		m.namespace.ID(), shard, blockStart)
+	if err != nil || exists {
+		return blockStart, exists, err
+	}
+
+	compactionOpts := m.namespace.Options().CompactionOptions()
+	compactedStart := blockStart.Truncate(compactionOpts.BlockSize())
+	if !compactionOpts.Enabled() || compactedStart.Equal(blockStart) {
+		return blockStart, false, nil
+	}
+	exists, err = m.filesetExistsAtFn(m.fsOpts.FilePathPrefix(),
+		m.namespace.ID(), shard, compactedStart)
+	return compactedStart, exists, err
 }
 
 type cachedReaderForKeyResult struct {
@@ -251,12 +274,17 @@ func (m *namespaceReaderManager) get(
 	// We have a closed reader from the cache (either a cached closed
 	// reader or newly allocated, either way need to prepare it)
 	reader := lookup.closedReader
+	filesetStart, _, err := m.filesetStartAt(shard, blockStart)
+	if err != nil {
+		return nil, err
+	}
 	openOpts := fs.DataReaderOpenOptions{
 		Identifier: fs.FileSetFileIdentifier{
 			Namespace:  m.namespace.ID(),
 			Shard:      shard,
-			BlockStart: blockStart,
+			BlockStart: filesetStart,
 		},
+		SubBlockStart: blockStart,
 	}
 	if err := reader.Open(openOpts); err != nil {
 		return nil, err
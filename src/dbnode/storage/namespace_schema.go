@@ -0,0 +1,45 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"github.com/m3db/m3/src/dbnode/encoding/proto"
+	"github.com/m3db/m3/src/dbnode/storage/block"
+	"github.com/m3db/m3/src/dbnode/storage/series"
+)
+
+// newSchemaSeriesOptions returns series options that encode the values of a
+// namespace with a declared schema as protobuf messages rather than M3TSZ.
+// The namespace gets its own encoder, iterator and block pools so that
+// buffer merges and block merges use the protobuf encoding as well.
+func newSchemaSeriesOptions(
+	seriesOpts series.Options,
+	schema *proto.Schema,
+	opts Options,
+) series.Options {
+	iopts := opts.InstrumentOptions()
+	iopts = iopts.SetMetricsScope(iopts.MetricsScope().SubScope("proto-encoding"))
+	blockOpts := block.NewSchemaOptions(seriesOpts.DatabaseBlockOptions(), schema, iopts)
+	return seriesOpts.
+		SetDatabaseBlockOptions(blockOpts).
+		SetEncoderPool(blockOpts.EncoderPool()).
+		SetMultiReaderIteratorPool(blockOpts.MultiReaderIteratorPool())
+}
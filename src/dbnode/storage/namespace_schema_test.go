@@ -0,0 +1,100 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/ts"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/protoc-gen-gogo/descriptor"
+	"github.com/stretchr/testify/require"
+)
+
+func testSchemaFileDescriptorSet(t *testing.T) []byte {
+	fds := &descriptor.FileDescriptorSet{
+		File: []*descriptor.FileDescriptorProto{
+			{
+				Name:    proto.String("event.proto"),
+				Package: proto.String("test"),
+				MessageType: []*descriptor.DescriptorProto{
+					{
+						Name: proto.String("Event"),
+						Field: []*descriptor.FieldDescriptorProto{
+							{
+								Name:   proto.String("status"),
+								Number: proto.Int32(1),
+								Type:   descriptor.FieldDescriptorProto_TYPE_INT64.Enum(),
+								Label:  descriptor.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	b, err := proto.Marshal(fds)
+	require.NoError(t, err)
+	return b
+}
+
+func TestNamespaceSchemaUsesProtoEncoding(t *testing.T) {
+	sopts := defaultTestNs1Opts.SchemaOptions().
+		SetFileDescriptorSet(testSchemaFileDescriptorSet(t)).
+		SetMessageName("test.Event")
+	ns, closer := newTestNamespaceWithIDOpts(t, defaultTestNs1ID,
+		defaultTestNs1Opts.SetSchemaOptions(sopts))
+	defer closer()
+
+	var (
+		now   = time.Now().Truncate(time.Second)
+		dp    = ts.Datapoint{Timestamp: now, Value: 1}
+		valid = proto.NewBuffer(nil)
+		other = proto.NewBuffer(nil)
+	)
+	require.NoError(t, valid.EncodeVarint(1<<3))
+	require.NoError(t, valid.EncodeVarint(200))
+	require.NoError(t, other.EncodeVarint(2<<3))
+	require.NoError(t, other.EncodeVarint(200))
+
+	enc := ns.seriesOpts.EncoderPool().Get()
+	enc.Reset(now, 0)
+	require.NoError(t, enc.Encode(dp, xtime.Second, valid.Bytes()))
+	require.Error(t, enc.Encode(dp, xtime.Second, other.Bytes()))
+	enc.Close()
+
+	enc = ns.seriesOpts.DatabaseBlockOptions().EncoderPool().Get()
+	enc.Reset(now, 0)
+	require.Error(t, enc.Encode(dp, xtime.Second, other.Bytes()))
+	enc.Close()
+
+	// Namespaces without a schema keep encoding with M3TSZ.
+	m3tszNs, m3tszCloser := newTestNamespace(t)
+	defer m3tszCloser()
+
+	enc = m3tszNs.seriesOpts.EncoderPool().Get()
+	enc.Reset(now, 0)
+	require.NoError(t, enc.Encode(dp, xtime.Second, other.Bytes()))
+	enc.Close()
+}
@@ -28,20 +28,24 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/cluster/shard"
+	"github.com/m3db/m3/src/dbnode/clock"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/runtime"
 	"github.com/m3db/m3/src/dbnode/sharding"
+	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	"github.com/m3db/m3/src/dbnode/storage/repair"
+	"github.com/m3db/m3/src/dbnode/storage/series"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/dbnode/x/metrics"
 	"github.com/m3db/m3x/context"
 	xerrors "github.com/m3db/m3x/errors"
 	"github.com/m3db/m3x/ident"
+	"github.com/m3db/m3x/instrument"
 	xtest "github.com/m3db/m3x/test"
 	xtime "github.com/m3db/m3x/time"
 
@@ -343,6 +347,40 @@ func TestNamespaceBootstrapOnlyNonBootstrappedShards(t *testing.T) {
 	require.Equal(t, Bootstrapped, ns.bootstrapState)
 }
 
+func TestNamespaceUpdateOptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	idx := NewMocknamespaceIndex(ctrl)
+	ns, closer := newTestNamespaceWithIndex(t, idx)
+	defer closer()
+
+	ropts := defaultTestNs1Opts.RetentionOptions()
+	ropts = ropts.SetRetentionPeriod(2 * ropts.RetentionPeriod())
+	for i := range testShardIDs {
+		shard := NewMockdatabaseShard(ctrl)
+		shard.EXPECT().UpdateSeriesOptions(gomock.Any()).Do(func(opts series.Options) {
+			require.Equal(t, ropts, opts.RetentionOptions())
+		})
+		ns.shards[testShardIDs[i].ID()] = shard
+	}
+	idx.EXPECT().SetRetentionPeriod(ropts.RetentionPeriod())
+
+	nopts := defaultTestNs1Opts.SetRetentionOptions(ropts)
+	require.NoError(t, ns.UpdateOptions(nopts))
+	require.Equal(t, nopts, ns.Options())
+	require.Equal(t, ropts, ns.seriesOpts.RetentionOptions())
+}
+
+func TestNamespaceUpdateOptionsNotLive(t *testing.T) {
+	ns, closer := newTestNamespace(t)
+	defer closer()
+
+	nopts := defaultTestNs1Opts.SetRepairEnabled(!defaultTestNs1Opts.RepairEnabled())
+	require.Equal(t, errNamespaceUpdateNotLive, ns.UpdateOptions(nopts))
+	require.Equal(t, defaultTestNs1Opts, ns.Options())
+}
+
 func TestNamespaceFlushNotBootstrapped(t *testing.T) {
 	ns, closer := newTestNamespace(t)
 	defer closer()
@@ -1043,6 +1081,50 @@ func TestNamespaceCloseDoesNotLeak(t *testing.T) {
 	require.Empty(t, ns.GetOwnedShards())
 }
 
+func TestNamespaceWiredListMaxBytes(t *testing.T) {
+	metadata, err := namespace.NewMetadata(defaultTestNs1ID, defaultTestNs1Opts)
+	require.NoError(t, err)
+	hashFn := func(identifier ident.ID) uint32 { return testShardIDs[0].ID() }
+	shardSet, err := sharding.NewShardSet(testShardIDs, hashFn)
+	require.NoError(t, err)
+
+	runtimeOptsMgr := runtime.NewOptionsManager()
+	dbWiredList := block.NewWiredList(block.WiredListOptions{
+		RuntimeOptionsManager: runtimeOptsMgr,
+		InstrumentOptions:     instrument.NewOptions(),
+		ClockOptions:          clock.NewOptions(),
+		AdmissionPolicy:       block.WiredListAdmitTinyLFU,
+	})
+	dopts := testDatabaseOptions().SetRuntimeOptionsManager(runtimeOptsMgr)
+	dopts = dopts.
+		SetDatabaseBlockOptions(dopts.DatabaseBlockOptions().SetWiredList(dbWiredList)).
+		SetNamespaceWiredListMaxBytes(map[string]int64{
+			defaultTestNs1ID.String(): 1024,
+		})
+
+	dbNs, err := newDatabaseNamespace(metadata, shardSet, nil, nil, nil, dopts)
+	require.NoError(t, err)
+	ns := dbNs.(*dbNamespace)
+
+	// The namespace has a wired list of its own that the series use.
+	require.NotNil(t, ns.wiredList)
+	require.True(t, ns.wiredList != dbWiredList)
+	require.True(t, ns.seriesOpts.DatabaseBlockOptions().WiredList() == ns.wiredList)
+	require.Equal(t, int64(1024), ns.wiredList.Options().MaxBytes)
+	require.Equal(t, block.WiredListAdmitTinyLFU, ns.wiredList.Options().AdmissionPolicy)
+
+	require.NoError(t, ns.Close())
+
+	// Namespaces without a budget share the database wired list.
+	dopts = dopts.SetNamespaceWiredListMaxBytes(nil)
+	dbNs, err = newDatabaseNamespace(metadata, shardSet, nil, nil, nil, dopts)
+	require.NoError(t, err)
+	ns = dbNs.(*dbNamespace)
+	require.Nil(t, ns.wiredList)
+	require.True(t, ns.seriesOpts.DatabaseBlockOptions().WiredList() == dbWiredList)
+	require.NoError(t, ns.Close())
+}
+
 func TestNamespaceIndexInsert(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1088,6 +1170,71 @@ func TestNamespaceIndexQuery(t *testing.T) {
 	require.NoError(t, ns.Close())
 }
 
+func TestNamespaceIndexCardinality(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	idx := NewMocknamespaceIndex(ctrl)
+	ns, closer := newTestNamespaceWithIndex(t, idx)
+	defer closer()
+
+	ctx := context.NewContext()
+	opts := index.CardinalityOptions{Limit: 1, MaxTerms: 1}
+	expected := []index.CardinalityResult{{NumSeries: 3}}
+
+	idx.EXPECT().Cardinality(ctx, opts).Return(expected, nil)
+	results, err := ns.Cardinality(ctx, opts)
+	require.NoError(t, err)
+	require.Equal(t, expected, results)
+
+	idx.EXPECT().Close().Return(nil)
+	require.NoError(t, ns.Close())
+}
+
+func TestNamespaceIndexDisabledCardinality(t *testing.T) {
+	ns, closer := newTestNamespace(t)
+	defer closer()
+
+	ctx := context.NewContext()
+	_, err := ns.Cardinality(ctx, index.CardinalityOptions{Limit: 1, MaxTerms: 1})
+	require.Equal(t, errNamespaceIndexingDisabled, err)
+
+	require.NoError(t, ns.Close())
+}
+
+func TestNamespaceIndexQueryCost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	idx := NewMocknamespaceIndex(ctrl)
+	ns, closer := newTestNamespaceWithIndex(t, idx)
+	defer closer()
+
+	ctx := context.NewContext()
+	query := index.Query{}
+	opts := index.QueryCostOptions{}
+	expected := []index.QueryCostResult{{BlockStart: time.Now()}}
+
+	idx.EXPECT().QueryCost(ctx, query, opts).Return(expected, nil)
+	results, err := ns.QueryCost(ctx, query, opts)
+	require.NoError(t, err)
+	require.Equal(t, expected, results)
+
+	idx.EXPECT().Close().Return(nil)
+	require.NoError(t, ns.Close())
+}
+
+func TestNamespaceIndexDisabledQueryCost(t *testing.T) {
+	ns, closer := newTestNamespace(t)
+	defer closer()
+
+	ctx := context.NewContext()
+	_, err := ns.QueryCost(ctx, index.Query{}, index.QueryCostOptions{})
+	require.Equal(t, errNamespaceIndexingDisabled, err)
+
+	require.NoError(t, ns.Close())
+}
+
 func TestNamespaceTicksIndex(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -0,0 +1,168 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/runtime"
+	m3dberrors "github.com/m3db/m3/src/dbnode/storage/errors"
+	"github.com/m3db/m3x/ident"
+
+	"github.com/uber-go/tally"
+)
+
+var (
+	errNamespaceDatapointsQuotaExceeded = errors.New(
+		"namespace datapoints per second quota exceeded")
+	errNamespaceNewSeriesQuotaExceeded = errors.New(
+		"namespace new series per second quota exceeded")
+	errNamespaceMaxSeriesQuotaExceeded = errors.New(
+		"namespace max series quota exceeded")
+)
+
+// namespaceWriteQuota enforces the write quota of a namespace, the quota is
+// updated by runtime options and limits are enforced over one second windows.
+type namespaceWriteQuota struct {
+	sync.Mutex
+
+	id      ident.ID
+	nowFn   clock.NowFn
+	quota   runtime.WriteQuota
+	metrics namespaceWriteQuotaMetrics
+
+	datapointsWindowNanos  int64
+	datapointsWindowValues int64
+	newSeriesWindowNanos   int64
+	newSeriesWindowValues  int64
+
+	// numSeries is the number of series as of the last tick plus the
+	// number of new series admitted since the last tick.
+	numSeries int64
+}
+
+type namespaceWriteQuotaMetrics struct {
+	datapointsExceeded tally.Counter
+	newSeriesExceeded  tally.Counter
+	maxSeriesExceeded  tally.Counter
+}
+
+func newNamespaceWriteQuotaMetrics(scope tally.Scope) namespaceWriteQuotaMetrics {
+	scope = scope.SubScope("write-quota")
+	return namespaceWriteQuotaMetrics{
+		datapointsExceeded: scope.Tagged(map[string]string{
+			"reason": "datapoints-per-second",
+		}).Counter("exceeded"),
+		newSeriesExceeded: scope.Tagged(map[string]string{
+			"reason": "new-series-per-second",
+		}).Counter("exceeded"),
+		maxSeriesExceeded: scope.Tagged(map[string]string{
+			"reason": "max-series",
+		}).Counter("exceeded"),
+	}
+}
+
+func newNamespaceWriteQuota(
+	id ident.ID,
+	nowFn clock.NowFn,
+	scope tally.Scope,
+) *namespaceWriteQuota {
+	return &namespaceWriteQuota{
+		id:      id,
+		nowFn:   nowFn,
+		metrics: newNamespaceWriteQuotaMetrics(scope),
+	}
+}
+
+func (q *namespaceWriteQuota) SetRuntimeOptions(value runtime.Options) {
+	quota := value.NamespaceWriteQuotas()[q.id.String()]
+	q.Lock()
+	q.quota = quota
+	q.Unlock()
+}
+
+// AllowWrite returns an error if writing a datapoint would exceed the
+// datapoints per second quota.
+func (q *namespaceWriteQuota) AllowWrite() error {
+	windowNanos := q.nowFn().Truncate(time.Second).UnixNano()
+
+	q.Lock()
+	limit := q.quota.DatapointsPerSecond
+	if limit <= 0 {
+		q.Unlock()
+		return nil
+	}
+	if q.datapointsWindowNanos != windowNanos {
+		// Rolled into to a new window
+		q.datapointsWindowNanos = windowNanos
+		q.datapointsWindowValues = 0
+	}
+	q.datapointsWindowValues++
+	exceeded := q.datapointsWindowValues > limit
+	q.Unlock()
+
+	if exceeded {
+		q.metrics.datapointsExceeded.Inc(1)
+		return m3dberrors.NewResourceExhaustedError(errNamespaceDatapointsQuotaExceeded)
+	}
+	return nil
+}
+
+// AllowNewSeries returns an error if inserting a new series would exceed
+// either the new series per second quota or the max series quota.
+func (q *namespaceWriteQuota) AllowNewSeries() error {
+	windowNanos := q.nowFn().Truncate(time.Second).UnixNano()
+
+	q.Lock()
+	quota := q.quota
+	if max := quota.MaxSeries; max > 0 && q.numSeries >= max {
+		q.Unlock()
+		q.metrics.maxSeriesExceeded.Inc(1)
+		return m3dberrors.NewResourceExhaustedError(errNamespaceMaxSeriesQuotaExceeded)
+	}
+	if limit := quota.NewSeriesPerSecond; limit > 0 {
+		if q.newSeriesWindowNanos != windowNanos {
+			// Rolled into to a new window
+			q.newSeriesWindowNanos = windowNanos
+			q.newSeriesWindowValues = 0
+		}
+		q.newSeriesWindowValues++
+		if q.newSeriesWindowValues > limit {
+			q.Unlock()
+			q.metrics.newSeriesExceeded.Inc(1)
+			return m3dberrors.NewResourceExhaustedError(errNamespaceNewSeriesQuotaExceeded)
+		}
+	}
+	q.numSeries++
+	q.Unlock()
+	return nil
+}
+
+// SetNumSeries resets the number of series the max series quota is
+// enforced against, it is called with the number of active series on tick.
+func (q *namespaceWriteQuota) SetNumSeries(value int64) {
+	q.Lock()
+	q.numSeries = value
+	q.Unlock()
+}
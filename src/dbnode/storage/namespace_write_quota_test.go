@@ -0,0 +1,111 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/runtime"
+	m3dberrors "github.com/m3db/m3/src/dbnode/storage/errors"
+	"github.com/m3db/m3x/ident"
+
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+)
+
+func newTestNamespaceWriteQuota(
+	quota runtime.WriteQuota,
+) (*namespaceWriteQuota, *time.Time) {
+	now := time.Now().Truncate(time.Second)
+	nowFn := func() time.Time { return now }
+	q := newNamespaceWriteQuota(ident.StringID("testns"), nowFn, tally.NoopScope)
+	q.SetRuntimeOptions(runtime.NewOptions().
+		SetNamespaceWriteQuotas(map[string]runtime.WriteQuota{
+			"testns": quota,
+		}))
+	return q, &now
+}
+
+func TestNamespaceWriteQuotaUnlimited(t *testing.T) {
+	q, _ := newTestNamespaceWriteQuota(runtime.WriteQuota{})
+	for i := 0; i < 100; i++ {
+		require.NoError(t, q.AllowWrite())
+		require.NoError(t, q.AllowNewSeries())
+	}
+}
+
+func TestNamespaceWriteQuotaOtherNamespaceNotLimited(t *testing.T) {
+	q, _ := newTestNamespaceWriteQuota(runtime.WriteQuota{})
+	q.SetRuntimeOptions(runtime.NewOptions().
+		SetNamespaceWriteQuotas(map[string]runtime.WriteQuota{
+			"otherns": {DatapointsPerSecond: 1},
+		}))
+	require.NoError(t, q.AllowWrite())
+	require.NoError(t, q.AllowWrite())
+}
+
+func TestNamespaceWriteQuotaDatapointsPerSecond(t *testing.T) {
+	q, now := newTestNamespaceWriteQuota(runtime.WriteQuota{
+		DatapointsPerSecond: 2,
+	})
+
+	require.NoError(t, q.AllowWrite())
+	require.NoError(t, q.AllowWrite())
+	err := q.AllowWrite()
+	require.Error(t, err)
+	require.True(t, m3dberrors.IsResourceExhaustedError(err))
+
+	// Rolling into the next window resets the quota
+	*now = now.Add(time.Second)
+	require.NoError(t, q.AllowWrite())
+}
+
+func TestNamespaceWriteQuotaNewSeriesPerSecond(t *testing.T) {
+	q, now := newTestNamespaceWriteQuota(runtime.WriteQuota{
+		NewSeriesPerSecond: 1,
+	})
+
+	require.NoError(t, q.AllowNewSeries())
+	err := q.AllowNewSeries()
+	require.Error(t, err)
+	require.True(t, m3dberrors.IsResourceExhaustedError(err))
+
+	*now = now.Add(time.Second)
+	require.NoError(t, q.AllowNewSeries())
+}
+
+func TestNamespaceWriteQuotaMaxSeries(t *testing.T) {
+	q, _ := newTestNamespaceWriteQuota(runtime.WriteQuota{
+		MaxSeries: 2,
+	})
+
+	require.NoError(t, q.AllowNewSeries())
+	require.NoError(t, q.AllowNewSeries())
+	err := q.AllowNewSeries()
+	require.Error(t, err)
+	require.True(t, m3dberrors.IsResourceExhaustedError(err))
+
+	// Series expiring on tick frees up room for new series
+	q.SetNumSeries(1)
+	require.NoError(t, q.AllowNewSeries())
+	require.Error(t, q.AllowNewSeries())
+}
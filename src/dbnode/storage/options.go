@@ -114,6 +114,7 @@ type options struct {
 	instrumentOpts                 instrument.Options
 	nsRegistryInitializer          namespace.Initializer
 	blockOpts                      block.Options
+	nsWiredListMaxBytes            map[string]int64
 	commitLogOpts                  commitlog.Options
 	runtimeOptsMgr                 m3dbruntime.OptionsManager
 	errCounterOpts                 xcounter.Options
@@ -296,6 +297,16 @@ func (o *options) DatabaseBlockOptions() block.Options {
 	return o.blockOpts
 }
 
+func (o *options) SetNamespaceWiredListMaxBytes(value map[string]int64) Options {
+	opts := *o
+	opts.nsWiredListMaxBytes = value
+	return &opts
+}
+
+func (o *options) NamespaceWiredListMaxBytes() map[string]int64 {
+	return o.nsWiredListMaxBytes
+}
+
 func (o *options) SetCommitLogOptions(value commitlog.Options) Options {
 	opts := *o
 	opts.commitLogOpts = value
@@ -30,6 +30,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	m3dberrors "github.com/m3db/m3/src/dbnode/storage/errors"
+	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/dbnode/x/xio"
 	"github.com/m3db/m3x/context"
@@ -616,6 +617,20 @@ func (b *dbBufferBucket) write(
 				// profile is lean as a side effect of this write being a no-op.
 				return nil
 			}
+			switch b.opts.DuplicatePolicy() {
+			case namespace.DuplicatePolicyMaxValue:
+				if value < last.Value {
+					// No-op since the existing value is retained when merged.
+					return nil
+				}
+			case namespace.DuplicatePolicyMinValue:
+				if value > last.Value {
+					// No-op since the existing value is retained when merged.
+					return nil
+				}
+			case namespace.DuplicatePolicyRejectDuplicate:
+				return m3dberrors.ErrDuplicateWrite
+			}
 			continue
 		}
 
@@ -625,11 +640,13 @@ func (b *dbBufferBucket) write(
 		}
 	}
 
-	// Upsert/last-write-wins semantics.
+	// Upsert semantics.
 	// NB(r): We push datapoints with the same timestamp but differing
 	// value into a new encoder later in the stack of in order encoders
 	// since an encoder is immutable.
-	// The encoders pushed later will surface their values first.
+	// The duplicate policy of the namespace decides which of the values
+	// surfaces when the encoders are merged, with last-write-wins the
+	// encoders pushed later will surface their values first.
 	if idx != -1 {
 		return b.writeToEncoderIndex(idx, datapoint, unit, annotation)
 	}
@@ -674,6 +691,17 @@ func (b *dbBufferBucket) writeToEncoderIndex(
 }
 
 func (b *dbBufferBucket) streams(ctx context.Context) []xio.BlockReader {
+	// NB: readers of the streams resolve values with equal timestamps by
+	// taking the value of the last stream, so unless the namespace uses
+	// last-write-wins merge the streams here to apply its duplicate policy
+	// on read as well as when the bucket is merged.
+	if b.opts.DuplicatePolicy() != namespace.DuplicatePolicyLastWriteWins &&
+		b.needsMerge() {
+		if stream, ok := b.mergedStream(ctx); ok {
+			return []xio.BlockReader{stream}
+		}
+	}
+
 	streams := make([]xio.BlockReader, 0, len(b.bootstrapped)+len(b.encoders))
 
 	for i := range b.bootstrapped {
@@ -701,6 +729,28 @@ func (b *dbBufferBucket) streams(ctx context.Context) []xio.BlockReader {
 	return streams
 }
 
+func (b *dbBufferBucket) mergedStream(ctx context.Context) (xio.BlockReader, bool) {
+	encoder, _, _, err := b.mergedEncoder()
+	if err != nil {
+		// Fall back to the unmerged streams.
+		return xio.BlockReader{}, false
+	}
+
+	segment := encoder.Discard()
+	if segment.Len() == 0 {
+		segment.Finalize()
+		return xio.BlockReader{}, false
+	}
+
+	s := xio.NewSegmentReader(segment)
+	ctx.RegisterFinalizer(s)
+	return xio.BlockReader{
+		SegmentReader: s,
+		Start:         b.start,
+		BlockSize:     b.opts.RetentionOptions().BlockSize(),
+	}, true
+}
+
 func (b *dbBufferBucket) streamsLen() int {
 	length := 0
 	for i := range b.bootstrapped {
@@ -764,6 +814,26 @@ func (b *dbBufferBucket) merge() (mergeResult, error) {
 		return mergeResult{}, nil
 	}
 
+	encoder, lastWriteAt, merges, err := b.mergedEncoder()
+	if err != nil {
+		return mergeResult{}, err
+	}
+
+	b.resetEncoders()
+	b.resetBootstrapped()
+
+	b.encoders = append(b.encoders, inOrderEncoder{
+		encoder:     encoder,
+		lastWriteAt: lastWriteAt,
+	})
+
+	return mergeResult{merges: merges}, nil
+}
+
+// mergedEncoder returns a new encoder with the values of the bootstrapped
+// blocks and encoders of the bucket merged using the duplicate policy of
+// the namespace, it leaves the bucket untouched.
+func (b *dbBufferBucket) mergedEncoder() (encoding.Encoder, time.Time, int, error) {
 	merges := 0
 	bopts := b.opts.DatabaseBlockOptions()
 	encoder := bopts.EncoderPool().Get()
@@ -807,26 +877,22 @@ func (b *dbBufferBucket) merge() (mergeResult, error) {
 
 	var lastWriteAt time.Time
 	iter.Reset(readers, start, b.opts.RetentionOptions().BlockSize())
+	iter.SetIterateEqualTimestampStrategy(
+		b.opts.DuplicatePolicy().IterateEqualTimestampStrategy())
 	for iter.Next() {
 		dp, unit, annotation := iter.Current()
 		if err := encoder.Encode(dp, unit, annotation); err != nil {
-			return mergeResult{}, err
+			encoder.Close()
+			return nil, time.Time{}, 0, err
 		}
 		lastWriteAt = dp.Timestamp
 	}
 	if err := iter.Err(); err != nil {
-		return mergeResult{}, err
+		encoder.Close()
+		return nil, time.Time{}, 0, err
 	}
 
-	b.resetEncoders()
-	b.resetBootstrapped()
-
-	b.encoders = append(b.encoders, inOrderEncoder{
-		encoder:     encoder,
-		lastWriteAt: lastWriteAt,
-	})
-
-	return mergeResult{merges: merges}, nil
+	return encoder, lastWriteAt, merges, nil
 }
 
 type discardMergedResult struct {
@@ -30,6 +30,8 @@ import (
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
 	"github.com/m3db/m3/src/dbnode/storage/block"
+	m3dberrors "github.com/m3db/m3/src/dbnode/storage/errors"
+	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/dbnode/x/xio"
 	"github.com/m3db/m3x/context"
@@ -607,6 +609,90 @@ func TestBufferBucketWriteDuplicateUpserts(t *testing.T) {
 	assertValuesEqual(t, expected, results, opts)
 }
 
+func TestBufferBucketWriteDuplicatePolicies(t *testing.T) {
+	curr := time.Now().Truncate(newBufferTestOptions().RetentionOptions().BlockSize())
+	data := []value{
+		{curr.Add(secs(10)), 5, xtime.Second, nil},
+		{curr.Add(secs(20)), 2, xtime.Second, nil},
+		{curr.Add(secs(10)), 3, xtime.Second, nil},
+		{curr.Add(secs(10)), 7, xtime.Second, nil},
+		{curr.Add(secs(20)), 1, xtime.Second, nil},
+	}
+
+	tests := []struct {
+		policy   namespace.DuplicatePolicy
+		rejected map[int]bool
+		expected []value
+	}{
+		{
+			policy: namespace.DuplicatePolicyLastWriteWins,
+			expected: []value{
+				{curr.Add(secs(10)), 7, xtime.Second, nil},
+				{curr.Add(secs(20)), 1, xtime.Second, nil},
+			},
+		},
+		{
+			policy: namespace.DuplicatePolicyMaxValue,
+			expected: []value{
+				{curr.Add(secs(10)), 7, xtime.Second, nil},
+				{curr.Add(secs(20)), 2, xtime.Second, nil},
+			},
+		},
+		{
+			policy: namespace.DuplicatePolicyMinValue,
+			expected: []value{
+				{curr.Add(secs(10)), 3, xtime.Second, nil},
+				{curr.Add(secs(20)), 1, xtime.Second, nil},
+			},
+		},
+		{
+			// NB: The third write is not rejected as the duplicate is not
+			// for the last value written to any of the encoders, instead the
+			// first value is retained when the encoders are merged.
+			policy:   namespace.DuplicatePolicyRejectDuplicate,
+			rejected: map[int]bool{3: true, 4: true},
+			expected: []value{
+				{curr.Add(secs(10)), 5, xtime.Second, nil},
+				{curr.Add(secs(20)), 2, xtime.Second, nil},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.policy.String(), func(t *testing.T) {
+			opts := newBufferTestOptions().SetDuplicatePolicy(test.policy)
+			b := &dbBufferBucket{opts: opts}
+			b.resetTo(curr)
+
+			for i, value := range data {
+				err := b.write(value.timestamp, value.value,
+					value.unit, value.annotation)
+				if test.rejected[i] {
+					require.Equal(t, m3dberrors.ErrDuplicateWrite, err)
+					continue
+				}
+				require.NoError(t, err)
+			}
+
+			ctx := context.NewContext()
+			defer ctx.Close()
+
+			// Assert the policy applies when reading the unmerged bucket.
+			results := [][]xio.BlockReader{b.streams(ctx)}
+			assertValuesEqual(t, test.expected, results, opts)
+
+			mergeResult, err := b.discardMerged()
+			require.NoError(t, err)
+
+			stream, err := mergeResult.block.Stream(ctx)
+			require.NoError(t, err)
+
+			results = [][]xio.BlockReader{[]xio.BlockReader{stream}}
+			assertValuesEqual(t, test.expected, results, opts)
+		})
+	}
+}
+
 func TestBufferFetchBlocks(t *testing.T) {
 	b, opts, expected := newTestBufferBucketWithData(t)
 	ctx := opts.ContextPool().Get()
@@ -25,6 +25,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/storage/block"
+	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	"github.com/m3db/m3x/context"
 	"github.com/m3db/m3x/ident"
 	"github.com/m3db/m3x/instrument"
@@ -43,6 +44,7 @@ type options struct {
 	fetchBlockMetadataResultsPool block.FetchBlockMetadataResultsPool
 	identifierPool                ident.Pool
 	stats                         Stats
+	duplicatePolicy               namespace.DuplicatePolicy
 }
 
 // NewOptions creates new database series options
@@ -66,6 +68,7 @@ func NewOptions() Options {
 		fetchBlockMetadataResultsPool: block.NewFetchBlockMetadataResultsPool(nil, 0),
 		identifierPool:                ident.NewPool(bytesPool, ident.PoolOptions{}),
 		stats:                         NewStats(iopts.MetricsScope()),
+		duplicatePolicy:               namespace.DefaultDuplicatePolicy,
 	}
 }
 
@@ -73,6 +76,9 @@ func (o *options) Validate() error {
 	if err := o.retentionOpts.Validate(); err != nil {
 		return err
 	}
+	if err := o.duplicatePolicy.Validate(); err != nil {
+		return err
+	}
 	return ValidateCachePolicy(o.cachePolicy)
 }
 
@@ -185,3 +191,13 @@ func (o *options) SetStats(value Stats) Options {
 func (o *options) Stats() Stats {
 	return o.stats
 }
+
+func (o *options) SetDuplicatePolicy(value namespace.DuplicatePolicy) Options {
+	opts := *o
+	opts.duplicatePolicy = value
+	return &opts
+}
+
+func (o *options) DuplicatePolicy() namespace.DuplicatePolicy {
+	return o.duplicatePolicy
+}
@@ -143,7 +143,20 @@ func (r Reader) readersWithBlocksMapAndBuffer(
 		case r.retriever != nil:
 			// Try to stream from disk
 			if r.retriever.IsBlockRetrievable(blockAt) {
-				streamedBlock, err := r.retriever.Stream(ctx, r.id, blockAt, r.onRetrieve)
+				var (
+					streamedBlock xio.BlockReader
+					err           error
+				)
+				partial := blockAt.Before(start) || blockAt.Add(size).After(end)
+				if partial && !r.cachesRetrievedBlocks(cachePolicy) {
+					// Only part of the block is read and the block will not be
+					// cached so let the retriever skip decoding the rest of it
+					// if it can, blocks that are cached are read whole.
+					streamedBlock, err = r.retriever.StreamRange(ctx, r.id, blockAt,
+						start, end, r.onRetrieve)
+				} else {
+					streamedBlock, err = r.retriever.Stream(ctx, r.id, blockAt, r.onRetrieve)
+				}
 				if err != nil {
 					return nil, err
 				}
@@ -164,6 +177,19 @@ func (r Reader) readersWithBlocksMapAndBuffer(
 	return results, nil
 }
 
+// cachesRetrievedBlocks returns whether blocks retrieved from disk are
+// cached in memory for subsequent reads.
+func (r Reader) cachesRetrievedBlocks(cachePolicy CachePolicy) bool {
+	if r.onRetrieve == nil {
+		return false
+	}
+	switch cachePolicy {
+	case CacheRecentlyRead, CacheLRU:
+		return true
+	}
+	return false
+}
+
 // FetchBlocks returns data blocks given a list of block start times using
 // just a block retriever.
 func (r Reader) FetchBlocks(
@@ -82,6 +82,84 @@ func TestReaderUsingRetrieverReadEncoded(t *testing.T) {
 	}
 }
 
+func TestReaderUsingRetrieverReadEncodedPartialBlockUsesStreamRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSeriesTestOptions().SetCachePolicy(CacheNone)
+	ropts := opts.RetentionOptions()
+
+	blockStart := opts.ClockOptions().NowFn()().Truncate(ropts.BlockSize()).
+		Add(-ropts.BlockSize())
+	start := blockStart.Add(ropts.BlockSize() / 4)
+	end := blockStart.Add(ropts.BlockSize() / 2)
+
+	onRetrieveBlock := block.NewMockOnRetrieveBlock(ctrl)
+
+	retriever := NewMockQueryableBlockRetriever(ctrl)
+	retriever.EXPECT().IsBlockRetrievable(blockStart).Return(true)
+
+	blockReader := xio.BlockReader{
+		SegmentReader: xio.NewMockSegmentReader(ctrl),
+	}
+
+	ctx := opts.ContextPool().Get()
+	defer ctx.Close()
+
+	retriever.EXPECT().
+		StreamRange(ctx, ident.NewIDMatcher("foo"),
+			blockStart, start, end, onRetrieveBlock).
+		Return(blockReader, nil)
+
+	reader := NewReaderUsingRetriever(
+		ident.StringID("foo"), retriever, onRetrieveBlock, nil, opts)
+
+	r, err := reader.ReadEncoded(ctx, start, end)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(r))
+	require.Equal(t, 1, len(r[0]))
+	assert.Equal(t, blockReader, r[0][0])
+}
+
+func TestReaderUsingRetrieverReadEncodedPartialCachedBlockUsesStream(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSeriesTestOptions().SetCachePolicy(CacheRecentlyRead)
+	ropts := opts.RetentionOptions()
+
+	blockStart := opts.ClockOptions().NowFn()().Truncate(ropts.BlockSize()).
+		Add(-ropts.BlockSize())
+	start := blockStart.Add(ropts.BlockSize() / 4)
+	end := blockStart.Add(ropts.BlockSize() / 2)
+
+	onRetrieveBlock := block.NewMockOnRetrieveBlock(ctrl)
+
+	retriever := NewMockQueryableBlockRetriever(ctrl)
+	retriever.EXPECT().IsBlockRetrievable(blockStart).Return(true)
+
+	blockReader := xio.BlockReader{
+		SegmentReader: xio.NewMockSegmentReader(ctrl),
+	}
+
+	ctx := opts.ContextPool().Get()
+	defer ctx.Close()
+
+	// The whole block is read so that it can be cached.
+	retriever.EXPECT().
+		Stream(ctx, ident.NewIDMatcher("foo"), blockStart, onRetrieveBlock).
+		Return(blockReader, nil)
+
+	reader := NewReaderUsingRetriever(
+		ident.StringID("foo"), retriever, onRetrieveBlock, nil, opts)
+
+	r, err := reader.ReadEncoded(ctx, start, end)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(r))
+	require.Equal(t, 1, len(r[0]))
+	assert.Equal(t, blockReader, r[0][0])
+}
+
 func TestReaderUsingRetrieverFetchBlocks(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -119,6 +119,12 @@ func (s *dbSeries) Tags() ident.Tags {
 	return tags
 }
 
+func (s *dbSeries) UpdateOptions(opts Options) {
+	s.Lock()
+	s.opts = opts
+	s.Unlock()
+}
+
 func (s *dbSeries) Tick() (TickResult, error) {
 	var r TickResult
 
@@ -414,7 +420,11 @@ func (s *dbSeries) mergeBlockWithLock(newBlock block.DatabaseBlock) error {
 		return nil
 	}
 
-	// There is already an existing block, perform a (lazy) merge.
+	// There is already an existing block, perform a (lazy) merge which
+	// resolves values with equal timestamps using the duplicate policy.
+	strategy := s.opts.DuplicatePolicy().IterateEqualTimestampStrategy()
+	existingBlock.SetIterateEqualTimestampStrategy(strategy)
+	newBlock.SetIterateEqualTimestampStrategy(strategy)
 	return existingBlock.Merge(newBlock)
 }
 
@@ -28,6 +28,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/storage/block"
+	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	"github.com/m3db/m3/src/dbnode/x/xio"
 	"github.com/m3db/m3x/context"
 	"github.com/m3db/m3x/ident"
@@ -51,6 +52,10 @@ type DatabaseSeries interface {
 	// Tick executes any updates to ensure buffer drains, blocks are flushed, etc
 	Tick() (TickResult, error)
 
+	// UpdateOptions updates the options of the series while it is open, the
+	// options must only differ from the current options in their retention period.
+	UpdateOptions(opts Options)
+
 	// Write writes a new value
 	Write(
 		ctx context.Context,
@@ -261,6 +266,14 @@ type Options interface {
 
 	// Stats returns the configured Stats.
 	Stats() Stats
+
+	// SetDuplicatePolicy sets the policy used to resolve values written
+	// for the same timestamp.
+	SetDuplicatePolicy(value namespace.DuplicatePolicy) Options
+
+	// DuplicatePolicy returns the policy used to resolve values written
+	// for the same timestamp.
+	DuplicatePolicy() namespace.DuplicatePolicy
 }
 
 // Stats is passed down from namespace/shard to avoid allocations per series.
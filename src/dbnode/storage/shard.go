@@ -82,6 +82,19 @@ type filesetBeforeFn func(
 
 type snapshotFilesFn func(filePathPrefix string, namespace ident.ID, shard uint32) (fs.FileSetFilesSlice, error)
 
+type dataFilesFn func(filePathPrefix string, namespace ident.ID, shard uint32) (fs.FileSetFilesSlice, error)
+
+type offloadFileSetFn func(opts fs.Options, namespace ident.ID, shard uint32, blockStart time.Time) error
+
+type compactFileSetsFn func(
+	opts fs.Options,
+	namespace ident.ID,
+	shard uint32,
+	compactBefore time.Time,
+	blockSize time.Duration,
+	subBlockSize time.Duration,
+) (int, error)
+
 type tickPolicy int
 
 const (
@@ -146,6 +159,7 @@ type dbShard struct {
 	increasingIndex          increasingIndex
 	seriesPool               series.DatabaseSeriesPool
 	reverseIndex             namespaceIndex
+	writeQuota               *namespaceWriteQuota
 	insertQueue              *dbShardInsertQueue
 	lookup                   *shardMap
 	list                     *list.List
@@ -153,6 +167,9 @@ type dbShard struct {
 	filesetBeforeFn          filesetBeforeFn
 	deleteFilesFn            deleteFilesFn
 	snapshotFilesFn          snapshotFilesFn
+	dataFilesFn              dataFilesFn
+	offloadFileSetFn         offloadFileSetFn
+	compactFileSetsFn        compactFileSetsFn
 	sleepFn                  func(time.Duration)
 	identifierPool           ident.Pool
 	contextPool              context.Pool
@@ -189,6 +206,8 @@ type dbShardMetrics struct {
 	insertAsyncWriteErrors        tally.Counter
 	seriesBootstrapBlocksToBuffer tally.Counter
 	seriesBootstrapBlocksMerged   tally.Counter
+	offloadedFileSets             tally.Counter
+	compactedFileSets             tally.Counter
 }
 
 func newDatabaseShardMetrics(scope tally.Scope) dbShardMetrics {
@@ -209,6 +228,8 @@ func newDatabaseShardMetrics(scope tally.Scope) dbShardMetrics {
 		}).Counter("insert-async.errors"),
 		seriesBootstrapBlocksToBuffer: seriesBootstrapScope.Counter("blocks-to-buffer"),
 		seriesBootstrapBlocksMerged:   seriesBootstrapScope.Counter("blocks-merged"),
+		offloadedFileSets:             scope.Counter("offloaded-filesets"),
+		compactedFileSets:             scope.Counter("compacted-filesets"),
 	}
 }
 
@@ -242,6 +263,7 @@ func newDatabaseShard(
 	namespaceReaderMgr databaseNamespaceReaderManager,
 	increasingIndex increasingIndex,
 	reverseIndex namespaceIndex,
+	writeQuota *namespaceWriteQuota,
 	needsBootstrap bool,
 	opts Options,
 	seriesOpts series.Options,
@@ -260,11 +282,15 @@ func newDatabaseShard(
 		increasingIndex:    increasingIndex,
 		seriesPool:         opts.DatabaseSeriesPool(),
 		reverseIndex:       reverseIndex,
+		writeQuota:         writeQuota,
 		lookup:             newShardMap(shardMapOptions{}),
 		list:               list.New(),
 		filesetBeforeFn:    fs.DataFileSetsBefore,
 		deleteFilesFn:      fs.DeleteFiles,
 		snapshotFilesFn:    fs.SnapshotFiles,
+		dataFilesFn:        fs.DataFiles,
+		offloadFileSetFn:   fs.OffloadDataFileSet,
+		compactFileSetsFn:  fs.CompactDataFileSets,
 		sleepFn:            time.Sleep,
 		identifierPool:     opts.IdentifierPool(),
 		contextPool:        opts.ContextPool(),
@@ -320,6 +346,25 @@ func (s *dbShard) SetRuntimeOptions(value runtime.Options) {
 	s.Unlock()
 }
 
+func (s *dbShard) UpdateSeriesOptions(opts series.Options) {
+	s.Lock()
+	s.seriesOpts = opts
+	s.Unlock()
+
+	// Series that are inserted after this point are updated by the insert.
+	s.forEachShardEntry(func(entry *lookup.Entry) bool {
+		entry.Series.UpdateOptions(opts)
+		return true
+	})
+}
+
+func (s *dbShard) seriesOptions() series.Options {
+	s.RLock()
+	opts := s.seriesOpts
+	s.RUnlock()
+	return opts
+}
+
 func (s *dbShard) ID() uint32 {
 	return s.shard
 }
@@ -341,6 +386,18 @@ func (s *dbShard) Stream(
 	return s.DatabaseBlockRetriever.Stream(ctx, s.shard, id, blockStart, onRetrieve)
 }
 
+// StreamRange implements series.QueryableBlockRetriever
+func (s *dbShard) StreamRange(
+	ctx context.Context,
+	id ident.ID,
+	blockStart time.Time,
+	readStart, readEnd time.Time,
+	onRetrieve block.OnRetrieveBlock,
+) (xio.BlockReader, error) {
+	return s.DatabaseBlockRetriever.StreamRange(ctx, s.shard, id, blockStart,
+		readStart, readEnd, onRetrieve)
+}
+
 // IsBlockRetrievable implements series.QueryableBlockRetriever
 func (s *dbShard) IsBlockRetrievable(blockStart time.Time) bool {
 	flushState := s.FlushState(blockStart)
@@ -377,7 +434,7 @@ func (s *dbShard) OnRetrieveBlock(
 		return
 	}
 
-	entry, err = s.newShardEntry(id, newTagsIterArg(tags))
+	entry, err = s.newShardEntry(id, newTagsIterArg(tags), s.seriesOptions())
 	if err != nil {
 		// should never happen
 		s.logger.WithFields(
@@ -784,6 +841,13 @@ func (s *dbShard) writeAndIndex(
 
 	writable := entry != nil
 
+	// Enforce the namespace new series quota before inserting a new series
+	if !writable && s.writeQuota != nil {
+		if err := s.writeQuota.AllowNewSeries(); err != nil {
+			return ts.Series{}, err
+		}
+	}
+
 	// If no entry and we are not writing new series asynchronously
 	if !writable && !opts.writeNewSeriesAsync {
 		// Avoid double lookup by enqueueing insert immediately
@@ -912,7 +976,7 @@ func (s *dbShard) ReadEncoded(
 
 	retriever := s.seriesBlockRetriever
 	onRetrieve := s.seriesOnRetrieveBlock
-	opts := s.seriesOpts
+	opts := s.seriesOptions()
 	reader := series.NewReaderUsingRetriever(id, retriever, onRetrieve, nil, opts)
 	return reader.ReadEncoded(ctx, start, end)
 }
@@ -980,6 +1044,7 @@ func (s *dbShard) tryRetrieveWritableSeries(id ident.ID) (
 func (s *dbShard) newShardEntry(
 	id ident.ID,
 	tagsArgOpts tagsArgOptions,
+	seriesOpts series.Options,
 ) (*lookup.Entry, error) {
 	// NB(r): As documented in storage/series.DatabaseSeries the series IDs
 	// are garbage collected, hence we cast the ID to a BytesID that can't be
@@ -1034,7 +1099,7 @@ func (s *dbShard) newShardEntry(
 
 	series := s.seriesPool.Get()
 	series.Reset(seriesID, seriesTags, s.seriesBlockRetriever,
-		s.seriesOnRetrieveBlock, s, s.seriesOpts)
+		s.seriesOnRetrieveBlock, s, seriesOpts)
 	uniqueIndex := s.increasingIndex.nextIndex()
 	return lookup.NewEntry(series, uniqueIndex), nil
 }
@@ -1097,7 +1162,7 @@ func (s *dbShard) insertSeriesAsyncBatched(
 	tags ident.TagIterator,
 	opts dbShardInsertAsyncOptions,
 ) (insertAsyncResult, error) {
-	entry, err := s.newShardEntry(id, newTagsIterArg(tags))
+	entry, err := s.newShardEntry(id, newTagsIterArg(tags), s.seriesOptions())
 	if err != nil {
 		return insertAsyncResult{}, err
 	}
@@ -1154,7 +1219,7 @@ func (s *dbShard) insertSeriesSync(
 		return entry, nil
 	}
 
-	entry, err = s.newShardEntry(id, tagsArgOpts)
+	entry, err = s.newShardEntry(id, tagsArgOpts, s.seriesOpts)
 	if err != nil {
 		// should never happen
 		s.logger.WithFields(
@@ -1248,8 +1313,10 @@ func (s *dbShard) insertSeriesBatch(inserts []dbShardInsert) error {
 			return err
 		}
 
-		// Insert still pending, perform the insert
+		// Insert still pending, perform the insert, the series may have been
+		// created before the series options of the shard were last updated.
 		entry = inserts[i].entry
+		entry.Series.UpdateOptions(s.seriesOpts)
 		if s.newSeriesBootstrapped {
 			_, err := entry.Series.Bootstrap(nil)
 			if err != nil {
@@ -1365,7 +1432,7 @@ func (s *dbShard) FetchBlocks(
 
 	retriever := s.seriesBlockRetriever
 	onRetrieve := s.seriesOnRetrieveBlock
-	opts := s.seriesOpts
+	opts := s.seriesOptions()
 	// Nil for onRead callback because we don't want peer bootstrapping to impact
 	// the behavior of the LRU
 	var onReadCb block.OnReadBlock
@@ -1544,7 +1611,7 @@ func (s *dbShard) FetchBlocksMetadataV2(
 	// flushed block and work backwards.
 	var (
 		result    = s.opts.FetchBlocksMetadataResultsPool().Get()
-		ropts     = s.namespace.Options().RetentionOptions()
+		ropts     = s.seriesOptions().RetentionOptions()
 		blockSize = ropts.BlockSize()
 		// Subtract one blocksize because all fetch requests are exclusive on the end side
 		blockStart      = end.Truncate(blockSize).Add(-1 * blockSize)
@@ -1757,11 +1824,41 @@ func (s *dbShard) Bootstrap(
 		}
 		info := result.Info
 		at := xtime.FromNanoseconds(info.BlockStart)
-		fs := s.FlushState(at)
-		if fs.Status != fileOpNotStarted {
-			continue // Already recorded progress
+		blockStarts := []time.Time{at}
+		if subBlockSize := time.Duration(info.SubBlockSize); subBlockSize > 0 {
+			// Compacted filesets hold the data of every block within them.
+			end := at.Add(time.Duration(info.BlockSize))
+			for t := at.Add(subBlockSize); t.Before(end); t = t.Add(subBlockSize) {
+				blockStarts = append(blockStarts, t)
+			}
+		}
+		for _, at := range blockStarts {
+			fs := s.FlushState(at)
+			if fs.Status != fileOpNotStarted {
+				continue // Already recorded progress
+			}
+			s.markFlushStateSuccess(at)
+		}
+	}
+
+	// Filesets that have been offloaded to the object store no longer exist
+	// locally but have been flushed and must not be flushed again.
+	if store := fsOpts.ObjectStore(); store != nil {
+		offloaded, err := fs.OffloadedDataFileSetBlockStarts(store, s.namespace.ID(), s.shard)
+		if err != nil {
+			s.logger.WithFields(
+				xlog.NewField("shard", s.ID()),
+				xlog.NewField("namespace", s.namespace.ID()),
+				xlog.NewField("error", err.Error()),
+			).Error("unable to list offloaded filesets in shard bootstrap")
+			multiErr = multiErr.Add(err)
+		}
+		for _, at := range offloaded {
+			if s.FlushState(at).Status != fileOpNotStarted {
+				continue // Already recorded progress
+			}
+			s.markFlushStateSuccess(at)
 		}
-		s.markFlushStateSuccess(at)
 	}
 
 	s.Lock()
@@ -1936,7 +2033,7 @@ func (s *dbShard) markFlushStateFail(blockStart time.Time) {
 
 func (s *dbShard) removeAnyFlushStatesTooEarly(tickStart time.Time) {
 	s.flushState.Lock()
-	earliestFlush := retention.FlushTimeStart(s.namespace.Options().RetentionOptions(), tickStart)
+	earliestFlush := retention.FlushTimeStart(s.seriesOptions().RetentionOptions(), tickStart)
 	for t := range s.flushState.statesByTime {
 		if t.ToTime().Before(earliestFlush) {
 			delete(s.flushState.statesByTime, t)
@@ -2028,7 +2125,12 @@ func (s *dbShard) CleanupSnapshots(earliestToRetain time.Time) error {
 func (s *dbShard) CleanupExpiredFileSets(earliestToRetain time.Time) error {
 	filePathPrefix := s.opts.CommitLogOptions().FilesystemOptions().FilePathPrefix()
 	multiErr := xerrors.NewMultiError()
-	expired, err := s.filesetBeforeFn(filePathPrefix, s.namespace.ID(), s.ID(), earliestToRetain)
+	expiredBefore := earliestToRetain
+	if compactionOpts := s.namespace.Options().CompactionOptions(); compactionOpts.Enabled() {
+		// Compacted filesets are only expired once all the blocks within them are.
+		expiredBefore = earliestToRetain.Truncate(compactionOpts.BlockSize())
+	}
+	expired, err := s.filesetBeforeFn(filePathPrefix, s.namespace.ID(), s.ID(), expiredBefore)
 	if err != nil {
 		detailedErr :=
 			fmt.Errorf("encountered errors when getting fileset files for prefix %s namespace %s shard %d: %v",
@@ -2038,9 +2140,103 @@ func (s *dbShard) CleanupExpiredFileSets(earliestToRetain time.Time) error {
 	if err := s.deleteFilesFn(expired); err != nil {
 		multiErr = multiErr.Add(err)
 	}
+	if err := s.cleanupExpiredOffloadedFileSets(earliestToRetain); err != nil {
+		multiErr = multiErr.Add(err)
+	}
 	return multiErr.FinalError()
 }
 
+// cleanupExpiredOffloadedFileSets removes expired filesets from the object store
+// and from the local cache of filesets fetched from the object store.
+func (s *dbShard) cleanupExpiredOffloadedFileSets(earliestToRetain time.Time) error {
+	fsOpts := s.opts.CommitLogOptions().FilesystemOptions()
+	store := fsOpts.ObjectStore()
+	if store == nil {
+		return nil
+	}
+
+	multiErr := xerrors.NewMultiError()
+	cachePathPrefix := fsOpts.ObjectStoreCachePathPrefix()
+	expired, err := s.filesetBeforeFn(cachePathPrefix, s.namespace.ID(), s.ID(), earliestToRetain)
+	if err != nil {
+		detailedErr :=
+			fmt.Errorf("encountered errors when getting cached fileset files for prefix %s namespace %s shard %d: %v",
+				cachePathPrefix, s.namespace.ID(), s.ID(), err)
+		multiErr = multiErr.Add(detailedErr)
+	}
+	if err := s.deleteFilesFn(expired); err != nil {
+		multiErr = multiErr.Add(err)
+	}
+
+	offloaded, err := fs.OffloadedDataFileSetBlockStarts(store, s.namespace.ID(), s.ID())
+	if err != nil {
+		return multiErr.Add(err).FinalError()
+	}
+	for _, blockStart := range offloaded {
+		if !blockStart.Before(earliestToRetain) {
+			continue
+		}
+		err := fs.DeleteOffloadedDataFileSetAt(store, s.namespace.ID(), s.ID(), blockStart)
+		multiErr = multiErr.Add(err)
+	}
+	return multiErr.FinalError()
+}
+
+func (s *dbShard) OffloadColdFileSets(offloadBefore time.Time) error {
+	fsOpts := s.opts.CommitLogOptions().FilesystemOptions()
+	if fsOpts.ObjectStore() == nil {
+		return nil
+	}
+
+	filePathPrefix := fsOpts.FilePathPrefix()
+	filesets, err := s.dataFilesFn(filePathPrefix, s.namespace.ID(), s.ID())
+	if err != nil {
+		return fmt.Errorf("encountered errors when getting fileset files for prefix %s namespace %s shard %d: %v",
+			filePathPrefix, s.namespace.ID(), s.ID(), err)
+	}
+
+	multiErr := xerrors.NewMultiError()
+	for _, fileset := range filesets {
+		blockStart := fileset.ID.BlockStart
+		if !blockStart.Before(offloadBefore) || !fileset.HasCheckpointFile() {
+			continue
+		}
+		if err := s.offloadFileSetFn(fsOpts, s.namespace.ID(), s.ID(), blockStart); err != nil {
+			multiErr = multiErr.Add(fmt.Errorf(
+				"encountered errors when offloading fileset for namespace %s shard %d block start %v: %v",
+				s.namespace.ID(), s.ID(), blockStart, err))
+			continue
+		}
+		// Only delete the local copy once the upload has been verified.
+		if err := s.deleteFilesFn(fileset.AbsoluteFilepaths); err != nil {
+			multiErr = multiErr.Add(err)
+			continue
+		}
+		s.metrics.offloadedFileSets.Inc(1)
+	}
+	return multiErr.FinalError()
+}
+
+func (s *dbShard) CompactFileSets(compactBefore time.Time) error {
+	var (
+		fsOpts         = s.opts.CommitLogOptions().FilesystemOptions()
+		nsOpts         = s.namespace.Options()
+		compactionOpts = nsOpts.CompactionOptions()
+	)
+	if !compactionOpts.Enabled() {
+		return nil
+	}
+
+	removed, err := s.compactFileSetsFn(fsOpts, s.namespace.ID(), s.ID(), compactBefore,
+		compactionOpts.BlockSize(), nsOpts.RetentionOptions().BlockSize())
+	s.metrics.compactedFileSets.Inc(int64(removed))
+	if err != nil {
+		return fmt.Errorf("encountered errors when compacting filesets for namespace %s shard %d: %v",
+			s.namespace.ID(), s.ID(), err)
+	}
+	return nil
+}
+
 func (s *dbShard) Repair(
 	ctx context.Context,
 	tr xtime.Range,
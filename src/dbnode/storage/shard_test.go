@@ -32,6 +32,7 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/persist/fs/objectstore"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/runtime"
 	"github.com/m3db/m3/src/dbnode/storage/block"
@@ -77,7 +78,7 @@ func testDatabaseShardWithIndexFn(
 	nsReaderMgr := newNamespaceReaderManager(metadata, tally.NoopScope, opts)
 	seriesOpts := NewSeriesOptionsFromOptions(opts, defaultTestNs1Opts.RetentionOptions())
 	return newDatabaseShard(metadata, 0, nil, nsReaderMgr,
-		&testIncreasingIndex{}, idx, true, opts, seriesOpts).(*dbShard)
+		&testIncreasingIndex{}, idx, nil, true, opts, seriesOpts).(*dbShard)
 }
 
 func addMockSeries(ctrl *gomock.Controller, shard *dbShard, id ident.ID, tags ident.Tags, index uint64) *series.MockDatabaseSeries {
@@ -97,7 +98,7 @@ func TestShardDontNeedBootstrap(t *testing.T) {
 	defer closer()
 	seriesOpts := NewSeriesOptionsFromOptions(opts, testNs.Options().RetentionOptions())
 	shard := newDatabaseShard(testNs.metadata, 0, nil, nil,
-		&testIncreasingIndex{}, nil, false, opts, seriesOpts).(*dbShard)
+		&testIncreasingIndex{}, nil, nil, false, opts, seriesOpts).(*dbShard)
 	defer shard.Close()
 
 	require.Equal(t, Bootstrapped, shard.bootstrapState)
@@ -110,7 +111,7 @@ func TestShardBootstrapState(t *testing.T) {
 	defer closer()
 	seriesOpts := NewSeriesOptionsFromOptions(opts, testNs.Options().RetentionOptions())
 	shard := newDatabaseShard(testNs.metadata, 0, nil, nil,
-		&testIncreasingIndex{}, nil, false, opts, seriesOpts).(*dbShard)
+		&testIncreasingIndex{}, nil, nil, false, opts, seriesOpts).(*dbShard)
 	defer shard.Close()
 
 	require.Equal(t, Bootstrapped, shard.bootstrapState)
@@ -408,6 +409,25 @@ func addTestSeriesWithCount(shard *dbShard, id ident.ID, count int32) series.Dat
 	return series
 }
 
+func TestShardUpdateSeriesOptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := testDatabaseOptions()
+	shard := testDatabaseShard(t, opts)
+
+	ropts := shard.seriesOpts.RetentionOptions()
+	seriesOpts := shard.seriesOpts.SetRetentionOptions(
+		ropts.SetRetentionPeriod(2 * ropts.RetentionPeriod()))
+	for _, id := range []string{"foo", "bar"} {
+		series := addMockTestSeries(ctrl, shard, ident.StringID(id))
+		series.EXPECT().UpdateOptions(seriesOpts)
+	}
+
+	shard.UpdateSeriesOptions(seriesOpts)
+	require.Equal(t, seriesOpts, shard.seriesOptions())
+}
+
 func TestShardTick(t *testing.T) {
 	now := time.Now()
 	nowLock := sync.RWMutex{}
@@ -877,6 +897,134 @@ func TestShardCleanupExpiredFileSets(t *testing.T) {
 	require.Equal(t, []string{defaultTestNs1ID.String(), "0"}, deletedFiles)
 }
 
+func TestShardOffloadColdFileSets(t *testing.T) {
+	opts := testDatabaseOptions()
+	fsOpts := opts.CommitLogOptions().FilesystemOptions().
+		SetObjectStore(objectstore.NewLocalStore("unused"))
+	opts = opts.SetCommitLogOptions(opts.CommitLogOptions().SetFilesystemOptions(fsOpts))
+	shard := testDatabaseShard(t, opts)
+	defer shard.Close()
+
+	var (
+		blockSize     = 2 * time.Hour
+		offloadBefore = time.Now().Truncate(blockSize)
+		offloadOk     = offloadBefore.Add(-3 * blockSize)
+		offloadFails  = offloadBefore.Add(-2 * blockSize)
+		incomplete    = offloadBefore.Add(-blockSize)
+		notCold       = offloadBefore
+	)
+	filesetFor := func(blockStart time.Time, complete bool) fs.FileSetFile {
+		fileset := fs.FileSetFile{
+			ID: fs.FileSetFileIdentifier{BlockStart: blockStart},
+			AbsoluteFilepaths: []string{
+				fmt.Sprintf("fileset-%d-data.db", blockStart.UnixNano()),
+			},
+		}
+		if complete {
+			fileset.AbsoluteFilepaths = append(fileset.AbsoluteFilepaths,
+				fmt.Sprintf("fileset-%d-checkpoint.db", blockStart.UnixNano()))
+		}
+		return fileset
+	}
+	filesets := fs.FileSetFilesSlice{
+		filesetFor(offloadOk, true),
+		filesetFor(offloadFails, true),
+		filesetFor(incomplete, false),
+		filesetFor(notCold, true),
+	}
+	shard.dataFilesFn = func(_ string, _ ident.ID, _ uint32) (fs.FileSetFilesSlice, error) {
+		return filesets, nil
+	}
+	var offloaded []time.Time
+	shard.offloadFileSetFn = func(_ fs.Options, _ ident.ID, _ uint32, blockStart time.Time) error {
+		offloaded = append(offloaded, blockStart)
+		if blockStart.Equal(offloadFails) {
+			return errors.New("upload failed")
+		}
+		return nil
+	}
+	var deletedFiles []string
+	shard.deleteFilesFn = func(files []string) error {
+		deletedFiles = append(deletedFiles, files...)
+		return nil
+	}
+
+	require.Error(t, shard.OffloadColdFileSets(offloadBefore))
+	require.Equal(t, []time.Time{offloadOk, offloadFails}, offloaded)
+	// Only the fileset that was successfully offloaded is deleted locally.
+	require.Equal(t, filesets[0].AbsoluteFilepaths, deletedFiles)
+}
+
+func TestShardOffloadColdFileSetsNoObjectStore(t *testing.T) {
+	opts := testDatabaseOptions()
+	shard := testDatabaseShard(t, opts)
+	defer shard.Close()
+
+	shard.dataFilesFn = func(_ string, _ ident.ID, _ uint32) (fs.FileSetFilesSlice, error) {
+		require.FailNow(t, "unexpected call to list filesets")
+		return nil, nil
+	}
+	require.NoError(t, shard.OffloadColdFileSets(time.Now()))
+}
+
+func TestShardCompactFileSets(t *testing.T) {
+	opts := testDatabaseOptions()
+	shard := testDatabaseShard(t, opts)
+	defer shard.Close()
+
+	compactionOpts := namespace.NewCompactionOptions().
+		SetEnabled(true).
+		SetBlockSize(24 * time.Hour).
+		SetCompactAfter(26 * time.Hour)
+	metadata, err := namespace.NewMetadata(defaultTestNs1ID,
+		defaultTestNs1Opts.SetCompactionOptions(compactionOpts))
+	require.NoError(t, err)
+	shard.namespace = metadata
+
+	var (
+		compactBefore = time.Now()
+		calls         int
+	)
+	shard.compactFileSetsFn = func(
+		_ fs.Options,
+		namespace ident.ID,
+		shardID uint32,
+		before time.Time,
+		blockSize time.Duration,
+		subBlockSize time.Duration,
+	) (int, error) {
+		calls++
+		require.True(t, defaultTestNs1ID.Equal(namespace))
+		require.Equal(t, shard.ID(), shardID)
+		require.True(t, compactBefore.Equal(before))
+		require.Equal(t, 24*time.Hour, blockSize)
+		require.Equal(t, defaultTestRetentionOpts.BlockSize(), subBlockSize)
+		return 2, errors.New("compaction failed")
+	}
+
+	require.Error(t, shard.CompactFileSets(compactBefore))
+	require.Equal(t, 1, calls)
+}
+
+func TestShardCompactFileSetsDisabled(t *testing.T) {
+	opts := testDatabaseOptions()
+	shard := testDatabaseShard(t, opts)
+	defer shard.Close()
+
+	shard.compactFileSetsFn = func(
+		_ fs.Options,
+		_ ident.ID,
+		_ uint32,
+		_ time.Time,
+		_ time.Duration,
+		_ time.Duration,
+	) (int, error) {
+		require.FailNow(t, "unexpected call to compact filesets")
+		return 0, nil
+	}
+	require.NoError(t, shard.CompactFileSets(time.Now()))
+}
+
 func TestShardCleanupSnapshot(t *testing.T) {
 	var (
 		opts                = testDatabaseOptions()
@@ -1102,7 +1250,7 @@ func TestShardNewInvalidShardEntry(t *testing.T) {
 		iter.EXPECT().Close(),
 	)
 
-	_, err := shard.newShardEntry(ident.StringID("abc"), newTagsIterArg(iter))
+	_, err := shard.newShardEntry(ident.StringID("abc"), newTagsIterArg(iter), shard.seriesOpts)
 	require.Error(t, err)
 }
 
@@ -1113,7 +1261,7 @@ func TestShardNewValidShardEntry(t *testing.T) {
 	shard := testDatabaseShard(t, testDatabaseOptions())
 	defer shard.Close()
 
-	_, err := shard.newShardEntry(ident.StringID("abc"), newTagsIterArg(ident.EmptyTagIterator))
+	_, err := shard.newShardEntry(ident.StringID("abc"), newTagsIterArg(ident.EmptyTagIterator), shard.seriesOpts)
 	require.NoError(t, err)
 }
 
@@ -1148,7 +1296,7 @@ func TestShardNewEntryDoesNotAlterIDOrTags(t *testing.T) {
 		Times(1).
 		Return(ident.NewTagsIterator(seriesTags))
 
-	entry, err := shard.newShardEntry(id, newTagsIterArg(iter))
+	entry, err := shard.newShardEntry(id, newTagsIterArg(iter), shard.seriesOpts)
 	require.NoError(t, err)
 
 	shard.Lock()
@@ -1209,7 +1357,7 @@ func TestShardNewEntryTakesRefToNoFinalizeID(t *testing.T) {
 		Times(1).
 		Return(ident.NewTagsIterator(seriesTags))
 
-	entry, err := shard.newShardEntry(id, newTagsIterArg(iter))
+	entry, err := shard.newShardEntry(id, newTagsIterArg(iter), shard.seriesOpts)
 	require.NoError(t, err)
 
 	shard.Lock()
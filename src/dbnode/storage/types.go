@@ -28,6 +28,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/clock"
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs/backup"
 	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
 	"github.com/m3db/m3/src/dbnode/runtime"
 	"github.com/m3db/m3/src/dbnode/sharding"
@@ -139,6 +140,23 @@ type Database interface {
 		opts index.QueryOptions,
 	) (index.QueryResults, error)
 
+	// Cardinality returns the cardinality of the series of the given
+	// namespace for each index block within the time range.
+	Cardinality(
+		ctx context.Context,
+		namespace ident.ID,
+		opts index.CardinalityOptions,
+	) ([]index.CardinalityResult, error)
+
+	// QueryCost returns the postings list sizes of the terms of a query
+	// of the given namespace for each index block within the time range.
+	QueryCost(
+		ctx context.Context,
+		namespace ident.ID,
+		query index.Query,
+		opts index.QueryCostOptions,
+	) ([]index.QueryCostResult, error)
+
 	// ReadEncoded retrieves encoded segments for an ID
 	ReadEncoded(
 		ctx context.Context,
@@ -189,6 +207,10 @@ type Database interface {
 	// Truncate truncates data for the given namespace
 	Truncate(namespace ident.ID) (int64, error)
 
+	// Backup snapshots all shards and copies the filesets of the database
+	// to the destination path, returning the manifest of the backup.
+	Backup(destinationPath string) (backup.Manifest, error)
+
 	// BootstrapState captures and returns a snapshot of the databases' bootstrap state.
 	BootstrapState() DatabaseBootstrapState
 }
@@ -217,6 +239,10 @@ type Namespace interface {
 
 	// Shards returns the shard description
 	Shards() []Shard
+
+	// MultiReaderIteratorPool returns the pool of iterators that decode the
+	// encoded series of the namespace, which depends on its encoding
+	MultiReaderIteratorPool() encoding.MultiReaderIteratorPool
 }
 
 // NamespacesByID is a sortable slice of namespaces by ID
@@ -237,6 +263,10 @@ type databaseNamespace interface {
 	// AssignShardSet sets the shard set assignment and returns immediately
 	AssignShardSet(shardSet sharding.ShardSet)
 
+	// UpdateOptions updates the options of the namespace while it is running,
+	// only the retention period of the namespace can be changed this way.
+	UpdateOptions(opts namespace.Options) error
+
 	// GetOwnedShards returns the database shards
 	GetOwnedShards() []databaseShard
 
@@ -274,6 +304,21 @@ type databaseNamespace interface {
 		opts index.QueryOptions,
 	) (index.QueryResults, error)
 
+	// Cardinality returns the cardinality of the series for each
+	// index block within the time range.
+	Cardinality(
+		ctx context.Context,
+		opts index.CardinalityOptions,
+	) ([]index.CardinalityResult, error)
+
+	// QueryCost returns the postings list sizes of the terms of a query
+	// for each index block within the time range.
+	QueryCost(
+		ctx context.Context,
+		query index.Query,
+		opts index.QueryCostOptions,
+	) ([]index.QueryCostResult, error)
+
 	// ReadEncoded reads data for given id within [start, end)
 	ReadEncoded(
 		ctx context.Context,
@@ -373,6 +418,10 @@ type databaseShard interface {
 	// Close will release the shard resources and close the shard
 	Close() error
 
+	// UpdateSeriesOptions updates the options of the series of the shard while it
+	// is running, only the retention options of the series can be changed this way.
+	UpdateSeriesOptions(opts series.Options)
+
 	// Tick performs any updates to ensure series drain their buffers and blocks are flushed, etc
 	Tick(c context.Cancellable, tickStart time.Time) (tickResult, error)
 
@@ -444,6 +493,14 @@ type databaseShard interface {
 	// CleanupExpiredFileSets removes expired fileset files.
 	CleanupExpiredFileSets(earliestToRetain time.Time) error
 
+	// OffloadColdFileSets offloads flushed fileset files with block starts
+	// before the given time to the object store and removes the local copies.
+	OffloadColdFileSets(offloadBefore time.Time) error
+
+	// CompactFileSets merges flushed fileset files that end before the given time
+	// into filesets of the namespace compaction block size.
+	CompactFileSets(compactBefore time.Time) error
+
 	// Repair repairs the shard data for a given time.
 	Repair(
 		ctx context.Context,
@@ -472,6 +529,21 @@ type namespaceIndex interface {
 		opts index.QueryOptions,
 	) (index.QueryResults, error)
 
+	// Cardinality returns the cardinality of the series for each
+	// index block within the time range.
+	Cardinality(
+		ctx context.Context,
+		opts index.CardinalityOptions,
+	) ([]index.CardinalityResult, error)
+
+	// QueryCost returns the postings list sizes of the terms of a query
+	// for each index block within the time range.
+	QueryCost(
+		ctx context.Context,
+		query index.Query,
+		opts index.QueryCostOptions,
+	) ([]index.QueryCostResult, error)
+
 	// Bootstrap bootstraps the index the provided segments.
 	Bootstrap(
 		bootstrapResults result.IndexResults,
@@ -481,6 +553,11 @@ type namespaceIndex interface {
 	// using the provided `t` as the frame of reference.
 	CleanupExpiredFileSets(t time.Time) error
 
+	// SetRetentionPeriod sets the retention period of the index, blocks and
+	// filesets that fall outside of it are expired by subsequent ticks and
+	// cleanups.
+	SetRetentionPeriod(value time.Duration)
+
 	// Tick performs internal house keeping in the index, including block rotation,
 	// data eviction, and so on.
 	Tick(c context.Cancellable, tickStart time.Time) (namespaceIndexTickResult, error)
@@ -543,6 +620,10 @@ type databaseFlushManager interface {
 	// Flush flushes in-memory data to persistent storage.
 	Flush(tickStart time.Time, dbBootstrapStateAtTickStart DatabaseBootstrapState) error
 
+	// Snapshot snapshots all unflushed in-memory data regardless of when
+	// the last snapshot was taken.
+	Snapshot(tickStart time.Time, dbBootstrapStateAtTickStart DatabaseBootstrapState) error
+
 	// LastSuccessfulSnapshotStartTime returns the start time of the last successful snapshot,
 	// if any.
 	LastSuccessfulSnapshotStartTime() (time.Time, bool)
@@ -560,6 +641,17 @@ type databaseCleanupManager interface {
 	Report()
 }
 
+// databaseBackupManager manages backing up the database.
+type databaseBackupManager interface {
+	// Backup snapshots all unflushed in-memory data and copies the filesets
+	// to the destination path.
+	Backup(
+		tickStart time.Time,
+		dbBootstrapStateAtTickStart DatabaseBootstrapState,
+		destinationPath string,
+	) (backup.Manifest, error)
+}
+
 // databaseFileSystemManager manages the database related filesystem activities.
 type databaseFileSystemManager interface {
 	// Cleanup cleans up data not needed in the persistent storage.
@@ -568,11 +660,25 @@ type databaseFileSystemManager interface {
 	// Flush flushes in-memory data to persistent storage.
 	Flush(t time.Time, dbBootstrapStateAtTickStart DatabaseBootstrapState) error
 
+	// Snapshot snapshots all unflushed in-memory data regardless of when
+	// the last snapshot was taken.
+	Snapshot(t time.Time, dbBootstrapStateAtTickStart DatabaseBootstrapState) error
+
+	// Backup snapshots all unflushed in-memory data and copies the filesets
+	// to the destination path.
+	Backup(
+		t time.Time,
+		dbBootstrapStateAtTickStart DatabaseBootstrapState,
+		destinationPath string,
+	) (backup.Manifest, error)
+
 	// Disable disables the filesystem manager and prevents it from
-	// performing file operations, returns the current file operation status
+	// performing file operations, returns the current file operation status.
+	// Disables are reference counted, file operations are only enabled again
+	// once every disable has been matched by an enable
 	Disable() fileOpStatus
 
-	// Enable enables the filesystem manager to perform file operations
+	// Enable reverses a previous disable of the filesystem manager
 	Enable() fileOpStatus
 
 	// Status returns the file operation status
@@ -659,6 +765,10 @@ type databaseMediator interface {
 	// Repair repairs the database.
 	Repair() error
 
+	// Backup backs up the database to the destination path with file
+	// operations disabled for the duration of the backup.
+	Backup(destinationPath string) (backup.Manifest, error)
+
 	// Close closes the mediator.
 	Close() error
 
@@ -714,6 +824,16 @@ type Options interface {
 	// DatabaseBlockOptions returns the database block options.
 	DatabaseBlockOptions() block.Options
 
+	// SetNamespaceWiredListMaxBytes sets the max bytes of blocks retrieved
+	// from disk to keep wired per namespace, namespaces present in the map
+	// use a wired list of their own rather than the database wired list.
+	SetNamespaceWiredListMaxBytes(value map[string]int64) Options
+
+	// NamespaceWiredListMaxBytes returns the max bytes of blocks retrieved
+	// from disk to keep wired per namespace, namespaces present in the map
+	// use a wired list of their own rather than the database wired list.
+	NamespaceWiredListMaxBytes() map[string]int64
+
 	// SetCommitLogOptions sets the commit log options.
 	SetCommitLogOptions(value commitlog.Options) Options
 
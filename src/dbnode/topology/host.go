@@ -39,8 +39,9 @@ func Majority(replicas int) int {
 }
 
 type host struct {
-	id      string
-	address string
+	id             string
+	address        string
+	isolationGroup string
 }
 
 func (h *host) ID() string {
@@ -51,6 +52,10 @@ func (h *host) Address() string {
 	return h.address
 }
 
+func (h *host) IsolationGroup() string {
+	return h.isolationGroup
+}
+
 func (h *host) String() string {
 	return fmt.Sprintf("Host<ID=%s, Address=%s>", h.id, h.address)
 }
@@ -60,6 +65,11 @@ func NewHost(id, address string) Host {
 	return &host{id: id, address: address}
 }
 
+// NewHostWithIsolationGroup creates a new host that belongs to an isolation group
+func NewHostWithIsolationGroup(id, address, isolationGroup string) Host {
+	return &host{id: id, address: address, isolationGroup: isolationGroup}
+}
+
 type hostShardSet struct {
 	host     Host
 	shardSet sharding.ShardSet
@@ -86,7 +96,8 @@ func NewHostShardSetFromServiceInstance(
 	if err != nil {
 		return nil, err
 	}
-	return NewHostShardSet(NewHost(si.InstanceID(), si.Endpoint()), shardSet), nil
+	host := NewHostWithIsolationGroup(si.InstanceID(), si.Endpoint(), si.IsolationGroup())
+	return NewHostShardSet(host, shardSet), nil
 }
 
 func (h *hostShardSet) Host() Host {
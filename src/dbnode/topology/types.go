@@ -37,6 +37,9 @@ type Host interface {
 	// Address returns the address of the host
 	Address() string
 
+	// IsolationGroup returns the isolation group of the host, empty if unknown
+	IsolationGroup() string
+
 	// String returns a string representation of the host
 	String() string
 }
@@ -30,6 +30,8 @@
 	It has these top-level messages:
 		TermQuery
 		RegexpQuery
+		FieldQuery
+		AllQuery
 		NegationQuery
 		ConjunctionQuery
 		DisjunctionQuery
@@ -102,6 +104,30 @@ func (m *RegexpQuery) GetRegexp() []byte {
 	return nil
 }
 
+type FieldQuery struct {
+	Field []byte `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+}
+
+func (m *FieldQuery) Reset()                    { *m = FieldQuery{} }
+func (m *FieldQuery) String() string            { return proto.CompactTextString(m) }
+func (*FieldQuery) ProtoMessage()               {}
+func (*FieldQuery) Descriptor() ([]byte, []int) { return fileDescriptorQuery, []int{2} }
+
+func (m *FieldQuery) GetField() []byte {
+	if m != nil {
+		return m.Field
+	}
+	return nil
+}
+
+type AllQuery struct {
+}
+
+func (m *AllQuery) Reset()                    { *m = AllQuery{} }
+func (m *AllQuery) String() string            { return proto.CompactTextString(m) }
+func (*AllQuery) ProtoMessage()               {}
+func (*AllQuery) Descriptor() ([]byte, []int) { return fileDescriptorQuery, []int{3} }
+
 type NegationQuery struct {
 	Query *Query `protobuf:"bytes,1,opt,name=query" json:"query,omitempty"`
 }
@@ -109,7 +135,7 @@ type NegationQuery struct {
 func (m *NegationQuery) Reset()                    { *m = NegationQuery{} }
 func (m *NegationQuery) String() string            { return proto.CompactTextString(m) }
 func (*NegationQuery) ProtoMessage()               {}
-func (*NegationQuery) Descriptor() ([]byte, []int) { return fileDescriptorQuery, []int{2} }
+func (*NegationQuery) Descriptor() ([]byte, []int) { return fileDescriptorQuery, []int{4} }
 
 func (m *NegationQuery) GetQuery() *Query {
 	if m != nil {
@@ -125,7 +151,7 @@ type ConjunctionQuery struct {
 func (m *ConjunctionQuery) Reset()                    { *m = ConjunctionQuery{} }
 func (m *ConjunctionQuery) String() string            { return proto.CompactTextString(m) }
 func (*ConjunctionQuery) ProtoMessage()               {}
-func (*ConjunctionQuery) Descriptor() ([]byte, []int) { return fileDescriptorQuery, []int{3} }
+func (*ConjunctionQuery) Descriptor() ([]byte, []int) { return fileDescriptorQuery, []int{5} }
 
 func (m *ConjunctionQuery) GetQueries() []*Query {
 	if m != nil {
@@ -141,7 +167,7 @@ type DisjunctionQuery struct {
 func (m *DisjunctionQuery) Reset()                    { *m = DisjunctionQuery{} }
 func (m *DisjunctionQuery) String() string            { return proto.CompactTextString(m) }
 func (*DisjunctionQuery) ProtoMessage()               {}
-func (*DisjunctionQuery) Descriptor() ([]byte, []int) { return fileDescriptorQuery, []int{4} }
+func (*DisjunctionQuery) Descriptor() ([]byte, []int) { return fileDescriptorQuery, []int{6} }
 
 func (m *DisjunctionQuery) GetQueries() []*Query {
 	if m != nil {
@@ -157,13 +183,15 @@ type Query struct {
 	//	*Query_Negation
 	//	*Query_Conjunction
 	//	*Query_Disjunction
+	//	*Query_Field
+	//	*Query_All
 	Query isQuery_Query `protobuf_oneof:"query"`
 }
 
 func (m *Query) Reset()                    { *m = Query{} }
 func (m *Query) String() string            { return proto.CompactTextString(m) }
 func (*Query) ProtoMessage()               {}
-func (*Query) Descriptor() ([]byte, []int) { return fileDescriptorQuery, []int{5} }
+func (*Query) Descriptor() ([]byte, []int) { return fileDescriptorQuery, []int{7} }
 
 type isQuery_Query interface {
 	isQuery_Query()
@@ -186,12 +214,20 @@ type Query_Conjunction struct {
 type Query_Disjunction struct {
 	Disjunction *DisjunctionQuery `protobuf:"bytes,5,opt,name=disjunction,oneof"`
 }
+type Query_Field struct {
+	Field *FieldQuery `protobuf:"bytes,6,opt,name=field,oneof"`
+}
+type Query_All struct {
+	All *AllQuery `protobuf:"bytes,7,opt,name=all,oneof"`
+}
 
 func (*Query_Term) isQuery_Query()        {}
 func (*Query_Regexp) isQuery_Query()      {}
 func (*Query_Negation) isQuery_Query()    {}
 func (*Query_Conjunction) isQuery_Query() {}
 func (*Query_Disjunction) isQuery_Query() {}
+func (*Query_Field) isQuery_Query()       {}
+func (*Query_All) isQuery_Query()         {}
 
 func (m *Query) GetQuery() isQuery_Query {
 	if m != nil {
@@ -235,6 +271,20 @@ func (m *Query) GetDisjunction() *DisjunctionQuery {
 	return nil
 }
 
+func (m *Query) GetField() *FieldQuery {
+	if x, ok := m.GetQuery().(*Query_Field); ok {
+		return x.Field
+	}
+	return nil
+}
+
+func (m *Query) GetAll() *AllQuery {
+	if x, ok := m.GetQuery().(*Query_All); ok {
+		return x.All
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*Query) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _Query_OneofMarshaler, _Query_OneofUnmarshaler, _Query_OneofSizer, []interface{}{
@@ -243,6 +293,8 @@ func (*Query) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error,
 		(*Query_Negation)(nil),
 		(*Query_Conjunction)(nil),
 		(*Query_Disjunction)(nil),
+		(*Query_Field)(nil),
+		(*Query_All)(nil),
 	}
 }
 
@@ -275,6 +327,16 @@ func _Query_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.Disjunction); err != nil {
 			return err
 		}
+	case *Query_Field:
+		_ = b.EncodeVarint(6<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Field); err != nil {
+			return err
+		}
+	case *Query_All:
+		_ = b.EncodeVarint(7<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.All); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("Query.Query has unexpected type %T", x)
@@ -325,6 +387,22 @@ func _Query_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer)
 		err := b.DecodeMessage(msg)
 		m.Query = &Query_Disjunction{msg}
 		return true, err
+	case 6: // query.field
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(FieldQuery)
+		err := b.DecodeMessage(msg)
+		m.Query = &Query_Field{msg}
+		return true, err
+	case 7: // query.all
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(AllQuery)
+		err := b.DecodeMessage(msg)
+		m.Query = &Query_All{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -359,6 +437,16 @@ func _Query_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(5<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *Query_Field:
+		s := proto.Size(x.Field)
+		n += proto.SizeVarint(6<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Query_All:
+		s := proto.Size(x.All)
+		n += proto.SizeVarint(7<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -369,6 +457,8 @@ func _Query_OneofSizer(msg proto.Message) (n int) {
 func init() {
 	proto.RegisterType((*TermQuery)(nil), "query.TermQuery")
 	proto.RegisterType((*RegexpQuery)(nil), "query.RegexpQuery")
+	proto.RegisterType((*FieldQuery)(nil), "query.FieldQuery")
+	proto.RegisterType((*AllQuery)(nil), "query.AllQuery")
 	proto.RegisterType((*NegationQuery)(nil), "query.NegationQuery")
 	proto.RegisterType((*ConjunctionQuery)(nil), "query.ConjunctionQuery")
 	proto.RegisterType((*DisjunctionQuery)(nil), "query.DisjunctionQuery")
@@ -434,6 +524,48 @@ func (m *RegexpQuery) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *FieldQuery) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FieldQuery) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Field) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.Field)))
+		i += copy(dAtA[i:], m.Field)
+	}
+	return i, nil
+}
+
+func (m *AllQuery) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AllQuery) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
 func (m *NegationQuery) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -617,6 +749,34 @@ func (m *Query_Disjunction) MarshalTo(dAtA []byte) (int, error) {
 	}
 	return i, nil
 }
+func (m *Query_Field) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.Field != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintQuery(dAtA, i, uint64(m.Field.Size()))
+		n8, err := m.Field.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n8
+	}
+	return i, nil
+}
+func (m *Query_All) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.All != nil {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintQuery(dAtA, i, uint64(m.All.Size()))
+		n9, err := m.All.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n9
+	}
+	return i, nil
+}
 func encodeVarintQuery(dAtA []byte, offset int, v uint64) int {
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
@@ -654,6 +814,22 @@ func (m *RegexpQuery) Size() (n int) {
 	return n
 }
 
+func (m *FieldQuery) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Field)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+
+func (m *AllQuery) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
 func (m *NegationQuery) Size() (n int) {
 	var l int
 	_ = l
@@ -742,6 +918,24 @@ func (m *Query_Disjunction) Size() (n int) {
 	}
 	return n
 }
+func (m *Query_Field) Size() (n int) {
+	var l int
+	_ = l
+	if m.Field != nil {
+		l = m.Field.Size()
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+func (m *Query_All) Size() (n int) {
+	var l int
+	_ = l
+	if m.All != nil {
+		l = m.All.Size()
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
 
 func sovQuery(x uint64) (n int) {
 	for {
@@ -980,6 +1174,137 @@ func (m *RegexpQuery) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *FieldQuery) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FieldQuery: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FieldQuery: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Field", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Field = append(m.Field[:0], dAtA[iNdEx:postIndex]...)
+			if m.Field == nil {
+				m.Field = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AllQuery) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AllQuery: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AllQuery: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *NegationQuery) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -1414,6 +1739,70 @@ func (m *Query) Unmarshal(dAtA []byte) error {
 			}
 			m.Query = &Query_Disjunction{v}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Field", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &FieldQuery{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Query = &Query_Field{v}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field All", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &AllQuery{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Query = &Query_All{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipQuery(dAtA[iNdEx:])
@@ -1545,27 +1934,30 @@ func init() {
 }
 
 var fileDescriptorQuery = []byte{
-	// 343 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x92, 0xbd, 0x4e, 0xc3, 0x30,
-	0x14, 0x85, 0xe3, 0xb6, 0x69, 0xe1, 0xa6, 0x48, 0x95, 0x55, 0x81, 0x59, 0xa2, 0x2a, 0x03, 0x62,
-	0x40, 0xb5, 0xd4, 0x88, 0x85, 0x4e, 0x14, 0x86, 0x4c, 0x48, 0x44, 0x4c, 0x6c, 0x4d, 0x62, 0x82,
-	0x11, 0x76, 0x8a, 0x9b, 0x4a, 0xe5, 0x2d, 0x18, 0x78, 0x28, 0x46, 0x1e, 0x01, 0x95, 0x17, 0x41,
-	0xb1, 0xdd, 0x5f, 0x24, 0x06, 0xa6, 0xe4, 0xfa, 0x9e, 0x4f, 0x3a, 0xf9, 0x62, 0xb8, 0xcc, 0x79,
-	0xf9, 0x38, 0x4b, 0xfa, 0x69, 0x21, 0xa8, 0x08, 0xb3, 0x84, 0x8a, 0x90, 0x4e, 0x55, 0x4a, 0x45,
-	0x28, 0xb9, 0x9c, 0xd3, 0x9c, 0x49, 0xa6, 0xc6, 0x25, 0xcb, 0xe8, 0x44, 0x15, 0x65, 0x41, 0x5f,
-	0x66, 0x4c, 0xbd, 0x4e, 0x12, 0xf3, 0xec, 0xeb, 0x33, 0xec, 0xea, 0x21, 0x38, 0x87, 0xfd, 0x3b,
-	0xa6, 0xc4, 0x6d, 0x35, 0xe0, 0x2e, 0xb8, 0x0f, 0x9c, 0x3d, 0x67, 0x04, 0xf5, 0xd0, 0x69, 0x3b,
-	0x36, 0x03, 0xc6, 0xd0, 0x28, 0x99, 0x12, 0xa4, 0xa6, 0x0f, 0xf5, 0x7b, 0x30, 0x04, 0x2f, 0x66,
-	0x39, 0x9b, 0x4f, 0xfe, 0x02, 0x0f, 0xa1, 0xa9, 0x74, 0xc8, 0xa2, 0x76, 0x0a, 0x42, 0x38, 0xb8,
-	0x61, 0xf9, 0xb8, 0xe4, 0x85, 0x34, 0x78, 0x00, 0xa6, 0x8d, 0xc6, 0xbd, 0x41, 0xbb, 0x6f, 0x8a,
-	0xea, 0x65, 0x6c, 0x8b, 0x5e, 0x40, 0xe7, 0xaa, 0x90, 0x4f, 0x33, 0x99, 0xae, 0xb9, 0x13, 0x68,
-	0x55, 0x4b, 0xce, 0xa6, 0x04, 0xf5, 0xea, 0xbf, 0xc8, 0xe5, 0xb2, 0x62, 0xaf, 0xf9, 0xf4, 0x7f,
-	0xec, 0x7b, 0x0d, 0xdc, 0x25, 0x61, 0x3c, 0x98, 0x92, 0x1d, 0x1b, 0x5f, 0xd9, 0x8b, 0x1c, 0xe3,
-	0x06, 0x9f, 0x6d, 0x7d, 0xb6, 0x37, 0xc0, 0x36, 0xb9, 0x21, 0x2c, 0x72, 0x96, 0x32, 0xf0, 0x00,
-	0xf6, 0xa4, 0x95, 0x41, 0xea, 0x3a, 0xdf, 0xb5, 0xf9, 0x2d, 0x47, 0x91, 0x13, 0xaf, 0x72, 0x78,
-	0x08, 0x5e, 0xba, 0x76, 0x41, 0x1a, 0x1a, 0x3b, 0xb2, 0xd8, 0xae, 0xa5, 0xc8, 0x89, 0x37, 0xd3,
-	0x15, 0x9c, 0xad, 0x65, 0x10, 0x77, 0x0b, 0xde, 0xd5, 0x54, 0xc1, 0x1b, 0xe9, 0x51, 0xcb, 0xfe,
-	0xa9, 0xd1, 0xf1, 0xc7, 0xc2, 0x47, 0x9f, 0x0b, 0x1f, 0x7d, 0x2d, 0x7c, 0xf4, 0xf6, 0xed, 0x3b,
-	0xf7, 0x2d, 0x7b, 0xcb, 0x92, 0xa6, 0xbe, 0x60, 0xe1, 0x4f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x87,
-	0xea, 0x95, 0x8a, 0xa5, 0x02, 0x00, 0x00,
+	// 386 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x92, 0xcf, 0x4a, 0xeb, 0x40,
+	0x14, 0x87, 0x93, 0xb6, 0x69, 0x7a, 0x4f, 0x7a, 0xb1, 0x0e, 0x45, 0xe3, 0x26, 0x94, 0x08, 0xa2,
+	0x20, 0x0d, 0x34, 0xb8, 0xb1, 0xab, 0x56, 0x91, 0xac, 0x04, 0x83, 0x2b, 0x77, 0x4d, 0x32, 0xc6,
+	0x48, 0x32, 0xa9, 0xd3, 0x14, 0xea, 0x5b, 0xf8, 0x58, 0x2e, 0x7d, 0x04, 0xa9, 0x3e, 0x88, 0xcc,
+	0x9f, 0x34, 0x6d, 0x85, 0x2e, 0x5c, 0x25, 0x67, 0xce, 0xef, 0x83, 0x99, 0xef, 0x1c, 0x18, 0xc5,
+	0x49, 0xf1, 0x34, 0x0f, 0xfa, 0x61, 0x9e, 0x39, 0x99, 0x1b, 0x05, 0x4e, 0xe6, 0x3a, 0x33, 0x1a,
+	0x3a, 0x99, 0x4b, 0x12, 0xb2, 0x70, 0x62, 0x4c, 0x30, 0x9d, 0x14, 0x38, 0x72, 0xa6, 0x34, 0x2f,
+	0x72, 0xe7, 0x65, 0x8e, 0xe9, 0xeb, 0x34, 0x10, 0xdf, 0x3e, 0x3f, 0x43, 0x1a, 0x2f, 0xec, 0x0b,
+	0xf8, 0x77, 0x8f, 0x69, 0x76, 0xc7, 0x0a, 0xd4, 0x05, 0xed, 0x31, 0xc1, 0x69, 0x64, 0xaa, 0x3d,
+	0xf5, 0xb4, 0xed, 0x8b, 0x02, 0x21, 0x68, 0x14, 0x98, 0x66, 0x66, 0x8d, 0x1f, 0xf2, 0x7f, 0x7b,
+	0x08, 0x86, 0x8f, 0x63, 0xbc, 0x98, 0xee, 0x02, 0x0f, 0xa0, 0x49, 0x79, 0x48, 0xa2, 0xb2, 0xb2,
+	0x6d, 0x80, 0x1b, 0x16, 0xd8, 0xc1, 0xda, 0x00, 0xad, 0x51, 0x9a, 0xf2, 0x84, 0xed, 0xc2, 0xff,
+	0x5b, 0x1c, 0x4f, 0x8a, 0x24, 0x27, 0x02, 0xb1, 0x41, 0xdc, 0x9e, 0x23, 0xc6, 0xa0, 0xdd, 0x17,
+	0x0f, 0xe3, 0x4d, 0x5f, 0x3e, 0xec, 0x12, 0x3a, 0x57, 0x39, 0x79, 0x9e, 0x93, 0xb0, 0xe2, 0x4e,
+	0x40, 0x67, 0xcd, 0x04, 0xcf, 0x4c, 0xb5, 0x57, 0xff, 0x45, 0x96, 0x4d, 0xc6, 0x5e, 0x27, 0xb3,
+	0xbf, 0xb1, 0xdf, 0x35, 0xd0, 0x4a, 0x42, 0x78, 0x13, 0x97, 0xec, 0xc8, 0xf8, 0xca, 0xb6, 0xa7,
+	0x08, 0x97, 0xe8, 0x7c, 0x43, 0x93, 0x31, 0x40, 0x32, 0xb9, 0x26, 0xd8, 0x53, 0x4a, 0x79, 0x68,
+	0x00, 0x2d, 0x22, 0x65, 0x98, 0x75, 0x9e, 0xef, 0xca, 0xfc, 0x86, 0x23, 0x4f, 0xf1, 0x57, 0x39,
+	0x34, 0x04, 0x23, 0xac, 0x5c, 0x98, 0x0d, 0x8e, 0x1d, 0x4a, 0x6c, 0xdb, 0x92, 0xa7, 0xf8, 0xeb,
+	0x69, 0x06, 0x47, 0x95, 0x0c, 0x53, 0xdb, 0x80, 0xb7, 0x35, 0x31, 0x78, 0x2d, 0x8d, 0xce, 0xca,
+	0xe1, 0x36, 0x39, 0xb6, 0x2f, 0xb1, 0x6a, 0xfc, 0x9e, 0x52, 0x6e, 0xcb, 0x31, 0xd4, 0x27, 0x69,
+	0x6a, 0xea, 0x3c, 0xb8, 0x27, 0x83, 0xe5, 0x0e, 0x78, 0x8a, 0xcf, 0xba, 0x63, 0x5d, 0x4e, 0x7e,
+	0x7c, 0xf4, 0xbe, 0xb4, 0xd4, 0x8f, 0xa5, 0xa5, 0x7e, 0x2e, 0x2d, 0xf5, 0xed, 0xcb, 0x52, 0x1e,
+	0x74, 0xb9, 0xe5, 0x41, 0x93, 0x2f, 0xb8, 0xfb, 0x33, 0x00, 0x10, 0x04, 0xe2, 0x6b, 0x25, 0x03,
+	0x00, 0x00,
 }
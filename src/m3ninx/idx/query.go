@@ -63,6 +63,21 @@ func MustCreateRegexpQuery(field, regexp []byte) Query {
 	}
 }
 
+// NewFieldQuery returns a new query for finding documents which contain the given field,
+// regardless of its value.
+func NewFieldQuery(field []byte) Query {
+	return Query{
+		query: query.NewFieldQuery(field),
+	}
+}
+
+// NewAllQuery returns a new query which matches all documents.
+func NewAllQuery() Query {
+	return Query{
+		query: query.NewAllQuery(),
+	}
+}
+
 // NewNegationQuery returns a new query for finding documents which don't match a given query.
 func NewNegationQuery(q Query) Query {
 	return Query{
@@ -31,6 +31,8 @@ type newFSTTermsIterOpts struct {
 	opts        Options
 	fst         *vellum.FST
 	finalizeFST bool
+	// start is the term iteration starts from, inclusive, if set.
+	start []byte
 }
 
 func (o *newFSTTermsIterOpts) Close() error {
@@ -73,7 +75,7 @@ func (f *fstTermsIter) Next() bool {
 	if !f.initialized {
 		f.initialized = true
 		f.iter = &vellum.FSTIterator{}
-		err = f.iter.Reset(f.iterOpts.fst, nil, nil, nil)
+		err = f.iter.Reset(f.iterOpts.fst, f.iterOpts.start, nil, nil)
 	} else {
 		err = f.iter.Next()
 	}
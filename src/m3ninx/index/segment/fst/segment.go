@@ -140,6 +140,8 @@ func NewSegment(data SegmentData, opts Options) (Segment, error) {
 	}, nil
 }
 
+var _ sgmt.SeekableTermsIterable = &fsSegment{}
+
 type fsSegment struct {
 	sync.RWMutex
 	closed          bool
@@ -231,6 +233,10 @@ func (r *fsSegment) Fields() (sgmt.FieldsIterator, error) {
 }
 
 func (r *fsSegment) Terms(field []byte) (sgmt.TermsIterator, error) {
+	return r.TermsFrom(field, nil)
+}
+
+func (r *fsSegment) TermsFrom(field []byte, start []byte) (sgmt.TermsIterator, error) {
 	r.RLock()
 	defer r.RUnlock()
 	if r.closed {
@@ -250,6 +256,7 @@ func (r *fsSegment) Terms(field []byte) (sgmt.TermsIterator, error) {
 		opts:        r.opts,
 		fst:         termsFST,
 		finalizeFST: true,
+		start:       start,
 	}), nil
 }
 
@@ -363,6 +370,68 @@ func (r *fsSegment) MatchRegexp(field []byte, compiled index.CompiledRegex) (pos
 	return pl, nil
 }
 
+func (r *fsSegment) MatchField(field []byte) (postings.List, error) {
+	r.RLock()
+	defer r.RUnlock()
+	if r.closed {
+		return nil, errReaderClosed
+	}
+
+	termsFST, exists, err := r.retrieveTermsFSTWithRLock(field)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		// i.e. we don't know anything about the field, so can early return an empty postings list
+		return r.opts.PostingsListPool().Get(), nil
+	}
+
+	var (
+		fstCloser     = x.NewSafeCloser(termsFST)
+		iter, iterErr = termsFST.Iterator(nil, nil)
+		iterCloser    = x.NewSafeCloser(iter)
+		pls           []postings.List
+	)
+	defer func() {
+		iterCloser.Close()
+		fstCloser.Close()
+	}()
+
+	for {
+		if iterErr == vellum.ErrIteratorDone {
+			break
+		}
+
+		if iterErr != nil {
+			return nil, iterErr
+		}
+
+		_, postingsOffset := iter.Current()
+		nextPl, err := r.retrievePostingsListWithRLock(postingsOffset)
+		if err != nil {
+			return nil, err
+		}
+		pls = append(pls, nextPl)
+		iterErr = iter.Next()
+	}
+
+	pl, err := roaring.Union(pls)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := iterCloser.Close(); err != nil {
+		return nil, err
+	}
+
+	if err := fstCloser.Close(); err != nil {
+		return nil, err
+	}
+
+	return pl, nil
+}
+
 func (r *fsSegment) MatchAll() (postings.MutableList, error) {
 	r.RLock()
 	defer r.RUnlock()
@@ -522,6 +591,15 @@ func (sr *fsSegmentReader) MatchRegexp(field []byte, compiled index.CompiledRege
 	return sr.fsSegment.MatchRegexp(field, compiled)
 }
 
+func (sr *fsSegmentReader) MatchField(field []byte) (postings.List, error) {
+	sr.RLock()
+	defer sr.RUnlock()
+	if sr.closed {
+		return nil, errReaderClosed
+	}
+	return sr.fsSegment.MatchField(field)
+}
+
 func (sr *fsSegmentReader) MatchAll() (postings.MutableList, error) {
 	sr.RLock()
 	defer sr.RUnlock()
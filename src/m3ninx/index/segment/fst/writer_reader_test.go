@@ -225,6 +225,27 @@ func TestTermEquals(t *testing.T) {
 	}
 }
 
+func TestTermsFrom(t *testing.T) {
+	for _, test := range testDocuments {
+		t.Run(test.name, func(t *testing.T) {
+			memSeg, fstSeg := newTestSegments(t, test.docs)
+			seekable, ok := fstSeg.(sgmt.SeekableTermsIterable)
+			require.True(t, ok)
+
+			memIDsIter, err := memSeg.Terms(doc.IDReservedFieldName)
+			require.NoError(t, err)
+			memIDs := toSlice(t, memIDsIter)
+
+			for i := 0; i < len(memIDs); i += 1 + len(memIDs)/50 {
+				fstIDsIter, err := seekable.TermsFrom(doc.IDReservedFieldName, memIDs[i])
+				require.NoError(t, err)
+				fstIDs := toSlice(t, fstIDsIter)
+				assertSliceOfByteSlicesEqual(t, memIDs[i:], fstIDs)
+			}
+		})
+	}
+}
+
 func TestPostingsListEqualForMatchTerm(t *testing.T) {
 	for _, test := range testDocuments {
 		t.Run(test.name, func(t *testing.T) {
@@ -299,6 +320,38 @@ func TestPostingsListRegexAll(t *testing.T) {
 	}
 }
 
+func TestPostingsListEqualForMatchField(t *testing.T) {
+	for _, test := range testDocuments {
+		t.Run(test.name, func(t *testing.T) {
+			memSeg, fstSeg := newTestSegments(t, test.docs)
+			memReader, err := memSeg.Reader()
+			require.NoError(t, err)
+			fstReader, err := fstSeg.Reader()
+			require.NoError(t, err)
+
+			memFieldsIter, err := memSeg.Fields()
+			require.NoError(t, err)
+			memFields := toSlice(t, memFieldsIter)
+
+			for _, f := range memFields {
+				memPl, err := memReader.MatchField(f)
+				require.NoError(t, err)
+				fstPl, err := fstReader.MatchField(f)
+				require.NoError(t, err)
+				require.True(t, memPl.Equal(fstPl),
+					fmt.Sprintf("%s - [%v] != [%v]", string(f), pprintIter(memPl), pprintIter(fstPl)))
+			}
+
+			memPl, err := memReader.MatchField([]byte("unknown-field"))
+			require.NoError(t, err)
+			require.Equal(t, 0, memPl.Len())
+			fstPl, err := fstReader.MatchField([]byte("unknown-field"))
+			require.NoError(t, err)
+			require.Equal(t, 0, fstPl.Len())
+		})
+	}
+}
+
 func TestSegmentDocs(t *testing.T) {
 	for _, test := range testDocuments {
 		t.Run(test.name, func(t *testing.T) {
@@ -97,6 +97,26 @@ func (m *concurrentPostingsMap) Get(key []byte) (postings.List, bool) {
 	return nil, false
 }
 
+// GetAll returns the union of all the postings lists in the map.
+func (m *concurrentPostingsMap) GetAll() (postings.List, bool) {
+	var pl postings.MutableList
+
+	m.RLock()
+	for _, mapEntry := range m.postingsMap.Iter() {
+		if pl == nil {
+			pl = mapEntry.Value().Clone()
+		} else {
+			pl.Union(mapEntry.Value())
+		}
+	}
+	m.RUnlock()
+
+	if pl == nil {
+		return nil, false
+	}
+	return pl, true
+}
+
 // GetRegex returns the union of the postings lists whose keys match the
 // provided regexp.
 func (m *concurrentPostingsMap) GetRegex(re *regexp.Regexp) (postings.List, bool) {
@@ -27,6 +27,7 @@ import (
 	"testing"
 
 	sgmt "github.com/m3db/m3/src/m3ninx/index/segment"
+	"github.com/m3db/m3/src/m3ninx/postings"
 
 	"github.com/stretchr/testify/require"
 )
@@ -59,6 +60,16 @@ func TestConcurrentPostingsMap(t *testing.T) {
 	re = regexp.MustCompile("abc.*")
 	_, ok = pm.GetRegex(re)
 	require.False(t, ok)
+
+	pl, ok = pm.GetAll()
+	require.True(t, ok)
+	require.Equal(t, 4, pl.Len())
+	for id := 1; id <= 4; id++ {
+		require.True(t, pl.Contains(postings.ID(id)))
+	}
+
+	_, ok = newConcurrentPostingsMap(opts).GetAll()
+	require.False(t, ok)
 }
 
 func TestConcurrentPostingsMapKeys(t *testing.T) {
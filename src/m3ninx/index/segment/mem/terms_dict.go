@@ -65,6 +65,20 @@ func (d *termsDict) MatchTerm(field, term []byte) postings.List {
 	return pl
 }
 
+func (d *termsDict) MatchField(field []byte) postings.List {
+	d.fields.RLock()
+	postingsMap, ok := d.fields.Get(field)
+	d.fields.RUnlock()
+	if !ok {
+		return d.opts.PostingsListPool().Get()
+	}
+	pl, ok := postingsMap.GetAll()
+	if !ok {
+		return d.opts.PostingsListPool().Get()
+	}
+	return pl
+}
+
 func (d *termsDict) Fields() sgmt.FieldsIterator {
 	d.fields.RLock()
 	defer d.fields.RUnlock()
@@ -223,6 +223,31 @@ func (t *termsDictionaryTestSuite) TestMatchTermNoResults() {
 	props.TestingRun(t.T())
 }
 
+func (t *termsDictionaryTestSuite) TestMatchField() {
+	props := getProperties()
+	props.Property(
+		"The dictionary should support field existence queries",
+		prop.ForAll(
+			func(f doc.Field, id postings.ID) (bool, error) {
+				t.termsDict.Insert(f, id)
+
+				pl := t.termsDict.MatchField(f.Name)
+				if pl == nil {
+					return false, fmt.Errorf("postings list of documents matching query should not be nil")
+				}
+				if !pl.Contains(id) {
+					return false, fmt.Errorf("id of new document '%v' is not in postings list of matching documents", id)
+				}
+
+				return true, nil
+			},
+			genField(),
+			genDocID(),
+		))
+
+	props.TestingRun(t.T())
+}
+
 func (t *termsDictionaryTestSuite) TestMatchRegex() {
 	props := getProperties()
 	props.Property(
@@ -54,6 +54,14 @@ type Segment interface {
 	Close() error
 }
 
+// SeekableTermsIterable is a Segment that can iterate over the known terms of
+// a field starting from a given term rather than from the first term.
+type SeekableTermsIterable interface {
+	// TermsFrom returns an iterator over the known terms values for the given
+	// field that are greater than or equal to start.
+	TermsFrom(field []byte, start []byte) (TermsIterator, error)
+}
+
 // OrderedBytesIterator iterates over a collection of []bytes in lexicographical order.
 type OrderedBytesIterator interface {
 	// Next returns a bool indicating if there are any more elements.
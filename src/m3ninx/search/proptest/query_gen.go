@@ -102,10 +102,37 @@ func genRegexpQuery(docs []doc.Document) gopter.Gen {
 	}
 }
 
+func genFieldQuery(docs []doc.Document) gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		docIDRes, ok := gen.IntRange(0, len(docs)-1)(genParams).Retrieve()
+		if !ok {
+			panic("unable to generate field query") // should never happen
+		}
+		docID := docIDRes.(int)
+
+		doc := docs[docID]
+		fieldRes, ok := gen.IntRange(0, len(doc.Fields)-1)(genParams).Retrieve()
+		if !ok {
+			panic("unable to generate field query fields") // should never happen
+		}
+
+		fieldID := fieldRes.(int)
+		field := doc.Fields[fieldID]
+
+		q := query.NewFieldQuery(field.Name)
+		return gopter.NewGenResult(q, gopter.NoShrinker)
+	}
+}
+
+func genAllQuery(docs []doc.Document) gopter.Gen {
+	return gen.Const(query.NewAllQuery())
+}
+
 func genNegationQuery(docs []doc.Document) gopter.Gen {
 	return gen.OneGenOf(
 		genTermQuery(docs),
 		genRegexpQuery(docs),
+		genFieldQuery(docs),
 	).
 		Map(func(q search.Query) search.Query {
 			return query.NewNegationQuery(q)
@@ -117,6 +144,7 @@ func genConjuctionQuery(docs []doc.Document) gopter.Gen {
 		gen.OneGenOf(
 			genTermQuery(docs),
 			genRegexpQuery(docs),
+			genFieldQuery(docs),
 			genNegationQuery(docs)),
 		reflect.TypeOf((*search.Query)(nil)).Elem()).
 		Map(func(qs []search.Query) search.Query {
@@ -129,6 +157,7 @@ func genDisjunctionQuery(docs []doc.Document) gopter.Gen {
 		gen.OneGenOf(
 			genTermQuery(docs),
 			genRegexpQuery(docs),
+			genFieldQuery(docs),
 			genNegationQuery(docs)),
 		reflect.TypeOf((*search.Query)(nil)).Elem()).
 		Map(func(qs []search.Query) search.Query {
@@ -140,6 +169,8 @@ func genQuery(docs []doc.Document) gopter.Gen {
 	return gen.OneGenOf(
 		genTermQuery(docs),
 		genRegexpQuery(docs),
+		genFieldQuery(docs),
+		genAllQuery(docs),
 		genNegationQuery(docs),
 		genConjuctionQuery(docs),
 		genDisjunctionQuery(docs))
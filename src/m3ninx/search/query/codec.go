@@ -57,6 +57,12 @@ func unmarshal(q *querypb.Query) (search.Query, error) {
 	case *querypb.Query_Regexp:
 		return NewRegexpQuery(q.Regexp.Field, q.Regexp.Regexp)
 
+	case *querypb.Query_Field:
+		return NewFieldQuery(q.Field.Field), nil
+
+	case *querypb.Query_All:
+		return NewAllQuery(), nil
+
 	case *querypb.Query_Negation:
 		inner, err := unmarshal(q.Negation.Query)
 		if err != nil {
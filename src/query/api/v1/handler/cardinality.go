@@ -0,0 +1,216 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage/m3"
+	"github.com/m3db/m3/src/query/util"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/net/http"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// CardinalityURL is the url to inspect the cardinality of the series
+	// of the cluster namespaces.
+	CardinalityURL = RoutePrefixV1 + "/cardinality"
+
+	// CardinalityHTTPMethod is the HTTP method used with this resource.
+	CardinalityHTTPMethod = http.MethodGet
+
+	namespaceParam = "namespace"
+	startParam     = "start"
+	endParam       = "end"
+	limitParam     = "limit"
+	nameTagParam   = "nameTag"
+	maxTermsParam  = "maxTerms"
+
+	defaultCardinalityRange = 2 * time.Hour
+)
+
+var (
+	errCardinalityUnknownNamespace = errors.New("unknown namespace")
+)
+
+// CardinalityHandler represents a handler for the cardinality endpoint.
+type CardinalityHandler struct {
+	clusters   m3.Clusters
+	tagOptions models.TagOptions
+	nowFn      func() time.Time
+}
+
+// CardinalityResponse is the response of the cardinality endpoint.
+type CardinalityResponse struct {
+	Namespaces []CardinalityNamespace `json:"namespaces"`
+}
+
+// CardinalityNamespace is the cardinality of the series of a namespace.
+type CardinalityNamespace struct {
+	Namespace string             `json:"namespace"`
+	Blocks    []CardinalityBlock `json:"blocks"`
+}
+
+// CardinalityBlock is the cardinality of the series of an index block.
+type CardinalityBlock struct {
+	BlockStart  time.Time          `json:"blockStart"`
+	NumSeries   int64              `json:"numSeries"`
+	MetricNames []CardinalityEntry `json:"metricNames"`
+	TagNames    []CardinalityEntry `json:"tagNames"`
+	TagValues   []CardinalityEntry `json:"tagValues"`
+	Exhaustive  bool               `json:"exhaustive"`
+}
+
+// CardinalityEntry is a name and the count of series or values for it.
+type CardinalityEntry struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+// NewCardinalityHandler returns a new instance of handler.
+func NewCardinalityHandler(
+	clusters m3.Clusters,
+	tagOptions models.TagOptions,
+) http.Handler {
+	return &CardinalityHandler{
+		clusters:   clusters,
+		tagOptions: tagOptions,
+		nowFn:      time.Now,
+	}
+}
+
+func (h *CardinalityHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := logging.WithContext(r.Context())
+
+	namespaces, opts, rErr := h.parseURLParams(r)
+	if rErr != nil {
+		logger.Error("unable to parse request", zap.Any("error", rErr))
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	response := CardinalityResponse{
+		Namespaces: make([]CardinalityNamespace, 0, len(namespaces)),
+	}
+	for _, ns := range namespaces {
+		results, err := ns.Session().Cardinality(ns.NamespaceID(), opts)
+		if err != nil {
+			logger.Error("unable to fetch cardinality", zap.Any("error", err))
+			xhttp.Error(w, err, http.StatusInternalServerError)
+			return
+		}
+		response.Namespaces = append(response.Namespaces,
+			newCardinalityNamespace(ns.NamespaceID().String(), results))
+	}
+
+	xhttp.WriteJSONResponse(w, response, logger)
+}
+
+func (h *CardinalityHandler) parseURLParams(
+	r *http.Request,
+) (m3.ClusterNamespaces, index.CardinalityOptions, *xhttp.ParseError) {
+	var (
+		query = r.URL.Query()
+		now   = h.nowFn()
+		opts  = index.CardinalityOptions{
+			StartInclusive: now.Add(-defaultCardinalityRange),
+			EndExclusive:   now,
+			NameTag:        h.tagOptions.MetricName(),
+			Limit:          index.DefaultCardinalityLimit,
+			MaxTerms:       index.DefaultCardinalityMaxTerms,
+		}
+		err error
+	)
+
+	if v := query.Get(startParam); v != "" {
+		if opts.StartInclusive, err = util.ParseTimeString(v); err != nil {
+			return nil, opts, xhttp.NewParseError(err, http.StatusBadRequest)
+		}
+	}
+	if v := query.Get(endParam); v != "" {
+		if opts.EndExclusive, err = util.ParseTimeString(v); err != nil {
+			return nil, opts, xhttp.NewParseError(err, http.StatusBadRequest)
+		}
+	}
+	if v := query.Get(nameTagParam); v != "" {
+		opts.NameTag = []byte(v)
+	}
+	if v := query.Get(limitParam); v != "" {
+		if opts.Limit, err = strconv.Atoi(v); err != nil {
+			return nil, opts, xhttp.NewParseError(err, http.StatusBadRequest)
+		}
+	}
+	if v := query.Get(maxTermsParam); v != "" {
+		if opts.MaxTerms, err = strconv.Atoi(v); err != nil {
+			return nil, opts, xhttp.NewParseError(err, http.StatusBadRequest)
+		}
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, opts, xhttp.NewParseError(err, http.StatusBadRequest)
+	}
+
+	namespaces := h.clusters.ClusterNamespaces()
+	name := query.Get(namespaceParam)
+	if name == "" {
+		return namespaces, opts, nil
+	}
+	for _, ns := range namespaces {
+		if ns.NamespaceID().String() == name {
+			return m3.ClusterNamespaces{ns}, opts, nil
+		}
+	}
+	return nil, opts, xhttp.NewParseError(
+		fmt.Errorf("%v: %s", errCardinalityUnknownNamespace, name), http.StatusNotFound)
+}
+
+func newCardinalityNamespace(
+	namespace string,
+	results []index.CardinalityResult,
+) CardinalityNamespace {
+	blocks := make([]CardinalityBlock, 0, len(results))
+	for _, r := range results {
+		blocks = append(blocks, CardinalityBlock{
+			BlockStart:  r.BlockStart,
+			NumSeries:   r.NumSeries,
+			MetricNames: newCardinalityEntries(r.MetricNames),
+			TagNames:    newCardinalityEntries(r.TagNames),
+			TagValues:   newCardinalityEntries(r.TagValues),
+			Exhaustive:  r.Exhaustive,
+		})
+	}
+	return CardinalityNamespace{Namespace: namespace, Blocks: blocks}
+}
+
+func newCardinalityEntries(entries []index.CardinalityEntry) []CardinalityEntry {
+	result := make([]CardinalityEntry, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, CardinalityEntry{Name: string(e.Name), Count: e.Count})
+	}
+	return result
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage/m3"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3x/ident"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCardinalityHandler(
+	t *testing.T,
+	ctrl *gomock.Controller,
+	now time.Time,
+) (*CardinalityHandler, *client.MockSession) {
+	logging.InitWithCores(nil)
+
+	session := client.NewMockSession(ctrl)
+	clusters, err := m3.NewClusters(m3.UnaggregatedClusterNamespaceDefinition{
+		NamespaceID: ident.StringID("metrics"),
+		Session:     session,
+		Retention:   48 * time.Hour,
+	})
+	require.NoError(t, err)
+
+	h := NewCardinalityHandler(clusters, models.NewTagOptions()).(*CardinalityHandler)
+	h.nowFn = func() time.Time { return now }
+	return h, session
+}
+
+func TestCardinalityHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Unix(7200, 0)
+	h, session := newTestCardinalityHandler(t, ctrl, now)
+
+	session.EXPECT().
+		Cardinality(ident.NewIDMatcher("metrics"), index.CardinalityOptions{
+			StartInclusive: now.Add(-defaultCardinalityRange),
+			EndExclusive:   now,
+			NameTag:        []byte("name"),
+			Limit:          5,
+			MaxTerms:       index.DefaultCardinalityMaxTerms,
+		}).
+		Return([]index.CardinalityResult{{
+			BlockStart: time.Unix(0, 0).UTC(),
+			NumSeries:  3,
+			MetricNames: []index.CardinalityEntry{
+				{Name: []byte("cpu"), Count: 3},
+			},
+			Exhaustive: true,
+		}}, nil)
+
+	req := httptest.NewRequest(CardinalityHTTPMethod,
+		CardinalityURL+"?namespace=metrics&limit=5&nameTag=name", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp CardinalityResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, 1, len(resp.Namespaces))
+	require.Equal(t, "metrics", resp.Namespaces[0].Namespace)
+	require.Equal(t, 1, len(resp.Namespaces[0].Blocks))
+
+	block := resp.Namespaces[0].Blocks[0]
+	require.Equal(t, int64(3), block.NumSeries)
+	require.True(t, block.Exhaustive)
+	require.Equal(t, []CardinalityEntry{{Name: "cpu", Count: 3}}, block.MetricNames)
+}
+
+func TestCardinalityHandlerInvalidParams(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h, _ := newTestCardinalityHandler(t, ctrl, time.Now())
+	for _, params := range []string{
+		"?namespace=unknown",
+		"?limit=0",
+		"?limit=foo",
+		"?maxTerms=-1",
+		"?start=bar",
+	} {
+		req := httptest.NewRequest(CardinalityHTTPMethod, CardinalityURL+params, nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		require.NotEqual(t, http.StatusOK, w.Code, params)
+	}
+}
@@ -32,4 +32,8 @@ const (
 
 	// DeprecatedHeader is the M3 deprecated header
 	DeprecatedHeader = "M3-Deprecated"
+
+	// ExactTagsHeader is the M3 header to set when the equality matchers of a
+	// query are the complete set of tags of the series to fetch
+	ExactTagsHeader = "M3-Exact-Tags"
 )
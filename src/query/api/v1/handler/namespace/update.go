@@ -0,0 +1,156 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package namespace
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"path"
+
+	clusterclient "github.com/m3db/m3/src/cluster/client"
+	nsproto "github.com/m3db/m3/src/dbnode/generated/proto/namespace"
+	"github.com/m3db/m3/src/dbnode/storage/namespace"
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/generated/proto/admin"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/net/http"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"go.uber.org/zap"
+)
+
+var (
+	// DeprecatedM3DBUpdateURL is the old url for the namespace update handler,
+	// maintained for backwards compatibility.
+	DeprecatedM3DBUpdateURL = path.Join(handler.RoutePrefixV1, NamespacePathName)
+
+	// M3DBUpdateURL is the url for the M3DB namespace update handler.
+	M3DBUpdateURL = path.Join(handler.RoutePrefixV1, M3DBServiceNamespacePathName)
+
+	// UpdateHTTPMethod is the HTTP method used with this resource.
+	UpdateHTTPMethod = http.MethodPut
+)
+
+// UpdateHandler is the handler for namespace updates.
+type UpdateHandler Handler
+
+// NewUpdateHandler returns a new instance of UpdateHandler.
+func NewUpdateHandler(client clusterclient.Client) *UpdateHandler {
+	return &UpdateHandler{client: client}
+}
+
+func (h *UpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.WithContext(ctx)
+
+	md, rErr := h.parseRequest(r)
+	if rErr != nil {
+		logger.Error("unable to parse request", zap.Any("error", rErr))
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	nsRegistry, err := h.Update(md)
+	if err != nil {
+		logger.Error("unable to update namespace", zap.Any("error", err))
+		if err == errNamespaceNotFound {
+			xhttp.Error(w, err, http.StatusNotFound)
+		} else {
+			xhttp.Error(w, err, http.StatusBadRequest)
+		}
+		return
+	}
+
+	resp := &admin.NamespaceGetResponse{
+		Registry: &nsRegistry,
+	}
+
+	xhttp.WriteProtoMsgJSONResponse(w, resp, logger)
+}
+
+func (h *UpdateHandler) parseRequest(r *http.Request) (*admin.NamespaceUpdateRequest, *xhttp.ParseError) {
+	defer r.Body.Close()
+	rBody, err := xhttp.DurationToNanosBytes(r.Body)
+	if err != nil {
+		return nil, xhttp.NewParseError(err, http.StatusBadRequest)
+	}
+
+	updateReq := new(admin.NamespaceUpdateRequest)
+	if err := jsonpb.Unmarshal(bytes.NewReader(rBody), updateReq); err != nil {
+		return nil, xhttp.NewParseError(err, http.StatusBadRequest)
+	}
+
+	return updateReq, nil
+}
+
+// Update updates the options of an existing namespace. The retention period
+// of a namespace can always be updated, its block size can only be updated
+// if the request migrates the data of the namespace to the new block size.
+func (h *UpdateHandler) Update(updateReq *admin.NamespaceUpdateRequest) (nsproto.Registry, error) {
+	var emptyReg = nsproto.Registry{}
+
+	md, err := namespace.ToMetadata(updateReq.Name, updateReq.Options)
+	if err != nil {
+		return emptyReg, fmt.Errorf("unable to get metadata: %v", err)
+	}
+
+	store, err := h.client.KV()
+	if err != nil {
+		return emptyReg, err
+	}
+
+	currentMetadata, version, err := Metadata(store)
+	if err != nil {
+		return emptyReg, err
+	}
+
+	mdIdx := -1
+	for idx, existing := range currentMetadata {
+		if existing.ID().Equal(md.ID()) {
+			mdIdx = idx
+			break
+		}
+	}
+	if mdIdx == -1 {
+		return emptyReg, errNamespaceNotFound
+	}
+
+	err = namespace.ValidateUpdate(currentMetadata[mdIdx].Options(), md.Options(),
+		updateReq.MigrateData)
+	if err != nil {
+		return emptyReg, fmt.Errorf("invalid namespace update: %v", err)
+	}
+	currentMetadata[mdIdx] = md
+
+	nsMap, err := namespace.NewMap(currentMetadata)
+	if err != nil {
+		return emptyReg, err
+	}
+
+	protoRegistry := namespace.ToProto(nsMap)
+	_, err = store.CheckAndSet(M3DBNodeNamespacesKey, version, protoRegistry)
+	if err != nil {
+		return emptyReg, fmt.Errorf("failed to update namespace: %v", err)
+	}
+
+	return *protoRegistry, nil
+}
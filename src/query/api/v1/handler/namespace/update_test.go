@@ -0,0 +1,172 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package namespace
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/m3db/m3/src/cluster/kv"
+	nsproto "github.com/m3db/m3/src/dbnode/generated/proto/namespace"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testUpdateJSONFormat = `
+        {
+            "name": "testNamespace",
+            "options": {
+              "bootstrapEnabled": true,
+              "flushEnabled": true,
+              "writesToCommitLog": true,
+              "cleanupEnabled": true,
+              "repairEnabled": true,
+              "retentionOptions": {
+                "retentionPeriodNanos": %d,
+                "blockSizeNanos": %d,
+                "bufferFutureNanos": 600000000000,
+                "bufferPastNanos": 600000000000,
+                "blockDataExpiry": true,
+                "blockDataExpiryAfterNotAccessPeriodNanos": 300000000000
+              },
+              "snapshotEnabled": true,
+              "indexOptions": {
+                "enabled": true,
+                "blockSizeNanos": 7200000000000
+              }
+            },
+            "migrateData": %t
+        }
+    `
+
+func testUpdateRegistry() nsproto.Registry {
+	return nsproto.Registry{
+		Namespaces: map[string]*nsproto.NamespaceOptions{
+			"testNamespace": &nsproto.NamespaceOptions{
+				BootstrapEnabled:  true,
+				FlushEnabled:      true,
+				WritesToCommitLog: true,
+				CleanupEnabled:    true,
+				RepairEnabled:     true,
+				RetentionOptions: &nsproto.RetentionOptions{
+					RetentionPeriodNanos:                     172800000000000,
+					BlockSizeNanos:                           7200000000000,
+					BufferFutureNanos:                        600000000000,
+					BufferPastNanos:                          600000000000,
+					BlockDataExpiry:                          true,
+					BlockDataExpiryAfterNotAccessPeriodNanos: 300000000000,
+				},
+				SnapshotEnabled: true,
+				IndexOptions: &nsproto.IndexOptions{
+					Enabled:        true,
+					BlockSizeNanos: 7200000000000,
+				},
+			},
+		},
+	}
+}
+
+func TestNamespaceUpdateHandlerNotFound(t *testing.T) {
+	mockClient, mockKV, _ := SetupNamespaceTest(t)
+	updateHandler := NewUpdateHandler(mockClient)
+
+	w := httptest.NewRecorder()
+
+	jsonInput := fmt.Sprintf(testUpdateJSONFormat, 345600000000000, 7200000000000, false)
+	req := httptest.NewRequest("PUT", "/namespace", strings.NewReader(jsonInput))
+	require.NotNil(t, req)
+
+	mockKV.EXPECT().Get(M3DBNodeNamespacesKey).Return(nil, kv.ErrNotFound)
+	updateHandler.ServeHTTP(w, req)
+
+	resp := w.Result()
+	body, _ := ioutil.ReadAll(resp.Body)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, "{\"error\":\"unable to find a namespace with specified name\"}\n", string(body))
+}
+
+func TestNamespaceUpdateHandlerRetentionPeriod(t *testing.T) {
+	mockClient, mockKV, ctrl := SetupNamespaceTest(t)
+	updateHandler := NewUpdateHandler(mockClient)
+
+	w := httptest.NewRecorder()
+
+	jsonInput := fmt.Sprintf(testUpdateJSONFormat, 345600000000000, 7200000000000, false)
+	req := httptest.NewRequest("PUT", "/namespace", strings.NewReader(jsonInput))
+	require.NotNil(t, req)
+
+	mockValue := kv.NewMockValue(ctrl)
+	mockValue.EXPECT().Unmarshal(gomock.Any()).Return(nil).SetArg(0, testUpdateRegistry())
+	mockValue.EXPECT().Version().Return(0)
+
+	mockKV.EXPECT().Get(M3DBNodeNamespacesKey).Return(mockValue, nil)
+	mockKV.EXPECT().CheckAndSet(M3DBNodeNamespacesKey, 0, gomock.Not(nil)).Return(1, nil)
+	updateHandler.ServeHTTP(w, req)
+
+	resp := w.Result()
+	body, _ := ioutil.ReadAll(resp.Body)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "{\"registry\":{\"namespaces\":{\"testNamespace\":{\"bootstrapEnabled\":true,\"flushEnabled\":true,\"writesToCommitLog\":true,\"cleanupEnabled\":true,\"repairEnabled\":true,\"retentionOptions\":{\"retentionPeriodNanos\":\"345600000000000\",\"blockSizeNanos\":\"7200000000000\",\"bufferFutureNanos\":\"600000000000\",\"bufferPastNanos\":\"600000000000\",\"blockDataExpiry\":true,\"blockDataExpiryAfterNotAccessPeriodNanos\":\"300000000000\"},\"snapshotEnabled\":true,\"indexOptions\":{\"enabled\":true,\"blockSizeNanos\":\"7200000000000\"}}}}}", string(body))
+}
+
+func TestNamespaceUpdateHandlerBlockSize(t *testing.T) {
+	mockClient, mockKV, ctrl := SetupNamespaceTest(t)
+	updateHandler := NewUpdateHandler(mockClient)
+
+	// Changing the block size without migrating the data is rejected.
+	w := httptest.NewRecorder()
+
+	jsonInput := fmt.Sprintf(testUpdateJSONFormat, 172800000000000, 3600000000000, false)
+	req := httptest.NewRequest("PUT", "/namespace", strings.NewReader(jsonInput))
+	require.NotNil(t, req)
+
+	mockValue := kv.NewMockValue(ctrl)
+	mockValue.EXPECT().Unmarshal(gomock.Any()).Return(nil).SetArg(0, testUpdateRegistry()).Times(2)
+	mockValue.EXPECT().Version().Return(0).Times(2)
+
+	mockKV.EXPECT().Get(M3DBNodeNamespacesKey).Return(mockValue, nil)
+	updateHandler.ServeHTTP(w, req)
+
+	resp := w.Result()
+	body, _ := ioutil.ReadAll(resp.Body)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, "{\"error\":\"invalid namespace update: cannot change namespace block size from 2h0m0s to 1h0m0s without migrating its data\"}\n", string(body))
+
+	// Changing the block size while migrating the data is accepted.
+	w = httptest.NewRecorder()
+
+	jsonInput = fmt.Sprintf(testUpdateJSONFormat, 172800000000000, 3600000000000, true)
+	req = httptest.NewRequest("PUT", "/namespace", strings.NewReader(jsonInput))
+	require.NotNil(t, req)
+
+	mockKV.EXPECT().Get(M3DBNodeNamespacesKey).Return(mockValue, nil)
+	mockKV.EXPECT().CheckAndSet(M3DBNodeNamespacesKey, 0, gomock.Not(nil)).Return(1, nil)
+	updateHandler.ServeHTTP(w, req)
+
+	resp = w.Result()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/m3db/m3/src/query/ts"
 	"github.com/m3db/m3/src/query/util/logging"
 	"github.com/m3db/m3/src/x/net/http"
+	xerrors "github.com/m3db/m3x/errors"
 
 	"go.uber.org/zap"
 )
@@ -128,7 +129,12 @@ func (h *PromReadHandler) ServeHTTPWithEngine(w http.ResponseWriter, r *http.Req
 	result, err := read(ctx, engine, h.tagOpts, w, params)
 	if err != nil {
 		logger.Error("unable to fetch data", zap.Error(err))
-		return nil, emptyReqParams, &RespError{Err: err, Code: http.StatusInternalServerError}
+		code := http.StatusInternalServerError
+		if xerrors.IsInvalidParams(err) {
+			// The query was refused by storage, e.g. for exceeding a limit
+			code = http.StatusBadRequest
+		}
+		return nil, emptyReqParams, &RespError{Err: err, Code: code}
 	}
 
 	return result, params, nil
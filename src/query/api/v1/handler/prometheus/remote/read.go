@@ -24,6 +24,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/m3db/m3/src/query/api/v1/handler"
@@ -33,6 +34,7 @@ import (
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/util/logging"
 	"github.com/m3db/m3/src/x/net/http"
+	xerrors "github.com/m3db/m3x/errors"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/snappy"
@@ -94,7 +96,20 @@ func (h *PromReadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result, err := h.read(ctx, w, req, timeout)
+	opts, err := parseEngineOptions(r)
+	if err != nil {
+		h.promReadMetrics.fetchErrorsClient.Inc(1)
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.read(ctx, w, req, timeout, opts)
+	if err != nil && xerrors.IsInvalidParams(err) {
+		// The query was refused by storage, e.g. for exceeding a limit
+		h.promReadMetrics.fetchErrorsClient.Inc(1)
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		h.promReadMetrics.fetchErrorsServer.Inc(1)
 		logger.Error("unable to fetch data", zap.Any("error", err))
@@ -142,7 +157,25 @@ func (h *PromReadHandler) parseRequest(r *http.Request) (*prompb.ReadRequest, *x
 	return &req, nil
 }
 
-func (h *PromReadHandler) read(reqCtx context.Context, w http.ResponseWriter, r *prompb.ReadRequest, timeout time.Duration) ([]*prompb.QueryResult, error) {
+func parseEngineOptions(r *http.Request) (*executor.EngineOptions, error) {
+	opts := &executor.EngineOptions{}
+	if str := r.Header.Get(handler.ExactTagsHeader); str != "" {
+		exactTags, err := strconv.ParseBool(str)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s header: %v", handler.ExactTagsHeader, err)
+		}
+		opts.ExactTags = exactTags
+	}
+	return opts, nil
+}
+
+func (h *PromReadHandler) read(
+	reqCtx context.Context,
+	w http.ResponseWriter,
+	r *prompb.ReadRequest,
+	timeout time.Duration,
+	opts *executor.EngineOptions,
+) ([]*prompb.QueryResult, error) {
 	// TODO: Handle multi query use case
 	if len(r.Queries) != 1 {
 		return nil, fmt.Errorf("prometheus read endpoint currently only supports one query at a time")
@@ -159,7 +192,6 @@ func (h *PromReadHandler) read(reqCtx context.Context, w http.ResponseWriter, r
 	// Results is closed by execute
 	results := make(chan *storage.QueryResult)
 
-	opts := &executor.EngineOptions{}
 	// Detect clients closing connections
 	handler.CloseWatcher(ctx, cancel, w)
 	go h.engine.Execute(ctx, query, opts, results)
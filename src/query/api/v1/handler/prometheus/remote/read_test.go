@@ -30,6 +30,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/x/metrics"
+	"github.com/m3db/m3/src/query/api/v1/handler"
 	"github.com/m3db/m3/src/query/executor"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
@@ -37,6 +38,7 @@ import (
 	"github.com/m3db/m3/src/query/test/m3"
 	"github.com/m3db/m3/src/query/util/logging"
 	xclock "github.com/m3db/m3x/clock"
+	xerrors "github.com/m3db/m3x/errors"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -88,6 +90,22 @@ func TestPromReadParsingBad(t *testing.T) {
 	require.NotNil(t, err, "unable to parse request")
 }
 
+func TestPromReadParseEngineOptions(t *testing.T) {
+	req, _ := http.NewRequest("POST", PromReadURL, nil)
+	opts, err := parseEngineOptions(req)
+	require.NoError(t, err)
+	assert.False(t, opts.ExactTags)
+
+	req.Header.Set(handler.ExactTagsHeader, "true")
+	opts, err = parseEngineOptions(req)
+	require.NoError(t, err)
+	assert.True(t, opts.ExactTags)
+
+	req.Header.Set(handler.ExactTagsHeader, "bad")
+	_, err = parseEngineOptions(req)
+	require.Error(t, err)
+}
+
 func TestPromReadStorageWithFetchError(t *testing.T) {
 	logging.InitWithCores(nil)
 	ctrl := gomock.NewController(t)
@@ -98,10 +116,26 @@ func TestPromReadStorageWithFetchError(t *testing.T) {
 		Return(nil, nil)
 	promRead := readHandler(storage)
 	req := test.GeneratePromReadRequest()
-	_, err := promRead.read(context.TODO(), httptest.NewRecorder(), req, time.Hour)
+	_, err := promRead.read(context.TODO(), httptest.NewRecorder(), req, time.Hour,
+		&executor.EngineOptions{})
 	require.NotNil(t, err, "unable to read from storage")
 }
 
+func TestPromReadStorageWithInvalidParamsError(t *testing.T) {
+	logging.InitWithCores(nil)
+	ctrl := gomock.NewController(t)
+	storage, session := m3.NewStorageAndSession(t, ctrl)
+	session.EXPECT().FetchTagged(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, true, xerrors.NewInvalidParamsError(fmt.Errorf("query too expensive")))
+	session.EXPECT().IteratorPools().
+		Return(nil, nil)
+	promRead := readHandler(storage)
+	req, _ := http.NewRequest("POST", PromReadURL, test.GeneratePromReadBody(t))
+	recorder := httptest.NewRecorder()
+	promRead.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
 func TestQueryMatchMustBeEqual(t *testing.T) {
 	logging.InitWithCores(nil)
 
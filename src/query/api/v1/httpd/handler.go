@@ -178,6 +178,13 @@ func (h *Handler) RegisterRoutes() error {
 		logged(remote.NewPromSeriesMatchHandler(h.storage, h.tagOptions)).ServeHTTP,
 	).Methods(remote.PromSeriesMatchHTTPMethod)
 
+	// Cardinality inspection endpoint
+	if h.clusters != nil {
+		h.router.HandleFunc(handler.CardinalityURL,
+			logged(handler.NewCardinalityHandler(h.clusters, h.tagOptions)).ServeHTTP,
+		).Methods(handler.CardinalityHTTPMethod)
+	}
+
 	// Debug endpoints
 	h.router.HandleFunc(validator.PromDebugURL,
 		logged(validator.NewPromDebugHandler(nativePromReadHandler, h.scope)).ServeHTTP,
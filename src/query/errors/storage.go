@@ -69,4 +69,7 @@ var (
 
 	// ErrUnexpectedGRPCResponseType is an error returned when rpc response type is unhandled
 	ErrUnexpectedGRPCResponseType = errors.New("unexpected grpc response type")
+
+	// ErrQueryPostingsLimitExceeded is an error returned when a query is expected to scan more postings than allowed
+	ErrQueryPostingsLimitExceeded = errors.New("query postings limit exceeded")
 )
@@ -39,6 +39,9 @@ type Engine struct {
 
 // EngineOptions can be used to pass custom flags to engine
 type EngineOptions struct {
+	// ExactTags specifies that the equality matchers of queries are the
+	// complete set of tags of the series to fetch.
+	ExactTags bool
 }
 
 // Query is the result after execution
@@ -104,7 +107,11 @@ func newEngineMetrics(scope tally.Scope) *engineMetrics {
 // Execute runs the query and closes the results channel once done
 func (e *Engine) Execute(ctx context.Context, query *storage.FetchQuery, opts *EngineOptions, results chan *storage.QueryResult) {
 	defer close(results)
-	result, err := e.store.Fetch(ctx, query, &storage.FetchOptions{})
+	fetchOpts := &storage.FetchOptions{}
+	if opts != nil {
+		fetchOpts.ExactTags = opts.ExactTags
+	}
+	result, err := e.store.Fetch(ctx, query, fetchOpts)
 	if err != nil {
 		results <- &storage.QueryResult{Err: err}
 		return
@@ -554,6 +554,11 @@ func newStorages(
 		readWorkerPool,
 		writeWorkerPool,
 		tagOptions,
+		m3.StorageOptions{
+			ExactIDFetch:        cfg.ExactIDFetch,
+			FetchTaggedPageSize: cfg.FetchTaggedPageSize,
+			MaxQueryPostings:    cfg.Limits.MaxQueryPostings,
+		},
 	)
 	stores := []storage.Storage{localStorage}
 	remoteEnabled := false
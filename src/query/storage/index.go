@@ -21,6 +21,7 @@
 package storage
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/m3db/m3/src/dbnode/storage/index"
@@ -117,6 +118,13 @@ func FetchQueryToM3Query(fetchQuery *FetchQuery) (index.Query, error) {
 	return index.Query{Query: q}, nil
 }
 
+var (
+	// matchAllRegexp matches every value of a tag, including the tag being absent.
+	matchAllRegexp = []byte(".*")
+	// matchFieldRegexp matches every value of a tag, provided the tag is present.
+	matchFieldRegexp = []byte(".+")
+)
+
 func matcherToQuery(matcher models.Matcher) (idx.Query, error) {
 	negate := false
 	switch matcher.Type {
@@ -125,9 +133,23 @@ func matcherToQuery(matcher models.Matcher) (idx.Query, error) {
 		negate = true
 		fallthrough
 	case models.MatchRegexp:
-		query, err := idx.NewRegexpQuery(matcher.Name, matcher.Value)
-		if err != nil {
-			return idx.Query{}, err
+		var (
+			query idx.Query
+			err   error
+		)
+		switch {
+		case bytes.Equal(matcher.Value, matchAllRegexp):
+			// NB: avoid scanning every term of the field when all series match.
+			query = idx.NewAllQuery()
+		case bytes.Equal(matcher.Value, matchFieldRegexp):
+			// NB: avoid scanning every term of the field when only the
+			// presence of the field matters.
+			query = idx.NewFieldQuery(matcher.Name)
+		default:
+			query, err = idx.NewRegexpQuery(matcher.Name, matcher.Value)
+			if err != nil {
+				return idx.Query{}, err
+			}
 		}
 		if negate {
 			query = idx.NewNegationQuery(query)
@@ -139,7 +161,15 @@ func matcherToQuery(matcher models.Matcher) (idx.Query, error) {
 		negate = true
 		fallthrough
 	case models.MatchEqual:
-		query := idx.NewTermQuery(matcher.Name, matcher.Value)
+		var query idx.Query
+		if len(matcher.Value) == 0 {
+			// NB: an equality match against an empty value matches series which
+			// do not have the tag, i.e. the negation of a field query.
+			query = idx.NewFieldQuery(matcher.Name)
+			negate = !negate
+		} else {
+			query = idx.NewTermQuery(matcher.Name, matcher.Value)
+		}
 		if negate {
 			query = idx.NewNegationQuery(query)
 		}
@@ -119,6 +119,61 @@ func TestFetchQueryToM3Query(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:     "empty exact match",
+			expected: "negation(field(t1))",
+			matchers: models.Matchers{
+				{
+					Type:  models.MatchEqual,
+					Name:  []byte("t1"),
+					Value: []byte(""),
+				},
+			},
+		},
+		{
+			name:     "empty exact match negated",
+			expected: "field(t1)",
+			matchers: models.Matchers{
+				{
+					Type:  models.MatchNotEqual,
+					Name:  []byte("t1"),
+					Value: []byte(""),
+				},
+			},
+		},
+		{
+			name:     "regexp match all",
+			expected: "all()",
+			matchers: models.Matchers{
+				{
+					Type:  models.MatchRegexp,
+					Name:  []byte("t1"),
+					Value: []byte(".*"),
+				},
+			},
+		},
+		{
+			name:     "regexp match field",
+			expected: "field(t1)",
+			matchers: models.Matchers{
+				{
+					Type:  models.MatchRegexp,
+					Name:  []byte("t1"),
+					Value: []byte(".+"),
+				},
+			},
+		},
+		{
+			name:     "regexp match field negated",
+			expected: "negation(field(t1))",
+			matchers: models.Matchers{
+				{
+					Type:  models.MatchNotRegexp,
+					Name:  []byte("t1"),
+					Value: []byte(".+"),
+				},
+			},
+		},
 		{
 			name:     "regexp match negated",
 			expected: "negation(regexp(t1, v1))",
@@ -29,7 +29,9 @@ import (
 	"sync"
 	"time"
 
+	"github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/errors"
 	"github.com/m3db/m3/src/query/models"
@@ -37,6 +39,7 @@ import (
 	"github.com/m3db/m3/src/query/ts"
 	"github.com/m3db/m3/src/query/ts/m3db"
 	"github.com/m3db/m3/src/query/ts/m3db/consolidators"
+	xerrors "github.com/m3db/m3x/errors"
 	"github.com/m3db/m3x/ident"
 	xsync "github.com/m3db/m3x/sync"
 )
@@ -57,9 +60,29 @@ type m3storage struct {
 	readWorkerPool  xsync.PooledWorkerPool
 	writeWorkerPool xsync.PooledWorkerPool
 	opts            m3db.Options
+	exactIDFetch    bool
+	pageSize        int
+	maxPostings     int64
 	nowFn           func() time.Time
 }
 
+// StorageOptions configures how the local storage fetches series.
+type StorageOptions struct {
+	// ExactIDFetch fetches queries that fully specify a series with equality
+	// matchers and the exact tags fetch option by ID rather than querying the
+	// index.
+	ExactIDFetch bool
+
+	// FetchTaggedPageSize when positive fetches series from the index that
+	// many at a time per host.
+	FetchTaggedPageSize int
+
+	// MaxQueryPostings when positive refuses queries expected to scan more
+	// postings than that in the index of a namespace before fetching any
+	// series.
+	MaxQueryPostings int64
+}
+
 // NewStorage creates a new local m3storage instance.
 // TODO: consider taking in an iterator pools here.
 func NewStorage(
@@ -67,6 +90,7 @@ func NewStorage(
 	readWorkerPool xsync.PooledWorkerPool,
 	writeWorkerPool xsync.PooledWorkerPool,
 	tagOptions models.TagOptions,
+	storageOpts StorageOptions,
 ) Storage {
 	opts := m3db.NewOptions().
 		SetTagOptions(tagOptions).
@@ -78,6 +102,9 @@ func NewStorage(
 		readWorkerPool:  readWorkerPool,
 		writeWorkerPool: writeWorkerPool,
 		opts:            opts,
+		exactIDFetch:    storageOpts.ExactIDFetch,
+		pageSize:        storageOpts.FetchTaggedPageSize,
+		maxPostings:     storageOpts.MaxQueryPostings,
 		nowFn:           time.Now,
 	}
 }
@@ -172,9 +199,14 @@ func (s *m3storage) FetchCompressed(
 	}
 
 	var (
-		opts = storage.FetchOptionsToM3Options(options, query)
-		wg   sync.WaitGroup
+		opts           = storage.FetchOptionsToM3Options(options, query)
+		wg             sync.WaitGroup
+		exactTags      models.Tags
+		exactTagsFound bool
 	)
+	if s.exactIDFetch && options.ExactTags {
+		exactTags, exactTagsFound = exactSeriesTags(query, s.opts.TagOptions())
+	}
 	if len(namespaces) == 0 {
 		return nil, noop, errNoNamespacesConfigured
 	}
@@ -190,13 +222,35 @@ func (s *m3storage) FetchCompressed(
 
 		wg.Add(1)
 		go func() {
-			session := namespace.Session()
-			ns := namespace.NamespaceID()
-			iters, _, err := session.FetchTagged(ns, m3query, opts)
+			var (
+				session = namespace.Session()
+				ns      = namespace.NamespaceID()
+				attrs   = namespace.Options().Attributes()
+				iters   encoding.SeriesIterators
+				err     error
+			)
+			defer wg.Done()
+			if exactTagsFound {
+				iters, err = fetchExactSeries(session, ns, exactTags, query)
+			}
+			if iters == nil && err == nil {
+				err = s.checkQueryCost(session, ns, m3query, opts)
+			}
+			if iters == nil && err == nil && s.pageSize > 0 {
+				// Fetch the series a page at a time to bound the memory used
+				// by the hosts and the size of each response.
+				fetchTaggedPages(session, ns, m3query, opts, s.pageSize,
+					attrs, result)
+				return
+			}
+			if iters == nil && err == nil {
+				// Series is not fully specified or was not found by its ID,
+				// fallback to resolving the query with the index.
+				iters, _, err = session.FetchTagged(ns, m3query, opts)
+			}
 			// Ignore error from getting iterator pools, since operation
 			// will not be dramatically impacted if pools is nil
-			result.Add(namespace.Options().Attributes(), iters, err)
-			wg.Done()
+			result.Add(attrs, iters, err)
 		}()
 	}
 
@@ -218,6 +272,160 @@ func (s *m3storage) FetchCompressed(
 	return iters, result.Close, nil
 }
 
+// checkQueryCost refuses queries expected to scan more postings in the index
+// of the namespace than the max postings, the postings of the terms of the
+// query are summed across index blocks as an upper bound on the series
+// the hosts have to look at to resolve the query. The query is refused with
+// an invalid params error as it can only succeed once it is narrowed.
+func (s *m3storage) checkQueryCost(
+	session client.Session,
+	ns ident.ID,
+	query index.Query,
+	opts index.QueryOptions,
+) error {
+	if s.maxPostings <= 0 {
+		return nil
+	}
+
+	results, err := session.QueryCost(ns, query, index.QueryCostOptions{
+		StartInclusive: opts.StartInclusive,
+		EndExclusive:   opts.EndExclusive,
+	})
+	if err != nil {
+		return err
+	}
+
+	var postings int64
+	for _, r := range results {
+		postings += r.Postings()
+	}
+	if postings > s.maxPostings {
+		return xerrors.NewInvalidParamsError(fmt.Errorf(
+			"%v: query %s is expected to scan %d postings in namespace %s, "+
+				"narrow the query or increase the limit (`limits.maxQueryPostings`) of %d",
+			errors.ErrQueryPostingsLimitExceeded, query.String(), postings,
+			ns.String(), s.maxPostings))
+	}
+	return nil
+}
+
+// fetchTaggedPages resolves the query with the index and adds the series
+// fetched to the result a page at a time. NB: every page is held in the
+// result until the query completes, so the memory used by the coordinator for
+// a query is not bounded by the page size, only that of each fetch.
+func fetchTaggedPages(
+	session client.Session,
+	ns ident.ID,
+	query index.Query,
+	opts index.QueryOptions,
+	pageSize int,
+	attrs storage.Attributes,
+	result MultiFetchResult,
+) {
+	cursor, err := session.FetchTaggedPages(ns, query, opts, pageSize)
+	if err != nil {
+		result.Add(attrs, nil, err)
+		return
+	}
+	for cursor.Next() {
+		result.Add(attrs, cursor.Current(), nil)
+	}
+	if err := cursor.Err(); err != nil {
+		result.Add(attrs, nil, err)
+	}
+}
+
+// exactSeriesTags returns the tags of the single series selected by a query
+// made up only of equality matchers that include the metric name, which is
+// the case for most alerting queries. The matchers only select a single
+// series if the caller guarantees they are the complete set of its tags.
+func exactSeriesTags(
+	query *storage.FetchQuery,
+	tagOptions models.TagOptions,
+) (models.Tags, bool) {
+	var (
+		tags    = models.NewTags(len(query.TagMatchers), tagOptions)
+		hasName bool
+	)
+	for _, matcher := range query.TagMatchers {
+		// NB: An empty equality matcher matches series without the tag, which
+		// can't be represented by an ID.
+		if matcher.Type != models.MatchEqual || len(matcher.Value) == 0 {
+			return models.Tags{}, false
+		}
+		if _, exists := tags.Get(matcher.Name); exists {
+			return models.Tags{}, false
+		}
+		if bytes.Equal(matcher.Name, tagOptions.MetricName()) {
+			hasName = true
+		}
+		tags = tags.AddTag(models.Tag{Name: matcher.Name, Value: matcher.Value})
+	}
+	if !hasName {
+		return models.Tags{}, false
+	}
+	return tags, true
+}
+
+// fetchExactSeries fetches the series with the given tags by its ID, the same
+// ID that writes are made with, returning nil iterators if the series has no
+// datapoints in the query range.
+func fetchExactSeries(
+	session client.Session,
+	namespace ident.ID,
+	tags models.Tags,
+	query *storage.FetchQuery,
+) (encoding.SeriesIterators, error) {
+	id := ident.BytesID(tags.IDMarshalTo(make([]byte, 0, tags.IDLen())))
+	iter, err := session.Fetch(namespace, id, query.Start, query.End)
+	if err != nil {
+		return nil, err
+	}
+
+	// NB: A series that does not exist is returned with replicas that have no
+	// datapoints, so the first datapoint is read to tell if the series exists.
+	if !iter.Next() {
+		err := iter.Err()
+		iter.Close()
+		return nil, err
+	}
+
+	iter = &exactSeriesIterator{
+		SeriesIterator: iter,
+		tags:           storage.TagsToIdentTagIterator(tags),
+		peeked:         true,
+	}
+	return encoding.NewSeriesIterators([]encoding.SeriesIterator{iter}, nil), nil
+}
+
+// exactSeriesIterator is a series iterator for a series fetched by ID, which
+// returns the tags the ID was made from since fetching by ID does not.
+type exactSeriesIterator struct {
+	encoding.SeriesIterator
+	tags ident.TagIterator
+
+	// peeked is set while the current datapoint of the underlying iterator
+	// was read to check the series exists and is yet to be returned.
+	peeked bool
+}
+
+func (it *exactSeriesIterator) Next() bool {
+	if it.peeked {
+		it.peeked = false
+		return true
+	}
+	return it.SeriesIterator.Next()
+}
+
+func (it *exactSeriesIterator) Tags() ident.TagIterator {
+	return it.tags
+}
+
+func (it *exactSeriesIterator) Close() {
+	it.SeriesIterator.Close()
+	it.tags.Close()
+}
+
 func (s *m3storage) FetchTags(
 	ctx context.Context,
 	query *storage.FetchQuery,
@@ -23,6 +23,7 @@ package m3
 import (
 	"context"
 	"fmt"
+	"io"
 	"math"
 	"strings"
 	"testing"
@@ -30,10 +31,13 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/test/seriesiter"
 	"github.com/m3db/m3/src/query/ts"
+	xerrors "github.com/m3db/m3x/errors"
 	"github.com/m3db/m3x/ident"
 	"github.com/m3db/m3x/sync"
 	xtest "github.com/m3db/m3x/test"
@@ -122,7 +126,7 @@ func newTestStorage(t *testing.T, clusters Clusters) storage.Storage {
 	require.NoError(t, err)
 	writePool.Init()
 	opts := models.NewTagOptions().SetMetricName([]byte("name"))
-	storage := NewStorage(clusters, nil, writePool, opts)
+	storage := NewStorage(clusters, nil, writePool, opts, StorageOptions{})
 	return storage
 }
 
@@ -271,6 +275,236 @@ func TestLocalRead(t *testing.T) {
 	assert.Equal(t, []byte("name"), results.SeriesList[0].Tags.Opts.MetricName())
 }
 
+func newExactIDFetchReq() *storage.FetchQuery {
+	req := newFetchReq()
+	req.TagMatchers = models.Matchers{
+		{
+			Type:  models.MatchEqual,
+			Name:  []byte("name"),
+			Value: []byte("up"),
+		},
+		{
+			Type:  models.MatchEqual,
+			Name:  []byte("biz"),
+			Value: []byte("baz"),
+		},
+	}
+	return req
+}
+
+func TestExactSeriesTags(t *testing.T) {
+	tagOpts := models.NewTagOptions().SetMetricName([]byte("name"))
+
+	tags, ok := exactSeriesTags(newExactIDFetchReq(), tagOpts)
+	require.True(t, ok)
+	assert.Equal(t, "biz=baz,name=up,", tags.ID())
+
+	// Missing metric name.
+	_, ok = exactSeriesTags(newFetchReq(), tagOpts)
+	assert.False(t, ok)
+
+	// Non equality matcher.
+	req := newExactIDFetchReq()
+	req.TagMatchers[1].Type = models.MatchRegexp
+	_, ok = exactSeriesTags(req, tagOpts)
+	assert.False(t, ok)
+
+	// Empty equality matcher.
+	req = newExactIDFetchReq()
+	req.TagMatchers[1].Value = nil
+	_, ok = exactSeriesTags(req, tagOpts)
+	assert.False(t, ok)
+}
+
+func TestLocalReadExactID(t *testing.T) {
+	ctrl := gomock.NewController(xtest.Reporter{T: t})
+	defer ctrl.Finish()
+	store, sessions := setup(t, ctrl)
+	store.(*m3storage).exactIDFetch = true
+
+	iter := seriesiter.NewMockSeriesIterSlice(ctrl, func() ident.TagIterator {
+		return ident.EmptyTagIterator
+	}, 1, 2)[0].(*encoding.MockSeriesIterator)
+
+	searchReq := newExactIDFetchReq()
+	session := sessions.unaggregated1MonthRetention
+	session.EXPECT().Fetch(ident.NewIDMatcher("metrics_unaggregated"),
+		ident.NewIDMatcher("biz=baz,name=up,"), searchReq.Start, searchReq.End).
+		Return(iter, nil)
+	session.EXPECT().IteratorPools().
+		Return(newTestIteratorPools(ctrl), nil).AnyTimes()
+
+	results, err := store.Fetch(context.TODO(), searchReq,
+		&storage.FetchOptions{Limit: 100, ExactTags: true})
+	require.NoError(t, err)
+	require.NotNil(t, results)
+	require.Len(t, results.SeriesList, 1)
+	assert.Equal(t, []models.Tag{
+		{Name: []byte("biz"), Value: []byte("baz")},
+		{Name: []byte("name"), Value: []byte("up")},
+	}, results.SeriesList[0].Tags.Tags)
+}
+
+func TestFetchExactSeriesReturnsFirstDatapoint(t *testing.T) {
+	ctrl := gomock.NewController(xtest.Reporter{T: t})
+	defer ctrl.Finish()
+
+	req := newExactIDFetchReq()
+	tags, ok := exactSeriesTags(req, models.NewTagOptions().SetMetricName([]byte("name")))
+	require.True(t, ok)
+
+	iter := encoding.NewMockSeriesIterator(ctrl)
+	gomock.InOrder(
+		iter.EXPECT().Next().Return(true).Times(2),
+		iter.EXPECT().Next().Return(false),
+	)
+	iter.EXPECT().Close()
+
+	session := client.NewMockSession(ctrl)
+	session.EXPECT().Fetch(gomock.Any(), ident.NewIDMatcher("biz=baz,name=up,"),
+		req.Start, req.End).Return(iter, nil)
+
+	iters, err := fetchExactSeries(session, ident.StringID("ns"), tags, req)
+	require.NoError(t, err)
+	require.Equal(t, 1, iters.Len())
+
+	// The datapoint read to check the series exists is still returned.
+	numDatapoints := 0
+	for iters.Iters()[0].Next() {
+		numDatapoints++
+	}
+	require.Equal(t, 2, numDatapoints)
+	iters.Close()
+}
+
+func TestLocalReadExactIDNotFoundFallsBackToIndex(t *testing.T) {
+	ctrl := gomock.NewController(xtest.Reporter{T: t})
+	defer ctrl.Finish()
+	store, sessions := setup(t, ctrl)
+	store.(*m3storage).exactIDFetch = true
+	testTags := seriesiter.GenerateTag()
+
+	// The session returns a series with replicas that have no datapoints
+	// for a series that does not exist.
+	searchReq := newExactIDFetchReq()
+	iterAlloc := func(r io.Reader) encoding.ReaderIterator {
+		return m3tsz.NewReaderIterator(r, m3tsz.DefaultIntOptimizationEnabled,
+			encoding.NewOptions())
+	}
+	iter := encoding.NewSeriesIterator(encoding.SeriesIteratorOptions{
+		ID:             ident.StringID("biz=baz,name=up,"),
+		Namespace:      ident.StringID("metrics_unaggregated"),
+		Replicas:       []encoding.MultiReaderIterator{encoding.NewMultiReaderIterator(iterAlloc, nil)},
+		StartInclusive: searchReq.Start,
+		EndExclusive:   searchReq.End,
+	}, nil)
+
+	session := sessions.unaggregated1MonthRetention
+	session.EXPECT().Fetch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(iter, nil)
+	session.EXPECT().FetchTagged(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(seriesiter.NewMockSeriesIters(ctrl, testTags, 1, 2), true, nil)
+	session.EXPECT().IteratorPools().
+		Return(newTestIteratorPools(ctrl), nil).AnyTimes()
+
+	results, err := store.Fetch(context.TODO(), searchReq,
+		&storage.FetchOptions{Limit: 100, ExactTags: true})
+	require.NoError(t, err)
+	assertFetchResult(t, results, testTags)
+}
+
+func TestLocalReadExactIDRequiresExactTags(t *testing.T) {
+	ctrl := gomock.NewController(xtest.Reporter{T: t})
+	defer ctrl.Finish()
+	store, sessions := setup(t, ctrl)
+	store.(*m3storage).exactIDFetch = true
+	testTags := seriesiter.GenerateTag()
+
+	// Without the exact tags option the matchers may select series with
+	// further tags so the query is resolved with the index.
+	session := sessions.unaggregated1MonthRetention
+	session.EXPECT().FetchTagged(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(seriesiter.NewMockSeriesIters(ctrl, testTags, 1, 2), true, nil)
+	session.EXPECT().IteratorPools().
+		Return(newTestIteratorPools(ctrl), nil).AnyTimes()
+
+	results, err := store.Fetch(context.TODO(), newExactIDFetchReq(),
+		&storage.FetchOptions{Limit: 100})
+	require.NoError(t, err)
+	assertFetchResult(t, results, testTags)
+}
+
+func TestLocalReadPaginated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store, sessions := setup(t, ctrl)
+	store.(*m3storage).pageSize = 10
+	testTags := seriesiter.GenerateTag()
+
+	cursor := client.NewMockSeriesIteratorsCursor(ctrl)
+	gomock.InOrder(
+		cursor.EXPECT().Next().Return(true),
+		cursor.EXPECT().Current().
+			Return(seriesiter.NewMockSeriesIters(ctrl, testTags, 1, 2)),
+		cursor.EXPECT().Next().Return(false),
+		cursor.EXPECT().Err().Return(nil),
+	)
+
+	session := sessions.unaggregated1MonthRetention
+	session.EXPECT().FetchTaggedPages(gomock.Any(), gomock.Any(), gomock.Any(), 10).
+		Return(cursor, nil)
+	session.EXPECT().IteratorPools().Return(nil, nil).AnyTimes()
+
+	results, err := store.Fetch(context.TODO(), newFetchReq(),
+		&storage.FetchOptions{Limit: 100})
+	require.NoError(t, err)
+	assertFetchResult(t, results, testTags)
+}
+
+func TestLocalReadQueryCostWithinLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store, sessions := setup(t, ctrl)
+	store.(*m3storage).maxPostings = 10
+	testTags := seriesiter.GenerateTag()
+
+	session := sessions.unaggregated1MonthRetention
+	session.EXPECT().QueryCost(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return([]index.QueryCostResult{
+			{Terms: []index.QueryTermCost{{Postings: 4}, {Postings: 2}}},
+			{Terms: []index.QueryTermCost{{Postings: 3}, {Postings: 1}}},
+		}, nil)
+	session.EXPECT().FetchTagged(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(seriesiter.NewMockSeriesIters(ctrl, testTags, 1, 2), true, nil)
+	session.EXPECT().IteratorPools().
+		Return(newTestIteratorPools(ctrl), nil).AnyTimes()
+
+	results, err := store.Fetch(context.TODO(), newFetchReq(),
+		&storage.FetchOptions{Limit: 100})
+	require.NoError(t, err)
+	assertFetchResult(t, results, testTags)
+}
+
+func TestLocalReadQueryCostExceedsLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store, sessions := setup(t, ctrl)
+	store.(*m3storage).maxPostings = 10
+
+	session := sessions.unaggregated1MonthRetention
+	session.EXPECT().QueryCost(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return([]index.QueryCostResult{
+			{Terms: []index.QueryTermCost{{Postings: 6}, {Postings: 5}}},
+		}, nil)
+	session.EXPECT().IteratorPools().Return(nil, nil).AnyTimes()
+
+	_, err := store.Fetch(context.TODO(), newFetchReq(),
+		&storage.FetchOptions{Limit: 100})
+	require.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "maxQueryPostings"))
+	assert.True(t, xerrors.IsInvalidParams(err))
+}
+
 func TestLocalReadExceedsRetention(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
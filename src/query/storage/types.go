@@ -83,6 +83,10 @@ type FetchOptions struct {
 	// Limit is the maximum number of series to return.
 	Limit     int
 	BlockType models.FetchedBlockType
+	// ExactTags specifies that the equality matchers of the query are the
+	// complete set of tags of the series to fetch, rather than a subset of
+	// them, which allows the series to be fetched by ID.
+	ExactTags bool
 }
 
 // NewFetchOptions creates a new fetch options.
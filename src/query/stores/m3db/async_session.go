@@ -114,6 +114,18 @@ func (s *AsyncSession) WriteTagged(namespace, id ident.ID, tags ident.TagIterato
 	return s.session.WriteTagged(namespace, id, tags, t, value, unit, annotation)
 }
 
+// WriteTaggedAsync enqueues a write of a value to the database for an ID and
+// given tags and calls onComplete with the result of the write
+func (s *AsyncSession) WriteTaggedAsync(namespace, id ident.ID, tags ident.TagIterator, t time.Time, value float64, unit xtime.Unit, annotation []byte, onComplete client.WriteCompletionFn) error {
+	s.RLock()
+	defer s.RUnlock()
+	if s.err != nil {
+		return s.err
+	}
+
+	return s.session.WriteTaggedAsync(namespace, id, tags, t, value, unit, annotation, onComplete)
+}
+
 // Fetch fetches values from the database for an ID
 func (s *AsyncSession) Fetch(namespace, id ident.ID, startInclusive, endExclusive time.Time) (encoding.SeriesIterator, error) {
 	s.RLock()
@@ -158,6 +170,42 @@ func (s *AsyncSession) FetchTaggedIDs(namespace ident.ID, q index.Query, opts in
 	return s.session.FetchTaggedIDs(namespace, q, opts)
 }
 
+// FetchTaggedPages resolves the provided query to known IDs, and returns a
+// cursor that fetches the data for them a page at a time.
+func (s *AsyncSession) FetchTaggedPages(namespace ident.ID, q index.Query, opts index.QueryOptions, pageSize int) (client.SeriesIteratorsCursor, error) {
+	s.RLock()
+	defer s.RUnlock()
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return s.session.FetchTaggedPages(namespace, q, opts, pageSize)
+}
+
+// Cardinality returns the cardinality of the series of the namespace for
+// each index block within the time range, merged across all hosts.
+func (s *AsyncSession) Cardinality(namespace ident.ID, opts index.CardinalityOptions) ([]index.CardinalityResult, error) {
+	s.RLock()
+	defer s.RUnlock()
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return s.session.Cardinality(namespace, opts)
+}
+
+// QueryCost returns an estimate of the cost of the query for each index
+// block within the time range, merged across all hosts.
+func (s *AsyncSession) QueryCost(namespace ident.ID, q index.Query, opts index.QueryCostOptions) ([]index.QueryCostResult, error) {
+	s.RLock()
+	defer s.RUnlock()
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return s.session.QueryCost(namespace, q, opts)
+}
+
 // ShardID returns the given shard for an ID for callers
 // to easily discern what shard is failing when operations
 // for given IDs begin failing
@@ -106,6 +106,12 @@ func TestAsyncSessionUninitialized(t *testing.T) {
 	_, _, err = asyncSession.FetchTaggedIDs(namespace, index.Query{}, index.QueryOptions{})
 	assert.Equal(t, err, errSessionUninitialized)
 
+	_, err = asyncSession.Cardinality(namespace, index.CardinalityOptions{})
+	assert.Equal(t, err, errSessionUninitialized)
+
+	_, err = asyncSession.QueryCost(namespace, index.Query{}, index.QueryCostOptions{})
+	assert.Equal(t, err, errSessionUninitialized)
+
 	id, err := asyncSession.ShardID(nil)
 	assert.Equal(t, uint32(0), id)
 	assert.Equal(t, err, errSessionUninitialized)
@@ -154,6 +160,14 @@ func TestAsyncSessionInitialized(t *testing.T) {
 	_, _, err = asyncSession.FetchTaggedIDs(namespace, index.Query{}, index.QueryOptions{})
 	assert.NoError(t, err)
 
+	mockSession.EXPECT().Cardinality(gomock.Any(), gomock.Any()).Return(nil, nil)
+	_, err = asyncSession.Cardinality(namespace, index.CardinalityOptions{})
+	assert.NoError(t, err)
+
+	mockSession.EXPECT().QueryCost(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	_, err = asyncSession.QueryCost(namespace, index.Query{}, index.QueryCostOptions{})
+	assert.NoError(t, err)
+
 	mockSession.EXPECT().ShardID(gomock.Any()).Return(uint32(0), nil)
 	_, err = asyncSession.ShardID(nil)
 	assert.NoError(t, err)